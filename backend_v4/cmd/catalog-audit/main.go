@@ -0,0 +1,203 @@
+// Command catalog-audit reports how much of the reference catalog lacks key
+// data: entries without translations, without examples, without
+// pronunciations, or whose every definition is a stub. Counts are grouped by
+// frequency band so enrichment and seeding effort can go where learners see
+// the gaps first. Optionally the worst offenders are written to a CSV file.
+//
+// Flags:
+//
+//	--bands     comma-separated inclusive upper ranks of the frequency bands
+//	            (default: 1000,5000,20000)
+//	--stub-len  definitions shorter than this many characters count as stubs
+//	            (default: 15)
+//	--csv       write the worst offenders to this CSV file
+//	--top       number of worst offenders in the CSV (default: 1000)
+//
+// Exit codes: 0 = success, 1 = error.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/refentry"
+	"github.com/heartmarshall/myenglish-backend/internal/app"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// gapStore runs the catalog audit queries.
+type gapStore interface {
+	CatalogGaps(ctx context.Context, bandLimits []int, stubLen int) ([]domain.CatalogGapCounts, error)
+	WorstCatalogGaps(ctx context.Context, stubLen, limit int) ([]domain.CatalogGapEntry, error)
+}
+
+func main() {
+	bandsFlag := flag.String("bands", "1000,5000,20000", "comma-separated inclusive upper ranks of the frequency bands")
+	stubLen := flag.Int("stub-len", 15, "definitions shorter than this many characters count as stubs")
+	csvPath := flag.String("csv", "", "write the worst offenders to this CSV file")
+	top := flag.Int("top", 1000, "number of worst offenders in the CSV")
+	flag.Parse()
+
+	bands, err := parseBands(*bandsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "--bands: %v\n", err)
+		os.Exit(1)
+	}
+	if *stubLen < 1 || *top < 1 {
+		fmt.Fprintln(os.Stderr, "--stub-len and --top must be positive")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	logger := app.NewLogger(cfg.Log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, cfg.Database)
+	if err != nil {
+		logger.Error("connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	repo := refentry.New(pool, postgres.NewTxManager(pool))
+
+	if err := report(ctx, os.Stdout, repo, bands, *stubLen); err != nil {
+		logger.Error("audit catalog", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	if *csvPath == "" {
+		return
+	}
+	f, err := os.Create(*csvPath)
+	if err != nil {
+		logger.Error("create csv", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	n, err := writeWorst(ctx, f, repo, *stubLen, *top)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		logger.Error("write worst offenders", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	logger.Info("worst offenders written", slog.String("path", *csvPath), slog.Int("entries", n))
+}
+
+// parseBands parses a comma-separated list of strictly ascending positive
+// ranks. An empty string yields no limits, i.e. a single ranked band.
+func parseBands(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var limits []int
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank %q", part)
+		}
+		if n < 1 || (len(limits) > 0 && n <= limits[len(limits)-1]) {
+			return nil, fmt.Errorf("ranks must be positive and ascending, got %q", s)
+		}
+		limits = append(limits, n)
+	}
+	return limits, nil
+}
+
+// report writes one line of gap counts per frequency band and a total line.
+func report(ctx context.Context, w io.Writer, store gapStore, bandLimits []int, stubLen int) error {
+	bands, err := store.CatalogGaps(ctx, bandLimits, stubLen)
+	if err != nil {
+		return fmt.Errorf("count gaps: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "band\tentries\tno_translations\tno_examples\tno_pronunciations\tstub_definitions\t")
+	var total domain.CatalogGapCounts
+	for _, b := range bands {
+		writeCounts(tw, bandLabel(b), b)
+		total.Entries += b.Entries
+		total.NoTranslations += b.NoTranslations
+		total.NoExamples += b.NoExamples
+		total.NoPronunciations += b.NoPronunciations
+		total.StubDefinitions += b.StubDefinitions
+	}
+	writeCounts(tw, "total", total)
+	return tw.Flush()
+}
+
+func writeCounts(w io.Writer, label string, c domain.CatalogGapCounts) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t\n",
+		label, c.Entries, c.NoTranslations, c.NoExamples, c.NoPronunciations, c.StubDefinitions)
+}
+
+func bandLabel(b domain.CatalogGapCounts) string {
+	switch {
+	case !b.Ranked:
+		return "unranked"
+	case b.MaxRank == 0:
+		return fmt.Sprintf("%d+", b.MinRank)
+	default:
+		return fmt.Sprintf("%d-%d", b.MinRank, b.MaxRank)
+	}
+}
+
+// writeWorst writes up to limit entries with the most gaps as CSV and
+// returns how many were written.
+func writeWorst(ctx context.Context, w io.Writer, store gapStore, stubLen, limit int) (int, error) {
+	entries, err := store.WorstCatalogGaps(ctx, stubLen, limit)
+	if err != nil {
+		return 0, fmt.Errorf("list worst offenders: %w", err)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(worstHeader); err != nil {
+		return 0, fmt.Errorf("write csv header: %w", err)
+	}
+	for _, e := range entries {
+		if err := cw.Write(worstRecord(e)); err != nil {
+			return 0, fmt.Errorf("write csv row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return 0, fmt.Errorf("flush csv: %w", err)
+	}
+	return len(entries), nil
+}
+
+var worstHeader = []string{
+	"ref_entry_id", "text", "frequency_rank", "gaps",
+	"no_translations", "no_examples", "no_pronunciations", "stub_definitions",
+}
+
+func worstRecord(e domain.CatalogGapEntry) []string {
+	var rank string
+	if e.FrequencyRank != nil {
+		rank = strconv.Itoa(*e.FrequencyRank)
+	}
+	return []string{
+		e.RefEntryID.String(), e.Text, rank, strconv.Itoa(e.Gaps()),
+		strconv.FormatBool(e.NoTranslations), strconv.FormatBool(e.NoExamples),
+		strconv.FormatBool(e.NoPronunciations), strconv.FormatBool(e.StubDefinitions),
+	}
+}
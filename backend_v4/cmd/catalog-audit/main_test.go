@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// fakeGapStore returns canned audit results and records the arguments.
+type fakeGapStore struct {
+	bands      []domain.CatalogGapCounts
+	worst      []domain.CatalogGapEntry
+	err        error
+	gotLimits  []int
+	gotStubLen int
+	gotLimit   int
+}
+
+func (f *fakeGapStore) CatalogGaps(_ context.Context, bandLimits []int, stubLen int) ([]domain.CatalogGapCounts, error) {
+	f.gotLimits, f.gotStubLen = bandLimits, stubLen
+	return f.bands, f.err
+}
+
+func (f *fakeGapStore) WorstCatalogGaps(_ context.Context, stubLen, limit int) ([]domain.CatalogGapEntry, error) {
+	f.gotStubLen, f.gotLimit = stubLen, limit
+	return f.worst, f.err
+}
+
+func TestParseBands(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{in: "1000,5000,20000", want: []int{1000, 5000, 20000}},
+		{in: " 10 , 20 ", want: []int{10, 20}},
+		{in: "", want: nil},
+		{in: "5000,1000", wantErr: true},
+		{in: "10,10", wantErr: true},
+		{in: "0,10", wantErr: true},
+		{in: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseBands(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBands(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseBands(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	store := &fakeGapStore{bands: []domain.CatalogGapCounts{
+		{Ranked: true, MinRank: 1, MaxRank: 1000, Entries: 1000, NoTranslations: 3, NoPronunciations: 40},
+		{Ranked: true, MinRank: 1001, Entries: 500, NoExamples: 120, StubDefinitions: 7},
+		{Entries: 20, NoTranslations: 20, NoExamples: 20, NoPronunciations: 20, StubDefinitions: 2},
+	}}
+
+	var buf bytes.Buffer
+	if err := report(context.Background(), &buf, store, []int{1000}, 15); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	if !slices.Equal(store.gotLimits, []int{1000}) || store.gotStubLen != 15 {
+		t.Errorf("CatalogGaps called with %v, %d", store.gotLimits, store.gotStubLen)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want header + 3 bands + total:\n%s", len(lines), buf.String())
+	}
+	for i, want := range [][]string{
+		{"band", "entries", "no_translations", "no_examples", "no_pronunciations", "stub_definitions"},
+		{"1-1000", "1000", "3", "0", "40", "0"},
+		{"1001+", "500", "0", "120", "0", "7"},
+		{"unranked", "20", "20", "20", "20", "2"},
+		{"total", "1520", "23", "140", "60", "9"},
+	} {
+		if got := strings.Fields(lines[i]); !slices.Equal(got, want) {
+			t.Errorf("line %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestReport_StoreError(t *testing.T) {
+	store := &fakeGapStore{err: errors.New("connection reset")}
+	if err := report(context.Background(), &bytes.Buffer{}, store, nil, 15); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWriteWorst(t *testing.T) {
+	rank := 42
+	first, second := uuid.New(), uuid.New()
+	store := &fakeGapStore{worst: []domain.CatalogGapEntry{
+		{RefEntryID: first, Text: "set, go", FrequencyRank: &rank,
+			NoTranslations: true, NoExamples: true, NoPronunciations: true},
+		{RefEntryID: second, Text: "quux", StubDefinitions: true},
+	}}
+
+	var buf bytes.Buffer
+	n, err := writeWorst(context.Background(), &buf, store, 15, 2)
+	if err != nil {
+		t.Fatalf("writeWorst: %v", err)
+	}
+	if n != 2 || store.gotLimit != 2 {
+		t.Errorf("wrote %d rows with limit %d, want 2 and 2", n, store.gotLimit)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	want := [][]string{
+		worstHeader,
+		{first.String(), "set, go", "42", "3", "true", "true", "true", "false"},
+		{second.String(), "quux", "", "1", "false", "false", "false", "true"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		if !slices.Equal(records[i], want[i]) {
+			t.Errorf("record %d = %v, want %v", i, records[i], want[i])
+		}
+	}
+}
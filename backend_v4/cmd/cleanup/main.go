@@ -1,12 +1,18 @@
-// Command cleanup physically removes soft-deleted entries and/or old audit
-// log records older than their configured retention periods.
+// Command cleanup physically removes soft-deleted entries, old audit log
+// records, old review logs and/or abandoned study sessions older than their
+// configured retention periods.
 // It is intended to be invoked by an external cron job, not as an in-process
 // goroutine.
 //
 // Flags:
 //
-//	--entries  cleanup soft-deleted entries (default: true)
-//	--audit    cleanup audit_log entries   (default: false)
+//	--entries      cleanup soft-deleted entries (default: true)
+//	--audit        cleanup audit_log entries   (default: false)
+//	--review-logs  cleanup review_logs         (default: false)
+//	--sessions     cleanup abandoned sessions  (default: false)
+//	--dry-run      only count rows each selected category would delete
+//
+// Every selected category runs even if an earlier one fails.
 //
 // Exit codes: 0 = success, 1 = error.
 package main
@@ -22,13 +28,27 @@ import (
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/audit"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/entry"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/reviewlog"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/session"
 	"github.com/heartmarshall/myenglish-backend/internal/app"
 	"github.com/heartmarshall/myenglish-backend/internal/config"
 )
 
+// category is one kind of row cleanup removes, with its retention period.
+type category struct {
+	name          string
+	enabled       bool
+	retentionDays int
+	deleteOld     func(ctx context.Context, threshold time.Time) (int64, error)
+	countOld      func(ctx context.Context, threshold time.Time) (int64, error)
+}
+
 func main() {
 	entriesFlag := flag.Bool("entries", true, "cleanup soft-deleted entries older than retention period")
 	auditFlag := flag.Bool("audit", false, "cleanup audit_log entries older than retention period")
+	reviewLogsFlag := flag.Bool("review-logs", false, "cleanup review_logs older than retention period")
+	sessionsFlag := flag.Bool("sessions", false, "cleanup abandoned study sessions older than retention period")
+	dryRunFlag := flag.Bool("dry-run", false, "count rows that would be deleted without deleting them")
 	flag.Parse()
 
 	cfg, err := config.Load()
@@ -48,41 +68,74 @@ func main() {
 	}
 	defer pool.Close()
 
-	if *entriesFlag {
-		entryRepo := entry.New(pool)
-		threshold := time.Now().AddDate(0, 0, -cfg.Dictionary.HardDeleteRetentionDays)
+	entryRepo := entry.New(pool)
+	auditRepo := audit.New(pool)
+	reviewLogRepo := reviewlog.New(pool)
+	sessionRepo := session.New(pool)
 
-		deleted, err := entryRepo.HardDeleteOld(ctx, threshold)
-		if err != nil {
-			logger.Error("hard delete failed",
-				slog.String("error", err.Error()),
-				slog.Time("threshold", threshold),
-			)
-			os.Exit(1)
-		}
+	categories := []category{
+		{"entries", *entriesFlag, cfg.Dictionary.HardDeleteRetentionDays, entryRepo.HardDeleteOld, entryRepo.CountOlderThan},
+		{"audit", *auditFlag, cfg.Dictionary.AuditRetentionDays, auditRepo.DeleteOlderThan, auditRepo.CountOlderThan},
+		{"review_logs", *reviewLogsFlag, cfg.Dictionary.ReviewLogRetentionDays, reviewLogRepo.DeleteOlderThan, reviewLogRepo.CountOlderThan},
+		{"sessions", *sessionsFlag, cfg.Dictionary.SessionRetentionDays, sessionRepo.DeleteOlderThan, sessionRepo.CountOlderThan},
+	}
 
-		logger.Info("hard delete completed",
-			slog.Int64("deleted", deleted),
-			slog.Time("threshold", threshold),
-		)
+	if *dryRunFlag {
+		logger.Info("dry-run mode: nothing will be deleted")
 	}
 
-	if *auditFlag {
-		auditRepo := audit.New(pool)
-		threshold := time.Now().AddDate(0, 0, -cfg.Dictionary.AuditRetentionDays)
+	if failed := run(ctx, logger, categories, *dryRunFlag, time.Now()); failed {
+		os.Exit(1)
+	}
+}
+
+// run processes every enabled category and reports whether any failed.
+// In dry-run mode it only counts the rows each category would delete.
+func run(ctx context.Context, logger *slog.Logger, categories []category, dryRun bool, now time.Time) (failed bool) {
+	for _, c := range categories {
+		if !c.enabled {
+			continue
+		}
+
+		threshold := now.AddDate(0, 0, -c.retentionDays)
 
-		deleted, err := auditRepo.DeleteOlderThan(ctx, threshold)
+		if dryRun {
+			n, err := c.countOld(ctx, threshold)
+			if err != nil {
+				logger.Error("dry-run count failed",
+					slog.String("category", c.name),
+					slog.String("error", err.Error()),
+					slog.Time("threshold", threshold),
+				)
+				failed = true
+				continue
+			}
+
+			logger.Info("dry-run: would delete",
+				slog.String("category", c.name),
+				slog.Int64("count", n),
+				slog.Time("threshold", threshold),
+			)
+			continue
+		}
+
+		deleted, err := c.deleteOld(ctx, threshold)
 		if err != nil {
-			logger.Error("audit cleanup failed",
+			logger.Error("cleanup failed",
+				slog.String("category", c.name),
 				slog.String("error", err.Error()),
 				slog.Time("threshold", threshold),
 			)
-			os.Exit(1)
+			failed = true
+			continue
 		}
 
-		logger.Info("audit cleanup completed",
+		logger.Info("cleanup completed",
+			slog.String("category", c.name),
 			slog.Int64("deleted", deleted),
 			slog.Time("threshold", threshold),
 		)
 	}
+
+	return failed
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTable holds row timestamps; rows strictly older than the threshold match.
+type fakeTable struct {
+	rows    []time.Time
+	deletes int
+}
+
+func (f *fakeTable) deleteOld(_ context.Context, threshold time.Time) (int64, error) {
+	f.deletes++
+	var kept []time.Time
+	var n int64
+	for _, t := range f.rows {
+		if t.Before(threshold) {
+			n++
+		} else {
+			kept = append(kept, t)
+		}
+	}
+	f.rows = kept
+	return n, nil
+}
+
+func (f *fakeTable) countOld(_ context.Context, threshold time.Time) (int64, error) {
+	var n int64
+	for _, t := range f.rows {
+		if t.Before(threshold) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeTable) category(name string, retentionDays int) category {
+	return category{name: name, enabled: true, retentionDays: retentionDays, deleteOld: f.deleteOld, countOld: f.countOld}
+}
+
+var testNow = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestRun_DryRunCountsWithoutDeleting(t *testing.T) {
+	entries := &fakeTable{rows: []time.Time{testNow.AddDate(0, 0, -40), testNow.AddDate(0, 0, -31), testNow.AddDate(0, 0, -5)}}
+	audit := &fakeTable{rows: []time.Time{testNow.AddDate(-2, 0, 0)}}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	failed := run(context.Background(), logger, []category{
+		entries.category("entries", 30),
+		audit.category("audit", 365),
+	}, true, testNow)
+
+	if failed {
+		t.Fatal("run reported failure")
+	}
+	if entries.deletes != 0 || audit.deletes != 0 {
+		t.Errorf("dry run deleted: entries=%d audit=%d calls, want 0", entries.deletes, audit.deletes)
+	}
+	if len(entries.rows) != 3 || len(audit.rows) != 1 {
+		t.Errorf("rows changed in dry run: entries=%d audit=%d", len(entries.rows), len(audit.rows))
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="dry-run: would delete" category=entries count=2`,
+		`msg="dry-run: would delete" category=audit count=1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRun_DeletesAndReportsFailures(t *testing.T) {
+	entries := &fakeTable{rows: []time.Time{testNow.AddDate(0, 0, -40), testNow}}
+	broken := category{
+		name:          "audit",
+		enabled:       true,
+		retentionDays: 365,
+		deleteOld: func(context.Context, time.Time) (int64, error) {
+			return 0, errors.New("db down")
+		},
+	}
+	skipped := &fakeTable{rows: []time.Time{testNow.AddDate(-5, 0, 0)}}
+	disabled := skipped.category("sessions", 90)
+	disabled.enabled = false
+
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+	failed := run(context.Background(), logger, []category{broken, entries.category("entries", 30), disabled}, false, testNow)
+
+	if !failed {
+		t.Error("run should report failure when a category errors")
+	}
+	if len(entries.rows) != 1 {
+		t.Errorf("entries left = %d, want 1 (later categories still run)", len(entries.rows))
+	}
+	if skipped.deletes != 0 {
+		t.Error("disabled category should not run")
+	}
+}
@@ -3,11 +3,28 @@
 // Wiktionary/WordNet/CMU data for each word into enrich-output/<word>.json files,
 // plus batch prompt files for manual LLM processing.
 //
+// With --output-format=jsonl-batch (or ENRICH_OUTPUT_FORMAT) the batch prompt
+// files are replaced by a single enrich-output/batch_requests.jsonl holding one
+// ready-to-send Message Batches API request per word, keyed by custom_id. The
+// system prompt and user prompt template come from the enrich config
+// (system_prompt, prompt_template).
+//
 // Source modes (ENRICH_SOURCE or config source):
 //
 //	file  (default) — reads words from WordListPath
 //	queue — claims words from enrichment_queue via DB
 //
+// In queue mode --order (or ENRICH_CLAIM_ORDER) picks the claim order:
+// priority (default, highest priority first) or fifo (oldest first).
+//
+// In queue mode --reclaim-stale=<duration> first returns items that have been
+// in processing for longer than the duration (e.g. left by a crashed run) to
+// pending, so they can be claimed again.
+//
+// In queue mode --enqueue-missing=<n> first queues up to n ref entries that
+// have not been enriched by the LLM and lack examples or translations, so the
+// run targets gaps in the catalog without a hand-made word list.
+//
 // Exit codes: 0 = success, 1 = error.
 package main
 
@@ -16,6 +33,7 @@ import (
 	"flag"
 	"log/slog"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +47,10 @@ import (
 
 func main() {
 	enrichConfigPath := flag.String("enrich-config", "", "path to enrich YAML config")
+	outputFormat := flag.String("output-format", "", "batch output: files|jsonl-batch (default: from config)")
+	orderFlag := flag.String("order", "", "queue claim order: priority|fifo (default: from config)")
+	reclaimStale := flag.Duration("reclaim-stale", 0, "queue mode: reclaim items processing for longer than this before claiming (0 = off)")
+	enqueueMissing := flag.Int("enqueue-missing", 0, "queue mode: queue up to this many under-enriched ref entries before claiming (0 = off)")
 	flag.Parse()
 
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
@@ -38,10 +60,20 @@ func main() {
 		logger.Error("load enrich config", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	if *orderFlag != "" {
+		cfg.ClaimOrder = *orderFlag
+	}
+	if *outputFormat != "" {
+		cfg.OutputFormat = *outputFormat
+	}
 
 	if cfg.Source == "queue" {
-		runQueueMode(cfg, logger)
+		runQueueMode(cfg, *reclaimStale, *enqueueMissing, logger)
 	} else {
+		if *enqueueMissing > 0 {
+			logger.Error("--enqueue-missing requires queue source")
+			os.Exit(1)
+		}
 		if _, err := enricher.Run(context.Background(), cfg, logger); err != nil {
 			logger.Error("enrichment failed", slog.String("error", err.Error()))
 			os.Exit(1)
@@ -49,7 +81,7 @@ func main() {
 	}
 }
 
-func runQueueMode(cfg *enricher.Config, logger *slog.Logger) {
+func runQueueMode(cfg *enricher.Config, reclaimStale time.Duration, enqueueMissing int, logger *slog.Logger) {
 	appCfg, err := config.Load()
 	if err != nil {
 		logger.Error("load app config", slog.String("error", err.Error()))
@@ -67,10 +99,24 @@ func runQueueMode(cfg *enricher.Config, logger *slog.Logger) {
 	defer pool.Close()
 
 	queueRepo := enrichmentrepo.New(pool)
-	queueSvc := enrichmentsvc.NewService(logger, queueRepo)
+	queueSvc := enrichmentsvc.NewService(logger, queueRepo, appCfg.Enrichment)
+
+	if enqueueMissing > 0 {
+		if _, err := queueSvc.EnqueueMissing(ctx, enqueueMissing); err != nil {
+			logger.Error("enqueue missing entries", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	}
+
+	if reclaimStale > 0 {
+		if _, err := queueSvc.ReclaimStale(ctx, reclaimStale); err != nil {
+			logger.Error("reclaim stale items", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	}
 
 	// Claim batch from queue.
-	items, err := queueSvc.ClaimBatch(ctx, cfg.BatchSize)
+	items, err := queueSvc.ClaimBatch(ctx, cfg.BatchSize, domain.EnrichmentClaimOrder(cfg.ClaimOrder))
 	if err != nil {
 		logger.Error("claim batch", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -121,13 +167,91 @@ func runQueueMode(cfg *enricher.Config, logger *slog.Logger) {
 		os.Exit(1)
 	}
 
+	// Mark words that failed individually, then release claimed items the run
+	// did not get to (deadline reached, or no ref entry text) so they are not
+	// left stuck in 'processing'. Use a fresh timeout: ctx is nearly expired
+	// when the pipeline stops early.
+	releaseCtx, releaseCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer releaseCancel()
+	failed := markFailedWords(releaseCtx, queueSvc, items, textByID, result.Failed, logger)
+	handled := slices.Clone(result.Completed)
+	for _, f := range result.Failed {
+		handled = append(handled, f.Word)
+	}
+	released := releaseUnfinished(releaseCtx, queueSvc, items, textByID, handled, logger)
+
 	logger.Info("queue enrichment complete",
 		slog.Int("total", result.TotalWords),
 		slog.Int("written", result.Written),
 		slog.Int("skipped", result.Skipped),
+		slog.Int("failed", failed),
+		slog.Int("released", released),
+		slog.Bool("stopped", result.Stopped),
 	)
-	// Items remain in 'processing' state until LLM output is imported
-	// via llm-import, which marks them done/failed.
+	// Completed items remain in 'processing' state until LLM output is
+	// imported via llm-import, which marks them done/failed.
+}
+
+// releaser returns claimed queue items to pending.
+type releaser interface {
+	Release(ctx context.Context, refEntryIDs []uuid.UUID) (int, error)
+}
+
+// releaseUnfinished releases every claimed item whose word is not in
+// handled and returns how many items were released.
+func releaseUnfinished(ctx context.Context, svc releaser, items []domain.EnrichmentQueueItem, textByID map[uuid.UUID]string, handled []string, logger *slog.Logger) int {
+	done := make(map[string]bool, len(handled))
+	for _, w := range handled {
+		done[w] = true
+	}
+
+	var unfinished []uuid.UUID
+	for _, item := range items {
+		if text, ok := textByID[item.RefEntryID]; !ok || !done[text] {
+			unfinished = append(unfinished, item.RefEntryID)
+		}
+	}
+	if len(unfinished) == 0 {
+		return 0
+	}
+
+	n, err := svc.Release(ctx, unfinished)
+	if err != nil {
+		logger.Error("release unfinished items", slog.Int("count", len(unfinished)), slog.String("error", err.Error()))
+		return 0
+	}
+	return n
+}
+
+// failureMarker records a failed attempt for a claimed queue item.
+type failureMarker interface {
+	MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error
+}
+
+// markFailedWords marks the claimed items of the words the pipeline failed on
+// and returns how many were marked.
+func markFailedWords(ctx context.Context, svc failureMarker, items []domain.EnrichmentQueueItem, textByID map[uuid.UUID]string, failed []enricher.WordError, logger *slog.Logger) int {
+	if len(failed) == 0 {
+		return 0
+	}
+	reasons := make(map[string]string, len(failed))
+	for _, f := range failed {
+		reasons[f.Word] = f.Reason
+	}
+
+	n := 0
+	for _, item := range items {
+		reason, ok := reasons[textByID[item.RefEntryID]]
+		if !ok {
+			continue
+		}
+		if err := svc.MarkFailed(ctx, item.RefEntryID, reason); err != nil {
+			logger.Error("mark failed", slog.String("ref_entry_id", item.RefEntryID.String()), slog.String("error", err.Error()))
+			continue
+		}
+		n++
+	}
+	return n
 }
 
 func markAllFailed(ctx context.Context, svc *enrichmentsvc.Service, items []domain.EnrichmentQueueItem, errMsg string, logger *slog.Logger) {
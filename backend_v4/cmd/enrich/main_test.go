@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/app/enricher"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+type fakeReleaser struct {
+	released []uuid.UUID
+	err      error
+}
+
+func (f *fakeReleaser) Release(_ context.Context, ids []uuid.UUID) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.released = append(f.released, ids...)
+	return len(ids), nil
+}
+
+func claimed(texts ...string) ([]domain.EnrichmentQueueItem, map[uuid.UUID]string) {
+	items := make([]domain.EnrichmentQueueItem, len(texts))
+	textByID := make(map[uuid.UUID]string, len(texts))
+	for i, text := range texts {
+		id := uuid.New()
+		items[i] = domain.EnrichmentQueueItem{RefEntryID: id}
+		textByID[id] = text
+	}
+	return items, textByID
+}
+
+func TestReleaseUnfinished_NearDeadlineReleasesRemaining(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	items, textByID := claimed("run", "walk", "jump")
+
+	// A context already inside the pipeline's safety margin stops before the
+	// first word, so every claimed item must go back to pending.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	cfg := &enricher.Config{EnrichOutputDir: t.TempDir()}
+	result, err := enricher.RunWithWords(ctx, cfg, []string{"run", "walk", "jump"}, logger)
+	if err != nil {
+		t.Fatalf("RunWithWords: %v", err)
+	}
+	if !result.Stopped || len(result.Completed) != 0 {
+		t.Fatalf("result = %+v, want stopped with nothing completed", result)
+	}
+
+	rel := &fakeReleaser{}
+	n := releaseUnfinished(context.Background(), rel, items, textByID, result.Completed, logger)
+	if n != 3 || len(rel.released) != 3 {
+		t.Errorf("released %d (%v), want all 3", n, rel.released)
+	}
+}
+
+func TestReleaseUnfinished_KeepsCompleted(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	items, textByID := claimed("run", "walk", "jump")
+	missing := domain.EnrichmentQueueItem{RefEntryID: uuid.New()} // no ref entry text
+	items = append(items, missing)
+
+	rel := &fakeReleaser{}
+	n := releaseUnfinished(context.Background(), rel, items, textByID, []string{"run"}, logger)
+
+	if n != 3 {
+		t.Fatalf("released %d, want 3", n)
+	}
+	want := map[uuid.UUID]bool{items[1].RefEntryID: true, items[2].RefEntryID: true, missing.RefEntryID: true}
+	for _, id := range rel.released {
+		if !want[id] {
+			t.Errorf("released unexpected item %s", id)
+		}
+	}
+}
+
+func TestReleaseUnfinished_AllCompletedSkipsRelease(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	items, textByID := claimed("run")
+
+	rel := &fakeReleaser{err: errors.New("should not be called")}
+	if n := releaseUnfinished(context.Background(), rel, items, textByID, []string{"run"}, logger); n != 0 {
+		t.Errorf("released %d, want 0", n)
+	}
+}
+
+type fakeFailureMarker struct {
+	failed map[uuid.UUID]string
+}
+
+func (f *fakeFailureMarker) MarkFailed(_ context.Context, id uuid.UUID, errMsg string) error {
+	f.failed[id] = errMsg
+	return nil
+}
+
+func TestMarkFailedWords_OnlyFailedWords(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	items, textByID := claimed("run", "walk", "jump")
+
+	fm := &fakeFailureMarker{failed: make(map[uuid.UUID]string)}
+	failed := []enricher.WordError{{Word: "walk", Reason: "write context file: disk full"}}
+	if n := markFailedWords(context.Background(), fm, items, textByID, failed, logger); n != 1 {
+		t.Fatalf("marked %d, want 1", n)
+	}
+	if got := fm.failed[items[1].RefEntryID]; got != "write context file: disk full" {
+		t.Errorf("walk reason = %q", got)
+	}
+	if len(fm.failed) != 1 {
+		t.Errorf("marked %v, want only walk", fm.failed)
+	}
+
+	// Failed words count as handled, so only unprocessed items are released.
+	rel := &fakeReleaser{}
+	if n := releaseUnfinished(context.Background(), rel, items, textByID, []string{"run", "walk"}, logger); n != 1 || rel.released[0] != items[2].RefEntryID {
+		t.Errorf("released %v, want only jump", rel.released)
+	}
+}
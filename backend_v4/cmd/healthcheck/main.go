@@ -0,0 +1,223 @@
+// Command healthcheck verifies that the database is ready for the server:
+// all required tables exist, migrations are at the expected version and the
+// reference catalog is seeded. It prints a short summary and is meant to run
+// before starting the server or as a deployment gate.
+//
+// Flags:
+//
+//	--migrations        migrations directory used to derive the expected
+//	                    version (default: migrations)
+//	--expected-version  expected goose version; overrides --migrations
+//	--min-entries       minimum number of ref_entries (default: 1)
+//
+// Exit codes: 0 = healthy, 1 = a check failed or the DB is unreachable.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+)
+
+// requiredTables are the tables the server and seeder rely on.
+var requiredTables = []string{
+	"goose_db_version",
+	"users", "user_settings", "refresh_tokens", "auth_methods",
+	"ref_entries", "ref_senses", "ref_translations", "ref_examples",
+	"ref_pronunciations", "ref_images", "ref_data_sources",
+	"ref_entry_source_coverage", "ref_word_relations",
+	"entries", "senses", "translations", "examples",
+	"entry_pronunciations", "entry_images", "user_images",
+	"cards", "review_logs", "study_sessions",
+	"topics", "entry_topics", "inbox_items", "audit_log",
+	"enrichment_queue", "llm_import_log",
+}
+
+// catalogDB is the subset of database access the checks need.
+type catalogDB interface {
+	ExistingTables(ctx context.Context, names []string) (map[string]bool, error)
+	MigrationVersion(ctx context.Context) (int64, error)
+	CountRows(ctx context.Context, table string) (int64, error)
+}
+
+func main() {
+	migrationsDir := flag.String("migrations", "migrations", "migrations directory used to derive the expected version")
+	expectedVersion := flag.Int64("expected-version", 0, "expected migration version (0 = highest version in --migrations)")
+	minEntries := flag.Int64("min-entries", 1, "minimum number of ref_entries for the catalog to count as seeded")
+	flag.Parse()
+
+	want := *expectedVersion
+	if want == 0 {
+		v, err := latestMigrationVersion(*migrationsDir)
+		if err != nil {
+			log.Fatalf("expected migration version: %v", err)
+		}
+		want = v
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "database   FAIL (%v)\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	if ok := run(ctx, os.Stdout, pgCatalogDB{pool: pool}, want, *minEntries); !ok {
+		os.Exit(1)
+	}
+}
+
+// run performs every check, writes one summary line per check to w and
+// reports whether all of them passed. Later checks still run after a failure
+// so the summary is complete.
+func run(ctx context.Context, w io.Writer, db catalogDB, expectedVersion, minEntries int64) bool {
+	ok := true
+	fail := func(format string, args ...any) {
+		ok = false
+		fmt.Fprintf(w, format+"\n", args...)
+	}
+
+	existing, err := db.ExistingTables(ctx, requiredTables)
+	if err != nil {
+		fail("tables      FAIL (%v)", err)
+		existing = map[string]bool{}
+	} else {
+		var missing []string
+		for _, t := range requiredTables {
+			if !existing[t] {
+				missing = append(missing, t)
+			}
+		}
+		if len(missing) > 0 {
+			fail("tables      FAIL (missing %d: %s)", len(missing), strings.Join(missing, ", "))
+		} else {
+			fmt.Fprintf(w, "tables      ok (%d)\n", len(requiredTables))
+		}
+	}
+
+	if existing["goose_db_version"] {
+		switch v, err := db.MigrationVersion(ctx); {
+		case err != nil:
+			fail("migrations  FAIL (%v)", err)
+		case v != expectedVersion:
+			fail("migrations  FAIL (version %d, want %d)", v, expectedVersion)
+		default:
+			fmt.Fprintf(w, "migrations  ok (version %d)\n", v)
+		}
+	} else {
+		fail("migrations  FAIL (goose_db_version missing)")
+	}
+
+	for _, table := range []string{"ref_entries", "ref_senses"} {
+		if !existing[table] {
+			fail("%-11s FAIL (table missing)", table)
+			continue
+		}
+		n, err := db.CountRows(ctx, table)
+		switch {
+		case err != nil:
+			fail("%-11s FAIL (%v)", table, err)
+		case table == "ref_entries" && n < minEntries:
+			fail("%-11s FAIL (%d rows, want at least %d)", table, n, minEntries)
+		default:
+			fmt.Fprintf(w, "%-11s %d\n", table, n)
+		}
+	}
+
+	if ok {
+		fmt.Fprintln(w, "status      OK")
+	} else {
+		fmt.Fprintln(w, "status      FAIL")
+	}
+	return ok
+}
+
+// latestMigrationVersion returns the highest numeric prefix among the .sql
+// files in dir (goose names them NNNNN_name.sql).
+func latestMigrationVersion(dir string) (int64, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return 0, err
+	}
+	var latest int64
+	for _, f := range files {
+		prefix, _, found := strings.Cut(filepath.Base(f), "_")
+		if !found {
+			continue
+		}
+		v, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, v)
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", dir)
+	}
+	return latest, nil
+}
+
+// pgCatalogDB implements catalogDB with a pgx pool.
+type pgCatalogDB struct {
+	pool *pgxpool.Pool
+}
+
+func (d pgCatalogDB) ExistingTables(ctx context.Context, names []string) (map[string]bool, error) {
+	rows, err := d.pool.Query(ctx,
+		`SELECT table_name FROM information_schema.tables
+		 WHERE table_schema = current_schema() AND table_name = ANY($1)`, names)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	existing, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	set := make(map[string]bool, len(existing))
+	for _, t := range existing {
+		set[t] = true
+	}
+	return set, nil
+}
+
+// MigrationVersion returns the highest applied goose version. Goose deletes
+// the version row on rollback, so this is the current version.
+func (d pgCatalogDB) MigrationVersion(ctx context.Context) (int64, error) {
+	var v int64
+	err := d.pool.QueryRow(ctx,
+		`SELECT COALESCE(max(version_id), 0) FROM goose_db_version WHERE is_applied`).Scan(&v)
+	if err != nil {
+		return 0, fmt.Errorf("migration version: %w", err)
+	}
+	return v, nil
+}
+
+// CountRows counts rows in one of the fixed catalog tables.
+func (d pgCatalogDB) CountRows(ctx context.Context, table string) (int64, error) {
+	var n int64
+	if err := d.pool.QueryRow(ctx, "SELECT count(*) FROM "+pgx.Identifier{table}.Sanitize()).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count %s: %w", table, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeCatalogDB is an in-memory catalogDB; tables maps table name to row count.
+type fakeCatalogDB struct {
+	tables     map[string]int64
+	version    int64
+	versionErr error
+}
+
+func (f *fakeCatalogDB) ExistingTables(_ context.Context, names []string) (map[string]bool, error) {
+	set := make(map[string]bool)
+	for _, n := range names {
+		if _, ok := f.tables[n]; ok {
+			set[n] = true
+		}
+	}
+	return set, nil
+}
+
+func (f *fakeCatalogDB) MigrationVersion(_ context.Context) (int64, error) {
+	return f.version, f.versionErr
+}
+
+func (f *fakeCatalogDB) CountRows(_ context.Context, table string) (int64, error) {
+	return f.tables[table], nil
+}
+
+func completeDB() *fakeCatalogDB {
+	db := &fakeCatalogDB{tables: make(map[string]int64), version: 27}
+	for _, t := range requiredTables {
+		db.tables[t] = 0
+	}
+	db.tables["ref_entries"] = 20000
+	db.tables["ref_senses"] = 54000
+	return db
+}
+
+func TestRun_SchemaComplete(t *testing.T) {
+	var out strings.Builder
+	if ok := run(context.Background(), &out, completeDB(), 27, 1); !ok {
+		t.Fatalf("expected healthy, got:\n%s", out.String())
+	}
+
+	want := "tables      ok (30)\n" +
+		"migrations  ok (version 27)\n" +
+		"ref_entries 20000\n" +
+		"ref_senses  54000\n" +
+		"status      OK\n"
+	if out.String() != want {
+		t.Errorf("summary:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRun_SchemaMissing(t *testing.T) {
+	db := completeDB()
+	delete(db.tables, "ref_senses")
+	delete(db.tables, "cards")
+
+	var out strings.Builder
+	if ok := run(context.Background(), &out, db, 27, 1); ok {
+		t.Fatal("expected failure with missing tables")
+	}
+	got := out.String()
+	for _, want := range []string{
+		"tables      FAIL (missing 2: ref_senses, cards)",
+		"ref_senses  FAIL (table missing)",
+		"ref_entries 20000",
+		"status      FAIL",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRun_EmptySchema(t *testing.T) {
+	var out strings.Builder
+	if ok := run(context.Background(), &out, &fakeCatalogDB{}, 27, 1); ok {
+		t.Fatal("expected failure on an empty database")
+	}
+	if !strings.Contains(out.String(), "migrations  FAIL (goose_db_version missing)") {
+		t.Errorf("summary:\n%s", out.String())
+	}
+}
+
+func TestRun_MigrationsBehind(t *testing.T) {
+	db := completeDB()
+	db.version = 25
+
+	var out strings.Builder
+	if ok := run(context.Background(), &out, db, 27, 1); ok {
+		t.Fatal("expected failure when migrations are behind")
+	}
+	if !strings.Contains(out.String(), "migrations  FAIL (version 25, want 27)") {
+		t.Errorf("summary:\n%s", out.String())
+	}
+}
+
+func TestRun_MigrationVersionError(t *testing.T) {
+	db := completeDB()
+	db.versionErr = errors.New("permission denied")
+
+	var out strings.Builder
+	if ok := run(context.Background(), &out, db, 27, 1); ok {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(out.String(), "migrations  FAIL (permission denied)") {
+		t.Errorf("summary:\n%s", out.String())
+	}
+}
+
+func TestRun_CatalogNotSeeded(t *testing.T) {
+	db := completeDB()
+	db.tables["ref_entries"] = 0
+
+	var out strings.Builder
+	if ok := run(context.Background(), &out, db, 27, 1); ok {
+		t.Fatal("expected failure with an empty catalog")
+	}
+	if !strings.Contains(out.String(), "ref_entries FAIL (0 rows, want at least 1)") {
+		t.Errorf("summary:\n%s", out.String())
+	}
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"00001_enums.sql", "00027_claimed_at.sql", "00003_users.sql", "README.md", "notes_x.sql"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	v, err := latestMigrationVersion(dir)
+	if err != nil || v != 27 {
+		t.Errorf("latestMigrationVersion = %d, %v; want 27", v, err)
+	}
+
+	if _, err := latestMigrationVersion(t.TempDir()); err == nil {
+		t.Error("expected error for a directory without migrations")
+	}
+}
+
+func TestLatestMigrationVersion_RepoMigrations(t *testing.T) {
+	// The expected version must track the repo's own migrations directory.
+	if _, err := latestMigrationVersion(filepath.Join("..", "..", "migrations")); err != nil {
+		t.Fatalf("repo migrations: %v", err)
+	}
+}
@@ -1,10 +1,13 @@
 // Command llm-import ingests LLM-generated word entries into the reference catalog.
 // It reads *.json files from a configured output directory, validates and maps them
-// to domain types, then bulk-inserts them into PostgreSQL.
+// to domain types, then bulk-inserts them into PostgreSQL. Files that are not
+// valid JSON or fail validation are moved to <output-dir>/rejected/ together
+// with a <name>.error.json sidecar listing the problems.
 //
 // Flags:
 //
 //	--import-config  path to llm-import config YAML (optional; falls back to env)
+//	--force          reprocess files already recorded in llm_import_log
 //
 // Exit codes: 0 = success, 1 = error.
 package main
@@ -18,6 +21,7 @@ import (
 	"time"
 
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/llmimport"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/refentry"
 	"github.com/heartmarshall/myenglish-backend/internal/app"
 	"github.com/heartmarshall/myenglish-backend/internal/config"
@@ -30,6 +34,7 @@ var _ seeder.RefEntryBulkRepo = (*refentry.Repo)(nil)
 
 func main() {
 	importConfigPath := flag.String("import-config", "", "path to llm-import config YAML")
+	forceFlag := flag.Bool("force", false, "reprocess files already recorded in llm_import_log")
 	flag.Parse()
 
 	// Load app config (for DB connection and logging).
@@ -46,6 +51,9 @@ func main() {
 		logger.Error("load import config", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	if *forceFlag {
+		importCfg.Force = true
+	}
 
 	// 30-minute context timeout.
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
@@ -66,7 +74,9 @@ func main() {
 		logger.Info("dry-run mode: no DB writes")
 	}
 
-	if _, err := llm_importer.Run(ctx, importCfg, repo, nil, logger); err != nil {
+	importLog := llmimport.New(pool)
+
+	if _, err := llm_importer.Run(ctx, importCfg, repo, nil, importLog, logger); err != nil {
 		logger.Error("import failed", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
@@ -0,0 +1,150 @@
+// Command reindex-positions renumbers the senses of every reference entry to
+// a dense 0..n-1 sequence, keeping their current order. The Wiktionary
+// converters and incremental merges can leave gaps or duplicate positions;
+// the frontend orders senses by position, so they should be contiguous.
+//
+// The catalog is walked in batches of entries; each batch is one round trip
+// for the reads and one for the updates. Running it twice is a no-op.
+//
+// Flags:
+//
+//	--batch-size  entries per batch (default: 500)
+//	--dry-run     report how many senses would move without updating them
+//
+// Exit codes: 0 = success, 1 = error.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/refentry"
+	"github.com/heartmarshall/myenglish-backend/internal/app"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// positionStore reads and rewrites reference sense positions.
+type positionStore interface {
+	GetEntryIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	GetSensePositionsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID][]domain.RefSense, error)
+	BulkUpdateSensePositions(ctx context.Context, updates []domain.RefSensePosition) (int, error)
+}
+
+// reindexStats summarizes a run.
+type reindexStats struct {
+	Entries int // entries scanned
+	Moved   int // senses whose position changed (or would change on a dry run)
+}
+
+func main() {
+	batchSize := flag.Int("batch-size", 500, "entries per batch")
+	dryRun := flag.Bool("dry-run", false, "report changes without updating positions")
+	flag.Parse()
+
+	if *batchSize < 1 {
+		fmt.Fprintln(os.Stderr, "--batch-size must be positive")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	logger := app.NewLogger(cfg.Log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, cfg.Database)
+	if err != nil {
+		logger.Error("connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	repo := refentry.New(pool, postgres.NewTxManager(pool))
+
+	stats, err := reindexPositions(ctx, repo, *batchSize, *dryRun)
+	if err != nil {
+		logger.Error("reindex sense positions", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	logger.Info("reindex sense positions done",
+		slog.Int("entries", stats.Entries),
+		slog.Int("senses_moved", stats.Moved),
+		slog.Bool("dry_run", *dryRun),
+	)
+}
+
+// reindexPositions walks all reference entries in batches and makes the
+// sense positions of each one dense.
+func reindexPositions(ctx context.Context, store positionStore, batchSize int, dryRun bool) (reindexStats, error) {
+	var stats reindexStats
+
+	after := uuid.Nil
+	for {
+		ids, err := store.GetEntryIDsAfter(ctx, after, batchSize)
+		if err != nil {
+			return stats, fmt.Errorf("list entries: %w", err)
+		}
+		if len(ids) == 0 {
+			return stats, nil
+		}
+		after = ids[len(ids)-1]
+		stats.Entries += len(ids)
+
+		sensesByEntry, err := store.GetSensePositionsByEntryIDs(ctx, ids)
+		if err != nil {
+			return stats, fmt.Errorf("load senses: %w", err)
+		}
+
+		var updates []domain.RefSensePosition
+		for _, id := range ids {
+			updates = append(updates, densePositions(sensesByEntry[id])...)
+		}
+		stats.Moved += len(updates)
+
+		if dryRun || len(updates) == 0 {
+			continue
+		}
+		if _, err := store.BulkUpdateSensePositions(ctx, updates); err != nil {
+			return stats, fmt.Errorf("update positions: %w", err)
+		}
+	}
+}
+
+// densePositions renumbers one entry's senses to 0..n-1 in their current
+// order (position, then created_at, then ID) and returns only the senses
+// whose position changes.
+func densePositions(senses []domain.RefSense) []domain.RefSensePosition {
+	ordered := slices.Clone(senses)
+	slices.SortStableFunc(ordered, func(a, b domain.RefSense) int {
+		if a.Position != b.Position {
+			return a.Position - b.Position
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(a.ID[:], b.ID[:])
+	})
+
+	var updates []domain.RefSensePosition
+	for i, s := range ordered {
+		if s.Position != i {
+			updates = append(updates, domain.RefSensePosition{SenseID: s.ID, Position: i})
+		}
+	}
+	return updates
+}
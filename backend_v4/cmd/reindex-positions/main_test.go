@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// fakePositionStore keeps ref senses in memory and applies position updates.
+type fakePositionStore struct {
+	entryIDs   []uuid.UUID // sorted
+	senses     map[uuid.UUID][]domain.RefSense
+	updateErr  error
+	updateRuns int
+}
+
+func (f *fakePositionStore) GetEntryIDsAfter(_ context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for _, id := range f.entryIDs {
+		if id.String() > after.String() && len(out) < limit {
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakePositionStore) GetSensePositionsByEntryIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID][]domain.RefSense, error) {
+	out := make(map[uuid.UUID][]domain.RefSense, len(ids))
+	for _, id := range ids {
+		out[id] = append([]domain.RefSense(nil), f.senses[id]...)
+	}
+	return out, nil
+}
+
+func (f *fakePositionStore) BulkUpdateSensePositions(_ context.Context, updates []domain.RefSensePosition) (int, error) {
+	f.updateRuns++
+	if f.updateErr != nil {
+		return 0, f.updateErr
+	}
+	for _, u := range updates {
+		for entryID, senses := range f.senses {
+			for i := range senses {
+				if senses[i].ID == u.SenseID {
+					f.senses[entryID][i].Position = u.Position
+				}
+			}
+		}
+	}
+	return len(updates), nil
+}
+
+// seedEntry adds an entry whose senses have the given positions, in insertion order.
+func (f *fakePositionStore) seedEntry(positions ...int) uuid.UUID {
+	entryID := uuid.New()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, pos := range positions {
+		f.senses[entryID] = append(f.senses[entryID], domain.RefSense{
+			ID: uuid.New(), RefEntryID: entryID, Position: pos, CreatedAt: base.Add(time.Duration(i) * time.Second),
+		})
+	}
+	f.entryIDs = append(f.entryIDs, entryID)
+	slices.SortFunc(f.entryIDs, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	return entryID
+}
+
+func newFakeStore() *fakePositionStore {
+	return &fakePositionStore{senses: make(map[uuid.UUID][]domain.RefSense)}
+}
+
+// positionsByOrder returns the senses' positions in their original insertion order.
+func positionsByOrder(senses []domain.RefSense) []int {
+	out := make([]int, len(senses))
+	for i, s := range senses {
+		out[i] = s.Position
+	}
+	return out
+}
+
+func TestDensePositions_Gapped(t *testing.T) {
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	senses := []domain.RefSense{
+		{ID: a, Position: 10},
+		{ID: b, Position: 2},
+		{ID: c, Position: 5},
+	}
+
+	got := densePositions(senses)
+
+	want := map[uuid.UUID]int{b: 0, c: 1, a: 2}
+	if len(got) != len(want) {
+		t.Fatalf("got %d updates, want %d: %+v", len(got), len(want), got)
+	}
+	for _, u := range got {
+		if want[u.SenseID] != u.Position {
+			t.Errorf("sense %s: got position %d, want %d", u.SenseID, u.Position, want[u.SenseID])
+		}
+	}
+}
+
+func TestDensePositions_AlreadyDense(t *testing.T) {
+	senses := []domain.RefSense{{ID: uuid.New(), Position: 0}, {ID: uuid.New(), Position: 1}}
+	if got := densePositions(senses); len(got) != 0 {
+		t.Errorf("expected no updates, got %+v", got)
+	}
+}
+
+func TestDensePositions_DuplicatesKeepCreationOrder(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	first, second := uuid.New(), uuid.New()
+	senses := []domain.RefSense{
+		{ID: second, Position: 0, CreatedAt: base.Add(time.Second)},
+		{ID: first, Position: 0, CreatedAt: base},
+	}
+
+	got := densePositions(senses)
+	if len(got) != 1 || got[0].SenseID != second || got[0].Position != 1 {
+		t.Errorf("got %+v, want only the later sense moved to 1", got)
+	}
+}
+
+func TestReindexPositions_MakesEveryEntryDense(t *testing.T) {
+	store := newFakeStore()
+	gapped := store.seedEntry(0, 3, 9)
+	merged := store.seedEntry(5, 1, 1)
+	dense := store.seedEntry(0, 1)
+
+	stats, err := reindexPositions(context.Background(), store, 2, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.Entries != 3 {
+		t.Errorf("Entries = %d, want 3", stats.Entries)
+	}
+	if stats.Moved != 4 {
+		t.Errorf("Moved = %d, want 4", stats.Moved)
+	}
+
+	tests := []struct {
+		name  string
+		entry uuid.UUID
+		want  []int
+	}{
+		{"gapped", gapped, []int{0, 1, 2}},
+		{"merged", merged, []int{2, 0, 1}},
+		{"dense", dense, []int{0, 1}},
+	}
+	for _, tt := range tests {
+		got := positionsByOrder(store.senses[tt.entry])
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestReindexPositions_DryRun(t *testing.T) {
+	store := newFakeStore()
+	entry := store.seedEntry(4, 8)
+
+	stats, err := reindexPositions(context.Background(), store, 10, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Moved != 2 {
+		t.Errorf("Moved = %d, want 2", stats.Moved)
+	}
+	if store.updateRuns != 0 {
+		t.Errorf("dry run issued %d updates", store.updateRuns)
+	}
+	if got := positionsByOrder(store.senses[entry]); got[0] != 4 || got[1] != 8 {
+		t.Errorf("dry run changed positions: %v", got)
+	}
+}
+
+func TestReindexPositions_UpdateError(t *testing.T) {
+	store := newFakeStore()
+	store.seedEntry(1)
+	store.updateErr = errors.New("db down")
+
+	if _, err := reindexPositions(context.Background(), store, 10, false); !errors.Is(err, store.updateErr) {
+		t.Errorf("got %v, want wrapped update error", err)
+	}
+}
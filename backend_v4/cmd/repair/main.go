@@ -0,0 +1,104 @@
+// Command repair finds data left inconsistent by maintenance jobs and,
+// optionally, fixes it. Currently it checks for orphaned cards: cards whose
+// entry row no longer exists, e.g. after cleanup hard-deleted entries on a
+// database restored without the cards.entry_id ON DELETE CASCADE.
+//
+// Like cleanup it is meant to be run as a maintenance task by an external
+// cron job.
+//
+// Flags:
+//
+//	--delete-orphans  delete orphaned cards instead of only reporting them
+//
+// Exit codes: 0 = success, 1 = error.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/card"
+	"github.com/heartmarshall/myenglish-backend/internal/app"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// orphanStore finds and deletes cards whose entry is gone.
+type orphanStore interface {
+	FindOrphaned(ctx context.Context) ([]domain.Card, error)
+	DeleteOrphaned(ctx context.Context, cardIDs []uuid.UUID) (int64, error)
+}
+
+func main() {
+	deleteOrphans := flag.Bool("delete-orphans", false, "delete orphaned cards instead of only reporting them")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	logger := app.NewLogger(cfg.Log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, cfg.Database)
+	if err != nil {
+		logger.Error("connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	if err := repairOrphanedCards(ctx, logger, card.New(pool), *deleteOrphans); err != nil {
+		logger.Error("repair orphaned cards", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+// repairOrphanedCards logs every orphaned card and deletes them when
+// deleteOrphans is set.
+func repairOrphanedCards(ctx context.Context, logger *slog.Logger, store orphanStore, deleteOrphans bool) error {
+	orphans, err := store.FindOrphaned(ctx)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		logger.Info("no orphaned cards found")
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(orphans))
+	for i, c := range orphans {
+		ids[i] = c.ID
+		logger.Warn("orphaned card",
+			slog.String("card_id", c.ID.String()),
+			slog.String("user_id", c.UserID.String()),
+			slog.String("entry_id", c.EntryID.String()),
+		)
+	}
+
+	if !deleteOrphans {
+		logger.Info("orphaned cards found; rerun with --delete-orphans to delete them",
+			slog.Int("count", len(orphans)),
+		)
+		return nil
+	}
+
+	deleted, err := store.DeleteOrphaned(ctx, ids)
+	if err != nil {
+		return err
+	}
+	logger.Info("orphaned cards deleted",
+		slog.Int("found", len(orphans)),
+		slog.Int64("deleted", deleted),
+	)
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// fakeOrphanStore holds orphaned cards and records deletions.
+type fakeOrphanStore struct {
+	orphans []domain.Card
+	findErr error
+	deleted []uuid.UUID
+}
+
+func (f *fakeOrphanStore) FindOrphaned(context.Context) ([]domain.Card, error) {
+	return f.orphans, f.findErr
+}
+
+func (f *fakeOrphanStore) DeleteOrphaned(_ context.Context, ids []uuid.UUID) (int64, error) {
+	f.deleted = append(f.deleted, ids...)
+	return int64(len(ids)), nil
+}
+
+func newOrphans(n int) []domain.Card {
+	cards := make([]domain.Card, n)
+	for i := range cards {
+		cards[i] = domain.Card{ID: uuid.New(), UserID: uuid.New(), EntryID: uuid.New()}
+	}
+	return cards
+}
+
+func TestRepairOrphanedCards_ReportOnly(t *testing.T) {
+	store := &fakeOrphanStore{orphans: newOrphans(2)}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	if err := repairOrphanedCards(context.Background(), logger, store, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 0 {
+		t.Errorf("deleted %v without --delete-orphans", store.deleted)
+	}
+	out := buf.String()
+	for _, c := range store.orphans {
+		if !strings.Contains(out, c.ID.String()) {
+			t.Errorf("orphan %s not reported:\n%s", c.ID, out)
+		}
+	}
+}
+
+func TestRepairOrphanedCards_DeleteOrphans(t *testing.T) {
+	store := &fakeOrphanStore{orphans: newOrphans(3)}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	if err := repairOrphanedCards(context.Background(), logger, store, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.deleted) != 3 {
+		t.Fatalf("deleted %d cards, want 3", len(store.deleted))
+	}
+	for i, c := range store.orphans {
+		if store.deleted[i] != c.ID {
+			t.Errorf("deleted[%d] = %s, want %s", i, store.deleted[i], c.ID)
+		}
+	}
+}
+
+func TestRepairOrphanedCards_NoOrphans(t *testing.T) {
+	store := &fakeOrphanStore{}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	if err := repairOrphanedCards(context.Background(), logger, store, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if store.deleted != nil {
+		t.Errorf("DeleteOrphaned called with %v, want no call", store.deleted)
+	}
+}
+
+func TestRepairOrphanedCards_FindError(t *testing.T) {
+	store := &fakeOrphanStore{findErr: errors.New("connection reset")}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	if err := repairOrphanedCards(context.Background(), logger, store, true); err == nil {
+		t.Fatal("expected error")
+	}
+}
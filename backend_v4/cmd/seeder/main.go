@@ -7,6 +7,15 @@
 //	--phase          comma-separated list of phases to run (default: all)
 //	--dry-run        parse datasets without writing to DB
 //	--seeder-config  path to seeder YAML config file
+//	--cefr-from-frequency  tag senses with CEFR estimated from NGSL/NAWL bands
+//	--normalize-ipa  store Wiktionary IPA in canonical form (see seeder config broad_ipa)
+//	--validate-audio check Wikimedia Commons audio URLs over HTTP before storing them
+//	--report         write the dry-run diff report as JSON to this path
+//	--parse-workers  goroutines used to parse the Wiktionary dump
+//	--resume         continue from the checkpoint file instead of starting over
+//	--strict-pos     fail the wiktionary phase if any POS tag has no mapping
+//	--use-copy       load Wiktionary entries, senses, translations and examples with COPY
+//	--batch-size     entries per insert batch and transaction
 //
 // Exit codes: 0 = success, 1 = error.
 package main
@@ -34,6 +43,15 @@ func main() {
 	phaseFlag := flag.String("phase", "", "comma-separated phases to run (default: all)")
 	dryRunFlag := flag.Bool("dry-run", false, "parse datasets without writing to DB")
 	seederConfigFlag := flag.String("seeder-config", "", "path to seeder YAML config file")
+	reportFlag := flag.String("report", "", "write the dry-run diff report as JSON to this path")
+	cefrFromFreqFlag := flag.Bool("cefr-from-frequency", false, "tag senses with CEFR estimated from NGSL/NAWL bands")
+	normalizeIPAFlag := flag.Bool("normalize-ipa", false, "store Wiktionary IPA in canonical form")
+	validateAudioFlag := flag.Bool("validate-audio", false, "check Wikimedia Commons audio URLs over HTTP before storing them")
+	resumeFlag := flag.Bool("resume", false, "continue from the checkpoint file instead of starting over")
+	strictPOSFlag := flag.Bool("strict-pos", false, "fail the wiktionary phase if any POS tag has no mapping")
+	parseWorkersFlag := flag.Int("parse-workers", 0, "goroutines used to parse the Wiktionary dump (default: from config)")
+	useCopyFlag := flag.Bool("use-copy", false, "load Wiktionary entries, senses, translations and examples with COPY")
+	batchSizeFlag := flag.Int("batch-size", 0, "entries per insert batch and transaction (default: from config)")
 	flag.Parse()
 
 	// Load app config (for DB connection).
@@ -55,6 +73,33 @@ func main() {
 	if *dryRunFlag {
 		seederCfg.DryRun = true
 	}
+	if *cefrFromFreqFlag {
+		seederCfg.CEFRFromFrequency = true
+	}
+	if *normalizeIPAFlag {
+		seederCfg.NormalizeIPA = true
+	}
+	if *validateAudioFlag {
+		seederCfg.ValidateAudio = true
+	}
+	if *reportFlag != "" {
+		seederCfg.ReportPath = *reportFlag
+	}
+	if *resumeFlag {
+		seederCfg.Resume = true
+	}
+	if *strictPOSFlag {
+		seederCfg.StrictPOS = true
+	}
+	if *parseWorkersFlag > 0 {
+		seederCfg.ParseWorkers = *parseWorkersFlag
+	}
+	if *useCopyFlag {
+		seederCfg.UseCopy = true
+	}
+	if *batchSizeFlag > 0 {
+		seederCfg.BatchSize = *batchSizeFlag
+	}
 
 	// Parse phase filter.
 	var phases []string
@@ -82,6 +127,10 @@ func main() {
 
 	// Run pipeline.
 	pipeline := seeder.NewPipeline(logger, repo, *seederCfg)
+	pipeline.SetTxManager(txm)
+	if seederCfg.ValidateAudio {
+		pipeline.SetAudioChecker(seeder.NewHTTPAudioChecker(10 * time.Second))
+	}
 	if err := pipeline.Run(ctx, phases); err != nil {
 		logger.Error("pipeline failed", slog.String("error", err.Error()))
 		os.Exit(1)
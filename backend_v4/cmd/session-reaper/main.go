@@ -0,0 +1,79 @@
+// Command session-reaper abandons ACTIVE study sessions that have had no
+// review for longer than the inactivity timeout, so sessions left open by
+// users who walked away do not stay active forever.
+// It is intended to be invoked by an external cron job, like cleanup.
+//
+// Flags:
+//
+//	--inactive-for  inactivity window (default: srs.session_inactivity_timeout)
+//
+// Exit codes: 0 = success, 1 = error.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/session"
+	"github.com/heartmarshall/myenglish-backend/internal/app"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+)
+
+// staleAbandoner abandons sessions without activity since a point in time.
+type staleAbandoner interface {
+	AbandonStale(ctx context.Context, olderThan time.Time) (int64, error)
+}
+
+func main() {
+	inactiveFor := flag.Duration("inactive-for", 0, "abandon sessions without a review for this long (0 = from config)")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("load config: %v", err)
+	}
+
+	logger := app.NewLogger(cfg.Log)
+
+	window := cfg.SRS.SessionInactivityTimeout
+	if *inactiveFor > 0 {
+		window = *inactiveFor
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pool, err := postgres.NewPool(ctx, cfg.Database)
+	if err != nil {
+		logger.Error("connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	if err := run(ctx, logger, session.New(pool), window, time.Now()); err != nil {
+		logger.Error("abandon stale sessions", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+// run abandons sessions whose last activity is more than inactiveFor before now.
+func run(ctx context.Context, logger *slog.Logger, repo staleAbandoner, inactiveFor time.Duration, now time.Time) error {
+	threshold := now.Add(-inactiveFor)
+
+	n, err := repo.AbandonStale(ctx, threshold)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("stale sessions abandoned",
+		slog.Int64("abandoned", n),
+		slog.Duration("inactive_for", inactiveFor),
+		slog.Time("threshold", threshold),
+	)
+	return nil
+}
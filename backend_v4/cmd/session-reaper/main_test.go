@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeSessions maps ACTIVE session IDs to their last activity.
+type fakeSessions struct {
+	active    map[uuid.UUID]time.Time
+	abandoned []uuid.UUID
+	err       error
+}
+
+func (f *fakeSessions) AbandonStale(_ context.Context, olderThan time.Time) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	var n int64
+	for id, last := range f.active {
+		if last.Before(olderThan) {
+			delete(f.active, id)
+			f.abandoned = append(f.abandoned, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+var testNow = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRun_AbandonsInactiveKeepsRecent(t *testing.T) {
+	inactive, recent := uuid.New(), uuid.New()
+	repo := &fakeSessions{active: map[uuid.UUID]time.Time{
+		inactive: testNow.Add(-3 * time.Hour),
+		recent:   testNow.Add(-10 * time.Minute),
+	}}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	if err := run(context.Background(), logger, repo, 2*time.Hour, testNow); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	if len(repo.abandoned) != 1 || repo.abandoned[0] != inactive {
+		t.Errorf("abandoned = %v, want only %s", repo.abandoned, inactive)
+	}
+	if _, ok := repo.active[recent]; !ok {
+		t.Error("recently active session was abandoned")
+	}
+}
+
+func TestRun_Error(t *testing.T) {
+	repo := &fakeSessions{err: errors.New("connection refused")}
+	logger := slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil))
+
+	if err := run(context.Background(), logger, repo, time.Hour, testNow); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,207 @@
+// Package anki reads notes and review schedules from Anki .apkg packages.
+// An .apkg is a zip archive holding a SQLite collection; it is unpacked to a
+// temporary file and queried read-only. No application database is touched.
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// MaxPackageSize is the largest .apkg accepted, in bytes.
+const MaxPackageSize = 100 << 20
+
+// Collection file names inside an .apkg, newest first. collection.anki21b is
+// zstd-compressed and not supported.
+const (
+	collectionAnki21  = "collection.anki21"
+	collectionAnki2   = "collection.anki2"
+	collectionAnki21b = "collection.anki21b"
+)
+
+// Anki card types (cards.type).
+const cardTypeReview = 2
+
+// fieldSeparator separates note fields in notes.flds.
+const fieldSeparator = "\x1f"
+
+// ErrUnsupportedPackage is returned for packages this reader cannot open.
+var ErrUnsupportedPackage = errors.New("unsupported anki package")
+
+// Reader parses .apkg packages. The zero value is ready to use.
+type Reader struct{}
+
+// New creates a new .apkg Reader.
+func New() *Reader {
+	return &Reader{}
+}
+
+// ReadNotes reads every note in the package in creation order. Notes with
+// fewer than two fields are returned with an empty Back so callers can
+// report them.
+func (r *Reader) ReadNotes(ctx context.Context, src io.Reader) ([]domain.AnkiNote, error) {
+	data, err := io.ReadAll(io.LimitReader(src, MaxPackageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read package: %w", err)
+	}
+	if len(data) > MaxPackageSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrUnsupportedPackage, MaxPackageSize)
+	}
+
+	path, err := extractCollection(data)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(path)
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("open collection: %w", err)
+	}
+	defer db.Close()
+
+	return readNotes(ctx, db)
+}
+
+// extractCollection writes the package's collection database to a temporary
+// file and returns its path.
+func extractCollection(data []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("%w: not a zip archive", ErrUnsupportedPackage)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var coll *zip.File
+	switch {
+	case files[collectionAnki21] != nil:
+		coll = files[collectionAnki21]
+	case files[collectionAnki2] != nil:
+		coll = files[collectionAnki2]
+	case files[collectionAnki21b] != nil:
+		return "", fmt.Errorf("%w: compressed collection, re-export with \"Support older Anki versions\"", ErrUnsupportedPackage)
+	default:
+		return "", fmt.Errorf("%w: no collection found", ErrUnsupportedPackage)
+	}
+
+	rc, err := coll.Open()
+	if err != nil {
+		return "", fmt.Errorf("open collection entry: %w", err)
+	}
+	defer rc.Close()
+
+	tmp, err := os.CreateTemp("", "anki-*.sqlite")
+	if err != nil {
+		return "", fmt.Errorf("create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, io.LimitReader(rc, MaxPackageSize)); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("extract collection: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// notesSQL pairs each note with its first card (lowest template ordinal).
+const notesSQL = `
+SELECT n.flds, c.type, c.due, c.ivl, c.factor, c.reps, c.lapses
+FROM notes n
+LEFT JOIN cards c ON c.id = (SELECT id FROM cards WHERE nid = n.id ORDER BY ord, id LIMIT 1)
+ORDER BY n.id`
+
+func readNotes(ctx context.Context, db *sql.DB) ([]domain.AnkiNote, error) {
+	// col.crt is the collection creation time; review due dates count days from it.
+	var crt int64
+	if err := db.QueryRowContext(ctx, `SELECT crt FROM col LIMIT 1`).Scan(&crt); err != nil {
+		return nil, fmt.Errorf("%w: read collection header: %v", ErrUnsupportedPackage, err)
+	}
+	created := time.Unix(crt, 0).UTC()
+
+	rows, err := db.QueryContext(ctx, notesSQL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: query notes: %v", ErrUnsupportedPackage, err)
+	}
+	defer rows.Close()
+
+	var notes []domain.AnkiNote
+	for rows.Next() {
+		var (
+			flds                       string
+			cardType, due, ivl, factor sql.NullInt64
+			reps, lapses               sql.NullInt64
+		)
+		if err := rows.Scan(&flds, &cardType, &due, &ivl, &factor, &reps, &lapses); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+
+		fields := strings.Split(flds, fieldSeparator)
+		note := domain.AnkiNote{Front: CleanField(fields[0])}
+		if len(fields) > 1 {
+			note.Back = CleanField(fields[1])
+		}
+
+		if cardType.Valid && cardType.Int64 == cardTypeReview && ivl.Int64 > 0 {
+			note.Schedule = &domain.AnkiSchedule{
+				Due:          created.AddDate(0, 0, int(due.Int64)),
+				IntervalDays: int(ivl.Int64),
+				Ease:         float64(factor.Int64) / 1000,
+				Reps:         int(reps.Int64),
+				Lapses:       int(lapses.Int64),
+			}
+		}
+
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+var (
+	soundTagRe = regexp.MustCompile(`\[sound:[^\]]*\]`)
+	lineTagRe  = regexp.MustCompile(`(?i)<br\s*/?>|</?(div|p|li)[^>]*>`)
+	htmlTagRe  = regexp.MustCompile(`<[^>]*>`)
+	spacesRe   = regexp.MustCompile(`[ \t\x{00a0}]+`)
+)
+
+// CleanField turns an Anki field into plain text: sound references and HTML
+// tags are removed, block tags become line breaks, entities are decoded and
+// whitespace is collapsed.
+func CleanField(s string) string {
+	s = soundTagRe.ReplaceAllString(s, "")
+	s = lineTagRe.ReplaceAllString(s, "\n")
+	s = htmlTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	lines := strings.Split(s, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		line = strings.TrimSpace(spacesRe.ReplaceAllString(line, " "))
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
@@ -0,0 +1,109 @@
+package anki
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestReadNotes_Fixture(t *testing.T) {
+	f, err := os.Open("testdata/deck.apkg")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	notes, err := New().ReadNotes(context.Background(), f)
+	if err != nil {
+		t.Fatalf("ReadNotes: %v", err)
+	}
+	if len(notes) != 4 {
+		t.Fatalf("got %d notes, want 4", len(notes))
+	}
+
+	run := notes[0]
+	if run.Front != "run" || run.Back != "бежать, мчаться; управлять" {
+		t.Errorf("note 0 = %q / %q", run.Front, run.Back)
+	}
+	if run.Schedule == nil {
+		t.Fatal("note 0: expected review schedule from its first card")
+	}
+	wantDue := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC) // crt + 40 days
+	if !run.Schedule.Due.Equal(wantDue) {
+		t.Errorf("due = %v, want %v", run.Schedule.Due, wantDue)
+	}
+	if run.Schedule.IntervalDays != 12 || run.Schedule.Ease != 2.5 ||
+		run.Schedule.Reps != 5 || run.Schedule.Lapses != 1 {
+		t.Errorf("schedule = %+v", *run.Schedule)
+	}
+
+	if notes[1].Front != "serendipity" || notes[1].Back != "a happy accident" {
+		t.Errorf("note 1 = %q / %q, want HTML and sound stripped", notes[1].Front, notes[1].Back)
+	}
+	if notes[1].Schedule != nil {
+		t.Errorf("note 1: new card must not carry a schedule")
+	}
+
+	if notes[2].Front != "orphan" || notes[2].Back != "" {
+		t.Errorf("note 2 = %q / %q, want single-field note with empty back", notes[2].Front, notes[2].Back)
+	}
+
+	if notes[3].Front != "take off" || notes[3].Back != "to leave the ground\nвзлетать" {
+		t.Errorf("note 3 = %q / %q", notes[3].Front, notes[3].Back)
+	}
+	if notes[3].Schedule != nil {
+		t.Errorf("note 3: learning card must not carry a schedule")
+	}
+}
+
+func TestReadNotes_Unsupported(t *testing.T) {
+	zipWith := func(name string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		w, _ := zw.Create(name)
+		w.Write([]byte("x"))
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"not a zip", []byte("plain text")},
+		{"compressed collection", zipWith(collectionAnki21b)},
+		{"no collection", zipWith("media")},
+		{"corrupt collection", zipWith(collectionAnki2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New().ReadNotes(context.Background(), bytes.NewReader(tt.data))
+			if !errors.Is(err, ErrUnsupportedPackage) {
+				t.Errorf("got %v, want ErrUnsupportedPackage", err)
+			}
+		})
+	}
+}
+
+func TestCleanField(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"<b>bold</b> text", "bold text"},
+		{"one<br>two<br/>three", "one\ntwo\nthree"},
+		{"<div>a</div><div></div><div>b</div>", "a\nb"},
+		{"word [sound:word.mp3]", "word"},
+		{"Tom &amp; Jerry&nbsp;show", "Tom & Jerry show"},
+		{"  spaced \t  out  ", "spaced out"},
+	}
+	for _, tt := range tests {
+		if got := CleanField(tt.in); got != tt.want {
+			t.Errorf("CleanField(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
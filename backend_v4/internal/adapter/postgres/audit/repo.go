@@ -1,5 +1,6 @@
 // Package audit implements the Audit repository using PostgreSQL.
 // It provides append-only operations for audit log records.
+// Static queries are generated by sqlc; the dynamic Query uses Squirrel.
 package audit
 
 import (
@@ -9,6 +10,7 @@ import (
 	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -20,6 +22,15 @@ import (
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
+const deleteOlderThanSQL = `
+DELETE FROM audit_log
+WHERE id IN (SELECT id FROM audit_log WHERE created_at < $1 LIMIT $2)`
+
+const countOlderThanSQL = `SELECT count(*) FROM audit_log WHERE created_at < $1`
+
+// psql is the Squirrel statement builder configured for PostgreSQL dollar placeholders.
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
 // Repo provides audit log persistence backed by PostgreSQL.
 type Repo struct {
 	pool *pgxpool.Pool
@@ -71,21 +82,37 @@ func (r *Repo) Log(ctx context.Context, record domain.AuditRecord) error {
 	return err
 }
 
-// DeleteOlderThan deletes audit_log records older than the given time.
+// DeleteOlderThan deletes audit_log records older than the given time in
+// batches of postgres.DefaultDeleteBatchSize, checking ctx between batches.
 // Returns the number of deleted records.
 func (r *Repo) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
-	result, err := r.pool.Exec(ctx,
-		"DELETE FROM audit_log WHERE created_at < $1", before)
+	total, err := postgres.DeleteInBatches(ctx, postgres.DefaultDeleteBatchSize, func(ctx context.Context, limit int) (int64, error) {
+		result, err := r.pool.Exec(ctx, deleteOlderThanSQL, before, limit)
+		if err != nil {
+			return 0, err
+		}
+		return result.RowsAffected(), nil
+	})
 	if err != nil {
-		return 0, fmt.Errorf("audit.DeleteOlderThan: %w", err)
+		return total, fmt.Errorf("audit.DeleteOlderThan: %w", err)
 	}
-	return result.RowsAffected(), nil
+	return total, nil
 }
 
 // ---------------------------------------------------------------------------
 // Read operations
 // ---------------------------------------------------------------------------
 
+// CountOlderThan returns how many audit_log records DeleteOlderThan would
+// remove for the same time.
+func (r *Repo) CountOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	if err := r.pool.QueryRow(ctx, countOlderThanSQL, before).Scan(&n); err != nil {
+		return 0, fmt.Errorf("audit.CountOlderThan: %w", err)
+	}
+	return n, nil
+}
+
 // GetByEntity returns the change history for a specific entity, ordered by
 // created_at DESC, limited to `limit` records.
 func (r *Repo) GetByEntity(ctx context.Context, entityType domain.EntityType, entityID uuid.UUID, limit int) ([]domain.AuditRecord, error) {
@@ -138,6 +165,74 @@ func (r *Repo) GetByUser(ctx context.Context, userID uuid.UUID, limit, offset in
 	return records, nil
 }
 
+// Query returns audit records matching the filter, ordered by created_at DESC,
+// together with the total number of matches.
+func (r *Repo) Query(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	where := sq.And{}
+	if f.UserID != nil {
+		where = append(where, sq.Eq{"user_id": *f.UserID})
+	}
+	if f.EntityType != nil {
+		where = append(where, sq.Eq{"entity_type": string(*f.EntityType)})
+	}
+	if f.Action != nil {
+		where = append(where, sq.Eq{"action": string(*f.Action)})
+	}
+	if f.From != nil {
+		where = append(where, sq.GtOrEq{"created_at": *f.From})
+	}
+	if f.To != nil {
+		where = append(where, sq.Lt{"created_at": *f.To})
+	}
+
+	countSQL, countArgs, err := psql.Select("count(*)").From("audit_log").Where(where).ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("audit.Query: build count query: %w", err)
+	}
+	var total int
+	if err := querier.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("audit.Query: count: %w", err)
+	}
+
+	dataSQL, dataArgs, err := psql.
+		Select("id", "user_id", "entity_type", "entity_id", "action", "changes", "created_at").
+		From("audit_log").
+		Where(where).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(f.Limit)).
+		Offset(uint64(f.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, 0, fmt.Errorf("audit.Query: build data query: %w", err)
+	}
+
+	rows, err := querier.Query(ctx, dataSQL, dataArgs...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("audit.Query: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.AuditRecord
+	for rows.Next() {
+		var row sqlc.AuditLog
+		if err := rows.Scan(&row.ID, &row.UserID, &row.EntityType, &row.EntityID, &row.Action, &row.Changes, &row.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("audit.Query: scan: %w", err)
+		}
+		rec, err := toDomainAuditRecord(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("audit.Query: %w", err)
+	}
+
+	return records, total, nil
+}
+
 // ---------------------------------------------------------------------------
 // Error mapping
 // ---------------------------------------------------------------------------
@@ -720,6 +720,50 @@ func TestRepo_Create_AllAuditActions(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Query tests
+// ---------------------------------------------------------------------------
+
+func TestRepo_Query_FiltersAndCounts(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+	alice := testhelper.SeedUser(t, pool)
+	bob := testhelper.SeedUser(t, pool)
+
+	base := time.Now().UTC().Truncate(time.Microsecond)
+	for i, rec := range []domain.AuditRecord{
+		buildAuditRecord(alice.ID, domain.EntityTypeEntry, nil, domain.AuditActionCreate, nil),
+		buildAuditRecord(alice.ID, domain.EntityTypeEntry, nil, domain.AuditActionUpdate, nil),
+		buildAuditRecord(alice.ID, domain.EntityTypeCard, nil, domain.AuditActionCreate, nil),
+		buildAuditRecord(bob.ID, domain.EntityTypeEntry, nil, domain.AuditActionCreate, nil),
+	} {
+		rec.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if _, err := repo.Create(ctx, rec); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	entryType := domain.EntityTypeEntry
+	got, total, err := repo.Query(ctx, domain.AuditFilter{UserID: &alice.ID, EntityType: &entryType, Limit: 1})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if total != 2 || len(got) != 1 || got[0].Action != domain.AuditActionUpdate {
+		t.Errorf("Query(alice, ENTRY) = %d records (total %d), want newest UPDATE of 2", len(got), total)
+	}
+
+	// No user filter: both users' records in the time window.
+	from := base.Add(2 * time.Minute)
+	got, total, err = repo.Query(ctx, domain.AuditFilter{From: &from, Limit: 10})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if total != 2 || len(got) != 2 || got[0].UserID != bob.ID {
+		t.Errorf("Query(from) = %d records (total %d), want 2 with bob's first", len(got), total)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Test helpers
 // ---------------------------------------------------------------------------
@@ -77,6 +77,24 @@ func (r *Repo) Create(ctx context.Context, am *domain.AuthMethod) (*domain.AuthM
 	return &result, nil
 }
 
+// UpdatePasswordHash replaces the password hash of an auth method.
+func (r *Repo) UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	rowsAffected, err := q.UpdatePasswordHash(ctx, sqlc.UpdatePasswordHashParams{
+		ID:           id,
+		PasswordHash: pgtype.Text{String: hash, Valid: true},
+	})
+	if err != nil {
+		return mapError(err, "auth_method")
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("auth_method %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
 // ListByUser returns all auth methods for a user.
 func (r *Repo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.AuthMethod, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
@@ -93,6 +111,34 @@ func (r *Repo) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.AuthM
 	return result, nil
 }
 
+// CountByUserForUpdate returns the number of auth methods linked to a user,
+// locking those rows until the transaction ends (must be called within a
+// transaction).
+func (r *Repo) CountByUserForUpdate(ctx context.Context, userID uuid.UUID) (int, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	count, err := q.CountByUserForUpdate(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("auth_method count: %w", err)
+	}
+	return int(count), nil
+}
+
+// Delete removes an auth method by ID.
+func (r *Repo) Delete(ctx context.Context, id uuid.UUID) error {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	rowsAffected, err := q.DeleteAuthMethod(ctx, id)
+	if err != nil {
+		return mapError(err, "auth_method")
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("auth_method %s: %w", id, domain.ErrNotFound)
+	}
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Error mapping
 // ---------------------------------------------------------------------------
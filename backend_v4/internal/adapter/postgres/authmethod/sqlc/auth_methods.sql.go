@@ -12,6 +12,34 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countByUserForUpdate = `-- name: CountByUserForUpdate :one
+SELECT count(*) FROM (
+    SELECT id FROM auth_methods
+    WHERE user_id = $1
+    FOR UPDATE
+) locked
+`
+
+func (q *Queries) CountByUserForUpdate(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countByUserForUpdate, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteAuthMethod = `-- name: DeleteAuthMethod :execrows
+DELETE FROM auth_methods
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAuthMethod(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuthMethod, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getByOAuth = `-- name: GetByOAuth :one
 SELECT id, user_id, method, provider_id, password_hash, created_at, updated_at
 FROM auth_methods
@@ -97,6 +125,25 @@ func (q *Queries) CreateAuthMethod(ctx context.Context, arg CreateAuthMethodPara
 	return i, err
 }
 
+const updatePasswordHash = `-- name: UpdatePasswordHash :execrows
+UPDATE auth_methods
+SET password_hash = $2, updated_at = now()
+WHERE id = $1
+`
+
+type UpdatePasswordHashParams struct {
+	ID           uuid.UUID
+	PasswordHash pgtype.Text
+}
+
+func (q *Queries) UpdatePasswordHash(ctx context.Context, arg UpdatePasswordHashParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePasswordHash, arg.ID, arg.PasswordHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const listByUser = `-- name: ListByUser :many
 SELECT id, user_id, method, provider_id, password_hash, created_at, updated_at
 FROM auth_methods
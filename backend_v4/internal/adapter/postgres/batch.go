@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultDeleteBatchSize bounds how many rows one cleanup statement removes,
+// keeping each DELETE short so it never holds locks for long.
+const DefaultDeleteBatchSize = 1000
+
+// DeleteInBatches calls deleteBatch with batchSize until it removes fewer
+// rows than requested, and returns the cumulative count. ctx is checked
+// between batches: on cancellation it stops after the last committed batch
+// and returns the count so far together with the context error.
+func DeleteInBatches(ctx context.Context, batchSize int, deleteBatch func(ctx context.Context, limit int) (int64, error)) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultDeleteBatchSize
+	}
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, fmt.Errorf("delete in batches: %w", err)
+		}
+
+		n, err := deleteBatch(ctx, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += n
+
+		if n < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeBatchRepo deletes up to limit rows per call from a fixed backlog.
+type fakeBatchRepo struct {
+	remaining int64
+	calls     int
+	onCall    func(call int)
+}
+
+func (r *fakeBatchRepo) deleteBatch(_ context.Context, limit int) (int64, error) {
+	r.calls++
+	n := min(r.remaining, int64(limit))
+	r.remaining -= n
+	if r.onCall != nil {
+		r.onCall(r.calls)
+	}
+	return n, nil
+}
+
+func TestDeleteInBatches_LoopsUntilExhausted(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeBatchRepo{remaining: 2500}
+	total, err := DeleteInBatches(context.Background(), 1000, repo.deleteBatch)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 2500 {
+		t.Errorf("total = %d, want 2500", total)
+	}
+	if repo.calls != 3 {
+		t.Errorf("calls = %d, want 3 (1000 + 1000 + 500)", repo.calls)
+	}
+}
+
+func TestDeleteInBatches_ExactMultipleNeedsFinalEmptyBatch(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeBatchRepo{remaining: 2000}
+	total, err := DeleteInBatches(context.Background(), 1000, repo.deleteBatch)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 2000 || repo.calls != 3 {
+		t.Errorf("total = %d, calls = %d; want 2000, 3", total, repo.calls)
+	}
+}
+
+func TestDeleteInBatches_StopsOnContextCancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	repo := &fakeBatchRepo{remaining: 10000}
+	repo.onCall = func(call int) {
+		if call == 2 {
+			cancel()
+		}
+	}
+
+	total, err := DeleteInBatches(ctx, 1000, repo.deleteBatch)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if total != 2000 {
+		t.Errorf("total = %d, want 2000 (batches committed before cancel)", total)
+	}
+	if repo.calls != 2 {
+		t.Errorf("calls = %d, want 2", repo.calls)
+	}
+}
+
+func TestDeleteInBatches_PropagatesError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	calls := 0
+	total, err := DeleteInBatches(context.Background(), 10, func(context.Context, int) (int64, error) {
+		calls++
+		if calls == 2 {
+			return 0, boom
+		}
+		return 10, nil
+	})
+
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+	if total != 10 {
+		t.Errorf("total = %d, want 10", total)
+	}
+}
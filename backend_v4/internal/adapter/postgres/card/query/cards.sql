@@ -5,14 +5,14 @@
 -- name: GetCardByID :one
 SELECT id, user_id, entry_id, state, step, stability, difficulty,
        due, last_review, reps, lapses, scheduled_days, elapsed_days,
-       created_at, updated_at
+       created_at, updated_at, suspended, version
 FROM cards
 WHERE id = @id AND user_id = @user_id;
 
 -- name: GetCardByEntryID :one
 SELECT id, user_id, entry_id, state, step, stability, difficulty,
        due, last_review, reps, lapses, scheduled_days, elapsed_days,
-       created_at, updated_at
+       created_at, updated_at, suspended, version
 FROM cards
 WHERE entry_id = @entry_id AND user_id = @user_id;
 
@@ -21,7 +21,7 @@ INSERT INTO cards (id, user_id, entry_id, state, due, created_at, updated_at)
 VALUES (@id, @user_id, @entry_id, 'NEW', now(), @created_at, @updated_at)
 RETURNING id, user_id, entry_id, state, step, stability, difficulty,
           due, last_review, reps, lapses, scheduled_days, elapsed_days,
-          created_at, updated_at;
+          created_at, updated_at, suspended, version;
 
 -- name: UpdateCardSRS :one
 UPDATE cards
@@ -35,11 +35,21 @@ SET state = @state,
     lapses = @lapses,
     scheduled_days = @scheduled_days,
     elapsed_days = @elapsed_days,
+    version = version + 1,
+    updated_at = now()
+WHERE id = @id AND user_id = @user_id AND version = @version
+RETURNING id, user_id, entry_id, state, step, stability, difficulty,
+          due, last_review, reps, lapses, scheduled_days, elapsed_days,
+          created_at, updated_at, suspended, version;
+
+-- name: SetCardSuspended :one
+UPDATE cards
+SET suspended = @suspended,
     updated_at = now()
 WHERE id = @id AND user_id = @user_id
 RETURNING id, user_id, entry_id, state, step, stability, difficulty,
           due, last_review, reps, lapses, scheduled_days, elapsed_days,
-          created_at, updated_at;
+          created_at, updated_at, suspended, version;
 
 -- name: DeleteCard :execrows
 DELETE FROM cards
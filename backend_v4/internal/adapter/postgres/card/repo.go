@@ -20,12 +20,22 @@ import (
 
 // Repo provides card persistence backed by PostgreSQL.
 type Repo struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool // dashboard stats: CountOverdue, CountByStatus, GetStabilityHistogram
 }
 
 // New creates a new card repository.
 func New(pool *pgxpool.Pool) *Repo {
-	return &Repo{pool: pool}
+	return &Repo{pool: pool, reader: pool}
+}
+
+// SetReader routes the repository's read-only stats queries to reader,
+// typically a read-replica pool. Queries inside a transaction still run on
+// the transaction. A nil reader keeps them on the primary pool.
+func (r *Repo) SetReader(reader *pgxpool.Pool) {
+	if reader != nil {
+		r.reader = reader
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -34,7 +44,7 @@ func New(pool *pgxpool.Pool) *Repo {
 
 const cardColumns = `c.id, c.user_id, c.entry_id, c.state, c.step, c.stability, c.difficulty,
        c.due, c.last_review, c.reps, c.lapses, c.scheduled_days, c.elapsed_days,
-       c.created_at, c.updated_at`
+       c.created_at, c.updated_at, c.suspended, c.version`
 
 // ---------------------------------------------------------------------------
 // Raw SQL for complex queries requiring JOINs
@@ -46,8 +56,45 @@ FROM cards c
 JOIN entries e ON c.entry_id = e.id
 WHERE c.user_id = $1
   AND e.deleted_at IS NULL
+  AND NOT c.suspended
+  AND c.state IN ('LEARNING', 'RELEARNING', 'REVIEW')
+  AND c.due <= $2
+ORDER BY c.due ASC
+LIMIT $3`
+
+var getDueCardsRandomSQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+JOIN entries e ON c.entry_id = e.id
+WHERE c.user_id = $1
+  AND e.deleted_at IS NULL
+  AND NOT c.suspended
+  AND c.state IN ('LEARNING', 'RELEARNING', 'REVIEW')
+  AND c.due <= $2
+ORDER BY random()
+LIMIT $3`
+
+var getDueCardsAddedSQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+JOIN entries e ON c.entry_id = e.id
+WHERE c.user_id = $1
+  AND e.deleted_at IS NULL
+  AND NOT c.suspended
   AND c.state IN ('LEARNING', 'RELEARNING', 'REVIEW')
   AND c.due <= $2
+ORDER BY c.created_at, c.id
+LIMIT $3`
+
+var getLearningDueSQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+JOIN entries e ON c.entry_id = e.id
+WHERE c.user_id = $1
+  AND e.deleted_at IS NULL
+  AND NOT c.suspended
+  AND c.state IN ('LEARNING', 'RELEARNING')
+  AND c.due <= $2
 ORDER BY c.due ASC
 LIMIT $3`
 
@@ -55,21 +102,40 @@ var getNewCardsSQL = `
 SELECT ` + cardColumns + `
 FROM cards c
 JOIN entries e ON c.entry_id = e.id
-WHERE c.user_id = $1 AND e.deleted_at IS NULL AND c.state = 'NEW'
+WHERE c.user_id = $1 AND e.deleted_at IS NULL AND NOT c.suspended AND c.state = 'NEW'
 ORDER BY c.created_at
 LIMIT $2`
 
+var getNewCardsRandomSQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+JOIN entries e ON c.entry_id = e.id
+WHERE c.user_id = $1 AND e.deleted_at IS NULL AND NOT c.suspended AND c.state = 'NEW'
+ORDER BY random()
+LIMIT $2`
+
+// Entries without a catalog link or frequency rank come last, oldest first.
+var getNewCardsFrequencySQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+JOIN entries e ON c.entry_id = e.id
+LEFT JOIN ref_entries re ON re.id = e.ref_entry_id
+WHERE c.user_id = $1 AND e.deleted_at IS NULL AND NOT c.suspended AND c.state = 'NEW'
+ORDER BY re.frequency_rank ASC NULLS LAST, c.created_at
+LIMIT $2`
+
 var countDueSQL = `
 SELECT count(*) FROM cards c
 JOIN entries e ON c.entry_id = e.id
 WHERE c.user_id = $1 AND e.deleted_at IS NULL
+  AND NOT c.suspended
   AND c.state IN ('LEARNING', 'RELEARNING', 'REVIEW')
   AND c.due <= $2`
 
 var countNewSQL = `
 SELECT count(*) FROM cards c
 JOIN entries e ON c.entry_id = e.id
-WHERE c.user_id = $1 AND e.deleted_at IS NULL AND c.state = 'NEW'`
+WHERE c.user_id = $1 AND e.deleted_at IS NULL AND NOT c.suspended AND c.state = 'NEW'`
 
 var countByStatusSQL = `
 SELECT c.state, count(*) as count
@@ -82,6 +148,7 @@ var countOverdueSQL = `
 SELECT count(*) FROM cards c
 JOIN entries e ON c.entry_id = e.id
 WHERE c.user_id = $1 AND e.deleted_at IS NULL
+  AND NOT c.suspended
   AND c.state IN ('LEARNING', 'RELEARNING', 'REVIEW')
   AND c.due < $2`
 
@@ -99,6 +166,53 @@ WHERE c.entry_id = ANY($1::uuid[]) AND c.user_id = $2`
 const existsByEntryIDsSQL = `
 SELECT entry_id FROM cards WHERE user_id = $1 AND entry_id = ANY($2::uuid[])`
 
+var studyableByIDsSQL = `
+SELECT c.id FROM cards c
+JOIN entries e ON c.entry_id = e.id
+WHERE c.user_id = $1 AND c.id = ANY($2::uuid[]) AND e.deleted_at IS NULL AND NOT c.suspended`
+
+// width_bucket returns 0 below the first bound and len(bounds) above the last.
+const stabilityHistogramSQL = `
+SELECT width_bucket(stability, $2::float8[]) AS bucket, count(*), COALESCE(sum(stability), 0)
+FROM cards
+WHERE user_id = $1 AND state <> 'NEW'
+GROUP BY bucket`
+
+// skip_count is analytics only; skipping leaves SRS state and updated_at alone.
+const incrementSkipCountSQL = `
+UPDATE cards SET skip_count = skip_count + 1
+WHERE id = $1 AND user_id = $2
+RETURNING skip_count`
+
+const cardExistsSQL = `SELECT EXISTS (SELECT 1 FROM cards WHERE id = $1 AND user_id = $2)`
+
+// Orphaned cards point at an entry row that no longer exists. The FK cascade
+// normally prevents this; these queries repair databases where it was missing.
+var findOrphanedSQL = `
+SELECT ` + cardColumns + `
+FROM cards c
+LEFT JOIN entries e ON e.id = c.entry_id
+WHERE e.id IS NULL
+ORDER BY c.user_id, c.created_at`
+
+const deleteOrphanedSQL = `
+DELETE FROM cards c
+WHERE c.id = ANY($1::uuid[])
+  AND NOT EXISTS (SELECT 1 FROM entries e WHERE e.id = c.entry_id)`
+
+// Same as the sqlc CreateCard query but with a preset starting memory state.
+const createWithParamsSQL = `
+INSERT INTO cards AS c (id, user_id, entry_id, state, stability, difficulty, due, created_at, updated_at)
+VALUES ($1, $2, $3, 'NEW', $4, $5, now(), $6, $6)
+RETURNING ` + cardColumns
+
+const batchCreateSQL = `
+INSERT INTO cards AS c (id, user_id, entry_id, state, stability, difficulty, due, created_at, updated_at)
+SELECT gen_random_uuid(), $1, n.entry_id, 'NEW', n.stability, n.difficulty, now(), $5, $5
+FROM unnest($2::uuid[], $3::float8[], $4::float8[]) AS n(entry_id, stability, difficulty)
+ON CONFLICT (user_id, entry_id) DO NOTHING
+RETURNING ` + cardColumns
+
 // ---------------------------------------------------------------------------
 // Read operations
 // ---------------------------------------------------------------------------
@@ -141,11 +255,13 @@ func (r *Repo) GetByIDForUpdate(ctx context.Context, userID, cardID uuid.UUID) (
 		elapsedDays   int32
 		createdAt     time.Time
 		updatedAt     time.Time
+		suspended     bool
+		version       int32
 	)
 
 	if err := row.Scan(&id, &uid, &entryID, &state, &step, &stability, &difficulty,
 		&due, &lastReview, &reps, &lapses, &scheduledDays, &elapsedDays,
-		&createdAt, &updatedAt); err != nil {
+		&createdAt, &updatedAt, &suspended, &version); err != nil {
 		return nil, mapError(err, "card", cardID)
 	}
 
@@ -157,6 +273,7 @@ func (r *Repo) GetByIDForUpdate(ctx context.Context, userID, cardID uuid.UUID) (
 		Reps: int(reps), Lapses: int(lapses),
 		ScheduledDays: int(scheduledDays), ElapsedDays: int(elapsedDays),
 		CreatedAt: createdAt, UpdatedAt: updatedAt,
+		Suspended: suspended, Version: int(version),
 	}
 	return &c, nil
 }
@@ -199,11 +316,21 @@ func (r *Repo) GetByEntryIDs(ctx context.Context, userID uuid.UUID, entryIDs []u
 	return cards, nil
 }
 
-// GetDueCards returns cards that are due for review.
-func (r *Repo) GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*domain.Card, error) {
+// GetDueCards returns cards that are due for review in the given order: most
+// overdue first ("due_date", also the fallback for unknown values), shuffled
+// ("random"), or by card creation time ("added").
+func (r *Repo) GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int, order domain.DueCardOrder) ([]*domain.Card, error) {
 	querier := postgres.QuerierFromCtx(ctx, r.pool)
 
-	rows, err := querier.Query(ctx, getDueCardsSQL, userID, now, limit)
+	query := getDueCardsSQL
+	switch order {
+	case domain.DueCardOrderRandom:
+		query = getDueCardsRandomSQL
+	case domain.DueCardOrderAdded:
+		query = getDueCardsAddedSQL
+	}
+
+	rows, err := querier.Query(ctx, query, userID, now, limit)
 	if err != nil {
 		return nil, fmt.Errorf("get due cards: %w", err)
 	}
@@ -217,11 +344,40 @@ func (r *Repo) GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time,
 	return cards, nil
 }
 
-// GetNewCards returns NEW cards ordered by creation time.
-func (r *Repo) GetNewCards(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Card, error) {
+// GetLearningDue returns LEARNING and RELEARNING cards due at or before the
+// given time, soonest first.
+func (r *Repo) GetLearningDue(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*domain.Card, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	rows, err := querier.Query(ctx, getLearningDueSQL, userID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get learning due cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards, err := scanCardPointers(rows)
+	if err != nil {
+		return nil, fmt.Errorf("get learning due cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// GetNewCards returns NEW cards in the given order: by creation time
+// ("added", also the fallback for unknown values), shuffled ("random"), or by
+// the ref entry's frequency rank ("frequency").
+func (r *Repo) GetNewCards(ctx context.Context, userID uuid.UUID, limit int, order domain.NewCardOrder) ([]*domain.Card, error) {
 	querier := postgres.QuerierFromCtx(ctx, r.pool)
 
-	rows, err := querier.Query(ctx, getNewCardsSQL, userID, limit)
+	query := getNewCardsSQL
+	switch order {
+	case domain.NewCardOrderRandom:
+		query = getNewCardsRandomSQL
+	case domain.NewCardOrderFrequency:
+		query = getNewCardsFrequencySQL
+	}
+
+	rows, err := querier.Query(ctx, query, userID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("get new cards: %w", err)
 	}
@@ -261,7 +417,7 @@ func (r *Repo) CountNew(ctx context.Context, userID uuid.UUID) (int, error) {
 
 // CountByStatus returns card counts grouped by state.
 func (r *Repo) CountByStatus(ctx context.Context, userID uuid.UUID) (domain.CardStatusCounts, error) {
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	rows, err := querier.Query(ctx, countByStatusSQL, userID)
 	if err != nil {
@@ -297,7 +453,7 @@ func (r *Repo) CountByStatus(ctx context.Context, userID uuid.UUID) (domain.Card
 
 // CountOverdue returns the count of cards that were due before dayStart (overdue by at least one full day).
 func (r *Repo) CountOverdue(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error) {
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	var count int
 	if err := querier.QueryRow(ctx, countOverdueSQL, userID, dayStart).Scan(&count); err != nil {
@@ -336,6 +492,81 @@ func (r *Repo) ExistsByEntryIDs(ctx context.Context, userID uuid.UUID, entryIDs
 	return result, nil
 }
 
+// StudyableByIDs returns the subset of the given card IDs that can still be
+// studied: the card exists for the user and its entry is not soft-deleted.
+func (r *Repo) StudyableByIDs(ctx context.Context, userID uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if len(cardIDs) == 0 {
+		return map[uuid.UUID]bool{}, nil
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	rows, err := querier.Query(ctx, studyableByIDsSQL, userID, cardIDs)
+	if err != nil {
+		return nil, fmt.Errorf("studyable by ids: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]bool, len(cardIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan card id: %w", err)
+		}
+		result[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate card ids: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetStabilityHistogram groups the user's non-NEW cards by stability. bounds
+// must be ascending; bucket i covers [bounds[i-1], bounds[i]), with open-ended
+// buckets below the first and above the last bound. Empty buckets are included.
+func (r *Repo) GetStabilityHistogram(ctx context.Context, userID uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error) {
+	buckets := make([]domain.StabilityBucket, len(bounds)+1)
+	for i := range buckets {
+		if i > 0 {
+			buckets[i].Min = bounds[i-1]
+		}
+		if i < len(bounds) {
+			upper := bounds[i]
+			buckets[i].Max = &upper
+		}
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	rows, err := querier.Query(ctx, stabilityHistogramSQL, userID, bounds)
+	if err != nil {
+		return nil, fmt.Errorf("stability histogram: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			idx   int
+			count int
+			sum   float64
+		)
+		if err := rows.Scan(&idx, &count, &sum); err != nil {
+			return nil, fmt.Errorf("scan stability bucket: %w", err)
+		}
+		if idx < 0 || idx >= len(buckets) {
+			continue
+		}
+		buckets[idx].Count = count
+		buckets[idx].StabilitySum = sum
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stability buckets: %w", err)
+	}
+
+	return buckets, nil
+}
+
 // ---------------------------------------------------------------------------
 // Write operations
 // ---------------------------------------------------------------------------
@@ -362,7 +593,65 @@ func (r *Repo) Create(ctx context.Context, userID, entryID uuid.UUID) (*domain.C
 	return &c, nil
 }
 
-// UpdateSRS updates all FSRS fields on a card.
+// CreateWithParams creates a NEW card whose stability and difficulty start at
+// the given values instead of zero. Zeros behave exactly like Create.
+func (r *Repo) CreateWithParams(ctx context.Context, userID, entryID uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	id := uuid.New()
+
+	rows, err := querier.Query(ctx, createWithParamsSQL, id, userID, entryID, stability, difficulty, now)
+	if err != nil {
+		return nil, mapError(err, "card", id)
+	}
+	defer rows.Close()
+
+	cards, err := scanCardPointers(rows)
+	if err != nil {
+		return nil, mapError(err, "card", id)
+	}
+	if len(cards) == 0 {
+		return nil, fmt.Errorf("card %s: %w", id, domain.ErrNotFound)
+	}
+
+	return cards[0], nil
+}
+
+// BatchCreate inserts NEW cards for all given entries in a single statement.
+// stability[i] and difficulty[i] are the starting memory state of the card
+// for entryIDs[i], as in CreateWithParams. Entries that already have a card
+// are silently skipped; only the cards that were actually inserted are
+// returned.
+func (r *Repo) BatchCreate(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+	if len(entryIDs) == 0 {
+		return []*domain.Card{}, nil
+	}
+	if len(stability) != len(entryIDs) || len(difficulty) != len(entryIDs) {
+		return nil, fmt.Errorf("batch create cards: %d entries, %d stabilities, %d difficulties", len(entryIDs), len(stability), len(difficulty))
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	rows, err := querier.Query(ctx, batchCreateSQL, userID, entryIDs, stability, difficulty, now)
+	if err != nil {
+		return nil, fmt.Errorf("batch create cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards, err := scanCardPointers(rows)
+	if err != nil {
+		return nil, mapError(err, "card", uuid.Nil)
+	}
+
+	return cards, nil
+}
+
+// UpdateSRS updates all FSRS fields on a card and bumps its version. The
+// update only applies while the card is still at params.Version; returns
+// domain.ErrConflict if it was changed concurrently and domain.ErrNotFound if
+// it does not exist or belongs to another user.
 func (r *Repo) UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 
@@ -379,10 +668,11 @@ func (r *Repo) UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params d
 		Lapses:        int32(params.Lapses),
 		ScheduledDays: int32(params.ScheduledDays),
 		ElapsedDays:   int32(params.ElapsedDays),
+		Version:       int32(params.Version),
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("card %s: %w", cardID, domain.ErrNotFound)
+			return nil, r.versionMismatch(ctx, userID, cardID)
 		}
 		return nil, mapError(err, "card", cardID)
 	}
@@ -391,6 +681,52 @@ func (r *Repo) UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params d
 	return &c, nil
 }
 
+// versionMismatch explains a conditional update that matched no row: the card
+// either does not exist or is no longer at the expected version.
+func (r *Repo) versionMismatch(ctx context.Context, userID, cardID uuid.UUID) error {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var exists bool
+	if err := querier.QueryRow(ctx, cardExistsSQL, cardID, userID).Scan(&exists); err != nil {
+		return mapError(err, "card", cardID)
+	}
+	if !exists {
+		return fmt.Errorf("card %s: %w", cardID, domain.ErrNotFound)
+	}
+	return fmt.Errorf("card %s was modified concurrently: %w", cardID, domain.ErrConflict)
+}
+
+// IncrementSkipCount bumps the card's skip counter and returns the new value.
+// Returns domain.ErrNotFound if the card does not exist or belongs to another user.
+func (r *Repo) IncrementSkipCount(ctx context.Context, userID, cardID uuid.UUID) (int, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var n int
+	if err := querier.QueryRow(ctx, incrementSkipCountSQL, cardID, userID).Scan(&n); err != nil {
+		return 0, mapError(err, "card", cardID)
+	}
+
+	return n, nil
+}
+
+// SetSuspended sets the card's suspended flag and returns the updated card.
+// Returns domain.ErrNotFound if the card does not exist or belongs to another user.
+func (r *Repo) SetSuspended(ctx context.Context, userID, cardID uuid.UUID, suspended bool) (*domain.Card, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	row, err := q.SetCardSuspended(ctx, sqlc.SetCardSuspendedParams{
+		Suspended: suspended,
+		ID:        cardID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return nil, mapError(err, "card", cardID)
+	}
+
+	c := toDomainCard(fromSetSuspendedRow(row))
+	return &c, nil
+}
+
 // Delete removes a card by ID.
 func (r *Repo) Delete(ctx context.Context, userID, cardID uuid.UUID) error {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
@@ -410,6 +746,42 @@ func (r *Repo) Delete(ctx context.Context, userID, cardID uuid.UUID) error {
 	return nil
 }
 
+// FindOrphaned returns cards of all users whose entry row no longer exists.
+// It is a maintenance query and is not scoped to a user.
+func (r *Repo) FindOrphaned(ctx context.Context) ([]domain.Card, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	rows, err := querier.Query(ctx, findOrphanedSQL)
+	if err != nil {
+		return nil, fmt.Errorf("find orphaned cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards, err := scanCards(rows)
+	if err != nil {
+		return nil, fmt.Errorf("find orphaned cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+// DeleteOrphaned deletes the given cards if they are still orphaned and
+// returns the number of rows deleted. Cards whose entry exists are kept.
+func (r *Repo) DeleteOrphaned(ctx context.Context, cardIDs []uuid.UUID) (int64, error) {
+	if len(cardIDs) == 0 {
+		return 0, nil
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	tag, err := querier.Exec(ctx, deleteOrphanedSQL, cardIDs)
+	if err != nil {
+		return 0, fmt.Errorf("delete orphaned cards: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
 // ---------------------------------------------------------------------------
 // Row scanning helpers
 // ---------------------------------------------------------------------------
@@ -471,11 +843,13 @@ func scanCardFromRows(rows pgx.Rows) (domain.Card, error) {
 		elapsedDays   int32
 		createdAt     time.Time
 		updatedAt     time.Time
+		suspended     bool
+		version       int32
 	)
 
 	if err := rows.Scan(&id, &userID, &entryID, &state, &step, &stability, &difficulty,
 		&due, &lastReview, &reps, &lapses, &scheduledDays, &elapsedDays,
-		&createdAt, &updatedAt); err != nil {
+		&createdAt, &updatedAt, &suspended, &version); err != nil {
 		return domain.Card{}, err
 	}
 
@@ -495,6 +869,8 @@ func scanCardFromRows(rows pgx.Rows) (domain.Card, error) {
 		ElapsedDays:   int(elapsedDays),
 		CreatedAt:     createdAt,
 		UpdatedAt:     updatedAt,
+		Suspended:     suspended,
+		Version:       int(version),
 	}, nil
 }
 
@@ -509,6 +885,7 @@ func fromGetByIDRow(r sqlc.GetCardByIDRow) sqlc.Card {
 		Due: r.Due, LastReview: r.LastReview, Reps: r.Reps, Lapses: r.Lapses,
 		ScheduledDays: r.ScheduledDays, ElapsedDays: r.ElapsedDays,
 		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		Suspended: r.Suspended, Version: r.Version,
 	}
 }
 
@@ -519,6 +896,7 @@ func fromGetByEntryIDRow(r sqlc.GetCardByEntryIDRow) sqlc.Card {
 		Due: r.Due, LastReview: r.LastReview, Reps: r.Reps, Lapses: r.Lapses,
 		ScheduledDays: r.ScheduledDays, ElapsedDays: r.ElapsedDays,
 		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		Suspended: r.Suspended, Version: r.Version,
 	}
 }
 
@@ -529,6 +907,18 @@ func fromUpdateSRSRow(r sqlc.UpdateCardSRSRow) sqlc.Card {
 		Due: r.Due, LastReview: r.LastReview, Reps: r.Reps, Lapses: r.Lapses,
 		ScheduledDays: r.ScheduledDays, ElapsedDays: r.ElapsedDays,
 		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		Suspended: r.Suspended, Version: r.Version,
+	}
+}
+
+func fromSetSuspendedRow(r sqlc.SetCardSuspendedRow) sqlc.Card {
+	return sqlc.Card{
+		ID: r.ID, UserID: r.UserID, EntryID: r.EntryID,
+		State: r.State, Step: r.Step, Stability: r.Stability, Difficulty: r.Difficulty,
+		Due: r.Due, LastReview: r.LastReview, Reps: r.Reps, Lapses: r.Lapses,
+		ScheduledDays: r.ScheduledDays, ElapsedDays: r.ElapsedDays,
+		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		Suspended: r.Suspended, Version: r.Version,
 	}
 }
 
@@ -539,6 +929,7 @@ func fromCreateRow(r sqlc.CreateCardRow) sqlc.Card {
 		Due: r.Due, LastReview: r.LastReview, Reps: r.Reps, Lapses: r.Lapses,
 		ScheduledDays: r.ScheduledDays, ElapsedDays: r.ElapsedDays,
 		CreatedAt: r.CreatedAt, UpdatedAt: r.UpdatedAt,
+		Suspended: r.Suspended, Version: r.Version,
 	}
 }
 
@@ -559,6 +950,8 @@ func toDomainCard(row sqlc.Card) domain.Card {
 		ElapsedDays:   int(row.ElapsedDays),
 		CreatedAt:     row.CreatedAt,
 		UpdatedAt:     row.UpdatedAt,
+		Suspended:     row.Suspended,
+		Version:       int(row.Version),
 	}
 }
 
@@ -167,7 +167,7 @@ func TestRepo_GetDueCards_OverdueFirst(t *testing.T) {
 		t.Fatalf("update card2: %v", err)
 	}
 
-	cards, err := repo.GetDueCards(ctx, user.ID, now, 10)
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards: unexpected error: %v", err)
 	}
@@ -185,6 +185,42 @@ func TestRepo_GetDueCards_OverdueFirst(t *testing.T) {
 	}
 }
 
+func TestRepo_GetLearningDue_OnlyShortStepStates(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	now := time.Now().UTC()
+
+	seed := func(state string, due time.Time) uuid.UUID {
+		t.Helper()
+		ref := testhelper.SeedRefEntry(t, pool, "lrn-"+uuid.New().String()[:8])
+		e := testhelper.SeedEntryWithCard(t, pool, user.ID, ref.ID)
+		if _, err := pool.Exec(ctx, `UPDATE cards SET state = $1, due = $2 WHERE id = $3`, state, due, e.Card.ID); err != nil {
+			t.Fatalf("update card: %v", err)
+		}
+		return e.Card.ID
+	}
+
+	relearning := seed("RELEARNING", now.Add(10*time.Minute))
+	learning := seed("LEARNING", now.Add(-time.Minute))
+	seed("REVIEW", now.Add(-time.Hour))
+	seed("LEARNING", now.Add(time.Hour))
+
+	cards, err := repo.GetLearningDue(ctx, user.ID, now.Add(15*time.Minute), 10)
+	if err != nil {
+		t.Fatalf("GetLearningDue: unexpected error: %v", err)
+	}
+
+	if len(cards) != 2 {
+		t.Fatalf("expected 2 cards, got %d", len(cards))
+	}
+	if cards[0].ID != learning || cards[1].ID != relearning {
+		t.Errorf("expected [learning, relearning] ordered by due, got [%s, %s]", cards[0].ID, cards[1].ID)
+	}
+}
+
 func TestRepo_GetDueCards_ExcludesSoftDeleted(t *testing.T) {
 	t.Parallel()
 	repo, pool := newRepo(t)
@@ -208,7 +244,7 @@ func TestRepo_GetDueCards_ExcludesSoftDeleted(t *testing.T) {
 		t.Fatalf("soft-delete entry: %v", err)
 	}
 
-	cards, err := repo.GetDueCards(ctx, user.ID, now, 10)
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards: unexpected error: %v", err)
 	}
@@ -232,7 +268,7 @@ func TestRepo_GetDueCards_ExcludesNew(t *testing.T) {
 	refEntry := testhelper.SeedRefEntry(t, pool, "new-"+uuid.New().String()[:8])
 	entry := testhelper.SeedEntryWithCard(t, pool, user.ID, refEntry.ID)
 
-	cards, err := repo.GetDueCards(ctx, user.ID, now, 10)
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards: unexpected error: %v", err)
 	}
@@ -263,7 +299,7 @@ func TestRepo_GetDueCards_RespectsLimit(t *testing.T) {
 		}
 	}
 
-	cards, err := repo.GetDueCards(ctx, user.ID, now, 2)
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 2, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards: unexpected error: %v", err)
 	}
@@ -273,6 +309,119 @@ func TestRepo_GetDueCards_RespectsLimit(t *testing.T) {
 	}
 }
 
+// seedDueCards creates n REVIEW cards in creation order, the later ones more
+// overdue, and returns their IDs in creation order.
+func seedDueCards(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID, n int, now time.Time) []uuid.UUID {
+	t.Helper()
+
+	ids := make([]uuid.UUID, 0, n)
+	for i := range n {
+		ref := testhelper.SeedRefEntry(t, pool, fmt.Sprintf("due-order-%d-%s", i, uuid.New().String()[:8]))
+		e := testhelper.SeedEntryWithCard(t, pool, userID, ref.ID)
+		due := now.Add(-time.Duration(i+1) * time.Hour)
+		if _, err := pool.Exec(context.Background(), `UPDATE cards SET state = 'REVIEW', due = $1 WHERE id = $2`, due, e.Card.ID); err != nil {
+			t.Fatalf("update card: %v", err)
+		}
+		ids = append(ids, e.Card.ID)
+		time.Sleep(2 * time.Millisecond) // ensure different created_at
+	}
+	return ids
+}
+
+func TestRepo_GetDueCards_OrderedByDueDate(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	now := time.Now().UTC()
+	ids := seedDueCards(t, pool, user.ID, 3, now)
+
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
+	if err != nil {
+		t.Fatalf("GetDueCards: unexpected error: %v", err)
+	}
+	if len(cards) != 3 {
+		t.Fatalf("expected 3 cards, got %d", len(cards))
+	}
+
+	// Most overdue first: the last created card.
+	for i, want := range []uuid.UUID{ids[2], ids[1], ids[0]} {
+		if cards[i].ID != want {
+			t.Errorf("cards[%d]: got %s, want %s", i, cards[i].ID, want)
+		}
+	}
+}
+
+func TestRepo_GetDueCards_OrderedByAdded(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	now := time.Now().UTC()
+	ids := seedDueCards(t, pool, user.ID, 3, now)
+
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderAdded)
+	if err != nil {
+		t.Fatalf("GetDueCards: unexpected error: %v", err)
+	}
+	if len(cards) != 3 {
+		t.Fatalf("expected 3 cards, got %d", len(cards))
+	}
+
+	// Oldest card first, regardless of due date.
+	for i, want := range ids {
+		if cards[i].ID != want {
+			t.Errorf("cards[%d]: got %s, want %s", i, cards[i].ID, want)
+		}
+	}
+}
+
+func TestRepo_GetDueCards_Random(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	now := time.Now().UTC()
+	ids := seedDueCards(t, pool, user.ID, 20, now)
+
+	want := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+
+	byDue, err := repo.GetDueCards(ctx, user.ID, now, 20, domain.DueCardOrderDueDate)
+	if err != nil {
+		t.Fatalf("GetDueCards due_date: %v", err)
+	}
+
+	// Same set of cards; with 20 cards, five shuffles all matching the
+	// due-date order is practically impossible.
+	shuffled := false
+	for attempt := 0; attempt < 5 && !shuffled; attempt++ {
+		cards, err := repo.GetDueCards(ctx, user.ID, now, 20, domain.DueCardOrderRandom)
+		if err != nil {
+			t.Fatalf("GetDueCards random: %v", err)
+		}
+		if len(cards) != len(want) {
+			t.Fatalf("GetDueCards random: got %d cards, want %d", len(cards), len(want))
+		}
+		for i, c := range cards {
+			if !want[c.ID] {
+				t.Fatalf("unexpected card %s", c.ID)
+			}
+			if c.ID != byDue[i].ID {
+				shuffled = true
+			}
+		}
+	}
+	if !shuffled {
+		t.Error("random order always matched due-date order")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // CountOverdue
 // ---------------------------------------------------------------------------
@@ -398,6 +547,103 @@ func TestRepo_CountNew(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// SetSuspended
+// ---------------------------------------------------------------------------
+
+func TestRepo_SetSuspended_ExcludesFromDueQueueAndCount(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	now := time.Now().UTC()
+	ids := seedDueCards(t, pool, user.ID, 2, now)
+
+	suspended, err := repo.SetSuspended(ctx, user.ID, ids[0], true)
+	if err != nil {
+		t.Fatalf("SetSuspended: unexpected error: %v", err)
+	}
+	if !suspended.Suspended {
+		t.Error("Suspended: got false, want true")
+	}
+
+	cards, err := repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
+	if err != nil {
+		t.Fatalf("GetDueCards: unexpected error: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ID != ids[1] {
+		t.Fatalf("due cards while suspended: got %d, want only %s", len(cards), ids[1])
+	}
+	if count, err := repo.CountDue(ctx, user.ID, now); err != nil || count != 1 {
+		t.Errorf("CountDue while suspended: got %d (err %v), want 1", count, err)
+	}
+
+	if _, err := repo.SetSuspended(ctx, user.ID, ids[0], false); err != nil {
+		t.Fatalf("SetSuspended(false): unexpected error: %v", err)
+	}
+
+	cards, err = repo.GetDueCards(ctx, user.ID, now, 10, domain.DueCardOrderDueDate)
+	if err != nil {
+		t.Fatalf("GetDueCards: unexpected error: %v", err)
+	}
+	if len(cards) != 2 {
+		t.Errorf("due cards after unsuspend: got %d, want 2", len(cards))
+	}
+	if count, err := repo.CountDue(ctx, user.ID, now); err != nil || count != 2 {
+		t.Errorf("CountDue after unsuspend: got %d (err %v), want 2", count, err)
+	}
+}
+
+func TestRepo_SetSuspended_ExcludesFromNewQueueAndCount(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	ref := testhelper.SeedRefEntry(t, pool, "suspend-new-"+uuid.New().String()[:8])
+	entry := testhelper.SeedEntryWithCard(t, pool, user.ID, ref.ID)
+
+	if _, err := repo.SetSuspended(ctx, user.ID, entry.Card.ID, true); err != nil {
+		t.Fatalf("SetSuspended: unexpected error: %v", err)
+	}
+
+	cards, err := repo.GetNewCards(ctx, user.ID, 10, domain.NewCardOrderAdded)
+	if err != nil {
+		t.Fatalf("GetNewCards: unexpected error: %v", err)
+	}
+	if len(cards) != 0 {
+		t.Errorf("new cards while suspended: got %d, want 0", len(cards))
+	}
+	if count, err := repo.CountNew(ctx, user.ID); err != nil || count != 0 {
+		t.Errorf("CountNew while suspended: got %d (err %v), want 0", count, err)
+	}
+
+	if _, err := repo.SetSuspended(ctx, user.ID, entry.Card.ID, false); err != nil {
+		t.Fatalf("SetSuspended(false): unexpected error: %v", err)
+	}
+
+	cards, err = repo.GetNewCards(ctx, user.ID, 10, domain.NewCardOrderAdded)
+	if err != nil {
+		t.Fatalf("GetNewCards: unexpected error: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ID != entry.Card.ID {
+		t.Errorf("new cards after unsuspend: got %d, want the unsuspended card", len(cards))
+	}
+	if count, err := repo.CountNew(ctx, user.ID); err != nil || count != 1 {
+		t.Errorf("CountNew after unsuspend: got %d (err %v), want 1", count, err)
+	}
+}
+
+func TestRepo_SetSuspended_NotFound(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+
+	user := testhelper.SeedUser(t, pool)
+	_, err := repo.SetSuspended(context.Background(), user.ID, uuid.New(), true)
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
+
 // ---------------------------------------------------------------------------
 // CountByStatus
 // ---------------------------------------------------------------------------
@@ -506,6 +752,53 @@ func TestRepo_UpdateSRS(t *testing.T) {
 	if !got.UpdatedAt.After(entry.Card.UpdatedAt) {
 		t.Errorf("expected UpdatedAt to be updated after SRS change")
 	}
+	if got.Version != entry.Card.Version+1 {
+		t.Errorf("Version: got %d, want %d", got.Version, entry.Card.Version+1)
+	}
+}
+
+func TestRepo_UpdateSRS_StaleVersion(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	refEntry := testhelper.SeedRefEntry(t, pool, "srs-version-"+uuid.New().String()[:8])
+	entry := testhelper.SeedEntryWithCard(t, pool, user.ID, refEntry.ID)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	params := domain.SRSUpdateParams{
+		State:      domain.CardStateReview,
+		Stability:  5.0,
+		Difficulty: 5.0,
+		Due:        now.Add(24 * time.Hour),
+		LastReview: &now,
+		Reps:       1,
+		Version:    entry.Card.Version,
+	}
+
+	// The first of two reviews computed from the same version wins.
+	if _, err := repo.UpdateSRS(ctx, user.ID, entry.Card.ID, params); err != nil {
+		t.Fatalf("first UpdateSRS: %v", err)
+	}
+
+	stale := params
+	stale.Reps = 2
+	_, err := repo.UpdateSRS(ctx, user.ID, entry.Card.ID, stale)
+	assertIsDomainError(t, err, domain.ErrConflict)
+
+	got, err := repo.GetByID(ctx, user.ID, entry.Card.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Reps != 1 || got.Version != entry.Card.Version+1 {
+		t.Errorf("card after stale update: reps %d, version %d; want the first update kept", got.Reps, got.Version)
+	}
+
+	// Another user's card is still reported as missing, not as a conflict.
+	other := testhelper.SeedUser(t, pool)
+	_, err = repo.UpdateSRS(ctx, other.ID, entry.Card.ID, params)
+	assertIsDomainError(t, err, domain.ErrNotFound)
 }
 
 // ---------------------------------------------------------------------------
@@ -629,7 +922,7 @@ func TestRepo_GetNewCards_OrderedByCreatedAt(t *testing.T) {
 		time.Sleep(2 * time.Millisecond) // ensure different created_at
 	}
 
-	cards, err := repo.GetNewCards(ctx, user.ID, 10)
+	cards, err := repo.GetNewCards(ctx, user.ID, 10, domain.NewCardOrderAdded)
 	if err != nil {
 		t.Fatalf("GetNewCards: %v", err)
 	}
@@ -647,6 +940,88 @@ func TestRepo_GetNewCards_OrderedByCreatedAt(t *testing.T) {
 	}
 }
 
+func TestRepo_GetNewCards_OrderedByFrequency(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+
+	// Created in order: rank 300, no rank, rank 5, rank 40.
+	ranks := []int{300, 0, 5, 40}
+	entryByRank := make(map[int]uuid.UUID)
+	for i, rank := range ranks {
+		ref := testhelper.SeedRefEntry(t, pool, fmt.Sprintf("freq-order-%d-%s", i, uuid.New().String()[:8]))
+		if rank > 0 {
+			if _, err := pool.Exec(ctx, `UPDATE ref_entries SET frequency_rank = $2 WHERE id = $1`, ref.ID, rank); err != nil {
+				t.Fatalf("set frequency_rank: %v", err)
+			}
+		}
+		entry := testhelper.SeedEntryWithCard(t, pool, user.ID, ref.ID)
+		entryByRank[rank] = entry.ID
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	cards, err := repo.GetNewCards(ctx, user.ID, 10, domain.NewCardOrderFrequency)
+	if err != nil {
+		t.Fatalf("GetNewCards: %v", err)
+	}
+	if len(cards) != 4 {
+		t.Fatalf("GetNewCards: got %d cards, want 4", len(cards))
+	}
+
+	// Most frequent first; entries without a rank last.
+	for i, rank := range []int{5, 40, 300, 0} {
+		if cards[i].EntryID != entryByRank[rank] {
+			t.Errorf("cards[%d]: got entry %s, want entry with rank %d", i, cards[i].EntryID, rank)
+		}
+	}
+}
+
+func TestRepo_GetNewCards_Random(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+
+	want := make(map[uuid.UUID]bool)
+	for i := 0; i < 20; i++ {
+		ref := testhelper.SeedRefEntry(t, pool, fmt.Sprintf("rand-order-%d-%s", i, uuid.New().String()[:8]))
+		entry := testhelper.SeedEntryWithCard(t, pool, user.ID, ref.ID)
+		want[entry.ID] = true
+	}
+
+	added, err := repo.GetNewCards(ctx, user.ID, 20, domain.NewCardOrderAdded)
+	if err != nil {
+		t.Fatalf("GetNewCards added: %v", err)
+	}
+
+	// Same set of cards; with 20 cards, five shuffles all matching the
+	// creation order is practically impossible.
+	shuffled := false
+	for attempt := 0; attempt < 5 && !shuffled; attempt++ {
+		cards, err := repo.GetNewCards(ctx, user.ID, 20, domain.NewCardOrderRandom)
+		if err != nil {
+			t.Fatalf("GetNewCards random: %v", err)
+		}
+		if len(cards) != len(want) {
+			t.Fatalf("GetNewCards random: got %d cards, want %d", len(cards), len(want))
+		}
+		for i, c := range cards {
+			if !want[c.EntryID] {
+				t.Fatalf("unexpected card for entry %s", c.EntryID)
+			}
+			if c.ID != added[i].ID {
+				shuffled = true
+			}
+		}
+	}
+	if !shuffled {
+		t.Error("random order always matched creation order")
+	}
+}
+
 func TestRepo_ExistsByEntryIDs_ReturnsCorrectMap(t *testing.T) {
 	t.Parallel()
 	repo, pool := newRepo(t)
@@ -714,7 +1089,7 @@ func TestRepo_GetDueCards_UserIsolation(t *testing.T) {
 	}
 
 	// User A should only see their card
-	cardsA, err := repo.GetDueCards(ctx, userA.ID, now, 10)
+	cardsA, err := repo.GetDueCards(ctx, userA.ID, now, 10, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards userA: %v", err)
 	}
@@ -725,7 +1100,7 @@ func TestRepo_GetDueCards_UserIsolation(t *testing.T) {
 	}
 
 	// User B should only see their card
-	cardsB, err := repo.GetDueCards(ctx, userB.ID, now, 10)
+	cardsB, err := repo.GetDueCards(ctx, userB.ID, now, 10, domain.DueCardOrderDueDate)
 	if err != nil {
 		t.Fatalf("GetDueCards userB: %v", err)
 	}
@@ -749,3 +1124,141 @@ func assertIsDomainError(t *testing.T, err error, target error) {
 		t.Fatalf("expected error wrapping %v, got: %v", target, err)
 	}
 }
+
+func TestRepo_BatchCreate_SkipsExisting(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	ref1 := testhelper.SeedRefEntry(t, pool, "batch1-"+uuid.New().String()[:8])
+	ref2 := testhelper.SeedRefEntry(t, pool, "batch2-"+uuid.New().String()[:8])
+	ref3 := testhelper.SeedRefEntry(t, pool, "batch3-"+uuid.New().String()[:8])
+
+	entry1 := testhelper.SeedEntryWithCard(t, pool, user.ID, ref1.ID)
+	entry2 := testhelper.SeedEntry(t, pool, user.ID, ref2.ID)
+	entry3 := testhelper.SeedEntry(t, pool, user.ID, ref3.ID)
+
+	cards, err := repo.BatchCreate(ctx, user.ID, []uuid.UUID{entry1.ID, entry2.ID, entry3.ID},
+		[]float64{0, 4.5, 0}, []float64{0, 3, 0})
+	if err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+
+	if len(cards) != 2 {
+		t.Fatalf("expected 2 created cards, got %d", len(cards))
+	}
+	for _, c := range cards {
+		if c.EntryID == entry1.ID {
+			t.Errorf("entry1 already had a card and should be skipped")
+		}
+		if c.State != domain.CardStateNew {
+			t.Errorf("expected state NEW, got %s", c.State)
+		}
+		wantStability, wantDifficulty := 0.0, 0.0
+		if c.EntryID == entry2.ID {
+			wantStability, wantDifficulty = 4.5, 3
+		}
+		if c.Stability != wantStability || c.Difficulty != wantDifficulty {
+			t.Errorf("entry %v: starting state (%v, %v), want (%v, %v)",
+				c.EntryID, c.Stability, c.Difficulty, wantStability, wantDifficulty)
+		}
+	}
+}
+
+func TestRepo_CreateWithParams(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	ref := testhelper.SeedRefEntry(t, pool, "preset-"+uuid.New().String()[:8])
+	entry := testhelper.SeedEntry(t, pool, user.ID, ref.ID)
+
+	card, err := repo.CreateWithParams(ctx, user.ID, entry.ID, 4.5, 3)
+	if err != nil {
+		t.Fatalf("CreateWithParams: %v", err)
+	}
+	if card.State != domain.CardStateNew {
+		t.Errorf("State: got %s, want NEW", card.State)
+	}
+	if card.Stability != 4.5 || card.Difficulty != 3 {
+		t.Errorf("memory state: got (%v, %v), want (4.5, 3)", card.Stability, card.Difficulty)
+	}
+
+	got, err := repo.GetByID(ctx, user.ID, card.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Stability != 4.5 || got.Difficulty != 3 {
+		t.Errorf("persisted memory state: got (%v, %v), want (4.5, 3)", got.Stability, got.Difficulty)
+	}
+
+	_, err = repo.CreateWithParams(ctx, user.ID, entry.ID, 0, 0)
+	if !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Errorf("duplicate: got %v, want ErrAlreadyExists", err)
+	}
+}
+
+func TestRepo_FindOrphaned_AndDeleteOrphaned(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	refKept := testhelper.SeedRefEntry(t, pool, "orphan-kept-"+uuid.New().String()[:8])
+	refGone := testhelper.SeedRefEntry(t, pool, "orphan-gone-"+uuid.New().String()[:8])
+	kept := testhelper.SeedEntry(t, pool, user.ID, refKept.ID)
+	gone := testhelper.SeedEntry(t, pool, user.ID, refGone.ID)
+
+	keptCard, err := repo.Create(ctx, user.ID, kept.ID)
+	if err != nil {
+		t.Fatalf("Create kept card: %v", err)
+	}
+	orphan, err := repo.Create(ctx, user.ID, gone.ID)
+	if err != nil {
+		t.Fatalf("Create orphan card: %v", err)
+	}
+
+	// Simulate a hard delete without the FK cascade.
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, "SET LOCAL session_replication_role = replica"); err != nil {
+		t.Fatalf("disable triggers: %v", err)
+	}
+	if _, err := tx.Exec(ctx, "DELETE FROM entries WHERE id = $1", gone.ID); err != nil {
+		t.Fatalf("delete entry: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	orphans, err := repo.FindOrphaned(ctx)
+	if err != nil {
+		t.Fatalf("FindOrphaned: %v", err)
+	}
+	found := map[uuid.UUID]bool{}
+	for _, c := range orphans {
+		found[c.ID] = true
+	}
+	if !found[orphan.ID] {
+		t.Errorf("orphan card %s not found", orphan.ID)
+	}
+	if found[keptCard.ID] {
+		t.Errorf("card %s with an existing entry reported as orphaned", keptCard.ID)
+	}
+
+	deleted, err := repo.DeleteOrphaned(ctx, []uuid.UUID{orphan.ID, keptCard.ID})
+	if err != nil {
+		t.Fatalf("DeleteOrphaned: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted %d, want 1", deleted)
+	}
+	if _, err := repo.GetByID(ctx, user.ID, keptCard.ID); err != nil {
+		t.Errorf("kept card should survive: %v", err)
+	}
+}
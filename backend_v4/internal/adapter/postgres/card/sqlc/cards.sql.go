@@ -17,7 +17,7 @@ INSERT INTO cards (id, user_id, entry_id, state, due, created_at, updated_at)
 VALUES ($1, $2, $3, 'NEW', now(), $4, $5)
 RETURNING id, user_id, entry_id, state, step, stability, difficulty,
           due, last_review, reps, lapses, scheduled_days, elapsed_days,
-          created_at, updated_at
+          created_at, updated_at, suspended, version
 `
 
 type CreateCardParams struct {
@@ -44,6 +44,8 @@ type CreateCardRow struct {
 	ElapsedDays   int32
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Suspended     bool
+	Version       int32
 }
 
 func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (CreateCardRow, error) {
@@ -71,6 +73,8 @@ func (q *Queries) CreateCard(ctx context.Context, arg CreateCardParams) (CreateC
 		&i.ElapsedDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Suspended,
+		&i.Version,
 	)
 	return i, err
 }
@@ -96,7 +100,7 @@ func (q *Queries) DeleteCard(ctx context.Context, arg DeleteCardParams) (int64,
 const getCardByEntryID = `-- name: GetCardByEntryID :one
 SELECT id, user_id, entry_id, state, step, stability, difficulty,
        due, last_review, reps, lapses, scheduled_days, elapsed_days,
-       created_at, updated_at
+       created_at, updated_at, suspended, version
 FROM cards
 WHERE entry_id = $1 AND user_id = $2
 `
@@ -122,6 +126,8 @@ type GetCardByEntryIDRow struct {
 	ElapsedDays   int32
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Suspended     bool
+	Version       int32
 }
 
 func (q *Queries) GetCardByEntryID(ctx context.Context, arg GetCardByEntryIDParams) (GetCardByEntryIDRow, error) {
@@ -143,6 +149,8 @@ func (q *Queries) GetCardByEntryID(ctx context.Context, arg GetCardByEntryIDPara
 		&i.ElapsedDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Suspended,
+		&i.Version,
 	)
 	return i, err
 }
@@ -151,7 +159,7 @@ const getCardByID = `-- name: GetCardByID :one
 
 SELECT id, user_id, entry_id, state, step, stability, difficulty,
        due, last_review, reps, lapses, scheduled_days, elapsed_days,
-       created_at, updated_at
+       created_at, updated_at, suspended, version
 FROM cards
 WHERE id = $1 AND user_id = $2
 `
@@ -177,6 +185,8 @@ type GetCardByIDRow struct {
 	ElapsedDays   int32
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Suspended     bool
+	Version       int32
 }
 
 // ---------------------------------------------------------------------------
@@ -201,6 +211,69 @@ func (q *Queries) GetCardByID(ctx context.Context, arg GetCardByIDParams) (GetCa
 		&i.ElapsedDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Suspended,
+		&i.Version,
+	)
+	return i, err
+}
+
+const setCardSuspended = `-- name: SetCardSuspended :one
+UPDATE cards
+SET suspended = $1,
+    updated_at = now()
+WHERE id = $2 AND user_id = $3
+RETURNING id, user_id, entry_id, state, step, stability, difficulty,
+          due, last_review, reps, lapses, scheduled_days, elapsed_days,
+          created_at, updated_at, suspended, version
+`
+
+type SetCardSuspendedParams struct {
+	Suspended bool
+	ID        uuid.UUID
+	UserID    uuid.UUID
+}
+
+type SetCardSuspendedRow struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	EntryID       uuid.UUID
+	State         CardState
+	Step          int32
+	Stability     float64
+	Difficulty    float64
+	Due           time.Time
+	LastReview    *time.Time
+	Reps          int32
+	Lapses        int32
+	ScheduledDays int32
+	ElapsedDays   int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Suspended     bool
+	Version       int32
+}
+
+func (q *Queries) SetCardSuspended(ctx context.Context, arg SetCardSuspendedParams) (SetCardSuspendedRow, error) {
+	row := q.db.QueryRow(ctx, setCardSuspended, arg.Suspended, arg.ID, arg.UserID)
+	var i SetCardSuspendedRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EntryID,
+		&i.State,
+		&i.Step,
+		&i.Stability,
+		&i.Difficulty,
+		&i.Due,
+		&i.LastReview,
+		&i.Reps,
+		&i.Lapses,
+		&i.ScheduledDays,
+		&i.ElapsedDays,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Suspended,
+		&i.Version,
 	)
 	return i, err
 }
@@ -217,11 +290,12 @@ SET state = $1,
     lapses = $8,
     scheduled_days = $9,
     elapsed_days = $10,
+    version = version + 1,
     updated_at = now()
-WHERE id = $11 AND user_id = $12
+WHERE id = $11 AND user_id = $12 AND version = $13
 RETURNING id, user_id, entry_id, state, step, stability, difficulty,
           due, last_review, reps, lapses, scheduled_days, elapsed_days,
-          created_at, updated_at
+          created_at, updated_at, suspended, version
 `
 
 type UpdateCardSRSParams struct {
@@ -237,6 +311,7 @@ type UpdateCardSRSParams struct {
 	ElapsedDays   int32
 	ID            uuid.UUID
 	UserID        uuid.UUID
+	Version       int32
 }
 
 type UpdateCardSRSRow struct {
@@ -255,6 +330,8 @@ type UpdateCardSRSRow struct {
 	ElapsedDays   int32
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Suspended     bool
+	Version       int32
 }
 
 func (q *Queries) UpdateCardSRS(ctx context.Context, arg UpdateCardSRSParams) (UpdateCardSRSRow, error) {
@@ -271,6 +348,7 @@ func (q *Queries) UpdateCardSRS(ctx context.Context, arg UpdateCardSRSParams) (U
 		arg.ElapsedDays,
 		arg.ID,
 		arg.UserID,
+		arg.Version,
 	)
 	var i UpdateCardSRSRow
 	err := row.Scan(
@@ -289,6 +367,8 @@ func (q *Queries) UpdateCardSRS(ctx context.Context, arg UpdateCardSRSParams) (U
 		&i.ElapsedDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Suspended,
+		&i.Version,
 	)
 	return i, err
 }
@@ -279,6 +279,8 @@ type Card struct {
 	Lapses        int32
 	ScheduledDays int32
 	ElapsedDays   int32
+	Suspended     bool
+	Version       int32
 }
 
 type EnrichmentQueue struct {
@@ -290,6 +292,8 @@ type EnrichmentQueue struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int32
+	NextRetryAt  *time.Time
 }
 
 type Entry struct {
@@ -2,29 +2,52 @@
 INSERT INTO enrichment_queue (ref_entry_id, priority)
 VALUES ($1, $2)
 ON CONFLICT (ref_entry_id)
-DO UPDATE SET priority = enrichment_queue.priority + 1,
+DO UPDATE SET priority = GREATEST(enrichment_queue.priority + 1, EXCLUDED.priority),
               requested_at = now()
 WHERE enrichment_queue.status IN ('pending', 'failed');
 
 -- name: ClaimBatch :many
 UPDATE enrichment_queue
-SET status = 'processing'
+SET status = 'processing', claimed_at = now()
 WHERE id IN (
     SELECT id FROM enrichment_queue
     WHERE status = 'pending'
-    ORDER BY priority DESC, requested_at
+      AND (next_retry_at IS NULL OR next_retry_at <= now())
+    ORDER BY priority DESC, created_at
     LIMIT $1
     FOR UPDATE SKIP LOCKED
 )
-RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at;
+RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at;
+
+-- name: ClaimBatchFIFO :many
+UPDATE enrichment_queue
+SET status = 'processing', claimed_at = now()
+WHERE id IN (
+    SELECT id FROM enrichment_queue
+    WHERE status = 'pending'
+      AND (next_retry_at IS NULL OR next_retry_at <= now())
+    ORDER BY created_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at;
 
 -- name: MarkDone :exec
 UPDATE enrichment_queue SET status = 'done', processed_at = now(), error_message = NULL
 WHERE ref_entry_id = $1;
 
 -- name: MarkFailed :exec
-UPDATE enrichment_queue SET status = 'failed', processed_at = now(), error_message = $2
-WHERE ref_entry_id = $1;
+-- Reschedules with exponential backoff (base_delay_secs * 2^attempts) until
+-- attempts reaches max_attempts; then the item stays failed.
+UPDATE enrichment_queue
+SET attempts      = attempts + 1,
+    processed_at  = now(),
+    error_message = sqlc.arg(error_message),
+    status        = CASE WHEN attempts + 1 < sqlc.arg(max_attempts)::int THEN 'pending' ELSE 'failed' END,
+    next_retry_at = CASE WHEN attempts + 1 < sqlc.arg(max_attempts)::int
+                         THEN now() + make_interval(secs => sqlc.arg(base_delay_secs)::float8 * power(2, attempts))
+                         ELSE NULL END
+WHERE ref_entry_id = sqlc.arg(ref_entry_id);
 
 -- name: GetStats :one
 SELECT
@@ -36,7 +59,7 @@ SELECT
 FROM enrichment_queue;
 
 -- name: List :many
-SELECT id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at
+SELECT id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at
 FROM enrichment_queue
 WHERE ($1::text = '' OR status = $1)
 ORDER BY priority DESC, requested_at
@@ -44,10 +67,23 @@ LIMIT $2 OFFSET $3;
 
 -- name: RetryAllFailed :execrows
 UPDATE enrichment_queue
-SET status = 'pending', error_message = NULL, processed_at = NULL
+SET status = 'pending', error_message = NULL, processed_at = NULL,
+    attempts = 0, next_retry_at = NULL
 WHERE status = 'failed';
 
+-- name: ReclaimStale :execrows
+-- Items claimed before claimed_at existed have no timestamp and count as stale.
+UPDATE enrichment_queue
+SET status = 'pending', claimed_at = NULL
+WHERE status = 'processing'
+  AND (claimed_at IS NULL OR claimed_at < now() - make_interval(secs => sqlc.arg(older_than_secs)::float8));
+
 -- name: ResetProcessing :execrows
 UPDATE enrichment_queue
-SET status = 'pending'
+SET status = 'pending', claimed_at = NULL
 WHERE status = 'processing';
+
+-- name: Release :execrows
+UPDATE enrichment_queue
+SET status = 'pending', claimed_at = NULL
+WHERE status = 'processing' AND ref_entry_id = ANY($1::uuid[]);
@@ -4,6 +4,7 @@ package enrichment
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -24,7 +25,8 @@ func New(pool *pgxpool.Pool) *Repo {
 	return &Repo{pool: pool}
 }
 
-// Enqueue adds a ref entry to the enrichment queue (idempotent, bumps priority on re-queue).
+// Enqueue adds a ref entry to the enrichment queue. Re-queuing a pending or
+// failed entry bumps its priority by one, or raises it to priority if higher.
 func (r *Repo) Enqueue(ctx context.Context, refEntryID uuid.UUID, priority int) error {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 	err := q.Enqueue(ctx, sqlc.EnqueueParams{
@@ -37,10 +39,19 @@ func (r *Repo) Enqueue(ctx context.Context, refEntryID uuid.UUID, priority int)
 	return nil
 }
 
-// ClaimBatch claims up to limit pending items for processing.
-func (r *Repo) ClaimBatch(ctx context.Context, limit int) ([]domain.EnrichmentQueueItem, error) {
+// ClaimBatch claims up to limit pending items for processing in the given order.
+func (r *Repo) ClaimBatch(ctx context.Context, limit int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
-	rows, err := q.ClaimBatch(ctx, int32(limit))
+
+	var (
+		rows []sqlc.EnrichmentQueue
+		err  error
+	)
+	if order == domain.EnrichmentClaimOrderFIFO {
+		rows, err = q.ClaimBatchFIFO(ctx, int32(limit))
+	} else {
+		rows, err = q.ClaimBatch(ctx, int32(limit))
+	}
 	if err != nil {
 		return nil, fmt.Errorf("enrichment.ClaimBatch: %w", err)
 	}
@@ -56,12 +67,16 @@ func (r *Repo) MarkDone(ctx context.Context, refEntryID uuid.UUID) error {
 	return nil
 }
 
-// MarkFailed marks an item as failed with error message.
-func (r *Repo) MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error {
+// MarkFailed records a failed attempt. While attempts stay below maxAttempts
+// the item goes back to pending with next_retry_at = now + baseDelay * 2^(attempts-1);
+// the attempt that reaches the cap leaves it failed.
+func (r *Repo) MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string, maxAttempts int, baseDelay time.Duration) error {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 	if err := q.MarkFailed(ctx, sqlc.MarkFailedParams{
-		RefEntryID:   refEntryID,
-		ErrorMessage: pgtype.Text{String: errMsg, Valid: true},
+		RefEntryID:    refEntryID,
+		ErrorMessage:  pgtype.Text{String: errMsg, Valid: true},
+		MaxAttempts:   int32(maxAttempts),
+		BaseDelaySecs: baseDelay.Seconds(),
 	}); err != nil {
 		return fmt.Errorf("enrichment.MarkFailed: %w", err)
 	}
@@ -98,7 +113,7 @@ func (r *Repo) List(ctx context.Context, status string, limit, offset int) ([]do
 	return toDomainItems(rows), nil
 }
 
-// RetryAllFailed resets all failed items to pending.
+// RetryAllFailed resets all failed items to pending with a fresh attempt budget.
 func (r *Repo) RetryAllFailed(ctx context.Context) (int, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 	n, err := q.RetryAllFailed(ctx)
@@ -108,6 +123,17 @@ func (r *Repo) RetryAllFailed(ctx context.Context) (int, error) {
 	return int(n), nil
 }
 
+// ReclaimStale returns processing items claimed more than olderThan ago to
+// pending, e.g. after an enrich run crashed without releasing them.
+func (r *Repo) ReclaimStale(ctx context.Context, olderThan time.Duration) (int, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+	n, err := q.ReclaimStale(ctx, olderThan.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("enrichment.ReclaimStale: %w", err)
+	}
+	return int(n), nil
+}
+
 // ResetProcessing resets all processing items back to pending (stuck items).
 func (r *Repo) ResetProcessing(ctx context.Context) (int, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
@@ -118,6 +144,53 @@ func (r *Repo) ResetProcessing(ctx context.Context) (int, error) {
 	return int(n), nil
 }
 
+// Release returns the given processing items to pending without counting an
+// attempt. Items in any other status are left untouched.
+func (r *Repo) Release(ctx context.Context, refEntryIDs []uuid.UUID) (int, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+	n, err := q.Release(ctx, refEntryIDs)
+	if err != nil {
+		return 0, fmt.Errorf("enrichment.Release: %w", err)
+	}
+	return int(n), nil
+}
+
+// enqueueMissingSQL queues ref entries that were never enriched by the LLM
+// and have no example or no translation, skipping entries already in the
+// queue in any status. Entries without examples go first, then by frequency.
+const enqueueMissingSQL = `
+INSERT INTO enrichment_queue (ref_entry_id)
+SELECT e.id
+FROM ref_entries e
+WHERE NOT EXISTS (SELECT 1 FROM enrichment_queue q WHERE q.ref_entry_id = e.id)
+  AND NOT EXISTS (
+      SELECT 1 FROM ref_entry_source_coverage c
+      WHERE c.ref_entry_id = e.id AND c.source_slug = 'llm')
+  AND (NOT EXISTS (
+          SELECT 1 FROM ref_senses s JOIN ref_examples x ON x.ref_sense_id = s.id
+          WHERE s.ref_entry_id = e.id)
+       OR NOT EXISTS (
+          SELECT 1 FROM ref_senses s JOIN ref_translations t ON t.ref_sense_id = s.id
+          WHERE s.ref_entry_id = e.id))
+ORDER BY EXISTS (
+             SELECT 1 FROM ref_senses s JOIN ref_examples x ON x.ref_sense_id = s.id
+             WHERE s.ref_entry_id = e.id),
+         e.frequency_rank NULLS LAST, e.id
+LIMIT $1
+ON CONFLICT (ref_entry_id) DO NOTHING`
+
+// EnqueueMissing queues up to limit under-enriched ref entries: entries with
+// no LLM enrichment that lack examples or translations and are not queued
+// yet. Returns the number of entries queued.
+func (r *Repo) EnqueueMissing(ctx context.Context, limit int) (int, error) {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	tag, err := q.Exec(ctx, enqueueMissingSQL, limit)
+	if err != nil {
+		return 0, fmt.Errorf("enrichment.EnqueueMissing: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
 // toDomainItems converts sqlc rows to domain items.
 func toDomainItems(rows []sqlc.EnrichmentQueue) []domain.EnrichmentQueueItem {
 	items := make([]domain.EnrichmentQueueItem, len(rows))
@@ -131,6 +204,9 @@ func toDomainItems(rows []sqlc.EnrichmentQueue) []domain.EnrichmentQueueItem {
 			RequestedAt: row.RequestedAt,
 			ProcessedAt: row.ProcessedAt,
 			CreatedAt:   row.CreatedAt,
+			Attempts:    int(row.Attempts),
+			NextRetryAt: row.NextRetryAt,
+			ClaimedAt:   row.ClaimedAt,
 		}
 	}
 	return items
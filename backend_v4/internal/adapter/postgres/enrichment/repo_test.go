@@ -0,0 +1,356 @@
+package enrichment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/enrichment"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/testhelper"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+func TestRepo_ClaimBatch_PriorityBeforeOlder(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	older := testhelper.SeedRefEntry(t, pool, "older-"+time.Now().Format("150405.000000"))
+	urgent := testhelper.SeedRefEntry(t, pool, "urgent-"+time.Now().Format("150405.000000"))
+
+	if err := repo.Enqueue(ctx, older.ID, 0); err != nil {
+		t.Fatalf("Enqueue older: %v", err)
+	}
+	// Make sure created_at differs so FIFO order is unambiguous.
+	if _, err := pool.Exec(ctx,
+		`UPDATE enrichment_queue SET created_at = created_at - interval '1 hour' WHERE ref_entry_id = $1`,
+		older.ID); err != nil {
+		t.Fatalf("backdate older: %v", err)
+	}
+	if err := repo.Enqueue(ctx, urgent.ID, 10); err != nil {
+		t.Fatalf("Enqueue urgent: %v", err)
+	}
+
+	claimed, err := repo.ClaimBatch(ctx, 1, domain.EnrichmentClaimOrderPriority)
+	if err != nil {
+		t.Fatalf("ClaimBatch priority: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].RefEntryID != urgent.ID {
+		t.Fatalf("priority order claimed %+v, want urgent entry first", claimed)
+	}
+
+	// Reset and claim FIFO: the older low-priority item comes first.
+	if _, err := repo.ResetProcessing(ctx); err != nil {
+		t.Fatalf("ResetProcessing: %v", err)
+	}
+	claimed, err = repo.ClaimBatch(ctx, 1, domain.EnrichmentClaimOrderFIFO)
+	if err != nil {
+		t.Fatalf("ClaimBatch fifo: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].RefEntryID != older.ID {
+		t.Fatalf("fifo order claimed %+v, want older entry first", claimed)
+	}
+}
+
+func containsEntry(items []domain.EnrichmentQueueItem, refEntryID uuid.UUID) *domain.EnrichmentQueueItem {
+	for i := range items {
+		if items[i].RefEntryID == refEntryID {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func TestRepo_MarkFailed_RetriesUntilCap(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	ref := testhelper.SeedRefEntry(t, pool, "flaky-"+time.Now().Format("150405.000000"))
+	if err := repo.Enqueue(ctx, ref.ID, 0); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	const maxAttempts = 3
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		claimed, err := repo.ClaimBatch(ctx, 100, domain.EnrichmentClaimOrderFIFO)
+		if err != nil {
+			t.Fatalf("attempt %d: ClaimBatch: %v", attempt, err)
+		}
+		item := containsEntry(claimed, ref.ID)
+		if item == nil {
+			t.Fatalf("attempt %d: item not claimable", attempt)
+		}
+		if item.Attempts != attempt-1 {
+			t.Errorf("attempt %d: Attempts = %d, want %d", attempt, item.Attempts, attempt-1)
+		}
+
+		// Zero base delay makes the retry due immediately.
+		if err := repo.MarkFailed(ctx, ref.ID, "llm timeout", maxAttempts, 0); err != nil {
+			t.Fatalf("attempt %d: MarkFailed: %v", attempt, err)
+		}
+	}
+
+	claimed, err := repo.ClaimBatch(ctx, 100, domain.EnrichmentClaimOrderFIFO)
+	if err != nil {
+		t.Fatalf("ClaimBatch after cap: %v", err)
+	}
+	if containsEntry(claimed, ref.ID) != nil {
+		t.Fatal("item claimable after reaching max attempts")
+	}
+
+	failed, err := repo.List(ctx, string(domain.EnrichmentStatusFailed), 100, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	item := containsEntry(failed, ref.ID)
+	if item == nil {
+		t.Fatal("item should stay failed after reaching max attempts")
+	}
+	if item.Attempts != maxAttempts || item.NextRetryAt != nil {
+		t.Errorf("final state: Attempts = %d, NextRetryAt = %v; want %d, nil", item.Attempts, item.NextRetryAt, maxAttempts)
+	}
+}
+
+func TestRepo_MarkFailed_BacksOffBeforeRetry(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	ref := testhelper.SeedRefEntry(t, pool, "backoff-"+time.Now().Format("150405.000000"))
+	if err := repo.Enqueue(ctx, ref.ID, 0); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if err := repo.MarkFailed(ctx, ref.ID, "rate limited", 5, time.Hour); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+
+	claimed, err := repo.ClaimBatch(ctx, 100, domain.EnrichmentClaimOrderFIFO)
+	if err != nil {
+		t.Fatalf("ClaimBatch: %v", err)
+	}
+	if containsEntry(claimed, ref.ID) != nil {
+		t.Fatal("item claimed before its backoff elapsed")
+	}
+
+	pending, err := repo.List(ctx, string(domain.EnrichmentStatusPending), 100, 0)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	item := containsEntry(pending, ref.ID)
+	if item == nil || item.NextRetryAt == nil {
+		t.Fatal("item should be pending with a scheduled retry")
+	}
+	if d := time.Until(*item.NextRetryAt); d < 50*time.Minute || d > 70*time.Minute {
+		t.Errorf("NextRetryAt in %s, want about 1h", d)
+	}
+}
+
+func TestRepo_Release_OnlyListedProcessingItems(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	suffix := time.Now().Format("150405.000000")
+	done := testhelper.SeedRefEntry(t, pool, "release-done-"+suffix)
+	left := testhelper.SeedRefEntry(t, pool, "release-left-"+suffix)
+	other := testhelper.SeedRefEntry(t, pool, "release-other-"+suffix)
+	for _, id := range []uuid.UUID{done.ID, left.ID, other.ID} {
+		if err := repo.Enqueue(ctx, id, 0); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	if _, err := repo.ClaimBatch(ctx, 100, domain.EnrichmentClaimOrderFIFO); err != nil {
+		t.Fatalf("ClaimBatch: %v", err)
+	}
+	if err := repo.MarkDone(ctx, done.ID); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+
+	n, err := repo.Release(ctx, []uuid.UUID{done.ID, left.ID})
+	if err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Release = %d, want 1", n)
+	}
+
+	pending, err := repo.List(ctx, string(domain.EnrichmentStatusPending), 100, 0)
+	if err != nil {
+		t.Fatalf("List pending: %v", err)
+	}
+	item := containsEntry(pending, left.ID)
+	if item == nil {
+		t.Fatal("released item should be pending")
+	}
+	if item.Attempts != 0 {
+		t.Errorf("Attempts = %d, want 0: release must not count as an attempt", item.Attempts)
+	}
+	if containsEntry(pending, done.ID) != nil {
+		t.Error("done item must not be released")
+	}
+	if containsEntry(pending, other.ID) != nil {
+		t.Error("unlisted processing item must stay processing")
+	}
+}
+
+func TestRepo_ReclaimStale_OnlyOldClaims(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	suffix := time.Now().Format("150405.000000")
+	stale := testhelper.SeedRefEntry(t, pool, "stale-"+suffix)
+	fresh := testhelper.SeedRefEntry(t, pool, "fresh-"+suffix)
+	for _, id := range []uuid.UUID{stale.ID, fresh.ID} {
+		if err := repo.Enqueue(ctx, id, 0); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	claimed, err := repo.ClaimBatch(ctx, 100, domain.EnrichmentClaimOrderFIFO)
+	if err != nil {
+		t.Fatalf("ClaimBatch: %v", err)
+	}
+	if item := containsEntry(claimed, fresh.ID); item == nil || item.ClaimedAt == nil {
+		t.Fatal("claimed item should have ClaimedAt set")
+	}
+	// Simulate a claim from a run that crashed hours ago.
+	if _, err := pool.Exec(ctx,
+		`UPDATE enrichment_queue SET claimed_at = now() - interval '3 hours' WHERE ref_entry_id = $1`,
+		stale.ID); err != nil {
+		t.Fatalf("backdate claim: %v", err)
+	}
+
+	if _, err := repo.ReclaimStale(ctx, time.Hour); err != nil {
+		t.Fatalf("ReclaimStale: %v", err)
+	}
+
+	pending, err := repo.List(ctx, string(domain.EnrichmentStatusPending), 100, 0)
+	if err != nil {
+		t.Fatalf("List pending: %v", err)
+	}
+	item := containsEntry(pending, stale.ID)
+	if item == nil {
+		t.Fatal("stale item should be reclaimed to pending")
+	}
+	if item.ClaimedAt != nil {
+		t.Errorf("reclaimed ClaimedAt = %v, want nil", item.ClaimedAt)
+	}
+
+	processing, err := repo.List(ctx, string(domain.EnrichmentStatusProcessing), 100, 0)
+	if err != nil {
+		t.Fatalf("List processing: %v", err)
+	}
+	if containsEntry(processing, fresh.ID) == nil {
+		t.Error("freshly claimed item should stay processing")
+	}
+}
+
+func TestRepo_EnqueueMissing_OnlyUnderEnriched(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	seed := func(name string) domain.RefEntry {
+		return testhelper.SeedRefEntry(t, pool, name+"-"+uuid.New().String()[:8])
+	}
+	exec := func(sql string, args ...any) {
+		t.Helper()
+		if _, err := pool.Exec(ctx, sql, args...); err != nil {
+			t.Fatalf("%s: %v", sql, err)
+		}
+	}
+	dropExamples := func(e domain.RefEntry) {
+		exec(`DELETE FROM ref_examples WHERE ref_sense_id IN (SELECT id FROM ref_senses WHERE ref_entry_id = $1)`, e.ID)
+	}
+
+	complete := seed("complete")
+	noExamples := seed("no-examples")
+	dropExamples(noExamples)
+	noTranslations := seed("no-translations")
+	exec(`DELETE FROM ref_translations WHERE ref_sense_id IN (SELECT id FROM ref_senses WHERE ref_entry_id = $1)`, noTranslations.ID)
+	llmEnriched := seed("llm-enriched")
+	dropExamples(llmEnriched)
+	exec(`INSERT INTO ref_entry_source_coverage (ref_entry_id, source_slug, status, fetched_at) VALUES ($1, 'llm', 'fetched', now())`, llmEnriched.ID)
+	queued := seed("queued")
+	dropExamples(queued)
+	if err := repo.Enqueue(ctx, queued.ID, 5); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	if _, err := repo.EnqueueMissing(ctx, 1_000_000); err != nil {
+		t.Fatalf("EnqueueMissing: %v", err)
+	}
+
+	ids := []uuid.UUID{complete.ID, noExamples.ID, noTranslations.ID, llmEnriched.ID, queued.ID}
+	rows, err := pool.Query(ctx, `SELECT ref_entry_id, priority FROM enrichment_queue WHERE ref_entry_id = ANY($1)`, ids)
+	if err != nil {
+		t.Fatalf("query queue: %v", err)
+	}
+	defer rows.Close()
+	priorities := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var priority int
+		if err := rows.Scan(&id, &priority); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		priorities[id] = priority
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+
+	for _, e := range []domain.RefEntry{noExamples, noTranslations} {
+		if _, ok := priorities[e.ID]; !ok {
+			t.Errorf("%s should be enqueued", e.Text)
+		}
+	}
+	for _, e := range []domain.RefEntry{complete, llmEnriched} {
+		if _, ok := priorities[e.ID]; ok {
+			t.Errorf("%s should not be enqueued", e.Text)
+		}
+	}
+	if priorities[queued.ID] != 5 {
+		t.Errorf("already queued entry priority = %d, want 5 (untouched)", priorities[queued.ID])
+	}
+
+	// Everything missing is queued now, so a second run adds nothing.
+	n, err := repo.EnqueueMissing(ctx, 1_000_000)
+	if err != nil {
+		t.Fatalf("second EnqueueMissing: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("second EnqueueMissing queued %d, want 0", n)
+	}
+}
+
+func TestRepo_EnqueueMissing_RespectsLimit(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := enrichment.New(pool)
+	ctx := context.Background()
+
+	// Queue whatever other tests left behind so only the entries below count.
+	if _, err := repo.EnqueueMissing(ctx, 1_000_000); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	for i := range 3 {
+		e := testhelper.SeedRefEntry(t, pool, "limit-"+uuid.New().String()[:8])
+		if _, err := pool.Exec(ctx,
+			`DELETE FROM ref_examples WHERE ref_sense_id IN (SELECT id FROM ref_senses WHERE ref_entry_id = $1)`,
+			e.ID); err != nil {
+			t.Fatalf("drop examples %d: %v", i, err)
+		}
+	}
+
+	n, err := repo.EnqueueMissing(ctx, 2)
+	if err != nil {
+		t.Fatalf("EnqueueMissing: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("queued %d, want 2", n)
+	}
+}
@@ -14,15 +14,16 @@ import (
 
 const claimBatch = `-- name: ClaimBatch :many
 UPDATE enrichment_queue
-SET status = 'processing'
+SET status = 'processing', claimed_at = now()
 WHERE id IN (
     SELECT id FROM enrichment_queue
     WHERE status = 'pending'
-    ORDER BY priority DESC, requested_at
+      AND (next_retry_at IS NULL OR next_retry_at <= now())
+    ORDER BY priority DESC, created_at
     LIMIT $1
     FOR UPDATE SKIP LOCKED
 )
-RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at
+RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at
 `
 
 func (q *Queries) ClaimBatch(ctx context.Context, limit int32) ([]EnrichmentQueue, error) {
@@ -43,6 +44,55 @@ func (q *Queries) ClaimBatch(ctx context.Context, limit int32) ([]EnrichmentQueu
 			&i.RequestedAt,
 			&i.ProcessedAt,
 			&i.CreatedAt,
+			&i.Attempts,
+			&i.NextRetryAt,
+			&i.ClaimedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimBatchFIFO = `-- name: ClaimBatchFIFO :many
+UPDATE enrichment_queue
+SET status = 'processing', claimed_at = now()
+WHERE id IN (
+    SELECT id FROM enrichment_queue
+    WHERE status = 'pending'
+      AND (next_retry_at IS NULL OR next_retry_at <= now())
+    ORDER BY created_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at
+`
+
+func (q *Queries) ClaimBatchFIFO(ctx context.Context, limit int32) ([]EnrichmentQueue, error) {
+	rows, err := q.db.Query(ctx, claimBatchFIFO, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EnrichmentQueue{}
+	for rows.Next() {
+		var i EnrichmentQueue
+		if err := rows.Scan(
+			&i.ID,
+			&i.RefEntryID,
+			&i.Status,
+			&i.Priority,
+			&i.ErrorMessage,
+			&i.RequestedAt,
+			&i.ProcessedAt,
+			&i.CreatedAt,
+			&i.Attempts,
+			&i.NextRetryAt,
+			&i.ClaimedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -58,7 +108,7 @@ const enqueue = `-- name: Enqueue :exec
 INSERT INTO enrichment_queue (ref_entry_id, priority)
 VALUES ($1, $2)
 ON CONFLICT (ref_entry_id)
-DO UPDATE SET priority = enrichment_queue.priority + 1,
+DO UPDATE SET priority = GREATEST(enrichment_queue.priority + 1, EXCLUDED.priority),
               requested_at = now()
 WHERE enrichment_queue.status IN ('pending', 'failed')
 `
@@ -105,7 +155,7 @@ func (q *Queries) GetStats(ctx context.Context) (GetStatsRow, error) {
 }
 
 const list = `-- name: List :many
-SELECT id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at
+SELECT id, ref_entry_id, status, priority, error_message, requested_at, processed_at, created_at, attempts, next_retry_at, claimed_at
 FROM enrichment_queue
 WHERE ($1::text = '' OR status = $1)
 ORDER BY priority DESC, requested_at
@@ -136,6 +186,9 @@ func (q *Queries) List(ctx context.Context, arg ListParams) ([]EnrichmentQueue,
 			&i.RequestedAt,
 			&i.ProcessedAt,
 			&i.CreatedAt,
+			&i.Attempts,
+			&i.NextRetryAt,
+			&i.ClaimedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -158,23 +211,69 @@ func (q *Queries) MarkDone(ctx context.Context, refEntryID uuid.UUID) error {
 }
 
 const markFailed = `-- name: MarkFailed :exec
-UPDATE enrichment_queue SET status = 'failed', processed_at = now(), error_message = $2
-WHERE ref_entry_id = $1
+UPDATE enrichment_queue
+SET attempts      = attempts + 1,
+    processed_at  = now(),
+    error_message = $1,
+    status        = CASE WHEN attempts + 1 < $2::int THEN 'pending' ELSE 'failed' END,
+    next_retry_at = CASE WHEN attempts + 1 < $2::int
+                         THEN now() + make_interval(secs => $3::float8 * power(2, attempts))
+                         ELSE NULL END
+WHERE ref_entry_id = $4
 `
 
 type MarkFailedParams struct {
-	RefEntryID   uuid.UUID
-	ErrorMessage pgtype.Text
+	ErrorMessage  pgtype.Text
+	MaxAttempts   int32
+	BaseDelaySecs float64
+	RefEntryID    uuid.UUID
 }
 
+// Reschedules with exponential backoff (base_delay_secs * 2^attempts) until
+// attempts reaches max_attempts; then the item stays failed.
 func (q *Queries) MarkFailed(ctx context.Context, arg MarkFailedParams) error {
-	_, err := q.db.Exec(ctx, markFailed, arg.RefEntryID, arg.ErrorMessage)
+	_, err := q.db.Exec(ctx, markFailed,
+		arg.ErrorMessage,
+		arg.MaxAttempts,
+		arg.BaseDelaySecs,
+		arg.RefEntryID,
+	)
 	return err
 }
 
+const release = `-- name: Release :execrows
+UPDATE enrichment_queue
+SET status = 'pending', claimed_at = NULL
+WHERE status = 'processing' AND ref_entry_id = ANY($1::uuid[])
+`
+
+func (q *Queries) Release(ctx context.Context, refEntryIds []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, release, refEntryIds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reclaimStale = `-- name: ReclaimStale :execrows
+UPDATE enrichment_queue
+SET status = 'pending', claimed_at = NULL
+WHERE status = 'processing'
+  AND (claimed_at IS NULL OR claimed_at < now() - make_interval(secs => $1::float8))
+`
+
+// Items claimed before claimed_at existed have no timestamp and count as stale.
+func (q *Queries) ReclaimStale(ctx context.Context, olderThanSecs float64) (int64, error) {
+	result, err := q.db.Exec(ctx, reclaimStale, olderThanSecs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const resetProcessing = `-- name: ResetProcessing :execrows
 UPDATE enrichment_queue
-SET status = 'pending'
+SET status = 'pending', claimed_at = NULL
 WHERE status = 'processing'
 `
 
@@ -188,7 +287,8 @@ func (q *Queries) ResetProcessing(ctx context.Context) (int64, error) {
 
 const retryAllFailed = `-- name: RetryAllFailed :execrows
 UPDATE enrichment_queue
-SET status = 'pending', error_message = NULL, processed_at = NULL
+SET status = 'pending', error_message = NULL, processed_at = NULL,
+    attempts = 0, next_retry_at = NULL
 WHERE status = 'failed'
 `
 
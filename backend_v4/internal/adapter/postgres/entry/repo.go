@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
@@ -33,18 +34,32 @@ const (
 	sortByCreatedAt = "created_at"
 	sortByUpdatedAt = "updated_at"
 
+	// Computed sort fields, derived from the entry's card; offset pagination only.
+	sortByDueDate     = "due_date"
+	sortByReviewCount = "review_count"
+
 	sortOrderASC  = "ASC"
 	sortOrderDESC = "DESC"
 )
 
 // Repo provides entry persistence backed by PostgreSQL.
 type Repo struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool // Find, FindCursor, Search and FindDeleted
 }
 
 // New creates a new entry repository.
 func New(pool *pgxpool.Pool) *Repo {
-	return &Repo{pool: pool}
+	return &Repo{pool: pool, reader: pool}
+}
+
+// SetReader routes the repository's read-only find and search queries to reader,
+// typically a read-replica pool. Queries inside a transaction still run on
+// the transaction. A nil reader keeps them on the primary pool.
+func (r *Repo) SetReader(reader *pgxpool.Pool) {
+	if reader != nil {
+		r.reader = reader
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -119,6 +134,70 @@ func (r *Repo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
 	return int(count), nil
 }
 
+const getFrequencyRankSQL = `
+SELECT re.frequency_rank
+FROM entries e
+LEFT JOIN ref_entries re ON re.id = e.ref_entry_id
+WHERE e.id = $1 AND e.user_id = $2 AND e.deleted_at IS NULL`
+
+// GetFrequencyRank returns the frequency rank of the reference entry behind a
+// user's entry. Custom entries and unranked catalog words return nil.
+func (r *Repo) GetFrequencyRank(ctx context.Context, userID, id uuid.UUID) (*int, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var rank pgtype.Int4
+	if err := querier.QueryRow(ctx, getFrequencyRankSQL, id, userID).Scan(&rank); err != nil {
+		return nil, mapError(err, "entry", id)
+	}
+	if !rank.Valid {
+		return nil, nil
+	}
+
+	v := int(rank.Int32)
+	return &v, nil
+}
+
+const getFrequencyRanksSQL = `
+SELECT e.id, re.frequency_rank
+FROM entries e
+JOIN ref_entries re ON re.id = e.ref_entry_id
+WHERE e.id = ANY($1::uuid[]) AND e.user_id = $2 AND e.deleted_at IS NULL
+  AND re.frequency_rank IS NOT NULL`
+
+// GetFrequencyRanks returns the frequency ranks of the reference entries
+// behind a user's entries, keyed by entry ID. Custom entries, unranked catalog
+// words and unknown IDs are absent from the map.
+func (r *Repo) GetFrequencyRanks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+	if len(ids) == 0 {
+		return map[uuid.UUID]int{}, nil
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	rows, err := querier.Query(ctx, getFrequencyRanksSQL, ids, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get frequency ranks: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]int, len(ids))
+	for rows.Next() {
+		var (
+			id   uuid.UUID
+			rank int32
+		)
+		if err := rows.Scan(&id, &rank); err != nil {
+			return nil, fmt.Errorf("scan frequency rank: %w", err)
+		}
+		result[id] = int(rank)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate frequency ranks: %w", err)
+	}
+
+	return result, nil
+}
+
 // ExistByIDs checks which of the given IDs exist as non-deleted entries for a user.
 // Returns a map of id -> true for each existing entry.
 func (r *Repo) ExistByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
@@ -165,7 +244,7 @@ func (r *Repo) ExistByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID
 func (r *Repo) Find(ctx context.Context, userID uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
 	normalizeFilter(&f)
 
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	// Build base WHERE conditions.
 	baseWhere := buildBaseWhere(userID, f)
@@ -185,14 +264,12 @@ func (r *Repo) Find(ctx context.Context, userID uuid.UUID, f domain.EntryFilter)
 	// --- Data query ---
 	cols := []string{
 		"id", "user_id", "ref_entry_id", "text", "text_normalized",
-		"notes", "created_at", "updated_at",
+		"notes", "created_at", "updated_at", "favorite",
 	}
 	dataQB := psql.Select(cols...).From("entries").Where(baseWhere)
 
 	// Sorting.
-	sortCol := sortColumn(f.SortBy)
-	orderDir := f.SortOrder
-	dataQB = dataQB.OrderBy(sortCol + " " + orderDir + ", id " + orderDir)
+	dataQB = dataQB.OrderBy(orderByClause(f))
 
 	// Limit.
 	dataQB = dataQB.Limit(uint64(f.Limit))
@@ -215,7 +292,11 @@ func (r *Repo) Find(ctx context.Context, userID uuid.UUID, f domain.EntryFilter)
 func (r *Repo) FindCursor(ctx context.Context, userID uuid.UUID, f domain.EntryFilter) ([]domain.Entry, bool, error) {
 	normalizeFilter(&f)
 
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	if isComputedSort(f.SortBy) {
+		return nil, false, domain.NewValidationError("sort_by", "not supported with cursor pagination")
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	// Build base WHERE conditions.
 	baseWhere := buildBaseWhere(userID, f)
@@ -223,7 +304,7 @@ func (r *Repo) FindCursor(ctx context.Context, userID uuid.UUID, f domain.EntryF
 	// --- Data query ---
 	cols := []string{
 		"id", "user_id", "ref_entry_id", "text", "text_normalized",
-		"notes", "created_at", "updated_at",
+		"notes", "created_at", "updated_at", "favorite",
 	}
 	dataQB := psql.Select(cols...).From("entries").Where(baseWhere)
 
@@ -237,9 +318,7 @@ func (r *Repo) FindCursor(ctx context.Context, userID uuid.UUID, f domain.EntryF
 	}
 
 	// Sorting.
-	sortCol := sortColumn(f.SortBy)
-	orderDir := f.SortOrder
-	dataQB = dataQB.OrderBy(sortCol + " " + orderDir + ", id " + orderDir)
+	dataQB = dataQB.OrderBy(orderByClause(f))
 
 	// Fetch limit+1 to detect hasNextPage.
 	fetchLimit := f.Limit + 1
@@ -260,10 +339,102 @@ func (r *Repo) FindCursor(ctx context.Context, userID uuid.UUID, f domain.EntryF
 	return entries, hasNextPage, nil
 }
 
+// searchSQL matches the trigger-maintained search_vector (entry text, sense
+// definitions, translations and notes). The snippet is built from the same
+// fields with matches wrapped in <b></b>.
+const searchSQL = `
+SELECT e.id, e.user_id, e.ref_entry_id, e.text, e.text_normalized, e.notes, e.created_at, e.updated_at, e.favorite,
+       ts_rank(e.search_vector, q) AS rank,
+       ts_headline('english',
+           concat_ws(' ', e.text, fn_entry_definitions(e.id), fn_entry_translations(e.id), e.notes),
+           q, 'MaxFragments=2, MaxWords=20, MinWords=5') AS snippet
+FROM entries e, plainto_tsquery('english', $2) q
+WHERE e.user_id = $1 AND e.deleted_at IS NULL AND e.search_vector @@ q
+ORDER BY rank DESC, e.id
+LIMIT $3`
+
+// Search runs a full-text query over the user's non-deleted entries and
+// returns hits ordered by relevance.
+func (r *Repo) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.EntrySearchHit, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	rows, err := querier.Query(ctx, searchSQL, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search entries: %w", err)
+	}
+	defer rows.Close()
+
+	hits := []domain.EntrySearchHit{}
+	for rows.Next() {
+		var (
+			hit        domain.EntrySearchHit
+			refEntryID pgtype.UUID
+			notes      pgtype.Text
+			rank       float32
+		)
+		e := &hit.Entry
+		if err := rows.Scan(&e.ID, &e.UserID, &refEntryID, &e.Text, &e.TextNormalized, &notes,
+			&e.CreatedAt, &e.UpdatedAt, &e.Favorite, &rank, &hit.Snippet); err != nil {
+			return nil, fmt.Errorf("scan search hit: %w", err)
+		}
+		if refEntryID.Valid {
+			rid := uuid.UUID(refEntryID.Bytes)
+			e.RefEntryID = &rid
+		}
+		if notes.Valid {
+			e.Notes = &notes.String
+		}
+		hit.Rank = float64(rank)
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// maxLevenshteinLen is the longest argument fuzzystrmatch's levenshtein
+// functions accept, in characters.
+const maxLevenshteinLen = 255
+
+// FindSimilarCandidates returns the user's non-deleted entries whose
+// normalized text is one of forms or at most maxDist edits away from text.
+// The edit distance is checked in SQL, so only real matches are returned and
+// no candidate is lost to a row limit.
+func (r *Repo) FindSimilarCandidates(ctx context.Context, userID uuid.UUID, forms []string, text string, maxDist int) ([]domain.Entry, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	match := sq.Or{sq.Expr("text_normalized = ANY(?::text[])", forms)}
+	if n := utf8.RuneCountInString(text); maxDist > 0 && n+maxDist <= maxLevenshteinLen {
+		// CASE keeps levenshtein_less_equal away from rows longer than it accepts;
+		// the length check alone would not guarantee evaluation order.
+		match = append(match, sq.Expr(
+			"CASE WHEN char_length(text_normalized) BETWEEN ? AND ? "+
+				"THEN levenshtein_less_equal(text_normalized, ?, ?) <= ? ELSE false END",
+			n-maxDist, n+maxDist, text, maxDist, maxDist,
+		))
+	}
+
+	cols := []string{
+		"id", "user_id", "ref_entry_id", "text", "text_normalized",
+		"notes", "created_at", "updated_at", "favorite",
+	}
+	qb := psql.Select(cols...).From("entries").
+		Where(sq.And{
+			sq.Eq{"user_id": userID},
+			sq.Expr("deleted_at IS NULL"),
+			match,
+		}).
+		OrderBy("text_normalized")
+
+	return scanEntries(ctx, querier, qb)
+}
+
 // FindDeleted returns soft-deleted entries for a user with offset-based pagination.
 // Returns (entries, totalCount, error).
 func (r *Repo) FindDeleted(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Entry, int, error) {
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	baseWhere := sq.And{
 		sq.Eq{"user_id": userID},
@@ -285,7 +456,7 @@ func (r *Repo) FindDeleted(ctx context.Context, userID uuid.UUID, limit, offset
 	// --- Data query ---
 	cols := []string{
 		"id", "user_id", "ref_entry_id", "text", "text_normalized",
-		"notes", "created_at", "updated_at", "deleted_at",
+		"notes", "created_at", "updated_at", "deleted_at", "favorite",
 	}
 	dataQB := psql.Select(cols...).From("entries").Where(baseWhere).
 		OrderBy("deleted_at DESC").
@@ -318,8 +489,9 @@ func (r *Repo) FindDeleted(ctx context.Context, userID uuid.UUID, limit, offset
 			createdAt      time.Time
 			updatedAt      time.Time
 			deletedAt      *time.Time
+			favorite       bool
 		)
-		if err := rows.Scan(&id, &uid, &refEntryID, &text, &textNormalized, &notes, &createdAt, &updatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&id, &uid, &refEntryID, &text, &textNormalized, &notes, &createdAt, &updatedAt, &deletedAt, &favorite); err != nil {
 			return nil, 0, fmt.Errorf("scan deleted entry: %w", err)
 		}
 
@@ -331,6 +503,7 @@ func (r *Repo) FindDeleted(ctx context.Context, userID uuid.UUID, limit, offset
 			CreatedAt:      createdAt,
 			UpdatedAt:      updatedAt,
 			DeletedAt:      deletedAt,
+			Favorite:       favorite,
 		}
 		if refEntryID.Valid {
 			rid := uuid.UUID(refEntryID.Bytes)
@@ -395,6 +568,23 @@ func (r *Repo) UpdateNotes(ctx context.Context, userID, id uuid.UUID, notes *str
 	return &e, nil
 }
 
+// SetFavorite stars or unstars a non-deleted entry.
+func (r *Repo) SetFavorite(ctx context.Context, userID, id uuid.UUID, favorite bool) (*domain.Entry, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	row, err := q.UpdateEntryFavorite(ctx, sqlc.UpdateEntryFavoriteParams{
+		ID:       id,
+		UserID:   userID,
+		Favorite: favorite,
+	})
+	if err != nil {
+		return nil, mapError(err, "entry", id)
+	}
+
+	e := toDomainEntry(row)
+	return &e, nil
+}
+
 // SoftDelete sets deleted_at on a non-deleted entry. Idempotent: if already
 // soft-deleted, no error is returned.
 func (r *Repo) SoftDelete(ctx context.Context, userID, id uuid.UUID) error {
@@ -425,28 +615,93 @@ func (r *Repo) Restore(ctx context.Context, userID, id uuid.UUID) (*domain.Entry
 	return &e, nil
 }
 
+// restoreBatchSQL undeletes a user's entries deleted at or after $2. An entry
+// whose text now belongs to an active entry stays deleted; among several
+// deleted entries with the same text only the most recently deleted returns.
+const restoreBatchSQL = `
+UPDATE entries SET deleted_at = NULL, updated_at = now()
+WHERE id IN (
+    SELECT DISTINCT ON (d.text_normalized) d.id
+    FROM entries d
+    WHERE d.user_id = $1 AND d.deleted_at >= $2
+      AND NOT EXISTS (
+          SELECT 1 FROM entries a
+          WHERE a.user_id = d.user_id AND a.text_normalized = d.text_normalized AND a.deleted_at IS NULL
+      )
+    ORDER BY d.text_normalized, d.deleted_at DESC
+)
+RETURNING id`
+
+// RestoreBatch undeletes every soft-deleted entry of the user deleted at or
+// after since and returns the restored IDs.
+func (r *Repo) RestoreBatch(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	rows, err := postgres.QuerierFromCtx(ctx, r.pool).Query(ctx, restoreBatchSQL, userID, since)
+	if err != nil {
+		return nil, fmt.Errorf("restore entries: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan restored entry id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate restored entries: %w", err)
+	}
+
+	return ids, nil
+}
+
+// CountDeletedBefore returns how many of the user's entries were soft-deleted
+// before threshold.
+func (r *Repo) CountDeletedBefore(ctx context.Context, userID uuid.UUID, threshold time.Time) (int, error) {
+	var n int
+	err := postgres.QuerierFromCtx(ctx, r.pool).
+		QueryRow(ctx, `SELECT count(*) FROM entries WHERE user_id = $1 AND deleted_at < $2`, userID, threshold).
+		Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count deleted entries: %w", err)
+	}
+	return n, nil
+}
+
 // HardDeleteOld permanently removes soft-deleted entries older than threshold.
-// Deletes in batches of 100 to avoid long-running transactions.
+// Deletes in batches of postgres.DefaultDeleteBatchSize, checking ctx between
+// batches, so no single statement holds locks for long.
 // Returns the total number of deleted rows.
 func (r *Repo) HardDeleteOld(ctx context.Context, threshold time.Time) (int64, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 
-	var total int64
-	ts := &threshold
-	for {
-		n, err := q.HardDeleteOldEntries(ctx, ts)
-		if err != nil {
-			return total, fmt.Errorf("hard delete entries: %w", err)
-		}
-		total += n
-		if n == 0 {
-			break
-		}
+	total, err := postgres.DeleteInBatches(ctx, postgres.DefaultDeleteBatchSize, func(ctx context.Context, limit int) (int64, error) {
+		return q.HardDeleteOldEntries(ctx, sqlc.HardDeleteOldEntriesParams{
+			DeletedAt: &threshold,
+			BatchSize: int32(limit),
+		})
+	})
+	if err != nil {
+		return total, fmt.Errorf("hard delete entries: %w", err)
 	}
 
 	return total, nil
 }
 
+// CountOlderThan returns how many soft-deleted entries HardDeleteOld would
+// remove for the same threshold.
+func (r *Repo) CountOlderThan(ctx context.Context, threshold time.Time) (int64, error) {
+	var n int64
+	err := postgres.QuerierFromCtx(ctx, r.pool).
+		QueryRow(ctx, `SELECT count(*) FROM entries WHERE deleted_at < $1`, threshold).
+		Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count old entries: %w", err)
+	}
+	return n, nil
+}
+
 // ---------------------------------------------------------------------------
 // Cursor encoding / decoding
 // ---------------------------------------------------------------------------
@@ -528,7 +783,7 @@ func CursorFromEntry(e domain.Entry, sortBy string) string {
 func normalizeFilter(f *domain.EntryFilter) {
 	// Sort column.
 	switch f.SortBy {
-	case sortByText, sortByCreatedAt, sortByUpdatedAt:
+	case sortByText, sortByCreatedAt, sortByUpdatedAt, sortByDueDate, sortByReviewCount:
 		// valid
 	default:
 		f.SortBy = sortByCreatedAt
@@ -551,18 +806,39 @@ func normalizeFilter(f *domain.EntryFilter) {
 	}
 }
 
-// sortColumn returns the SQL column name for the given SortBy value.
+// sortColumn returns the SQL column name (or, for computed sort fields, the
+// scalar subquery) for the given SortBy value.
 func sortColumn(sortBy string) string {
 	switch sortBy {
 	case sortByText:
 		return "text_normalized"
 	case sortByUpdatedAt:
 		return "updated_at"
+	case sortByDueDate:
+		return "(SELECT cards.due FROM cards WHERE cards.entry_id = entries.id)"
+	case sortByReviewCount:
+		return "(SELECT count(*) FROM review_logs rl JOIN cards ON cards.id = rl.card_id WHERE cards.entry_id = entries.id)"
 	default:
 		return "created_at"
 	}
 }
 
+// orderByClause returns the ORDER BY clause for f, with id as the tie-breaker.
+// Entries without a card have no due date and sort last in either direction.
+func orderByClause(f domain.EntryFilter) string {
+	nulls := ""
+	if f.SortBy == sortByDueDate {
+		nulls = " NULLS LAST"
+	}
+	return sortColumn(f.SortBy) + " " + f.SortOrder + nulls + ", id " + f.SortOrder
+}
+
+// isComputedSort reports whether sortBy is derived from cards rather than an
+// entries column; such sorts have no keyset cursor.
+func isComputedSort(sortBy string) bool {
+	return sortBy == sortByDueDate || sortBy == sortByReviewCount
+}
+
 // ---------------------------------------------------------------------------
 // Dynamic WHERE builder (shared between count and data queries)
 // ---------------------------------------------------------------------------
@@ -600,6 +876,10 @@ func buildBaseWhere(userID uuid.UUID, f domain.EntryFilter) sq.And {
 		))
 	}
 
+	if f.Favorite != nil {
+		where = append(where, sq.Eq{"favorite": *f.Favorite})
+	}
+
 	if f.Status != nil {
 		where = append(where, sq.Expr(
 			"EXISTS (SELECT 1 FROM cards WHERE cards.entry_id = entries.id AND cards.state = ?)",
@@ -637,8 +917,9 @@ func scanEntries(ctx context.Context, querier postgres.Querier, qb sq.SelectBuil
 			notes          pgtype.Text
 			createdAt      time.Time
 			updatedAt      time.Time
+			favorite       bool
 		)
-		if err := rows.Scan(&id, &uid, &refEntryID, &text, &textNormalized, &notes, &createdAt, &updatedAt); err != nil {
+		if err := rows.Scan(&id, &uid, &refEntryID, &text, &textNormalized, &notes, &createdAt, &updatedAt, &favorite); err != nil {
 			return nil, fmt.Errorf("scan entry: %w", err)
 		}
 
@@ -649,6 +930,7 @@ func scanEntries(ctx context.Context, querier postgres.Querier, qb sq.SelectBuil
 			TextNormalized: textNormalized,
 			CreatedAt:      createdAt,
 			UpdatedAt:      updatedAt,
+			Favorite:       favorite,
 		}
 		if refEntryID.Valid {
 			rid := uuid.UUID(refEntryID.Bytes)
@@ -717,6 +999,7 @@ func toDomainEntry(row sqlc.Entry) domain.Entry {
 		CreatedAt:      row.CreatedAt,
 		UpdatedAt:      row.UpdatedAt,
 		DeletedAt:      row.DeletedAt,
+		Favorite:       row.Favorite,
 	}
 
 	if row.RefEntryID.Valid {
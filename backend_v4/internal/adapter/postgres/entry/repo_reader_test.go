@@ -0,0 +1,64 @@
+package entry_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/entry"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// unreachablePool returns a lazily connecting pool for a server that does not
+// exist. Every query fails with a connect error naming database, which tells
+// which pool served it.
+func unreachablePool(t *testing.T, database string) *pgxpool.Pool {
+	t.Helper()
+	pool, err := pgxpool.New(context.Background(), "postgres://u:p@127.0.0.1:1/"+database+"?connect_timeout=1")
+	if err != nil {
+		t.Fatalf("create pool: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func assertServedBy(t *testing.T, op string, err error, database string) {
+	t.Helper()
+	if err == nil || !strings.Contains(err.Error(), "database="+database) {
+		t.Errorf("%s: error = %v, want a connect error for %s", op, err, database)
+	}
+}
+
+func TestRepo_SetReader_RoutesReadsToReader(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	repo := entry.New(unreachablePool(t, "primary"))
+	repo.SetReader(unreachablePool(t, "replica"))
+
+	_, err := repo.Search(ctx, userID, "cat", 10)
+	assertServedBy(t, "Search", err, "replica")
+	_, _, err = repo.Find(ctx, userID, domain.EntryFilter{})
+	assertServedBy(t, "Find", err, "replica")
+
+	_, err = repo.Create(ctx, &domain.Entry{ID: uuid.New(), UserID: userID, Text: "cat", TextNormalized: "cat"})
+	assertServedBy(t, "Create", err, "primary")
+	assertServedBy(t, "SoftDelete", repo.SoftDelete(ctx, userID, uuid.New()), "primary")
+	_, err = repo.GetByID(ctx, userID, uuid.New())
+	assertServedBy(t, "GetByID", err, "primary")
+}
+
+func TestRepo_WithoutReader_ReadsUsePrimary(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	repo := entry.New(unreachablePool(t, "primary"))
+	repo.SetReader(nil)
+
+	_, err := repo.Search(ctx, uuid.New(), "cat", 10)
+	assertServedBy(t, "Search", err, "primary")
+}
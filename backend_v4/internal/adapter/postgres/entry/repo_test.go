@@ -3,6 +3,7 @@ package entry_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -462,6 +463,51 @@ func TestRepo_CountByUser(t *testing.T) {
 	}
 }
 
+func TestRepo_GetFrequencyRank(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	ranked := testhelper.SeedRefEntry(t, pool, "rank-"+uuid.New().String()[:8])
+	unranked := testhelper.SeedRefEntry(t, pool, "norank-"+uuid.New().String()[:8])
+	if _, err := pool.Exec(ctx, `UPDATE ref_entries SET frequency_rank = 42 WHERE id = $1`, ranked.ID); err != nil {
+		t.Fatalf("set frequency_rank: %v", err)
+	}
+
+	rankedEntry := testhelper.SeedEntry(t, pool, user.ID, ranked.ID)
+	unrankedEntry := testhelper.SeedEntry(t, pool, user.ID, unranked.ID)
+
+	rank, err := repo.GetFrequencyRank(ctx, user.ID, rankedEntry.ID)
+	if err != nil {
+		t.Fatalf("GetFrequencyRank: %v", err)
+	}
+	if rank == nil || *rank != 42 {
+		t.Errorf("ranked entry: got %v, want 42", rank)
+	}
+
+	rank, err = repo.GetFrequencyRank(ctx, user.ID, unrankedEntry.ID)
+	if err != nil {
+		t.Fatalf("GetFrequencyRank unranked: %v", err)
+	}
+	if rank != nil {
+		t.Errorf("unranked entry: got %d, want nil", *rank)
+	}
+
+	_, err = repo.GetFrequencyRank(ctx, user.ID, uuid.New())
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("missing entry: got %v, want ErrNotFound", err)
+	}
+
+	ranks, err := repo.GetFrequencyRanks(ctx, user.ID, []uuid.UUID{rankedEntry.ID, unrankedEntry.ID, uuid.New()})
+	if err != nil {
+		t.Fatalf("GetFrequencyRanks: %v", err)
+	}
+	if len(ranks) != 1 || ranks[rankedEntry.ID] != 42 {
+		t.Errorf("GetFrequencyRanks: got %v, want only the ranked entry at 42", ranks)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // UpdateNotes tests
 // ---------------------------------------------------------------------------
@@ -521,6 +567,61 @@ func TestRepo_UpdateNotes_NotFound(t *testing.T) {
 	assertIsDomainError(t, err, domain.ErrNotFound)
 }
 
+// ---------------------------------------------------------------------------
+// SetFavorite tests
+// ---------------------------------------------------------------------------
+
+func TestRepo_SetFavorite_HappyPath(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	e := buildEntry(user.ID, "fav-"+uuid.New().String()[:8], nil)
+	created, _ := repo.Create(ctx, &e)
+	if created.Favorite {
+		t.Fatal("new entry must not be a favorite")
+	}
+
+	got, err := repo.SetFavorite(ctx, user.ID, created.ID, true)
+	if err != nil {
+		t.Fatalf("SetFavorite: unexpected error: %v", err)
+	}
+	if !got.Favorite {
+		t.Error("expected Favorite to be true")
+	}
+
+	read, err := repo.GetByID(ctx, user.ID, created.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !read.Favorite {
+		t.Error("expected Favorite to be persisted")
+	}
+
+	got, err = repo.SetFavorite(ctx, user.ID, created.ID, false)
+	if err != nil {
+		t.Fatalf("SetFavorite(false): unexpected error: %v", err)
+	}
+	if got.Favorite {
+		t.Error("expected Favorite to be false")
+	}
+}
+
+func TestRepo_SetFavorite_WrongUser(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	owner := testhelper.SeedUser(t, pool)
+	other := testhelper.SeedUser(t, pool)
+	e := buildEntry(owner.ID, "fav-other-"+uuid.New().String()[:8], nil)
+	created, _ := repo.Create(ctx, &e)
+
+	_, err := repo.SetFavorite(ctx, other.ID, created.ID, true)
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
+
 // ---------------------------------------------------------------------------
 // SoftDelete tests
 // ---------------------------------------------------------------------------
@@ -1069,6 +1170,43 @@ func TestRepo_Find_TopicFilter(t *testing.T) {
 	}
 }
 
+func TestRepo_Find_FavoriteFilter(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	suffix := uuid.New().String()[:8]
+
+	eStarred := buildEntry(user.ID, "fav-yes-"+suffix, nil)
+	cStarred, _ := repo.Create(ctx, &eStarred)
+	if _, err := repo.SetFavorite(ctx, user.ID, cStarred.ID, true); err != nil {
+		t.Fatalf("SetFavorite: %v", err)
+	}
+
+	ePlain := buildEntry(user.ID, "fav-no-"+suffix, nil)
+	repo.Create(ctx, &ePlain)
+
+	search := suffix
+	favorite := true
+	entries, totalCount, err := repo.Find(ctx, user.ID, domain.EntryFilter{Favorite: &favorite, Search: &search})
+	if err != nil {
+		t.Fatalf("Find Favorite=true: %v", err)
+	}
+	if totalCount != 1 || len(entries) != 1 || entries[0].ID != cStarred.ID || !entries[0].Favorite {
+		t.Errorf("Favorite=true: expected only the starred entry, got %d (total %d)", len(entries), totalCount)
+	}
+
+	favorite = false
+	_, totalCount, err = repo.Find(ctx, user.ID, domain.EntryFilter{Favorite: &favorite, Search: &search})
+	if err != nil {
+		t.Fatalf("Find Favorite=false: %v", err)
+	}
+	if totalCount != 1 {
+		t.Errorf("Favorite=false: expected 1, got %d", totalCount)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Find tests: Status filter
 // ---------------------------------------------------------------------------
@@ -1103,6 +1241,42 @@ func TestRepo_Find_StatusFilter(t *testing.T) {
 	}
 }
 
+func TestRepo_Find_StatusFilter_Review(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	suffix := uuid.New().String()[:8]
+
+	eReview := buildEntry(user.ID, "status-review-"+suffix, nil)
+	cReview, _ := repo.Create(ctx, &eReview)
+	seedCard(t, pool, user.ID, cReview.ID, domain.CardStateReview)
+
+	eNew := buildEntry(user.ID, "status-review-new-"+suffix, nil)
+	cNew, _ := repo.Create(ctx, &eNew)
+	seedCard(t, pool, user.ID, cNew.ID, domain.CardStateNew)
+
+	eNoCard := buildEntry(user.ID, "status-review-nocard-"+suffix, nil)
+	if _, err := repo.Create(ctx, &eNoCard); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	status := domain.CardStateReview
+	hasCard := true
+	search := suffix
+	entries, totalCount, err := repo.Find(ctx, user.ID, domain.EntryFilter{Status: &status, HasCard: &hasCard, Search: &search})
+	if err != nil {
+		t.Fatalf("Find Status=REVIEW: %v", err)
+	}
+	if totalCount != 1 || len(entries) != 1 {
+		t.Fatalf("Status=REVIEW: expected 1, got %d (total %d)", len(entries), totalCount)
+	}
+	if entries[0].ID != cReview.ID {
+		t.Errorf("Status=REVIEW: got entry %s, want %s", entries[0].ID, cReview.ID)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Find tests: combined filters
 // ---------------------------------------------------------------------------
@@ -1234,6 +1408,117 @@ func TestRepo_Find_SortByCreatedAtDESC(t *testing.T) {
 // FindCursor tests: cursor-based pagination
 // ---------------------------------------------------------------------------
 
+// seedSortEntries creates three entries sharing suffix: "late" and "soon" with
+// cards due in 48h and 1h, and "none" without a card. "late" has three review
+// logs and "soon" one. Returns the entries keyed by name.
+func seedSortEntries(t *testing.T, repo *entry.Repo, pool *pgxpool.Pool, userID uuid.UUID, suffix string) map[string]uuid.UUID {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	ids := make(map[string]uuid.UUID, 3)
+	for name, spec := range map[string]struct {
+		due     time.Duration
+		reviews int
+	}{"late": {48 * time.Hour, 3}, "soon": {time.Hour, 1}, "none": {}} {
+		e := buildEntry(userID, "sort-"+name+"-"+suffix, nil)
+		created, err := repo.Create(ctx, &e)
+		if err != nil {
+			t.Fatalf("Create %s: %v", name, err)
+		}
+		ids[name] = created.ID
+		if name == "none" {
+			continue
+		}
+
+		cardID := seedCard(t, pool, userID, created.ID, domain.CardStateReview)
+		if _, err := pool.Exec(ctx, `UPDATE cards SET due = $1 WHERE id = $2`, now.Add(spec.due), cardID); err != nil {
+			t.Fatalf("update card due: %v", err)
+		}
+		for range spec.reviews {
+			if _, err := pool.Exec(ctx, `INSERT INTO review_logs (card_id, grade) VALUES ($1, 'GOOD')`, cardID); err != nil {
+				t.Fatalf("insert review log: %v", err)
+			}
+		}
+	}
+	return ids
+}
+
+func TestRepo_Find_SortByDueDate_NoCardLast(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	suffix := uuid.New().String()[:8]
+	ids := seedSortEntries(t, repo, pool, user.ID, suffix)
+
+	search := suffix
+	for _, tc := range []struct {
+		order string
+		want  []uuid.UUID
+	}{
+		{"ASC", []uuid.UUID{ids["soon"], ids["late"], ids["none"]}},
+		{"DESC", []uuid.UUID{ids["late"], ids["soon"], ids["none"]}},
+	} {
+		entries, _, err := repo.Find(ctx, user.ID, domain.EntryFilter{
+			Search:    &search,
+			SortBy:    "due_date",
+			SortOrder: tc.order,
+		})
+		if err != nil {
+			t.Fatalf("Find sort by due_date %s: %v", tc.order, err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("due_date %s: expected 3 entries, got %d", tc.order, len(entries))
+		}
+		for i, want := range tc.want {
+			if entries[i].ID != want {
+				t.Errorf("due_date %s: entries[%d] = %s, want %s", tc.order, i, entries[i].ID, want)
+			}
+		}
+	}
+}
+
+func TestRepo_Find_SortByReviewCount(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	suffix := uuid.New().String()[:8]
+	ids := seedSortEntries(t, repo, pool, user.ID, suffix)
+
+	search := suffix
+	entries, _, err := repo.Find(ctx, user.ID, domain.EntryFilter{
+		Search:    &search,
+		SortBy:    "review_count",
+		SortOrder: "DESC",
+	})
+	if err != nil {
+		t.Fatalf("Find sort by review_count: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	for i, want := range []uuid.UUID{ids["late"], ids["soon"], ids["none"]} {
+		if entries[i].ID != want {
+			t.Errorf("entries[%d] = %s, want %s", i, entries[i].ID, want)
+		}
+	}
+}
+
+func TestRepo_FindCursor_RejectsComputedSort(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+
+	user := testhelper.SeedUser(t, pool)
+	_, _, err := repo.FindCursor(context.Background(), user.ID, domain.EntryFilter{SortBy: "due_date"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("expected ErrValidation, got %v", err)
+	}
+}
+
 func TestRepo_FindCursor_Pagination(t *testing.T) {
 	t.Parallel()
 	repo, pool := newRepo(t)
@@ -1445,3 +1730,184 @@ func assertIsDomainError(t *testing.T, err error, target error) {
 		t.Fatalf("expected error wrapping %v, got: %v", target, err)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Search
+// ---------------------------------------------------------------------------
+
+func TestRepo_Search_MatchesDefinitionNotHeadword(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	e := buildEntry(user.ID, "zephyr-"+uuid.New().String()[:8], nil)
+	if _, err := repo.Create(ctx, &e); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	other := buildEntry(user.ID, "unrelated-"+uuid.New().String()[:8], nil)
+	if _, err := repo.Create(ctx, &other); err != nil {
+		t.Fatalf("Create other: %v", err)
+	}
+
+	// The sense is inserted after the entry; the trigger must refresh the
+	// entry's search vector.
+	_, err := pool.Exec(ctx,
+		`INSERT INTO senses (id, entry_id, definition, source_slug, position) VALUES ($1, $2, $3, 'user', 0)`,
+		uuid.New(), e.ID, "A gentle westerly breeze",
+	)
+	if err != nil {
+		t.Fatalf("insert sense: %v", err)
+	}
+
+	hits, err := repo.Search(ctx, user.ID, "breezes", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("Search: got %d hits, want 1", len(hits))
+	}
+	if hits[0].Entry.ID != e.ID {
+		t.Errorf("Search: got entry %v, want %v", hits[0].Entry.ID, e.ID)
+	}
+	if !strings.Contains(hits[0].Snippet, "<b>breeze</b>") {
+		t.Errorf("Snippet = %q, want highlighted match", hits[0].Snippet)
+	}
+	if hits[0].Rank <= 0 {
+		t.Errorf("Rank = %v, want > 0", hits[0].Rank)
+	}
+
+	// Soft-deleted entries are excluded.
+	if err := repo.SoftDelete(ctx, user.ID, e.ID); err != nil {
+		t.Fatalf("SoftDelete: %v", err)
+	}
+	hits, err = repo.Search(ctx, user.ID, "breeze", 10)
+	if err != nil {
+		t.Fatalf("Search after delete: %v", err)
+	}
+	if len(hits) != 0 {
+		t.Errorf("Search after delete: got %d hits, want 0", len(hits))
+	}
+}
+
+func TestRepo_Search_FollowsRefTextUpdates(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	ref := testhelper.SeedRefEntry(t, pool, "ref-"+uuid.New().String()[:8])
+	e := testhelper.SeedEntry(t, pool, user.ID, ref.ID)
+
+	// The entry's sense and translations inherit the catalog text, so
+	// catalog edits must refresh its search vector.
+	_, err := pool.Exec(ctx, `UPDATE ref_senses SET definition = 'A gentle westerly breeze' WHERE id = $1`, ref.Senses[0].ID)
+	if err != nil {
+		t.Fatalf("update ref_sense: %v", err)
+	}
+	_, err = pool.Exec(ctx, `UPDATE ref_translations SET text = 'зефир' WHERE id = $1`, ref.Senses[0].Translations[0].ID)
+	if err != nil {
+		t.Fatalf("update ref_translation: %v", err)
+	}
+
+	for _, query := range []string{"breeze", "зефир"} {
+		hits, err := repo.Search(ctx, user.ID, query, 10)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", query, err)
+		}
+		if len(hits) != 1 || hits[0].Entry.ID != e.ID {
+			t.Errorf("Search(%q): got %d hits, want entry %v", query, len(hits), e.ID)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// FindSimilarCandidates
+// ---------------------------------------------------------------------------
+
+func TestRepo_FindSimilarCandidates_FormsOrEditDistance(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	for _, text := range []string{"run", "recieve", "examine", "abandonment"} {
+		e := buildEntry(user.ID, text, nil)
+		if _, err := repo.Create(ctx, &e); err != nil {
+			t.Fatalf("Create %q: %v", text, err)
+		}
+	}
+
+	got, err := repo.FindSimilarCandidates(ctx, user.ID, []string{"receive", "run"}, "receive", 2)
+	if err != nil {
+		t.Fatalf("FindSimilarCandidates: %v", err)
+	}
+
+	var texts []string
+	for _, e := range got {
+		texts = append(texts, e.TextNormalized)
+	}
+	if len(texts) != 2 || texts[0] != "recieve" || texts[1] != "run" {
+		t.Errorf("FindSimilarCandidates: got %v, want [recieve run]", texts)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RestoreBatch / CountDeletedBefore
+// ---------------------------------------------------------------------------
+
+func TestRepo_RestoreBatch_RetentionBoundary(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	threshold := time.Now().UTC().Add(-30 * 24 * time.Hour).Truncate(time.Microsecond)
+
+	seedDeleted := func(text string, deletedAt time.Time) uuid.UUID {
+		t.Helper()
+		e := buildEntry(user.ID, text, nil)
+		if _, err := repo.Create(ctx, &e); err != nil {
+			t.Fatalf("Create %q: %v", text, err)
+		}
+		if _, err := pool.Exec(ctx, `UPDATE entries SET deleted_at = $2 WHERE id = $1`, e.ID, deletedAt); err != nil {
+			t.Fatalf("set deleted_at: %v", err)
+		}
+		return e.ID
+	}
+
+	atBoundary := seedDeleted("boundary", threshold)
+	inside := seedDeleted("inside", threshold.Add(time.Hour))
+	expired := seedDeleted("expired", threshold.Add(-time.Second))
+
+	// A deleted entry whose text was re-added stays deleted.
+	seedDeleted("readded", threshold.Add(time.Hour))
+	active := buildEntry(user.ID, "readded", nil)
+	if _, err := repo.Create(ctx, &active); err != nil {
+		t.Fatalf("Create active: %v", err)
+	}
+
+	pending, err := repo.CountDeletedBefore(ctx, user.ID, threshold)
+	if err != nil {
+		t.Fatalf("CountDeletedBefore: %v", err)
+	}
+	if pending != 1 {
+		t.Errorf("CountDeletedBefore = %d, want 1", pending)
+	}
+
+	restored, err := repo.RestoreBatch(ctx, user.ID, threshold)
+	if err != nil {
+		t.Fatalf("RestoreBatch: %v", err)
+	}
+	if len(restored) != 2 {
+		t.Fatalf("RestoreBatch: restored %d, want 2", len(restored))
+	}
+
+	for _, id := range []uuid.UUID{atBoundary, inside} {
+		if _, err := repo.GetByID(ctx, user.ID, id); err != nil {
+			t.Errorf("GetByID(%v) after restore: %v", id, err)
+		}
+	}
+	_, err = repo.GetByID(ctx, user.ID, expired)
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
@@ -28,7 +28,7 @@ func (q *Queries) CountEntriesByUser(ctx context.Context, userID uuid.UUID) (int
 const createEntry = `-- name: CreateEntry :one
 INSERT INTO entries (id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at
+RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at, favorite
 `
 
 type CreateEntryParams struct {
@@ -64,13 +64,14 @@ func (q *Queries) CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Favorite,
 	)
 	return i, err
 }
 
 const getEntriesByIDs = `-- name: GetEntriesByIDs :many
 SELECT id, user_id, ref_entry_id, text, text_normalized, notes,
-       created_at, updated_at, deleted_at
+       created_at, updated_at, deleted_at, favorite
 FROM entries
 WHERE user_id = $1 AND id = ANY($2::uuid[]) AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -100,6 +101,7 @@ func (q *Queries) GetEntriesByIDs(ctx context.Context, arg GetEntriesByIDsParams
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Favorite,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +115,7 @@ func (q *Queries) GetEntriesByIDs(ctx context.Context, arg GetEntriesByIDsParams
 
 const getEntryByID = `-- name: GetEntryByID :one
 SELECT id, user_id, ref_entry_id, text, text_normalized, notes,
-       created_at, updated_at, deleted_at
+       created_at, updated_at, deleted_at, favorite
 FROM entries
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
@@ -136,13 +138,14 @@ func (q *Queries) GetEntryByID(ctx context.Context, arg GetEntryByIDParams) (Ent
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Favorite,
 	)
 	return i, err
 }
 
 const getEntryByText = `-- name: GetEntryByText :one
 SELECT id, user_id, ref_entry_id, text, text_normalized, notes,
-       created_at, updated_at, deleted_at
+       created_at, updated_at, deleted_at, favorite
 FROM entries
 WHERE user_id = $1 AND text_normalized = $2 AND deleted_at IS NULL
 `
@@ -165,6 +168,7 @@ func (q *Queries) GetEntryByText(ctx context.Context, arg GetEntryByTextParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Favorite,
 	)
 	return i, err
 }
@@ -172,12 +176,17 @@ func (q *Queries) GetEntryByText(ctx context.Context, arg GetEntryByTextParams)
 const hardDeleteOldEntries = `-- name: HardDeleteOldEntries :execrows
 DELETE FROM entries
 WHERE id IN (
-    SELECT e.id FROM entries e WHERE e.deleted_at < $1 LIMIT 100
+    SELECT e.id FROM entries e WHERE e.deleted_at < $1 LIMIT $2::int
 )
 `
 
-func (q *Queries) HardDeleteOldEntries(ctx context.Context, deletedAt *time.Time) (int64, error) {
-	result, err := q.db.Exec(ctx, hardDeleteOldEntries, deletedAt)
+type HardDeleteOldEntriesParams struct {
+	DeletedAt *time.Time `json:"deleted_at"`
+	BatchSize int32      `json:"batch_size"`
+}
+
+func (q *Queries) HardDeleteOldEntries(ctx context.Context, arg HardDeleteOldEntriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, hardDeleteOldEntries, arg.DeletedAt, arg.BatchSize)
 	if err != nil {
 		return 0, err
 	}
@@ -188,7 +197,7 @@ const restoreEntry = `-- name: RestoreEntry :one
 UPDATE entries
 SET deleted_at = NULL, updated_at = now()
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
-RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at
+RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at, favorite
 `
 
 type RestoreEntryParams struct {
@@ -209,6 +218,7 @@ func (q *Queries) RestoreEntry(ctx context.Context, arg RestoreEntryParams) (Ent
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Favorite,
 	)
 	return i, err
 }
@@ -229,11 +239,42 @@ func (q *Queries) SoftDeleteEntry(ctx context.Context, arg SoftDeleteEntryParams
 	return err
 }
 
+const updateEntryFavorite = `-- name: UpdateEntryFavorite :one
+UPDATE entries
+SET favorite = $3, updated_at = now()
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
+RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at, favorite
+`
+
+type UpdateEntryFavoriteParams struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Favorite bool
+}
+
+func (q *Queries) UpdateEntryFavorite(ctx context.Context, arg UpdateEntryFavoriteParams) (Entry, error) {
+	row := q.db.QueryRow(ctx, updateEntryFavorite, arg.ID, arg.UserID, arg.Favorite)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RefEntryID,
+		&i.Text,
+		&i.TextNormalized,
+		&i.Notes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Favorite,
+	)
+	return i, err
+}
+
 const updateEntryNotes = `-- name: UpdateEntryNotes :one
 UPDATE entries
 SET notes = $3, updated_at = now()
 WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
-RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at
+RETURNING id, user_id, ref_entry_id, text, text_normalized, notes, created_at, updated_at, deleted_at, favorite
 `
 
 type UpdateEntryNotesParams struct {
@@ -255,6 +296,7 @@ func (q *Queries) UpdateEntryNotes(ctx context.Context, arg UpdateEntryNotesPara
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Favorite,
 	)
 	return i, err
 }
@@ -290,6 +290,8 @@ type EnrichmentQueue struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int32
+	NextRetryAt  *time.Time
 }
 
 type Entry struct {
@@ -302,6 +304,7 @@ type Entry struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time
+	Favorite       bool
 }
 
 type EntryImage struct {
@@ -290,6 +290,8 @@ type EnrichmentQueue struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int32
+	NextRetryAt  *time.Time
 }
 
 type Entry struct {
@@ -0,0 +1,68 @@
+// Package llmimport implements the llm-import processed-files log using PostgreSQL.
+// All queries use raw SQL (no sqlc); the table is only touched by cmd/llm-import.
+package llmimport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	postgres "github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+)
+
+// Repo provides llm_import_log persistence backed by PostgreSQL.
+type Repo struct {
+	pool *pgxpool.Pool
+}
+
+// New creates a new llm-import log repository.
+func New(pool *pgxpool.Pool) *Repo {
+	return &Repo{pool: pool}
+}
+
+const filterImportedSQL = `
+SELECT content_hash FROM llm_import_log WHERE content_hash = ANY($1::text[])`
+
+const recordImportSQL = `
+INSERT INTO llm_import_log (content_hash, file_name, word)
+VALUES ($1, $2, $3)
+ON CONFLICT (content_hash) DO UPDATE
+SET file_name = EXCLUDED.file_name, word = EXCLUDED.word, imported_at = now()`
+
+// FilterImported returns the subset of hashes that are already recorded.
+func (r *Repo) FilterImported(ctx context.Context, hashes []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(hashes) == 0 {
+		return result, nil
+	}
+
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx, filterImportedSQL, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("llmimport.FilterImported: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("llmimport.FilterImported scan: %w", err)
+		}
+		result[hash] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("llmimport.FilterImported rows: %w", err)
+	}
+	return result, nil
+}
+
+// RecordImport marks a file's content as imported. Re-recording the same
+// content (e.g. after a forced re-import) refreshes the row.
+func (r *Repo) RecordImport(ctx context.Context, contentHash, fileName, word string) error {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	if _, err := q.Exec(ctx, recordImportSQL, contentHash, fileName, word); err != nil {
+		return fmt.Errorf("llmimport.RecordImport: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package llmimport_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/llmimport"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/testhelper"
+)
+
+func TestRepo_RecordAndFilterImported(t *testing.T) {
+	pool := testhelper.SetupTestDB(t)
+	repo := llmimport.New(pool)
+	ctx := context.Background()
+
+	imported, fresh := uuid.NewString(), uuid.NewString()
+
+	if err := repo.RecordImport(ctx, imported, "abandon.json", "abandon"); err != nil {
+		t.Fatalf("RecordImport: %v", err)
+	}
+	// Recording the same hash again is idempotent.
+	if err := repo.RecordImport(ctx, imported, "abandon-copy.json", "abandon"); err != nil {
+		t.Fatalf("RecordImport again: %v", err)
+	}
+
+	got, err := repo.FilterImported(ctx, []string{imported, fresh})
+	if err != nil {
+		t.Fatalf("FilterImported: %v", err)
+	}
+	if !got[imported] || got[fresh] || len(got) != 1 {
+		t.Errorf("FilterImported = %v, want only %s", got, imported)
+	}
+}
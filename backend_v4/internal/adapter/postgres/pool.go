@@ -10,19 +10,15 @@ import (
 )
 
 // NewPool creates a PostgreSQL connection pool configured from DatabaseConfig.
-// It parses the DSN, applies pool settings (max/min conns, lifetimes), pings
-// the database for fail-fast validation, and returns the ready pool.
+// It parses the DSN, applies pool settings (max/min conns, lifetimes, connect
+// timeout), pings the database for fail-fast validation, and returns the
+// ready pool.
 func NewPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
-	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
+	poolCfg, err := poolConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("parse database DSN: %w", err)
+		return nil, err
 	}
 
-	poolCfg.MaxConns = cfg.MaxConns
-	poolCfg.MinConns = cfg.MinConns
-	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
-	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
-
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("create connection pool: %w", err)
@@ -35,3 +31,45 @@ func NewPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, err
 
 	return pool, nil
 }
+
+// NewReplicaPool creates a pool for the read replica at cfg.ReplicaDSN with
+// the same pool settings as the primary. It returns a nil pool when no
+// replica is configured, in which case reads stay on the primary.
+func NewReplicaPool(ctx context.Context, cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	if cfg.ReplicaDSN == "" {
+		return nil, nil
+	}
+	cfg.DSN = cfg.ReplicaDSN
+	pool, err := NewPool(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("replica: %w", err)
+	}
+	return pool, nil
+}
+
+// poolConfig parses the DSN and applies the pool settings from cfg. Zero
+// settings keep the pgx default, or the value given in the DSN.
+func poolConfig(cfg config.DatabaseConfig) (*pgxpool.Config, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("parse database DSN: %w", err)
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.ConnectTimeout > 0 {
+		poolCfg.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
+	}
+
+	return poolCfg, nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/config"
+)
+
+func TestPoolConfig_AppliesSettings(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DatabaseConfig{
+		DSN:             "postgres://u:p@localhost:5432/testdb",
+		MaxConns:        40,
+		MinConns:        3,
+		MaxConnLifetime: 2 * time.Hour,
+		MaxConnIdleTime: 10 * time.Minute,
+		ConnectTimeout:  7 * time.Second,
+	}
+
+	got, err := poolConfig(cfg)
+	if err != nil {
+		t.Fatalf("poolConfig: %v", err)
+	}
+	if got.MaxConns != 40 || got.MinConns != 3 {
+		t.Errorf("conns = max %d, min %d; want 40, 3", got.MaxConns, got.MinConns)
+	}
+	if got.MaxConnLifetime != 2*time.Hour || got.MaxConnIdleTime != 10*time.Minute {
+		t.Errorf("lifetime = %s, idle = %s; want 2h, 10m", got.MaxConnLifetime, got.MaxConnIdleTime)
+	}
+	if got.ConnConfig.ConnectTimeout != 7*time.Second {
+		t.Errorf("ConnectTimeout = %s, want 7s", got.ConnConfig.ConnectTimeout)
+	}
+}
+
+func TestPoolConfig_ZeroKeepsDSNSettings(t *testing.T) {
+	t.Parallel()
+
+	got, err := poolConfig(config.DatabaseConfig{
+		DSN: "postgres://u:p@localhost:5432/testdb?pool_max_conns=12&connect_timeout=3",
+	})
+	if err != nil {
+		t.Fatalf("poolConfig: %v", err)
+	}
+	if got.MaxConns != 12 {
+		t.Errorf("MaxConns = %d, want 12 from the DSN", got.MaxConns)
+	}
+	if got.ConnConfig.ConnectTimeout != 3*time.Second {
+		t.Errorf("ConnectTimeout = %s, want 3s from the DSN", got.ConnConfig.ConnectTimeout)
+	}
+}
+
+func TestPoolConfig_InvalidDSN(t *testing.T) {
+	t.Parallel()
+
+	if _, err := poolConfig(config.DatabaseConfig{DSN: "postgres://u:p@localhost:5432/testdb?pool_max_conns=x"}); err == nil {
+		t.Fatal("expected error for invalid DSN")
+	}
+}
+
+func TestNewReplicaPool_NotConfigured(t *testing.T) {
+	t.Parallel()
+
+	pool, err := NewReplicaPool(context.Background(), config.DatabaseConfig{DSN: "postgres://u:p@localhost:5432/testdb"})
+	if err != nil || pool != nil {
+		t.Fatalf("NewReplicaPool = %v, %v; want nil pool without a replica DSN", pool, err)
+	}
+}
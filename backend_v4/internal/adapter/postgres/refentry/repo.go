@@ -22,13 +22,23 @@ import (
 
 // Repo provides reference catalog persistence backed by PostgreSQL.
 type Repo struct {
-	pool *pgxpool.Pool
-	txm  *postgres.TxManager
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool // Search
+	txm    *postgres.TxManager
 }
 
 // New creates a new reference catalog repository.
 func New(pool *pgxpool.Pool, txm *postgres.TxManager) *Repo {
-	return &Repo{pool: pool, txm: txm}
+	return &Repo{pool: pool, reader: pool, txm: txm}
+}
+
+// SetReader routes the repository's read-only search queries to reader,
+// typically a read-replica pool. Queries inside a transaction still run on
+// the transaction. A nil reader keeps them on the primary pool.
+func (r *Repo) SetReader(reader *pgxpool.Pool) {
+	if reader != nil {
+		r.reader = reader
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -81,7 +91,7 @@ func (r *Repo) Search(ctx context.Context, query string, limit int) ([]domain.Re
 		return []domain.RefEntry{}, nil
 	}
 
-	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.reader))
 
 	rows, err := q.SearchRefEntries(ctx, sqlc.SearchRefEntriesParams{
 		Query: query,
@@ -99,6 +109,43 @@ func (r *Repo) Search(ctx context.Context, query string, limit int) ([]domain.Re
 	return entries, nil
 }
 
+// pickFromPoolSQL picks the entry at position seed mod pool size from the
+// ID-ordered pool. An empty pool yields no row: NULLIF turns the modulus into
+// NULL and OFFSET NULL means no offset.
+const pickFromPoolSQL = `
+WITH pool AS (
+    SELECT re.id FROM ref_entries re
+    WHERE ($1::text[] IS NULL OR re.cefr_level = ANY($1::text[]))
+      AND ($2::int = 0 OR re.frequency_rank <= $2)
+      AND ($3::uuid IS NULL OR NOT EXISTS (
+          SELECT 1 FROM entries e
+          WHERE e.user_id = $3 AND e.ref_entry_id = re.id AND e.deleted_at IS NULL))
+)
+SELECT id FROM pool
+ORDER BY id
+OFFSET $4::bigint % NULLIF((SELECT count(*) FROM pool), 0)
+LIMIT 1`
+
+// PickFromPool deterministically picks one ref_entry ID matching the filter:
+// the one at position seed mod pool size in ID order. Only the picked ID
+// leaves the database. seed must be non-negative. Returns domain.ErrNotFound
+// when the pool is empty.
+func (r *Repo) PickFromPool(ctx context.Context, filter domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var cefrLevels []string
+	if len(filter.CEFRLevels) > 0 {
+		cefrLevels = filter.CEFRLevels
+	}
+
+	var id uuid.UUID
+	err := querier.QueryRow(ctx, pickFromPoolSQL, cefrLevels, filter.MaxFrequencyRank, filter.ExcludeUserID, seed).Scan(&id)
+	if err != nil {
+		return uuid.Nil, mapError(err, "ref_entry pool", uuid.Nil)
+	}
+	return id, nil
+}
+
 // ---------------------------------------------------------------------------
 // Write operations
 // ---------------------------------------------------------------------------
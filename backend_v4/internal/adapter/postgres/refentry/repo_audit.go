@@ -0,0 +1,133 @@
+package refentry
+
+import (
+	"context"
+	"fmt"
+
+	postgres "github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// ---------------------------------------------------------------------------
+// Catalog quality audit
+// ---------------------------------------------------------------------------
+
+// catalogGapsCTE flags, per ref_entry, which key data it lacks. A definition
+// shorter than $1 characters counts as a stub; an entry with no senses has
+// only stub definitions.
+const catalogGapsCTE = `
+WITH gaps AS (
+    SELECT e.id, e.text, e.frequency_rank,
+           NOT EXISTS (
+               SELECT 1 FROM ref_senses s JOIN ref_translations t ON t.ref_sense_id = s.id
+               WHERE s.ref_entry_id = e.id) AS no_translations,
+           NOT EXISTS (
+               SELECT 1 FROM ref_senses s JOIN ref_examples x ON x.ref_sense_id = s.id
+               WHERE s.ref_entry_id = e.id) AS no_examples,
+           NOT EXISTS (
+               SELECT 1 FROM ref_pronunciations p WHERE p.ref_entry_id = e.id) AS no_pronunciations,
+           NOT EXISTS (
+               SELECT 1 FROM ref_senses s
+               WHERE s.ref_entry_id = e.id AND char_length(btrim(s.definition)) >= $1) AS stub_definitions
+    FROM ref_entries e
+)`
+
+// catalogGapsSQL counts gaps per frequency band. $2 holds the lower bound of
+// each ranked band; unranked entries get a NULL band.
+const catalogGapsSQL = catalogGapsCTE + `
+SELECT width_bucket(frequency_rank, $2::int[]) AS band,
+       count(*),
+       count(*) FILTER (WHERE no_translations),
+       count(*) FILTER (WHERE no_examples),
+       count(*) FILTER (WHERE no_pronunciations),
+       count(*) FILTER (WHERE stub_definitions)
+FROM gaps
+GROUP BY band`
+
+// worstCatalogGapsSQL lists the entries with the most gaps, the most
+// frequent first among ties.
+const worstCatalogGapsSQL = catalogGapsCTE + `
+SELECT id, text, frequency_rank, no_translations, no_examples, no_pronunciations, stub_definitions
+FROM gaps
+WHERE no_translations OR no_examples OR no_pronunciations OR stub_definitions
+ORDER BY no_translations::int + no_examples::int + no_pronunciations::int + stub_definitions::int DESC,
+         frequency_rank NULLS LAST, text
+LIMIT $2`
+
+// CatalogGaps counts ref_entries that lack translations, examples,
+// pronunciations or a non-stub definition, grouped by frequency band.
+// bandLimits are the ascending inclusive upper ranks of the bands: [1000, 5000]
+// yields 1-1000, 1001-5000 and 5001+, followed by a band of unranked entries.
+// Every band is returned, including empty ones. A definition shorter than
+// stubLen characters counts as a stub.
+func (r *Repo) CatalogGaps(ctx context.Context, bandLimits []int, stubLen int) ([]domain.CatalogGapCounts, error) {
+	bands := make([]domain.CatalogGapCounts, len(bandLimits)+2)
+	lower := make([]int, len(bandLimits)+1)
+	lower[0] = 1
+	for i, limit := range bandLimits {
+		bands[i] = domain.CatalogGapCounts{Ranked: true, MinRank: lower[i], MaxRank: limit}
+		lower[i+1] = limit + 1
+	}
+	bands[len(bandLimits)] = domain.CatalogGapCounts{Ranked: true, MinRank: lower[len(bandLimits)]}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := querier.Query(ctx, catalogGapsSQL, stubLen, lower)
+	if err != nil {
+		return nil, fmt.Errorf("count catalog gaps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			band *int
+			c    domain.CatalogGapCounts
+		)
+		if err := rows.Scan(&band, &c.Entries, &c.NoTranslations, &c.NoExamples, &c.NoPronunciations, &c.StubDefinitions); err != nil {
+			return nil, fmt.Errorf("scan catalog gaps: %w", err)
+		}
+
+		// width_bucket numbers the ranked bands from 1; ranks below 1 land in
+		// bucket 0 and are counted with the first band.
+		b := &bands[len(bands)-1]
+		if band != nil {
+			b = &bands[max(*band, 1)-1]
+		}
+		b.Entries += c.Entries
+		b.NoTranslations += c.NoTranslations
+		b.NoExamples += c.NoExamples
+		b.NoPronunciations += c.NoPronunciations
+		b.StubDefinitions += c.StubDefinitions
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catalog gaps: %w", err)
+	}
+
+	return bands, nil
+}
+
+// WorstCatalogGaps returns up to limit ref_entries lacking the most kinds of
+// data, ordered by gap count and then by frequency rank. stubLen is as in
+// CatalogGaps.
+func (r *Repo) WorstCatalogGaps(ctx context.Context, stubLen, limit int) ([]domain.CatalogGapEntry, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := querier.Query(ctx, worstCatalogGapsSQL, stubLen, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list catalog gaps: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.CatalogGapEntry
+	for rows.Next() {
+		var e domain.CatalogGapEntry
+		if err := rows.Scan(&e.RefEntryID, &e.Text, &e.FrequencyRank,
+			&e.NoTranslations, &e.NoExamples, &e.NoPronunciations, &e.StubDefinitions); err != nil {
+			return nil, fmt.Errorf("scan catalog gap entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catalog gap entries: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,138 @@
+package refentry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/testhelper"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// auditStubLen is shorter than the definitions SeedRefEntry writes.
+const auditStubLen = 10
+
+// gapFixture is a small catalog with known gaps, ranked from base so that it
+// fills frequency bands no other test uses.
+type gapFixture struct {
+	complete, noTranslations, bare, stub domain.RefEntry
+}
+
+func seedGapFixture(t *testing.T, pool *pgxpool.Pool, base int) gapFixture {
+	t.Helper()
+	ctx := context.Background()
+	exec := func(sql string, args ...any) {
+		t.Helper()
+		if _, err := pool.Exec(ctx, sql, args...); err != nil {
+			t.Fatalf("%s: %v", sql, err)
+		}
+	}
+	seed := func(name string, rank int) domain.RefEntry {
+		e := testhelper.SeedRefEntry(t, pool, name+"-"+uuid.New().String()[:8])
+		exec(`UPDATE ref_entries SET frequency_rank = $2 WHERE id = $1`, e.ID, rank)
+		return e
+	}
+
+	var f gapFixture
+	f.complete = seed("audit-complete", base+10)
+	f.noTranslations = seed("audit-no-translations", base+20)
+	exec(`DELETE FROM ref_translations WHERE ref_sense_id IN (SELECT id FROM ref_senses WHERE ref_entry_id = $1)`, f.noTranslations.ID)
+
+	// No senses and no pronunciations: every gap at once.
+	f.bare = seed("audit-bare", base+150)
+	exec(`DELETE FROM ref_senses WHERE ref_entry_id = $1`, f.bare.ID)
+	exec(`DELETE FROM ref_pronunciations WHERE ref_entry_id = $1`, f.bare.ID)
+
+	f.stub = seed("audit-stub", base+160)
+	exec(`UPDATE ref_senses SET definition = ' n. ' WHERE ref_entry_id = $1`, f.stub.ID)
+	exec(`DELETE FROM ref_pronunciations WHERE ref_entry_id = $1`, f.stub.ID)
+
+	return f
+}
+
+func TestRepo_CatalogGaps_ByBand(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	const base = 900_000_000
+	seedGapFixture(t, pool, base)
+
+	bands, err := repo.CatalogGaps(ctx, []int{base, base + 100, base + 200}, auditStubLen)
+	if err != nil {
+		t.Fatalf("CatalogGaps: %v", err)
+	}
+	if len(bands) != 5 {
+		t.Fatalf("bands: got %d, want 5 (3 limits + open band + unranked)", len(bands))
+	}
+	if last := bands[4]; last.Ranked {
+		t.Errorf("last band should hold unranked entries, got %+v", last)
+	}
+	if open := bands[3]; !open.Ranked || open.MinRank != base+201 || open.MaxRank != 0 {
+		t.Errorf("open band = %+v, want ranks %d and up", open, base+201)
+	}
+
+	want := []domain.CatalogGapCounts{
+		{Ranked: true, MinRank: base + 1, MaxRank: base + 100, Entries: 2, NoTranslations: 1},
+		{Ranked: true, MinRank: base + 101, MaxRank: base + 200, Entries: 2,
+			NoTranslations: 1, NoExamples: 1, NoPronunciations: 2, StubDefinitions: 2},
+	}
+	for i, w := range want {
+		if got := bands[i+1]; got != w {
+			t.Errorf("band %d = %+v, want %+v", i+1, got, w)
+		}
+	}
+}
+
+func TestRepo_WorstCatalogGaps(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	f := seedGapFixture(t, pool, 910_000_000)
+
+	entries, err := repo.WorstCatalogGaps(ctx, auditStubLen, 1_000_000)
+	if err != nil {
+		t.Fatalf("WorstCatalogGaps: %v", err)
+	}
+
+	pos := make(map[uuid.UUID]int, len(entries))
+	byID := make(map[uuid.UUID]domain.CatalogGapEntry, len(entries))
+	for i, e := range entries {
+		pos[e.RefEntryID] = i
+		byID[e.RefEntryID] = e
+	}
+
+	if _, ok := byID[f.complete.ID]; ok {
+		t.Error("complete entry should not be listed")
+	}
+	for _, tc := range []struct {
+		entry domain.RefEntry
+		gaps  int
+	}{{f.bare, 4}, {f.stub, 2}, {f.noTranslations, 1}} {
+		got, ok := byID[tc.entry.ID]
+		if !ok {
+			t.Fatalf("%s missing from worst offenders", tc.entry.Text)
+		}
+		if got.Gaps() != tc.gaps {
+			t.Errorf("%s: %d gaps (%+v), want %d", tc.entry.Text, got.Gaps(), got, tc.gaps)
+		}
+	}
+	if !(pos[f.bare.ID] < pos[f.stub.ID] && pos[f.stub.ID] < pos[f.noTranslations.ID]) {
+		t.Errorf("order: bare %d, stub %d, no-translations %d; want most gaps first",
+			pos[f.bare.ID], pos[f.stub.ID], pos[f.noTranslations.ID])
+	}
+	if !byID[f.stub.ID].StubDefinitions || !byID[f.stub.ID].NoPronunciations {
+		t.Errorf("stub entry flags = %+v, want stub definitions and no pronunciations", byID[f.stub.ID])
+	}
+
+	limited, err := repo.WorstCatalogGaps(ctx, auditStubLen, 1)
+	if err != nil {
+		t.Fatalf("WorstCatalogGaps limit 1: %v", err)
+	}
+	if len(limited) != 1 {
+		t.Errorf("limit 1: got %d entries", len(limited))
+	}
+}
@@ -56,12 +56,16 @@ func (r *Repo) BulkInsertSenses(ctx context.Context, senses []domain.RefSense) (
 			p := string(*s.PartOfSpeech)
 			pos = &p
 		}
+		sources := s.Sources
+		if sources == nil {
+			sources = []string{}
+		}
 
 		batch.Queue(
-			`INSERT INTO ref_senses (id, ref_entry_id, definition, part_of_speech, cefr_level, notes, source_slug, position, created_at)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			`INSERT INTO ref_senses (id, ref_entry_id, definition, part_of_speech, cefr_level, notes, source_slug, sources, position, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			 ON CONFLICT (id) DO NOTHING`,
-			s.ID, s.RefEntryID, s.Definition, pos, s.CEFRLevel, s.Notes, s.SourceSlug, s.Position, s.CreatedAt,
+			s.ID, s.RefEntryID, s.Definition, pos, s.CEFRLevel, s.Notes, s.SourceSlug, sources, s.Position, s.CreatedAt,
 		)
 	}
 
@@ -503,10 +507,285 @@ func (r *Repo) BulkUpdateEntryMetadata(ctx context.Context, updates []domain.Ent
 	return r.sendBatchExec(ctx, batch)
 }
 
+// BulkUpdateSensePositions sets ref_senses.position for each given sense.
+// Rows already at the requested position are left untouched. Returns the
+// number of updated rows.
+func (r *Repo) BulkUpdateSensePositions(ctx context.Context, updates []domain.RefSensePosition) (int, error) {
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, u := range updates {
+		batch.Queue(
+			`UPDATE ref_senses SET position = $2 WHERE id = $1 AND position <> $2`,
+			u.SenseID, u.Position,
+		)
+	}
+
+	return r.sendBatchExec(ctx, batch)
+}
+
+// MergeSenses writes the outcome of merging near-duplicate senses. Every
+// sense in kept gets its Sources and Position, and its translations and
+// examples their RefSenseID and Position; rows already in that state are left
+// untouched. Each sense in merged (merged sense ID → kept sense ID) is then
+// deleted, after user senses linked to it are re-pointed to the kept one; its
+// children that are not in kept go with it. Runs in the transaction from ctx,
+// or in its own one if there is none. Returns the number of deleted senses.
+func (r *Repo) MergeSenses(ctx context.Context, kept []domain.RefSense, merged map[uuid.UUID]uuid.UUID) (int, error) {
+	if _, inTx := postgres.QuerierFromCtx(ctx, r.pool).(pgx.Tx); inTx {
+		return r.mergeSenses(ctx, kept, merged)
+	}
+
+	var deleted int
+	err := r.txm.RunInTx(ctx, func(txCtx context.Context) error {
+		n, err := r.mergeSenses(txCtx, kept, merged)
+		deleted = n
+		return err
+	})
+	return deleted, err
+}
+
+func (r *Repo) mergeSenses(ctx context.Context, kept []domain.RefSense, merged map[uuid.UUID]uuid.UUID) (int, error) {
+	if len(merged) == 0 {
+		return 0, nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, s := range kept {
+		sources := s.Sources
+		if sources == nil {
+			sources = []string{}
+		}
+		batch.Queue(
+			`UPDATE ref_senses SET sources = $2, position = $3
+			 WHERE id = $1 AND (sources <> $2 OR position <> $3)`,
+			s.ID, sources, s.Position,
+		)
+		for _, tr := range s.Translations {
+			batch.Queue(
+				`UPDATE ref_translations SET ref_sense_id = $2, position = $3
+				 WHERE id = $1 AND (ref_sense_id <> $2 OR position <> $3)`,
+				tr.ID, tr.RefSenseID, tr.Position,
+			)
+		}
+		for _, ex := range s.Examples {
+			batch.Queue(
+				`UPDATE ref_examples SET ref_sense_id = $2, position = $3
+				 WHERE id = $1 AND (ref_sense_id <> $2 OR position <> $3)`,
+				ex.ID, ex.RefSenseID, ex.Position,
+			)
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(merged))
+	for from, into := range merged {
+		batch.Queue(`UPDATE senses SET ref_sense_id = $2 WHERE ref_sense_id = $1`, from, into)
+		ids = append(ids, from)
+	}
+
+	if _, err := r.sendBatchExec(ctx, batch); err != nil {
+		return 0, fmt.Errorf("merge senses: %w", err)
+	}
+
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	tag, err := q.Exec(ctx, `DELETE FROM ref_senses WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("delete merged senses: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
 // ---------------------------------------------------------------------------
 // Lookup methods (for seeder pipeline)
 // ---------------------------------------------------------------------------
 
+// GetEntryIDsAfter returns up to limit ref_entry IDs greater than after, in
+// ID order. Pass uuid.Nil to start from the beginning; used to walk the whole
+// catalog in batches.
+func (r *Repo) GetEntryIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx,
+		`SELECT id FROM ref_entries WHERE id > $1 ORDER BY id LIMIT $2`,
+		after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get entry IDs after: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0, limit)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan entry ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate entry IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetSensePositionsByEntryIDs returns a map of entry_id → senses ordered by
+// position (ties by created_at, id). Only ID, RefEntryID, Position and
+// CreatedAt are populated.
+func (r *Repo) GetSensePositionsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID][]domain.RefSense, error) {
+	if len(entryIDs) == 0 {
+		return map[uuid.UUID][]domain.RefSense{}, nil
+	}
+
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx,
+		`SELECT id, ref_entry_id, position, created_at
+		 FROM ref_senses
+		 WHERE ref_entry_id = ANY($1)
+		 ORDER BY ref_entry_id, position, created_at, id`,
+		entryIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get sense positions: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID][]domain.RefSense, len(entryIDs))
+	for rows.Next() {
+		var (
+			s   domain.RefSense
+			pos int32
+		)
+		if err := rows.Scan(&s.ID, &s.RefEntryID, &pos, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan sense position: %w", err)
+		}
+		s.Position = int(pos)
+		result[s.RefEntryID] = append(result[s.RefEntryID], s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sense positions: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetMultiSourceEntryIDsAfter returns up to limit IDs greater than after of
+// ref_entries whose senses come from more than one source, in ID order. Pass
+// uuid.Nil to start from the beginning.
+func (r *Repo) GetMultiSourceEntryIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx,
+		`SELECT ref_entry_id
+		 FROM ref_senses
+		 WHERE ref_entry_id > $1
+		 GROUP BY ref_entry_id
+		 HAVING count(DISTINCT source_slug) > 1
+		 ORDER BY ref_entry_id
+		 LIMIT $2`,
+		after, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get multi-source entry IDs: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0, limit)
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan entry ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate entry IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetSensesByEntryIDs returns the senses of the given entries, Sources
+// included, with their translations and examples, ordered by entry and
+// position (ties by created_at, id).
+func (r *Repo) GetSensesByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]domain.RefSense, error) {
+	if len(entryIDs) == 0 {
+		return []domain.RefSense{}, nil
+	}
+
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx,
+		`SELECT id, ref_entry_id, definition, part_of_speech, cefr_level, notes, source_slug, sources, position, created_at
+		 FROM ref_senses
+		 WHERE ref_entry_id = ANY($1)
+		 ORDER BY ref_entry_id, position, created_at, id`,
+		entryIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get senses: %w", err)
+	}
+	defer rows.Close()
+
+	var senses []domain.RefSense
+	for rows.Next() {
+		var (
+			s          domain.RefSense
+			definition *string
+			pos        *string
+			position   int32
+		)
+		if err := rows.Scan(&s.ID, &s.RefEntryID, &definition, &pos, &s.CEFRLevel, &s.Notes, &s.SourceSlug, &s.Sources, &position, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan sense: %w", err)
+		}
+		if definition != nil {
+			s.Definition = *definition
+		}
+		if pos != nil {
+			p := domain.PartOfSpeech(*pos)
+			s.PartOfSpeech = &p
+		}
+		s.Position = int(position)
+		senses = append(senses, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate senses: %w", err)
+	}
+	if len(senses) == 0 {
+		return []domain.RefSense{}, nil
+	}
+
+	senseIDs := make([]uuid.UUID, len(senses))
+	for i, s := range senses {
+		senseIDs[i] = s.ID
+	}
+	sq := sqlc.New(q)
+	trRows, err := sq.GetRefTranslationsBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get translations: %w", err)
+	}
+	exRows, err := sq.GetRefExamplesBySenseIDs(ctx, senseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get examples: %w", err)
+	}
+
+	index := make(map[uuid.UUID]int, len(senses))
+	for i, s := range senses {
+		index[s.ID] = i
+	}
+	for _, row := range trRows {
+		tr := toDomainRefTranslation(row)
+		s := &senses[index[tr.RefSenseID]]
+		s.Translations = append(s.Translations, tr)
+	}
+	for _, row := range exRows {
+		ex := toDomainRefExample(row)
+		s := &senses[index[ex.RefSenseID]]
+		s.Examples = append(s.Examples, ex)
+	}
+
+	return senses, nil
+}
+
 // GetEntryIDsByNormalizedTexts returns a map of text_normalized → UUID
 // for all matching entries.
 func (r *Repo) GetEntryIDsByNormalizedTexts(ctx context.Context, texts []string) (map[string]uuid.UUID, error) {
@@ -636,6 +915,42 @@ func (r *Repo) GetPronunciationIPAsByEntryIDs(ctx context.Context, entryIDs []uu
 	return result, nil
 }
 
+// GetSenseDefinitionsByEntryIDs returns a map of entry_id → set of existing sense definitions.
+// Used by the seeder dry-run report to tell duplicate entries from ones that would gain senses.
+func (r *Repo) GetSenseDefinitionsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID]map[string]bool, error) {
+	if len(entryIDs) == 0 {
+		return map[uuid.UUID]map[string]bool{}, nil
+	}
+
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	rows, err := q.Query(ctx,
+		`SELECT ref_entry_id, definition FROM ref_senses WHERE ref_entry_id = ANY($1)`,
+		entryIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get sense definitions: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]map[string]bool, len(entryIDs))
+	for rows.Next() {
+		var entryID uuid.UUID
+		var definition string
+		if err := rows.Scan(&entryID, &definition); err != nil {
+			return nil, fmt.Errorf("scan sense definition: %w", err)
+		}
+		if result[entryID] == nil {
+			result[entryID] = make(map[string]bool)
+		}
+		result[entryID][definition] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sense definitions: %w", err)
+	}
+
+	return result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Registry method
 // ---------------------------------------------------------------------------
@@ -2,11 +2,13 @@ package refentry_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 
+	postgres "github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/testhelper"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
@@ -896,6 +898,295 @@ func TestRepo_GetCoverageByEntryIDs(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Sense positions (GetSensePositionsByEntryIDs / BulkUpdateSensePositions)
+// ---------------------------------------------------------------------------
+
+func TestRepo_SensePositions_RoundTrip(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	entry := makeRefEntry("positions-" + uuid.New().String()[:8])
+	if _, err := repo.BulkInsertEntries(ctx, []domain.RefEntry{entry}); err != nil {
+		t.Fatalf("BulkInsertEntries: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	senses := make([]domain.RefSense, 3)
+	for i, pos := range []int{7, 0, 3} {
+		senses[i] = domain.RefSense{
+			ID: uuid.New(), RefEntryID: entry.ID, Definition: "def",
+			SourceSlug: "test-source", Position: pos, CreatedAt: now,
+		}
+	}
+	if _, err := repo.BulkInsertSenses(ctx, senses); err != nil {
+		t.Fatalf("BulkInsertSenses: %v", err)
+	}
+
+	got, err := repo.GetSensePositionsByEntryIDs(ctx, []uuid.UUID{entry.ID})
+	if err != nil {
+		t.Fatalf("GetSensePositionsByEntryIDs: %v", err)
+	}
+	ordered := got[entry.ID]
+	if len(ordered) != 3 {
+		t.Fatalf("expected 3 senses, got %d", len(ordered))
+	}
+	wantOrder := []uuid.UUID{senses[1].ID, senses[2].ID, senses[0].ID}
+	for i, s := range ordered {
+		if s.ID != wantOrder[i] {
+			t.Errorf("sense[%d]: got %s, want %s", i, s.ID, wantOrder[i])
+		}
+	}
+
+	updated, err := repo.BulkUpdateSensePositions(ctx, []domain.RefSensePosition{
+		{SenseID: senses[1].ID, Position: 0}, // unchanged
+		{SenseID: senses[2].ID, Position: 1},
+		{SenseID: senses[0].ID, Position: 2},
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateSensePositions: %v", err)
+	}
+	if updated != 2 {
+		t.Errorf("expected 2 updated rows, got %d", updated)
+	}
+
+	var positions []int32
+	rows, err := pool.Query(ctx, `SELECT position FROM ref_senses WHERE ref_entry_id = $1 ORDER BY position`, entry.ID)
+	if err != nil {
+		t.Fatalf("query positions: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var p int32
+		if err := rows.Scan(&p); err != nil {
+			t.Fatalf("scan position: %v", err)
+		}
+		positions = append(positions, p)
+	}
+	for i, p := range positions {
+		if int(p) != i {
+			t.Errorf("positions not dense: %v", positions)
+			break
+		}
+	}
+}
+
+func TestRepo_GetEntryIDsAfter_Paginates(t *testing.T) {
+	t.Parallel()
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	entries := []domain.RefEntry{
+		makeRefEntry("page-a-" + uuid.New().String()[:8]),
+		makeRefEntry("page-b-" + uuid.New().String()[:8]),
+	}
+	if _, err := repo.BulkInsertEntries(ctx, entries); err != nil {
+		t.Fatalf("BulkInsertEntries: %v", err)
+	}
+
+	first, err := repo.GetEntryIDsAfter(ctx, uuid.Nil, 1)
+	if err != nil {
+		t.Fatalf("GetEntryIDsAfter: %v", err)
+	}
+	if len(first) != 1 {
+		t.Fatalf("expected 1 ID, got %d", len(first))
+	}
+
+	next, err := repo.GetEntryIDsAfter(ctx, first[0], 1)
+	if err != nil {
+		t.Fatalf("GetEntryIDsAfter: %v", err)
+	}
+	if len(next) != 1 || next[0].String() <= first[0].String() {
+		t.Errorf("second page %v should follow %s", next, first[0])
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Cross-source sense merge
+// ---------------------------------------------------------------------------
+
+func TestRepo_MergeSenses(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	seeded := testhelper.SeedRefEntry(t, pool, "merge-senses-"+uuid.New().String()[:8])
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	kept := domain.RefSense{ID: uuid.New(), RefEntryID: seeded.ID, Definition: "a domesticated mammal", SourceSlug: "wiktionary", Position: 10, CreatedAt: now}
+	dup := domain.RefSense{ID: uuid.New(), RefEntryID: seeded.ID, Definition: "domesticated mammal", SourceSlug: "llm", Position: 11, CreatedAt: now}
+	if _, err := repo.BulkInsertSenses(ctx, []domain.RefSense{kept, dup}); err != nil {
+		t.Fatalf("BulkInsertSenses: %v", err)
+	}
+	moved := domain.RefTranslation{ID: uuid.New(), RefSenseID: dup.ID, Text: "пёс", SourceSlug: "llm"}
+	if _, err := repo.BulkInsertTranslations(ctx, []domain.RefTranslation{moved}); err != nil {
+		t.Fatalf("BulkInsertTranslations: %v", err)
+	}
+
+	ids, err := repo.GetMultiSourceEntryIDsAfter(ctx, uuid.Nil, 1_000_000)
+	if err != nil {
+		t.Fatalf("GetMultiSourceEntryIDsAfter: %v", err)
+	}
+	found := false
+	for _, id := range ids {
+		found = found || id == seeded.ID
+	}
+	if !found {
+		t.Fatalf("entry %s with senses from two sources not listed", seeded.ID)
+	}
+
+	kept.Sources = []string{"llm"}
+	moved.RefSenseID = kept.ID
+	kept.Translations = []domain.RefTranslation{moved}
+	deleted, err := repo.MergeSenses(ctx, []domain.RefSense{kept}, map[uuid.UUID]uuid.UUID{dup.ID: kept.ID})
+	if err != nil {
+		t.Fatalf("MergeSenses: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("expected 1 deleted, got %d", deleted)
+	}
+
+	senses, err := repo.GetSensesByEntryIDs(ctx, []uuid.UUID{seeded.ID})
+	if err != nil {
+		t.Fatalf("GetSensesByEntryIDs: %v", err)
+	}
+	var got *domain.RefSense
+	for i := range senses {
+		if senses[i].ID == dup.ID {
+			t.Fatal("merged sense still present")
+		}
+		if senses[i].ID == kept.ID {
+			got = &senses[i]
+		}
+	}
+	if got == nil {
+		t.Fatal("kept sense missing")
+	}
+	if len(got.Sources) != 1 || got.Sources[0] != "llm" {
+		t.Errorf("Sources = %v, want [llm]", got.Sources)
+	}
+	if len(got.Translations) != 1 || got.Translations[0].ID != moved.ID {
+		t.Errorf("translations = %+v, want the moved translation", got.Translations)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// CopyFrom
+// ---------------------------------------------------------------------------
+
+func TestRepo_CopyFrom_RowCounts(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	var (
+		entries      []domain.RefEntry
+		senses       []domain.RefSense
+		translations []domain.RefTranslation
+		examples     []domain.RefExample
+		entryIDs     []uuid.UUID
+	)
+	for i := range 3 {
+		e := buildRefEntry(fmt.Sprintf("copy-%d-%s", i, uuid.New().String()[:8]))
+		entries = append(entries, e)
+		entryIDs = append(entryIDs, e.ID)
+		for _, s := range e.Senses {
+			senses = append(senses, s)
+			translations = append(translations, s.Translations...)
+			examples = append(examples, s.Examples...)
+		}
+	}
+	wantTotal := len(entries) + len(senses) + len(translations) + len(examples)
+
+	inserted, err := repo.CopyFrom(ctx, entries, senses, translations, examples)
+	if err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	if inserted != wantTotal {
+		t.Errorf("inserted: got %d, want %d", inserted, wantTotal)
+	}
+
+	counts := []struct {
+		query string
+		want  int
+	}{
+		{`SELECT count(*) FROM ref_entries WHERE id = ANY($1)`, len(entries)},
+		{`SELECT count(*) FROM ref_senses WHERE ref_entry_id = ANY($1)`, len(senses)},
+		{`SELECT count(*) FROM ref_translations t JOIN ref_senses s ON s.id = t.ref_sense_id WHERE s.ref_entry_id = ANY($1)`, len(translations)},
+		{`SELECT count(*) FROM ref_examples x JOIN ref_senses s ON s.id = x.ref_sense_id WHERE s.ref_entry_id = ANY($1)`, len(examples)},
+	}
+	for _, c := range counts {
+		var got int
+		if err := pool.QueryRow(ctx, c.query, entryIDs).Scan(&got); err != nil {
+			t.Fatalf("%s: %v", c.query, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: got %d, want %d", c.query, got, c.want)
+		}
+	}
+
+	var pos string
+	if err := pool.QueryRow(ctx, `SELECT part_of_speech FROM ref_senses WHERE id = $1`, senses[0].ID).Scan(&pos); err != nil {
+		t.Fatalf("read part_of_speech: %v", err)
+	}
+	if pos != string(*senses[0].PartOfSpeech) {
+		t.Errorf("part_of_speech: got %q, want %q", pos, *senses[0].PartOfSpeech)
+	}
+
+	// A second COPY of the same rows skips them all.
+	again, err := repo.CopyFrom(ctx, entries, senses, translations, examples)
+	if err != nil {
+		t.Fatalf("second CopyFrom: %v", err)
+	}
+	if again != 0 {
+		t.Errorf("second CopyFrom inserted %d, want 0", again)
+	}
+}
+
+func TestRepo_CopyFrom_InExistingTx(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	txm := postgres.NewTxManager(pool)
+	ctx := context.Background()
+
+	first := makeRefEntry("copy-tx-1-" + uuid.New().String()[:8])
+	second := makeRefEntry("copy-tx-2-" + uuid.New().String()[:8])
+
+	err := txm.RunInTx(ctx, func(txCtx context.Context) error {
+		// Two calls in one transaction reuse the staging table names.
+		if _, err := repo.CopyFrom(txCtx, []domain.RefEntry{first}, nil, nil, nil); err != nil {
+			return err
+		}
+		_, err := repo.CopyFrom(txCtx, []domain.RefEntry{second}, nil, nil, nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+
+	var got int
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM ref_entries WHERE id = ANY($1)`,
+		[]uuid.UUID{first.ID, second.ID}).Scan(&got); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if got != 2 {
+		t.Errorf("entries: got %d, want 2", got)
+	}
+}
+
+func TestRepo_CopyFrom_Empty(t *testing.T) {
+	t.Parallel()
+	repo, _ := newRepo(t)
+
+	inserted, err := repo.CopyFrom(context.Background(), nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("CopyFrom: %v", err)
+	}
+	if inserted != 0 {
+		t.Errorf("expected 0, got %d", inserted)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Test helpers
 // ---------------------------------------------------------------------------
@@ -0,0 +1,164 @@
+package refentry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	postgres "github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// copyTable describes how one ref table is loaded through COPY. Rows are
+// streamed into a temporary staging table and moved into the target with
+// INSERT ... SELECT, which keeps the ON CONFLICT DO NOTHING semantics of the
+// pgx.Batch inserts (COPY itself cannot skip conflicting rows).
+type copyTable struct {
+	target   string
+	staging  string
+	columns  string // staging column definitions
+	names    []string
+	selectAs string // select list that casts staging columns to target types
+	conflict string
+}
+
+var (
+	copyEntries = copyTable{
+		target:  "ref_entries",
+		staging: "copy_ref_entries",
+		columns: `id UUID, text TEXT, text_normalized TEXT, frequency_rank INT,
+			cefr_level TEXT, is_core_lexicon BOOLEAN, created_at TIMESTAMPTZ`,
+		names:    []string{"id", "text", "text_normalized", "frequency_rank", "cefr_level", "is_core_lexicon", "created_at"},
+		selectAs: "id, text, text_normalized, frequency_rank, cefr_level, is_core_lexicon, created_at",
+		conflict: "(text_normalized)",
+	}
+	copySenses = copyTable{
+		target:  "ref_senses",
+		staging: "copy_ref_senses",
+		columns: `id UUID, ref_entry_id UUID, definition TEXT, part_of_speech TEXT, cefr_level TEXT,
+			notes TEXT, source_slug TEXT, position INT, created_at TIMESTAMPTZ`,
+		names:    []string{"id", "ref_entry_id", "definition", "part_of_speech", "cefr_level", "notes", "source_slug", "position", "created_at"},
+		selectAs: "id, ref_entry_id, definition, part_of_speech::part_of_speech, cefr_level, notes, source_slug, position, created_at",
+		conflict: "(id)",
+	}
+	copyTranslations = copyTable{
+		target:   "ref_translations",
+		staging:  "copy_ref_translations",
+		columns:  `id UUID, ref_sense_id UUID, text TEXT, source_slug TEXT, position INT`,
+		names:    []string{"id", "ref_sense_id", "text", "source_slug", "position"},
+		selectAs: "id, ref_sense_id, text, source_slug, position",
+		conflict: "(id)",
+	}
+	copyExamples = copyTable{
+		target:   "ref_examples",
+		staging:  "copy_ref_examples",
+		columns:  `id UUID, ref_sense_id UUID, sentence TEXT, translation TEXT, source_slug TEXT, position INT`,
+		names:    []string{"id", "ref_sense_id", "sentence", "translation", "source_slug", "position"},
+		selectAs: "id, ref_sense_id, sentence, translation, source_slug, position",
+		conflict: "(id)",
+	}
+)
+
+// CopyFrom inserts entries, senses, translations and examples using COPY,
+// which is much faster than pgx.Batch for large seeds. IDs must be assigned
+// by the caller; tables are loaded parent first so foreign keys resolve.
+// Rows that already exist are skipped like in the BulkInsert methods. Runs in
+// the transaction from ctx, or in its own one if there is none. Returns the
+// number of actually inserted rows.
+func (r *Repo) CopyFrom(ctx context.Context, entries []domain.RefEntry, senses []domain.RefSense, translations []domain.RefTranslation, examples []domain.RefExample) (int, error) {
+	if _, inTx := postgres.QuerierFromCtx(ctx, r.pool).(pgx.Tx); inTx {
+		return r.copyFrom(ctx, entries, senses, translations, examples)
+	}
+
+	var inserted int
+	err := r.txm.RunInTx(ctx, func(txCtx context.Context) error {
+		n, err := r.copyFrom(txCtx, entries, senses, translations, examples)
+		inserted = n
+		return err
+	})
+	return inserted, err
+}
+
+func (r *Repo) copyFrom(ctx context.Context, entries []domain.RefEntry, senses []domain.RefSense, translations []domain.RefTranslation, examples []domain.RefExample) (int, error) {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+	total := 0
+
+	n, err := copyRows(ctx, q, copyEntries, len(entries), func(i int) []any {
+		e := entries[i]
+		return []any{e.ID, e.Text, e.TextNormalized, domain.IntPtrToInt32Ptr(e.FrequencyRank), e.CEFRLevel, e.IsCoreLexicon, e.CreatedAt}
+	})
+	if err != nil {
+		return total, err
+	}
+	total += n
+
+	n, err = copyRows(ctx, q, copySenses, len(senses), func(i int) []any {
+		s := senses[i]
+		var pos *string
+		if s.PartOfSpeech != nil {
+			p := string(*s.PartOfSpeech)
+			pos = &p
+		}
+		return []any{s.ID, s.RefEntryID, s.Definition, pos, s.CEFRLevel, s.Notes, s.SourceSlug, s.Position, s.CreatedAt}
+	})
+	if err != nil {
+		return total, err
+	}
+	total += n
+
+	n, err = copyRows(ctx, q, copyTranslations, len(translations), func(i int) []any {
+		tr := translations[i]
+		return []any{tr.ID, tr.RefSenseID, tr.Text, tr.SourceSlug, tr.Position}
+	})
+	if err != nil {
+		return total, err
+	}
+	total += n
+
+	n, err = copyRows(ctx, q, copyExamples, len(examples), func(i int) []any {
+		ex := examples[i]
+		return []any{ex.ID, ex.RefSenseID, ex.Sentence, ex.Translation, ex.SourceSlug, ex.Position}
+	})
+	if err != nil {
+		return total, err
+	}
+	total += n
+
+	return total, nil
+}
+
+// copyRows loads n rows into t via its staging table and returns the number
+// of rows inserted into the target.
+func copyRows(ctx context.Context, q postgres.Querier, t copyTable, n int, row func(i int) []any) (int, error) {
+	if n == 0 {
+		return 0, nil
+	}
+
+	if _, err := q.Exec(ctx, fmt.Sprintf(
+		`CREATE TEMP TABLE %s (%s) ON COMMIT DROP`, t.staging, t.columns,
+	)); err != nil {
+		return 0, fmt.Errorf("create %s: %w", t.staging, err)
+	}
+
+	if _, err := q.CopyFrom(ctx, pgx.Identifier{t.staging}, t.names, pgx.CopyFromSlice(n, func(i int) ([]any, error) {
+		return row(i), nil
+	})); err != nil {
+		return 0, fmt.Errorf("copy %s: %w", t.target, err)
+	}
+
+	tag, err := q.Exec(ctx, fmt.Sprintf(
+		`INSERT INTO %s (%s) SELECT %s FROM %s ON CONFLICT %s DO NOTHING`,
+		t.target, strings.Join(t.names, ", "), t.selectAs, t.staging, t.conflict,
+	))
+	if err != nil {
+		return 0, fmt.Errorf("insert %s: %w", t.target, err)
+	}
+
+	if _, err := q.Exec(ctx, `DROP TABLE `+t.staging); err != nil {
+		return 0, fmt.Errorf("drop %s: %w", t.staging, err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
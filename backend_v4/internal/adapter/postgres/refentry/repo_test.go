@@ -783,3 +783,35 @@ func assertIsDomainError(t *testing.T, err error, target error) {
 		t.Fatalf("expected error wrapping %v, got: %v", target, err)
 	}
 }
+
+func TestRepo_PickFromPool_ExcludesUserEntries(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	owned := testhelper.SeedRefEntry(t, pool, "pool-owned-"+uuid.New().String()[:8])
+	testhelper.SeedEntry(t, pool, user.ID, owned.ID)
+
+	// Every seed walks a different position of the pool; none may land on
+	// the owned entry.
+	filter := domain.RefEntryPoolFilter{ExcludeUserID: &user.ID}
+	for seed := range int64(20) {
+		id, err := repo.PickFromPool(ctx, filter, seed)
+		if err != nil {
+			t.Fatalf("PickFromPool(%d): %v", seed, err)
+		}
+		if id == owned.ID {
+			t.Fatalf("PickFromPool(%d) picked an entry already in the user's dictionary", seed)
+		}
+	}
+}
+
+func TestRepo_PickFromPool_EmptyPool(t *testing.T) {
+	t.Parallel()
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.PickFromPool(ctx, domain.RefEntryPoolFilter{CEFRLevels: []string{"no-such-level"}}, 7)
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
@@ -3,8 +3,8 @@
 -- ---------------------------------------------------------------------------
 
 -- name: CreateReviewLog :one
-INSERT INTO review_logs (id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at)
-VALUES (@id, @card_id, @user_id, @grade, @prev_state, @duration_ms, @reviewed_at)
+INSERT INTO review_logs (id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at, idempotency_key)
+VALUES (@id, @card_id, @user_id, @grade, @prev_state, @duration_ms, @reviewed_at, @idempotency_key)
 RETURNING id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at;
 
 -- name: GetByCardID :many
@@ -1,6 +1,6 @@
 // Package reviewlog implements the ReviewLog repository using PostgreSQL.
 // Simple CRUD queries use sqlc; queries requiring JOINs (CountToday,
-// GetStreakDays, GetByCardIDs) use raw SQL.
+// GetStreakDays, GetAllActiveDays, GetByCardIDs, GetDifficultWords) use raw SQL.
 package reviewlog
 
 import (
@@ -29,12 +29,22 @@ type ReviewLogWithCardID struct {
 
 // Repo provides review log persistence backed by PostgreSQL.
 type Repo struct {
-	pool *pgxpool.Pool
+	pool   *pgxpool.Pool
+	reader *pgxpool.Pool // stats: streaks, active days and aggregations
 }
 
 // New creates a new review log repository.
 func New(pool *pgxpool.Pool) *Repo {
-	return &Repo{pool: pool}
+	return &Repo{pool: pool, reader: pool}
+}
+
+// SetReader routes the repository's read-only stats queries to reader,
+// typically a read-replica pool. Queries inside a transaction still run on
+// the transaction. A nil reader keeps them on the primary pool.
+func (r *Repo) SetReader(reader *pgxpool.Pool) {
+	if reader != nil {
+		r.reader = reader
+	}
 }
 
 // ---------------------------------------------------------------------------
@@ -55,14 +65,43 @@ GROUP BY review_date
 ORDER BY review_date DESC
 LIMIT $3`
 
+const getActiveDaysSQL = `
+SELECT DISTINCT date_trunc('day', reviewed_at AT TIME ZONE $3)::date AS review_date
+FROM review_logs
+WHERE user_id = $1 AND reviewed_at >= $2
+ORDER BY review_date DESC`
+
+const getGradeCountsByDaySQL = `
+SELECT
+    date_trunc('day', reviewed_at AT TIME ZONE $3)::date AS review_date,
+    count(*) FILTER (WHERE grade = 'AGAIN') AS again_count,
+    count(*) FILTER (WHERE grade = 'HARD') AS hard_count,
+    count(*) FILTER (WHERE grade = 'GOOD') AS good_count,
+    count(*) FILTER (WHERE grade = 'EASY') AS easy_count
+FROM review_logs
+WHERE user_id = $1 AND reviewed_at >= $2
+GROUP BY review_date
+ORDER BY review_date`
+
 const getByCardIDsSQL = `
 SELECT id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at
 FROM review_logs
 WHERE card_id = ANY($1::uuid[])
 ORDER BY card_id, reviewed_at DESC`
 
+const deleteOlderThanSQL = `
+DELETE FROM review_logs
+WHERE id IN (SELECT id FROM review_logs WHERE reviewed_at < $1 LIMIT $2)`
+
+const countOlderThanSQL = `SELECT count(*) FROM review_logs WHERE reviewed_at < $1`
+
 const countByCardIDSQL = `SELECT count(*) FROM review_logs WHERE card_id = $1`
 
+const getByIdempotencyKeySQL = `
+SELECT id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at
+FROM review_logs
+WHERE card_id = $1 AND idempotency_key = $2`
+
 // countNewTodaySQL depends on the JSON key "state" in cardSnapshotJSON.
 // If you rename cardSnapshotJSON.State's json tag, update this query too.
 const countNewTodaySQL = `
@@ -82,12 +121,57 @@ SELECT
 FROM review_logs
 WHERE card_id = $1`
 
+const getByCardIDCursorSQL = `
+SELECT id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at
+FROM review_logs
+WHERE card_id = $1
+  AND ($2::timestamptz IS NULL OR (reviewed_at, id) < ($2, $3::uuid))
+ORDER BY reviewed_at DESC, id DESC
+LIMIT $4`
+
+const getUserAggregationSQL = `
+SELECT
+    count(*) AS total,
+    count(*) FILTER (WHERE grade = 'AGAIN') AS again_count,
+    count(*) FILTER (WHERE grade = 'HARD') AS hard_count,
+    count(*) FILTER (WHERE grade = 'GOOD') AS good_count,
+    count(*) FILTER (WHERE grade = 'EASY') AS easy_count,
+    count(*) FILTER (WHERE reviewed_at >= $2 AND prev_state->>'state' = 'REVIEW') AS recent_mature,
+    count(*) FILTER (WHERE reviewed_at >= $2 AND prev_state->>'state' = 'REVIEW' AND grade <> 'AGAIN') AS recent_mature_recalled
+FROM review_logs
+WHERE user_id = $1`
+
+// getDifficultWordsSQL ranks the user's cards by AGAIN ratio over their whole
+// history. Cards with fewer than $2 reviews or no AGAIN grade are left out.
+const getDifficultWordsSQL = `
+SELECT c.entry_id, c.id, e.text,
+       count(*) AS total,
+       count(*) FILTER (WHERE rl.grade = 'AGAIN') AS again_count
+FROM review_logs rl
+JOIN cards c ON c.id = rl.card_id
+JOIN entries e ON e.id = c.entry_id
+WHERE rl.user_id = $1 AND e.deleted_at IS NULL
+GROUP BY c.id, c.entry_id, e.text
+HAVING count(*) >= $2 AND count(*) FILTER (WHERE rl.grade = 'AGAIN') > 0
+ORDER BY count(*) FILTER (WHERE rl.grade = 'AGAIN')::float8 / count(*) DESC, count(*) DESC, c.id
+LIMIT $3`
+
 const getByPeriodSQL = `
 SELECT id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at
 FROM review_logs
 WHERE user_id = $1 AND reviewed_at >= $2 AND reviewed_at <= $3
 ORDER BY reviewed_at DESC`
 
+const getByPeriodPageSQL = `
+SELECT rl.id, rl.card_id, rl.user_id, rl.grade, rl.prev_state, rl.duration_ms, rl.reviewed_at, e.text
+FROM review_logs rl
+JOIN cards c ON c.id = rl.card_id
+JOIN entries e ON e.id = c.entry_id
+WHERE rl.user_id = $1 AND rl.reviewed_at >= $2 AND rl.reviewed_at <= $3
+  AND ($4::timestamptz IS NULL OR (rl.reviewed_at, rl.id) > ($4, $5::uuid))
+ORDER BY rl.reviewed_at, rl.id
+LIMIT $6`
+
 // ---------------------------------------------------------------------------
 // Read operations
 // ---------------------------------------------------------------------------
@@ -131,6 +215,53 @@ func (r *Repo) GetByCardID(ctx context.Context, cardID uuid.UUID, limit, offset
 	return logs, total, nil
 }
 
+// GetByCardIDCursor returns up to limit review logs for a card that come after
+// the cursor in (reviewed_at, id) DESC order; a nil cursor starts from the
+// newest. Keyset paging stays stable when new reviews are inserted. Returns
+// logs, whether more remain, and error.
+func (r *Repo) GetByCardIDCursor(ctx context.Context, cardID uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var (
+		afterAt *time.Time
+		afterID uuid.UUID
+	)
+	if after != nil {
+		afterAt = &after.ReviewedAt
+		afterID = after.ID
+	}
+
+	// Fetch one extra row to detect whether another page exists.
+	rows, err := querier.Query(ctx, getByCardIDCursorSQL, cardID, afterAt, afterID, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("get review_logs by card_id cursor: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []*domain.ReviewLog
+	for rows.Next() {
+		var row sqlc.CreateReviewLogRow
+		if err := rows.Scan(&row.ID, &row.CardID, &row.UserID, &row.Grade, &row.PrevState, &row.DurationMs, &row.ReviewedAt); err != nil {
+			return nil, false, fmt.Errorf("scan review_log: %w", err)
+		}
+		rl, err := toDomainReviewLog(row)
+		if err != nil {
+			return nil, false, err
+		}
+		logs = append(logs, &rl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("iterate review_logs: %w", err)
+	}
+
+	hasMore := len(logs) > limit
+	if hasMore {
+		logs = logs[:limit]
+	}
+
+	return logs, hasMore, nil
+}
+
 // GetLastByCardID returns the most recent review log for a card.
 // Returns domain.ErrNotFound if no review logs exist for the card.
 func (r *Repo) GetLastByCardID(ctx context.Context, cardID uuid.UUID) (*domain.ReviewLog, error) {
@@ -149,6 +280,27 @@ func (r *Repo) GetLastByCardID(ctx context.Context, cardID uuid.UUID) (*domain.R
 	return &rl, nil
 }
 
+// GetByIdempotencyKey returns the review log of a card created with the given
+// idempotency key. Returns domain.ErrNotFound if there is none.
+func (r *Repo) GetByIdempotencyKey(ctx context.Context, cardID uuid.UUID, key string) (*domain.ReviewLog, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var row sqlc.CreateReviewLogRow
+	if err := querier.QueryRow(ctx, getByIdempotencyKeySQL, cardID, key).Scan(
+		&row.ID, &row.CardID, &row.UserID, &row.Grade, &row.PrevState, &row.DurationMs, &row.ReviewedAt,
+	); err != nil {
+		return nil, mapError(err, "review_log", cardID)
+	}
+
+	rl, err := toDomainReviewLog(row)
+	if err != nil {
+		return nil, err
+	}
+	rl.IdempotencyKey = &key
+
+	return &rl, nil
+}
+
 // GetByCardIDs returns review logs for multiple cards (batch for DataLoader).
 // Results include CardID for grouping by the caller.
 func (r *Repo) GetByCardIDs(ctx context.Context, cardIDs []uuid.UUID) ([]ReviewLogWithCardID, error) {
@@ -233,7 +385,7 @@ func (r *Repo) CountToday(ctx context.Context, userID uuid.UUID, dayStart time.T
 // dayStart is the start of the current day; the query goes back lastNDays from it.
 // timezone is an IANA timezone name (e.g. "America/New_York") used for day grouping.
 func (r *Repo) GetStreakDays(ctx context.Context, userID uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 
 	// dayStart is already the start of the first day. We go back lastNDays from dayStart.
 	from := dayStart.AddDate(0, 0, -lastNDays)
@@ -263,6 +415,61 @@ func (r *Repo) GetStreakDays(ctx context.Context, userID uuid.UUID, dayStart tim
 	return counts, nil
 }
 
+// GetGradeCountsByDay returns the user's review counts per grade for every
+// day since from on which they reviewed, oldest first. Days are calendar
+// days in timezone; days without reviews are left out.
+func (r *Repo) GetGradeCountsByDay(ctx context.Context, userID uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	rows, err := querier.Query(ctx, getGradeCountsByDaySQL, userID, from, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("get grade counts by day: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []domain.DayGradeCounts{}
+	for rows.Next() {
+		var dc domain.DayGradeCounts
+		if err := rows.Scan(&dc.Date, &dc.Grades.Again, &dc.Grades.Hard, &dc.Grades.Good, &dc.Grades.Easy); err != nil {
+			return nil, fmt.Errorf("scan grade counts: %w", err)
+		}
+		counts = append(counts, dc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate grade counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// GetActiveDays returns the distinct days since the given time on which the
+// user reviewed at least one card, ordered by date DESC. Days are grouped in
+// the IANA timezone. The since bound keeps the scan on the
+// (user_id, reviewed_at) index instead of the user's whole history.
+func (r *Repo) GetActiveDays(ctx context.Context, userID uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	rows, err := querier.Query(ctx, getActiveDaysSQL, userID, since, timezone)
+	if err != nil {
+		return nil, fmt.Errorf("get active days: %w", err)
+	}
+	defer rows.Close()
+
+	days := []time.Time{}
+	for rows.Next() {
+		var d time.Time
+		if err := rows.Scan(&d); err != nil {
+			return nil, fmt.Errorf("scan active day: %w", err)
+		}
+		days = append(days, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate active days: %w", err)
+	}
+
+	return days, nil
+}
+
 // ---------------------------------------------------------------------------
 // Write operations
 // ---------------------------------------------------------------------------
@@ -281,14 +488,20 @@ func (r *Repo) Create(ctx context.Context, rl *domain.ReviewLog) (*domain.Review
 		durationMs = pgtype.Int4{Int32: int32(*rl.DurationMs), Valid: true}
 	}
 
+	var idempotencyKey pgtype.Text
+	if rl.IdempotencyKey != nil {
+		idempotencyKey = pgtype.Text{String: *rl.IdempotencyKey, Valid: true}
+	}
+
 	row, err := q.CreateReviewLog(ctx, sqlc.CreateReviewLogParams{
-		ID:         rl.ID,
-		CardID:     rl.CardID,
-		UserID:     rl.UserID,
-		Grade:      sqlc.ReviewGrade(rl.Grade),
-		PrevState:  prevStateBytes,
-		DurationMs: durationMs,
-		ReviewedAt: rl.ReviewedAt,
+		ID:             rl.ID,
+		CardID:         rl.CardID,
+		UserID:         rl.UserID,
+		Grade:          sqlc.ReviewGrade(rl.Grade),
+		PrevState:      prevStateBytes,
+		DurationMs:     durationMs,
+		ReviewedAt:     rl.ReviewedAt,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		return nil, mapError(err, "review_log", rl.ID)
@@ -298,6 +511,7 @@ func (r *Repo) Create(ctx context.Context, rl *domain.ReviewLog) (*domain.Review
 	if err != nil {
 		return nil, err
 	}
+	result.IdempotencyKey = rl.IdempotencyKey
 
 	return &result, nil
 }
@@ -319,6 +533,33 @@ func (r *Repo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteOlderThan permanently removes review logs reviewed before threshold,
+// in batches of postgres.DefaultDeleteBatchSize. Returns the number of deleted logs.
+func (r *Repo) DeleteOlderThan(ctx context.Context, threshold time.Time) (int64, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	total, err := postgres.DeleteInBatches(ctx, postgres.DefaultDeleteBatchSize, func(ctx context.Context, limit int) (int64, error) {
+		tag, err := querier.Exec(ctx, deleteOlderThanSQL, threshold, limit)
+		if err != nil {
+			return 0, err
+		}
+		return tag.RowsAffected(), nil
+	})
+	if err != nil {
+		return total, fmt.Errorf("reviewlog.DeleteOlderThan: %w", err)
+	}
+	return total, nil
+}
+
+// CountOlderThan returns how many review logs DeleteOlderThan would remove
+// for the same threshold.
+func (r *Repo) CountOlderThan(ctx context.Context, threshold time.Time) (int64, error) {
+	var n int64
+	if err := postgres.QuerierFromCtx(ctx, r.pool).QueryRow(ctx, countOlderThanSQL, threshold).Scan(&n); err != nil {
+		return 0, fmt.Errorf("reviewlog.CountOlderThan: %w", err)
+	}
+	return n, nil
+}
+
 // CountNewToday returns the count of reviews for NEW-status cards since dayStart.
 func (r *Repo) CountNewToday(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error) {
 	querier := postgres.QuerierFromCtx(ctx, r.pool)
@@ -334,7 +575,7 @@ func (r *Repo) CountNewToday(ctx context.Context, userID uuid.UUID, dayStart tim
 // GetStatsByCardID returns aggregated review statistics for a card,
 // computed entirely in SQL (no loading of individual rows).
 func (r *Repo) GetStatsByCardID(ctx context.Context, cardID uuid.UUID) (domain.ReviewLogAggregation, error) {
-	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
 	var stats domain.ReviewLogAggregation
 	var avgDur *float64
 	err := querier.QueryRow(ctx, getStatsByCardIDSQL, cardID).Scan(
@@ -351,6 +592,51 @@ func (r *Repo) GetStatsByCardID(ctx context.Context, cardID uuid.UUID) (domain.R
 	return stats, nil
 }
 
+// GetUserAggregation returns review counts across all of a user's cards in a
+// single query. The recent-mature counts cover reviews since the given time.
+func (r *Repo) GetUserAggregation(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UserReviewAggregation, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	var agg domain.UserReviewAggregation
+	err := querier.QueryRow(ctx, getUserAggregationSQL, userID, since).Scan(
+		&agg.TotalReviews, &agg.AgainCount, &agg.HardCount, &agg.GoodCount, &agg.EasyCount,
+		&agg.RecentMatureReviews, &agg.RecentMatureRecalled,
+	)
+	if err != nil {
+		return domain.UserReviewAggregation{}, fmt.Errorf("get user review aggregation: %w", err)
+	}
+
+	return agg, nil
+}
+
+// GetDifficultWords returns up to limit of the user's entries ranked by the
+// share of their card's reviews graded AGAIN, highest first. Only cards with
+// at least minReviews reviews and one AGAIN grade are considered.
+func (r *Repo) GetDifficultWords(ctx context.Context, userID uuid.UUID, minReviews, limit int) ([]domain.DifficultWord, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.reader)
+
+	rows, err := querier.Query(ctx, getDifficultWordsSQL, userID, minReviews, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get difficult words: %w", err)
+	}
+	defer rows.Close()
+
+	words := make([]domain.DifficultWord, 0)
+	for rows.Next() {
+		var w domain.DifficultWord
+		if err := rows.Scan(&w.EntryID, &w.CardID, &w.Text, &w.TotalReviews, &w.AgainCount); err != nil {
+			return nil, fmt.Errorf("scan difficult word: %w", err)
+		}
+		w.LapseRate = float64(w.AgainCount) / float64(w.TotalReviews)
+		words = append(words, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate difficult words: %w", err)
+	}
+
+	return words, nil
+}
+
 // GetByPeriod returns review logs for a user within a time range,
 // ordered by reviewed_at DESC.
 func (r *Repo) GetByPeriod(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.ReviewLog, error) {
@@ -410,6 +696,50 @@ func (r *Repo) GetByPeriod(ctx context.Context, userID uuid.UUID, from, to time.
 	return logs, nil
 }
 
+// GetByPeriodPage returns up to limit review logs for a user within a time
+// range that come after the cursor in (reviewed_at, id) ASC order, each with
+// its entry's text; a nil cursor starts from the oldest. Callers page until
+// fewer than limit logs come back.
+func (r *Repo) GetByPeriodPage(ctx context.Context, userID uuid.UUID, from, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	var (
+		afterAt *time.Time
+		afterID uuid.UUID
+	)
+	if after != nil {
+		afterAt = &after.ReviewedAt
+		afterID = after.ID
+	}
+
+	rows, err := querier.Query(ctx, getByPeriodPageSQL, userID, from, to, afterAt, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get review_logs page by period: %w", err)
+	}
+	defer rows.Close()
+
+	logs := make([]domain.ReviewLogWithWord, 0, limit)
+	for rows.Next() {
+		var (
+			row  sqlc.CreateReviewLogRow
+			word string
+		)
+		if err := rows.Scan(&row.ID, &row.CardID, &row.UserID, &row.Grade, &row.PrevState, &row.DurationMs, &row.ReviewedAt, &word); err != nil {
+			return nil, fmt.Errorf("scan review_log: %w", err)
+		}
+		rl, err := toDomainReviewLog(row)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, domain.ReviewLogWithWord{ReviewLog: rl, Word: word})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review_logs: %w", err)
+	}
+
+	return logs, nil
+}
+
 // ---------------------------------------------------------------------------
 // JSONB serialization helpers for CardSnapshot (prev_state)
 // ---------------------------------------------------------------------------
@@ -3,6 +3,7 @@ package reviewlog_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -237,6 +238,53 @@ func TestRepo_GetLastByCardID_NotFound(t *testing.T) {
 	assertIsDomainError(t, err, domain.ErrNotFound)
 }
 
+// ---------------------------------------------------------------------------
+// GetByIdempotencyKey
+// ---------------------------------------------------------------------------
+
+func TestRepo_GetByIdempotencyKey(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	_, card := seedCard(t, pool)
+	_, otherCard := seedCard(t, pool)
+	key := "submit-" + uuid.New().String()
+
+	input := buildReviewLog(card.ID, domain.ReviewGradeGood, nil, nil)
+	input.IdempotencyKey = &key
+	created, err := repo.Create(ctx, &input)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.IdempotencyKey == nil || *created.IdempotencyKey != key {
+		t.Errorf("created IdempotencyKey: got %v, want %q", created.IdempotencyKey, key)
+	}
+
+	got, err := repo.GetByIdempotencyKey(ctx, card.ID, key)
+	if err != nil {
+		t.Fatalf("GetByIdempotencyKey: %v", err)
+	}
+	if got.ID != created.ID {
+		t.Errorf("ID: got %s, want %s", got.ID, created.ID)
+	}
+
+	// The key is unique per card only.
+	dup := buildReviewLog(card.ID, domain.ReviewGradeAgain, nil, nil)
+	dup.IdempotencyKey = &key
+	_, err = repo.Create(ctx, &dup)
+	assertIsDomainError(t, err, domain.ErrAlreadyExists)
+
+	other := buildReviewLog(otherCard.ID, domain.ReviewGradeGood, nil, nil)
+	other.IdempotencyKey = &key
+	if _, err := repo.Create(ctx, &other); err != nil {
+		t.Errorf("same key on another card: %v", err)
+	}
+
+	_, err = repo.GetByIdempotencyKey(ctx, card.ID, "unknown-"+key)
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
+
 // ---------------------------------------------------------------------------
 // Delete
 // ---------------------------------------------------------------------------
@@ -382,6 +430,106 @@ func TestRepo_GetStreakDays(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// GetGradeCountsByDay
+// ---------------------------------------------------------------------------
+
+func TestRepo_GetGradeCountsByDay(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user, card := seedCard(t, pool)
+
+	// Days are bucketed in Tokyo time (UTC+9).
+	at := func(day, hour int) time.Time { return time.Date(2025, time.January, day, hour, 0, 0, 0, time.UTC) }
+	fixture := []struct {
+		grade      domain.ReviewGrade
+		reviewedAt time.Time
+	}{
+		{domain.ReviewGradeGood, at(5, 10)},   // before the range
+		{domain.ReviewGradeAgain, at(10, 10)}, // Jan 10, 19:00 Tokyo
+		{domain.ReviewGradeGood, at(10, 11)},  // Jan 10, 20:00 Tokyo
+		{domain.ReviewGradeEasy, at(10, 16)},  // Jan 11, 01:00 Tokyo
+		{domain.ReviewGradeHard, at(12, 1)},   // Jan 12
+		{domain.ReviewGradeGood, at(12, 2)},   // Jan 12
+	}
+	for _, f := range fixture {
+		if _, err := pool.Exec(ctx,
+			`INSERT INTO review_logs (id, card_id, user_id, grade, reviewed_at) VALUES ($1, $2, $3, $4, $5)`,
+			uuid.New(), card.ID, user.ID, string(f.grade), f.reviewedAt,
+		); err != nil {
+			t.Fatalf("insert log: %v", err)
+		}
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	from := time.Date(2025, time.January, 10, 0, 0, 0, 0, tokyo)
+
+	got, err := repo.GetGradeCountsByDay(ctx, user.ID, from, "Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("GetGradeCountsByDay: %v", err)
+	}
+
+	want := []domain.DayGradeCounts{
+		{Date: time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC), Grades: domain.GradeCounts{Again: 1, Good: 1}},
+		{Date: time.Date(2025, time.January, 11, 0, 0, 0, 0, time.UTC), Grades: domain.GradeCounts{Easy: 1}},
+		{Date: time.Date(2025, time.January, 12, 0, 0, 0, 0, time.UTC), Grades: domain.GradeCounts{Hard: 1, Good: 1}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d days, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Grades != want[i].Grades {
+			t.Errorf("day %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRepo_GetActiveDays(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user, card := seedCard(t, pool)
+
+	// Two reviews today and one 400 days ago, outside the GetStreakDays window.
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	for _, reviewedAt := range []time.Time{now, now.Add(-time.Minute), now.AddDate(0, 0, -400)} {
+		_, err := pool.Exec(ctx,
+			`INSERT INTO review_logs (id, card_id, grade, reviewed_at) VALUES ($1, $2, $3, $4)`,
+			uuid.New(), card.ID, "GOOD", reviewedAt,
+		)
+		if err != nil {
+			t.Fatalf("insert log: %v", err)
+		}
+	}
+
+	days, err := repo.GetActiveDays(ctx, user.ID, now.AddDate(-2, 0, 0), "UTC")
+	if err != nil {
+		t.Fatalf("GetActiveDays: unexpected error: %v", err)
+	}
+
+	if len(days) != 2 {
+		t.Fatalf("expected 2 distinct days, got %d", len(days))
+	}
+	if !days[0].After(days[1]) {
+		t.Errorf("days not in DESC order: %s, %s", days[0], days[1])
+	}
+
+	// The since bound excludes the old review.
+	days, err = repo.GetActiveDays(ctx, user.ID, now.AddDate(0, 0, -30), "UTC")
+	if err != nil {
+		t.Fatalf("GetActiveDays: unexpected error: %v", err)
+	}
+	if len(days) != 1 {
+		t.Errorf("expected 1 day within the window, got %d", len(days))
+	}
+}
+
 // ---------------------------------------------------------------------------
 // GetByCardIDs batch
 // ---------------------------------------------------------------------------
@@ -677,10 +825,261 @@ func TestRepo_CountNewToday_MatchesJSONBKey(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// GetByCardIDCursor
+// ---------------------------------------------------------------------------
+
+func TestRepo_GetByCardIDCursor_StableUnderInserts(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	_, card := seedCard(t, pool)
+
+	base := time.Now().UTC().Add(-time.Hour).Truncate(time.Microsecond)
+	want := make(map[uuid.UUID]bool)
+	for i := range 5 {
+		rl := buildReviewLog(card.ID, domain.ReviewGradeGood, nil, nil)
+		rl.ReviewedAt = base.Add(time.Duration(i/2) * time.Minute) // pairs share a timestamp
+		if _, err := repo.Create(ctx, &rl); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		want[rl.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]int)
+	var after *domain.ReviewLogCursor
+	for page := 0; ; page++ {
+		logs, hasMore, err := repo.GetByCardIDCursor(ctx, card.ID, after, 2)
+		if err != nil {
+			t.Fatalf("GetByCardIDCursor page %d: %v", page, err)
+		}
+		for _, rl := range logs {
+			seen[rl.ID]++
+		}
+
+		if page == 0 {
+			rl := buildReviewLog(card.ID, domain.ReviewGradeEasy, nil, nil)
+			if _, err := repo.Create(ctx, &rl); err != nil {
+				t.Fatalf("Create mid-pagination: %v", err)
+			}
+		}
+
+		if !hasMore {
+			break
+		}
+		last := logs[len(logs)-1]
+		after = &domain.ReviewLogCursor{ReviewedAt: last.ReviewedAt, ID: last.ID}
+	}
+
+	for id := range want {
+		if seen[id] != 1 {
+			t.Errorf("log %s seen %d times, want 1", id, seen[id])
+		}
+	}
+	if len(seen) != len(want) {
+		t.Errorf("paged %d logs, want %d", len(seen), len(want))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// DeleteOlderThan
+// ---------------------------------------------------------------------------
+
+func TestRepo_DeleteOlderThan_ThresholdBoundary(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	_, card := seedCard(t, pool)
+
+	// A threshold far in the past keeps parallel tests' logs out of range.
+	threshold := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Time{
+		threshold.Add(-time.Microsecond), // deleted
+		threshold,                        // kept: strictly older only
+		threshold.Add(time.Microsecond),  // kept
+	} {
+		rl := buildReviewLog(card.ID, domain.ReviewGradeGood, nil, nil)
+		rl.ReviewedAt = at
+		if _, err := repo.Create(ctx, &rl); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	count, err := repo.CountOlderThan(ctx, threshold)
+	if err != nil {
+		t.Fatalf("CountOlderThan: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountOlderThan = %d, want 1", count)
+	}
+
+	deleted, err := repo.DeleteOlderThan(ctx, threshold)
+	if err != nil {
+		t.Fatalf("DeleteOlderThan: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	_, total, err := repo.GetByCardID(ctx, card.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("GetByCardID: %v", err)
+	}
+	if total != 2 {
+		t.Errorf("remaining logs = %d, want 2", total)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Test helpers
 // ---------------------------------------------------------------------------
 
+func TestRepo_GetUserAggregation_CountsGradesAndRecentMature(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user, card := seedCard(t, pool)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	mature := &domain.CardSnapshot{State: domain.CardStateReview, Stability: 30}
+
+	logs := []domain.ReviewLog{
+		{ID: uuid.New(), CardID: card.ID, Grade: domain.ReviewGradeGood, ReviewedAt: now.Add(-90 * 24 * time.Hour), PrevState: mature},
+		{ID: uuid.New(), CardID: card.ID, Grade: domain.ReviewGradeAgain, ReviewedAt: now.Add(-2 * time.Hour), PrevState: mature},
+		{ID: uuid.New(), CardID: card.ID, Grade: domain.ReviewGradeEasy, ReviewedAt: now.Add(-1 * time.Hour), PrevState: mature},
+		{ID: uuid.New(), CardID: card.ID, Grade: domain.ReviewGradeHard, ReviewedAt: now.Add(-1 * time.Hour)},
+	}
+	for i := range logs {
+		if _, err := repo.Create(ctx, &logs[i]); err != nil {
+			t.Fatalf("Create log %d: %v", i, err)
+		}
+	}
+
+	agg, err := repo.GetUserAggregation(ctx, user.ID, now.AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatalf("GetUserAggregation: %v", err)
+	}
+
+	want := domain.UserReviewAggregation{
+		TotalReviews:         4,
+		AgainCount:           1,
+		HardCount:            1,
+		GoodCount:            1,
+		EasyCount:            1,
+		RecentMatureReviews:  2,
+		RecentMatureRecalled: 1,
+	}
+	if agg != want {
+		t.Errorf("GetUserAggregation: got %+v, want %+v", agg, want)
+	}
+}
+
+func TestRepo_GetDifficultWords_RanksByLapseRateWithMinReviews(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	seed := func(grades ...domain.ReviewGrade) domain.Card {
+		t.Helper()
+		ref := testhelper.SeedRefEntry(t, pool, "hard-"+uuid.New().String()[:8])
+		entry := testhelper.SeedEntryWithCard(t, pool, user.ID, ref.ID)
+		for _, g := range grades {
+			rl := buildReviewLog(entry.Card.ID, g, nil, nil)
+			if _, err := repo.Create(ctx, &rl); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+		return *entry.Card
+	}
+	again, good := domain.ReviewGradeAgain, domain.ReviewGradeGood
+
+	hardest := seed(again, again, again, good) // 0.75
+	harder := seed(again, good, good, good)    // 0.25
+	seed(again)                                // 1.0, but below the minimum
+	seed(good, good, good, good)               // no lapses
+	middle := seed(again, again, good, good)   // 0.5
+
+	words, err := repo.GetDifficultWords(ctx, user.ID, 3, 10)
+	if err != nil {
+		t.Fatalf("GetDifficultWords: %v", err)
+	}
+
+	want := []uuid.UUID{hardest.ID, middle.ID, harder.ID}
+	if len(words) != len(want) {
+		t.Fatalf("words: got %d, want %d: %+v", len(words), len(want), words)
+	}
+	for i, id := range want {
+		if words[i].CardID != id {
+			t.Errorf("words[%d]: got card %s, want %s", i, words[i].CardID, id)
+		}
+	}
+	if w := words[0]; w.TotalReviews != 4 || w.AgainCount != 3 || w.LapseRate != 0.75 || w.Text == "" {
+		t.Errorf("words[0] metrics: got %+v", w)
+	}
+
+	limited, err := repo.GetDifficultWords(ctx, user.ID, 3, 1)
+	if err != nil {
+		t.Fatalf("GetDifficultWords limited: %v", err)
+	}
+	if len(limited) != 1 || limited[0].CardID != hardest.ID {
+		t.Errorf("limit 1: got %+v, want only the hardest card", limited)
+	}
+}
+
+func TestRepo_GetByPeriodPage_PagesInOrderWithWord(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user, card := seedCard(t, pool)
+	base := time.Now().UTC().Truncate(time.Microsecond).Add(-time.Hour)
+
+	var want []uuid.UUID
+	for i := range 5 {
+		rl := buildReviewLog(card.ID, domain.ReviewGradeGood, nil, nil)
+		rl.ReviewedAt = base.Add(time.Duration(i) * time.Minute)
+		if _, err := repo.Create(ctx, &rl); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		want = append(want, rl.ID)
+	}
+	// Outside the range.
+	old := buildReviewLog(card.ID, domain.ReviewGradeAgain, nil, nil)
+	old.ReviewedAt = base.Add(-48 * time.Hour)
+	if _, err := repo.Create(ctx, &old); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	var (
+		got   []uuid.UUID
+		after *domain.ReviewLogCursor
+	)
+	for range 10 {
+		page, err := repo.GetByPeriodPage(ctx, user.ID, base, base.Add(time.Hour), after, 2)
+		if err != nil {
+			t.Fatalf("GetByPeriodPage: %v", err)
+		}
+		for _, rl := range page {
+			if rl.Word == "" {
+				t.Errorf("log %s: empty word", rl.ID)
+			}
+			got = append(got, rl.ID)
+		}
+		if len(page) < 2 {
+			break
+		}
+		last := page[len(page)-1]
+		after = &domain.ReviewLogCursor{ReviewedAt: last.ReviewedAt, ID: last.ID}
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("paged logs: got %v, want %v", got, want)
+	}
+}
+
 func assertIsDomainError(t *testing.T, err error, target error) {
 	t.Helper()
 	if err == nil {
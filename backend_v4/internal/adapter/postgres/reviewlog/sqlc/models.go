@@ -290,6 +290,8 @@ type EnrichmentQueue struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int32
+	NextRetryAt  *time.Time
 }
 
 type Entry struct {
@@ -432,13 +434,14 @@ type RefreshToken struct {
 }
 
 type ReviewLog struct {
-	ID         uuid.UUID
-	CardID     uuid.UUID
-	Grade      ReviewGrade
-	PrevState  []byte
-	DurationMs pgtype.Int4
-	ReviewedAt time.Time
-	UserID     uuid.UUID
+	ID             uuid.UUID
+	CardID         uuid.UUID
+	Grade          ReviewGrade
+	PrevState      []byte
+	DurationMs     pgtype.Int4
+	ReviewedAt     time.Time
+	UserID         uuid.UUID
+	IdempotencyKey pgtype.Text
 }
 
 type Sense struct {
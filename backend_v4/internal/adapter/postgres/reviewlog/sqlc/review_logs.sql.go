@@ -15,19 +15,20 @@ import (
 
 const createReviewLog = `-- name: CreateReviewLog :one
 
-INSERT INTO review_logs (id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO review_logs (id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at, idempotency_key)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING id, card_id, user_id, grade, prev_state, duration_ms, reviewed_at
 `
 
 type CreateReviewLogParams struct {
-	ID         uuid.UUID
-	CardID     uuid.UUID
-	UserID     uuid.UUID
-	Grade      ReviewGrade
-	PrevState  []byte
-	DurationMs pgtype.Int4
-	ReviewedAt time.Time
+	ID             uuid.UUID
+	CardID         uuid.UUID
+	UserID         uuid.UUID
+	Grade          ReviewGrade
+	PrevState      []byte
+	DurationMs     pgtype.Int4
+	ReviewedAt     time.Time
+	IdempotencyKey pgtype.Text
 }
 
 type CreateReviewLogRow struct {
@@ -52,6 +53,7 @@ func (q *Queries) CreateReviewLog(ctx context.Context, arg CreateReviewLogParams
 		arg.PrevState,
 		arg.DurationMs,
 		arg.ReviewedAt,
+		arg.IdempotencyKey,
 	)
 	var i CreateReviewLogRow
 	err := row.Scan(
@@ -311,6 +311,30 @@ func (r *Repo) Reorder(ctx context.Context, items []domain.ReorderItem) error {
 	})
 }
 
+// updatePositionsSQL sets each sense's position to its zero-based index in
+// the ID array, scoped to one entry.
+const updatePositionsSQL = `
+UPDATE senses s
+SET position = o.ord - 1
+FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+WHERE s.id = o.id AND s.entry_id = $1`
+
+// UpdatePositions renumbers the given senses of an entry in slice order with
+// a single statement. IDs not belonging to the entry are ignored.
+func (r *Repo) UpdatePositions(ctx context.Context, entryID uuid.UUID, orderedIDs []uuid.UUID) error {
+	if len(orderedIDs) == 0 {
+		return nil
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, updatePositionsSQL, entryID, orderedIDs); err != nil {
+		return fmt.Errorf("update sense positions: %w", err)
+	}
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Row scanning helpers
 // ---------------------------------------------------------------------------
@@ -613,6 +613,38 @@ func TestRepo_Reorder_HappyPath(t *testing.T) {
 	}
 }
 
+func TestRepo_UpdatePositions_FollowsSliceOrder(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	entry := testhelper.SeedEntryCustom(t, pool, user.ID)
+	other := testhelper.SeedEntryCustom(t, pool, user.ID)
+
+	// A sense of another entry in the list must be left untouched.
+	ids := []uuid.UUID{entry.Senses[1].ID, other.Senses[1].ID, entry.Senses[0].ID}
+	if err := repo.UpdatePositions(ctx, entry.ID, ids); err != nil {
+		t.Fatalf("UpdatePositions: %v", err)
+	}
+
+	senses, err := repo.GetByEntryID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("GetByEntryID: %v", err)
+	}
+	if len(senses) != 2 || senses[0].ID != entry.Senses[1].ID || senses[1].ID != entry.Senses[0].ID {
+		t.Errorf("order after UpdatePositions = %+v, want senses swapped", senses)
+	}
+
+	otherSenses, err := repo.GetByEntryID(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("GetByEntryID other: %v", err)
+	}
+	if otherSenses[1].ID != other.Senses[1].ID || otherSenses[1].Position != 1 {
+		t.Errorf("other entry's sense moved: %+v", otherSenses[1])
+	}
+}
+
 func TestRepo_Reorder_EmptyItems(t *testing.T) {
 	t.Parallel()
 	repo, _ := newRepo(t)
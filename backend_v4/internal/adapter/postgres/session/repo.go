@@ -33,11 +33,11 @@ func New(pool *pgxpool.Pool) *Repo {
 // SQL constants
 // ---------------------------------------------------------------------------
 
-const sessionColumns = `id, user_id, status, started_at, finished_at, result, created_at`
+const sessionColumns = `id, user_id, status, started_at, finished_at, result, created_at, queue_card_ids`
 
 const createSQL = `
-INSERT INTO study_sessions (id, user_id, status, started_at, created_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO study_sessions (id, user_id, status, started_at, created_at, queue_card_ids)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING ` + sessionColumns
 
 const getByIDSQL = `
@@ -61,6 +61,45 @@ UPDATE study_sessions
 SET status = 'ABANDONED', finished_at = now()
 WHERE id = $1 AND user_id = $2 AND status = 'ACTIVE'`
 
+// A session is stale when it started before $1 and its user has no review
+// logged since $1.
+const abandonStaleSQL = `
+UPDATE study_sessions s
+SET status = 'ABANDONED', finished_at = now()
+WHERE s.status = 'ACTIVE'
+  AND s.started_at < $1
+  AND NOT EXISTS (
+      SELECT 1 FROM review_logs rl
+      WHERE rl.user_id = s.user_id AND rl.reviewed_at >= $1
+  )`
+
+const removeFromQueueSQL = `
+UPDATE study_sessions
+SET queue_card_ids = array_remove(queue_card_ids, $2)
+WHERE user_id = $1 AND status = 'ACTIVE'`
+
+const returnToQueueSQL = `
+UPDATE study_sessions
+SET queue_card_ids = array_prepend($2, array_remove(queue_card_ids, $2))
+WHERE user_id = $1 AND status = 'ACTIVE'`
+
+const moveToQueueEndSQL = `
+UPDATE study_sessions
+SET queue_card_ids = array_append(array_remove(queue_card_ids, $2), $2)
+WHERE user_id = $1 AND status = 'ACTIVE' AND $2 = ANY(queue_card_ids)`
+
+const deleteAbandonedOlderThanSQL = `
+DELETE FROM study_sessions
+WHERE id IN (
+    SELECT id FROM study_sessions
+    WHERE status = 'ABANDONED' AND COALESCE(finished_at, started_at) < $1
+    LIMIT $2
+)`
+
+const countAbandonedOlderThanSQL = `
+SELECT count(*) FROM study_sessions
+WHERE status = 'ABANDONED' AND COALESCE(finished_at, started_at) < $1`
+
 const countByUserIDSQL = `
 SELECT count(*) FROM study_sessions WHERE user_id = $1`
 
@@ -144,12 +183,18 @@ func (r *Repo) Create(ctx context.Context, session *domain.StudySession) (*domai
 	now := time.Now().UTC().Truncate(time.Microsecond)
 	startedAt := session.StartedAt.UTC().Truncate(time.Microsecond)
 
+	queue := session.QueueCardIDs
+	if queue == nil {
+		queue = []uuid.UUID{}
+	}
+
 	row := querier.QueryRow(ctx, createSQL,
 		session.ID,
 		session.UserID,
 		string(session.Status),
 		startedAt,
 		now,
+		queue,
 	)
 
 	created, err := scanSession(row)
@@ -197,6 +242,82 @@ func (r *Repo) Abandon(ctx context.Context, userID, sessionID uuid.UUID) error {
 	return nil
 }
 
+// AbandonStale marks every ACTIVE session without activity since olderThan as
+// ABANDONED and returns how many were abandoned. Activity is the session start
+// or the user's latest review log.
+func (r *Repo) AbandonStale(ctx context.Context, olderThan time.Time) (int64, error) {
+	ct, err := postgres.QuerierFromCtx(ctx, r.pool).Exec(ctx, abandonStaleSQL, olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("session.AbandonStale: %w", err)
+	}
+	return ct.RowsAffected(), nil
+}
+
+// RemoveFromQueue drops a card from the user's ACTIVE session queue snapshot.
+// It is a no-op if there is no active session or the card is not queued.
+func (r *Repo) RemoveFromQueue(ctx context.Context, userID, cardID uuid.UUID) error {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, removeFromQueueSQL, userID, cardID); err != nil {
+		return mapError(err, "session", uuid.Nil)
+	}
+
+	return nil
+}
+
+// ReturnToQueue puts a card back at the front of the user's ACTIVE session
+// queue snapshot, undoing RemoveFromQueue. It is a no-op if there is no
+// active session.
+func (r *Repo) ReturnToQueue(ctx context.Context, userID, cardID uuid.UUID) error {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, returnToQueueSQL, userID, cardID); err != nil {
+		return mapError(err, "session", uuid.Nil)
+	}
+
+	return nil
+}
+
+// MoveToQueueEnd moves a card to the end of the user's ACTIVE session queue
+// snapshot. It is a no-op if there is no active session or the card is not queued.
+func (r *Repo) MoveToQueueEnd(ctx context.Context, userID, cardID uuid.UUID) error {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, moveToQueueEndSQL, userID, cardID); err != nil {
+		return mapError(err, "session", uuid.Nil)
+	}
+
+	return nil
+}
+
+// DeleteOlderThan permanently removes abandoned sessions that ended before
+// threshold, in batches of postgres.DefaultDeleteBatchSize. Finished sessions
+// keep their results and are never removed. Returns the number of deleted sessions.
+func (r *Repo) DeleteOlderThan(ctx context.Context, threshold time.Time) (int64, error) {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+	total, err := postgres.DeleteInBatches(ctx, postgres.DefaultDeleteBatchSize, func(ctx context.Context, limit int) (int64, error) {
+		ct, err := querier.Exec(ctx, deleteAbandonedOlderThanSQL, threshold, limit)
+		if err != nil {
+			return 0, err
+		}
+		return ct.RowsAffected(), nil
+	})
+	if err != nil {
+		return total, fmt.Errorf("session.DeleteOlderThan: %w", err)
+	}
+	return total, nil
+}
+
+// CountOlderThan returns how many sessions DeleteOlderThan would remove for
+// the same threshold.
+func (r *Repo) CountOlderThan(ctx context.Context, threshold time.Time) (int64, error) {
+	var n int64
+	if err := postgres.QuerierFromCtx(ctx, r.pool).QueryRow(ctx, countAbandonedOlderThanSQL, threshold).Scan(&n); err != nil {
+		return 0, fmt.Errorf("session.CountOlderThan: %w", err)
+	}
+	return n, nil
+}
+
 // ---------------------------------------------------------------------------
 // Row scanning helpers
 // ---------------------------------------------------------------------------
@@ -211,19 +332,21 @@ func scanSession(row pgx.Row) (*domain.StudySession, error) {
 		finishedAt *time.Time
 		resultJSON []byte
 		createdAt  time.Time
+		queue      []uuid.UUID
 	)
 
-	if err := row.Scan(&id, &userID, &status, &startedAt, &finishedAt, &resultJSON, &createdAt); err != nil {
+	if err := row.Scan(&id, &userID, &status, &startedAt, &finishedAt, &resultJSON, &createdAt, &queue); err != nil {
 		return nil, err
 	}
 
 	session := &domain.StudySession{
-		ID:         id,
-		UserID:     userID,
-		Status:     domain.SessionStatus(status),
-		StartedAt:  startedAt,
-		FinishedAt: finishedAt,
-		CreatedAt:  createdAt,
+		ID:           id,
+		UserID:       userID,
+		Status:       domain.SessionStatus(status),
+		StartedAt:    startedAt,
+		FinishedAt:   finishedAt,
+		CreatedAt:    createdAt,
+		QueueCardIDs: queue,
 	}
 
 	result, err := unmarshalResult(resultJSON)
@@ -247,19 +370,21 @@ func scanSessions(rows pgx.Rows) ([]*domain.StudySession, error) {
 			finishedAt *time.Time
 			resultJSON []byte
 			createdAt  time.Time
+			queue      []uuid.UUID
 		)
 
-		if err := rows.Scan(&id, &userID, &status, &startedAt, &finishedAt, &resultJSON, &createdAt); err != nil {
+		if err := rows.Scan(&id, &userID, &status, &startedAt, &finishedAt, &resultJSON, &createdAt, &queue); err != nil {
 			return nil, err
 		}
 
 		session := &domain.StudySession{
-			ID:         id,
-			UserID:     userID,
-			Status:     domain.SessionStatus(status),
-			StartedAt:  startedAt,
-			FinishedAt: finishedAt,
-			CreatedAt:  createdAt,
+			ID:           id,
+			UserID:       userID,
+			Status:       domain.SessionStatus(status),
+			StartedAt:    startedAt,
+			FinishedAt:   finishedAt,
+			CreatedAt:    createdAt,
+			QueueCardIDs: queue,
 		}
 
 		result, err := unmarshalResult(resultJSON)
@@ -0,0 +1,130 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/session"
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/testhelper"
+)
+
+func TestRepo_DeleteOlderThan_ThresholdBoundary(t *testing.T) {
+	t.Parallel()
+	pool := testhelper.SetupTestDB(t)
+	repo := session.New(pool)
+	ctx := context.Background()
+
+	// A threshold far in the past keeps parallel tests' sessions out of range.
+	threshold := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	insert := func(status string, finishedAt time.Time) uuid.UUID {
+		t.Helper()
+		// Each user gets one session so the single-ACTIVE index never trips.
+		user := testhelper.SeedUser(t, pool)
+		id := uuid.New()
+		_, err := pool.Exec(ctx,
+			`INSERT INTO study_sessions (id, user_id, status, started_at, finished_at) VALUES ($1, $2, $3, $4, $5)`,
+			id, user.ID, status, finishedAt.Add(-time.Hour), finishedAt)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		return id
+	}
+
+	oldAbandoned := insert("ABANDONED", threshold.Add(-time.Microsecond))
+	atThreshold := insert("ABANDONED", threshold)
+	oldFinished := insert("FINISHED", threshold.Add(-time.Hour))
+
+	count, err := repo.CountOlderThan(ctx, threshold)
+	if err != nil {
+		t.Fatalf("CountOlderThan: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountOlderThan = %d, want 1", count)
+	}
+
+	deleted, err := repo.DeleteOlderThan(ctx, threshold)
+	if err != nil {
+		t.Fatalf("DeleteOlderThan: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	for id, wantExists := range map[uuid.UUID]bool{
+		oldAbandoned: false,
+		atThreshold:  true,
+		oldFinished:  true,
+	} {
+		var exists bool
+		if err := pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM study_sessions WHERE id = $1)`, id).Scan(&exists); err != nil {
+			t.Fatalf("check session: %v", err)
+		}
+		if exists != wantExists {
+			t.Errorf("session %s exists = %v, want %v", id, exists, wantExists)
+		}
+	}
+}
+
+func TestRepo_AbandonStale_KeepsRecentlyActive(t *testing.T) {
+	t.Parallel()
+	pool := testhelper.SetupTestDB(t)
+	repo := session.New(pool)
+	ctx := context.Background()
+
+	// Timestamps far in the past keep parallel tests' sessions out of range.
+	threshold := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	startedAt := threshold.Add(-24 * time.Hour)
+
+	insert := func(userID uuid.UUID) uuid.UUID {
+		t.Helper()
+		id := uuid.New()
+		_, err := pool.Exec(ctx,
+			`INSERT INTO study_sessions (id, user_id, status, started_at) VALUES ($1, $2, 'ACTIVE', $3)`,
+			id, userID, startedAt)
+		if err != nil {
+			t.Fatalf("insert session: %v", err)
+		}
+		return id
+	}
+
+	idleUser := testhelper.SeedUser(t, pool)
+	idle := insert(idleUser.ID)
+
+	// The active user reviewed a card after the threshold.
+	activeUser := testhelper.SeedUser(t, pool)
+	active := insert(activeUser.ID)
+	ref := testhelper.SeedRefEntry(t, pool, "stale-session-"+uuid.New().String()[:8])
+	entry := testhelper.SeedEntryWithCard(t, pool, activeUser.ID, ref.ID)
+	var cardID uuid.UUID
+	if err := pool.QueryRow(ctx, `SELECT id FROM cards WHERE entry_id = $1`, entry.ID).Scan(&cardID); err != nil {
+		t.Fatalf("get card: %v", err)
+	}
+	_, err := pool.Exec(ctx,
+		`INSERT INTO review_logs (id, card_id, user_id, grade, reviewed_at) VALUES ($1, $2, $3, 'GOOD', $4)`,
+		uuid.New(), cardID, activeUser.ID, threshold.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("insert review log: %v", err)
+	}
+
+	abandoned, err := repo.AbandonStale(ctx, threshold)
+	if err != nil {
+		t.Fatalf("AbandonStale: %v", err)
+	}
+	if abandoned < 1 {
+		t.Errorf("abandoned = %d, want at least 1", abandoned)
+	}
+
+	for id, want := range map[uuid.UUID]string{idle: "ABANDONED", active: "ACTIVE"} {
+		var status string
+		if err := pool.QueryRow(ctx, `SELECT status FROM study_sessions WHERE id = $1`, id).Scan(&status); err != nil {
+			t.Fatalf("get session %s: %v", id, err)
+		}
+		if status != want {
+			t.Errorf("session %s status = %s, want %s", id, status, want)
+		}
+	}
+}
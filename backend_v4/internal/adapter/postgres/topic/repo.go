@@ -321,6 +321,42 @@ func (r *Repo) UnlinkEntry(ctx context.Context, entryID, topicID uuid.UUID) erro
 	return nil
 }
 
+// UnlinkAllFromEntry removes every topic link of an entry.
+func (r *Repo) UnlinkAllFromEntry(ctx context.Context, entryID uuid.UUID) error {
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, unlinkAllFromEntrySQL, entryID); err != nil {
+		return fmt.Errorf("unlink all topics from entry %s: %w", entryID, err)
+	}
+
+	return nil
+}
+
+// SetTopicBatch replaces the topic links of the given entries: every existing
+// link is removed and, when topicID is non-nil, each entry is linked to it.
+// Callers run it inside a transaction so the two statements apply together.
+func (r *Repo) SetTopicBatch(ctx context.Context, entryIDs []uuid.UUID, topicID *uuid.UUID) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+
+	querier := postgres.QuerierFromCtx(ctx, r.pool)
+
+	if _, err := querier.Exec(ctx, unlinkAllFromEntriesSQL, entryIDs); err != nil {
+		return fmt.Errorf("unlink entries from topics: %w", err)
+	}
+
+	if topicID == nil {
+		return nil
+	}
+
+	if _, err := querier.Exec(ctx, batchLinkEntriesSQL, entryIDs, *topicID); err != nil {
+		return mapError(err, "entry_topic", *topicID)
+	}
+
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Raw SQL for new queries
 // ---------------------------------------------------------------------------
@@ -329,6 +365,10 @@ const countTopicsByUserSQL = `SELECT count(*) FROM topics WHERE user_id = $1`
 
 const batchLinkEntriesSQL = `INSERT INTO entry_topics (entry_id, topic_id) SELECT unnest($1::uuid[]), $2 ON CONFLICT DO NOTHING`
 
+const unlinkAllFromEntrySQL = `DELETE FROM entry_topics WHERE entry_id = $1`
+
+const unlinkAllFromEntriesSQL = `DELETE FROM entry_topics WHERE entry_id = ANY($1::uuid[])`
+
 const countEntriesByTopicIDSQL = `SELECT count(*) FROM entry_topics WHERE topic_id = $1`
 
 // Count returns the number of topics for a user.
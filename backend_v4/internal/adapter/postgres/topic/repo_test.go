@@ -500,6 +500,102 @@ func TestRepo_UnlinkEntry(t *testing.T) {
 	}
 }
 
+func TestRepo_UnlinkAllFromEntry(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	refEntry := testhelper.SeedRefEntry(t, pool, "unlinkall-"+uuid.New().String()[:8])
+	entry := testhelper.SeedEntry(t, pool, user.ID, refEntry.ID)
+	otherRef := testhelper.SeedRefEntry(t, pool, "unlinkall-other-"+uuid.New().String()[:8])
+	other := testhelper.SeedEntry(t, pool, user.ID, otherRef.ID)
+
+	for i := 0; i < 2; i++ {
+		topic, err := repo.Create(ctx, user.ID, &domain.Topic{Name: "UnlinkAll-" + uuid.New().String()[:8]})
+		if err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := repo.LinkEntry(ctx, entry.ID, topic.ID); err != nil {
+			t.Fatalf("LinkEntry: %v", err)
+		}
+		if err := repo.LinkEntry(ctx, other.ID, topic.ID); err != nil {
+			t.Fatalf("LinkEntry other: %v", err)
+		}
+	}
+
+	if err := repo.UnlinkAllFromEntry(ctx, entry.ID); err != nil {
+		t.Fatalf("UnlinkAllFromEntry: %v", err)
+	}
+
+	got, err := repo.GetTopicsByEntryID(ctx, entry.ID)
+	if err != nil {
+		t.Fatalf("GetTopicsByEntryID: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected 0 topics after unlink all, got %d", len(got))
+	}
+
+	// Other entries keep their links.
+	got, err = repo.GetTopicsByEntryID(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("GetTopicsByEntryID other: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected other entry to keep 2 topics, got %d", len(got))
+	}
+}
+
+func TestRepo_SetTopicBatch_ReplacesLinks(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	user := testhelper.SeedUser(t, pool)
+	refA := testhelper.SeedRefEntry(t, pool, "settopic-a-"+uuid.New().String()[:8])
+	refB := testhelper.SeedRefEntry(t, pool, "settopic-b-"+uuid.New().String()[:8])
+	entryA := testhelper.SeedEntry(t, pool, user.ID, refA.ID)
+	entryB := testhelper.SeedEntry(t, pool, user.ID, refB.ID)
+
+	oldTopic, err := repo.Create(ctx, user.ID, &domain.Topic{Name: "SetOld-" + uuid.New().String()[:8]})
+	if err != nil {
+		t.Fatalf("Create old: %v", err)
+	}
+	newTopic, err := repo.Create(ctx, user.ID, &domain.Topic{Name: "SetNew-" + uuid.New().String()[:8]})
+	if err != nil {
+		t.Fatalf("Create new: %v", err)
+	}
+	if err := repo.LinkEntry(ctx, entryA.ID, oldTopic.ID); err != nil {
+		t.Fatalf("LinkEntry: %v", err)
+	}
+
+	ids := []uuid.UUID{entryA.ID, entryB.ID}
+	if err := repo.SetTopicBatch(ctx, ids, &newTopic.ID); err != nil {
+		t.Fatalf("SetTopicBatch: %v", err)
+	}
+	for _, id := range ids {
+		got, err := repo.GetTopicsByEntryID(ctx, id)
+		if err != nil {
+			t.Fatalf("GetTopicsByEntryID: %v", err)
+		}
+		if len(got) != 1 || got[0].ID != newTopic.ID {
+			t.Errorf("entry %s topics = %+v, want only %s", id, got, newTopic.ID)
+		}
+	}
+
+	// A nil topic removes all links.
+	if err := repo.SetTopicBatch(ctx, ids, nil); err != nil {
+		t.Fatalf("SetTopicBatch nil: %v", err)
+	}
+	count, err := repo.CountEntriesByTopicID(ctx, newTopic.ID)
+	if err != nil {
+		t.Fatalf("CountEntriesByTopicID: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected 0 linked entries after nil assign, got %d", count)
+	}
+}
+
 func TestRepo_UnlinkEntry_NonExisting(t *testing.T) {
 	t.Parallel()
 	repo, pool := newRepo(t)
@@ -2,21 +2,40 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	// defaultTxAttempts bounds how often RunInTx runs a transaction that keeps
+	// failing with a retryable error.
+	defaultTxAttempts = 3
+	// defaultTxRetryDelay is the backoff before the first retry; it doubles
+	// on every further attempt.
+	defaultTxRetryDelay = 10 * time.Millisecond
+)
+
 // TxManager manages database transactions using the context pattern.
 // Nested RunInTx calls are NOT supported — calling RunInTx inside a RunInTx
 // callback will create a second independent transaction, which is a bug.
 type TxManager struct {
-	pool *pgxpool.Pool
+	begin       func(ctx context.Context) (pgx.Tx, error)
+	maxAttempts int
+	retryDelay  time.Duration
 }
 
 // NewTxManager creates a new TxManager.
 func NewTxManager(pool *pgxpool.Pool) *TxManager {
-	return &TxManager{pool: pool}
+	return &TxManager{
+		begin:       pool.Begin,
+		maxAttempts: defaultTxAttempts,
+		retryDelay:  defaultTxRetryDelay,
+	}
 }
 
 // RunInTx executes fn within a database transaction.
@@ -24,8 +43,32 @@ func NewTxManager(pool *pgxpool.Pool) *TxManager {
 // On success: commits.
 // On error from fn: rolls back and returns the error.
 // On panic from fn: rolls back and re-panics.
-func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
-	tx, err := m.pool.Begin(ctx)
+//
+// When fn or the commit fails with a serialization failure (40001) or a
+// deadlock (40P01), the whole transaction is retried with backoff on a fresh
+// transaction, up to a small bounded number of attempts. fn must therefore be
+// safe to run more than once. Other errors are returned immediately.
+func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	delay := m.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := m.runOnce(ctx, fn)
+		if err == nil || !isRetryableTxError(err) || attempt >= m.maxAttempts {
+			return err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+func (m *TxManager) runOnce(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := m.begin(ctx)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
 	}
@@ -52,3 +95,13 @@ func (m *TxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) er
 
 	return nil
 }
+
+// isRetryableTxError reports whether err is a PostgreSQL serialization
+// failure or deadlock, after which rerunning the transaction may succeed.
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeTx records how a transaction ended. Methods other than Commit and
+// Rollback panic via the nil embedded interface.
+type fakeTx struct {
+	pgx.Tx
+	commitErr  error
+	committed  bool
+	rolledBack bool
+}
+
+func (t *fakeTx) Commit(context.Context) error {
+	t.committed = t.commitErr == nil
+	return t.commitErr
+}
+
+func (t *fakeTx) Rollback(context.Context) error {
+	t.rolledBack = true
+	return nil
+}
+
+// fakeTxManager returns a TxManager whose transactions are fakeTxs, the i-th
+// one failing its commit with commitErrs[i] when set.
+func fakeTxManager(commitErrs ...error) (*TxManager, *[]*fakeTx) {
+	var txs []*fakeTx
+	m := &TxManager{
+		begin: func(context.Context) (pgx.Tx, error) {
+			tx := &fakeTx{}
+			if len(txs) < len(commitErrs) {
+				tx.commitErr = commitErrs[len(txs)]
+			}
+			txs = append(txs, tx)
+			return tx, nil
+		},
+		maxAttempts: defaultTxAttempts,
+		retryDelay:  time.Millisecond,
+	}
+	return m, &txs
+}
+
+// errorSequence returns a closure that fails with errs in turn, then succeeds.
+func errorSequence(errs ...error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+var (
+	errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	errDeadlock      = &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+)
+
+func TestRunInTx_RetriesSerializationFailure(t *testing.T) {
+	t.Parallel()
+
+	m, txs := fakeTxManager()
+	fn, calls := errorSequence(fmt.Errorf("update card: %w", errSerialization))
+
+	if err := m.RunInTx(context.Background(), fn); err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+	if *calls != 2 || len(*txs) != 2 {
+		t.Fatalf("calls = %d, transactions = %d; want 2 each", *calls, len(*txs))
+	}
+	if first := (*txs)[0]; !first.rolledBack || first.committed {
+		t.Errorf("first transaction = %+v, want rolled back", first)
+	}
+	if second := (*txs)[1]; !second.committed {
+		t.Errorf("second transaction = %+v, want committed", second)
+	}
+}
+
+func TestRunInTx_RetriesDeadlock(t *testing.T) {
+	t.Parallel()
+
+	m, _ := fakeTxManager()
+	fn, calls := errorSequence(errDeadlock, errDeadlock)
+
+	if err := m.RunInTx(context.Background(), fn); err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+}
+
+func TestRunInTx_RetriesSerializationFailureOnCommit(t *testing.T) {
+	t.Parallel()
+
+	m, txs := fakeTxManager(errSerialization)
+	fn, calls := errorSequence()
+
+	if err := m.RunInTx(context.Background(), fn); err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+	if *calls != 2 || !(*txs)[1].committed {
+		t.Errorf("calls = %d, transactions = %+v; want a committed retry", *calls, *txs)
+	}
+}
+
+func TestRunInTx_NonRetryableErrorNotRetried(t *testing.T) {
+	t.Parallel()
+
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+	for _, wantErr := range []error{errors.New("business logic error"), uniqueViolation} {
+		m, _ := fakeTxManager()
+		fn, calls := errorSequence(wantErr)
+
+		if err := m.RunInTx(context.Background(), fn); !errors.Is(err, wantErr) {
+			t.Errorf("RunInTx error = %v, want %v", err, wantErr)
+		}
+		if *calls != 1 {
+			t.Errorf("%v: calls = %d, want 1", wantErr, *calls)
+		}
+	}
+}
+
+func TestRunInTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	m, _ := fakeTxManager()
+	fn, calls := errorSequence(errSerialization, errSerialization, errSerialization, errSerialization)
+
+	err := m.RunInTx(context.Background(), fn)
+	if !errors.Is(err, errSerialization) {
+		t.Errorf("RunInTx error = %v, want the serialization failure", err)
+	}
+	if *calls != defaultTxAttempts {
+		t.Errorf("calls = %d, want %d", *calls, defaultTxAttempts)
+	}
+}
+
+func TestRunInTx_StopsRetryingWhenContextDone(t *testing.T) {
+	t.Parallel()
+
+	m, _ := fakeTxManager()
+	m.retryDelay = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	fn := func(context.Context) error {
+		calls++
+		cancel()
+		return errSerialization
+	}
+
+	if err := m.RunInTx(ctx, fn); !errors.Is(err, errSerialization) {
+		t.Errorf("RunInTx error = %v, want the serialization failure", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
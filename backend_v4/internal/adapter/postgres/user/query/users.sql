@@ -1,53 +1,71 @@
 -- name: GetUserByID :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE email = $1;
 
 -- name: GetUserByUsername :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE username = $1;
 
 -- name: CreateUser :one
 INSERT INTO users (id, email, username, name, avatar_url, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at;
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
 
 -- name: UpdateUser :one
 UPDATE users
 SET name = $2, avatar_url = $3, updated_at = now()
 WHERE id = $1
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at;
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
+
+-- name: UpdateUserProfile :one
+UPDATE users
+SET name = $2, avatar_url = $3, profile_customized = true, updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
 
 -- name: GetUserSettings :one
-SELECT user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at
+SELECT user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at
 FROM user_settings
 WHERE user_id = $1;
 
 -- name: CreateUserSettings :one
-INSERT INTO user_settings (user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, now())
-RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at;
+INSERT INTO user_settings (user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now())
+RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at;
 
 -- name: UpdateUserSettings :one
 UPDATE user_settings
-SET new_cards_per_day = $2, reviews_per_day = $3, max_interval_days = $4, desired_retention = $5, timezone = $6, updated_at = now()
+SET new_cards_per_day = $2, reviews_per_day = $3, max_interval_days = $4, desired_retention = $5, timezone = $6, new_card_order = $7, hard_interval_factor = $8, daily_goal = $9, due_card_order = $10, interleave_new = $11, relearning_steps = $12, learning_steps = $13, updated_at = now()
 WHERE user_id = $1
-RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at;
+RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at;
+
+-- name: UpdateUsername :one
+UPDATE users
+SET username = $2, updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
 
 -- name: UpdateUserRole :one
 UPDATE users
 SET role = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at;
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
+
+-- name: AnonymizeUser :one
+UPDATE users
+SET email = $2, username = $3, name = NULL, avatar_url = NULL, role = 'user', updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at;
 
 -- name: ListUsers :many
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 ORDER BY created_at
 LIMIT $1 OFFSET $2;
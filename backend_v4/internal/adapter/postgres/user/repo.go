@@ -92,7 +92,8 @@ func (r *Repo) Create(ctx context.Context, u *domain.User) (*domain.User, error)
 	return &result, nil
 }
 
-// Update modifies name and avatar_url for the given user.
+// Update modifies name and avatar_url for the given user without marking the
+// profile as customized; used to sync provider data on OAuth login.
 func (r *Repo) Update(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 
@@ -109,6 +110,41 @@ func (r *Repo) Update(ctx context.Context, id uuid.UUID, name *string, avatarURL
 	return &u, nil
 }
 
+// UpdateProfile sets name and avatar_url on behalf of the user and marks the
+// profile as customized so that OAuth login stops overwriting it.
+func (r *Repo) UpdateProfile(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	row, err := q.UpdateUserProfile(ctx, sqlc.UpdateUserProfileParams{
+		ID:        id,
+		Name:      ptrStringToPgText(name),
+		AvatarUrl: ptrStringToPgText(avatarURL),
+	})
+	if err != nil {
+		return nil, mapError(err, "user", id)
+	}
+
+	u := toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.ProfileCustomized, row.CreatedAt, row.UpdatedAt})
+	return &u, nil
+}
+
+// UpdateUsername changes the username for the given user.
+// Returns ErrAlreadyExists if another user already has the username.
+func (r *Repo) UpdateUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	row, err := q.UpdateUsername(ctx, sqlc.UpdateUsernameParams{
+		ID:       id,
+		Username: username,
+	})
+	if err != nil {
+		return nil, mapError(err, "user", id)
+	}
+
+	u := toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.ProfileCustomized, row.CreatedAt, row.UpdatedAt})
+	return &u, nil
+}
+
 // UpdateRole changes the role for the given user.
 func (r *Repo) UpdateRole(ctx context.Context, id uuid.UUID, role string) (*domain.User, error) {
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
@@ -121,7 +157,7 @@ func (r *Repo) UpdateRole(ctx context.Context, id uuid.UUID, role string) (*doma
 		return nil, mapError(err, "user", id)
 	}
 
-	u := toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.CreatedAt, row.UpdatedAt})
+	u := toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.ProfileCustomized, row.CreatedAt, row.UpdatedAt})
 	return &u, nil
 }
 
@@ -139,7 +175,7 @@ func (r *Repo) ListUsers(ctx context.Context, limit, offset int) ([]domain.User,
 
 	users := make([]domain.User, len(rows))
 	for i, row := range rows {
-		users[i] = toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.CreatedAt, row.UpdatedAt})
+		users[i] = toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.ProfileCustomized, row.CreatedAt, row.UpdatedAt})
 	}
 	return users, nil
 }
@@ -154,6 +190,53 @@ func (r *Repo) CountUsers(ctx context.Context) (int, error) {
 	return int(count), nil
 }
 
+// Anonymize replaces the user's identifying fields with placeholders and
+// resets the role, keeping the row (and its ID) for the final audit record.
+func (r *Repo) Anonymize(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
+
+	row, err := q.AnonymizeUser(ctx, sqlc.AnonymizeUserParams{
+		ID:       id,
+		Email:    domain.AnonymizedUserEmail(id),
+		Username: domain.AnonymizedUsername(id),
+	})
+	if err != nil {
+		return nil, mapError(err, "user", id)
+	}
+
+	u := toDomainUser(userRow{row.ID, row.Email, row.Username, row.Name, row.AvatarUrl, row.Role, row.ProfileCustomized, row.CreatedAt, row.UpdatedAt})
+	return &u, nil
+}
+
+// deleteOwnedDataSQL removes every row owned by a user, children first.
+// Entries cascade to senses, translations, examples, pronunciations, images
+// and topic links; cards cascade to review logs.
+var deleteOwnedDataSQL = []string{
+	`DELETE FROM review_logs WHERE user_id = $1`,
+	`DELETE FROM study_sessions WHERE user_id = $1`,
+	`DELETE FROM cards WHERE user_id = $1`,
+	`DELETE FROM entries WHERE user_id = $1`,
+	`DELETE FROM topics WHERE user_id = $1`,
+	`DELETE FROM inbox_items WHERE user_id = $1`,
+	`DELETE FROM audit_log WHERE user_id = $1`,
+	`DELETE FROM auth_methods WHERE user_id = $1`,
+	`DELETE FROM refresh_tokens WHERE user_id = $1`,
+	`DELETE FROM user_settings WHERE user_id = $1`,
+}
+
+// DeleteOwnedData deletes all data owned by the user except the users row
+// itself. Run it in a transaction so a failure leaves the account intact.
+func (r *Repo) DeleteOwnedData(ctx context.Context, userID uuid.UUID) error {
+	q := postgres.QuerierFromCtx(ctx, r.pool)
+
+	for _, stmt := range deleteOwnedDataSQL {
+		if _, err := q.Exec(ctx, stmt, userID); err != nil {
+			return mapError(err, "user", userID)
+		}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // UserSettings operations
 // ---------------------------------------------------------------------------
@@ -176,12 +259,19 @@ func (r *Repo) CreateSettings(ctx context.Context, s *domain.UserSettings) error
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 
 	_, err := q.CreateUserSettings(ctx, sqlc.CreateUserSettingsParams{
-		UserID:           s.UserID,
-		NewCardsPerDay:   int32(s.NewCardsPerDay),
-		ReviewsPerDay:    int32(s.ReviewsPerDay),
-		MaxIntervalDays:  int32(s.MaxIntervalDays),
-		DesiredRetention: s.DesiredRetention,
-		Timezone:         s.Timezone,
+		UserID:             s.UserID,
+		NewCardsPerDay:     int32(s.NewCardsPerDay),
+		ReviewsPerDay:      int32(s.ReviewsPerDay),
+		MaxIntervalDays:    int32(s.MaxIntervalDays),
+		DesiredRetention:   s.DesiredRetention,
+		Timezone:           s.Timezone,
+		NewCardOrder:       string(s.NewCardOrder),
+		HardIntervalFactor: s.HardIntervalFactor,
+		DailyGoal:          int32(s.DailyGoal),
+		DueCardOrder:       string(s.DueCardOrder),
+		InterleaveNew:      s.InterleaveNew,
+		RelearningSteps:    stepsToSeconds(s.RelearningSteps),
+		LearningSteps:      stepsToSeconds(s.LearningSteps),
 	})
 	if err != nil {
 		return mapError(err, "user_settings", s.UserID)
@@ -195,12 +285,19 @@ func (r *Repo) UpdateSettings(ctx context.Context, userID uuid.UUID, s domain.Us
 	q := sqlc.New(postgres.QuerierFromCtx(ctx, r.pool))
 
 	row, err := q.UpdateUserSettings(ctx, sqlc.UpdateUserSettingsParams{
-		UserID:           userID,
-		NewCardsPerDay:   int32(s.NewCardsPerDay),
-		ReviewsPerDay:    int32(s.ReviewsPerDay),
-		MaxIntervalDays:  int32(s.MaxIntervalDays),
-		DesiredRetention: s.DesiredRetention,
-		Timezone:         s.Timezone,
+		UserID:             userID,
+		NewCardsPerDay:     int32(s.NewCardsPerDay),
+		ReviewsPerDay:      int32(s.ReviewsPerDay),
+		MaxIntervalDays:    int32(s.MaxIntervalDays),
+		DesiredRetention:   s.DesiredRetention,
+		Timezone:           s.Timezone,
+		NewCardOrder:       string(s.NewCardOrder),
+		HardIntervalFactor: s.HardIntervalFactor,
+		DailyGoal:          int32(s.DailyGoal),
+		DueCardOrder:       string(s.DueCardOrder),
+		InterleaveNew:      s.InterleaveNew,
+		RelearningSteps:    stepsToSeconds(s.RelearningSteps),
+		LearningSteps:      stepsToSeconds(s.LearningSteps),
 	})
 	if err != nil {
 		return nil, mapError(err, "user_settings", userID)
@@ -264,28 +361,31 @@ type userRow struct {
 	Name      pgtype.Text
 	AvatarUrl pgtype.Text
 	Role      string
+
+	ProfileCustomized bool
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
 func fromGetByID(r sqlc.GetUserByIDRow) userRow {
-	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.CreatedAt, r.UpdatedAt}
+	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.ProfileCustomized, r.CreatedAt, r.UpdatedAt}
 }
 
 func fromGetByEmail(r sqlc.GetUserByEmailRow) userRow {
-	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.CreatedAt, r.UpdatedAt}
+	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.ProfileCustomized, r.CreatedAt, r.UpdatedAt}
 }
 
 func fromGetByUsername(r sqlc.GetUserByUsernameRow) userRow {
-	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.CreatedAt, r.UpdatedAt}
+	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.ProfileCustomized, r.CreatedAt, r.UpdatedAt}
 }
 
 func fromCreate(r sqlc.CreateUserRow) userRow {
-	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.CreatedAt, r.UpdatedAt}
+	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.ProfileCustomized, r.CreatedAt, r.UpdatedAt}
 }
 
 func fromUpdate(r sqlc.UpdateUserRow) userRow {
-	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.CreatedAt, r.UpdatedAt}
+	return userRow{r.ID, r.Email, r.Username, r.Name, r.AvatarUrl, r.Role, r.ProfileCustomized, r.CreatedAt, r.UpdatedAt}
 }
 
 // toDomainUser converts a userRow into a domain.User.
@@ -299,39 +399,80 @@ func toDomainUser(row userRow) domain.User {
 		Role:      domain.UserRole(row.Role),
 		CreatedAt: row.CreatedAt,
 		UpdatedAt: row.UpdatedAt,
+
+		ProfileCustomized: row.ProfileCustomized,
 	}
 }
 
 // settingsRow is the common field set returned by all user_settings queries.
 type settingsRow struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
-	UpdatedAt        time.Time
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
+	UpdatedAt          time.Time
 }
 
 func fromGetSettingsRow(r sqlc.GetUserSettingsRow) settingsRow {
-	return settingsRow{r.UserID, r.NewCardsPerDay, r.ReviewsPerDay, r.MaxIntervalDays, r.DesiredRetention, r.Timezone, r.UpdatedAt}
+	return settingsRow{r.UserID, r.NewCardsPerDay, r.ReviewsPerDay, r.MaxIntervalDays, r.DesiredRetention, r.Timezone, r.NewCardOrder, r.HardIntervalFactor, r.DailyGoal, r.DueCardOrder, r.InterleaveNew, r.RelearningSteps, r.LearningSteps, r.UpdatedAt}
 }
 
 func fromUpdateSettingsRow(r sqlc.UpdateUserSettingsRow) settingsRow {
-	return settingsRow{r.UserID, r.NewCardsPerDay, r.ReviewsPerDay, r.MaxIntervalDays, r.DesiredRetention, r.Timezone, r.UpdatedAt}
+	return settingsRow{r.UserID, r.NewCardsPerDay, r.ReviewsPerDay, r.MaxIntervalDays, r.DesiredRetention, r.Timezone, r.NewCardOrder, r.HardIntervalFactor, r.DailyGoal, r.DueCardOrder, r.InterleaveNew, r.RelearningSteps, r.LearningSteps, r.UpdatedAt}
 }
 
 // toDomainSettings converts a settingsRow into a domain.UserSettings.
 func toDomainSettings(row settingsRow) domain.UserSettings {
 	return domain.UserSettings{
-		UserID:           row.UserID,
-		NewCardsPerDay:   int(row.NewCardsPerDay),
-		ReviewsPerDay:    int(row.ReviewsPerDay),
-		MaxIntervalDays:  int(row.MaxIntervalDays),
-		DesiredRetention: row.DesiredRetention,
-		Timezone:         row.Timezone,
-		UpdatedAt:        row.UpdatedAt,
+		UserID:             row.UserID,
+		NewCardsPerDay:     int(row.NewCardsPerDay),
+		ReviewsPerDay:      int(row.ReviewsPerDay),
+		MaxIntervalDays:    int(row.MaxIntervalDays),
+		DesiredRetention:   row.DesiredRetention,
+		Timezone:           row.Timezone,
+		NewCardOrder:       domain.NewCardOrder(row.NewCardOrder),
+		HardIntervalFactor: row.HardIntervalFactor,
+		DailyGoal:          int(row.DailyGoal),
+		DueCardOrder:       domain.DueCardOrder(row.DueCardOrder),
+		InterleaveNew:      row.InterleaveNew,
+		RelearningSteps:    secondsToSteps(row.RelearningSteps),
+		LearningSteps:      secondsToSteps(row.LearningSteps),
+		UpdatedAt:          row.UpdatedAt,
+	}
+}
+
+// stepsToSeconds converts SRS steps to the whole seconds stored in the
+// database. Nil (use the server default) stays nil and is stored as NULL.
+func stepsToSeconds(steps []time.Duration) []int32 {
+	if steps == nil {
+		return nil
+	}
+	secs := make([]int32, len(steps))
+	for i, d := range steps {
+		secs[i] = int32(d / time.Second)
+	}
+	return secs
+}
+
+// secondsToSteps is the inverse of stepsToSeconds.
+func secondsToSteps(secs []int32) []time.Duration {
+	if secs == nil {
+		return nil
+	}
+	steps := make([]time.Duration, len(secs))
+	for i, s := range secs {
+		steps[i] = time.Duration(s) * time.Second
 	}
+	return steps
 }
 
 // ---------------------------------------------------------------------------
@@ -3,6 +3,7 @@ package user_test
 import (
 	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -260,6 +261,82 @@ func TestRepo_Update_ClearAvatar(t *testing.T) {
 	}
 }
 
+func TestRepo_UpdateProfile_MarksCustomized(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	seeded := testhelper.SeedUser(t, pool)
+
+	// Provider sync does not mark the profile as customized.
+	synced := "Provider Name"
+	got, err := repo.Update(ctx, seeded.ID, &synced, nil)
+	if err != nil {
+		t.Fatalf("Update: unexpected error: %v", err)
+	}
+	if got.ProfileCustomized {
+		t.Error("ProfileCustomized should stay false after Update")
+	}
+
+	chosen := "Chosen Name"
+	got, err = repo.UpdateProfile(ctx, seeded.ID, &chosen, nil)
+	if err != nil {
+		t.Fatalf("UpdateProfile: unexpected error: %v", err)
+	}
+	if got.Name != chosen || !got.ProfileCustomized {
+		t.Errorf("got Name=%q ProfileCustomized=%v, want %q and true", got.Name, got.ProfileCustomized, chosen)
+	}
+
+	reloaded, err := repo.GetByID(ctx, seeded.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if !reloaded.ProfileCustomized {
+		t.Error("ProfileCustomized not persisted")
+	}
+}
+
+func TestRepo_UpdateUsername_HappyPath(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	seeded := testhelper.SeedUser(t, pool)
+	newUsername := "renamed-" + uuid.New().String()[:8]
+
+	got, err := repo.UpdateUsername(ctx, seeded.ID, newUsername)
+	if err != nil {
+		t.Fatalf("UpdateUsername: unexpected error: %v", err)
+	}
+	if got.Username != newUsername {
+		t.Errorf("Username mismatch: got %q, want %q", got.Username, newUsername)
+	}
+	if got.Email != seeded.Email {
+		t.Errorf("Email changed: got %q, want %q", got.Email, seeded.Email)
+	}
+}
+
+func TestRepo_UpdateUsername_Taken(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	u1 := testhelper.SeedUser(t, pool)
+	u2 := testhelper.SeedUser(t, pool)
+
+	_, err := repo.UpdateUsername(ctx, u2.ID, u1.Username)
+	assertIsDomainError(t, err, domain.ErrAlreadyExists)
+}
+
+func TestRepo_UpdateUsername_NotFound(t *testing.T) {
+	t.Parallel()
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	_, err := repo.UpdateUsername(ctx, uuid.New(), "ghost-"+uuid.New().String()[:8])
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
+
 // ---------------------------------------------------------------------------
 // UserSettings CRUD
 // ---------------------------------------------------------------------------
@@ -284,11 +361,15 @@ func TestRepo_CreateSettings_HappyPath(t *testing.T) {
 	}
 
 	s := domain.UserSettings{
-		UserID:          u.ID,
-		NewCardsPerDay:  30,
-		ReviewsPerDay:   150,
-		MaxIntervalDays: 180,
-		Timezone:        "Europe/Moscow",
+		UserID:             u.ID,
+		NewCardsPerDay:     30,
+		ReviewsPerDay:      150,
+		MaxIntervalDays:    180,
+		Timezone:           "Europe/Moscow",
+		NewCardOrder:       domain.NewCardOrderAdded,
+		HardIntervalFactor: 1.0,
+		DailyGoal:          40,
+		DueCardOrder:       domain.DueCardOrderDueDate,
 	}
 
 	err := repo.CreateSettings(ctx, &s)
@@ -317,6 +398,12 @@ func TestRepo_CreateSettings_HappyPath(t *testing.T) {
 	if got.Timezone != s.Timezone {
 		t.Errorf("Timezone mismatch: got %s, want %s", got.Timezone, s.Timezone)
 	}
+	if got.DailyGoal != s.DailyGoal {
+		t.Errorf("DailyGoal mismatch: got %d, want %d", got.DailyGoal, s.DailyGoal)
+	}
+	if got.LearningSteps != nil || got.RelearningSteps != nil {
+		t.Errorf("steps: got %v / %v, want nil (server default)", got.LearningSteps, got.RelearningSteps)
+	}
 }
 
 func TestRepo_CreateSettings_DuplicateUserID(t *testing.T) {
@@ -370,10 +457,17 @@ func TestRepo_UpdateSettings_HappyPath(t *testing.T) {
 	seeded := testhelper.SeedUser(t, pool)
 
 	updated := domain.UserSettings{
-		NewCardsPerDay:  50,
-		ReviewsPerDay:   300,
-		MaxIntervalDays: 730,
-		Timezone:        "America/New_York",
+		NewCardsPerDay:     50,
+		ReviewsPerDay:      300,
+		MaxIntervalDays:    730,
+		Timezone:           "America/New_York",
+		NewCardOrder:       domain.NewCardOrderRandom,
+		HardIntervalFactor: 1.2,
+		DailyGoal:          100,
+		DueCardOrder:       domain.DueCardOrderRandom,
+		InterleaveNew:      true,
+		LearningSteps:      []time.Duration{time.Minute, 15 * time.Minute},
+		RelearningSteps:    []time.Duration{5 * time.Minute, 20 * time.Minute},
 	}
 
 	got, err := repo.UpdateSettings(ctx, seeded.ID, updated)
@@ -393,6 +487,24 @@ func TestRepo_UpdateSettings_HappyPath(t *testing.T) {
 	if got.Timezone != updated.Timezone {
 		t.Errorf("Timezone mismatch: got %s, want %s", got.Timezone, updated.Timezone)
 	}
+	if got.HardIntervalFactor != updated.HardIntervalFactor {
+		t.Errorf("HardIntervalFactor mismatch: got %v, want %v", got.HardIntervalFactor, updated.HardIntervalFactor)
+	}
+	if got.DailyGoal != updated.DailyGoal {
+		t.Errorf("DailyGoal mismatch: got %d, want %d", got.DailyGoal, updated.DailyGoal)
+	}
+	if got.DueCardOrder != updated.DueCardOrder {
+		t.Errorf("DueCardOrder mismatch: got %s, want %s", got.DueCardOrder, updated.DueCardOrder)
+	}
+	if got.InterleaveNew != updated.InterleaveNew {
+		t.Errorf("InterleaveNew mismatch: got %v, want %v", got.InterleaveNew, updated.InterleaveNew)
+	}
+	if !slices.Equal(got.LearningSteps, updated.LearningSteps) {
+		t.Errorf("LearningSteps mismatch: got %v, want %v", got.LearningSteps, updated.LearningSteps)
+	}
+	if !slices.Equal(got.RelearningSteps, updated.RelearningSteps) {
+		t.Errorf("RelearningSteps mismatch: got %v, want %v", got.RelearningSteps, updated.RelearningSteps)
+	}
 }
 
 func TestRepo_UpdateSettings_NotFound(t *testing.T) {
@@ -404,10 +516,135 @@ func TestRepo_UpdateSettings_NotFound(t *testing.T) {
 	assertIsDomainError(t, err, domain.ErrNotFound)
 }
 
+// ---------------------------------------------------------------------------
+// Account deletion
+// ---------------------------------------------------------------------------
+
+func TestRepo_DeleteOwnedData_ScopedToUser(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	refEntry := testhelper.SeedRefEntry(t, pool, "delete-account-"+uuid.New().String()[:8])
+	doomed := testhelper.SeedUser(t, pool)
+	other := testhelper.SeedUser(t, pool)
+	seedOwnedData(t, pool, doomed.ID, refEntry.ID)
+	seedOwnedData(t, pool, other.ID, refEntry.ID)
+
+	if err := repo.DeleteOwnedData(ctx, doomed.ID); err != nil {
+		t.Fatalf("DeleteOwnedData: %v", err)
+	}
+
+	for table, n := range ownedRowCounts(t, pool, doomed.ID) {
+		if n != 0 {
+			t.Errorf("%s: %d rows left for deleted user, want 0", table, n)
+		}
+	}
+	for table, n := range ownedRowCounts(t, pool, other.ID) {
+		if n == 0 {
+			t.Errorf("%s: other user's rows were deleted", table)
+		}
+	}
+
+	// The users row itself is kept for anonymization.
+	if _, err := repo.GetByID(ctx, doomed.ID); err != nil {
+		t.Fatalf("GetByID after DeleteOwnedData: %v", err)
+	}
+}
+
+func TestRepo_Anonymize(t *testing.T) {
+	t.Parallel()
+	repo, pool := newRepo(t)
+	ctx := context.Background()
+
+	seeded := testhelper.SeedUser(t, pool)
+
+	got, err := repo.Anonymize(ctx, seeded.ID)
+	if err != nil {
+		t.Fatalf("Anonymize: %v", err)
+	}
+	if got.Email != domain.AnonymizedUserEmail(seeded.ID) || got.Username != domain.AnonymizedUsername(seeded.ID) {
+		t.Errorf("Anonymize: got email %q, username %q", got.Email, got.Username)
+	}
+	if got.Name != "" || got.AvatarURL != nil {
+		t.Errorf("Anonymize: name %q and avatar %v not cleared", got.Name, got.AvatarURL)
+	}
+	if !got.IsDeleted() {
+		t.Error("expected anonymized user to report IsDeleted")
+	}
+
+	if _, err := repo.GetByEmail(ctx, seeded.Email); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByEmail(old email): expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestRepo_Anonymize_NotFound(t *testing.T) {
+	t.Parallel()
+	repo, _ := newRepo(t)
+
+	_, err := repo.Anonymize(context.Background(), uuid.New())
+	assertIsDomainError(t, err, domain.ErrNotFound)
+}
+
 // ---------------------------------------------------------------------------
 // Test helpers
 // ---------------------------------------------------------------------------
 
+// ownedTables lists the tables DeleteOwnedData must clear, keyed by a query
+// counting one user's rows.
+var ownedTables = map[string]string{
+	"entries":        `SELECT count(*) FROM entries WHERE user_id = $1`,
+	"senses":         `SELECT count(*) FROM senses s JOIN entries e ON e.id = s.entry_id WHERE e.user_id = $1`,
+	"cards":          `SELECT count(*) FROM cards WHERE user_id = $1`,
+	"review_logs":    `SELECT count(*) FROM review_logs WHERE user_id = $1`,
+	"study_sessions": `SELECT count(*) FROM study_sessions WHERE user_id = $1`,
+	"topics":         `SELECT count(*) FROM topics WHERE user_id = $1`,
+	"inbox_items":    `SELECT count(*) FROM inbox_items WHERE user_id = $1`,
+	"audit_log":      `SELECT count(*) FROM audit_log WHERE user_id = $1`,
+	"auth_methods":   `SELECT count(*) FROM auth_methods WHERE user_id = $1`,
+	"refresh_tokens": `SELECT count(*) FROM refresh_tokens WHERE user_id = $1`,
+	"user_settings":  `SELECT count(*) FROM user_settings WHERE user_id = $1`,
+}
+
+func ownedRowCounts(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID) map[string]int {
+	t.Helper()
+	counts := make(map[string]int, len(ownedTables))
+	for table, query := range ownedTables {
+		var n int
+		if err := pool.QueryRow(context.Background(), query, userID).Scan(&n); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		counts[table] = n
+	}
+	return counts
+}
+
+// seedOwnedData gives the user one row in every table DeleteOwnedData clears.
+func seedOwnedData(t *testing.T, pool *pgxpool.Pool, userID, refEntryID uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+
+	entry := testhelper.SeedEntryWithCard(t, pool, userID, refEntryID)
+
+	stmts := []struct {
+		sql  string
+		args []any
+	}{
+		{`INSERT INTO review_logs (card_id, user_id, grade) VALUES ($1, $2, 'GOOD')`, []any{entry.Card.ID, userID}},
+		{`INSERT INTO study_sessions (user_id) VALUES ($1)`, []any{userID}},
+		{`INSERT INTO topics (user_id, name) VALUES ($1, 'topic')`, []any{userID}},
+		{`INSERT INTO inbox_items (user_id, text) VALUES ($1, 'inbox')`, []any{userID}},
+		{`INSERT INTO audit_log (user_id, entity_type, action) VALUES ($1, 'ENTRY', 'CREATE')`, []any{userID}},
+		{`INSERT INTO auth_methods (user_id, method, password_hash) VALUES ($1, 'password', 'hash')`, []any{userID}},
+		{`INSERT INTO refresh_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, now() + interval '1 day')`, []any{userID, uuid.New().String()}},
+	}
+	for _, s := range stmts {
+		if _, err := pool.Exec(ctx, s.sql, s.args...); err != nil {
+			t.Fatalf("seedOwnedData %q: %v", s.sql, err)
+		}
+	}
+}
+
 func ptrStr(s string) *string {
 	return &s
 }
@@ -290,6 +290,8 @@ type EnrichmentQueue struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int32
+	NextRetryAt  *time.Time
 }
 
 type Entry struct {
@@ -482,14 +484,15 @@ type Translation struct {
 }
 
 type User struct {
-	ID        uuid.UUID
-	Email     string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	Username  string
-	Role      string
+	ID                uuid.UUID
+	Email             string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Username          string
+	Role              string
+	ProfileCustomized bool
 }
 
 type UserImage struct {
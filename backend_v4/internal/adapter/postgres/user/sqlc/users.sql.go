@@ -13,6 +13,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :one
+UPDATE users
+SET email = $2, username = $3, name = NULL, avatar_url = NULL, role = 'user', updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
+`
+
+type AnonymizeUserParams struct {
+	ID       uuid.UUID
+	Email    string
+	Username string
+}
+
+type AnonymizeUserRow struct {
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (AnonymizeUserRow, error) {
+	row := q.db.QueryRow(ctx, anonymizeUser, arg.ID, arg.Email, arg.Username)
+	var i AnonymizeUserRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.Name,
+		&i.AvatarUrl,
+		&i.Role,
+		&i.ProfileCustomized,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT count(*) FROM users
 `
@@ -27,7 +69,7 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, email, username, name, avatar_url, created_at, updated_at)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 `
 
 type CreateUserParams struct {
@@ -41,14 +83,15 @@ type CreateUserParams struct {
 }
 
 type CreateUserRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
@@ -69,6 +112,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -76,28 +120,42 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 }
 
 const createUserSettings = `-- name: CreateUserSettings :one
-INSERT INTO user_settings (user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, now())
-RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at
+INSERT INTO user_settings (user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, now())
+RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at
 `
 
 type CreateUserSettingsParams struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
 }
 
 type CreateUserSettingsRow struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
-	UpdatedAt        time.Time
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
+	UpdatedAt          time.Time
 }
 
 func (q *Queries) CreateUserSettings(ctx context.Context, arg CreateUserSettingsParams) (CreateUserSettingsRow, error) {
@@ -108,6 +166,13 @@ func (q *Queries) CreateUserSettings(ctx context.Context, arg CreateUserSettings
 		arg.MaxIntervalDays,
 		arg.DesiredRetention,
 		arg.Timezone,
+		arg.NewCardOrder,
+		arg.HardIntervalFactor,
+		arg.DailyGoal,
+		arg.DueCardOrder,
+		arg.InterleaveNew,
+		arg.RelearningSteps,
+		arg.LearningSteps,
 	)
 	var i CreateUserSettingsRow
 	err := row.Scan(
@@ -117,26 +182,34 @@ func (q *Queries) CreateUserSettings(ctx context.Context, arg CreateUserSettings
 		&i.MaxIntervalDays,
 		&i.DesiredRetention,
 		&i.Timezone,
+		&i.NewCardOrder,
+		&i.HardIntervalFactor,
+		&i.DailyGoal,
+		&i.DueCardOrder,
+		&i.InterleaveNew,
+		&i.RelearningSteps,
+		&i.LearningSteps,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE email = $1
 `
 
 type GetUserByEmailRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -149,6 +222,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -156,20 +230,21 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE id = $1
 `
 
 type GetUserByIDRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error) {
@@ -182,6 +257,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -189,20 +265,21 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 WHERE username = $1
 `
 
 type GetUserByUsernameRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error) {
@@ -215,6 +292,7 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUs
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -222,19 +300,26 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUs
 }
 
 const getUserSettings = `-- name: GetUserSettings :one
-SELECT user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at
+SELECT user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at
 FROM user_settings
 WHERE user_id = $1
 `
 
 type GetUserSettingsRow struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
-	UpdatedAt        time.Time
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
+	UpdatedAt          time.Time
 }
 
 func (q *Queries) GetUserSettings(ctx context.Context, userID uuid.UUID) (GetUserSettingsRow, error) {
@@ -247,13 +332,20 @@ func (q *Queries) GetUserSettings(ctx context.Context, userID uuid.UUID) (GetUse
 		&i.MaxIntervalDays,
 		&i.DesiredRetention,
 		&i.Timezone,
+		&i.NewCardOrder,
+		&i.HardIntervalFactor,
+		&i.DailyGoal,
+		&i.DueCardOrder,
+		&i.InterleaveNew,
+		&i.RelearningSteps,
+		&i.LearningSteps,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, username, name, avatar_url, role, created_at, updated_at
+SELECT id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 FROM users
 ORDER BY created_at
 LIMIT $1 OFFSET $2
@@ -265,14 +357,15 @@ type ListUsersParams struct {
 }
 
 type ListUsersRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error) {
@@ -291,6 +384,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUse
 			&i.Name,
 			&i.AvatarUrl,
 			&i.Role,
+			&i.ProfileCustomized,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -308,7 +402,7 @@ const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET name = $2, avatar_url = $3, updated_at = now()
 WHERE id = $1
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 `
 
 type UpdateUserParams struct {
@@ -318,14 +412,15 @@ type UpdateUserParams struct {
 }
 
 type UpdateUserRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error) {
@@ -338,6 +433,90 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUserProfile = `-- name: UpdateUserProfile :one
+UPDATE users
+SET name = $2, avatar_url = $3, profile_customized = true, updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
+`
+
+type UpdateUserProfileParams struct {
+	ID        uuid.UUID
+	Name      pgtype.Text
+	AvatarUrl pgtype.Text
+}
+
+type UpdateUserProfileRow struct {
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error) {
+	row := q.db.QueryRow(ctx, updateUserProfile, arg.ID, arg.Name, arg.AvatarUrl)
+	var i UpdateUserProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.Name,
+		&i.AvatarUrl,
+		&i.Role,
+		&i.ProfileCustomized,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateUsername = `-- name: UpdateUsername :one
+UPDATE users
+SET username = $2, updated_at = now()
+WHERE id = $1
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
+`
+
+type UpdateUsernameParams struct {
+	ID       uuid.UUID
+	Username string
+}
+
+type UpdateUsernameRow struct {
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func (q *Queries) UpdateUsername(ctx context.Context, arg UpdateUsernameParams) (UpdateUsernameRow, error) {
+	row := q.db.QueryRow(ctx, updateUsername, arg.ID, arg.Username)
+	var i UpdateUsernameRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Username,
+		&i.Name,
+		&i.AvatarUrl,
+		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -348,7 +527,7 @@ const updateUserRole = `-- name: UpdateUserRole :one
 UPDATE users
 SET role = $2, updated_at = now()
 WHERE id = $1
-RETURNING id, email, username, name, avatar_url, role, created_at, updated_at
+RETURNING id, email, username, name, avatar_url, role, profile_customized, created_at, updated_at
 `
 
 type UpdateUserRoleParams struct {
@@ -357,14 +536,15 @@ type UpdateUserRoleParams struct {
 }
 
 type UpdateUserRoleRow struct {
-	ID        uuid.UUID
-	Email     string
-	Username  string
-	Name      pgtype.Text
-	AvatarUrl pgtype.Text
-	Role      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                uuid.UUID
+	Email             string
+	Username          string
+	Name              pgtype.Text
+	AvatarUrl         pgtype.Text
+	Role              string
+	ProfileCustomized bool
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (UpdateUserRoleRow, error) {
@@ -377,6 +557,7 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 		&i.Name,
 		&i.AvatarUrl,
 		&i.Role,
+		&i.ProfileCustomized,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -385,28 +566,42 @@ func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams)
 
 const updateUserSettings = `-- name: UpdateUserSettings :one
 UPDATE user_settings
-SET new_cards_per_day = $2, reviews_per_day = $3, max_interval_days = $4, desired_retention = $5, timezone = $6, updated_at = now()
+SET new_cards_per_day = $2, reviews_per_day = $3, max_interval_days = $4, desired_retention = $5, timezone = $6, new_card_order = $7, hard_interval_factor = $8, daily_goal = $9, due_card_order = $10, interleave_new = $11, relearning_steps = $12, learning_steps = $13, updated_at = now()
 WHERE user_id = $1
-RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, updated_at
+RETURNING user_id, new_cards_per_day, reviews_per_day, max_interval_days, desired_retention, timezone, new_card_order, hard_interval_factor, daily_goal, due_card_order, interleave_new, relearning_steps, learning_steps, updated_at
 `
 
 type UpdateUserSettingsParams struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
 }
 
 type UpdateUserSettingsRow struct {
-	UserID           uuid.UUID
-	NewCardsPerDay   int32
-	ReviewsPerDay    int32
-	MaxIntervalDays  int32
-	DesiredRetention float64
-	Timezone         string
-	UpdatedAt        time.Time
+	UserID             uuid.UUID
+	NewCardsPerDay     int32
+	ReviewsPerDay      int32
+	MaxIntervalDays    int32
+	DesiredRetention   float64
+	Timezone           string
+	NewCardOrder       string
+	HardIntervalFactor float64
+	DailyGoal          int32
+	DueCardOrder       string
+	InterleaveNew      bool
+	RelearningSteps    []int32
+	LearningSteps      []int32
+	UpdatedAt          time.Time
 }
 
 func (q *Queries) UpdateUserSettings(ctx context.Context, arg UpdateUserSettingsParams) (UpdateUserSettingsRow, error) {
@@ -417,6 +612,13 @@ func (q *Queries) UpdateUserSettings(ctx context.Context, arg UpdateUserSettings
 		arg.MaxIntervalDays,
 		arg.DesiredRetention,
 		arg.Timezone,
+		arg.NewCardOrder,
+		arg.HardIntervalFactor,
+		arg.DailyGoal,
+		arg.DueCardOrder,
+		arg.InterleaveNew,
+		arg.RelearningSteps,
+		arg.LearningSteps,
 	)
 	var i UpdateUserSettingsRow
 	err := row.Scan(
@@ -426,6 +628,13 @@ func (q *Queries) UpdateUserSettings(ctx context.Context, arg UpdateUserSettings
 		&i.MaxIntervalDays,
 		&i.DesiredRetention,
 		&i.Timezone,
+		&i.NewCardOrder,
+		&i.HardIntervalFactor,
+		&i.DailyGoal,
+		&i.DueCardOrder,
+		&i.InterleaveNew,
+		&i.RelearningSteps,
+		&i.LearningSteps,
 		&i.UpdatedAt,
 	)
 	return i, err
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"net/http"
+	"slices"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 
+	"github.com/heartmarshall/myenglish-backend/internal/adapter/anki"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres"
 	"github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/audit"
 	authmethodrepo "github.com/heartmarshall/myenglish-backend/internal/adapter/postgres/authmethod"
@@ -49,6 +52,7 @@ import (
 	"github.com/heartmarshall/myenglish-backend/internal/transport/graphql/resolver"
 	"github.com/heartmarshall/myenglish-backend/internal/transport/middleware"
 	"github.com/heartmarshall/myenglish-backend/internal/transport/rest"
+	"github.com/heartmarshall/myenglish-backend/pkg/metrics"
 )
 
 // Run is the application entry point. It loads configuration, initializes
@@ -86,6 +90,16 @@ func Run(ctx context.Context) error {
 		slog.Int("max_conns", int(cfg.Database.MaxConns)),
 	)
 
+	// Optional read replica for search and stats queries; nil without one.
+	replica, err := postgres.NewReplicaPool(ctx, cfg.Database)
+	if err != nil {
+		return fmt.Errorf("connect to read replica: %w", err)
+	}
+	if replica != nil {
+		defer replica.Close()
+		logger.Info("read replica connected")
+	}
+
 	// -----------------------------------------------------------------------
 	// 4. Create TxManager
 	// -----------------------------------------------------------------------
@@ -112,6 +126,11 @@ func Run(ctx context.Context) error {
 	userRepo := userrepo.New(pool)
 	enrichmentQueueRepo := enrichmentrepo.New(pool)
 
+	cardRepo.SetReader(replica)
+	entryRepo.SetReader(replica)
+	refentryRepo.SetReader(replica)
+	reviewlogRepo.SetReader(replica)
+
 	// -----------------------------------------------------------------------
 	// 6. Create external providers
 	// -----------------------------------------------------------------------
@@ -121,8 +140,13 @@ func Run(ctx context.Context) error {
 	// -----------------------------------------------------------------------
 	// 7. Create JWT manager + OAuth verifier
 	// -----------------------------------------------------------------------
-	jwtManager := authpkg.NewJWTManager(
-		cfg.Auth.JWTSecret,
+	previousJWTKeys := make([]authpkg.JWTKey, 0, len(cfg.Auth.JWTPreviousKeys))
+	for _, kid := range slices.Sorted(maps.Keys(cfg.Auth.JWTPreviousKeys)) {
+		previousJWTKeys = append(previousJWTKeys, authpkg.JWTKey{ID: kid, Secret: cfg.Auth.JWTPreviousKeys[kid]})
+	}
+	jwtManager := authpkg.NewJWTManagerWithKeys(
+		authpkg.JWTKey{ID: cfg.Auth.JWTKeyID, Secret: cfg.Auth.JWTSecret},
+		previousJWTKeys,
 		cfg.Auth.JWTIssuer,
 		cfg.Auth.AccessTokenTTL,
 	)
@@ -140,6 +164,10 @@ func Run(ctx context.Context) error {
 	authService := authsvc.NewService(
 		logger, userRepo, userRepo, tokenRepo, authMethodRepo, txm, oauthVerifier, jwtManager, cfg.Auth,
 	)
+	authService.SetAuditRepo(auditRepo)
+	if cfg.Auth.LoginMaxFailures > 0 {
+		authService.SetLoginAttempts(authpkg.NewLoginAttempts(cfg.Auth.LoginMaxFailures, cfg.Auth.LoginFailureWindow))
+	}
 
 	userService := usersvc.NewService(
 		logger, userRepo, userRepo, auditRepo, txm,
@@ -148,6 +176,7 @@ func Run(ctx context.Context) error {
 	refCatalogService := refcatalog.NewService(
 		logger, refentryRepo, txm, dictProvider, transProvider,
 	)
+	refCatalogService.SetSettings(userRepo)
 
 	srsConfig := domain.SRSConfig{
 		DefaultRetention:  cfg.SRS.DefaultRetention,
@@ -158,10 +187,18 @@ func Run(ctx context.Context) error {
 		NewCardsPerDay:    cfg.SRS.NewCardsPerDay,
 		ReviewsPerDay:     cfg.SRS.ReviewsPerDay,
 		UndoWindowMinutes: cfg.SRS.UndoWindowMinutes,
+		DifficultyMin:     cfg.SRS.DifficultyMin,
+		DifficultyMax:     cfg.SRS.DifficultyMax,
+		CardPresets: domain.CardPresets{
+			FrequentMaxRank:    cfg.SRS.FrequentWordMaxRank,
+			FrequentStability:  cfg.SRS.FrequentWordStability,
+			FrequentDifficulty: cfg.SRS.FrequentWordDifficulty,
+		},
+		NewCardInterleaveRatio: cfg.SRS.NewCardInterleaveRatio,
 	}
 
 	enrichmentService := enrichmentsvc.NewService(
-		logger, enrichmentQueueRepo,
+		logger, enrichmentQueueRepo, cfg.Enrichment,
 	)
 
 	dictionaryService := dictionary.NewService(
@@ -170,6 +207,9 @@ func Run(ctx context.Context) error {
 		refCatalogService, cfg.Dictionary,
 	)
 	dictionaryService.SetEnrichment(enrichmentService)
+	dictionaryService.SetAnkiReader(anki.New())
+	dictionaryService.SetTopics(topicRepo)
+	dictionaryService.SetCardPresets(srsConfig.CardPresets)
 
 	contentService := content.NewService(
 		logger, entryRepo, senseRepo, translationRepo, exampleRepo,
@@ -183,6 +223,8 @@ func Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create study service: %w", err)
 	}
+	metricsRegistry := metrics.NewRegistry()
+	studyService.SetMetrics(study.NewMetrics(metricsRegistry))
 
 	topicService := topicsvc.NewService(
 		logger, topicRepo, entryRepo, auditRepo, txm,
@@ -270,12 +312,13 @@ func Run(ctx context.Context) error {
 	mux.HandleFunc("GET /live", healthHandler.Live)
 	mux.HandleFunc("GET /ready", healthHandler.Ready)
 	mux.HandleFunc("GET /health", healthHandler.Health)
+	mux.Handle("GET /metrics", metricsRegistry.Handler())
 
 	// Auth endpoints - CORS only (no auth middleware)
 	authCORS := middleware.CORS(cfg.CORS)
 	mux.Handle("POST /auth/register", authCORS(authRateLimitRegister(http.HandlerFunc(authHandler.Register))))
 	mux.Handle("POST /auth/login", authCORS(authRateLimitLogin(http.HandlerFunc(authHandler.Login))))
-	mux.Handle("POST /auth/login/password", authCORS(authRateLimitLogin(http.HandlerFunc(authHandler.LoginWithPassword))))
+	mux.Handle("POST /auth/login/password", authCORS(authRateLimitLogin(middleware.ClientIP()(http.HandlerFunc(authHandler.LoginWithPassword)))))
 	mux.Handle("POST /auth/refresh", authCORS(authRateLimitRefresh(http.HandlerFunc(authHandler.Refresh))))
 	mux.Handle("POST /auth/logout", authCORS(http.HandlerFunc(authHandler.Logout)))
 	mux.Handle("OPTIONS /auth/{path...}", authCORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
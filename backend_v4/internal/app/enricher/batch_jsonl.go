@@ -0,0 +1,164 @@
+package enricher
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// batchRequestsFile is the jsonl-batch output file inside EnrichOutputDir.
+const batchRequestsFile = "batch_requests.jsonl"
+
+// batchCustomIDsFile maps each custom_id in batchRequestsFile back to its
+// word, so batch results can be matched without parsing the responses.
+const batchCustomIDsFile = "batch_custom_ids.json"
+
+// batchMaxTokens matches the max_tokens used for direct API calls.
+const batchMaxTokens = 2048
+
+// batchRequest is one line of batch_requests.jsonl, in the request shape of
+// the Message Batches API.
+type batchRequest struct {
+	CustomID string             `json:"custom_id"`
+	Params   batchRequestParams `json:"params"`
+}
+
+type batchRequestParams struct {
+	Model     string         `json:"model"`
+	MaxTokens int            `json:"max_tokens"`
+	System    string         `json:"system,omitempty"`
+	Messages  []batchMessage `json:"messages"`
+}
+
+type batchMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// promptData is the data passed to Config.PromptTemplate.
+type promptData struct {
+	Word    string
+	Context string
+}
+
+// jsonlBatchWriter writes one chat request per word to batch_requests.jsonl.
+type jsonlBatchWriter struct {
+	cfg  *Config
+	tmpl *template.Template
+	f    *os.File
+	w    *bufio.Writer
+	n    int
+	ids  map[string]string // custom_id → word
+}
+
+// newJSONLBatchWriter parses the prompt template and truncates the output file.
+func newJSONLBatchWriter(cfg *Config) (*jsonlBatchWriter, error) {
+	var tmpl *template.Template
+	if cfg.PromptTemplate != "" {
+		t, err := template.New("prompt").Option("missingkey=error").Parse(cfg.PromptTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("parse prompt template: %w", err)
+		}
+		tmpl = t
+	}
+
+	f, err := os.Create(filepath.Join(cfg.EnrichOutputDir, batchRequestsFile))
+	if err != nil {
+		return nil, fmt.Errorf("create %s: %w", batchRequestsFile, err)
+	}
+	return &jsonlBatchWriter{cfg: cfg, tmpl: tmpl, f: f, w: bufio.NewWriter(f), ids: make(map[string]string)}, nil
+}
+
+// add appends the request for one word.
+func (b *jsonlBatchWriter) add(enrichCtx EnrichContext) error {
+	contextJSON, err := json.MarshalIndent(enrichCtx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal context: %w", err)
+	}
+
+	prompt, err := b.prompt(enrichCtx.Word, string(contextJSON))
+	if err != nil {
+		return err
+	}
+
+	customID := batchCustomID(b.n, domain.NormalizeText(enrichCtx.Word))
+	line, err := json.Marshal(batchRequest{
+		CustomID: customID,
+		Params: batchRequestParams{
+			Model:     b.cfg.LLMModel,
+			MaxTokens: batchMaxTokens,
+			System:    b.cfg.SystemPrompt,
+			Messages:  []batchMessage{{Role: "user", Content: prompt}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	if _, err := b.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write request: %w", err)
+	}
+	b.ids[customID] = enrichCtx.Word
+	b.n++
+	return nil
+}
+
+func (b *jsonlBatchWriter) prompt(word, contextJSON string) (string, error) {
+	if b.tmpl == nil {
+		return buildPrompt(word, contextJSON), nil
+	}
+	var sb strings.Builder
+	if err := b.tmpl.Execute(&sb, promptData{Word: word, Context: contextJSON}); err != nil {
+		return "", fmt.Errorf("render prompt template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// close flushes and closes the output file and writes the custom_id map.
+func (b *jsonlBatchWriter) close() error {
+	if err := b.w.Flush(); err != nil {
+		b.f.Close()
+		return fmt.Errorf("flush %s: %w", batchRequestsFile, err)
+	}
+	if err := b.f.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", batchRequestsFile, err)
+	}
+
+	data, err := json.MarshalIndent(b.ids, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal custom ids: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(b.cfg.EnrichOutputDir, batchCustomIDsFile), data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", batchCustomIDsFile, err)
+	}
+	return nil
+}
+
+// batchCustomID derives the custom_id of request index i from a normalized
+// word. The index makes the ID unique; the word is only a readable suffix.
+// The batch API accepts only [a-zA-Z0-9_-] up to 64 characters, so other
+// characters become '_' and the suffix is cut to fit.
+func batchCustomID(i int, word string) string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(i))
+	sb.WriteByte('-')
+	for _, r := range word {
+		if sb.Len() >= 64 {
+			break
+		}
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,173 @@
+package enricher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	anthropic "github.com/anthropics/anthropic-sdk-go"
+)
+
+func readBatchRequests(t *testing.T, dir string) []batchRequest {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join(dir, batchRequestsFile))
+	if err != nil {
+		t.Fatalf("open batch requests: %v", err)
+	}
+	defer f.Close()
+
+	var reqs []batchRequest
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if !json.Valid(scanner.Bytes()) {
+			t.Fatalf("line %d is not valid JSON: %s", len(reqs)+1, scanner.Text())
+		}
+		var req batchRequest
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			t.Fatalf("line %d: %v", len(reqs)+1, err)
+		}
+		reqs = append(reqs, req)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	return reqs
+}
+
+func TestEnrichWords_JSONLBatch_OneRequestPerWord(t *testing.T) {
+	cfg := &Config{
+		EnrichOutputDir: t.TempDir(),
+		Mode:            "manual",
+		OutputFormat:    OutputFormatJSONLBatch,
+		BatchSize:       2,
+		LLMModel:        "test-model",
+		SystemPrompt:    "You are a dictionary editor.",
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// "run" was generated by an earlier run; it must still get a request.
+	prev, _ := json.Marshal(EnrichContext{Word: "run", IPA: "/rʌn/"})
+	if err := os.WriteFile(filepath.Join(cfg.EnrichOutputDir, "run.json"), prev, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	jsonl, err := newJSONLBatchWriter(cfg)
+	if err != nil {
+		t.Fatalf("newJSONLBatchWriter: %v", err)
+	}
+	words := []string{"run", "walk", "ice cream"}
+	var result PipelineResult
+	enrichWords(context.Background(), cfg, words, datasets{}, anthropic.Client{}, jsonl, &result, log)
+	if err := jsonl.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reqs := readBatchRequests(t, cfg.EnrichOutputDir)
+	if len(reqs) != len(words) {
+		t.Fatalf("got %d requests, want %d", len(reqs), len(words))
+	}
+	wantIDs := []string{"0-run", "1-walk", "2-ice_cream"}
+	for i, req := range reqs {
+		if req.CustomID != wantIDs[i] {
+			t.Errorf("request %d custom_id = %q, want %q", i, req.CustomID, wantIDs[i])
+		}
+		if req.Params.Model != "test-model" || req.Params.MaxTokens != batchMaxTokens {
+			t.Errorf("request %d params = %+v", i, req.Params)
+		}
+		if req.Params.System != cfg.SystemPrompt {
+			t.Errorf("request %d system = %q", i, req.Params.System)
+		}
+		if len(req.Params.Messages) != 1 || req.Params.Messages[0].Role != "user" {
+			t.Fatalf("request %d messages = %+v", i, req.Params.Messages)
+		}
+		if !strings.Contains(req.Params.Messages[0].Content, words[i]) {
+			t.Errorf("request %d prompt does not mention %q", i, words[i])
+		}
+	}
+	if !strings.Contains(reqs[0].Params.Messages[0].Content, "/rʌn/") {
+		t.Error("skipped word prompt should use its existing context file")
+	}
+
+	raw, err := os.ReadFile(filepath.Join(cfg.EnrichOutputDir, batchCustomIDsFile))
+	if err != nil {
+		t.Fatalf("read custom ids: %v", err)
+	}
+	var ids map[string]string
+	if err := json.Unmarshal(raw, &ids); err != nil {
+		t.Fatalf("unmarshal custom ids: %v", err)
+	}
+	for i, id := range wantIDs {
+		if ids[id] != words[i] {
+			t.Errorf("custom id %q maps to %q, want %q", id, ids[id], words[i])
+		}
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(cfg.EnrichOutputDir, "batch_*_prompt.txt")); len(matches) != 0 {
+		t.Errorf("jsonl-batch should not write prompt files, got %v", matches)
+	}
+}
+
+func TestJSONLBatchWriter_PromptTemplate(t *testing.T) {
+	cfg := &Config{
+		EnrichOutputDir: t.TempDir(),
+		PromptTemplate:  "Word: {{.Word}}\nData: {{.Context}}",
+	}
+
+	jsonl, err := newJSONLBatchWriter(cfg)
+	if err != nil {
+		t.Fatalf("newJSONLBatchWriter: %v", err)
+	}
+	if err := jsonl.add(EnrichContext{Word: "walk"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := jsonl.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reqs := readBatchRequests(t, cfg.EnrichOutputDir)
+	if len(reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(reqs))
+	}
+	got := reqs[0].Params.Messages[0].Content
+	if !strings.HasPrefix(got, "Word: walk\nData: {") {
+		t.Errorf("prompt = %q, want rendered template", got)
+	}
+	if reqs[0].Params.System != "" {
+		t.Errorf("system = %q, want omitted", reqs[0].Params.System)
+	}
+}
+
+func TestNewJSONLBatchWriter_BadTemplate(t *testing.T) {
+	cfg := &Config{EnrichOutputDir: t.TempDir(), PromptTemplate: "{{.Word"}
+	if _, err := newJSONLBatchWriter(cfg); err == nil {
+		t.Fatal("expected template parse error")
+	}
+}
+
+func TestBatchCustomID(t *testing.T) {
+	tests := map[string]string{
+		"run":                   "7-run",
+		"ice cream":             "7-ice_cream",
+		"don't":                 "7-don_t",
+		"naïve":                 "7-na_ve",
+		strings.Repeat("a", 70): "7-" + strings.Repeat("a", 62),
+	}
+	for in, want := range tests {
+		if got := batchCustomID(7, in); got != want {
+			t.Errorf("batchCustomID(7, %q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// Words that sanitize to the same text still get distinct IDs.
+	if a, b := batchCustomID(0, "don't"), batchCustomID(1, "don t"); a == b {
+		t.Errorf("colliding custom ids %q", a)
+	}
+}
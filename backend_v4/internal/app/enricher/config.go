@@ -7,7 +7,18 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// Output formats for the per-run batch output.
+const (
+	OutputFormatFiles      = "files"       // batch_NNNN_prompt.txt files for manual pasting
+	OutputFormatJSONLBatch = "jsonl-batch" // one batch_requests.jsonl for a batch LLM API
+)
+
 // Config holds enricher pipeline settings.
+//
+// PromptTemplate is a text/template rendered with .Word and .Context (the
+// word's context JSON) to build the user prompt in jsonl-batch output; when
+// empty the built-in prompt is used. SystemPrompt is sent as the system prompt
+// when set.
 type Config struct {
 	WordListPath    string `yaml:"word_list_path"    env:"ENRICH_WORD_LIST_PATH"`
 	WiktionaryPath  string `yaml:"wiktionary_path"   env:"ENRICH_WIKTIONARY_PATH"`
@@ -16,10 +27,15 @@ type Config struct {
 	EnrichOutputDir string `yaml:"enrich_output_dir" env:"ENRICH_OUTPUT_DIR"      env-default:"./enrich-output"`
 	LLMOutputDir    string `yaml:"llm_output_dir"    env:"ENRICH_LLM_OUTPUT_DIR"  env-default:"./llm-output"`
 	Mode            string `yaml:"mode"              env:"ENRICH_MODE"             env-default:"manual"`
+	OutputFormat    string `yaml:"output_format"     env:"ENRICH_OUTPUT_FORMAT"    env-default:"files"`
 	Source          string `yaml:"source"            env:"ENRICH_SOURCE"           env-default:"file"`
 	BatchSize       int    `yaml:"batch_size"        env:"ENRICH_BATCH_SIZE"       env-default:"50"`
+	ProgressEvery   int    `yaml:"progress_every"    env:"ENRICH_PROGRESS_EVERY"   env-default:"100"`
+	ClaimOrder      string `yaml:"claim_order"       env:"ENRICH_CLAIM_ORDER"      env-default:"priority"`
 	LLMAPIKey       string `yaml:"llm_api_key"       env:"ENRICH_LLM_API_KEY"`
 	LLMModel        string `yaml:"llm_model"         env:"ENRICH_LLM_MODEL"        env-default:"claude-opus-4-6"`
+	SystemPrompt    string `yaml:"system_prompt"     env:"ENRICH_SYSTEM_PROMPT"`
+	PromptTemplate  string `yaml:"prompt_template"   env:"ENRICH_PROMPT_TEMPLATE"`
 	DatabaseDSN     string `yaml:"database_dsn"      env:"DATABASE_DSN"`
 }
 
@@ -40,7 +40,10 @@ func BuildContext(
 				if len(s.Glosses) == 0 {
 					continue
 				}
-				definition := wiktionary.StripMarkup(s.Glosses[0])
+				definition := wiktionary.TruncateDefinition(
+					wiktionary.CleanText(s.Glosses[0], wiktionary.MarkupStrip),
+					wiktionary.DefaultMaxDefinitionLen,
+				)
 				ctx.WiktionarySenses = append(ctx.WiktionarySenses, WiktionarySense{
 					POS:          pg.POS,
 					Definition:   definition,
@@ -9,11 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	anthropic "github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/option"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/cmu"
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/progress"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wordnet"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
 )
@@ -24,10 +26,42 @@ type PipelineResult struct {
 	Written    int
 	Skipped    int // already existed in enrich-output/
 	BatchFiles int
+	// BatchRequests is the number of lines written to batch_requests.jsonl
+	// in jsonl-batch output format.
+	BatchRequests int
+
+	// Completed lists the words whose context file exists after the run,
+	// whether written now or skipped as already present.
+	Completed []string
+	// Failed lists the words that errored; they are neither in Completed nor
+	// counted as written or skipped.
+	Failed []WordError
+	// Stopped is set when the run ended early because the context deadline
+	// was too close to start another word; the rest of the list is untouched.
+	Stopped bool
+}
+
+// WordError records why enriching a single word failed.
+type WordError struct {
+	Word   string
+	Reason string
+}
+
+// deadlineMargin is the minimum time left on the context deadline for the
+// pipeline to start enriching another word.
+const deadlineMargin = 30 * time.Second
+
+// datasets holds the parsed reference data used to build word contexts.
+type datasets struct {
+	wikt map[string]*wiktionary.ParsedEntry
+	rel  map[string]map[string][]string
+	cmu  cmu.ParseResult
 }
 
 // RunWithWords runs the enrichment pipeline for a given word list.
 // This is the core function used by both file-based and queue-based modes.
+// If ctx has a deadline the run may stop early; result.Completed lists the
+// words that were handled.
 func RunWithWords(ctx context.Context, cfg *Config, words []string, log *slog.Logger) (PipelineResult, error) {
 	return runPipeline(ctx, cfg, words, log)
 }
@@ -49,11 +83,22 @@ func runPipeline(ctx context.Context, cfg *Config, words []string, log *slog.Log
 	result.TotalWords = len(words)
 	log.Info("word list loaded", slog.Int("count", len(words)))
 
+	if cfg.OutputFormat != "" && cfg.OutputFormat != OutputFormatFiles && cfg.OutputFormat != OutputFormatJSONLBatch {
+		return result, fmt.Errorf("unknown output format %q", cfg.OutputFormat)
+	}
+
 	wordSet := make(map[string]bool, len(words))
 	for _, w := range words {
 		wordSet[domain.NormalizeText(w)] = true
 	}
 
+	// Parsing the datasets takes a while; don't start if no word could follow.
+	if deadlineNear(ctx) {
+		log.Warn("context deadline near, skipping run", slog.Int("remaining", len(words)))
+		result.Stopped = true
+		return result, nil
+	}
+
 	// 2. Load datasets.
 	log.Info("parsing wiktionary...")
 	wiktEntries, _, err := wiktionary.Parse(cfg.WiktionaryPath, wordSet, len(wordSet)+10000)
@@ -91,36 +136,78 @@ func runPipeline(ctx context.Context, cfg *Config, words []string, log *slog.Log
 		llmClient = anthropic.NewClient(option.WithAPIKey(cfg.LLMAPIKey))
 	}
 
-	// 4. Build context files + batch prompts.
-	var batch []EnrichContext
-	batchNum := 1
+	var jsonl *jsonlBatchWriter
+	if cfg.OutputFormat == OutputFormatJSONLBatch {
+		if jsonl, err = newJSONLBatchWriter(cfg); err != nil {
+			return result, err
+		}
+	}
 
-	for _, word := range words {
-		outPath := filepath.Join(cfg.EnrichOutputDir, domain.NormalizeText(word)+".json")
+	data := datasets{wikt: wiktMap, rel: relMap, cmu: cmuResult}
+	enrichWords(ctx, cfg, words, data, llmClient, jsonl, &result, log)
 
-		// Resume: skip if already generated.
-		if _, err := os.Stat(outPath); err == nil {
-			result.Skipped++
-			continue
+	if jsonl != nil {
+		if err := jsonl.close(); err != nil {
+			return result, err
 		}
+		result.BatchRequests = jsonl.n
+	}
 
-		enrichCtx := BuildContext(word, wiktMap, relMap, cmuResult)
+	log.Info("enrichment complete",
+		slog.Int("total", result.TotalWords),
+		slog.Int("written", result.Written),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("failed", len(result.Failed)),
+		slog.Int("batch_files", result.BatchFiles),
+		slog.Int("batch_requests", result.BatchRequests),
+		slog.Bool("stopped", result.Stopped),
+	)
+	return result, nil
+}
 
-		data, err := json.MarshalIndent(enrichCtx, "", "  ")
+// enrichWords writes a context file (and, in api mode, calls the LLM) for
+// each word in order. Before each word it checks the context deadline and
+// stops early if less than deadlineMargin remains, so a caller holding the
+// words as claimed queue items can release the unfinished ones.
+//
+// A word that errors or panics is recorded in result.Failed and the run moves
+// on to the next word.
+//
+// When jsonl is non-nil every completed word, including ones skipped because
+// their context file already exists, gets a request line there instead of
+// going into batch prompt files.
+func enrichWords(ctx context.Context, cfg *Config, words []string, data datasets, llmClient anthropic.Client, jsonl *jsonlBatchWriter, result *PipelineResult, log *slog.Logger) {
+	// 4. Build context files + batch prompts.
+	var batch []EnrichContext
+	batchNum := 1
+	prog := progress.New(log, "enrich", len(words), cfg.ProgressEvery, progress.DefaultInterval)
+
+	for i, word := range words {
+		if deadlineNear(ctx) {
+			log.Warn("context deadline near, stopping early",
+				slog.Int("completed", len(result.Completed)),
+				slog.Int("remaining", len(words)-i),
+			)
+			result.Stopped = true
+			break
+		}
+
+		enrichCtx, skipped, err := enrichWord(ctx, cfg, word, data, llmClient, jsonl, log)
+		prog.Add(1)
 		if err != nil {
-			log.Error("marshal context", slog.String("word", word), slog.String("error", err.Error()))
+			log.Error("enrich word", slog.String("word", word), slog.String("error", err.Error()))
+			result.Failed = append(result.Failed, WordError{Word: word, Reason: err.Error()})
 			continue
 		}
-		if err := os.WriteFile(outPath, data, 0644); err != nil {
-			log.Error("write context file", slog.String("word", word), slog.String("error", err.Error()))
+		result.Completed = append(result.Completed, word)
+		if skipped {
+			result.Skipped++
 			continue
 		}
 		result.Written++
 
-		if cfg.Mode == "api" {
-			if err := callLLM(ctx, llmClient, cfg, enrichCtx, log); err != nil {
-				log.Error("llm api call", slog.String("word", word), slog.String("error", err.Error()))
-			}
+		if jsonl != nil {
+			continue
 		}
 
 		batch = append(batch, enrichCtx)
@@ -143,14 +230,85 @@ func runPipeline(ctx context.Context, cfg *Config, words []string, log *slog.Log
 			result.BatchFiles++
 		}
 	}
+}
+
+// enrichWord handles one word: it writes the context file, calls the LLM in
+// api mode and adds the jsonl batch request. skipped is set when the context
+// file already existed. A panic is recovered and returned as an error, and a
+// context file written for a word that then fails is removed so the next run
+// retries it.
+func enrichWord(ctx context.Context, cfg *Config, word string, data datasets, llmClient anthropic.Client, jsonl *jsonlBatchWriter, log *slog.Logger) (enrichCtx EnrichContext, skipped bool, err error) {
+	outPath := filepath.Join(cfg.EnrichOutputDir, domain.NormalizeText(word)+".json")
+	written := false
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+		if err != nil && written {
+			os.Remove(outPath)
+		}
+	}()
+
+	// Resume: skip if already generated.
+	if _, err := os.Stat(outPath); err == nil {
+		if jsonl != nil {
+			if err := addExistingToBatch(jsonl, outPath); err != nil {
+				return EnrichContext{}, true, err
+			}
+		}
+		return EnrichContext{}, true, nil
+	}
 
-	log.Info("enrichment complete",
-		slog.Int("total", result.TotalWords),
-		slog.Int("written", result.Written),
-		slog.Int("skipped", result.Skipped),
-		slog.Int("batch_files", result.BatchFiles),
-	)
-	return result, nil
+	enrichCtx = BuildContext(word, data.wikt, data.rel, data.cmu)
+
+	raw, err := json.MarshalIndent(enrichCtx, "", "  ")
+	if err != nil {
+		return enrichCtx, false, fmt.Errorf("marshal context: %w", err)
+	}
+	if err := os.WriteFile(outPath, raw, 0644); err != nil {
+		return enrichCtx, false, fmt.Errorf("write context file: %w", err)
+	}
+	written = true
+
+	if cfg.Mode == "api" {
+		if err := callLLM(ctx, llmClient, cfg, enrichCtx, log); err != nil {
+			return enrichCtx, false, err
+		}
+	}
+
+	if jsonl != nil {
+		if err := jsonl.add(enrichCtx); err != nil {
+			return enrichCtx, false, fmt.Errorf("write batch request: %w", err)
+		}
+	}
+	return enrichCtx, false, nil
+}
+
+// addExistingToBatch adds a batch request for a word from its previously
+// written context file.
+func addExistingToBatch(jsonl *jsonlBatchWriter, path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read context file: %w", err)
+	}
+	var enrichCtx EnrichContext
+	if err := json.Unmarshal(raw, &enrichCtx); err != nil {
+		return fmt.Errorf("parse context file: %w", err)
+	}
+	if err := jsonl.add(enrichCtx); err != nil {
+		return fmt.Errorf("write batch request: %w", err)
+	}
+	return nil
+}
+
+// deadlineNear reports whether ctx is done or its deadline is closer than
+// deadlineMargin.
+func deadlineNear(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < deadlineMargin
 }
 
 func readWordList(path string) ([]string, error) {
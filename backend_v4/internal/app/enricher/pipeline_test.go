@@ -0,0 +1,128 @@
+package enricher
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	anthropic "github.com/anthropics/anthropic-sdk-go"
+)
+
+func TestEnrichWords_AllCompletedWithoutDeadline(t *testing.T) {
+	cfg := &Config{EnrichOutputDir: t.TempDir(), Mode: "manual", BatchSize: 2}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// "run" already has a context file and should be reported as completed.
+	if err := os.WriteFile(filepath.Join(cfg.EnrichOutputDir, "run.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var result PipelineResult
+	enrichWords(context.Background(), cfg, []string{"run", "walk", "jump"}, datasets{}, anthropic.Client{}, nil, &result, log)
+
+	if result.Stopped {
+		t.Error("Stopped = true, want false")
+	}
+	if result.Written != 2 || result.Skipped != 1 {
+		t.Errorf("written=%d skipped=%d, want 2 and 1", result.Written, result.Skipped)
+	}
+	if want := []string{"run", "walk", "jump"}; !slices.Equal(result.Completed, want) {
+		t.Errorf("Completed = %v, want %v", result.Completed, want)
+	}
+	if result.BatchFiles != 1 {
+		t.Errorf("BatchFiles = %d, want 1", result.BatchFiles)
+	}
+}
+
+func TestEnrichWords_StopsNearDeadline(t *testing.T) {
+	cfg := &Config{EnrichOutputDir: t.TempDir(), Mode: "manual", BatchSize: 10}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), deadlineMargin/2)
+	defer cancel()
+
+	var result PipelineResult
+	enrichWords(ctx, cfg, []string{"run", "walk"}, datasets{}, anthropic.Client{}, nil, &result, log)
+
+	if !result.Stopped {
+		t.Error("Stopped = false, want true")
+	}
+	if len(result.Completed) != 0 || result.Written != 0 {
+		t.Errorf("completed=%v written=%d, want nothing processed", result.Completed, result.Written)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.EnrichOutputDir, "run.json")); !os.IsNotExist(err) {
+		t.Errorf("run.json should not be written, stat err = %v", err)
+	}
+}
+
+func TestEnrichWords_FailedWordDoesNotStopOthers(t *testing.T) {
+	cfg := &Config{EnrichOutputDir: t.TempDir(), Mode: "manual", BatchSize: 10}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// "and/or" maps to a file in a missing subdirectory, so its write fails.
+	var result PipelineResult
+	enrichWords(context.Background(), cfg, []string{"run", "and/or", "walk"}, datasets{}, anthropic.Client{}, nil, &result, log)
+
+	if want := []string{"run", "walk"}; !slices.Equal(result.Completed, want) {
+		t.Errorf("Completed = %v, want %v", result.Completed, want)
+	}
+	if result.Written != 2 {
+		t.Errorf("Written = %d, want 2", result.Written)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Word != "and/or" || result.Failed[0].Reason == "" {
+		t.Fatalf("Failed = %+v, want one entry for and/or", result.Failed)
+	}
+	for _, w := range []string{"run", "walk"} {
+		if _, err := os.Stat(filepath.Join(cfg.EnrichOutputDir, w+".json")); err != nil {
+			t.Errorf("%s.json not written: %v", w, err)
+		}
+	}
+	if result.BatchFiles != 1 {
+		t.Errorf("BatchFiles = %d, want 1", result.BatchFiles)
+	}
+}
+
+func TestEnrichWords_PanicIsRecordedPerWord(t *testing.T) {
+	cfg := &Config{EnrichOutputDir: t.TempDir(), Mode: "manual", BatchSize: 10}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// A writer without an output file panics on add.
+	jsonl := &jsonlBatchWriter{cfg: cfg}
+
+	var result PipelineResult
+	enrichWords(context.Background(), cfg, []string{"run", "walk"}, datasets{}, anthropic.Client{}, jsonl, &result, log)
+
+	if len(result.Failed) != 2 {
+		t.Fatalf("Failed = %+v, want both words", result.Failed)
+	}
+	if len(result.Completed) != 0 || result.Written != 0 {
+		t.Errorf("completed=%v written=%d, want nothing", result.Completed, result.Written)
+	}
+	// The context file of a failed word is removed so a rerun retries it.
+	if _, err := os.Stat(filepath.Join(cfg.EnrichOutputDir, "run.json")); !os.IsNotExist(err) {
+		t.Errorf("run.json should be removed, stat err = %v", err)
+	}
+}
+
+func TestDeadlineNear(t *testing.T) {
+	if deadlineNear(context.Background()) {
+		t.Error("no deadline should not be near")
+	}
+
+	far, cancelFar := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelFar()
+	if deadlineNear(far) {
+		t.Error("deadline an hour away should not be near")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if !deadlineNear(canceled) {
+		t.Error("canceled context should be near")
+	}
+}
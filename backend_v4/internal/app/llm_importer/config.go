@@ -7,12 +7,21 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// DefaultSourceSlug is the provenance slug stamped on imported rows when
+// source_slug is not configured.
+const DefaultSourceSlug = "llm"
+
 // Config holds llm-import settings.
 type Config struct {
 	LLMOutputDir string `yaml:"llm_output_dir" env:"LLM_IMPORT_OUTPUT_DIR" env-default:"./llm-output"`
 	BatchSize    int    `yaml:"batch_size"      env:"LLM_IMPORT_BATCH_SIZE" env-default:"500"`
 	DryRun       bool   `yaml:"dry_run"         env:"LLM_IMPORT_DRY_RUN"`
 	SourceSlug   string `yaml:"source_slug"     env:"LLM_IMPORT_SOURCE_SLUG" env-default:"llm"`
+	Force        bool   `yaml:"force"           env:"LLM_IMPORT_FORCE"`
+
+	// mapEntry replaces Map when set. Tests use it to feed Verify mappings
+	// that Map itself never produces.
+	mapEntry func(e LLMWordEntry, sourceSlug string) MappedEntry
 }
 
 // LoadConfig reads config from YAML file or environment variables.
@@ -2,11 +2,15 @@ package llm_importer
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder"
@@ -19,19 +23,36 @@ type EnrichmentQueue interface {
 	MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error
 }
 
+// ImportLog is an optional dependency that remembers which files were
+// imported, keyed by content hash, so re-runs skip them.
+type ImportLog interface {
+	FilterImported(ctx context.Context, hashes []string) (map[string]bool, error)
+	RecordImport(ctx context.Context, contentHash, fileName, word string) error
+}
+
+// rejectedDir is the subdirectory of LLMOutputDir that receives files
+// failing JSON decoding or validation.
+const rejectedDir = "rejected"
+
 // Result holds import statistics.
 type Result struct {
 	FilesProcessed int
 	Inserted       int
 	Replaced       int
 	Skipped        int
-	Errors         int
+	Rejected       int
+	// AlreadyImported counts files skipped because the import log already
+	// holds their content hash.
+	AlreadyImported int
+	Errors          int
 }
 
-// Run scans llmOutputDir for *.json files, validates, maps, and imports them.
+// Run scans llmOutputDir for *.json files, validates, maps, verifies, and
+// imports them.
 // For words that already exist in ref_entries, it replaces their content.
-// For new words, it bulk-inserts them.
-func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue EnrichmentQueue, log *slog.Logger) (Result, error) {
+// For new words, it bulk-inserts them. When importLog is set, files whose
+// content was imported before are skipped unless cfg.Force is set.
+func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue EnrichmentQueue, importLog ImportLog, log *slog.Logger) (Result, error) {
 	files, err := filepath.Glob(filepath.Join(cfg.LLMOutputDir, "*.json"))
 	if err != nil {
 		return Result{}, fmt.Errorf("glob llm output dir: %w", err)
@@ -39,12 +60,21 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 
 	var result Result
 
-	// Collect all entries first to batch-lookup existing ones.
-	type parsedFile struct {
-		path  string
-		entry LLMWordEntry
+	sourceSlug := cfg.SourceSlug
+	if sourceSlug == "" {
+		sourceSlug = DefaultSourceSlug
+	}
+	mapEntry := cfg.mapEntry
+	if mapEntry == nil {
+		mapEntry = Map
 	}
-	var parsed []parsedFile
+
+	type rawFile struct {
+		path string
+		hash string
+		data []byte
+	}
+	var raws []rawFile
 
 	for _, path := range files {
 		result.FilesProcessed++
@@ -56,24 +86,101 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 			continue
 		}
 
-		var entry LLMWordEntry
-		if err := json.Unmarshal(data, &entry); err != nil {
-			log.Error("unmarshal JSON", slog.String("path", path), slog.String("error", err.Error()))
-			result.Errors++
+		sum := sha256.Sum256(data)
+		raws = append(raws, rawFile{path: path, hash: hex.EncodeToString(sum[:]), data: data})
+	}
+
+	imported := map[string]bool{}
+	if importLog != nil && !cfg.Force && len(raws) > 0 {
+		hashes := make([]string, len(raws))
+		for i, raw := range raws {
+			hashes[i] = raw.hash
+		}
+		imported, err = importLog.FilterImported(ctx, hashes)
+		if err != nil {
+			return result, fmt.Errorf("lookup import log: %w", err)
+		}
+	}
+
+	// Collect all entries first to batch-lookup existing ones.
+	type parsedFile struct {
+		path   string
+		hash   string
+		entry  LLMWordEntry
+		mapped MappedEntry
+	}
+	var parsed []parsedFile
+	seenWords := make(map[string]string)
+
+	for _, raw := range raws {
+		path := raw.path
+
+		if imported[raw.hash] {
+			log.Debug("skip already imported file", slog.String("path", path))
+			result.AlreadyImported++
 			continue
 		}
 
-		if entry.SourceSlug == "" {
-			entry.SourceSlug = cfg.SourceSlug
+		var entry LLMWordEntry
+		if err := json.Unmarshal(raw.data, &entry); err != nil {
+			log.Warn("reject file: malformed JSON", slog.String("path", path), slog.String("error", err.Error()))
+			result.Rejected++
+			if !cfg.DryRun {
+				if err := reject(path, []string{"malformed JSON: " + err.Error()}); err != nil {
+					log.Error("move rejected file", slog.String("path", path), slog.String("error", err.Error()))
+				}
+			}
+			continue
 		}
 
 		if err := Validate(entry); err != nil {
-			log.Error("invalid entry", slog.String("path", path), slog.String("error", err.Error()))
-			result.Errors++
+			log.Warn("reject file: invalid entry", slog.String("path", path), slog.String("error", err.Error()))
+			result.Rejected++
+			if !cfg.DryRun {
+				rejectInvalid(path, err, log)
+			}
 			continue
 		}
 
-		parsed = append(parsed, parsedFile{path: path, entry: entry})
+		// Verify the mapped rows before anything is written, so a file that
+		// would leave translations or examples without a sense is rejected
+		// as a whole, dry run included.
+		// Provenance comes from the configuration only: a source_slug in
+		// model output is not trusted.
+		if entry.SourceSlug != "" && entry.SourceSlug != sourceSlug {
+			log.Warn("ignore source_slug from file", slog.String("path", path),
+				slog.String("file_slug", entry.SourceSlug), slog.String("slug", sourceSlug))
+		}
+		mapped := mapEntry(entry, sourceSlug)
+		if err := Verify(mapped); err != nil {
+			log.Warn("reject file: broken references", slog.String("path", path), slog.String("error", err.Error()))
+			result.Rejected++
+			if !cfg.DryRun {
+				rejectInvalid(path, err, log)
+			}
+			continue
+		}
+
+		// Two files for the same word in one run would insert the entry
+		// twice; keep the first and skip the rest.
+		normalized := domain.NormalizeText(entry.Word)
+		if first, dup := seenWords[normalized]; dup {
+			log.Warn("skip duplicate word", slog.String("path", path), slog.String("first", first))
+			result.Skipped++
+			continue
+		}
+		seenWords[normalized] = path
+
+		parsed = append(parsed, parsedFile{path: path, hash: raw.hash, entry: entry, mapped: mapped})
+	}
+
+	record := func(p parsedFile) {
+		if importLog == nil {
+			return
+		}
+		if err := importLog.RecordImport(ctx, p.hash, filepath.Base(p.path), p.entry.Word); err != nil {
+			log.Warn("record import", slog.String("path", p.path), slog.String("error", err.Error()))
+		}
 	}
 
 	if len(parsed) == 0 {
@@ -93,6 +200,7 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 
 	// Separate into replace vs insert.
 	var (
+		newFiles        []parsedFile
 		newEntries      []domain.RefEntry
 		newSenses       []domain.RefSense
 		newTranslations []domain.RefTranslation
@@ -101,7 +209,7 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 
 	for _, p := range parsed {
 		normalized := domain.NormalizeText(p.entry.Word)
-		mapped := Map(p.entry)
+		mapped := p.mapped
 
 		if existingID, exists := existingIDs[normalized]; exists {
 			// Replace: rewrite senses/translations/examples for existing entry.
@@ -124,12 +232,14 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 				continue
 			}
 			result.Replaced++
+			record(p)
 
 			if queue != nil {
 				_ = queue.MarkDone(ctx, existingID)
 			}
 		} else {
 			// New entry: accumulate for bulk insert.
+			newFiles = append(newFiles, p)
 			newEntries = append(newEntries, mapped.Entry)
 			newSenses = append(newSenses, mapped.Senses...)
 			newTranslations = append(newTranslations, mapped.Translations...)
@@ -155,6 +265,10 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 		if _, err := repo.BulkInsertExamples(ctx, newExamples); err != nil {
 			return result, fmt.Errorf("bulk insert examples: %w", err)
 		}
+
+		for _, p := range newFiles {
+			record(p)
+		}
 	}
 
 	log.Info("llm-import complete",
@@ -162,7 +276,51 @@ func Run(ctx context.Context, cfg *Config, repo seeder.RefEntryBulkRepo, queue E
 		slog.Int("inserted", result.Inserted),
 		slog.Int("replaced", result.Replaced),
 		slog.Int("skipped", result.Skipped),
+		slog.Int("rejected", result.Rejected),
+		slog.Int("already_imported", result.AlreadyImported),
 		slog.Int("errors", result.Errors),
 	)
 	return result, nil
 }
+
+// rejection is the sidecar written next to a rejected file.
+type rejection struct {
+	File   string   `json:"file"`
+	Errors []string `json:"errors"`
+}
+
+// rejectInvalid rejects path with the problems listed by a validation error.
+func rejectInvalid(path string, err error, log *slog.Logger) {
+	problems := []string{err.Error()}
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		problems = verr.Problems
+	}
+	if err := reject(path, problems); err != nil {
+		log.Error("move rejected file", slog.String("path", path), slog.String("error", err.Error()))
+	}
+}
+
+// reject moves path into the rejected/ subdirectory of its directory and
+// writes <name>.error.json next to it explaining why.
+func reject(path string, problems []string) error {
+	dir := filepath.Join(filepath.Dir(path), rejectedDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create rejected dir: %w", err)
+	}
+
+	name := filepath.Base(path)
+	sidecar, err := json.MarshalIndent(rejection{File: name, Errors: problems}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode rejection: %w", err)
+	}
+	errPath := filepath.Join(dir, strings.TrimSuffix(name, filepath.Ext(name))+".error.json")
+	if err := os.WriteFile(errPath, sidecar, 0o644); err != nil {
+		return fmt.Errorf("write rejection: %w", err)
+	}
+
+	if err := os.Rename(path, filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("move file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,339 @@
+package llm_importer
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// stubRepo implements the subset of seeder.RefEntryBulkRepo used by Run.
+// Calling any other method panics via the nil embedded interface.
+type stubRepo struct {
+	seeder.RefEntryBulkRepo
+	entries      []domain.RefEntry
+	senses       []domain.RefSense
+	translations []domain.RefTranslation
+	examples     []domain.RefExample
+}
+
+func (r *stubRepo) GetEntryIDsByNormalizedTexts(_ context.Context, _ []string) (map[string]uuid.UUID, error) {
+	return map[string]uuid.UUID{}, nil
+}
+
+func (r *stubRepo) BulkInsertEntries(_ context.Context, entries []domain.RefEntry) (int, error) {
+	r.entries = append(r.entries, entries...)
+	return len(entries), nil
+}
+
+func (r *stubRepo) BulkInsertSenses(_ context.Context, senses []domain.RefSense) (int, error) {
+	r.senses = append(r.senses, senses...)
+	return len(senses), nil
+}
+
+func (r *stubRepo) BulkInsertTranslations(_ context.Context, translations []domain.RefTranslation) (int, error) {
+	r.translations = append(r.translations, translations...)
+	return len(translations), nil
+}
+
+func (r *stubRepo) BulkInsertExamples(_ context.Context, examples []domain.RefExample) (int, error) {
+	r.examples = append(r.examples, examples...)
+	return len(examples), nil
+}
+
+func writeJSON(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun_RejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "abandon.json",
+		`{"word":"abandon","senses":[{"pos":"verb","definition":"To leave permanently.","translations":["бросать"]}]}`)
+	writeJSON(t, dir, "nodef.json",
+		`{"word":"nodef","senses":[{"pos":"NOUN","definition":""}]}`)
+	writeJSON(t, dir, "badpos.json",
+		`{"word":"badpos","senses":[{"pos":"BANANA","definition":"Something."}]}`)
+
+	repo := &stubRepo{}
+	cfg := &Config{LLMOutputDir: dir, SourceSlug: "llm"}
+
+	result, err := Run(context.Background(), cfg, repo, nil, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if result.FilesProcessed != 3 || result.Inserted != 1 || result.Rejected != 2 || result.Errors != 0 {
+		t.Errorf("result = %+v, want 3 processed, 1 inserted, 2 rejected, 0 errors", result)
+	}
+
+	// The valid file is imported with its Wiktionary-style POS resolved.
+	if len(repo.senses) != 1 || *repo.senses[0].PartOfSpeech != domain.PartOfSpeechVerb {
+		t.Errorf("imported senses = %+v, want one VERB sense", repo.senses)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "abandon.json")); err != nil {
+		t.Errorf("valid file should stay in place: %v", err)
+	}
+
+	for name, wantProblem := range map[string]string{
+		"nodef":  "empty definition",
+		"badpos": `invalid POS "BANANA"`,
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name+".json")); !os.IsNotExist(err) {
+			t.Errorf("%s.json should have been moved out of the input dir", name)
+		}
+		if _, err := os.Stat(filepath.Join(dir, rejectedDir, name+".json")); err != nil {
+			t.Errorf("%s.json missing from rejected/: %v", name, err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, rejectedDir, name+".error.json"))
+		if err != nil {
+			t.Fatalf("read %s sidecar: %v", name, err)
+		}
+		var rej rejection
+		if err := json.Unmarshal(data, &rej); err != nil {
+			t.Fatalf("decode %s sidecar: %v", name, err)
+		}
+		if rej.File != name+".json" || len(rej.Errors) == 0 || !strings.Contains(rej.Errors[0], wantProblem) {
+			t.Errorf("%s sidecar = %+v, want problem containing %q", name, rej, wantProblem)
+		}
+	}
+}
+
+func TestRun_DryRunDoesNotMoveRejected(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "broken.json", `{"word":`)
+
+	cfg := &Config{LLMOutputDir: dir, DryRun: true}
+	result, err := Run(context.Background(), cfg, &stubRepo{}, nil, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Rejected != 1 {
+		t.Errorf("Rejected = %d, want 1", result.Rejected)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.json")); err != nil {
+		t.Errorf("dry run should leave rejected file in place: %v", err)
+	}
+}
+
+// memImportLog is an in-memory ImportLog.
+type memImportLog struct {
+	hashes map[string]string
+}
+
+func (l *memImportLog) FilterImported(_ context.Context, hashes []string) (map[string]bool, error) {
+	out := make(map[string]bool)
+	for _, h := range hashes {
+		if _, ok := l.hashes[h]; ok {
+			out[h] = true
+		}
+	}
+	return out, nil
+}
+
+func (l *memImportLog) RecordImport(_ context.Context, contentHash, fileName, _ string) error {
+	if l.hashes == nil {
+		l.hashes = make(map[string]string)
+	}
+	l.hashes[contentHash] = fileName
+	return nil
+}
+
+func TestRun_SkipsAlreadyImportedOnRerun(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "abandon.json",
+		`{"word":"abandon","senses":[{"pos":"VERB","definition":"To leave permanently."}]}`)
+
+	repo := &stubRepo{}
+	importLog := &memImportLog{}
+	cfg := &Config{LLMOutputDir: dir}
+
+	if _, err := Run(context.Background(), cfg, repo, nil, importLog, slog.Default()); err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+	if len(importLog.hashes) != 1 {
+		t.Fatalf("import log has %d entries after first run, want 1", len(importLog.hashes))
+	}
+
+	// A second file with new content is imported; the unchanged one is not.
+	writeJSON(t, dir, "abrupt.json",
+		`{"word":"abrupt","senses":[{"pos":"ADJECTIVE","definition":"Sudden and unexpected."}]}`)
+
+	result, err := Run(context.Background(), cfg, repo, nil, importLog, slog.Default())
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if result.AlreadyImported != 1 || result.Inserted != 1 {
+		t.Errorf("result = %+v, want 1 already imported, 1 inserted", result)
+	}
+	if len(repo.entries) != 2 {
+		t.Errorf("repo holds %d entries, want 2 (no duplicates)", len(repo.entries))
+	}
+}
+
+func TestRun_ForceReprocesses(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "abandon.json",
+		`{"word":"abandon","senses":[{"pos":"VERB","definition":"To leave permanently."}]}`)
+
+	repo := &stubRepo{}
+	importLog := &memImportLog{}
+	if _, err := Run(context.Background(), &Config{LLMOutputDir: dir}, repo, nil, importLog, slog.Default()); err != nil {
+		t.Fatalf("first Run: %v", err)
+	}
+
+	result, err := Run(context.Background(), &Config{LLMOutputDir: dir, Force: true}, repo, nil, importLog, slog.Default())
+	if err != nil {
+		t.Fatalf("forced Run: %v", err)
+	}
+	if result.AlreadyImported != 0 || result.Inserted != 1 {
+		t.Errorf("result = %+v, want 0 already imported, 1 inserted", result)
+	}
+}
+
+func TestRun_DedupesWordWithinRun(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "a.json",
+		`{"word":"Abandon","senses":[{"pos":"VERB","definition":"To leave permanently."}]}`)
+	writeJSON(t, dir, "b.json",
+		`{"word":" abandon ","senses":[{"pos":"VERB","definition":"To give up."}]}`)
+
+	repo := &stubRepo{}
+	result, err := Run(context.Background(), &Config{LLMOutputDir: dir}, repo, nil, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if result.Inserted != 1 || result.Skipped != 1 {
+		t.Errorf("result = %+v, want 1 inserted, 1 skipped", result)
+	}
+	if len(repo.entries) != 1 || repo.entries[0].Text != "Abandon" {
+		t.Errorf("entries = %+v, want only the first file's entry", repo.entries)
+	}
+}
+
+func TestRun_RejectsDanglingTranslation(t *testing.T) {
+	// Map never produces a dangling translation, so break the mapping of one
+	// word to exercise the verify step.
+	mapEntry := func(e LLMWordEntry, sourceSlug string) MappedEntry {
+		m := Map(e, sourceSlug)
+		if e.Word == "dangling" {
+			m.Translations[0].RefSenseID = uuid.New()
+		}
+		return m
+	}
+
+	for _, dryRun := range []bool{false, true} {
+		t.Run(map[bool]string{false: "import", true: "dry run"}[dryRun], func(t *testing.T) {
+			dir := t.TempDir()
+			writeJSON(t, dir, "abandon.json",
+				`{"word":"abandon","senses":[{"pos":"VERB","definition":"To leave permanently.","translations":["бросать"]}]}`)
+			writeJSON(t, dir, "dangling.json",
+				`{"word":"dangling","senses":[{"pos":"VERB","definition":"To hang loosely.","translations":["болтаться"]}]}`)
+
+			repo := &stubRepo{}
+			cfg := &Config{LLMOutputDir: dir, DryRun: dryRun, mapEntry: mapEntry}
+			result, err := Run(context.Background(), cfg, repo, nil, nil, slog.Default())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			if result.Rejected != 1 || result.Errors != 0 {
+				t.Errorf("result = %+v, want 1 rejected, 0 errors", result)
+			}
+
+			if dryRun {
+				if len(repo.entries) != 0 {
+					t.Errorf("dry run inserted %d entries", len(repo.entries))
+				}
+				if _, err := os.Stat(filepath.Join(dir, "dangling.json")); err != nil {
+					t.Errorf("dry run should leave rejected file in place: %v", err)
+				}
+				return
+			}
+
+			if len(repo.entries) != 1 || repo.entries[0].Text != "abandon" {
+				t.Errorf("entries = %+v, want only abandon", repo.entries)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, rejectedDir, "dangling.error.json"))
+			if err != nil {
+				t.Fatalf("read sidecar: %v", err)
+			}
+			var rej rejection
+			if err := json.Unmarshal(data, &rej); err != nil {
+				t.Fatalf("decode sidecar: %v", err)
+			}
+			if len(rej.Errors) == 0 || !strings.Contains(rej.Errors[0], "unknown sense") {
+				t.Errorf("sidecar = %+v, want an unknown sense problem", rej)
+			}
+		})
+	}
+}
+
+func TestRun_StampsConfiguredSourceSlug(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "abandon.json",
+		`{"word":"abandon","senses":[{"pos":"VERB","definition":"To leave permanently.","translations":["бросать"],"examples":[{"sentence":"She abandoned the car."}]}]}`)
+	writeJSON(t, dir, "run.json",
+		`{"word":"run","source_slug":"wiktionary","senses":[{"pos":"VERB","definition":"To move fast.","translations":["бежать"]}]}`)
+
+	repo := &stubRepo{}
+	cfg := &Config{LLMOutputDir: dir, SourceSlug: "llm-gpt"}
+	if _, err := Run(context.Background(), cfg, repo, nil, nil, slog.Default()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(repo.senses) != 2 || len(repo.translations) != 2 || len(repo.examples) != 1 {
+		t.Fatalf("inserted %d senses, %d translations, %d examples; want 2, 2, 1",
+			len(repo.senses), len(repo.translations), len(repo.examples))
+	}
+
+	// run.json claims to come from wiktionary; the file's slug is ignored and
+	// every row gets the configured one.
+	want := map[string]string{"abandon": "llm-gpt", "run": "llm-gpt"}
+	entryText := make(map[uuid.UUID]string)
+	for _, e := range repo.entries {
+		entryText[e.ID] = e.Text
+	}
+	senseText := make(map[uuid.UUID]string)
+	for _, s := range repo.senses {
+		senseText[s.ID] = entryText[s.RefEntryID]
+		if s.SourceSlug != want[senseText[s.ID]] {
+			t.Errorf("sense of %q: SourceSlug = %q, want %q", senseText[s.ID], s.SourceSlug, want[senseText[s.ID]])
+		}
+	}
+	for _, tr := range repo.translations {
+		if word := senseText[tr.RefSenseID]; tr.SourceSlug != want[word] {
+			t.Errorf("translation of %q: SourceSlug = %q, want %q", word, tr.SourceSlug, want[word])
+		}
+	}
+	for _, ex := range repo.examples {
+		if word := senseText[ex.RefSenseID]; ex.SourceSlug != want[word] {
+			t.Errorf("example of %q: SourceSlug = %q, want %q", word, ex.SourceSlug, want[word])
+		}
+	}
+}
+
+func TestRun_DefaultSourceSlug(t *testing.T) {
+	dir := t.TempDir()
+	writeJSON(t, dir, "abandon.json",
+		`{"word":"abandon","senses":[{"pos":"VERB","definition":"To leave permanently.","translations":["бросать"]}]}`)
+
+	repo := &stubRepo{}
+	if _, err := Run(context.Background(), &Config{LLMOutputDir: dir}, repo, nil, nil, slog.Default()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(repo.senses) != 1 || repo.senses[0].SourceSlug != DefaultSourceSlug ||
+		len(repo.translations) != 1 || repo.translations[0].SourceSlug != DefaultSourceSlug {
+		t.Errorf("senses = %+v, translations = %+v, want slug %q", repo.senses, repo.translations, DefaultSourceSlug)
+	}
+}
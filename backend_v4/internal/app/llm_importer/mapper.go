@@ -15,15 +15,15 @@ type MappedEntry struct {
 	Examples     []domain.RefExample
 }
 
-// Map converts an LLMWordEntry to domain types for insertion.
-// Assumes the entry has been validated via Validate() first.
-func Map(e LLMWordEntry) MappedEntry {
+// Map converts an LLMWordEntry to domain types for insertion, stamping
+// sourceSlug (DefaultSourceSlug when empty) on every sense, translation and
+// example. Assumes the entry has been validated via Validate() first.
+func Map(e LLMWordEntry, sourceSlug string) MappedEntry {
 	now := time.Now()
 	entryID := uuid.New()
 
-	sourceSlug := e.SourceSlug
 	if sourceSlug == "" {
-		sourceSlug = "llm"
+		sourceSlug = DefaultSourceSlug
 	}
 
 	result := MappedEntry{
@@ -38,7 +38,7 @@ func Map(e LLMWordEntry) MappedEntry {
 
 	for i, s := range e.Senses {
 		senseID := uuid.New()
-		pos := domain.PartOfSpeech(s.POS)
+		pos, _ := ResolvePOS(s.POS)
 
 		sense := domain.RefSense{
 			ID:           senseID,
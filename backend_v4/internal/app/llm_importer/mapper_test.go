@@ -24,7 +24,7 @@ func TestMap_basicEntry(t *testing.T) {
 		},
 	}
 
-	result := Map(input)
+	result := Map(input, "llm")
 
 	if result.Entry.Text != "Abandon" {
 		t.Errorf("Entry.Text = %q, want %q", result.Entry.Text, "Abandon")
@@ -59,6 +59,9 @@ func TestMap_basicEntry(t *testing.T) {
 	if result.Translations[0].Text != "бросать" {
 		t.Errorf("Translations[0].Text = %q", result.Translations[0].Text)
 	}
+	if result.Translations[0].SourceSlug != "llm" {
+		t.Errorf("Translations[0].SourceSlug = %q, want llm", result.Translations[0].SourceSlug)
+	}
 
 	if len(result.Examples) != 1 {
 		t.Fatalf("len(Examples) = %d, want 1", len(result.Examples))
@@ -77,7 +80,7 @@ func TestMap_emptyCEFRAndNotes(t *testing.T) {
 		Word: "run", SourceSlug: "llm",
 		Senses: []LLMSense{{POS: "VERB", Definition: "To move fast."}},
 	}
-	result := Map(input)
+	result := Map(input, "llm")
 	if result.Senses[0].CEFRLevel != nil {
 		t.Error("CEFRLevel should be nil when empty string in JSON")
 	}
@@ -3,7 +3,9 @@ package llm_importer
 // LLMWordEntry is the top-level JSON document produced by the LLM.
 // One file per word: llm-output/<word>.json
 type LLMWordEntry struct {
-	Word       string     `json:"word"`
+	Word string `json:"word"`
+	// SourceSlug is what the model claims as the source. Run ignores it and
+	// stamps the configured slug instead.
 	SourceSlug string     `json:"source_slug"`
 	Senses     []LLMSense `json:"senses"`
 }
@@ -2,33 +2,157 @@ package llm_importer
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
+// Length limits for LLM output, in runes.
+const (
+	maxDefinitionLen  = 5000
+	maxTranslationLen = 500
+	maxSentenceLen    = 1000
+)
+
 var validCEFR = map[string]bool{
 	"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true,
 }
 
-// Validate checks that an LLMWordEntry has all required fields with valid values.
+// ValidationError lists every problem found in one LLM word entry.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// ResolvePOS maps an LLM POS value to the domain enum. Both domain values
+// ("VERB") and Wiktionary tags ("verb", "adj") are accepted, case-insensitively.
+func ResolvePOS(raw string) (domain.PartOfSpeech, bool) {
+	if pos := domain.PartOfSpeech(strings.ToUpper(strings.TrimSpace(raw))); pos.IsValid() {
+		return pos, true
+	}
+	return wiktionary.LookupPOS(strings.TrimSpace(raw))
+}
+
+// Validate checks that an LLMWordEntry has all required fields with valid
+// values. It returns a *ValidationError listing every problem found.
 func Validate(e LLMWordEntry) error {
-	if e.Word == "" {
-		return fmt.Errorf("word is empty")
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if strings.TrimSpace(e.Word) == "" {
+		addf("word is empty")
 	}
 	if len(e.Senses) == 0 {
-		return fmt.Errorf("word %q has no senses", e.Word)
+		addf("word %q has no senses", e.Word)
 	}
 	for i, s := range e.Senses {
-		if s.Definition == "" {
-			return fmt.Errorf("sense %d of %q has empty definition", i, e.Word)
+		def := strings.TrimSpace(s.Definition)
+		if def == "" {
+			addf("sense %d of %q has empty definition", i, e.Word)
+		} else if utf8.RuneCountInString(def) > maxDefinitionLen {
+			addf("sense %d of %q has definition longer than %d characters", i, e.Word, maxDefinitionLen)
 		}
-		pos := domain.PartOfSpeech(s.POS)
-		if !pos.IsValid() {
-			return fmt.Errorf("sense %d of %q has invalid POS %q", i, e.Word, s.POS)
+		if _, ok := ResolvePOS(s.POS); !ok {
+			addf("sense %d of %q has invalid POS %q", i, e.Word, s.POS)
 		}
 		if s.CEFRLevel != "" && !validCEFR[s.CEFRLevel] {
-			return fmt.Errorf("sense %d of %q has invalid CEFR level %q", i, e.Word, s.CEFRLevel)
+			addf("sense %d of %q has invalid CEFR level %q", i, e.Word, s.CEFRLevel)
+		}
+		for j, tr := range s.Translations {
+			tr = strings.TrimSpace(tr)
+			if tr == "" {
+				addf("sense %d translation %d of %q is empty", i, j, e.Word)
+			} else if utf8.RuneCountInString(tr) > maxTranslationLen {
+				addf("sense %d translation %d of %q is longer than %d characters", i, j, e.Word, maxTranslationLen)
+			}
+		}
+		for j, ex := range s.Examples {
+			sentence := strings.TrimSpace(ex.Sentence)
+			if sentence == "" {
+				addf("sense %d example %d of %q has empty sentence", i, j, e.Word)
+			} else if utf8.RuneCountInString(sentence) > maxSentenceLen {
+				addf("sense %d example %d of %q is longer than %d characters", i, j, e.Word, maxSentenceLen)
+			}
+			if utf8.RuneCountInString(ex.Translation) > maxSentenceLen {
+				addf("sense %d example %d translation of %q is longer than %d characters", i, j, e.Word, maxSentenceLen)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Verify checks the referential integrity of a mapped entry before it is
+// written: every sense belongs to the entry and has its required fields, and
+// every translation and example points to a sense of the same entry. It
+// returns a *ValidationError listing every problem found.
+func Verify(m MappedEntry) error {
+	var problems []string
+	addf := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if m.Entry.ID == uuid.Nil {
+		addf("entry %q has no ID", m.Entry.Text)
+	}
+	if strings.TrimSpace(m.Entry.Text) == "" {
+		addf("entry has empty text")
+	}
+
+	senses := make(map[uuid.UUID]bool, len(m.Senses))
+	for i, s := range m.Senses {
+		if s.ID == uuid.Nil {
+			addf("sense %d of %q has no ID", i, m.Entry.Text)
+		} else if senses[s.ID] {
+			addf("sense %d of %q has duplicate ID %s", i, m.Entry.Text, s.ID)
+		}
+		senses[s.ID] = true
+
+		if s.RefEntryID != m.Entry.ID {
+			addf("sense %d of %q belongs to another entry %s", i, m.Entry.Text, s.RefEntryID)
+		}
+		if strings.TrimSpace(s.Definition) == "" {
+			addf("sense %d of %q has empty definition", i, m.Entry.Text)
+		}
+		if s.PartOfSpeech == nil || !s.PartOfSpeech.IsValid() {
+			addf("sense %d of %q has no valid part of speech", i, m.Entry.Text)
 		}
+		if s.SourceSlug == "" {
+			addf("sense %d of %q has no source", i, m.Entry.Text)
+		}
+	}
+
+	for i, tr := range m.Translations {
+		if s := tr.RefSenseID; s == uuid.Nil || !senses[s] {
+			addf("translation %d %q of %q points to unknown sense %s", i, tr.Text, m.Entry.Text, s)
+		}
+		if strings.TrimSpace(tr.Text) == "" {
+			addf("translation %d of %q is empty", i, m.Entry.Text)
+		}
+	}
+	for i, ex := range m.Examples {
+		if s := ex.RefSenseID; s == uuid.Nil || !senses[s] {
+			addf("example %d of %q points to unknown sense %s", i, m.Entry.Text, s)
+		}
+		if strings.TrimSpace(ex.Sentence) == "" {
+			addf("example %d of %q has empty sentence", i, m.Entry.Text)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
 	}
 	return nil
 }
@@ -1,6 +1,12 @@
 package llm_importer
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
 
 func TestValidate_valid(t *testing.T) {
 	entry := LLMWordEntry{
@@ -67,3 +73,76 @@ func TestValidate_emptyDefinition(t *testing.T) {
 		t.Error("Validate() expected error for empty definition")
 	}
 }
+
+func TestValidate_wiktionaryPOS(t *testing.T) {
+	entry := LLMWordEntry{
+		Word: "run", SourceSlug: "llm",
+		Senses: []LLMSense{{POS: "adj", Definition: "x"}},
+	}
+	if err := Validate(entry); err != nil {
+		t.Errorf("Validate() unexpected error for Wiktionary POS tag: %v", err)
+	}
+}
+
+func TestValidate_translationTooLong(t *testing.T) {
+	entry := LLMWordEntry{
+		Word: "run", SourceSlug: "llm",
+		Senses: []LLMSense{{POS: "VERB", Definition: "x", Translations: []string{strings.Repeat("я", maxTranslationLen+1)}}},
+	}
+	if err := Validate(entry); err == nil {
+		t.Error("Validate() expected error for overlong translation")
+	}
+}
+
+func TestValidate_collectsAllProblems(t *testing.T) {
+	entry := LLMWordEntry{
+		Word: "run", SourceSlug: "llm",
+		Senses: []LLMSense{{POS: "BANANA", Definition: ""}},
+	}
+	var verr *ValidationError
+	if err := Validate(entry); !errors.As(err, &verr) || len(verr.Problems) != 2 {
+		t.Errorf("Validate() = %v, want ValidationError with 2 problems", err)
+	}
+}
+
+func validMapped() MappedEntry {
+	return Map(LLMWordEntry{
+		Word: "abandon",
+		Senses: []LLMSense{{
+			POS: "VERB", Definition: "To leave permanently.",
+			Translations: []string{"бросать"},
+			Examples:     []LLMExample{{Sentence: "She abandoned the car."}},
+		}},
+	}, "llm")
+}
+
+func TestVerify_valid(t *testing.T) {
+	if err := Verify(validMapped()); err != nil {
+		t.Errorf("Verify() unexpected error: %v", err)
+	}
+}
+
+func TestVerify_brokenReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(m *MappedEntry)
+		want   string
+	}{
+		{"dangling translation", func(m *MappedEntry) { m.Translations[0].RefSenseID = uuid.New() }, "translation 0"},
+		{"dangling example", func(m *MappedEntry) { m.Examples[0].RefSenseID = uuid.New() }, "example 0"},
+		{"sense of another entry", func(m *MappedEntry) { m.Senses[0].RefEntryID = uuid.New() }, "another entry"},
+		{"sense without definition", func(m *MappedEntry) { m.Senses[0].Definition = " " }, "empty definition"},
+		{"sense without part of speech", func(m *MappedEntry) { m.Senses[0].PartOfSpeech = nil }, "part of speech"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := validMapped()
+			tt.mutate(&m)
+
+			var verr *ValidationError
+			if err := Verify(m); !errors.As(err, &verr) || !strings.Contains(verr.Error(), tt.want) {
+				t.Errorf("Verify() = %v, want ValidationError mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// audioCheckWorkers bounds concurrent requests while validating audio URLs.
+const audioCheckWorkers = 8
+
+// AudioChecker reports whether a pronunciation audio URL resolves to a file.
+// An error means the check itself failed (e.g. no network), not that the
+// file is missing.
+type AudioChecker interface {
+	Exists(ctx context.Context, url string) (bool, error)
+}
+
+// HTTPAudioChecker checks audio URLs with HEAD requests.
+type HTTPAudioChecker struct {
+	client *http.Client
+}
+
+// NewHTTPAudioChecker creates an HTTPAudioChecker with the given per-request
+// timeout.
+func NewHTTPAudioChecker(timeout time.Duration) *HTTPAudioChecker {
+	return &HTTPAudioChecker{client: &http.Client{Timeout: timeout}}
+}
+
+// Exists returns true for 2xx, false for 404/410 and an error otherwise.
+func (c *HTTPAudioChecker) Exists(ctx context.Context, url string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return true, nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+}
+
+// validateAudioURLs clears AudioURL on pronunciations whose file does not
+// exist. URLs whose check fails are kept, so an offline run loses nothing.
+// Returns the number of dropped URLs and failed checks.
+func validateAudioURLs(ctx context.Context, checker AudioChecker, prons []domain.RefPronunciation) (dropped, failed int) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, audioCheckWorkers)
+	)
+
+	for i := range prons {
+		if prons[i].AudioURL == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p *domain.RefPronunciation) {
+			defer func() { <-sem; wg.Done() }()
+
+			ok, err := checker.Exists(ctx, *p.AudioURL)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				failed++
+			case !ok:
+				p.AudioURL = nil
+				dropped++
+			}
+		}(&prons[i])
+	}
+	wg.Wait()
+
+	return dropped, failed
+}
@@ -0,0 +1,90 @@
+package seeder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// fakeAudioChecker answers from a fixed table of URLs.
+type fakeAudioChecker struct {
+	mu      sync.Mutex
+	exists  map[string]bool
+	errs    map[string]error
+	checked []string
+}
+
+func (f *fakeAudioChecker) Exists(_ context.Context, url string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checked = append(f.checked, url)
+	return f.exists[url], f.errs[url]
+}
+
+func TestValidateAudioURLs(t *testing.T) {
+	ok, missing, offline := "https://x/ok.ogg", "https://x/missing.ogg", "https://x/offline.ogg"
+	prons := []domain.RefPronunciation{
+		{AudioURL: &ok},
+		{AudioURL: &missing},
+		{AudioURL: &offline},
+		{AudioURL: nil},
+	}
+	checker := &fakeAudioChecker{
+		exists: map[string]bool{ok: true},
+		errs:   map[string]error{offline: errors.New("no network")},
+	}
+
+	dropped, failed := validateAudioURLs(context.Background(), checker, prons)
+
+	if dropped != 1 || failed != 1 {
+		t.Errorf("dropped, failed = %d, %d; want 1, 1", dropped, failed)
+	}
+	if len(checker.checked) != 3 {
+		t.Errorf("checked %d URLs, want 3 (nil URLs skipped)", len(checker.checked))
+	}
+	if prons[0].AudioURL == nil {
+		t.Error("existing URL was dropped")
+	}
+	if prons[1].AudioURL != nil {
+		t.Error("missing URL was kept")
+	}
+	if prons[2].AudioURL == nil {
+		t.Error("URL with a failed check should be kept")
+	}
+}
+
+func TestHTTPAudioChecker_Exists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		switch r.URL.Path {
+		case "/ok.ogg":
+			w.WriteHeader(http.StatusOK)
+		case "/gone.ogg":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	checker := NewHTTPAudioChecker(time.Second)
+	ctx := context.Background()
+
+	if ok, err := checker.Exists(ctx, srv.URL+"/ok.ogg"); !ok || err != nil {
+		t.Errorf("ok.ogg: got (%v, %v), want (true, nil)", ok, err)
+	}
+	if ok, err := checker.Exists(ctx, srv.URL+"/gone.ogg"); ok || err != nil {
+		t.Errorf("gone.ogg: got (%v, %v), want (false, nil)", ok, err)
+	}
+	if _, err := checker.Exists(ctx, srv.URL+"/busy.ogg"); err == nil {
+		t.Error("busy.ogg: expected error for 503")
+	}
+}
@@ -0,0 +1,106 @@
+package seeder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// phaseCheckpoint records how far a phase got. The dataset stamp ties the
+// checkpoint to the exact input file it was written for.
+type phaseCheckpoint struct {
+	DatasetSize    int64     `json:"dataset_size"`
+	DatasetModTime time.Time `json:"dataset_mod_time"`
+	EntriesDone    int       `json:"entries_done,omitempty"`
+	LastWord       string    `json:"last_word,omitempty"`
+	Completed      bool      `json:"completed"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// checkpoint is the on-disk progress file, keyed by phase name.
+type checkpoint struct {
+	Phases map[string]*phaseCheckpoint `json:"phases"`
+}
+
+func newCheckpoint() *checkpoint {
+	return &checkpoint{Phases: make(map[string]*phaseCheckpoint)}
+}
+
+// loadCheckpoint reads a checkpoint file. A missing file yields an empty checkpoint.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return newCheckpoint(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+
+	cp := newCheckpoint()
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint: %w", err)
+	}
+	if cp.Phases == nil {
+		cp.Phases = make(map[string]*phaseCheckpoint)
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint atomically via a temp file and rename, so a
+// crash mid-write never leaves a truncated checkpoint behind.
+func (c *checkpoint) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create checkpoint temp file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("close checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("rename checkpoint: %w", err)
+	}
+	return nil
+}
+
+// datasetStamp returns the size and modification time of a phase's dataset.
+// For a dataset spread over several files it returns their total size and
+// the latest modification time, so a change to any file changes the stamp.
+func datasetStamp(paths ...string) (int64, time.Time, error) {
+	var (
+		size    int64
+		modTime time.Time
+	)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		size += info.Size()
+		if mt := info.ModTime().UTC(); mt.After(modTime) {
+			modTime = mt
+		}
+	}
+	return size, modTime, nil
+}
+
+// matches reports whether the checkpoint was written for the dataset as it
+// is on disk now.
+func (pc *phaseCheckpoint) matches(size int64, modTime time.Time) bool {
+	return pc.DatasetSize == size && pc.DatasetModTime.Equal(modTime)
+}
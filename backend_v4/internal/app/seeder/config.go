@@ -7,6 +7,9 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// defaultBatchSize is used when batch_size is not positive.
+const defaultBatchSize = 500
+
 // Config holds seeder pipeline settings.
 type Config struct {
 	WiktionaryPath     string `yaml:"wiktionary_path"      env:"SEEDER_WIKTIONARY_PATH"`
@@ -17,8 +20,37 @@ type Config struct {
 	TatoebaPath        string `yaml:"tatoeba_path"         env:"SEEDER_TATOEBA_PATH"`
 	TopN               int    `yaml:"top_n"                env:"SEEDER_TOP_N"          env-default:"20000"`
 	BatchSize          int    `yaml:"batch_size"           env:"SEEDER_BATCH_SIZE"      env-default:"500"`
+	ParseWorkers       int    `yaml:"parse_workers"        env:"SEEDER_PARSE_WORKERS"   env-default:"1"`
 	MaxExamplesPerWord int    `yaml:"max_examples_per_word" env:"SEEDER_MAX_EXAMPLES"   env-default:"5"`
+	MaxDefinitionLen   int    `yaml:"max_definition_len"   env:"SEEDER_MAX_DEFINITION_LEN" env-default:"5000"`
+	MinDefinitionLen   int    `yaml:"min_definition_len"   env:"SEEDER_MIN_DEFINITION_LEN"`
+	MarkupMode         string `yaml:"markup_mode"          env:"SEEDER_MARKUP_MODE"     env-default:"strip"`
+	NormalizeIPA       bool   `yaml:"normalize_ipa"        env:"SEEDER_NORMALIZE_IPA"`
+	BroadIPA           bool   `yaml:"broad_ipa"            env:"SEEDER_BROAD_IPA"`
+	DeterministicIDs   bool   `yaml:"deterministic_ids"    env:"SEEDER_DETERMINISTIC_IDS"`
+	ValidateAudio      bool   `yaml:"validate_audio"       env:"SEEDER_VALIDATE_AUDIO"`
 	DryRun             bool   `yaml:"dry_run"              env:"SEEDER_DRY_RUN"`
+	CEFRFromFrequency  bool   `yaml:"cefr_from_frequency"  env:"SEEDER_CEFR_FROM_FREQUENCY"`
+	ReportPath         string `yaml:"report_path"          env:"SEEDER_REPORT_PATH"`
+	CheckpointPath     string `yaml:"checkpoint_path"      env:"SEEDER_CHECKPOINT_PATH"`
+	Resume             bool   `yaml:"resume"               env:"SEEDER_RESUME"`
+	StrictPOS          bool   `yaml:"strict_pos"           env:"SEEDER_STRICT_POS"`
+	UseCopy            bool   `yaml:"use_copy"             env:"SEEDER_USE_COPY"`
+	ProgressEvery      int    `yaml:"progress_every"       env:"SEEDER_PROGRESS_EVERY"  env-default:"1000"`
+
+	// SenseSimilarity is the word-overlap threshold (0..1) above which senses
+	// of one entry from different sources are merged; 0 disables merging.
+	SenseSimilarity float64 `yaml:"sense_similarity" env:"SEEDER_SENSE_SIMILARITY" env-default:"0.8"`
+
+	// Languages is the allowlist of Wiktionary translation language codes;
+	// HeadwordLanguages is the allowlist of entry (headword) languages.
+	Languages         []string `yaml:"languages"          env:"SEEDER_LANGUAGES"          env-default:"ru"`
+	HeadwordLanguages []string `yaml:"headword_languages" env:"SEEDER_HEADWORD_LANGUAGES" env-default:"en"`
+
+	// POSMappings registers extra POS tag mappings per source slug, e.g.
+	// {"wiktionary": {"n": "NOUN"}}. They take precedence over the built-in
+	// mapping for that source. Only "wiktionary" maps POS tags. YAML only.
+	POSMappings map[string]map[string]string `yaml:"pos_mappings"`
 }
 
 // LoadConfig reads seeder configuration from a YAML file and environment variables.
@@ -31,6 +63,9 @@ func LoadConfig(path string) (*Config, error) {
 			if err := cleanenv.ReadConfig(path, &cfg); err != nil {
 				return nil, fmt.Errorf("seeder config: read %s: %w", path, err)
 			}
+			if err := cfg.Validate(); err != nil {
+				return nil, err
+			}
 			return &cfg, nil
 		}
 		return nil, fmt.Errorf("seeder config: file %s not found", path)
@@ -39,6 +74,25 @@ func LoadConfig(path string) (*Config, error) {
 	if err := cleanenv.ReadEnv(&cfg); err != nil {
 		return nil, fmt.Errorf("seeder config: read env: %w", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 
 	return &cfg, nil
 }
+
+// posMappingSources lists the source slugs whose phase reads POSMappings.
+var posMappingSources = map[string]bool{"wiktionary": true}
+
+// Validate rejects settings that would otherwise be silently ignored.
+func (c *Config) Validate() error {
+	for source := range c.POSMappings {
+		if !posMappingSources[source] {
+			return fmt.Errorf("seeder config: pos_mappings: unknown source %q: only wiktionary maps POS tags", source)
+		}
+	}
+	if c.SenseSimilarity < 0 || c.SenseSimilarity > 1 {
+		return fmt.Errorf("seeder config: sense_similarity: %v is outside 0..1", c.SenseSimilarity)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package seeder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+func TestLoadConfig_POSMappingsOverrideDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeder.yaml")
+	yaml := "pos_mappings:\n  wiktionary:\n    n: NOUN\n    name: OTHER\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	m, err := wiktionary.NewPOSMap(cfg.POSMappings["wiktionary"])
+	if err != nil {
+		t.Fatalf("NewPOSMap: %v", err)
+	}
+	if got := m.Map("name"); got != domain.PartOfSpeechOther {
+		t.Errorf("Map(name) = %q, want OTHER from config over the built-in NOUN", got)
+	}
+	if got := m.Map("n"); got != domain.PartOfSpeechNoun {
+		t.Errorf("Map(n) = %q, want NOUN", got)
+	}
+}
+
+func TestLoadConfig_SenseSimilarityOutOfRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeder.yaml")
+	if err := os.WriteFile(path, []byte("sense_similarity: 1.5\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), "sense_similarity") {
+		t.Fatalf("LoadConfig: err = %v, want sense_similarity range error", err)
+	}
+}
+
+func TestLoadConfig_POSMappingsUnknownSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seeder.yaml")
+	yaml := "pos_mappings:\n  wordnet:\n    n: NOUN\n"
+	if err := os.WriteFile(path, []byte(yaml), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	_, err := LoadConfig(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown source "wordnet"`) {
+		t.Fatalf("LoadConfig: err = %v, want unknown source error", err)
+	}
+}
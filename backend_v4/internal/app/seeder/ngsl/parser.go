@@ -147,6 +147,25 @@ func parseNAWL(r io.Reader) ([]domain.EntryMetadataUpdate, error) {
 	return entries, nil
 }
 
+// CEFRLookup builds a normalized-word → CEFR map from parsed metadata updates.
+// Updates are expected in Parse order (NGSL first, then NAWL), so a word present
+// in both lists keeps its NGSL frequency-band level. Updates without a CEFR
+// level are ignored.
+func CEFRLookup(updates []domain.EntryMetadataUpdate) map[string]string {
+	lookup := make(map[string]string, len(updates))
+	for i := range updates {
+		u := &updates[i]
+		if u.CEFRLevel == nil {
+			continue
+		}
+		if _, ok := lookup[u.TextNormalized]; ok {
+			continue
+		}
+		lookup[u.TextNormalized] = *u.CEFRLevel
+	}
+	return lookup
+}
+
 // cefrForRank maps a 1-based NGSL frequency rank to a CEFR level.
 //
 //	1-500   → A1
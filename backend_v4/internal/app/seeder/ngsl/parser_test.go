@@ -314,3 +314,52 @@ func TestNormalizationApplied(t *testing.T) {
 		t.Errorf("TextNormalized = %q, want %q", entries[0].TextNormalized, want)
 	}
 }
+
+// --- CEFR lookup ---
+
+func TestCEFRLookup_TopWordIsA1(t *testing.T) {
+	updates, _, err := Parse(testdataPath(t, "ngsl_sample.csv"), testdataPath(t, "nawl_sample.csv"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lookup := CEFRLookup(updates)
+
+	if got := lookup["the"]; got != "A1" {
+		t.Errorf("lookup[the] = %q, want A1", got)
+	}
+}
+
+func TestCEFRLookup_AcademicWordIsHigher(t *testing.T) {
+	updates, _, err := Parse(testdataPath(t, "ngsl_sample.csv"), testdataPath(t, "nawl_sample.csv"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lookup := CEFRLookup(updates)
+
+	if got := lookup["abstract"]; got != "C1" {
+		t.Errorf("lookup[abstract] = %q, want C1", got)
+	}
+	if _, ok := lookup["unknownword"]; ok {
+		t.Error("unknown word should not be in lookup")
+	}
+}
+
+func TestCEFRLookup_NGSLWinsOverNAWL(t *testing.T) {
+	a1, c1 := "A1", "C1"
+	updates := []domain.EntryMetadataUpdate{
+		{TextNormalized: "process", CEFRLevel: &a1},
+		{TextNormalized: "process", CEFRLevel: &c1},
+		{TextNormalized: "nolevel"},
+	}
+
+	lookup := CEFRLookup(updates)
+
+	if got := lookup["process"]; got != "A1" {
+		t.Errorf("lookup[process] = %q, want A1", got)
+	}
+	if _, ok := lookup["nolevel"]; ok {
+		t.Error("update without CEFR should be ignored")
+	}
+}
@@ -2,9 +2,12 @@ package seeder
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,13 +15,14 @@ import (
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/cmu"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/ngsl"
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/progress"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/tatoeba"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
 	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wordnet"
 )
 
 // allPhases defines the canonical execution order.
-var allPhases = []string{"wiktionary", "ngsl", "cmu", "wordnet", "tatoeba"}
+var allPhases = []string{"wiktionary", "ngsl", "cmu", "wordnet", "senses", "tatoeba"}
 
 // knownDataSources returns the 8 predefined data sources.
 func knownDataSources() []domain.RefDataSource {
@@ -45,16 +49,27 @@ type PhaseResult struct {
 	Err      error
 }
 
-// Pipeline orchestrates the 5-phase seeding process.
+// Pipeline orchestrates the 6-phase seeding process.
 type Pipeline struct {
-	log     *slog.Logger
-	repo    RefEntryBulkRepo
-	cfg     Config
-	results map[string]PhaseResult
+	log        *slog.Logger
+	repo       RefEntryBulkRepo
+	tx         TxManager
+	cfg        Config
+	results    map[string]PhaseResult
+	report     *DryRunReport
+	checkpoint *checkpoint
+	audio      AudioChecker
+
+	unmappedPOS map[string]int
 }
 
-// NewPipeline creates a new Pipeline.
+// NewPipeline creates a new Pipeline. A non-positive batch size is replaced by
+// the default.
 func NewPipeline(log *slog.Logger, repo RefEntryBulkRepo, cfg Config) *Pipeline {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+
 	return &Pipeline{
 		log:     log,
 		repo:    repo,
@@ -63,11 +78,38 @@ func NewPipeline(log *slog.Logger, repo RefEntryBulkRepo, cfg Config) *Pipeline
 	}
 }
 
+// SetTxManager makes the Wiktionary phase commit each entry batch together
+// with its senses, translations, examples and pronunciations. Without it a
+// crash mid-batch can leave a batch half-written, which a resumed run cannot
+// repair.
+func (p *Pipeline) SetTxManager(tx TxManager) {
+	p.tx = tx
+}
+
+// SetAudioChecker makes the Wiktionary phase verify resolved Commons audio
+// URLs before inserting them, dropping those that do not exist. Without it
+// URLs are stored unchecked.
+func (p *Pipeline) SetAudioChecker(c AudioChecker) {
+	p.audio = c
+}
+
 // Results returns phase results after Run completes.
 func (p *Pipeline) Results() map[string]PhaseResult {
 	return p.results
 }
 
+// Report returns the dry-run report, or nil if the Wiktionary phase did not
+// run in dry-run mode.
+func (p *Pipeline) Report() *DryRunReport {
+	return p.report
+}
+
+// UnmappedPOS returns, per lowercase source tag, how many Wiktionary POS
+// groups had a tag no POS mapping knows. Empty if none were seen.
+func (p *Pipeline) UnmappedPOS() map[string]int {
+	return p.unmappedPOS
+}
+
 // HasErrors returns true if any phase recorded errors.
 func (p *Pipeline) HasErrors() bool {
 	for _, r := range p.results {
@@ -101,8 +143,26 @@ func (p *Pipeline) Run(ctx context.Context, phases []string) error {
 		toRun = filtered
 	}
 
+	if p.cfg.CheckpointPath != "" && !p.cfg.DryRun {
+		cp := newCheckpoint()
+		if p.cfg.Resume {
+			loaded, err := loadCheckpoint(p.cfg.CheckpointPath)
+			if err != nil {
+				return fmt.Errorf("load checkpoint: %w", err)
+			}
+			cp = loaded
+		}
+		p.checkpoint = cp
+	}
+
 	// Step 3: Execute phases in order.
 	for _, phase := range toRun {
+		if pc := p.resumePoint(phase); pc != nil && pc.Completed {
+			p.log.Info("phase already completed, skipping", slog.String("phase", phase))
+			p.results[phase] = PhaseResult{Skipped: 1}
+			continue
+		}
+
 		start := time.Now()
 		p.log.Info("starting phase", slog.String("phase", phase))
 
@@ -116,6 +176,8 @@ func (p *Pipeline) Run(ctx context.Context, phases []string) error {
 			result = p.runCMU(ctx)
 		case "wordnet":
 			result = p.runWordNet(ctx)
+		case "senses":
+			result = p.runSenseMerge(ctx)
 		case "tatoeba":
 			result = p.runTatoeba(ctx)
 		}
@@ -136,11 +198,26 @@ func (p *Pipeline) Run(ctx context.Context, phases []string) error {
 				slog.Int("skipped", result.Skipped),
 				slog.Duration("duration", result.Duration),
 			)
+			if err := p.saveProgress(phase, func(pc *phaseCheckpoint) { pc.Completed = true }); err != nil {
+				p.log.Warn("checkpoint not saved", slog.String("phase", phase), slog.String("error", err.Error()))
+			}
 		}
 	}
 
 	// Step 4: Summary log.
 	p.log.Info("pipeline completed", slog.Int("phases_run", len(toRun)))
+	if len(p.unmappedPOS) > 0 {
+		p.log.Warn("unmapped POS tags stored as OTHER; extend pos_mappings to keep them",
+			slog.String("tags", formatTagCounts(p.unmappedPOS)),
+		)
+	}
+
+	if p.report != nil && p.cfg.ReportPath != "" {
+		if err := writeReport(p.cfg.ReportPath, p.report); err != nil {
+			return fmt.Errorf("dry-run report: %w", err)
+		}
+		p.log.Info("dry-run report written", slog.String("path", p.cfg.ReportPath))
+	}
 	return nil
 }
 
@@ -149,82 +226,427 @@ func (p *Pipeline) runWiktionary(ctx context.Context) PhaseResult {
 	if p.cfg.WiktionaryPath == "" {
 		return PhaseResult{Skipped: 1, Err: fmt.Errorf("wiktionary path not configured")}
 	}
+	markup := wiktionary.MarkupMode(p.cfg.MarkupMode)
+	if markup != "" && !markup.IsValid() {
+		return PhaseResult{Err: fmt.Errorf("invalid markup mode %q: want strip, links or preserve", p.cfg.MarkupMode)}
+	}
+	posMap, err := wiktionary.NewPOSMap(p.cfg.POSMappings["wiktionary"])
+	if err != nil {
+		return PhaseResult{Err: err}
+	}
 
-	// Parse NGSL/NAWL first for core words (if available).
-	var coreWords map[string]bool
+	// Parse NGSL/NAWL first for core words and frequency-based CEFR (if available).
+	var (
+		coreWords  map[string]bool
+		cefrLookup map[string]string
+	)
 	if p.cfg.NGSLPath != "" && p.cfg.NAWLPath != "" {
-		_, cw, err := ngsl.Parse(p.cfg.NGSLPath, p.cfg.NAWLPath)
+		updates, cw, err := ngsl.Parse(p.cfg.NGSLPath, p.cfg.NAWLPath)
 		if err != nil {
 			p.log.Warn("could not parse NGSL/NAWL for core words", slog.String("error", err.Error()))
 		} else {
 			coreWords = cw
+			if p.cfg.CEFRFromFrequency {
+				cefrLookup = ngsl.CEFRLookup(updates)
+			}
 		}
 	}
 
-	entries, stats, err := wiktionary.Parse(p.cfg.WiktionaryPath, coreWords, p.cfg.TopN)
+	langs := wiktionary.LanguageFilter{
+		Headwords:    p.cfg.HeadwordLanguages,
+		Translations: p.cfg.Languages,
+	}
+	sel, err := wiktionary.Select(p.cfg.WiktionaryPath, coreWords, p.cfg.TopN, p.cfg.ParseWorkers, langs)
 	if err != nil {
 		return PhaseResult{Err: fmt.Errorf("parse wiktionary: %w", err)}
 	}
-	p.log.Info("wiktionary parsed", slog.Int("entries", len(entries)), slog.Int("total_lines", stats.TotalLines))
+	p.log.Info("wiktionary words selected", slog.Int("entries", sel.Len()))
+
+	// The scoring pass already saw every line, so strict mode stops here,
+	// before anything is written.
+	if unmapped := sel.UnmappedPOS(posMap); len(unmapped) > 0 {
+		p.unmappedPOS = unmapped
+		if p.cfg.StrictPOS {
+			return PhaseResult{Err: fmt.Errorf("unmapped POS tags (strict_pos): %s", formatTagCounts(unmapped))}
+		}
+	}
+
+	conv := &wiktionaryConverter{
+		p: p,
+		opts: wiktionary.CleanOptions{
+			MaxDefinitionLen:    p.cfg.MaxDefinitionLen,
+			MinDefinitionLength: p.cfg.MinDefinitionLen,
+			Markup:              markup,
+			NormalizeIPA:        p.cfg.NormalizeIPA,
+			BroadIPA:            p.cfg.BroadIPA,
+			POS:                 posMap,
+			DeterministicIDs:    p.cfg.DeterministicIDs,
+		},
+		cefrLookup: cefrLookup,
+	}
 
 	if p.cfg.DryRun {
-		return PhaseResult{Skipped: len(entries)}
+		return p.dryRunWiktionary(ctx, sel, conv)
+	}
+
+	start, lastWord := 0, ""
+	if pc := p.resumePoint("wiktionary"); pc != nil && pc.EntriesDone > 0 {
+		if pc.EntriesDone <= sel.Len() {
+			start, lastWord = pc.EntriesDone, pc.LastWord
+		} else {
+			p.log.Warn("wiktionary checkpoint does not match parsed entries, starting over",
+				slog.String("last_word", pc.LastWord),
+			)
+		}
 	}
 
-	domainData := wiktionary.ToDomainEntries(entries)
+	result, stats := p.insertWiktionary(ctx, sel, start, lastWord, conv)
+	if errors.Is(result.Err, errCheckpointMismatch) {
+		p.log.Warn("wiktionary checkpoint does not match parsed entries, starting over",
+			slog.String("last_word", lastWord),
+		)
+		result, stats = p.insertWiktionary(ctx, sel, 0, "", conv)
+	}
+	p.logWiktionaryParsed(stats)
+	conv.logTotals()
+	return result
+}
 
-	var result PhaseResult
+// errCheckpointMismatch reports that the entry at the checkpointed position
+// is not the checkpointed word, so the input selection changed.
+var errCheckpointMismatch = errors.New("wiktionary checkpoint does not match parsed entries")
+
+// dryRunWiktionary streams the selected entries batch by batch and sums up
+// what inserting them would change in the catalog.
+func (p *Pipeline) dryRunWiktionary(ctx context.Context, sel *wiktionary.Selection, conv *wiktionaryConverter) PhaseResult {
+	report := &DryRunReport{}
+	stats, err := p.streamWiktionary(ctx, sel, 0, "", conv, func(chunk wiktionary.DomainResult, _ int) error {
+		r, err := buildDryRunReport(ctx, p.repo, chunk, p.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("build dry-run report: %w", err)
+		}
+		report.add(r)
+		return nil
+	})
+	p.logWiktionaryParsed(stats)
+	conv.logTotals()
+	if err != nil {
+		return PhaseResult{Err: err}
+	}
+
+	report.SkippedByLanguage = stats.SkippedByLanguage
+	report.TranslationsSkippedByLanguage = stats.TranslationsSkippedByLanguage
+	report.SkippedShortDefinitions = conv.skippedShort
+	p.report = report
+	p.log.Info("dry-run report",
+		slog.Int("new_entries", report.NewEntries),
+		slog.Int("new_entry_senses", report.NewEntrySenses),
+		slog.Int("merge_entries", report.MergeEntries),
+		slog.Int("merge_senses", report.MergeSenses),
+		slog.Int("duplicate_entries", report.DuplicateEntries),
+		slog.Int("skipped_by_language", report.SkippedByLanguage),
+		slog.Int("translations_skipped_by_language", report.TranslationsSkippedByLanguage),
+		slog.Int("skipped_short_definitions", report.SkippedShortDefinitions),
+	)
+	return PhaseResult{Skipped: stats.EntriesParsed}
+}
+
+func (p *Pipeline) logWiktionaryParsed(stats wiktionary.Stats) {
+	p.log.Info("wiktionary parsed",
+		slog.Int("entries", stats.EntriesParsed),
+		slog.Int("total_lines", stats.TotalLines),
+		slog.Int("skipped_by_language", stats.SkippedByLanguage),
+		slog.Int("translations_skipped_by_language", stats.TranslationsSkippedByLanguage),
+		slog.Int("workers", max(p.cfg.ParseWorkers, 1)),
+	)
+}
+
+// wiktionaryConverter turns streamed entries into domain rows one entry at a
+// time, applying the post-processing steps to each finished batch and keeping
+// running totals for the phase log.
+type wiktionaryConverter struct {
+	p          *Pipeline
+	opts       wiktionary.CleanOptions
+	cefrLookup map[string]string
+
+	batch   wiktionary.DomainResult
+	pending int // entries in batch
+
+	skippedShort   int
+	cefrTagged     int
+	audioDropped   int
+	audioCheckFail int
+}
+
+// add converts e and appends its rows to the batch being built.
+func (c *wiktionaryConverter) add(e wiktionary.ParsedEntry) {
+	c.batch.Append(wiktionary.ToDomainEntry(e, c.opts))
+	c.pending++
+}
+
+// take post-processes the batch being built and returns it, starting an
+// empty one. Every step works per entry, so converting in batches yields the
+// same rows as converting all entries at once.
+func (c *wiktionaryConverter) take(ctx context.Context) wiktionary.DomainResult {
+	data := c.batch
+	c.batch, c.pending = wiktionary.DomainResult{}, 0
+
+	c.skippedShort += data.SkippedShortDefinitions
+	if c.cefrLookup != nil {
+		c.cefrTagged += applyFrequencyCEFR(&data, c.cefrLookup)
+	}
+	if c.p.audio != nil {
+		dropped, failed := validateAudioURLs(ctx, c.p.audio, data.Pronunciations)
+		c.audioDropped += dropped
+		c.audioCheckFail += failed
+	}
+	return data
+}
+
+func (c *wiktionaryConverter) logTotals() {
+	log := c.p.log
+	if c.skippedShort > 0 {
+		log.Info("senses with short definitions skipped",
+			slog.Int("skipped", c.skippedShort),
+			slog.Int("min_definition_len", c.p.cfg.MinDefinitionLen),
+		)
+	}
+	if c.cefrLookup != nil {
+		log.Info("cefr tagged from frequency lists", slog.Int("senses", c.cefrTagged))
+	}
+	if c.p.audio != nil {
+		log.Info("audio urls validated",
+			slog.Int("dropped_missing", c.audioDropped),
+			slog.Int("check_failed", c.audioCheckFail),
+		)
+	}
+}
+
+// streamWiktionary streams the selected entries, converting each one as it
+// arrives, and calls flush with every batch_size converted entries and once
+// more with the remainder; done is the number of entries streamed so far.
+// The first skip entries are read but not converted. The last of them must
+// be lastWord, otherwise errCheckpointMismatch is returned before anything
+// is flushed.
+func (p *Pipeline) streamWiktionary(ctx context.Context, sel *wiktionary.Selection, skip int, lastWord string, conv *wiktionaryConverter, flush func(chunk wiktionary.DomainResult, done int) error) (wiktionary.Stats, error) {
+	done := 0
+	stats, err := sel.Stream(func(e wiktionary.ParsedEntry) error {
+		done++
+		if done <= skip {
+			if done < skip {
+				return nil
+			}
+			// Stream order is deterministic, so the checkpointed word must
+			// sit at the same position.
+			if domain.NormalizeText(e.Word) != lastWord {
+				return errCheckpointMismatch
+			}
+			p.log.Info("resuming wiktionary from checkpoint",
+				slog.Int("entries_done", skip),
+				slog.String("last_word", lastWord),
+			)
+			return nil
+		}
+
+		conv.add(e)
+		if conv.pending < p.cfg.BatchSize {
+			return nil
+		}
+		return flush(conv.take(ctx), done)
+	})
+	if err == nil && conv.pending > 0 {
+		err = flush(conv.take(ctx), done)
+	}
+	return stats, err
+}
+
+// insertWiktionary streams the selected entries and inserts them one batch at
+// a time, skipping the first start entries that a checkpoint records as
+// committed. Only the entries of words whose lines are still ahead in the
+// dump and the batch being built are held in memory, so memory grows with
+// batch_size, not with the dump.
+// Each batch is written together with its children in parent→child order
+// (entries → senses → translations → examples → pronunciations) and
+// checkpointed once committed.
+func (p *Pipeline) insertWiktionary(ctx context.Context, sel *wiktionary.Selection, start int, lastWord string, conv *wiktionaryConverter) (PhaseResult, wiktionary.Stats) {
+	batchSize := p.cfg.BatchSize
+	result := PhaseResult{Skipped: start}
+	prog := progress.New(p.log, "wiktionary", sel.Len()-start, p.cfg.ProgressEvery, progress.DefaultInterval)
+
+	stats, err := p.streamWiktionary(ctx, sel, start, lastWord, conv, func(chunk wiktionary.DomainResult, done int) error {
+		var inserted int
+		err := p.runInTx(ctx, func(ctx context.Context) error {
+			n, err := p.insertWiktionaryChunk(ctx, chunk)
+			inserted = n
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		result.Inserted += inserted
+
+		// Record coverage for wiktionary.
+		coverage := buildCoverage(chunk.Entries, "wiktionary", "fetched")
+		if _, err := batchProcess(coverage, batchSize, func(batch []domain.RefEntrySourceCoverage) (int, error) {
+			return p.repo.BulkInsertCoverage(ctx, batch)
+		}); err != nil {
+			p.log.Warn("wiktionary coverage insert failed", slog.String("error", err.Error()))
+		}
+
+		lastWord := chunk.Entries[len(chunk.Entries)-1].TextNormalized
+		if err := p.saveProgress("wiktionary", func(pc *phaseCheckpoint) {
+			pc.EntriesDone = done
+			pc.LastWord = lastWord
+		}); err != nil {
+			return fmt.Errorf("save checkpoint: %w", err)
+		}
+		prog.Add(len(chunk.Entries))
+		return nil
+	})
+	result.Err = err
+	return result, stats
+}
 
-	// Insert in parent→child order: entries → senses → translations → examples → pronunciations.
-	inserted, err := batchProcess(domainData.Entries, p.cfg.BatchSize, func(batch []domain.RefEntry) (int, error) {
+// insertWiktionaryChunk writes one entry batch and its children. With
+// use_copy the entries, senses, translations and examples go through a single
+// COPY-based call; pronunciations always use the batch insert.
+func (p *Pipeline) insertWiktionaryChunk(ctx context.Context, chunk wiktionary.DomainResult) (int, error) {
+	total := 0
+
+	if p.cfg.UseCopy {
+		inserted, err := p.repo.CopyFrom(ctx, chunk.Entries, chunk.Senses, chunk.Translations, chunk.Examples)
+		if err != nil {
+			return total, fmt.Errorf("copy entries: %w", err)
+		}
+		total += inserted
+	} else {
+		inserted, err := p.insertWiktionaryRows(ctx, chunk)
+		if err != nil {
+			return total, err
+		}
+		total += inserted
+	}
+
+	inserted, err := batchProcess(chunk.Pronunciations, p.cfg.BatchSize, func(batch []domain.RefPronunciation) (int, error) {
+		return p.repo.BulkInsertPronunciations(ctx, batch)
+	})
+	if err != nil {
+		return total, fmt.Errorf("insert pronunciations: %w", err)
+	}
+	total += inserted
+
+	return total, nil
+}
+
+// insertWiktionaryRows writes entries, senses, translations and examples of
+// one batch with the pgx.Batch inserts.
+func (p *Pipeline) insertWiktionaryRows(ctx context.Context, chunk wiktionary.DomainResult) (int, error) {
+	total := 0
+
+	inserted, err := batchProcess(chunk.Entries, p.cfg.BatchSize, func(batch []domain.RefEntry) (int, error) {
 		return p.repo.BulkInsertEntries(ctx, batch)
 	})
 	if err != nil {
-		return PhaseResult{Err: fmt.Errorf("insert entries: %w", err)}
+		return total, fmt.Errorf("insert entries: %w", err)
 	}
-	result.Inserted += inserted
+	total += inserted
 
-	inserted, err = batchProcess(domainData.Senses, p.cfg.BatchSize, func(batch []domain.RefSense) (int, error) {
+	inserted, err = batchProcess(chunk.Senses, p.cfg.BatchSize, func(batch []domain.RefSense) (int, error) {
 		return p.repo.BulkInsertSenses(ctx, batch)
 	})
 	if err != nil {
-		return PhaseResult{Err: fmt.Errorf("insert senses: %w", err)}
+		return total, fmt.Errorf("insert senses: %w", err)
 	}
-	result.Inserted += inserted
+	total += inserted
 
-	inserted, err = batchProcess(domainData.Translations, p.cfg.BatchSize, func(batch []domain.RefTranslation) (int, error) {
+	inserted, err = batchProcess(chunk.Translations, p.cfg.BatchSize, func(batch []domain.RefTranslation) (int, error) {
 		return p.repo.BulkInsertTranslations(ctx, batch)
 	})
 	if err != nil {
-		return PhaseResult{Err: fmt.Errorf("insert translations: %w", err)}
+		return total, fmt.Errorf("insert translations: %w", err)
 	}
-	result.Inserted += inserted
+	total += inserted
 
-	inserted, err = batchProcess(domainData.Examples, p.cfg.BatchSize, func(batch []domain.RefExample) (int, error) {
+	inserted, err = batchProcess(chunk.Examples, p.cfg.BatchSize, func(batch []domain.RefExample) (int, error) {
 		return p.repo.BulkInsertExamples(ctx, batch)
 	})
 	if err != nil {
-		return PhaseResult{Err: fmt.Errorf("insert examples: %w", err)}
+		return total, fmt.Errorf("insert examples: %w", err)
 	}
-	result.Inserted += inserted
+	total += inserted
 
-	inserted, err = batchProcess(domainData.Pronunciations, p.cfg.BatchSize, func(batch []domain.RefPronunciation) (int, error) {
-		return p.repo.BulkInsertPronunciations(ctx, batch)
-	})
-	if err != nil {
-		return PhaseResult{Err: fmt.Errorf("insert pronunciations: %w", err)}
+	return total, nil
+}
+
+// runInTx runs fn in a transaction when a TxManager is set, directly otherwise.
+func (p *Pipeline) runInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p.tx == nil {
+		return fn(ctx)
 	}
-	result.Inserted += inserted
+	return p.tx.RunInTx(ctx, fn)
+}
 
-	// Record coverage for wiktionary.
-	coverage := buildCoverage(domainData.Entries, "wiktionary", "fetched")
-	if _, err := batchProcess(coverage, p.cfg.BatchSize, func(batch []domain.RefEntrySourceCoverage) (int, error) {
-		return p.repo.BulkInsertCoverage(ctx, batch)
-	}); err != nil {
-		p.log.Warn("wiktionary coverage insert failed", slog.String("error", err.Error()))
+// phaseDataset returns the input paths that a phase's checkpoint is tied to.
+func (p *Pipeline) phaseDataset(phase string) []string {
+	switch phase {
+	case "wiktionary":
+		return []string{p.cfg.WiktionaryPath}
+	case "ngsl":
+		return []string{p.cfg.NGSLPath, p.cfg.NAWLPath}
+	case "cmu":
+		return []string{p.cfg.CMUPath}
+	case "wordnet":
+		return []string{p.cfg.WordNetPath}
+	case "tatoeba":
+		return []string{p.cfg.TatoebaPath}
 	}
+	return nil
+}
 
-	return result
+// resumePoint returns the saved progress of a phase, or nil when there is
+// none. A checkpoint written for a different version of the dataset is
+// dropped with a warning, so the phase runs from scratch.
+func (p *Pipeline) resumePoint(phase string) *phaseCheckpoint {
+	if p.checkpoint == nil {
+		return nil
+	}
+	pc, ok := p.checkpoint.Phases[phase]
+	if !ok {
+		return nil
+	}
+
+	size, modTime, err := datasetStamp(p.phaseDataset(phase)...)
+	if err != nil || !pc.matches(size, modTime) {
+		p.log.Warn("checkpoint does not match dataset, ignoring",
+			slog.String("phase", phase),
+			slog.Time("checkpoint_dataset_mod_time", pc.DatasetModTime),
+		)
+		delete(p.checkpoint.Phases, phase)
+		return nil
+	}
+	return pc
+}
+
+// saveProgress applies update to a phase's checkpoint and writes the file.
+// It is a no-op when checkpointing is disabled.
+func (p *Pipeline) saveProgress(phase string, update func(pc *phaseCheckpoint)) error {
+	if p.checkpoint == nil {
+		return nil
+	}
+
+	pc, ok := p.checkpoint.Phases[phase]
+	if !ok {
+		size, modTime, err := datasetStamp(p.phaseDataset(phase)...)
+		if err != nil {
+			return fmt.Errorf("stat dataset: %w", err)
+		}
+		pc = &phaseCheckpoint{DatasetSize: size, DatasetModTime: modTime}
+		p.checkpoint.Phases[phase] = pc
+	}
+	update(pc)
+	pc.UpdatedAt = time.Now().UTC()
+
+	return p.checkpoint.save(p.cfg.CheckpointPath)
 }
 
 // runNGSL parses NGSL/NAWL and updates entry metadata.
@@ -389,6 +811,56 @@ func (p *Pipeline) runWordNet(ctx context.Context) PhaseResult {
 	return PhaseResult{Inserted: inserted}
 }
 
+// runSenseMerge merges near-duplicate senses that different sources gave the
+// same entry, walking the entries with senses from more than one source in
+// batches. In dry-run mode the senses that would be merged count as skipped.
+func (p *Pipeline) runSenseMerge(ctx context.Context) PhaseResult {
+	if p.cfg.SenseSimilarity <= 0 {
+		p.log.Info("cross-source sense merge disabled")
+		return PhaseResult{Skipped: 1}
+	}
+
+	var result PhaseResult
+	entries := 0
+	after := uuid.Nil
+	for {
+		ids, err := p.repo.GetMultiSourceEntryIDsAfter(ctx, after, p.cfg.BatchSize)
+		if err != nil {
+			return PhaseResult{Err: fmt.Errorf("get multi-source entries: %w", err)}
+		}
+		if len(ids) == 0 {
+			break
+		}
+		after = ids[len(ids)-1]
+		entries += len(ids)
+
+		senses, err := p.repo.GetSensesByEntryIDs(ctx, ids)
+		if err != nil {
+			return PhaseResult{Err: fmt.Errorf("get senses: %w", err)}
+		}
+		kept, merged := mergeCrossSourceSenses(senses, p.cfg.SenseSimilarity)
+		if len(merged) == 0 {
+			continue
+		}
+		if p.cfg.DryRun {
+			result.Skipped += len(merged)
+			continue
+		}
+
+		n, err := p.repo.MergeSenses(ctx, kept, merged)
+		if err != nil {
+			return PhaseResult{Err: fmt.Errorf("merge senses: %w", err)}
+		}
+		result.Updated += n
+	}
+
+	p.log.Info("cross-source senses merged",
+		slog.Int("entries", entries),
+		slog.Int("merged", result.Updated+result.Skipped),
+	)
+	return result
+}
+
 // runTatoeba parses Tatoeba and inserts examples for known entries.
 func (p *Pipeline) runTatoeba(ctx context.Context) PhaseResult {
 	if p.cfg.TatoebaPath == "" {
@@ -451,7 +923,7 @@ func batchProcess[T any](items []T, batchSize int, fn func([]T) (int, error)) (i
 		return 0, nil
 	}
 	if batchSize <= 0 {
-		batchSize = 500
+		batchSize = defaultBatchSize
 	}
 
 	total := 0
@@ -472,7 +944,7 @@ func batchedLookup(ctx context.Context, repo RefEntryBulkRepo, texts []string, b
 		return make(map[string]uuid.UUID), nil
 	}
 	if batchSize <= 0 {
-		batchSize = 500
+		batchSize = defaultBatchSize
 	}
 
 	result := make(map[string]uuid.UUID, len(texts))
@@ -487,6 +959,33 @@ func batchedLookup(ctx context.Context, repo RefEntryBulkRepo, texts []string, b
 	return result, nil
 }
 
+// applyFrequencyCEFR sets CEFRLevel on every sense whose entry appears in the
+// frequency lookup. Senses that already carry a level and entries missing from
+// the lookup are left untouched. Returns the number of senses tagged.
+func applyFrequencyCEFR(data *wiktionary.DomainResult, lookup map[string]string) int {
+	levelByEntry := make(map[uuid.UUID]string, len(data.Entries))
+	for _, e := range data.Entries {
+		if level, ok := lookup[e.TextNormalized]; ok {
+			levelByEntry[e.ID] = level
+		}
+	}
+
+	tagged := 0
+	for i := range data.Senses {
+		s := &data.Senses[i]
+		if s.CEFRLevel != nil {
+			continue
+		}
+		level, ok := levelByEntry[s.RefEntryID]
+		if !ok {
+			continue
+		}
+		s.CEFRLevel = &level
+		tagged++
+	}
+	return tagged
+}
+
 // buildCoverage creates coverage records for inserted entries.
 func buildCoverage(entries []domain.RefEntry, sourceSlug, status string) []domain.RefEntrySourceCoverage {
 	now := time.Now()
@@ -502,3 +1001,12 @@ func buildCoverage(entries []domain.RefEntry, sourceSlug, status string) []domai
 	}
 	return coverage
 }
+
+// formatTagCounts renders tag counts as "tag=count" pairs sorted by tag.
+func formatTagCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(counts))
+	for _, tag := range slices.Sorted(maps.Keys(counts)) {
+		parts = append(parts, fmt.Sprintf("%s=%d", tag, counts[tag]))
+	}
+	return strings.Join(parts, ", ")
+}
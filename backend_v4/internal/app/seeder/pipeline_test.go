@@ -2,15 +2,20 @@ package seeder
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/google/uuid"
 
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
@@ -26,8 +31,12 @@ type mockRepo struct {
 	relationsInserted      int
 	coverageInserted       int
 	metadataUpdated        int
+	sensesMerged           int
 	dataSourcesUpserted    bool
 
+	senses           []domain.RefSense
+	senseDefinitions map[uuid.UUID]map[string]bool
+
 	bulkInsertEntriesErr        error
 	bulkInsertSensesErr         error
 	bulkInsertTranslationsErr   error
@@ -80,6 +89,7 @@ func (m *mockRepo) BulkInsertSenses(_ context.Context, senses []domain.RefSense)
 	}
 	m.mu.Lock()
 	m.sensesInserted += len(senses)
+	m.senses = append(m.senses, senses...)
 	m.mu.Unlock()
 	return len(senses), nil
 }
@@ -139,6 +149,18 @@ func (m *mockRepo) BulkInsertCoverage(_ context.Context, coverage []domain.RefEn
 	return len(coverage), nil
 }
 
+func (m *mockRepo) CopyFrom(_ context.Context, entries []domain.RefEntry, senses []domain.RefSense, translations []domain.RefTranslation, examples []domain.RefExample) (int, error) {
+	m.logCall("CopyFrom")
+	m.mu.Lock()
+	m.entriesInserted += len(entries)
+	m.sensesInserted += len(senses)
+	m.translationsInserted += len(translations)
+	m.examplesInserted += len(examples)
+	m.senses = append(m.senses, senses...)
+	m.mu.Unlock()
+	return len(entries) + len(senses) + len(translations) + len(examples), nil
+}
+
 func (m *mockRepo) BulkUpdateEntryMetadata(_ context.Context, updates []domain.EntryMetadataUpdate) (int, error) {
 	m.logCall("BulkUpdateEntryMetadata")
 	if m.bulkUpdateMetadataErr != nil {
@@ -191,6 +213,79 @@ func (m *mockRepo) GetPronunciationIPAsByEntryIDs(_ context.Context, _ []uuid.UU
 	return map[uuid.UUID]map[string]bool{}, nil
 }
 
+func (m *mockRepo) GetSenseDefinitionsByEntryIDs(_ context.Context, entryIDs []uuid.UUID) (map[uuid.UUID]map[string]bool, error) {
+	m.logCall("GetSenseDefinitionsByEntryIDs")
+	result := make(map[uuid.UUID]map[string]bool)
+	for _, id := range entryIDs {
+		if defs, ok := m.senseDefinitions[id]; ok {
+			result[id] = defs
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepo) GetMultiSourceEntryIDsAfter(_ context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	m.logCall("GetMultiSourceEntryIDsAfter")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	sources := make(map[uuid.UUID]map[string]bool)
+	for _, s := range m.senses {
+		if sources[s.RefEntryID] == nil {
+			sources[s.RefEntryID] = make(map[string]bool)
+		}
+		sources[s.RefEntryID][s.SourceSlug] = true
+	}
+	var ids []uuid.UUID
+	for id, slugs := range sources {
+		if len(slugs) > 1 && strings.Compare(id.String(), after.String()) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int { return strings.Compare(a.String(), b.String()) })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+func (m *mockRepo) GetSensesByEntryIDs(_ context.Context, entryIDs []uuid.UUID) ([]domain.RefSense, error) {
+	m.logCall("GetSensesByEntryIDs")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []domain.RefSense
+	for _, id := range entryIDs {
+		for _, s := range m.senses {
+			if s.RefEntryID == id {
+				result = append(result, s)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepo) MergeSenses(_ context.Context, kept []domain.RefSense, merged map[uuid.UUID]uuid.UUID) (int, error) {
+	m.logCall("MergeSenses")
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byID := make(map[uuid.UUID]domain.RefSense, len(kept))
+	for _, s := range kept {
+		byID[s.ID] = s
+	}
+	senses := m.senses[:0]
+	for _, s := range m.senses {
+		if _, ok := merged[s.ID]; ok {
+			continue
+		}
+		if k, ok := byID[s.ID]; ok {
+			s = k
+		}
+		senses = append(senses, s)
+	}
+	m.senses = senses
+	m.sensesMerged += len(merged)
+	return len(merged), nil
+}
+
 func (m *mockRepo) UpsertDataSources(_ context.Context, _ []domain.RefDataSource) error {
 	m.logCall("UpsertDataSources")
 	if m.upsertDataSourcesErr != nil {
@@ -332,6 +427,48 @@ func TestPipeline_PhaseFilter(t *testing.T) {
 	}
 }
 
+func TestPipeline_SenseMergeAcrossSources(t *testing.T) {
+	dog := uuid.New()
+	repo := newMockRepo()
+	repo.senses = []domain.RefSense{
+		testSense(dog, "wiktionary", "A domesticated carnivorous mammal, Canis familiaris.", domain.PartOfSpeechNoun, 0),
+		testSense(dog, "llm", "domesticated carnivorous mammal (Canis familiaris)", domain.PartOfSpeechNoun, 1),
+	}
+
+	p := NewPipeline(testLogger(), repo, Config{BatchSize: 100, SenseSimilarity: 0.8})
+	if err := p.Run(context.Background(), []string{"senses"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := p.Results()["senses"]; got.Err != nil || got.Updated != 1 {
+		t.Errorf("senses result = %+v, want 1 updated", got)
+	}
+	if len(repo.senses) != 1 || !slices.Equal(repo.senses[0].Sources, []string{"llm"}) {
+		t.Errorf("senses = %+v, want one wiktionary sense with Sources [llm]", repo.senses)
+	}
+}
+
+func TestPipeline_SenseMergeDryRun(t *testing.T) {
+	dog := uuid.New()
+	repo := newMockRepo()
+	repo.senses = []domain.RefSense{
+		testSense(dog, "wiktionary", "A dog.", domain.PartOfSpeechNoun, 0),
+		testSense(dog, "llm", "A dog.", domain.PartOfSpeechNoun, 1),
+	}
+
+	p := NewPipeline(testLogger(), repo, Config{BatchSize: 100, SenseSimilarity: 0.8, DryRun: true})
+	if err := p.Run(context.Background(), []string{"senses"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := p.Results()["senses"]; got.Skipped != 1 || got.Updated != 0 {
+		t.Errorf("senses result = %+v, want 1 skipped", got)
+	}
+	if repo.sensesMerged != 0 || len(repo.senses) != 2 {
+		t.Errorf("dry run merged %d senses, want none", repo.sensesMerged)
+	}
+}
+
 func TestPipeline_PhaseOrderingDataSourcesFirst(t *testing.T) {
 	repo := newMockRepo()
 	cfg := Config{
@@ -476,3 +613,568 @@ func createTempFile(t *testing.T, prefix, content string) string {
 	f.Close()
 	return f.Name()
 }
+
+func TestApplyFrequencyCEFR(t *testing.T) {
+	theID, analyzeID, unknownID := uuid.New(), uuid.New(), uuid.New()
+	preset := "B2"
+
+	data := wiktionary.DomainResult{
+		Entries: []domain.RefEntry{
+			{ID: theID, TextNormalized: "the"},
+			{ID: analyzeID, TextNormalized: "analyze"},
+			{ID: unknownID, TextNormalized: "zyzzyva"},
+		},
+		Senses: []domain.RefSense{
+			{RefEntryID: theID, Definition: "definite article"},
+			{RefEntryID: theID, Definition: "used before superlatives"},
+			{RefEntryID: analyzeID, Definition: "examine in detail"},
+			{RefEntryID: analyzeID, Definition: "psychoanalyze", CEFRLevel: &preset},
+			{RefEntryID: unknownID, Definition: "a tropical weevil"},
+		},
+	}
+	lookup := map[string]string{"the": "A1", "analyze": "C1"}
+
+	tagged := applyFrequencyCEFR(&data, lookup)
+
+	if tagged != 3 {
+		t.Errorf("tagged = %d, want 3", tagged)
+	}
+	a1, c1 := "A1", "C1"
+	want := []*string{&a1, &a1, &c1, &preset, nil}
+	for i, s := range data.Senses {
+		switch {
+		case want[i] == nil && s.CEFRLevel != nil:
+			t.Errorf("sense %d: CEFR = %q, want nil", i, *s.CEFRLevel)
+		case want[i] != nil && (s.CEFRLevel == nil || *s.CEFRLevel != *want[i]):
+			t.Errorf("sense %d: CEFR = %v, want %q", i, s.CEFRLevel, *want[i])
+		}
+	}
+}
+
+func TestPipeline_CEFRFromFrequency(t *testing.T) {
+	wiktData := `{"word":"hello","pos":"interjection","lang":"English","senses":[{"glosses":["greeting"]}]}` + "\n"
+	tmpWikt := createTempFile(t, "wiktionary", wiktData)
+	tmpNGSL := createTempFile(t, "ngsl", "word\nhello\n")
+	tmpNAWL := createTempFile(t, "nawl", "word\nworld\n")
+
+	repo := newMockRepo()
+	cfg := Config{
+		WiktionaryPath:    tmpWikt,
+		NGSLPath:          tmpNGSL,
+		NAWLPath:          tmpNAWL,
+		BatchSize:         100,
+		TopN:              100,
+		CEFRFromFrequency: true,
+	}
+
+	p := NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repo.senses) != 1 {
+		t.Fatalf("expected 1 sense inserted, got %d", len(repo.senses))
+	}
+	if got := repo.senses[0].CEFRLevel; got == nil || *got != "A1" {
+		t.Errorf("sense CEFR = %v, want A1", got)
+	}
+}
+
+func TestPipeline_DryRunReport(t *testing.T) {
+	// hello: already in catalog with the same sense → duplicate.
+	// world: already in catalog, one of two senses is new → merge.
+	// brand: not in catalog → new entry.
+	wiktData := `{"word":"hello","pos":"intj","lang":"English","senses":[{"glosses":["greeting"]}]}
+{"word":"world","pos":"noun","lang":"English","senses":[{"glosses":["the Earth"]},{"glosses":["a sphere of activity"]}]}
+{"word":"brand","pos":"noun","lang":"English","senses":[{"glosses":["a trademark"]},{"glosses":["a burning stick"]}]}
+`
+	tmpWikt := createTempFile(t, "wiktionary", wiktData)
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+
+	helloID, worldID := uuid.New(), uuid.New()
+	repo := newMockRepo()
+	repo.entryIDMap = map[string]uuid.UUID{"hello": helloID, "world": worldID}
+	repo.senseDefinitions = map[uuid.UUID]map[string]bool{
+		helloID: {"greeting": true},
+		worldID: {"the Earth": true},
+	}
+
+	cfg := Config{
+		WiktionaryPath: tmpWikt,
+		BatchSize:      100,
+		TopN:           100,
+		DryRun:         true,
+		ReportPath:     reportPath,
+	}
+
+	p := NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := DryRunReport{
+		NewEntries:       1,
+		NewEntrySenses:   2,
+		MergeEntries:     1,
+		MergeSenses:      1,
+		DuplicateEntries: 1,
+	}
+	if got := p.Report(); got == nil || *got != want {
+		t.Fatalf("report = %+v, want %+v", got, want)
+	}
+	if repo.entriesInserted != 0 || repo.sensesInserted != 0 {
+		t.Error("dry run must not insert data")
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("read report file: %v", err)
+	}
+	var fromFile DryRunReport
+	if err := json.Unmarshal(data, &fromFile); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if fromFile != want {
+		t.Errorf("report file = %+v, want %+v", fromFile, want)
+	}
+}
+
+func TestPipeline_DryRunReport_LanguageCounts(t *testing.T) {
+	wiktData := `{"word":"cat","pos":"noun","lang":"English","senses":[{"glosses":["a feline"],"translations":[{"code":"ru","word":"кошка"},{"code":"de","word":"Katze"}]}]}
+{"word":"chat","pos":"noun","lang":"French","lang_code":"fr","senses":[{"glosses":["cat"]}]}
+`
+	cfg := Config{
+		WiktionaryPath: createTempFile(t, "wiktionary", wiktData),
+		BatchSize:      100,
+		TopN:           100,
+		DryRun:         true,
+	}
+
+	p := NewPipeline(testLogger(), newMockRepo(), cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := p.Report()
+	if got == nil {
+		t.Fatal("expected a dry-run report")
+	}
+	if got.SkippedByLanguage != 1 || got.TranslationsSkippedByLanguage != 1 {
+		t.Errorf("report = %+v, want 1 entry and 1 translation skipped by language", got)
+	}
+}
+
+// flakyEntriesRepo fails BulkInsertEntries from the failOnCall-th call onwards
+// and records the words it did insert.
+type flakyEntriesRepo struct {
+	*mockRepo
+	calls      int
+	failOnCall int
+	words      []string
+}
+
+func (r *flakyEntriesRepo) BulkInsertEntries(ctx context.Context, entries []domain.RefEntry) (int, error) {
+	r.calls++
+	if r.failOnCall > 0 && r.calls >= r.failOnCall {
+		return 0, errors.New("connection lost")
+	}
+	for _, e := range entries {
+		r.words = append(r.words, e.TextNormalized)
+	}
+	return r.mockRepo.BulkInsertEntries(ctx, entries)
+}
+
+func TestPipeline_ResumeSkipsCommittedBatches(t *testing.T) {
+	var wiktData string
+	for _, w := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		wiktData += `{"word":"` + w + `","pos":"noun","lang":"English","senses":[{"glosses":["a ` + w + `"]}]}` + "\n"
+	}
+	tmpWikt := createTempFile(t, "wiktionary", wiktData)
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cfg := Config{
+		WiktionaryPath: tmpWikt,
+		BatchSize:      2,
+		TopN:           100,
+		CheckpointPath: checkpointPath,
+	}
+
+	// First run dies on the second entry batch.
+	first := &flakyEntriesRepo{mockRepo: newMockRepo(), failOnCall: 2}
+	p := NewPipeline(testLogger(), first, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if p.Results()["wiktionary"].Err == nil {
+		t.Fatal("expected first run to fail")
+	}
+	if len(first.words) != 2 {
+		t.Fatalf("first run committed %d entries, want 2", len(first.words))
+	}
+
+	// Second run resumes after the committed batch.
+	cfg.Resume = true
+	second := &flakyEntriesRepo{mockRepo: newMockRepo()}
+	p = NewPipeline(testLogger(), second, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if err := p.Results()["wiktionary"].Err; err != nil {
+		t.Fatalf("second run phase error: %v", err)
+	}
+
+	committed := make(map[string]bool)
+	for _, w := range first.words {
+		committed[w] = true
+	}
+	for _, w := range second.words {
+		if committed[w] {
+			t.Errorf("entry %q re-inserted after resume", w)
+		}
+	}
+	if got := len(first.words) + len(second.words); got != 5 {
+		t.Errorf("total entries inserted: got %d, want 5", got)
+	}
+	if second.sensesInserted != 3 {
+		t.Errorf("senses inserted on resume: got %d, want 3", second.sensesInserted)
+	}
+
+	// A third run sees the phase as completed and does nothing.
+	third := &flakyEntriesRepo{mockRepo: newMockRepo()}
+	p = NewPipeline(testLogger(), third, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	if len(third.words) != 0 {
+		t.Errorf("completed phase re-inserted %d entries", len(third.words))
+	}
+}
+
+func TestPipeline_ResumeRestartsWhenCheckpointedWordMoved(t *testing.T) {
+	var wiktData string
+	for _, w := range []string{"alpha", "bravo", "charlie", "delta"} {
+		wiktData += `{"word":"` + w + `","pos":"noun","lang":"English","senses":[{"glosses":["a ` + w + `"]}]}` + "\n"
+	}
+	tmpWikt := createTempFile(t, "wiktionary", wiktData)
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cfg := Config{
+		WiktionaryPath: tmpWikt,
+		BatchSize:      2,
+		TopN:           100,
+		CheckpointPath: checkpointPath,
+	}
+
+	first := &flakyEntriesRepo{mockRepo: newMockRepo(), failOnCall: 2}
+	p := NewPipeline(testLogger(), first, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	// The word at the checkpointed position is no longer the recorded one.
+	cp, err := loadCheckpoint(checkpointPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.Phases["wiktionary"].LastWord = "zulu"
+	if err := cp.save(checkpointPath); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Resume = true
+	repo := &flakyEntriesRepo{mockRepo: newMockRepo()}
+	p = NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if err := p.Results()["wiktionary"].Err; err != nil {
+		t.Fatalf("second run phase error: %v", err)
+	}
+	if want := []string{"alpha", "bravo", "charlie", "delta"}; !slices.Equal(repo.words, want) {
+		t.Errorf("entries inserted after mismatch = %v, want %v", repo.words, want)
+	}
+}
+
+func TestPipeline_ResumeIgnoresCheckpointForChangedDataset(t *testing.T) {
+	tmpWikt := createTempFile(t, "wiktionary",
+		`{"word":"alpha","pos":"noun","lang":"English","senses":[{"glosses":["a"]}]}`+"\n")
+	checkpointPath := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	cfg := Config{
+		WiktionaryPath: tmpWikt,
+		BatchSize:      100,
+		TopN:           100,
+		CheckpointPath: checkpointPath,
+	}
+
+	p := NewPipeline(testLogger(), newMockRepo(), cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	// Replace the dataset after the checkpoint was written.
+	if err := os.WriteFile(tmpWikt, []byte(
+		`{"word":"alpha","pos":"noun","lang":"English","senses":[{"glosses":["a"]}]}`+"\n"+
+			`{"word":"bravo","pos":"noun","lang":"English","senses":[{"glosses":["b"]}]}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Resume = true
+	repo := newMockRepo()
+	p = NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if repo.entriesInserted != 2 {
+		t.Errorf("entries inserted: got %d, want 2 (stale checkpoint should be ignored)", repo.entriesInserted)
+	}
+}
+
+func TestPipeline_ResumeReRunsNGSLWhenNAWLChanges(t *testing.T) {
+	tmpNGSL := createTempFile(t, "ngsl", "word\nhello\n")
+	tmpNAWL := createTempFile(t, "nawl", "word\nworld\n")
+
+	cfg := Config{
+		NGSLPath:       tmpNGSL,
+		NAWLPath:       tmpNAWL,
+		BatchSize:      100,
+		TopN:           100,
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+	}
+
+	p := NewPipeline(testLogger(), newMockRepo(), cfg)
+	if err := p.Run(context.Background(), []string{"ngsl"}); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	// Only the NAWL list changes; the NGSL file is untouched.
+	if err := os.WriteFile(tmpNAWL, []byte("word\nworld\nacademic\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Resume = true
+	repo := newMockRepo()
+	p = NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"ngsl"}); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if repo.metadataUpdated == 0 {
+		t.Error("ngsl phase skipped as completed although the NAWL list changed")
+	}
+}
+
+func TestPipeline_BatchedConversionMatchesSinglePass(t *testing.T) {
+	var wiktData string
+	for i, w := range []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf"} {
+		wiktData += fmt.Sprintf(`{"word":"%s","pos":"noun","lang":"English","senses":[{"glosses":["a %s thing"],"examples":[{"text":"One %s."}],"translations":[{"code":"ru","word":"слово%d"}]},{"glosses":["sense %d"]}],"sounds":[{"ipa":"/%s/"}]}`, w, w, w, i, i, w) + "\n"
+	}
+	path := createTempFile(t, "wiktionary", wiktData)
+
+	run := func(batchSize int) *mockRepo {
+		t.Helper()
+		repo := newMockRepo()
+		p := NewPipeline(testLogger(), repo, Config{WiktionaryPath: path, BatchSize: batchSize, TopN: 100})
+		if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+			t.Fatalf("batch=%d: %v", batchSize, err)
+		}
+		if err := p.Results()["wiktionary"].Err; err != nil {
+			t.Fatalf("batch=%d: phase error: %v", batchSize, err)
+		}
+		return repo
+	}
+
+	single, batched := run(1000), run(2)
+
+	if single.entriesInserted != 7 {
+		t.Fatalf("entries inserted = %d, want 7", single.entriesInserted)
+	}
+	if single.translationsInserted == 0 || single.examplesInserted == 0 || single.pronunciationsInserted == 0 {
+		t.Fatalf("fixture produced no children: %v", counts(single))
+	}
+	if counts(batched) != counts(single) {
+		t.Errorf("batched counts %+v differ from single pass %+v", counts(batched), counts(single))
+	}
+
+	senseKey := func(s domain.RefSense) string { return fmt.Sprintf("%d:%s", s.Position, s.Definition) }
+	if len(batched.senses) != len(single.senses) {
+		t.Fatalf("senses: got %d, want %d", len(batched.senses), len(single.senses))
+	}
+	for i := range single.senses {
+		if got, want := senseKey(batched.senses[i]), senseKey(single.senses[i]); got != want {
+			t.Errorf("sense %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestPipeline_UseCopyMatchesBatchInsert(t *testing.T) {
+	var wiktData string
+	for i, w := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		wiktData += fmt.Sprintf(`{"word":"%s","pos":"noun","lang":"English","senses":[{"glosses":["a %s thing"],"examples":[{"text":"One %s."}],"translations":[{"code":"ru","word":"слово%d"}]}],"sounds":[{"ipa":"/%s/"}]}`, w, w, w, i, w) + "\n"
+	}
+	path := createTempFile(t, "wiktionary", wiktData)
+
+	run := func(useCopy bool) (*mockRepo, PhaseResult) {
+		t.Helper()
+		repo := newMockRepo()
+		p := NewPipeline(testLogger(), repo, Config{WiktionaryPath: path, BatchSize: 2, TopN: 100, UseCopy: useCopy})
+		if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+			t.Fatalf("use_copy=%v: %v", useCopy, err)
+		}
+		res := p.Results()["wiktionary"]
+		if res.Err != nil {
+			t.Fatalf("use_copy=%v: phase error: %v", useCopy, res.Err)
+		}
+		return repo, res
+	}
+
+	batch, batchRes := run(false)
+	copied, copyRes := run(true)
+
+	if counts(copied) != counts(batch) {
+		t.Errorf("COPY counts %v differ from batch insert %v", counts(copied), counts(batch))
+	}
+	if copyRes.Inserted != batchRes.Inserted {
+		t.Errorf("Inserted: COPY %d, batch %d", copyRes.Inserted, batchRes.Inserted)
+	}
+
+	calls := map[string]int{}
+	for _, c := range copied.callLog {
+		calls[c]++
+	}
+	if calls["CopyFrom"] != 3 {
+		t.Errorf("CopyFrom calls = %d, want 3", calls["CopyFrom"])
+	}
+	for _, method := range []string{"BulkInsertEntries", "BulkInsertSenses", "BulkInsertTranslations", "BulkInsertExamples"} {
+		if calls[method] != 0 {
+			t.Errorf("%s called %d times with use_copy", method, calls[method])
+		}
+	}
+	if calls["BulkInsertPronunciations"] == 0 {
+		t.Error("pronunciations should still use the batch insert")
+	}
+}
+
+func TestPipeline_InsertsInBatches(t *testing.T) {
+	var wiktData string
+	for i := range 7 {
+		wiktData += fmt.Sprintf(`{"word":"word%d","pos":"noun","lang":"English","senses":[{"glosses":["meaning %d"]}]}`, i, i) + "\n"
+	}
+	path := createTempFile(t, "wiktionary", wiktData)
+
+	tests := []struct {
+		batchSize   int
+		wantBatches int
+	}{
+		{batchSize: 1, wantBatches: 7},
+		{batchSize: 3, wantBatches: 3},
+		{batchSize: 7, wantBatches: 1},
+		{batchSize: 0, wantBatches: 1},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("batch=%d", tt.batchSize), func(t *testing.T) {
+			repo := newMockRepo()
+			p := NewPipeline(testLogger(), repo, Config{WiktionaryPath: path, BatchSize: tt.batchSize, TopN: 100})
+			if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			calls := map[string]int{}
+			for _, c := range repo.callLog {
+				calls[c]++
+			}
+			for _, method := range []string{"BulkInsertEntries", "BulkInsertSenses", "BulkInsertCoverage"} {
+				if calls[method] != tt.wantBatches {
+					t.Errorf("%s calls = %d, want %d", method, calls[method], tt.wantBatches)
+				}
+			}
+			if repo.entriesInserted != 7 {
+				t.Errorf("entries inserted = %d, want 7", repo.entriesInserted)
+			}
+		})
+	}
+}
+
+func TestNewPipeline_BatchSizeBounds(t *testing.T) {
+	tests := []struct {
+		in, want int
+	}{
+		{in: 0, want: defaultBatchSize},
+		{in: -5, want: defaultBatchSize},
+		{in: 200, want: 200},
+		{in: 100000, want: 100000},
+	}
+	for _, tt := range tests {
+		p := NewPipeline(testLogger(), newMockRepo(), Config{BatchSize: tt.in})
+		if p.cfg.BatchSize != tt.want {
+			t.Errorf("BatchSize %d: got %d, want %d", tt.in, p.cfg.BatchSize, tt.want)
+		}
+	}
+}
+
+// counts returns the entry, sense, translation, example, pronunciation and
+// coverage insert counts of m.
+func counts(m *mockRepo) [6]int {
+	return [6]int{m.entriesInserted, m.sensesInserted, m.translationsInserted, m.examplesInserted, m.pronunciationsInserted, m.coverageInserted}
+}
+
+func TestPipeline_UnmappedPOSRecorded(t *testing.T) {
+	wiktData := `{"word":"cat","pos":"noun","lang":"English","senses":[{"glosses":["a feline"]}]}
+{"word":"ago","pos":"postp","lang":"English","senses":[{"glosses":["in the past"]}]}
+`
+	cfg := Config{
+		WiktionaryPath: createTempFile(t, "wiktionary", wiktData),
+		BatchSize:      100,
+		TopN:           100,
+	}
+
+	repo := newMockRepo()
+	p := NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := p.UnmappedPOS(); len(got) != 1 || got["postp"] != 1 {
+		t.Errorf("UnmappedPOS = %v, want map[postp:1]", got)
+	}
+	if p.HasErrors() {
+		t.Error("unmapped tags must not fail the run without strict_pos")
+	}
+	if repo.sensesInserted != 2 {
+		t.Errorf("senses inserted = %d, want 2", repo.sensesInserted)
+	}
+}
+
+func TestPipeline_StrictPOSFailsOnUnmappedTags(t *testing.T) {
+	wiktData := `{"word":"ago","pos":"postp","lang":"English","senses":[{"glosses":["in the past"]}]}
+`
+	cfg := Config{
+		WiktionaryPath: createTempFile(t, "wiktionary", wiktData),
+		BatchSize:      100,
+		TopN:           100,
+		StrictPOS:      true,
+	}
+
+	repo := newMockRepo()
+	p := NewPipeline(testLogger(), repo, cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result := p.Results()["wiktionary"]
+	if result.Err == nil || !strings.Contains(result.Err.Error(), "postp=1") {
+		t.Errorf("wiktionary phase error = %v, want unmapped postp", result.Err)
+	}
+	if repo.entriesInserted != 0 {
+		t.Error("strict mode must stop before inserting")
+	}
+
+	// A mapping from config makes the same input pass.
+	cfg.POSMappings = map[string]map[string]string{"wiktionary": {"postp": "PREPOSITION"}}
+	p = NewPipeline(testLogger(), newMockRepo(), cfg)
+	if err := p.Run(context.Background(), []string{"wiktionary"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.HasErrors() {
+		t.Errorf("mapped tag must pass strict mode: %v", p.Results()["wiktionary"].Err)
+	}
+}
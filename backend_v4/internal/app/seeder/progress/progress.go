@@ -0,0 +1,90 @@
+// Package progress logs the progress of long seeder and enricher runs.
+package progress
+
+import (
+	"log/slog"
+	"math"
+	"time"
+)
+
+// DefaultInterval is the minimum time between two progress lines.
+const DefaultInterval = 5 * time.Second
+
+// Reporter logs processed count, rate and ETA of a loop over a known number
+// of items. A line is logged once at least every items were processed since
+// the previous line and at least interval has passed, so fast loops do not
+// flood the log. A Reporter is not safe for concurrent use.
+type Reporter struct {
+	log      *slog.Logger
+	name     string
+	total    int
+	every    int
+	interval time.Duration
+	now      func() time.Time
+
+	start    time.Time
+	last     time.Time
+	lastDone int
+	done     int
+}
+
+// New creates a Reporter for total items named name in the log. every <= 0
+// disables progress lines.
+func New(log *slog.Logger, name string, total, every int, interval time.Duration) *Reporter {
+	r := &Reporter{
+		log:      log,
+		name:     name,
+		total:    total,
+		every:    every,
+		interval: interval,
+		now:      time.Now,
+	}
+	r.start = r.now()
+	r.last = r.start
+	return r
+}
+
+// Add records n more processed items and logs a progress line when due.
+func (r *Reporter) Add(n int) {
+	r.done += n
+	if r.every <= 0 || r.done-r.lastDone < r.every {
+		return
+	}
+	now := r.now()
+	if now.Sub(r.last) < r.interval {
+		return
+	}
+	r.last = now
+	r.lastDone = r.done
+	r.log.Info("progress", r.attrs(now)...)
+}
+
+func (r *Reporter) attrs(now time.Time) []any {
+	rt := rate(r.done, now.Sub(r.start))
+	attrs := []any{
+		slog.String("task", r.name),
+		slog.Int("processed", r.done),
+		slog.Float64("rate_per_sec", math.Round(rt*10)/10),
+	}
+	if r.total > 0 {
+		attrs = append(attrs,
+			slog.Int("total", r.total),
+			slog.Duration("eta", eta(r.total-r.done, rt).Round(time.Second)),
+		)
+	}
+	return attrs
+}
+
+func rate(done int, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(done) / elapsed.Seconds()
+}
+
+func eta(remaining int, rate float64) time.Duration {
+	if remaining <= 0 || rate <= 0 {
+		return 0
+	}
+	return time.Duration(float64(remaining) / rate * float64(time.Second))
+}
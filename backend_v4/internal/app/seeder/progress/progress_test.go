@@ -0,0 +1,121 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock advances by step on every reading.
+type fakeClock struct {
+	t    time.Time
+	step time.Duration
+}
+
+func (c *fakeClock) now() time.Time {
+	c.t = c.t.Add(c.step)
+	return c.t
+}
+
+func newTestReporter(buf *bytes.Buffer, total, every int, interval, step time.Duration) *Reporter {
+	log := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	clock := &fakeClock{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), step: step}
+	r := New(log, "test", total, every, interval)
+	r.now = clock.now
+	r.start = clock.t
+	r.last = clock.t
+	return r
+}
+
+func progressLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, l := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if l == "" {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal([]byte(l), &m); err != nil {
+			t.Fatalf("decode %q: %v", l, err)
+		}
+		lines = append(lines, m)
+	}
+	return lines
+}
+
+func TestReporter_EmitsEveryN(t *testing.T) {
+	var buf bytes.Buffer
+	// Each clock reading advances 100ms; only logging reads the clock.
+	r := newTestReporter(&buf, 100, 10, 0, 100*time.Millisecond)
+
+	for range 35 {
+		r.Add(1)
+	}
+
+	lines := progressLines(t, &buf)
+	if len(lines) != 3 {
+		t.Fatalf("progress lines: got %d, want 3", len(lines))
+	}
+	for i, want := range []float64{10, 20, 30} {
+		if got := lines[i]["processed"]; got != want {
+			t.Errorf("line %d processed = %v, want %v", i, got, want)
+		}
+		if lines[i]["total"] != float64(100) {
+			t.Errorf("line %d total = %v, want 100", i, lines[i]["total"])
+		}
+	}
+}
+
+func TestReporter_RateAndETA(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestReporter(&buf, 100, 10, 0, time.Second)
+
+	// One clock reading per logged line: 10 items after 1s, 20 after 2s.
+	for range 20 {
+		r.Add(1)
+	}
+
+	lines := progressLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("progress lines: got %d, want 2", len(lines))
+	}
+	if got := lines[1]["rate_per_sec"]; got != float64(10) {
+		t.Errorf("rate_per_sec = %v, want 10", got)
+	}
+	// 80 items left at 10/s; slog encodes durations as nanoseconds.
+	if got := lines[1]["eta"]; got != float64(8*time.Second) {
+		t.Errorf("eta = %v, want %v", got, float64(8*time.Second))
+	}
+}
+
+func TestReporter_RateLimited(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestReporter(&buf, 0, 1, 5*time.Second, time.Second)
+
+	// Every Add is due by count, but a line needs 5s since the previous one.
+	for range 12 {
+		r.Add(1)
+	}
+
+	lines := progressLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("progress lines: got %d, want 2", len(lines))
+	}
+	if _, ok := lines[0]["eta"]; ok {
+		t.Error("eta logged without a known total")
+	}
+}
+
+func TestReporter_Disabled(t *testing.T) {
+	var buf bytes.Buffer
+	r := newTestReporter(&buf, 100, 0, 0, time.Second)
+
+	r.Add(100)
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
@@ -22,6 +22,10 @@ type RefEntryBulkRepo interface {
 	BulkInsertRelations(ctx context.Context, relations []domain.RefWordRelation) (int, error)
 	BulkInsertCoverage(ctx context.Context, coverage []domain.RefEntrySourceCoverage) (int, error)
 
+	// COPY-based insert of entries and their senses, translations and
+	// examples — same skip-existing semantics, faster for full seeds.
+	CopyFrom(ctx context.Context, entries []domain.RefEntry, senses []domain.RefSense, translations []domain.RefTranslation, examples []domain.RefExample) (int, error)
+
 	// Replace — delete+insert for LLM enrichment.
 	ReplaceEntryContent(ctx context.Context, entryID uuid.UUID, senses []domain.RefSense, translations []domain.RefTranslation, examples []domain.RefExample) error
 
@@ -33,7 +37,20 @@ type RefEntryBulkRepo interface {
 	GetAllNormalizedTexts(ctx context.Context) (map[string]bool, error)
 	GetFirstSenseIDsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID]uuid.UUID, error)
 	GetPronunciationIPAsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID]map[string]bool, error)
+	GetSenseDefinitionsByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) (map[uuid.UUID]map[string]bool, error)
+
+	// Cross-source sense merge — walk entries with senses from several
+	// sources and fold near-duplicates into one sense.
+	GetMultiSourceEntryIDsAfter(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
+	GetSensesByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]domain.RefSense, error)
+	MergeSenses(ctx context.Context, kept []domain.RefSense, merged map[uuid.UUID]uuid.UUID) (int, error)
 
 	// Registry — data source versioning.
 	UpsertDataSources(ctx context.Context, sources []domain.RefDataSource) error
 }
+
+// TxManager runs a function inside a database transaction.
+// Implemented by postgres.TxManager.
+type TxManager interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
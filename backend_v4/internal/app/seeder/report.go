@@ -0,0 +1,112 @@
+package seeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/app/seeder/wiktionary"
+)
+
+// DryRunReport summarizes what a real Wiktionary run would change in the catalog.
+type DryRunReport struct {
+	// NewEntries is the number of parsed words absent from the catalog.
+	NewEntries int `json:"new_entries"`
+	// NewEntrySenses is the number of senses belonging to NewEntries.
+	NewEntrySenses int `json:"new_entry_senses"`
+	// MergeEntries is the number of existing words that would gain senses.
+	MergeEntries int `json:"merge_entries"`
+	// MergeSenses is the number of senses that would merge into existing words.
+	MergeSenses int `json:"merge_senses"`
+	// DuplicateEntries is the number of existing words whose every parsed sense
+	// is already in the catalog.
+	DuplicateEntries int `json:"duplicate_entries"`
+	// SkippedByLanguage is the number of dump lines whose headword language
+	// is not in the allowlist.
+	SkippedByLanguage int `json:"skipped_by_language"`
+	// TranslationsSkippedByLanguage is the number of translations of parsed
+	// words dropped because their language is not in the allowlist.
+	TranslationsSkippedByLanguage int `json:"translations_skipped_by_language"`
+	// SkippedShortDefinitions is the number of senses dropped because their
+	// definition is shorter than min_definition_len.
+	SkippedShortDefinitions int `json:"skipped_short_definitions"`
+}
+
+// buildDryRunReport compares parsed Wiktionary data with the current catalog.
+// Senses are matched by exact definition text.
+func buildDryRunReport(ctx context.Context, repo RefEntryBulkRepo, data wiktionary.DomainResult, batchSize int) (*DryRunReport, error) {
+	texts := make([]string, len(data.Entries))
+	for i, e := range data.Entries {
+		texts[i] = e.TextNormalized
+	}
+
+	existingIDs, err := batchedLookup(ctx, repo, texts, batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("lookup entry IDs: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(existingIDs))
+	for _, id := range existingIDs {
+		ids = append(ids, id)
+	}
+	existingDefs, err := repo.GetSenseDefinitionsByEntryIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get existing senses: %w", err)
+	}
+
+	sensesByEntry := make(map[uuid.UUID][]string, len(data.Entries))
+	for _, s := range data.Senses {
+		sensesByEntry[s.RefEntryID] = append(sensesByEntry[s.RefEntryID], s.Definition)
+	}
+
+	report := &DryRunReport{}
+	for _, e := range data.Entries {
+		defs := sensesByEntry[e.ID]
+
+		existingID, ok := existingIDs[e.TextNormalized]
+		if !ok {
+			report.NewEntries++
+			report.NewEntrySenses += len(defs)
+			continue
+		}
+
+		novel := 0
+		for _, d := range defs {
+			if !existingDefs[existingID][d] {
+				novel++
+			}
+		}
+		if novel == 0 {
+			report.DuplicateEntries++
+			continue
+		}
+		report.MergeEntries++
+		report.MergeSenses += novel
+	}
+
+	return report, nil
+}
+
+// add sums the catalog comparison counts of other into r.
+func (r *DryRunReport) add(other *DryRunReport) {
+	r.NewEntries += other.NewEntries
+	r.NewEntrySenses += other.NewEntrySenses
+	r.MergeEntries += other.MergeEntries
+	r.MergeSenses += other.MergeSenses
+	r.DuplicateEntries += other.DuplicateEntries
+}
+
+// writeReport writes the report as indented JSON to path.
+func writeReport(path string, report *DryRunReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write report %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package seeder
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// mergeCrossSourceSenses folds near-duplicate senses of an entry into one.
+// senses must be grouped by entry and ordered by position. A sense is merged
+// into the first earlier sense of the same entry that comes from a different
+// source, has the same part of speech and whose definition shares at least
+// threshold of its words (Jaccard similarity on normalized words). The kept
+// sense records the merged sense's source in Sources and takes over its
+// translations and examples, skipping texts it already has. Positions of the
+// kept senses and of their translations and examples are renumbered.
+//
+// Returns the kept senses and, per merged sense ID, the ID of the sense it was
+// merged into. A non-positive threshold merges nothing.
+func mergeCrossSourceSenses(senses []domain.RefSense, threshold float64) ([]domain.RefSense, map[uuid.UUID]uuid.UUID) {
+	merged := make(map[uuid.UUID]uuid.UUID)
+	if threshold <= 0 {
+		return senses, merged
+	}
+
+	kept := make([]domain.RefSense, 0, len(senses))
+	var words [][]string // definition words of kept, by index
+	entryStart := 0      // index in kept of the current entry's first sense
+
+	for _, s := range senses {
+		if len(kept) > 0 && kept[len(kept)-1].RefEntryID != s.RefEntryID {
+			entryStart = len(kept)
+		}
+		sw := definitionWords(s.Definition)
+
+		into := -1
+		for i := entryStart; i < len(kept); i++ {
+			k := kept[i]
+			if k.SourceSlug == s.SourceSlug || !samePOS(k.PartOfSpeech, s.PartOfSpeech) {
+				continue
+			}
+			if jaccard(words[i], sw) >= threshold {
+				into = i
+				break
+			}
+		}
+
+		if into < 0 {
+			s.Position = len(kept) - entryStart
+			kept = append(kept, s)
+			words = append(words, sw)
+			continue
+		}
+
+		k := &kept[into]
+		merged[s.ID] = k.ID
+		if !containsFold(k.Sources, s.SourceSlug) {
+			k.Sources = append(k.Sources, s.SourceSlug)
+		}
+		for _, src := range s.Sources {
+			if src != k.SourceSlug && !containsFold(k.Sources, src) {
+				k.Sources = append(k.Sources, src)
+			}
+		}
+		for _, tr := range s.Translations {
+			if !hasTranslation(k.Translations, tr.Text) {
+				tr.RefSenseID = k.ID
+				tr.Position = len(k.Translations)
+				k.Translations = append(k.Translations, tr)
+			}
+		}
+		for _, ex := range s.Examples {
+			if !hasExample(k.Examples, ex.Sentence) {
+				ex.RefSenseID = k.ID
+				ex.Position = len(k.Examples)
+				k.Examples = append(k.Examples, ex)
+			}
+		}
+	}
+
+	return kept, merged
+}
+
+// definitionWords returns the lowercased runs of letters and digits in s.
+func definitionWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// jaccard returns the share of distinct words common to a and b.
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	set := make(map[string]bool, len(a))
+	for _, w := range a {
+		set[w] = true
+	}
+	union := len(set)
+	common := 0
+	seen := make(map[string]bool, len(b))
+	for _, w := range b {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		if set[w] {
+			common++
+		} else {
+			union++
+		}
+	}
+	return float64(common) / float64(union)
+}
+
+// samePOS reports whether two optional parts of speech are equal.
+func samePOS(a, b *domain.PartOfSpeech) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTranslation(list []domain.RefTranslation, text string) bool {
+	for _, tr := range list {
+		if strings.EqualFold(tr.Text, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasExample(list []domain.RefExample, sentence string) bool {
+	for _, ex := range list {
+		if strings.EqualFold(ex.Sentence, sentence) {
+			return true
+		}
+	}
+	return false
+}
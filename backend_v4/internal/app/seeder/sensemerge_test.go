@@ -0,0 +1,128 @@
+package seeder
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+func testSense(entryID uuid.UUID, source, definition string, pos domain.PartOfSpeech, position int) domain.RefSense {
+	return domain.RefSense{
+		ID:           uuid.New(),
+		RefEntryID:   entryID,
+		Definition:   definition,
+		PartOfSpeech: &pos,
+		SourceSlug:   source,
+		Position:     position,
+	}
+}
+
+func withTranslations(s domain.RefSense, texts ...string) domain.RefSense {
+	for i, text := range texts {
+		s.Translations = append(s.Translations, domain.RefTranslation{
+			ID: uuid.New(), RefSenseID: s.ID, Text: text, SourceSlug: s.SourceSlug, Position: i,
+		})
+	}
+	return s
+}
+
+func TestMergeCrossSourceSenses_Dog(t *testing.T) {
+	dog := uuid.New()
+	wikt := withTranslations(
+		testSense(dog, "wiktionary", "A domesticated carnivorous mammal, Canis familiaris.", domain.PartOfSpeechNoun, 0),
+		"собака",
+	)
+	slang := testSense(dog, "wiktionary", "An unattractive person.", domain.PartOfSpeechNoun, 1)
+	other := withTranslations(
+		testSense(dog, "wordnet", "domesticated carnivorous mammal (Canis familiaris)", domain.PartOfSpeechNoun, 2),
+		"Собака", "пёс",
+	)
+	other.Examples = []domain.RefExample{{ID: uuid.New(), RefSenseID: other.ID, Sentence: "The dog barked.", SourceSlug: "wordnet"}}
+
+	kept, merged := mergeCrossSourceSenses([]domain.RefSense{wikt, slang, other}, 0.8)
+
+	if len(kept) != 2 {
+		t.Fatalf("kept %d senses, want 2", len(kept))
+	}
+	if merged[other.ID] != wikt.ID || len(merged) != 1 {
+		t.Fatalf("merged = %v, want the wordnet sense merged into the wiktionary one", merged)
+	}
+
+	canon := kept[0]
+	if canon.ID != wikt.ID || canon.SourceSlug != "wiktionary" {
+		t.Errorf("canonical sense = %s from %s, want the first (wiktionary) sense", canon.ID, canon.SourceSlug)
+	}
+	if !slices.Equal(canon.Sources, []string{"wordnet"}) {
+		t.Errorf("Sources = %v, want [wordnet]", canon.Sources)
+	}
+
+	var texts []string
+	for i, tr := range canon.Translations {
+		texts = append(texts, tr.Text)
+		if tr.RefSenseID != canon.ID || tr.Position != i {
+			t.Errorf("translation %q: sense %s position %d, want %s position %d", tr.Text, tr.RefSenseID, tr.Position, canon.ID, i)
+		}
+	}
+	if !slices.Equal(texts, []string{"собака", "пёс"}) {
+		t.Errorf("translations = %v, want [собака пёс] without the repeated Собака", texts)
+	}
+	if len(canon.Examples) != 1 || canon.Examples[0].RefSenseID != canon.ID {
+		t.Errorf("examples = %+v, want the wordnet example moved to the canonical sense", canon.Examples)
+	}
+
+	if kept[1].ID != slang.ID || kept[1].Position != 1 {
+		t.Errorf("second sense = %s at %d, want the slang sense at 1", kept[1].ID, kept[1].Position)
+	}
+}
+
+func TestMergeCrossSourceSenses_KeepsDistinct(t *testing.T) {
+	dog, cat := uuid.New(), uuid.New()
+	def := "A domesticated carnivorous mammal."
+
+	tests := []struct {
+		name   string
+		senses []domain.RefSense
+	}{
+		{"same source", []domain.RefSense{
+			testSense(dog, "wiktionary", def, domain.PartOfSpeechNoun, 0),
+			testSense(dog, "wiktionary", def, domain.PartOfSpeechNoun, 1),
+		}},
+		{"different part of speech", []domain.RefSense{
+			testSense(dog, "wiktionary", def, domain.PartOfSpeechNoun, 0),
+			testSense(dog, "wordnet", def, domain.PartOfSpeechVerb, 1),
+		}},
+		{"different entries", []domain.RefSense{
+			testSense(dog, "wiktionary", def, domain.PartOfSpeechNoun, 0),
+			testSense(cat, "wordnet", def, domain.PartOfSpeechNoun, 0),
+		}},
+		{"below threshold", []domain.RefSense{
+			testSense(dog, "wiktionary", def, domain.PartOfSpeechNoun, 0),
+			testSense(dog, "wordnet", "To follow someone persistently.", domain.PartOfSpeechNoun, 1),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, merged := mergeCrossSourceSenses(tt.senses, 0.8)
+			if len(kept) != len(tt.senses) || len(merged) != 0 {
+				t.Errorf("kept %d, merged %d; want all %d senses kept", len(kept), len(merged), len(tt.senses))
+			}
+		})
+	}
+}
+
+func TestMergeCrossSourceSenses_Disabled(t *testing.T) {
+	dog := uuid.New()
+	senses := []domain.RefSense{
+		testSense(dog, "wiktionary", "A dog.", domain.PartOfSpeechNoun, 0),
+		testSense(dog, "wordnet", "A dog.", domain.PartOfSpeechNoun, 1),
+	}
+
+	kept, merged := mergeCrossSourceSenses(senses, 0)
+	if len(kept) != 2 || len(merged) != 0 {
+		t.Errorf("kept %d, merged %d; want nothing merged at threshold 0", len(kept), len(merged))
+	}
+}
@@ -6,11 +6,112 @@ import (
 )
 
 var (
-	htmlTagRe   = regexp.MustCompile(`<[^>]*>`)
-	wikiLinkRe  = regexp.MustCompile(`\[\[([^|\]]*\|)?([^\]]*)\]\]`)
+	htmlTagRe    = regexp.MustCompile(`<[^>]*>`)
+	wikiLinkRe   = regexp.MustCompile(`\[\[([^|\]]*\|)?([^\]]*)\]\]`)
 	multiSpaceRe = regexp.MustCompile(`\s{2,}`)
+	templateRe   = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+	spacePunctRe = regexp.MustCompile(`\s+([.,;:!?])`)
 )
 
+// DefaultMaxDefinitionLen is the definition length cap used when
+// CleanOptions.MaxDefinitionLen is not set.
+const DefaultMaxDefinitionLen = 5000
+
+// MarkupMode selects how much Wiktionary markup survives in definitions and
+// examples.
+type MarkupMode string
+
+const (
+	// MarkupStrip removes HTML tags and templates and reduces wiki links to
+	// their display text.
+	MarkupStrip MarkupMode = "strip"
+	// MarkupLinks is MarkupStrip but renders links whose target differs from
+	// the display text as "display (target)", keeping the target word.
+	MarkupLinks MarkupMode = "links"
+	// MarkupPreserve removes only HTML tags, leaving wiki links and templates
+	// verbatim for later processing.
+	MarkupPreserve MarkupMode = "preserve"
+)
+
+// IsValid reports whether m is a known markup mode.
+func (m MarkupMode) IsValid() bool {
+	switch m {
+	case MarkupStrip, MarkupLinks, MarkupPreserve:
+		return true
+	}
+	return false
+}
+
+// CleanOptions controls how glosses, examples and pronunciations are cleaned
+// when converting to domain structs. The zero value means MarkupStrip,
+// DefaultMaxDefinitionLen, no minimum definition length and raw IPA.
+type CleanOptions struct {
+	MaxDefinitionLen int
+	Markup           MarkupMode
+	// MinDefinitionLength drops senses whose cleaned definition is shorter,
+	// in characters, such as one-word stub glosses. 0 keeps every sense.
+	MinDefinitionLength int
+	// NormalizeIPA runs transcriptions through NormalizeIPA; BroadIPA
+	// additionally reduces them to broad form. Without NormalizeIPA the raw
+	// Wiktionary string is stored.
+	NormalizeIPA bool
+	BroadIPA     bool
+	// POS overrides the built-in POS tag mapping; nil uses it alone.
+	POS POSMap
+	// DeterministicIDs derives row IDs from the word and the sense and child
+	// positions instead of generating random ones, so converting the same
+	// input twice yields the same IDs.
+	DeterministicIDs bool
+}
+
+func (o CleanOptions) withDefaults() CleanOptions {
+	if o.MaxDefinitionLen <= 0 {
+		o.MaxDefinitionLen = DefaultMaxDefinitionLen
+	}
+	if o.Markup == "" {
+		o.Markup = MarkupStrip
+	}
+	return o
+}
+
+// CleanText cleans s according to mode, collapsing whitespace in every mode.
+// An unknown mode behaves like MarkupStrip.
+func CleanText(s string, mode MarkupMode) string {
+	switch mode {
+	case MarkupPreserve:
+		s = htmlTagRe.ReplaceAllString(s, "")
+		return strings.TrimSpace(multiSpaceRe.ReplaceAllString(s, " "))
+	case MarkupLinks:
+		s = wikiLinkRe.ReplaceAllStringFunc(s, renderLink)
+		return StripMarkup(stripTemplates(s))
+	default:
+		return StripMarkup(stripTemplates(s))
+	}
+}
+
+// renderLink turns [[target|display]] into "display (target)" when the two
+// differ, and into the display text otherwise.
+func renderLink(link string) string {
+	m := wikiLinkRe.FindStringSubmatch(link)
+	target, display := strings.TrimSuffix(m[1], "|"), m[2]
+	if target == "" || strings.EqualFold(target, display) {
+		return display
+	}
+	return display + " (" + target + ")"
+}
+
+// stripTemplates removes {{...}} templates, innermost first, along with the
+// space a removed template leaves before punctuation.
+func stripTemplates(s string) string {
+	if !templateRe.MatchString(s) {
+		return s
+	}
+	for templateRe.MatchString(s) {
+		s = templateRe.ReplaceAllString(s, "")
+	}
+	return spacePunctRe.ReplaceAllString(s, "$1")
+}
+
 // StripMarkup removes HTML tags and wiki-style links from s,
 // collapses multiple spaces, and trims whitespace.
 func StripMarkup(s string) string {
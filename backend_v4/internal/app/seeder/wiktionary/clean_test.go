@@ -243,3 +243,32 @@ func TestDeduplicateStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestCleanText_Modes(t *testing.T) {
+	const gloss = `<i>Of a [[feline|cat]]</i>: {{lb|en|informal}} to [[purr]] {{q|loudly}}.`
+
+	tests := []struct {
+		mode MarkupMode
+		want string
+	}{
+		{MarkupStrip, "Of a cat: to purr."},
+		{MarkupLinks, "Of a cat (feline): to purr."},
+		{MarkupPreserve, "Of a [[feline|cat]]: {{lb|en|informal}} to [[purr]] {{q|loudly}}."},
+		{"", "Of a cat: to purr."},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			if got := CleanText(gloss, tt.mode); got != tt.want {
+				t.Errorf("CleanText(%q) = %q, want %q", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanText_NestedTemplates(t *testing.T) {
+	got := CleanText("a {{outer|{{inner}}|x}} word", MarkupStrip)
+	if got != "a word" {
+		t.Errorf("got %q, want %q", got, "a word")
+	}
+}
@@ -0,0 +1,30 @@
+package wiktionary
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+// commonsUploadBase is where Wikimedia Commons serves original media files.
+const commonsUploadBase = "https://upload.wikimedia.org/wikipedia/commons/"
+
+// CommonsAudioURL builds the direct Wikimedia Commons URL for an audio file
+// name as referenced by Wiktionary ("En-us-water.ogg"). MediaWiki stores
+// files under /<h[0]>/<h[0:2]>/ where h is the MD5 of the canonical name:
+// spaces become underscores and the first letter is upper-cased. An empty
+// name returns "".
+func CommonsAudioURL(filename string) string {
+	name := strings.ReplaceAll(strings.TrimSpace(filename), " ", "_")
+	name = strings.TrimPrefix(name, "File:")
+	if name == "" {
+		return ""
+	}
+	name = strings.ToUpper(name[:1]) + name[1:]
+
+	sum := md5.Sum([]byte(name))
+	h := hex.EncodeToString(sum[:])
+
+	return commonsUploadBase + h[:1] + "/" + h[:2] + "/" + url.PathEscape(name)
+}
@@ -0,0 +1,48 @@
+package wiktionary
+
+import "testing"
+
+func TestCommonsAudioURL(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{"plain", "En-us-water.ogg", "https://upload.wikimedia.org/wikipedia/commons/b/bd/En-us-water.ogg"},
+		{"lower-case first letter", "en-us-water.ogg", "https://upload.wikimedia.org/wikipedia/commons/b/bd/En-us-water.ogg"},
+		{"File: prefix", "File:En-us-water.ogg", "https://upload.wikimedia.org/wikipedia/commons/b/bd/En-us-water.ogg"},
+		{"spaces become underscores", "En-us-ice cream.ogg", "https://upload.wikimedia.org/wikipedia/commons/2/28/En-us-ice_cream.ogg"},
+		{"empty", "", ""},
+		{"blank", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommonsAudioURL(tt.file); got != tt.want {
+				t.Errorf("CommonsAudioURL(%q) = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSounds_AttachesAudioByRegion(t *testing.T) {
+	entry := &kaikkiEntry{Sounds: []kaikkiSound{
+		{IPA: "/ˈwɔːtə/", Tags: []string{"Received-Pronunciation"}},
+		{IPA: "/ˈwɑtɚ/", Tags: []string{"General-American"}},
+		{IPA: "[ˈwɑɾɚ]", Tags: []string{"General-American"}},
+		{Audio: "En-us-water.ogg", Tags: []string{"US"}},
+		{Audio: "En-au-water.ogg", Tags: []string{"Australia"}},
+	}}
+
+	sounds := buildSounds(entry)
+
+	if len(sounds) != 2 {
+		t.Fatalf("got %d sounds, want 2: %+v", len(sounds), sounds)
+	}
+	if sounds[0].AudioFile != "" {
+		t.Errorf("UK sound got audio %q, want none", sounds[0].AudioFile)
+	}
+	if sounds[1].AudioFile != "En-us-water.ogg" {
+		t.Errorf("US sound audio = %q, want En-us-water.ogg", sounds[1].AudioFile)
+	}
+}
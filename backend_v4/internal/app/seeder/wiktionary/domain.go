@@ -1,7 +1,10 @@
 package wiktionary
 
 import (
+	"strconv"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -9,6 +12,27 @@ import (
 
 const sourceSlug = "wiktionary"
 
+// idNamespace roots the IDs derived with CleanOptions.DeterministicIDs.
+var idNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://kaikki.org/dictionary/English/"))
+
+// newEntryID returns the ID of the entry for word: random, or derived from
+// the normalized word with opts.DeterministicIDs.
+func newEntryID(opts CleanOptions, word string) uuid.UUID {
+	if !opts.DeterministicIDs {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(idNamespace, []byte(domain.NormalizeText(word)))
+}
+
+// newChildID returns the ID of the idx-th row of a kind under parent: random,
+// or derived from the parent ID, kind and index with opts.DeterministicIDs.
+func newChildID(opts CleanOptions, parent uuid.UUID, kind string, idx int) uuid.UUID {
+	if !opts.DeterministicIDs {
+		return uuid.New()
+	}
+	return uuid.NewSHA1(parent, []byte(kind+":"+strconv.Itoa(idx)))
+}
+
 // DomainResult holds the flat slices ready for batch insert.
 type DomainResult struct {
 	Entries        []domain.RefEntry
@@ -16,6 +40,10 @@ type DomainResult struct {
 	Translations   []domain.RefTranslation
 	Examples       []domain.RefExample
 	Pronunciations []domain.RefPronunciation
+
+	// SkippedShortDefinitions counts senses dropped by
+	// CleanOptions.MinDefinitionLength.
+	SkippedShortDefinitions int
 }
 
 // senseKey is used for deduplicating senses within a single entry.
@@ -26,7 +54,6 @@ type senseKey struct {
 
 // mergedSense accumulates data from duplicate senses.
 type mergedSense struct {
-	id           uuid.UUID
 	definition   string
 	pos          domain.PartOfSpeech
 	translations []string
@@ -36,118 +63,222 @@ type mergedSense struct {
 // ToDomainEntries converts parsed Wiktionary entries into flat domain slices
 // suitable for batch insertion. Senses with identical (definition, partOfSpeech)
 // within the same entry are merged: their examples and translations are combined.
-func ToDomainEntries(entries []ParsedEntry) DomainResult {
+// Glosses and examples are cleaned, definitions truncated, senses with too
+// short a definition dropped and, if enabled, IPA transcriptions normalized
+// per opts.
+func ToDomainEntries(entries []ParsedEntry, opts CleanOptions) DomainResult {
+	if len(entries) == 0 {
+		return DomainResult{}
+	}
+	return toDomainEntries(entries, time.Now(), opts.withDefaults())
+}
+
+// ToDomainEntriesWithWorkers is ToDomainEntries with the conversion split
+// into contiguous chunks handled by up to workers goroutines. Chunks are
+// concatenated in input order, so the result is identical to the serial
+// conversion; with opts.DeterministicIDs that includes the IDs.
+func ToDomainEntriesWithWorkers(entries []ParsedEntry, workers int, opts CleanOptions) DomainResult {
 	if len(entries) == 0 {
 		return DomainResult{}
 	}
 
 	now := time.Now()
+	opts = opts.withDefaults()
+	if workers <= 1 || len(entries) < workers {
+		return toDomainEntries(entries, now, opts)
+	}
+
+	chunkSize := (len(entries) + workers - 1) / workers
+	parts := make([]DomainResult, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		lo := w * chunkSize
+		if lo >= len(entries) {
+			break
+		}
+		hi := min(lo+chunkSize, len(entries))
 
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[w] = toDomainEntries(entries[lo:hi], now, opts)
+		}()
+	}
+	wg.Wait()
+
+	var result DomainResult
+	for i := range parts {
+		result.Append(parts[i])
+	}
+	return result
+}
+
+// ToDomainEntry converts a single parsed entry with the same rules as
+// ToDomainEntries, so a stream of entries can be converted one at a time.
+func ToDomainEntry(entry ParsedEntry, opts CleanOptions) DomainResult {
 	var result DomainResult
+	appendDomainEntry(&result, &entry, time.Now(), opts.withDefaults())
+	return result
+}
+
+// Append adds the rows and counters of other to r.
+func (r *DomainResult) Append(other DomainResult) {
+	r.Entries = append(r.Entries, other.Entries...)
+	r.Senses = append(r.Senses, other.Senses...)
+	r.Translations = append(r.Translations, other.Translations...)
+	r.Examples = append(r.Examples, other.Examples...)
+	r.Pronunciations = append(r.Pronunciations, other.Pronunciations...)
+	r.SkippedShortDefinitions += other.SkippedShortDefinitions
+}
 
+func toDomainEntries(entries []ParsedEntry, now time.Time, opts CleanOptions) DomainResult {
+	var result DomainResult
 	for i := range entries {
-		pe := &entries[i]
-		entryID := uuid.New()
-
-		result.Entries = append(result.Entries, domain.RefEntry{
-			ID:             entryID,
-			Text:           pe.Word,
-			TextNormalized: domain.NormalizeText(pe.Word),
-			IsCoreLexicon:  false,
-			CreatedAt:      now,
-		})
+		appendDomainEntry(&result, &entries[i], now, opts)
+	}
+	return result
+}
 
-		// Deduplicate senses by (definition, partOfSpeech).
-		seenSenses := make(map[senseKey]int) // key → index in merged slice
-		var merged []mergedSense
-
-		for pgIdx := range pe.POSGroups {
-			pg := &pe.POSGroups[pgIdx]
-			pos := MapPOS(pg.POS)
-
-			for sIdx := range pg.Senses {
-				ps := &pg.Senses[sIdx]
-
-				if len(ps.Glosses) == 0 {
-					continue
-				}
-
-				def := TruncateDefinition(StripMarkup(ps.Glosses[0]), 5000)
-				key := senseKey{definition: def, partOfSpeech: pos}
-
-				if idx, exists := seenSenses[key]; exists {
-					// Merge examples and translations into existing sense.
-					merged[idx].examples = append(merged[idx].examples, ps.Examples...)
-					merged[idx].translations = append(merged[idx].translations, ps.Translations...)
-				} else {
-					seenSenses[key] = len(merged)
-					merged = append(merged, mergedSense{
-						id:           uuid.New(),
-						definition:   def,
-						pos:          pos,
-						translations: append([]string(nil), ps.Translations...),
-						examples:     append([]string(nil), ps.Examples...),
-					})
-				}
-			}
-		}
+// appendDomainEntry converts pe and appends its rows to result.
+func appendDomainEntry(result *DomainResult, pe *ParsedEntry, now time.Time, opts CleanOptions) {
+	entryID := newEntryID(opts, pe.Word)
 
-		// Convert merged senses to domain structs.
-		for sensePos, ms := range merged {
-			pos := ms.pos //nolint:copyloopvar // need addressable copy for pointer
-			result.Senses = append(result.Senses, domain.RefSense{
-				ID:           ms.id,
-				RefEntryID:   entryID,
-				Definition:   ms.definition,
-				PartOfSpeech: &pos,
-				SourceSlug:   sourceSlug,
-				Position:     sensePos,
-				CreatedAt:    now,
-			})
+	result.Entries = append(result.Entries, domain.RefEntry{
+		ID:             entryID,
+		Text:           pe.Word,
+		TextNormalized: domain.NormalizeText(pe.Word),
+		IsCoreLexicon:  false,
+		CreatedAt:      now,
+	})
 
-			// Deduplicated translations.
-			for trIdx, tr := range DeduplicateStrings(ms.translations) {
-				result.Translations = append(result.Translations, domain.RefTranslation{
-					ID:         uuid.New(),
-					RefSenseID: ms.id,
-					Text:       tr,
-					SourceSlug: sourceSlug,
-					Position:   trIdx,
-				})
+	// Deduplicate senses by (definition, partOfSpeech).
+	seenSenses := make(map[senseKey]int) // key → index in merged slice
+	var merged []mergedSense
+
+	for pgIdx := range pe.POSGroups {
+		pg := &pe.POSGroups[pgIdx]
+		pos := opts.POS.Map(pg.POS)
+
+		for sIdx := range pg.Senses {
+			ps := &pg.Senses[sIdx]
+
+			if len(ps.Glosses) == 0 {
+				continue
+			}
+
+			def := TruncateDefinition(CleanText(ps.Glosses[0], opts.Markup), opts.MaxDefinitionLen)
+			if def == "" {
+				continue
+			}
+			if utf8.RuneCountInString(def) < opts.MinDefinitionLength {
+				result.SkippedShortDefinitions++
+				continue
 			}
+			key := senseKey{definition: def, partOfSpeech: pos}
 
-			// Deduplicated examples.
-			for exIdx, ex := range DeduplicateStrings(ms.examples) {
-				result.Examples = append(result.Examples, domain.RefExample{
-					ID:          uuid.New(),
-					RefSenseID:  ms.id,
-					Sentence:    StripMarkup(ex),
-					Translation: nil,
-					SourceSlug:  sourceSlug,
-					Position:    exIdx,
+			if idx, exists := seenSenses[key]; exists {
+				// Merge examples and translations into existing sense.
+				merged[idx].examples = append(merged[idx].examples, ps.Examples...)
+				merged[idx].translations = append(merged[idx].translations, ps.Translations...)
+			} else {
+				seenSenses[key] = len(merged)
+				merged = append(merged, mergedSense{
+					definition:   def,
+					pos:          pos,
+					translations: append([]string(nil), ps.Translations...),
+					examples:     append([]string(nil), ps.Examples...),
 				})
 			}
 		}
+	}
+
+	// Convert merged senses to domain structs.
+	for sensePos, ms := range merged {
+		pos := ms.pos //nolint:copyloopvar // need addressable copy for pointer
+		senseID := newChildID(opts, entryID, "sense", sensePos)
+		result.Senses = append(result.Senses, domain.RefSense{
+			ID:           senseID,
+			RefEntryID:   entryID,
+			Definition:   ms.definition,
+			PartOfSpeech: &pos,
+			SourceSlug:   sourceSlug,
+			Position:     sensePos,
+			CreatedAt:    now,
+		})
 
-		// Pronunciations.
-		for sndIdx := range pe.Sounds {
-			snd := &pe.Sounds[sndIdx]
+		// Deduplicated translations.
+		for trIdx, tr := range DeduplicateStrings(ms.translations) {
+			result.Translations = append(result.Translations, domain.RefTranslation{
+				ID:         newChildID(opts, senseID, "translation", trIdx),
+				RefSenseID: senseID,
+				Text:       tr,
+				SourceSlug: sourceSlug,
+				Position:   trIdx,
+			})
+		}
 
-			var region *string
-			if snd.Region != "" {
-				region = &snd.Region
+		// Cleaned, deduplicated examples.
+		sentences := make([]string, 0, len(ms.examples))
+		for _, ex := range ms.examples {
+			if sentence := CleanText(ex, opts.Markup); sentence != "" {
+				sentences = append(sentences, sentence)
 			}
-
-			result.Pronunciations = append(result.Pronunciations, domain.RefPronunciation{
-				ID:            uuid.New(),
-				RefEntryID:    entryID,
-				Transcription: &snd.IPA,
-				AudioURL:      nil,
-				Region:        region,
-				SourceSlug:    sourceSlug,
+		}
+		for exIdx, sentence := range DeduplicateStrings(sentences) {
+			result.Examples = append(result.Examples, domain.RefExample{
+				ID:          newChildID(opts, senseID, "example", exIdx),
+				RefSenseID:  senseID,
+				Sentence:    sentence,
+				Translation: nil,
+				SourceSlug:  sourceSlug,
+				Position:    exIdx,
 			})
 		}
 	}
 
-	return result
+	// Pronunciations, deduplicated by transcription and canonical region.
+	seenSounds := make(map[string]int, len(pe.Sounds))
+	for sndIdx := range pe.Sounds {
+		snd := &pe.Sounds[sndIdx]
+
+		var region *string
+		if r := CanonicalRegion(snd.Region); r != "" {
+			region = &r
+		}
+
+		ipa := snd.IPA
+		if opts.NormalizeIPA {
+			ipa = NormalizeIPA(ipa, opts.BroadIPA)
+			if ipa == "" {
+				continue
+			}
+		}
+
+		var audioURL *string
+		if u := CommonsAudioURL(snd.AudioFile); u != "" {
+			audioURL = &u
+		}
+
+		key := ipa + "|"
+		if region != nil {
+			key += *region
+		}
+		if i, ok := seenSounds[key]; ok {
+			if result.Pronunciations[i].AudioURL == nil {
+				result.Pronunciations[i].AudioURL = audioURL
+			}
+			continue
+		}
+		seenSounds[key] = len(result.Pronunciations)
+
+		result.Pronunciations = append(result.Pronunciations, domain.RefPronunciation{
+			ID:            newChildID(opts, entryID, "pronunciation", len(seenSounds)-1),
+			RefEntryID:    entryID,
+			Transcription: &ipa,
+			AudioURL:      audioURL,
+			Region:        region,
+			SourceSlug:    sourceSlug,
+		})
+	}
 }
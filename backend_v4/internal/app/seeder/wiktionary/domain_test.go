@@ -1,6 +1,7 @@
 package wiktionary
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -8,7 +9,7 @@ import (
 )
 
 func TestToDomainEntries_EmptyInput(t *testing.T) {
-	result := ToDomainEntries(nil)
+	result := ToDomainEntries(nil, CleanOptions{})
 
 	if len(result.Entries) != 0 {
 		t.Errorf("Entries: got %d, want 0", len(result.Entries))
@@ -27,7 +28,7 @@ func TestToDomainEntries_EmptyInput(t *testing.T) {
 	}
 
 	// Also test empty slice.
-	result2 := ToDomainEntries([]ParsedEntry{})
+	result2 := ToDomainEntries([]ParsedEntry{}, CleanOptions{})
 	if len(result2.Entries) != 0 {
 		t.Errorf("Entries (empty slice): got %d, want 0", len(result2.Entries))
 	}
@@ -50,7 +51,7 @@ func TestToDomainEntries_SingleEntryOneSense(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	// One entry.
 	if len(result.Entries) != 1 {
@@ -125,7 +126,7 @@ func TestToDomainEntries_MultiplePOSGroups(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if len(result.Senses) != 3 {
 		t.Fatalf("Senses: got %d, want 3", len(result.Senses))
@@ -183,7 +184,7 @@ func TestToDomainEntries_TranslationsAndExamples(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	// Translations.
 	if len(result.Translations) != 2 {
@@ -257,7 +258,7 @@ func TestToDomainEntries_EmptyGlossesSkipped(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if len(result.Senses) != 1 {
 		t.Fatalf("Senses: got %d, want 1 (empty glosses should be skipped)", len(result.Senses))
@@ -288,7 +289,7 @@ func TestToDomainEntries_PronunciationsWithRegion(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if len(result.Pronunciations) != 2 {
 		t.Fatalf("Pronunciations: got %d, want 2", len(result.Pronunciations))
@@ -343,7 +344,7 @@ func TestToDomainEntries_PronunciationWithoutRegion(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if len(result.Pronunciations) != 1 {
 		t.Fatalf("Pronunciations: got %d, want 1", len(result.Pronunciations))
@@ -380,7 +381,7 @@ func TestToDomainEntries_SourceSlugConsistency(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	const want = "wiktionary"
 
@@ -419,7 +420,7 @@ func TestToDomainEntries_TextNormalized(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if result.Entries[0].Text != "  Hello  World  " {
 		t.Errorf("Text should be preserved as-is: got %q", result.Entries[0].Text)
@@ -449,7 +450,7 @@ func TestToDomainEntries_AllIDsNonZero(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if result.Entries[0].ID == uuid.Nil {
 		t.Error("Entry ID should not be zero")
@@ -492,7 +493,7 @@ func TestToDomainEntries_ForeignKeysCorrect(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	entryID := result.Entries[0].ID
 
@@ -554,7 +555,7 @@ func TestToDomainEntries_DefinitionUsesFirstGlossOnly(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if result.Senses[0].Definition != "to put in place" {
 		t.Errorf("Definition should use first gloss only: got %q, want %q",
@@ -577,7 +578,7 @@ func TestToDomainEntries_DefinitionMarkupStripped(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if result.Senses[0].Definition != "a formal examination" {
 		t.Errorf("Definition markup not stripped: got %q, want %q",
@@ -601,7 +602,7 @@ func TestToDomainEntries_MultipleEntries(t *testing.T) {
 		},
 	}
 
-	result := ToDomainEntries(entries)
+	result := ToDomainEntries(entries, CleanOptions{})
 
 	if len(result.Entries) != 2 {
 		t.Fatalf("Entries: got %d, want 2", len(result.Entries))
@@ -623,3 +624,231 @@ func TestToDomainEntries_MultipleEntries(t *testing.T) {
 		t.Error("Entries should have different IDs")
 	}
 }
+
+func TestToDomainEntries_CleanOptions(t *testing.T) {
+	entries := []ParsedEntry{
+		{
+			Word: "cat",
+			POSGroups: []POSGroup{
+				{
+					POS: "noun",
+					Senses: []ParsedSense{{
+						Glosses:  []string{"A small [[feline|felid]] {{lb|en|zoology}} kept as a pet."},
+						Examples: []string{"The [[cat]] {{q|happily}} slept."},
+					}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		opts        CleanOptions
+		wantDef     string
+		wantExample string
+	}{
+		{
+			name:        "strip",
+			opts:        CleanOptions{Markup: MarkupStrip},
+			wantDef:     "A small felid kept as a pet.",
+			wantExample: "The cat slept.",
+		},
+		{
+			name:        "links",
+			opts:        CleanOptions{Markup: MarkupLinks},
+			wantDef:     "A small felid (feline) kept as a pet.",
+			wantExample: "The cat slept.",
+		},
+		{
+			name:        "preserve",
+			opts:        CleanOptions{Markup: MarkupPreserve},
+			wantDef:     "A small [[feline|felid]] {{lb|en|zoology}} kept as a pet.",
+			wantExample: "The [[cat]] {{q|happily}} slept.",
+		},
+		{
+			name:        "max length",
+			opts:        CleanOptions{MaxDefinitionLen: 7},
+			wantDef:     "A small…",
+			wantExample: "The cat slept.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToDomainEntries(entries, tt.opts)
+			if len(result.Senses) != 1 || len(result.Examples) != 1 {
+				t.Fatalf("got %d senses, %d examples; want 1 and 1", len(result.Senses), len(result.Examples))
+			}
+			if got := result.Senses[0].Definition; got != tt.wantDef {
+				t.Errorf("Definition = %q, want %q", got, tt.wantDef)
+			}
+			if got := result.Examples[0].Sentence; got != tt.wantExample {
+				t.Errorf("Sentence = %q, want %q", got, tt.wantExample)
+			}
+		})
+	}
+}
+
+func TestToDomainEntries_NormalizeIPA(t *testing.T) {
+	entries := []ParsedEntry{
+		{
+			Word: "water",
+			POSGroups: []POSGroup{
+				{POS: "noun", Senses: []ParsedSense{{Glosses: []string{"a liquid"}}}},
+			},
+			Sounds: []Sound{{IPA: "/'wɔ:.tə/", Region: "UK"}, {IPA: "//"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts CleanOptions
+		want []string
+	}{
+		{"disabled keeps raw", CleanOptions{}, []string{"/'wɔ:.tə/", "//"}},
+		{"normalized", CleanOptions{NormalizeIPA: true}, []string{"ˈwɔː.tə"}},
+		{"broad", CleanOptions{NormalizeIPA: true, BroadIPA: true}, []string{"ˈwɔːtə"}},
+		{"broad without normalize keeps raw", CleanOptions{BroadIPA: true}, []string{"/'wɔ:.tə/", "//"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToDomainEntries(entries, tt.opts)
+			if len(result.Pronunciations) != len(tt.want) {
+				t.Fatalf("Pronunciations: got %d, want %d", len(result.Pronunciations), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if got := *result.Pronunciations[i].Transcription; got != want {
+					t.Errorf("Transcription[%d] = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestToDomainEntries_MinDefinitionLength(t *testing.T) {
+	entries := []ParsedEntry{
+		{
+			Word: "hi",
+			POSGroups: []POSGroup{
+				{POS: "interjection", Senses: []ParsedSense{
+					{Glosses: []string{"[[Hello]]."}},
+					{Glosses: []string{"A casual greeting."}},
+				}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		opts        CleanOptions
+		wantSenses  []string
+		wantSkipped int
+	}{
+		{"disabled keeps all", CleanOptions{}, []string{"Hello.", "A casual greeting."}, 0},
+		{"one-word gloss dropped", CleanOptions{MinDefinitionLength: 10}, []string{"A casual greeting."}, 1},
+		{"exact length kept", CleanOptions{MinDefinitionLength: 6}, []string{"Hello.", "A casual greeting."}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToDomainEntries(entries, tt.opts)
+			if len(result.Senses) != len(tt.wantSenses) {
+				t.Fatalf("Senses: got %d, want %d", len(result.Senses), len(tt.wantSenses))
+			}
+			for i, want := range tt.wantSenses {
+				if got := result.Senses[i].Definition; got != want {
+					t.Errorf("Definition[%d] = %q, want %q", i, got, want)
+				}
+				if result.Senses[i].Position != i {
+					t.Errorf("Position[%d] = %d, want %d", i, result.Senses[i].Position, i)
+				}
+			}
+			if result.SkippedShortDefinitions != tt.wantSkipped {
+				t.Errorf("SkippedShortDefinitions = %d, want %d", result.SkippedShortDefinitions, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestToDomainEntriesWithWorkers_SumsSkippedShortDefinitions(t *testing.T) {
+	entries := make([]ParsedEntry, 8)
+	for i := range entries {
+		entries[i] = ParsedEntry{
+			Word: fmt.Sprintf("word%d", i),
+			POSGroups: []POSGroup{
+				{POS: "noun", Senses: []ParsedSense{{Glosses: []string{"Stub."}}, {Glosses: []string{"A longer definition."}}}},
+			},
+		}
+	}
+
+	result := ToDomainEntriesWithWorkers(entries, 4, CleanOptions{MinDefinitionLength: 10})
+	if result.SkippedShortDefinitions != len(entries) {
+		t.Errorf("SkippedShortDefinitions = %d, want %d", result.SkippedShortDefinitions, len(entries))
+	}
+	if len(result.Senses) != len(entries) {
+		t.Errorf("Senses: got %d, want %d", len(result.Senses), len(entries))
+	}
+}
+
+func TestToDomainEntries_PronunciationRegionsCanonical(t *testing.T) {
+	entries := []ParsedEntry{
+		{
+			Word: "tomato",
+			POSGroups: []POSGroup{
+				{POS: "noun", Senses: []ParsedSense{{Glosses: []string{"a fruit"}}}},
+			},
+			Sounds: []Sound{
+				{IPA: "/təˈmeɪtoʊ/", Region: "General American"},
+				{IPA: "/təˈmeɪtoʊ/", Region: "US"},
+				{IPA: "/təˈmɑːtəʊ/", Region: "RP"},
+				{IPA: "/təˈmɑːtəʉ/", Region: "Cockney"},
+			},
+		},
+	}
+
+	result := ToDomainEntries(entries, CleanOptions{})
+
+	if len(result.Pronunciations) != 3 {
+		t.Fatalf("Pronunciations: got %d, want 3 (US synonyms deduplicated)", len(result.Pronunciations))
+	}
+	wantRegions := []string{"US", "UK", ""}
+	for i, want := range wantRegions {
+		got := ""
+		if r := result.Pronunciations[i].Region; r != nil {
+			got = *r
+		}
+		if got != want {
+			t.Errorf("Pronunciation[%d] Region = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestToDomainEntries_AudioURL(t *testing.T) {
+	entries := []ParsedEntry{
+		{
+			Word: "water",
+			POSGroups: []POSGroup{
+				{POS: "noun", Senses: []ParsedSense{{Glosses: []string{"a liquid"}}}},
+			},
+			Sounds: []Sound{
+				{IPA: "/ˈwɑtɚ/", Region: "US", AudioFile: "En-us-water.ogg"},
+				{IPA: "/ˈwɔːtə/", Region: "UK"},
+			},
+		},
+	}
+
+	result := ToDomainEntries(entries, CleanOptions{})
+
+	if len(result.Pronunciations) != 2 {
+		t.Fatalf("Pronunciations: got %d, want 2", len(result.Pronunciations))
+	}
+	us, uk := result.Pronunciations[0], result.Pronunciations[1]
+	want := "https://upload.wikimedia.org/wikipedia/commons/b/bd/En-us-water.ogg"
+	if us.AudioURL == nil || *us.AudioURL != want {
+		t.Errorf("US AudioURL = %v, want %s", us.AudioURL, want)
+	}
+	if uk.AudioURL != nil {
+		t.Errorf("UK AudioURL = %q, want nil", *uk.AudioURL)
+	}
+}
@@ -0,0 +1,52 @@
+package wiktionary
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ipaReplacer maps look-alike characters that Wiktionary editors use for
+// stress and length marks to the canonical IPA code points.
+var ipaReplacer = strings.NewReplacer(
+	"'", "ˈ", // apostrophe
+	"’", "ˈ", // right single quotation mark
+	"′", "ˈ", // prime
+	"ʹ", "ˈ", // modifier letter prime
+	"ˊ", "ˈ", // modifier letter acute accent
+	"ˏ", "ˌ", // modifier letter low acute accent
+	"͵", "ˌ", // Greek lower numeral sign
+	":", "ː", // colon as length mark
+	"g", "ɡ", // Latin g instead of IPA script g
+)
+
+// broadDrop lists narrow-transcription symbols removed in broad form:
+// syllable breaks, linking marks, optional-sound parentheses, aspiration and
+// the rhotic hook written as a separate modifier.
+var broadDrop = map[rune]bool{
+	'.': true, '‿': true, '(': true, ')': true, 'ʰ': true, '˞': true,
+}
+
+// NormalizeIPA brings a Wiktionary IPA transcription to a canonical form:
+// enclosing /slashes/ or [brackets] are removed, look-alike stress and length
+// characters become ˈ, ˌ and ː, and whitespace is collapsed. With broad set,
+// narrow detail (combining diacritics, syllable dots, parentheses,
+// aspiration) is dropped as well, leaving a broad phonemic string.
+func NormalizeIPA(s string, broad bool) string {
+	s = strings.TrimSpace(s)
+	if n := len(s); n >= 2 && (s[0] == '/' && s[n-1] == '/' || s[0] == '[' && s[n-1] == ']') {
+		s = strings.TrimSpace(s[1 : n-1])
+	}
+
+	s = ipaReplacer.Replace(s)
+
+	if broad {
+		s = strings.Map(func(r rune) rune {
+			if broadDrop[r] || unicode.Is(unicode.Mn, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+
+	return strings.Join(strings.Fields(s), " ")
+}
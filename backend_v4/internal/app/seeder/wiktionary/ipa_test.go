@@ -0,0 +1,44 @@
+package wiktionary
+
+import "testing"
+
+func TestNormalizeIPA(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		broad bool
+		want  string
+	}{
+		{"slashes", "/həˈləʊ/", false, "həˈləʊ"},
+		{"brackets", "[ˈkʰæt]", false, "ˈkʰæt"},
+		{"apostrophe stress", "/hə'ləʊ/", false, "həˈləʊ"},
+		{"prime stress", "/hə′ləʊ/", false, "həˈləʊ"},
+		{"curly quote stress", "/ˌɪn.fəɹ’meɪ.ʃən/", false, "ˌɪn.fəɹˈmeɪ.ʃən"},
+		{"colon length and latin g", "/ɡɑ:gəl/", false, "ɡɑːɡəl"},
+		{"surrounding and inner spaces", "  / ˈwɔː  tə / ", false, "ˈwɔː tə"},
+		{"unbalanced delimiters kept", "/kæt", false, "/kæt"},
+		{"only delimiters", "//", false, ""},
+		{"broad drops syllable dots", "/ˌɪn.fəˈmeɪ.ʃən/", true, "ˌɪnfəˈmeɪʃən"},
+		{"broad drops aspiration and diacritics", "[ˈkʰæ̃t̚]", true, "ˈkæt"},
+		{"broad drops optional sounds", "/ˈwɔː.tə(ɹ)/", true, "ˈwɔːtəɹ"},
+		{"broad keeps stress and length", "/hə'lə:ʊ/", true, "həˈləːʊ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeIPA(tt.in, tt.broad); got != tt.want {
+				t.Errorf("NormalizeIPA(%q, %v) = %q, want %q", tt.in, tt.broad, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeIPA_SourcesAgree(t *testing.T) {
+	variants := []string{"/ˈwɔːtə/", "[ˈwɔːtə]", "/'wɔ:tə/", " /ˈwɔː.tə/ "}
+	want := NormalizeIPA(variants[0], true)
+	for _, v := range variants[1:] {
+		if got := NormalizeIPA(v, true); got != want {
+			t.Errorf("NormalizeIPA(%q) = %q, want %q", v, got, want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package wiktionary
+
+import "strings"
+
+// Default language allowlists: English headwords with Russian translations.
+var (
+	DefaultHeadwordLanguages    = []string{"en"}
+	DefaultTranslationLanguages = []string{"ru"}
+)
+
+// LanguageFilter is the language allowlist applied while parsing. Entries
+// whose language is not in Headwords are skipped, and translations whose code
+// is not in Translations are dropped. Values are Wiktionary language codes
+// ("en", "ru"); an empty list falls back to the default.
+type LanguageFilter struct {
+	Headwords    []string
+	Translations []string
+}
+
+// langNameCodes maps Kaikki language names to codes for dumps that omit
+// lang_code on entries.
+var langNameCodes = map[string]string{
+	"English":   "en",
+	"Russian":   "ru",
+	"French":    "fr",
+	"German":    "de",
+	"Spanish":   "es",
+	"Italian":   "it",
+	"Ukrainian": "uk",
+}
+
+// languageSet is a compiled LanguageFilter.
+type languageSet struct {
+	headwords    map[string]bool
+	translations map[string]bool
+}
+
+func (f LanguageFilter) compile() languageSet {
+	return languageSet{
+		headwords:    codeSet(f.Headwords, DefaultHeadwordLanguages),
+		translations: codeSet(f.Translations, DefaultTranslationLanguages),
+	}
+}
+
+func codeSet(codes, defaults []string) map[string]bool {
+	if len(codes) == 0 {
+		codes = defaults
+	}
+	set := make(map[string]bool, len(codes))
+	for _, c := range codes {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			set[c] = true
+		}
+	}
+	return set
+}
+
+// allowsEntry reports whether the entry's headword language is allowed.
+func (s languageSet) allowsEntry(entry *kaikkiEntry) bool {
+	code := entry.LangCode
+	if code == "" {
+		code = langNameCodes[entry.Lang]
+	}
+	return s.headwords[strings.ToLower(code)]
+}
+
+// allowsTranslation reports whether a translation language code is allowed.
+func (s languageSet) allowsTranslation(code string) bool {
+	return s.translations[strings.ToLower(code)]
+}
@@ -0,0 +1,85 @@
+package wiktionary
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const multilingualDump = `{"word":"cat","pos":"noun","lang":"English","lang_code":"en","senses":[{"glosses":["a feline"],"translations":[{"code":"ru","word":"кошка"},{"code":"de","word":"Katze"},{"code":"fr","word":"chat"},{"code":"ru","word":"кот"}]}]}
+{"word":"chat","pos":"noun","lang":"French","lang_code":"fr","senses":[{"glosses":["cat"],"translations":[{"code":"ru","word":"кошка"}]}]}
+{"word":"кот","pos":"noun","lang":"Russian","lang_code":"ru","senses":[{"glosses":["tomcat"]}]}
+{"word":"dog","pos":"noun","lang":"English","senses":[{"glosses":["a canine"],"translations":[{"code":"ru","word":"собака"},{"code":"de","word":"Hund"}]}]}
+`
+
+func writeMultilingualDump(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "multi.jsonl")
+	if err := os.WriteFile(path, []byte(multilingualDump), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func translationsOf(entries []ParsedEntry) map[string][]string {
+	out := make(map[string][]string)
+	for _, e := range entries {
+		for _, pg := range e.POSGroups {
+			for _, s := range pg.Senses {
+				out[e.Word] = append(out[e.Word], s.Translations...)
+			}
+		}
+	}
+	return out
+}
+
+func TestParseWithWorkers_DefaultLanguages(t *testing.T) {
+	entries, stats, err := ParseWithWorkers(writeMultilingualDump(t), nil, 100, 1, LanguageFilter{})
+	if err != nil {
+		t.Fatalf("ParseWithWorkers: %v", err)
+	}
+
+	got := translationsOf(entries)
+	if len(got) != 2 {
+		t.Fatalf("entries = %v, want only English cat and dog", got)
+	}
+	if want := []string{"кошка", "кот"}; !slices.Equal(got["cat"], want) {
+		t.Errorf("cat translations = %v, want %v", got["cat"], want)
+	}
+	if want := []string{"собака"}; !slices.Equal(got["dog"], want) {
+		t.Errorf("dog translations = %v, want %v", got["dog"], want)
+	}
+
+	if stats.SkippedByLanguage != 2 {
+		t.Errorf("SkippedByLanguage = %d, want 2", stats.SkippedByLanguage)
+	}
+	if stats.TranslationsSkippedByLanguage != 3 {
+		t.Errorf("TranslationsSkippedByLanguage = %d, want 3", stats.TranslationsSkippedByLanguage)
+	}
+}
+
+func TestParseWithWorkers_ConfiguredLanguages(t *testing.T) {
+	langs := LanguageFilter{Headwords: []string{"en", "FR"}, Translations: []string{"de", " ru "}}
+	entries, stats, err := ParseWithWorkers(writeMultilingualDump(t), nil, 100, 1, langs)
+	if err != nil {
+		t.Fatalf("ParseWithWorkers: %v", err)
+	}
+
+	got := translationsOf(entries)
+	if want := []string{"кошка", "Katze", "кот"}; !slices.Equal(got["cat"], want) {
+		t.Errorf("cat translations = %v, want %v", got["cat"], want)
+	}
+	if want := []string{"собака", "Hund"}; !slices.Equal(got["dog"], want) {
+		t.Errorf("dog translations = %v, want %v", got["dog"], want)
+	}
+	if want := []string{"кошка"}; !slices.Equal(got["chat"], want) {
+		t.Errorf("chat translations = %v, want %v", got["chat"], want)
+	}
+	if _, ok := got["кот"]; ok {
+		t.Error("Russian headword should be skipped")
+	}
+	if stats.SkippedByLanguage != 1 || stats.TranslationsSkippedByLanguage != 1 {
+		t.Errorf("stats = %+v, want 1 entry and 1 translation skipped", stats)
+	}
+}
@@ -1,7 +1,6 @@
 package wiktionary
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -18,88 +17,217 @@ const (
 
 	// maxLineSize is the buffer size for bufio.Scanner (16 MB).
 	maxLineSize = 16 << 20
-
-	// maxDefinitionLen is the maximum length for definitions.
-	maxDefinitionLen = 5000
 )
 
 // Parse performs a two-pass parse of a Kaikki JSONL file.
 // Pass 1 scores entries and selects top N words.
 // Pass 2 fully parses only selected words.
 // coreWords is a set of NGSL/NAWL words guaranteed inclusion.
+// Only English headwords with Russian translations are kept.
 func Parse(filePath string, coreWords map[string]bool, topN int) ([]ParsedEntry, Stats, error) {
-	scores, stats, err := scoringPass(filePath, coreWords)
+	return ParseWithWorkers(filePath, coreWords, topN, 1, LanguageFilter{})
+}
+
+// ParseWithWorkers is Parse with JSON decoding spread over the given number
+// of goroutines and a configurable language allowlist. Results are merged in
+// file order, so the output is identical to the serial path for any worker
+// count. All entries are collected in memory; use Select and
+// Selection.Stream to process them one at a time instead.
+func ParseWithWorkers(filePath string, coreWords map[string]bool, topN, workers int, langs LanguageFilter) ([]ParsedEntry, Stats, error) {
+	sel, err := Select(filePath, coreWords, topN, workers, langs)
 	if err != nil {
-		return nil, stats, fmt.Errorf("scoring pass: %w", err)
+		return nil, Stats{}, err
 	}
 
-	if len(scores) == 0 {
-		return nil, stats, nil
+	var entries []ParsedEntry
+	stats, err := sel.Stream(func(e ParsedEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, stats, err
 	}
+	return entries, stats, nil
+}
 
-	selected := selectTopN(scores, coreWords, topN)
+// Selection is the outcome of the scoring pass: the words chosen for import
+// and, for each of them, the last line of the file that mentions it.
+type Selection struct {
+	filePath string
+	workers  int
+	allowed  languageSet
+
+	lastLine   map[string]int            // selected word → index of its last line
+	unknownPOS map[string]map[string]int // selected word → tag → lines
+	stats      Stats
+}
 
-	entries, err := parsingPass(filePath, selected)
+// Select runs the scoring pass over a Kaikki JSONL file and picks the top N
+// words, core words first. The file is read once and only per-word scores
+// and line numbers are kept.
+func Select(filePath string, coreWords map[string]bool, topN, workers int, langs LanguageFilter) (*Selection, error) {
+	allowed := langs.compile()
+
+	sc, stats, err := scoringPass(filePath, coreWords, workers, allowed)
 	if err != nil {
-		return nil, stats, fmt.Errorf("parsing pass: %w", err)
+		return nil, fmt.Errorf("scoring pass: %w", err)
 	}
 
-	stats.EntriesParsed = len(entries)
-	return entries, stats, nil
+	sel := &Selection{
+		filePath:   filePath,
+		workers:    workers,
+		allowed:    allowed,
+		lastLine:   make(map[string]int),
+		unknownPOS: make(map[string]map[string]int),
+		stats:      stats,
+	}
+	for w := range selectTopN(sc.scores, coreWords, topN) {
+		sel.lastLine[w] = sc.lastLine[w]
+		if tags, ok := sc.unknownPOS[w]; ok {
+			sel.unknownPOS[w] = tags
+		}
+	}
+	return sel, nil
+}
+
+// Len returns the number of selected words, which is the number of entries
+// Stream yields.
+func (s *Selection) Len() int {
+	return len(s.lastLine)
+}
+
+// UnmappedPOS counts the lines of selected words per lowercase POS tag that
+// neither m nor the built-in mapping knows; their senses are stored as
+// PartOfSpeechOther. Each line becomes one POSGroup, so the counts match the
+// POS groups Stream yields. Returns nil when every non-empty tag is mapped.
+func (s *Selection) UnmappedPOS(m POSMap) map[string]int {
+	var counts map[string]int
+	for _, tags := range s.unknownPOS {
+		for tag, n := range tags {
+			if _, ok := m.Lookup(tag); ok {
+				continue
+			}
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+			counts[tag] += n
+		}
+	}
+	return counts
 }
 
-// scoringPass streams the JSONL file, scoring each English entry.
-// Returns cumulative scores per normalized word and parse statistics.
-func scoringPass(filePath string, coreWords map[string]bool) (map[string]float64, Stats, error) {
+// Stream re-reads the file and calls fn with the entry of each selected word
+// as soon as its last line has been read, merging the word's lines as Parse
+// does. Entries come in the order of their last lines, which is the same on
+// every run over the same file. Only words whose lines are still ahead are
+// held in memory; in a dump grouped by word that is a single entry. An error
+// from fn stops the stream and is returned as is.
+func (s *Selection) Stream(fn func(ParsedEntry) error) (Stats, error) {
+	stats := s.stats
+	if len(s.lastLine) == 0 {
+		return stats, nil
+	}
+
+	skippedTranslations, err := parsingPass(s.filePath, s.lastLine, s.workers, s.allowed, func(e ParsedEntry) error {
+		stats.EntriesParsed++
+		return fn(e)
+	})
+	stats.TranslationsSkippedByLanguage = skippedTranslations
+	return stats, err
+}
+
+// scoredLine is the per-line outcome of the scoring pass.
+type scoredLine struct {
+	malformed bool
+	english   bool // headword language is allowed
+	word      string
+	pos       string
+	score     float64
+}
+
+// scoring is what the scoring pass keeps per normalized word.
+type scoring struct {
+	scores   map[string]float64
+	lastLine map[string]int
+	// unknownPOS counts lines per lowercase tag missing from the built-in
+	// POS mapping. Such tags are rare, so the map stays small.
+	unknownPOS map[string]map[string]int
+}
+
+// scoringPass streams the JSONL file, scoring each entry in an allowed
+// headword language. Returns cumulative scores, the last line and the lines
+// with unknown POS tags per normalized word, and parse statistics.
+func scoringPass(filePath string, coreWords map[string]bool, workers int, allowed languageSet) (scoring, Stats, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
-		return nil, Stats{}, fmt.Errorf("open file: %w", err)
+		return scoring{}, Stats{}, fmt.Errorf("open file: %w", err)
 	}
 	defer f.Close()
 
-	scores := make(map[string]float64)
+	sc := scoring{
+		scores:     make(map[string]float64),
+		lastLine:   make(map[string]int),
+		unknownPOS: make(map[string]map[string]int),
+	}
 	var stats Stats
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, maxLineSize), maxLineSize)
-
-	for scanner.Scan() {
-		stats.TotalLines++
-		line := scanner.Bytes()
-
+	decode := func(line []byte) scoredLine {
 		var entry kaikkiEntry
 		if err := json.Unmarshal(line, &entry); err != nil {
-			stats.MalformedLines++
-			continue
+			return scoredLine{malformed: true}
+		}
+		if !allowed.allowsEntry(&entry) {
+			return scoredLine{}
 		}
+		return scoredLine{
+			english: true,
+			word:    domain.NormalizeText(entry.Word),
+			pos:     strings.ToLower(entry.POS),
+			score:   ScoreEntry(&entry),
+		}
+	}
 
-		if entry.Lang != "English" {
-			continue
+	// Scores are summed in file order so float rounding does not depend on
+	// the worker count.
+	emit := func(sl scoredLine) error {
+		line := stats.TotalLines
+		stats.TotalLines++
+		if sl.malformed {
+			stats.MalformedLines++
+			return nil
+		}
+		if !sl.english {
+			stats.SkippedByLanguage++
+			return nil
 		}
 		stats.EnglishLines++
-
-		word := domain.NormalizeText(entry.Word)
-		if word == "" {
-			continue
+		if sl.word == "" {
+			return nil
 		}
-
-		score := ScoreEntry(&entry)
-		scores[word] += score
+		sc.scores[sl.word] += sl.score
+		sc.lastLine[sl.word] = line
+		if _, known := posMap[sl.pos]; sl.pos != "" && !known {
+			if sc.unknownPOS[sl.word] == nil {
+				sc.unknownPOS[sl.word] = make(map[string]int)
+			}
+			sc.unknownPOS[sl.word][sl.pos]++
+		}
+		return nil
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, stats, fmt.Errorf("scanner error: %w", err)
+	if err := decodeLines(f, workers, decode, emit); err != nil {
+		return scoring{}, stats, fmt.Errorf("scanner error: %w", err)
 	}
 
 	// Apply core word bonus.
 	for w := range coreWords {
 		normalized := domain.NormalizeText(w)
-		if _, ok := scores[normalized]; ok {
-			scores[normalized] += coreWordBonus
+		if _, ok := sc.scores[normalized]; ok {
+			sc.scores[normalized] += coreWordBonus
 		}
 	}
 
-	return scores, stats, nil
+	return sc, stats, nil
 }
 
 // selectTopN picks the top N words by score. Core words are guaranteed
@@ -118,8 +246,12 @@ func selectTopN(scores map[string]float64, coreWords map[string]bool, topN int)
 	for w, s := range scores {
 		sorted = append(sorted, wordScore{w, s})
 	}
+	// Ties are broken by word so the selection does not depend on map order.
 	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].score > sorted[j].score
+		if sorted[i].score != sorted[j].score {
+			return sorted[i].score > sorted[j].score
+		}
+		return sorted[i].word < sorted[j].word
 	})
 
 	selected := make(map[string]bool, topN)
@@ -143,66 +275,109 @@ func selectTopN(scores map[string]float64, coreWords map[string]bool, topN int)
 	return selected
 }
 
-// parsingPass re-streams the file, fully parsing only entries for selected words.
-// Entries with the same normalized word are merged (POS groups and sounds combined).
-func parsingPass(filePath string, selected map[string]bool) ([]ParsedEntry, error) {
+// parsedLine is the per-line outcome of the parsing pass.
+type parsedLine struct {
+	ok     bool
+	word   string
+	raw    string
+	pg     POSGroup
+	sounds []Sound
+
+	skippedTranslations int
+}
+
+// parsingPass re-streams the file, fully parsing only entries for the words
+// in lastLine. Lines with the same normalized word are merged (POS groups and
+// sounds combined) and the entry is passed to fn once the word's last line,
+// as recorded by the scoring pass, has been read. It also returns how many
+// translations were dropped by the language filter.
+func parsingPass(filePath string, lastLine map[string]int, workers int, allowed languageSet, fn func(ParsedEntry) error) (int, error) {
 	f, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("open file: %w", err)
+		return 0, fmt.Errorf("open file: %w", err)
 	}
 	defer f.Close()
 
-	// Map from normalized word to index in entries slice.
-	entryIndex := make(map[string]int)
-	var entries []ParsedEntry
-
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, maxLineSize), maxLineSize)
-
-	for scanner.Scan() {
-		line := scanner.Bytes()
+	// Entries whose last line has not been read yet.
+	pending := make(map[string]*ParsedEntry)
+	line := 0
+	skippedTranslations := 0
+	var fnErr error
 
+	// lastLine is only read here, so sharing it across workers is safe.
+	decode := func(line []byte) parsedLine {
 		var entry kaikkiEntry
 		if err := json.Unmarshal(line, &entry); err != nil {
-			continue
+			return parsedLine{}
 		}
 
-		if entry.Lang != "English" {
-			continue
+		if !allowed.allowsEntry(&entry) {
+			return parsedLine{}
 		}
 
 		word := domain.NormalizeText(entry.Word)
-		if !selected[word] {
-			continue
+		if _, ok := lastLine[word]; !ok {
+			return parsedLine{}
 		}
 
-		pg := buildPOSGroup(&entry)
-		sounds := buildSounds(&entry)
+		pg, skipped := buildPOSGroup(&entry, allowed)
+		return parsedLine{
+			ok:                  true,
+			word:                word,
+			raw:                 entry.Word,
+			pg:                  pg,
+			sounds:              buildSounds(&entry),
+			skippedTranslations: skipped,
+		}
+	}
 
-		idx, exists := entryIndex[word]
+	emit := func(pl parsedLine) error {
+		n := line
+		line++
+		if !pl.ok {
+			return nil
+		}
+		skippedTranslations += pl.skippedTranslations
+
+		pe, exists := pending[pl.word]
 		if !exists {
-			entryIndex[word] = len(entries)
-			entries = append(entries, ParsedEntry{
-				Word:      entry.Word,
-				POSGroups: []POSGroup{pg},
-				Sounds:    sounds,
-			})
+			pe = &ParsedEntry{Word: pl.raw, POSGroups: []POSGroup{pl.pg}, Sounds: pl.sounds}
+			pending[pl.word] = pe
 		} else {
-			entries[idx].POSGroups = append(entries[idx].POSGroups, pg)
-			entries[idx].Sounds = mergeSounds(entries[idx].Sounds, sounds)
+			pe.POSGroups = append(pe.POSGroups, pl.pg)
+			pe.Sounds = mergeSounds(pe.Sounds, pl.sounds)
+		}
+
+		if n != lastLine[pl.word] {
+			return nil
 		}
+		delete(pending, pl.word)
+		fnErr = fn(*pe)
+		return fnErr
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scanner error: %w", err)
+	if err := decodeLines(f, workers, decode, emit); err != nil {
+		if fnErr != nil {
+			return skippedTranslations, fnErr
+		}
+		return skippedTranslations, fmt.Errorf("scanner error: %w", err)
 	}
 
-	return entries, nil
+	// Every selected word ends on a line the scoring pass saw; leftovers mean
+	// the file changed between the passes.
+	if len(pending) > 0 {
+		return skippedTranslations, fmt.Errorf("%d selected words not completed: file changed after the scoring pass", len(pending))
+	}
+
+	return skippedTranslations, nil
 }
 
-// buildPOSGroup extracts senses from a Kaikki entry into a POSGroup.
-func buildPOSGroup(entry *kaikkiEntry) POSGroup {
+// buildPOSGroup extracts senses from a Kaikki entry into a POSGroup, keeping
+// only translations in an allowed language. It returns the number of
+// translations dropped by the language filter.
+func buildPOSGroup(entry *kaikkiEntry, allowed languageSet) (POSGroup, int) {
 	pg := POSGroup{POS: entry.POS}
+	skipped := 0
 
 	for i := range entry.Senses {
 		ks := &entry.Senses[i]
@@ -214,9 +389,10 @@ func buildPOSGroup(entry *kaikkiEntry) POSGroup {
 
 		ps := ParsedSense{}
 
-		// Clean glosses.
+		// Glosses and examples lose only HTML here; wiki links and templates
+		// are left for ToDomainEntries to clean according to CleanOptions.
 		for _, g := range ks.Glosses {
-			cleaned := TruncateDefinition(StripMarkup(g), maxDefinitionLen)
+			cleaned := CleanText(g, MarkupPreserve)
 			if cleaned != "" {
 				ps.Glosses = append(ps.Glosses, cleaned)
 			}
@@ -225,75 +401,96 @@ func buildPOSGroup(entry *kaikkiEntry) POSGroup {
 			continue
 		}
 
-		// Clean examples.
 		for _, ex := range ks.Examples {
-			cleaned := StripMarkup(ex.Text)
+			cleaned := CleanText(ex.Text, MarkupPreserve)
 			if cleaned != "" {
 				ps.Examples = append(ps.Examples, cleaned)
 			}
 		}
 
-		// Collect Russian translations only, deduplicated.
-		var ruTranslations []string
+		// Collect translations in allowed languages, deduplicated.
+		var translations []string
 		for _, tr := range ks.Translations {
-			if tr.Code == "ru" && tr.Word != "" {
-				ruTranslations = append(ruTranslations, tr.Word)
+			if tr.Word == "" {
+				continue
+			}
+			if !allowed.allowsTranslation(tr.Code) {
+				skipped++
+				continue
 			}
+			translations = append(translations, tr.Word)
 		}
-		ps.Translations = DeduplicateStrings(ruTranslations)
+		ps.Translations = DeduplicateStrings(translations)
 
 		pg.Senses = append(pg.Senses, ps)
 	}
 
-	return pg
+	return pg, skipped
 }
 
 // buildSounds extracts IPA pronunciations from a Kaikki entry.
 // Only phonemic transcriptions (wrapped in /slashes/) are kept;
-// phonetic/allophonic variants in [brackets] are skipped.
+// phonetic/allophonic variants in [brackets] are skipped. Audio-only sound
+// entries are attached to a transcription of the same region (see
+// attachAudio); recordings without a matching transcription are dropped.
 func buildSounds(entry *kaikkiEntry) []Sound {
-	var sounds []Sound
+	var sounds, recordings []Sound
 	for _, ks := range entry.Sounds {
-		if ks.IPA == "" {
-			continue
+		region := extractRegion(ks.Tags)
+		switch {
+		case strings.HasPrefix(ks.IPA, "/"):
+			// Keep only phonemic transcriptions in /slashes/.
+			sounds = append(sounds, Sound{IPA: ks.IPA, Region: region, AudioFile: ks.Audio})
+		case ks.IPA == "" && ks.Audio != "":
+			recordings = append(recordings, Sound{Region: region, AudioFile: ks.Audio})
 		}
-		// Keep only phonemic transcriptions in /slashes/.
-		if !strings.HasPrefix(ks.IPA, "/") {
-			continue
+	}
+	return attachAudio(sounds, recordings)
+}
+
+// attachAudio gives each recording to the first transcription without audio
+// in the same region. A recording without a region goes to the first
+// transcription without audio.
+func attachAudio(sounds, recordings []Sound) []Sound {
+	for _, rec := range recordings {
+		for i := range sounds {
+			if sounds[i].AudioFile == "" && (rec.Region == "" || sounds[i].Region == rec.Region) {
+				sounds[i].AudioFile = rec.AudioFile
+				break
+			}
 		}
-		sounds = append(sounds, Sound{
-			IPA:    ks.IPA,
-			Region: extractRegion(ks.Tags),
-		})
 	}
 	return sounds
 }
 
-// extractRegion parses sound tags to determine US/UK region.
+// extractRegion returns the canonical region of the first sound tag that
+// names one (see CanonicalRegion), or "" if none does.
 func extractRegion(tags []string) string {
 	for _, tag := range tags {
-		switch {
-		case tag == "US" || tag == "General-American" || strings.Contains(tag, "GenAm"):
-			return "US"
-		case tag == "UK" || tag == "Received-Pronunciation" || strings.Contains(tag, "RP"):
-			return "UK"
+		if region := CanonicalRegion(tag); region != "" {
+			return region
 		}
 	}
 	return ""
 }
 
-// mergeSounds combines two sound slices, deduplicating by IPA+Region.
+// mergeSounds combines two sound slices, deduplicating by IPA+Region. A
+// duplicate still contributes its recording if the kept sound has none.
 func mergeSounds(existing, new []Sound) []Sound {
-	seen := make(map[string]bool, len(existing))
-	for _, s := range existing {
-		seen[s.IPA+"|"+s.Region] = true
+	seen := make(map[string]int, len(existing))
+	for i, s := range existing {
+		seen[s.IPA+"|"+s.Region] = i
 	}
 	for _, s := range new {
 		key := s.IPA + "|" + s.Region
-		if !seen[key] {
-			existing = append(existing, s)
-			seen[key] = true
+		if i, ok := seen[key]; ok {
+			if existing[i].AudioFile == "" {
+				existing[i].AudioFile = s.AudioFile
+			}
+			continue
 		}
+		existing = append(existing, s)
+		seen[key] = len(existing) - 1
 	}
 	return existing
 }
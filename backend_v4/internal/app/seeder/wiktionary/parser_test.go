@@ -1,10 +1,14 @@
 package wiktionary
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"testing"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
 func testdataPath(t *testing.T, name string) string {
@@ -22,10 +26,11 @@ func TestScoringPass(t *testing.T) {
 	path := testdataPath(t, "sample.jsonl")
 	coreWords := map[string]bool{"water": true}
 
-	scores, stats, err := scoringPass(path, coreWords)
+	sc, stats, err := scoringPass(path, coreWords, 1, LanguageFilter{}.compile())
 	if err != nil {
 		t.Fatalf("scoringPass returned error: %v", err)
 	}
+	scores := sc.scores
 
 	// Stats checks.
 	if stats.TotalLines != 10 {
@@ -123,9 +128,18 @@ func TestSelectTopN_EmptyScores(t *testing.T) {
 
 func TestParsingPass(t *testing.T) {
 	path := testdataPath(t, "sample.jsonl")
-	selected := map[string]bool{"run": true, "house": true}
+	allowed := LanguageFilter{}.compile()
+	sc, _, err := scoringPass(path, nil, 1, allowed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lastLine := map[string]int{"run": sc.lastLine["run"], "house": sc.lastLine["house"]}
 
-	entries, err := parsingPass(path, selected)
+	var entries []ParsedEntry
+	_, err = parsingPass(path, lastLine, 1, allowed, func(e ParsedEntry) error {
+		entries = append(entries, e)
+		return nil
+	})
 	if err != nil {
 		t.Fatalf("parsingPass returned error: %v", err)
 	}
@@ -271,6 +285,110 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestSelection_StreamMergesScatteredLines(t *testing.T) {
+	data := `{"word":"run","pos":"verb","lang":"English","senses":[{"glosses":["to move fast"]}]}
+{"word":"cat","pos":"noun","lang":"English","senses":[{"glosses":["a feline"]}]}
+{"word":"Run","pos":"noun","lang":"English","senses":[{"glosses":["an act of running"]}]}
+{"word":"dog","pos":"noun","lang":"English","senses":[{"glosses":["a canine"]}]}
+`
+	path := filepath.Join(t.TempDir(), "dump.jsonl")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{1, 4} {
+		sel, err := Select(path, nil, 10, workers, LanguageFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sel.Len() != 3 {
+			t.Fatalf("Len = %d, want 3", sel.Len())
+		}
+
+		var words []string
+		var run ParsedEntry
+		stats, err := sel.Stream(func(e ParsedEntry) error {
+			words = append(words, e.Word)
+			if e.Word == "run" {
+				run = e
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// "run" is complete only after its second line, so "cat" comes first.
+		if want := []string{"cat", "run", "dog"}; !reflect.DeepEqual(words, want) {
+			t.Errorf("workers=%d: order = %v, want %v", workers, words, want)
+		}
+		if len(run.POSGroups) != 2 {
+			t.Errorf("workers=%d: run POS groups = %d, want 2", workers, len(run.POSGroups))
+		}
+		if stats.EntriesParsed != 3 {
+			t.Errorf("workers=%d: EntriesParsed = %d, want 3", workers, stats.EntriesParsed)
+		}
+	}
+}
+
+func TestSelection_StreamStopsOnError(t *testing.T) {
+	path := writeSyntheticDump(t, 2000)
+	stop := errors.New("stop")
+
+	for _, workers := range []int{1, 4} {
+		sel, err := Select(path, nil, 200, workers, LanguageFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		calls := 0
+		_, err = sel.Stream(func(ParsedEntry) error {
+			calls++
+			if calls == 3 {
+				return stop
+			}
+			return nil
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("workers=%d: err = %v, want the callback error", workers, err)
+		}
+		if calls != 3 {
+			t.Errorf("workers=%d: callback ran %d times after the error, want 3", workers, calls)
+		}
+	}
+}
+
+func TestSelection_UnmappedPOS(t *testing.T) {
+	data := `{"word":"to","pos":"postp","lang":"English","senses":[{"glosses":["a postposition"]}]}
+{"word":"to","pos":"prep","lang":"English","senses":[{"glosses":["toward"]}]}
+{"word":"of","pos":"Postp","lang":"English","senses":[{"glosses":["another postposition"]}]}
+`
+	path := filepath.Join(t.TempDir(), "dump.jsonl")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sel, err := Select(path, nil, 10, 1, LanguageFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sel.UnmappedPOS(nil); len(got) != 1 || got["postp"] != 2 {
+		t.Errorf("UnmappedPOS: got %v, want map[postp:2]", got)
+	}
+	mapped := POSMap{"postp": domain.PartOfSpeechPreposition}
+	if got := sel.UnmappedPOS(mapped); len(got) != 0 {
+		t.Errorf("UnmappedPOS with override: got %v, want none", got)
+	}
+
+	entries, _, err := Parse(path, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := ToDomainEntries(entries, CleanOptions{})
+	if *result.Senses[0].PartOfSpeech != domain.PartOfSpeechOther {
+		t.Errorf("unmapped sense POS: got %q, want OTHER", *result.Senses[0].PartOfSpeech)
+	}
+}
+
 func TestParse_FileNotFound(t *testing.T) {
 	_, _, err := Parse("/nonexistent/file.jsonl", nil, 100)
 	if err == nil {
@@ -309,6 +427,9 @@ func TestExtractRegion(t *testing.T) {
 		{"UK tag", []string{"UK"}, "UK"},
 		{"General-American", []string{"General-American"}, "US"},
 		{"Received-Pronunciation", []string{"Received-Pronunciation"}, "UK"},
+		{"GA", []string{"GA"}, "US"},
+		{"Australia", []string{"Australia"}, "AU"},
+		{"unknown accent", []string{"Cockney"}, ""},
 		{"US in mixed tags", []string{"phoneme", "US", "standard"}, "US"},
 		{"UK in mixed tags", []string{"UK", "phoneme"}, "UK"},
 		{"no region tags", []string{"phoneme", "standard"}, ""},
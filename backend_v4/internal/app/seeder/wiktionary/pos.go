@@ -1,6 +1,7 @@
 package wiktionary
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -47,8 +48,53 @@ var posMap = map[string]domain.PartOfSpeech{
 // MapPOS converts a Wiktionary/Kaikki POS string to the domain PartOfSpeech enum.
 // The lookup is case-insensitive. Unknown or empty values map to PartOfSpeechOther.
 func MapPOS(wiktionaryPOS string) domain.PartOfSpeech {
-	if pos, ok := posMap[strings.ToLower(wiktionaryPOS)]; ok {
+	return POSMap(nil).Map(wiktionaryPOS)
+}
+
+// LookupPOS is MapPOS without the fallback: ok is false for strings that are
+// not known Wiktionary/Kaikki POS tags.
+func LookupPOS(wiktionaryPOS string) (domain.PartOfSpeech, bool) {
+	return POSMap(nil).Lookup(wiktionaryPOS)
+}
+
+// POSMap holds POS tag mappings, keyed by lowercase tag, that take precedence
+// over the built-in Wiktionary/Kaikki mapping, e.g. for a dataset tagging
+// nouns as "n". A nil POSMap is the built-in mapping alone.
+type POSMap map[string]domain.PartOfSpeech
+
+// NewPOSMap builds a POSMap from config-style tag → part-of-speech strings,
+// such as {"n": "NOUN"}. Tags and values are case-insensitive; values must
+// name a domain.PartOfSpeech.
+func NewPOSMap(raw map[string]string) (POSMap, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	m := make(POSMap, len(raw))
+	for tag, value := range raw {
+		pos := domain.PartOfSpeech(strings.ToUpper(strings.TrimSpace(value)))
+		if !pos.IsValid() {
+			return nil, fmt.Errorf("pos mapping %q: unknown part of speech %q", tag, value)
+		}
+		m[strings.ToLower(strings.TrimSpace(tag))] = pos
+	}
+	return m, nil
+}
+
+// Map converts tag like MapPOS, consulting m before the built-in mapping.
+func (m POSMap) Map(tag string) domain.PartOfSpeech {
+	if pos, ok := m.Lookup(tag); ok {
 		return pos
 	}
 	return domain.PartOfSpeechOther
 }
+
+// Lookup is Map without the fallback: ok is false for tags known to neither
+// m nor the built-in mapping.
+func (m POSMap) Lookup(tag string) (domain.PartOfSpeech, bool) {
+	key := strings.ToLower(tag)
+	if pos, ok := m[key]; ok {
+		return pos, true
+	}
+	pos, ok := posMap[key]
+	return pos, ok
+}
@@ -79,3 +79,49 @@ func TestMapPOS(t *testing.T) {
 		})
 	}
 }
+
+func TestLookupPOS(t *testing.T) {
+	if pos, ok := LookupPOS("Verb"); !ok || pos != domain.PartOfSpeechVerb {
+		t.Errorf("LookupPOS(Verb) = %q, %v; want VERB, true", pos, ok)
+	}
+	if pos, ok := LookupPOS("suffix"); !ok || pos != domain.PartOfSpeechOther {
+		t.Errorf("LookupPOS(suffix) = %q, %v; want OTHER, true", pos, ok)
+	}
+	if _, ok := LookupPOS("banana"); ok {
+		t.Error("LookupPOS(banana) should not be ok")
+	}
+}
+
+func TestPOSMap_OverrideTakesPrecedence(t *testing.T) {
+	m, err := NewPOSMap(map[string]string{"n": "noun", "Name": "OTHER"})
+	if err != nil {
+		t.Fatalf("NewPOSMap: %v", err)
+	}
+
+	tests := []struct {
+		tag  string
+		want domain.PartOfSpeech
+	}{
+		{"n", domain.PartOfSpeechNoun},        // new tag
+		{"NAME", domain.PartOfSpeechOther},    // overrides built-in NOUN
+		{"verb", domain.PartOfSpeechVerb},     // built-in fallback
+		{"unknown", domain.PartOfSpeechOther}, // unknown everywhere
+	}
+	for _, tt := range tests {
+		if got := m.Map(tt.tag); got != tt.want {
+			t.Errorf("Map(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+	if MapPOS("name") != domain.PartOfSpeechNoun {
+		t.Error("overrides must not change the built-in MapPOS")
+	}
+}
+
+func TestNewPOSMap_RejectsUnknownPartOfSpeech(t *testing.T) {
+	if _, err := NewPOSMap(map[string]string{"n": "NOUNISH"}); err == nil {
+		t.Error("expected error for an unknown part of speech")
+	}
+	if m, err := NewPOSMap(nil); err != nil || m != nil {
+		t.Errorf("NewPOSMap(nil) = %v, %v; want nil, nil", m, err)
+	}
+}
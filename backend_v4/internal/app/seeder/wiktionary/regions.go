@@ -0,0 +1,69 @@
+package wiktionary
+
+import "strings"
+
+// Canonical pronunciation regions stored in ref_pronunciations.region.
+const (
+	RegionUS = "US"
+	RegionUK = "UK"
+	RegionAU = "AU"
+	RegionCA = "CA"
+	RegionNZ = "NZ"
+	RegionIE = "IE"
+	RegionZA = "ZA"
+	RegionIN = "IN"
+)
+
+// regionAliases maps Wiktionary sound tags and free-text accent labels to a
+// canonical region. Keys are lower-case with '-' and '_' replaced by spaces.
+var regionAliases = map[string]string{
+	"us":                        RegionUS,
+	"usa":                       RegionUS,
+	"american":                  RegionUS,
+	"america":                   RegionUS,
+	"ga":                        RegionUS,
+	"genam":                     RegionUS,
+	"general american":          RegionUS,
+	"general american english":  RegionUS,
+	"uk":                        RegionUK,
+	"gb":                        RegionUK,
+	"british":                   RegionUK,
+	"britain":                   RegionUK,
+	"england":                   RegionUK,
+	"rp":                        RegionUK,
+	"received pronunciation":    RegionUK,
+	"ssb":                       RegionUK,
+	"standard southern british": RegionUK,
+	"scotland":                  RegionUK,
+	"scottish":                  RegionUK,
+	"wales":                     RegionUK,
+	"welsh":                     RegionUK,
+	"au":                        RegionAU,
+	"australia":                 RegionAU,
+	"australian":                RegionAU,
+	"general australian":        RegionAU,
+	"ca":                        RegionCA,
+	"canada":                    RegionCA,
+	"canadian":                  RegionCA,
+	"nz":                        RegionNZ,
+	"new zealand":               RegionNZ,
+	"ie":                        RegionIE,
+	"ireland":                   RegionIE,
+	"irish":                     RegionIE,
+	"za":                        RegionZA,
+	"south africa":              RegionZA,
+	"south african":             RegionZA,
+	"in":                        RegionIN,
+	"india":                     RegionIN,
+	"indian":                    RegionIN,
+}
+
+var regionKeyReplacer = strings.NewReplacer("-", " ", "_", " ")
+
+// CanonicalRegion maps a region tag or label ("GA", "General-American",
+// "Received Pronunciation") to one of the Region* codes. Unknown labels
+// return "", which is stored as a NULL region.
+func CanonicalRegion(label string) string {
+	key := strings.ToLower(regionKeyReplacer.Replace(strings.TrimSpace(label)))
+	return regionAliases[strings.Join(strings.Fields(key), " ")]
+}
@@ -0,0 +1,34 @@
+package wiktionary
+
+import "testing"
+
+func TestCanonicalRegion(t *testing.T) {
+	tests := []struct {
+		want   string
+		labels []string
+	}{
+		{RegionUS, []string{"US", "us", "GA", "GenAm", "General-American", "General American", " general_american "}},
+		{RegionUK, []string{"UK", "GB", "RP", "Received-Pronunciation", "received pronunciation", "British", "Standard-Southern-British"}},
+		{RegionAU, []string{"AU", "Australia", "General-Australian"}},
+		{RegionCA, []string{"CA", "Canada", "Canadian"}},
+		{RegionNZ, []string{"NZ", "New-Zealand", "new  zealand"}},
+		{RegionIE, []string{"Ireland", "Irish"}},
+		{"", []string{"", "phoneme", "Cockney", "Martian"}},
+	}
+
+	for _, tt := range tests {
+		for _, label := range tt.labels {
+			if got := CanonicalRegion(label); got != tt.want {
+				t.Errorf("CanonicalRegion(%q) = %q, want %q", label, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestCanonicalRegion_Idempotent(t *testing.T) {
+	for _, code := range []string{RegionUS, RegionUK, RegionAU, RegionCA, RegionNZ, RegionIE, RegionZA, RegionIN} {
+		if got := CanonicalRegion(code); got != code {
+			t.Errorf("CanonicalRegion(%q) = %q, want it unchanged", code, got)
+		}
+	}
+}
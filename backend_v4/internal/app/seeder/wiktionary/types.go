@@ -24,27 +24,36 @@ type ParsedSense struct {
 	Translations []string // Russian only
 }
 
-// Sound holds a single IPA pronunciation with optional region.
+// Sound holds a single IPA pronunciation with optional region and the
+// Commons file name of a matching recording.
 type Sound struct {
-	IPA    string
-	Region string // "US", "UK", or ""
+	IPA       string
+	Region    string // canonical region code (see CanonicalRegion) or ""
+	AudioFile string // e.g. "En-us-water.ogg"; "" when there is no recording
 }
 
 // Stats holds parser statistics for logging.
 type Stats struct {
-	TotalLines    int
+	TotalLines     int
 	MalformedLines int
-	EnglishLines  int
-	EntriesParsed int
+	EnglishLines   int // lines in an allowed headword language
+	EntriesParsed  int
+
+	// SkippedByLanguage counts lines whose headword language is not allowed.
+	SkippedByLanguage int
+	// TranslationsSkippedByLanguage counts translations of selected words
+	// dropped because their language is not allowed.
+	TranslationsSkippedByLanguage int
 }
 
 // kaikkiEntry mirrors the Kaikki JSONL structure (only fields we need).
 type kaikkiEntry struct {
-	Word   string        `json:"word"`
-	POS    string        `json:"pos"`
-	Lang   string        `json:"lang"`
-	Senses []kaikkiSense `json:"senses"`
-	Sounds []kaikkiSound `json:"sounds"`
+	Word     string        `json:"word"`
+	POS      string        `json:"pos"`
+	Lang     string        `json:"lang"`
+	LangCode string        `json:"lang_code"`
+	Senses   []kaikkiSense `json:"senses"`
+	Sounds   []kaikkiSound `json:"sounds"`
 }
 
 // kaikkiSense mirrors one sense from a Kaikki entry.
@@ -67,6 +76,7 @@ type kaikkiTranslation struct {
 
 // kaikkiSound mirrors a sound entry from Kaikki.
 type kaikkiSound struct {
-	IPA  string   `json:"ipa"`
-	Tags []string `json:"tags"`
+	IPA   string   `json:"ipa"`
+	Audio string   `json:"audio"`
+	Tags  []string `json:"tags"`
 }
@@ -0,0 +1,126 @@
+package wiktionary
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// linesPerChunk is how many JSONL lines a worker decodes per job. Larger chunks
+// amortise channel overhead; smaller ones keep the reorder buffer short.
+const linesPerChunk = 256
+
+type lineChunk struct {
+	seq   int
+	lines [][]byte
+}
+
+type decodedChunk[T any] struct {
+	seq     int
+	results []T
+}
+
+// decodeLines streams r line by line, runs decode on each line using up to
+// workers goroutines, and calls emit with the decoded values in the original
+// line order. emit always runs on the calling goroutine, so it may mutate
+// shared state without locking; decode must not. The first error returned by
+// emit stops reading and is returned as is.
+func decodeLines[T any](r io.Reader, workers int, decode func([]byte) T, emit func(T) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, maxLineSize), maxLineSize)
+
+	if workers <= 1 {
+		for scanner.Scan() {
+			if err := emit(decode(scanner.Bytes())); err != nil {
+				return err
+			}
+		}
+		return scanner.Err()
+	}
+
+	jobs := make(chan lineChunk, workers)
+	results := make(chan decodedChunk[T], workers)
+	// done is closed when emit fails, releasing the reader and workers.
+	done := make(chan struct{})
+
+	// Reader: the scanner reuses its buffer, so every line is copied.
+	var scanErr error
+	go func() {
+		defer close(jobs)
+		seq := 0
+		chunk := make([][]byte, 0, linesPerChunk)
+		for scanner.Scan() {
+			chunk = append(chunk, append([]byte(nil), scanner.Bytes()...))
+			if len(chunk) == linesPerChunk {
+				select {
+				case jobs <- lineChunk{seq: seq, lines: chunk}:
+				case <-done:
+					return
+				}
+				seq++
+				chunk = make([][]byte, 0, linesPerChunk)
+			}
+		}
+		if len(chunk) > 0 {
+			select {
+			case jobs <- lineChunk{seq: seq, lines: chunk}:
+			case <-done:
+				return
+			}
+		}
+		scanErr = scanner.Err()
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				out := make([]T, len(job.lines))
+				for i, line := range job.lines {
+					out[i] = decode(line)
+				}
+				select {
+				case results <- decodedChunk[T]{seq: job.seq, results: out}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Collector: chunks arrive out of order and are buffered until their
+	// predecessors have been emitted.
+	pending := make(map[int][]T)
+	next := 0
+	for res := range results {
+		pending[res.seq] = res.results
+		for {
+			out, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			for _, v := range out {
+				if err := emit(v); err != nil {
+					close(done)
+					// Drain until the workers have exited; the reader
+					// stops at its next send.
+					for range results {
+					}
+					return err
+				}
+			}
+			next++
+		}
+	}
+
+	// results is closed only after the reader closed jobs, so scanErr is set.
+	return scanErr
+}
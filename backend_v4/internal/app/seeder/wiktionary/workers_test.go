@@ -0,0 +1,247 @@
+package wiktionary
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// writeSyntheticDump writes n JSONL lines cycling through sample.jsonl with
+// the word suffixed so that every few lines map to a new headword.
+func writeSyntheticDump(tb testing.TB, n int) string {
+	tb.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	sample, err := os.ReadFile(filepath.Join(filepath.Dir(file), "testdata", "sample.jsonl"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(sample), []byte("\n"))
+
+	path := filepath.Join(tb.TempDir(), "dump.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	for i := range n {
+		line := string(lines[i%len(lines)])
+		line = strings.Replace(line, `"word":"`, fmt.Sprintf(`"word":"w%d`, i/(2*len(lines))), 1)
+		fmt.Fprintln(w, line)
+	}
+	if err := w.Flush(); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func TestParseWithWorkers_MatchesSerial(t *testing.T) {
+	path := writeSyntheticDump(t, 5000)
+	coreWords := map[string]bool{"w3water": true}
+
+	serial, serialStats, err := Parse(path, coreWords, 300)
+	if err != nil {
+		t.Fatalf("serial parse: %v", err)
+	}
+	if len(serial) == 0 {
+		t.Fatal("serial parse returned no entries")
+	}
+
+	for _, workers := range []int{2, 4, 8} {
+		parallel, stats, err := ParseWithWorkers(path, coreWords, 300, workers, LanguageFilter{})
+		if err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		if stats != serialStats {
+			t.Errorf("workers=%d: stats %+v, want %+v", workers, stats, serialStats)
+		}
+		if !reflect.DeepEqual(parallel, serial) {
+			t.Errorf("workers=%d: parsed entries differ from serial path", workers)
+		}
+	}
+}
+
+func TestToDomainEntriesWithWorkers_MatchesSerial(t *testing.T) {
+	path := writeSyntheticDump(t, 2000)
+	entries, _, err := Parse(path, nil, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With deterministic IDs the results must match exactly, IDs included.
+	opts := CleanOptions{DeterministicIDs: true}
+	serial := zeroTimestamps(ToDomainEntries(entries, opts))
+	for _, workers := range []int{2, 3, 8} {
+		parallel := zeroTimestamps(ToDomainEntriesWithWorkers(entries, workers, opts))
+		if !reflect.DeepEqual(parallel, serial) {
+			t.Errorf("workers=%d: deterministic domain result differs from serial path", workers)
+		}
+	}
+
+	// Random IDs differ, but content and parent/child links must not.
+	serial = canonicalIDs(ToDomainEntries(entries, CleanOptions{}))
+	for _, workers := range []int{2, 3, 8} {
+		parallel := canonicalIDs(ToDomainEntriesWithWorkers(entries, workers, CleanOptions{}))
+		if !reflect.DeepEqual(parallel, serial) {
+			t.Errorf("workers=%d: domain result differs from serial path", workers)
+		}
+	}
+}
+
+// zeroTimestamps clears the conversion time, the only field that differs
+// between two deterministic conversions of the same input.
+func zeroTimestamps(r DomainResult) DomainResult {
+	for i := range r.Entries {
+		r.Entries[i].CreatedAt = time.Time{}
+	}
+	for i := range r.Senses {
+		r.Senses[i].CreatedAt = time.Time{}
+	}
+	return r
+}
+
+func TestToDomainEntry_MatchesBatch(t *testing.T) {
+	path := writeSyntheticDump(t, 2000)
+	entries, _, err := Parse(path, nil, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := CleanOptions{MinDefinitionLength: 10, NormalizeIPA: true}
+
+	var streamed DomainResult
+	for _, e := range entries {
+		streamed.Append(ToDomainEntry(e, opts))
+	}
+
+	batch := canonicalIDs(ToDomainEntries(entries, opts))
+	if !reflect.DeepEqual(canonicalIDs(streamed), batch) {
+		t.Error("per-entry conversion differs from batch conversion")
+	}
+}
+
+// canonicalIDs replaces random UUIDs by their order of first appearance and
+// zeroes timestamps, so that two conversions of the same input compare equal
+// exactly when their content and parent/child links match.
+func canonicalIDs(r DomainResult) DomainResult {
+	ids := make(map[uuid.UUID]uuid.UUID)
+	canon := func(id uuid.UUID) uuid.UUID {
+		if c, ok := ids[id]; ok {
+			return c
+		}
+		var c uuid.UUID
+		n := len(ids) + 1
+		c[12], c[13], c[14], c[15] = byte(n>>24), byte(n>>16), byte(n>>8), byte(n)
+		ids[id] = c
+		return c
+	}
+
+	for i := range r.Entries {
+		r.Entries[i].ID = canon(r.Entries[i].ID)
+		r.Entries[i].CreatedAt = time.Time{}
+	}
+	for i := range r.Senses {
+		r.Senses[i].ID = canon(r.Senses[i].ID)
+		r.Senses[i].RefEntryID = canon(r.Senses[i].RefEntryID)
+		r.Senses[i].CreatedAt = time.Time{}
+	}
+	for i := range r.Translations {
+		r.Translations[i].ID = canon(r.Translations[i].ID)
+		r.Translations[i].RefSenseID = canon(r.Translations[i].RefSenseID)
+	}
+	for i := range r.Examples {
+		r.Examples[i].ID = canon(r.Examples[i].ID)
+		r.Examples[i].RefSenseID = canon(r.Examples[i].RefSenseID)
+	}
+	for i := range r.Pronunciations {
+		r.Pronunciations[i].ID = canon(r.Pronunciations[i].ID)
+		r.Pronunciations[i].RefEntryID = canon(r.Pronunciations[i].RefEntryID)
+	}
+	return r
+}
+
+// BenchmarkParseWithWorkers compares the serial path against the worker pool
+// on a synthetic dump. Run with -benchtime=3x for a quick comparison.
+func BenchmarkParseWithWorkers(b *testing.B) {
+	path := writeSyntheticDump(b, 50000)
+
+	for _, workers := range []int{1, 2, 4, runtime.GOMAXPROCS(0)} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				entries, _, err := ParseWithWorkers(path, nil, 20000, workers, LanguageFilter{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				_ = ToDomainEntriesWithWorkers(entries, workers, CleanOptions{})
+			}
+		})
+	}
+}
+
+// BenchmarkImport_Memory compares parsing the whole dump into memory and
+// converting it at once with streaming entries one at a time into bounded
+// batches that are dropped after use. peak-heap-B is the largest live heap
+// observed after a conversion step; only the streaming one stays flat as the
+// dump grows. Run with -benchtime=1x: every sample forces a GC.
+func BenchmarkImport_Memory(b *testing.B) {
+	path := writeSyntheticDump(b, 50000)
+
+	// liveHeap collects garbage first so the sample is the reachable heap.
+	liveHeap := func() uint64 {
+		runtime.GC()
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		return ms.HeapAlloc
+	}
+
+	b.Run("parse-all", func(b *testing.B) {
+		var peak uint64
+		for b.Loop() {
+			runtime.GC()
+			entries, _, err := Parse(path, nil, 20000)
+			if err != nil {
+				b.Fatal(err)
+			}
+			r := ToDomainEntries(entries, CleanOptions{})
+			peak = max(peak, liveHeap())
+			runtime.KeepAlive(entries)
+			runtime.KeepAlive(r)
+		}
+		b.ReportMetric(float64(peak), "peak-heap-B")
+	})
+
+	b.Run("stream-batch=500", func(b *testing.B) {
+		var peak uint64
+		for b.Loop() {
+			runtime.GC()
+			sel, err := Select(path, nil, 20000, 1, LanguageFilter{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			var batch DomainResult
+			n := 0
+			_, err = sel.Stream(func(e ParsedEntry) error {
+				batch.Append(ToDomainEntry(e, CleanOptions{}))
+				if n++; n == 500 {
+					peak = max(peak, liveHeap())
+					batch, n = DomainResult{}, 0
+				}
+				return nil
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.ReportMetric(float64(peak), "peak-heap-B")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -15,16 +16,30 @@ import (
 // JWTManager handles JWT access token generation and validation,
 // plus refresh token generation and hashing.
 type JWTManager struct {
-	secret    []byte
+	// keys[0] is the current signing key; the rest only verify tokens.
+	keys      []JWTKey
 	issuer    string
 	accessTTL time.Duration
 }
 
-// NewJWTManager creates a new JWT manager.
+// JWTKey is an HS256 secret identified by the "kid" token header.
+type JWTKey struct {
+	ID     string
+	Secret string
+}
+
+// NewJWTManager creates a new JWT manager with a single key.
 // secret must be at least 32 characters for HS256 security.
 func NewJWTManager(secret string, issuer string, accessTTL time.Duration) *JWTManager {
+	return NewJWTManagerWithKeys(JWTKey{Secret: secret}, nil, issuer, accessTTL)
+}
+
+// NewJWTManagerWithKeys creates a JWT manager that signs with current and
+// also accepts tokens signed with any of the previous keys, so the signing
+// key can be rotated without invalidating issued tokens.
+func NewJWTManagerWithKeys(current JWTKey, previous []JWTKey, issuer string, accessTTL time.Duration) *JWTManager {
 	return &JWTManager{
-		secret:    []byte(secret),
+		keys:      append([]JWTKey{current}, previous...),
 		issuer:    issuer,
 		accessTTL: accessTTL,
 	}
@@ -49,8 +64,12 @@ func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, role string) (string,
 		Role: role,
 	}
 
+	current := m.keys[0]
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(m.secret)
+	if current.ID != "" {
+		token.Header["kid"] = current.ID
+	}
+	signed, err := token.SignedString([]byte(current.Secret))
 	if err != nil {
 		return "", fmt.Errorf("sign token: %w", err)
 	}
@@ -59,18 +78,31 @@ func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, role string) (string,
 }
 
 // ValidateAccessToken parses and validates a JWT access token.
-// Returns the user ID and role if valid.
+// Returns the user ID and role if valid. A token with a "kid" header is
+// verified with that key only; a token without one is tried against every
+// key, current first.
 func (m *JWTManager) ValidateAccessToken(tokenString string) (uuid.UUID, string, error) {
 	if tokenString == "" {
 		return uuid.Nil, "", fmt.Errorf("token is empty")
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &accessClaims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	candidates, err := m.verificationKeys(tokenString)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("parse token: %w", err)
+	}
+
+	var token *jwt.Token
+	for _, key := range candidates {
+		token, err = jwt.ParseWithClaims(tokenString, &accessClaims{}, func(token *jwt.Token) (any, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(key.Secret), nil
+		})
+		if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
 		}
-		return m.secret, nil
-	})
+	}
 
 	if err != nil {
 		return uuid.Nil, "", fmt.Errorf("parse token: %w", err)
@@ -93,6 +125,26 @@ func (m *JWTManager) ValidateAccessToken(tokenString string) (uuid.UUID, string,
 	return userID, claims.Role, nil
 }
 
+// verificationKeys returns the keys to try for tokenString: the key named by
+// its "kid" header, or all keys when the header is absent.
+func (m *JWTManager) verificationKeys(tokenString string) ([]JWTKey, error) {
+	unverified, _, err := jwt.NewParser().ParseUnverified(tokenString, &accessClaims{})
+	if err != nil {
+		return nil, err
+	}
+
+	kid, _ := unverified.Header["kid"].(string)
+	if kid == "" {
+		return m.keys, nil
+	}
+	for _, key := range m.keys {
+		if key.ID == kid {
+			return []JWTKey{key}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown key id %q", kid)
+}
+
 // GenerateRefreshToken creates a cryptographically random refresh token.
 // Returns both the raw token (to send to client) and its SHA-256 hash (to store in DB).
 func (m *JWTManager) GenerateRefreshToken() (raw string, hash string, err error) {
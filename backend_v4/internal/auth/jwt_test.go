@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
@@ -106,6 +107,103 @@ func TestJWTManager_ValidateAccessToken_InvalidSignature(t *testing.T) {
 	}
 }
 
+func TestJWTManager_KeyRotation(t *testing.T) {
+	oldKey := JWTKey{ID: "2024-01", Secret: "old-secret-at-least-32-chars-long-for-security"}
+	newKey := JWTKey{ID: "2024-06", Secret: "new-secret-at-least-32-chars-long-for-security"}
+	issuer := "myenglish-test"
+	ttl := 15 * time.Minute
+	userID := uuid.New()
+
+	before := NewJWTManagerWithKeys(oldKey, nil, issuer, ttl)
+	after := NewJWTManagerWithKeys(newKey, []JWTKey{oldKey}, issuer, ttl)
+
+	oldToken, err := before.GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken (old key) failed: %v", err)
+	}
+	newToken, err := after.GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken (new key) failed: %v", err)
+	}
+
+	// A token signed with the retired key still validates after rotation.
+	gotID, _, err := after.ValidateAccessToken(oldToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken (old token) failed: %v", err)
+	}
+	if gotID != userID {
+		t.Errorf("expected userID %s, got %s", userID, gotID)
+	}
+
+	// New tokens are signed with the current key only.
+	if kid := tokenKeyID(t, newToken); kid != newKey.ID {
+		t.Errorf("expected kid %q, got %q", newKey.ID, kid)
+	}
+	if _, _, err := before.ValidateAccessToken(newToken); err == nil {
+		t.Error("expected manager without the new key to reject the new token")
+	}
+	if _, _, err := NewJWTManagerWithKeys(newKey, nil, issuer, ttl).ValidateAccessToken(newToken); err != nil {
+		t.Errorf("ValidateAccessToken (new token) failed: %v", err)
+	}
+
+	// Once the old key is dropped its tokens are rejected.
+	if _, _, err := NewJWTManagerWithKeys(newKey, nil, issuer, ttl).ValidateAccessToken(oldToken); err == nil {
+		t.Error("expected error for token signed with a dropped key")
+	}
+}
+
+func TestJWTManager_KeyRotation_TokenWithoutKeyID(t *testing.T) {
+	secret := "legacy-secret-at-least-32-chars-long-for-security"
+	issuer := "myenglish-test"
+	ttl := 15 * time.Minute
+	userID := uuid.New()
+
+	// Tokens issued before key IDs were configured carry no kid header.
+	legacyToken, err := NewJWTManager(secret, issuer, ttl).GenerateAccessToken(userID, "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken failed: %v", err)
+	}
+	if kid := tokenKeyID(t, legacyToken); kid != "" {
+		t.Fatalf("expected no kid, got %q", kid)
+	}
+
+	rotated := NewJWTManagerWithKeys(
+		JWTKey{ID: "2024-06", Secret: "new-secret-at-least-32-chars-long-for-security"},
+		[]JWTKey{{ID: "legacy", Secret: secret}},
+		issuer, ttl,
+	)
+	if _, _, err := rotated.ValidateAccessToken(legacyToken); err != nil {
+		t.Errorf("ValidateAccessToken (legacy token) failed: %v", err)
+	}
+}
+
+func TestJWTManager_ValidateAccessToken_UnknownKeyID(t *testing.T) {
+	issuer := "myenglish-test"
+	ttl := 15 * time.Minute
+	secret := "shared-secret-at-least-32-chars-long-for-security"
+
+	token, err := NewJWTManagerWithKeys(JWTKey{ID: "other", Secret: secret}, nil, issuer, ttl).GenerateAccessToken(uuid.New(), "user")
+	if err != nil {
+		t.Fatalf("GenerateAccessToken failed: %v", err)
+	}
+
+	manager := NewJWTManagerWithKeys(JWTKey{ID: "current", Secret: secret}, nil, issuer, ttl)
+	if _, _, err := manager.ValidateAccessToken(token); err == nil || !strings.Contains(err.Error(), "unknown key id") {
+		t.Errorf("expected unknown key id error, got %v", err)
+	}
+}
+
+// tokenKeyID returns the kid header of token without verifying it.
+func tokenKeyID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &accessClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified failed: %v", err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
 func TestJWTManager_ValidateAccessToken_Malformed(t *testing.T) {
 	secret := "test-secret-at-least-32-chars-long-for-security"
 	issuer := "myenglish-test"
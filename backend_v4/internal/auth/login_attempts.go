@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// LoginAttempts is an in-memory failed-login counter. A key is locked once it
+// has collected maxFailures failures within the sliding window; it unlocks
+// as old failures fall out of the window or when Reset is called.
+type LoginAttempts struct {
+	maxFailures int
+	window      time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	failures  map[string][]time.Time
+	lastSweep time.Time
+}
+
+// NewLoginAttempts creates a counter that locks a key after maxFailures
+// failures within window.
+func NewLoginAttempts(maxFailures int, window time.Duration) *LoginAttempts {
+	return &LoginAttempts{
+		maxFailures: maxFailures,
+		window:      window,
+		now:         time.Now,
+		failures:    make(map[string][]time.Time),
+	}
+}
+
+// Locked reports whether any of the keys has reached the failure limit.
+func (a *LoginAttempts) Locked(keys ...string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	for _, key := range keys {
+		if len(a.recent(key, now)) >= a.maxFailures {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordFailure registers a failed attempt against each key.
+func (a *LoginAttempts) RecordFailure(keys ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	for _, key := range keys {
+		a.failures[key] = append(a.recent(key, now), now)
+	}
+
+	// Drop keys without recent failures so the map doesn't grow unbounded.
+	if now.Sub(a.lastSweep) >= a.window {
+		for key := range a.failures {
+			a.recent(key, now)
+		}
+		a.lastSweep = now
+	}
+}
+
+// Reset clears the failures recorded against key.
+func (a *LoginAttempts) Reset(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.failures, key)
+}
+
+// recent prunes failures older than the window and returns the rest.
+// Must be called with mu held.
+func (a *LoginAttempts) recent(key string, now time.Time) []time.Time {
+	times := a.failures[key]
+	cutoff := now.Add(-a.window)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	if i == len(times) {
+		delete(a.failures, key)
+		return nil
+	}
+	times = times[i:]
+	a.failures[key] = times
+	return times
+}
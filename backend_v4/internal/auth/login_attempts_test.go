@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginAttempts_LocksAfterMaxFailures(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := NewLoginAttempts(3, 15*time.Minute)
+	a.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		a.RecordFailure("email:alice@example.com")
+	}
+	if a.Locked("email:alice@example.com") {
+		t.Fatal("locked after 2 failures, want unlocked")
+	}
+
+	a.RecordFailure("email:alice@example.com")
+	if !a.Locked("email:alice@example.com") {
+		t.Fatal("not locked after 3 failures")
+	}
+	if !a.Locked("ip:192.0.2.1", "email:alice@example.com") {
+		t.Error("Locked should report true when any key is locked")
+	}
+	if a.Locked("email:bob@example.com") {
+		t.Error("other key should not be locked")
+	}
+}
+
+func TestLoginAttempts_WindowExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	a := NewLoginAttempts(2, 15*time.Minute)
+	a.now = func() time.Time { return now }
+
+	a.RecordFailure("k")
+	now = now.Add(10 * time.Minute)
+	a.RecordFailure("k")
+	if !a.Locked("k") {
+		t.Fatal("not locked after 2 failures within window")
+	}
+
+	// The first failure falls out of the window.
+	now = now.Add(6 * time.Minute)
+	if a.Locked("k") {
+		t.Error("still locked after oldest failure expired")
+	}
+
+	now = now.Add(time.Hour)
+	a.RecordFailure("other")
+	if _, ok := a.failures["k"]; ok {
+		t.Error("stale key was not swept")
+	}
+}
+
+func TestLoginAttempts_Reset(t *testing.T) {
+	a := NewLoginAttempts(2, time.Hour)
+
+	a.RecordFailure("k")
+	a.RecordFailure("k")
+	if !a.Locked("k") {
+		t.Fatal("not locked after 2 failures")
+	}
+
+	a.Reset("k")
+	if a.Locked("k") {
+		t.Error("still locked after Reset")
+	}
+}
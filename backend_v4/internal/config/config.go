@@ -16,6 +16,7 @@ type Config struct {
 	SRS        SRSConfig        `yaml:"srs"`
 	CORS       CORSConfig       `yaml:"cors"`
 	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Enrichment EnrichmentConfig `yaml:"enrichment"`
 }
 
 // CORSConfig holds CORS settings.
@@ -49,25 +50,36 @@ type ServerConfig struct {
 // DatabaseConfig holds PostgreSQL connection settings.
 type DatabaseConfig struct {
 	DSN             string        `yaml:"dsn"                env:"DATABASE_DSN"                env-required:"true"`
+	ReplicaDSN      string        `yaml:"replica_dsn"        env:"DATABASE_REPLICA_DSN"`
 	MaxConns        int32         `yaml:"max_conns"          env:"DATABASE_MAX_CONNS"          env-default:"25"`
 	MinConns        int32         `yaml:"min_conns"          env:"DATABASE_MIN_CONNS"          env-default:"5"`
 	MaxConnLifetime time.Duration `yaml:"max_conn_lifetime"  env:"DATABASE_MAX_CONN_LIFETIME"  env-default:"1h"`
 	MaxConnIdleTime time.Duration `yaml:"max_conn_idle_time" env:"DATABASE_MAX_CONN_IDLE_TIME" env-default:"30m"`
+	ConnectTimeout  time.Duration `yaml:"connect_timeout"    env:"DATABASE_CONNECT_TIMEOUT"    env-default:"10s"`
 }
 
 // AuthConfig holds authentication and OAuth settings.
 type AuthConfig struct {
 	JWTSecret          string        `yaml:"jwt_secret"           env:"AUTH_JWT_SECRET"           env-required:"true"`
 	JWTIssuer          string        `yaml:"jwt_issuer"           env:"AUTH_JWT_ISSUER"           env-default:"myenglish"`
+	JWTKeyID           string        `yaml:"jwt_key_id"           env:"AUTH_JWT_KEY_ID"`
+	JWTPreviousKeysRaw string        `yaml:"jwt_previous_keys"    env:"AUTH_JWT_PREVIOUS_KEYS"`
 	AccessTokenTTL     time.Duration `yaml:"access_token_ttl"     env:"AUTH_ACCESS_TOKEN_TTL"     env-default:"15m"`
 	RefreshTokenTTL    time.Duration `yaml:"refresh_token_ttl"    env:"AUTH_REFRESH_TOKEN_TTL"    env-default:"720h"`
 	PasswordHashCost   int           `yaml:"password_hash_cost"   env:"AUTH_PASSWORD_HASH_COST"   env-default:"12"`
+	PasswordAlgorithm  string        `yaml:"password_algorithm"   env:"AUTH_PASSWORD_ALGORITHM"   env-default:"bcrypt"`
+	LoginMaxFailures   int           `yaml:"login_max_failures"   env:"AUTH_LOGIN_MAX_FAILURES"   env-default:"5"`
+	LoginFailureWindow time.Duration `yaml:"login_failure_window" env:"AUTH_LOGIN_FAILURE_WINDOW" env-default:"15m"`
 	GoogleClientID     string        `yaml:"google_client_id"     env:"AUTH_GOOGLE_CLIENT_ID"`
 	GoogleClientSecret string        `yaml:"google_client_secret" env:"AUTH_GOOGLE_CLIENT_SECRET"`
 	GoogleRedirectURI  string        `yaml:"google_redirect_uri"  env:"AUTH_GOOGLE_REDIRECT_URI"`
 	AppleKeyID         string        `yaml:"apple_key_id"         env:"AUTH_APPLE_KEY_ID"`
 	AppleTeamID        string        `yaml:"apple_team_id"        env:"AUTH_APPLE_TEAM_ID"`
 	ApplePrivateKey    string        `yaml:"apple_private_key"    env:"AUTH_APPLE_PRIVATE_KEY"`
+
+	// JWTPreviousKeys is parsed from JWTPreviousKeysRaw during validation:
+	// key ID → secret of retired keys that still verify access tokens.
+	JWTPreviousKeys map[string]string `yaml:"-" env:"-"`
 }
 
 // DictionaryConfig holds dictionary service settings.
@@ -77,6 +89,12 @@ type DictionaryConfig struct {
 	ExportMaxEntries        int `yaml:"export_max_entries"          env:"DICT_EXPORT_MAX_ENTRIES"         env-default:"10000"`
 	HardDeleteRetentionDays int `yaml:"hard_delete_retention_days"  env:"DICT_HARD_DELETE_RETENTION_DAYS" env-default:"30"`
 	AuditRetentionDays      int `yaml:"audit_retention_days"        env:"AUDIT_RETENTION_DAYS"            env-default:"365"`
+	ReviewLogRetentionDays  int `yaml:"review_log_retention_days"   env:"REVIEW_LOG_RETENTION_DAYS"       env-default:"730"`
+	SessionRetentionDays    int `yaml:"session_retention_days"      env:"SESSION_RETENTION_DAYS"          env-default:"90"`
+	// Caps on the parts of a custom entry; translations and examples are per sense.
+	MaxCustomSenses       int `yaml:"max_custom_senses"       env:"DICT_MAX_CUSTOM_SENSES"       env-default:"20"`
+	MaxCustomTranslations int `yaml:"max_custom_translations" env:"DICT_MAX_CUSTOM_TRANSLATIONS" env-default:"20"`
+	MaxCustomExamples     int `yaml:"max_custom_examples"     env:"DICT_MAX_CUSTOM_EXAMPLES"     env-default:"50"`
 }
 
 // GraphQLConfig holds GraphQL server settings.
@@ -86,6 +104,12 @@ type GraphQLConfig struct {
 	ComplexityLimit       int  `yaml:"complexity_limit"       env:"GRAPHQL_COMPLEXITY_LIMIT"       env-default:"300"`
 }
 
+// EnrichmentConfig holds enrichment queue retry settings.
+type EnrichmentConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts"     env:"ENRICHMENT_MAX_ATTEMPTS"     env-default:"5"`
+	RetryBaseDelay time.Duration `yaml:"retry_base_delay" env:"ENRICHMENT_RETRY_BASE_DELAY" env-default:"1m"`
+}
+
 // LogConfig holds logging settings.
 type LogConfig struct {
 	Level  string `yaml:"level"  env:"LOG_LEVEL"  env-default:"info"`
@@ -102,6 +126,20 @@ type SRSConfig struct {
 	NewCardsPerDay     int     `yaml:"new_cards_per_day"    env:"SRS_NEW_CARDS_DAY"         env-default:"20"`
 	ReviewsPerDay      int     `yaml:"reviews_per_day"      env:"SRS_REVIEWS_DAY"           env-default:"200"` // Not enforced in queue
 	UndoWindowMinutes  int     `yaml:"undo_window_minutes"  env:"SRS_UNDO_WINDOW_MINUTES"   env-default:"10"`
+	DifficultyMin      float64 `yaml:"difficulty_min"       env:"SRS_DIFFICULTY_MIN"        env-default:"1"`
+	DifficultyMax      float64 `yaml:"difficulty_max"       env:"SRS_DIFFICULTY_MAX"        env-default:"10"`
+	// SessionInactivityTimeout is how long an ACTIVE session may go without a
+	// review before cmd/session-reaper abandons it.
+	SessionInactivityTimeout time.Duration `yaml:"session_inactivity_timeout" env:"SRS_SESSION_INACTIVITY_TIMEOUT" env-default:"2h"`
+	// NewCardInterleaveRatio is how many due cards come before each new card
+	// in the study queue of users who enabled interleaving.
+	NewCardInterleaveRatio int `yaml:"new_card_interleave_ratio" env:"SRS_NEW_CARD_INTERLEAVE_RATIO" env-default:"5"`
+	// Starting memory state for new cards of frequent words (frequency rank
+	// 1..FrequentWordMaxRank). Zero stability or difficulty keeps the FSRS
+	// default for that value; a zero max rank disables the preset.
+	FrequentWordMaxRank    int     `yaml:"frequent_word_max_rank"   env:"SRS_FREQUENT_WORD_MAX_RANK"   env-default:"2000"`
+	FrequentWordStability  float64 `yaml:"frequent_word_stability"  env:"SRS_FREQUENT_WORD_STABILITY"  env-default:"4.5"`
+	FrequentWordDifficulty float64 `yaml:"frequent_word_difficulty" env:"SRS_FREQUENT_WORD_DIFFICULTY" env-default:"0"`
 
 	// LearningSteps is parsed from LearningStepsRaw during validation.
 	LearningSteps []time.Duration `yaml:"-" env:"-"`
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"maps"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
 // validEnv sets the minimum required env vars for a valid config.
@@ -99,6 +102,9 @@ func TestLoad_ValidYAML(t *testing.T) {
 	if cfg.Database.MaxConns != 10 {
 		t.Errorf("database.max_conns = %d, want 10", cfg.Database.MaxConns)
 	}
+	if cfg.Database.ConnectTimeout != 10*time.Second {
+		t.Errorf("database.connect_timeout = %s, want default 10s", cfg.Database.ConnectTimeout)
+	}
 
 	// Auth
 	if cfg.Auth.GoogleClientID != "gid" {
@@ -118,6 +124,10 @@ func TestLoad_ValidYAML(t *testing.T) {
 	if cfg.Dictionary.HardDeleteRetentionDays != 60 {
 		t.Errorf("dictionary.hard_delete_retention_days = %d, want 60", cfg.Dictionary.HardDeleteRetentionDays)
 	}
+	if d := cfg.Dictionary; d.MaxCustomSenses != 20 || d.MaxCustomTranslations != 20 || d.MaxCustomExamples != 50 {
+		t.Errorf("dictionary custom entry caps = %d/%d/%d, want defaults 50/20/20",
+			d.MaxCustomSenses, d.MaxCustomTranslations, d.MaxCustomExamples)
+	}
 
 	// GraphQL
 	if !cfg.GraphQL.PlaygroundEnabled {
@@ -233,6 +243,45 @@ func TestValidate_JWTSecretEmpty(t *testing.T) {
 	}
 }
 
+func TestValidate_JWTPreviousKeys(t *testing.T) {
+	const secret = "previous-secret-at-least-32-chars-long"
+
+	tests := []struct {
+		name     string
+		keyID    string
+		raw      string
+		wantKeys map[string]string
+		wantErr  bool
+	}{
+		{"empty", "", "", nil, false},
+		{"two keys", "k3", "k1:" + secret + ", k2:" + secret + "!", map[string]string{"k1": secret, "k2": secret + "!"}, false},
+		{"missing kid", "k2", ":" + secret, nil, true},
+		{"missing separator", "k2", secret, nil, true},
+		{"short secret", "k2", "k1:short", nil, true},
+		{"duplicate kid", "k3", "k1:" + secret + ",k1:" + secret, nil, true},
+		{"same as current", "k1", "k1:" + secret, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Auth.JWTKeyID = tt.keyID
+			cfg.Auth.JWTPreviousKeysRaw = tt.raw
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !maps.Equal(cfg.Auth.JWTPreviousKeys, tt.wantKeys) {
+				t.Errorf("JWTPreviousKeys = %v, want %v", cfg.Auth.JWTPreviousKeys, tt.wantKeys)
+			}
+		})
+	}
+}
+
 func TestValidate_NoOAuthProvider(t *testing.T) {
 	cfg := validConfig()
 	cfg.Auth.GoogleClientID = ""
@@ -265,6 +314,44 @@ func TestValidate_PasswordHashCostTooHigh(t *testing.T) {
 	}
 }
 
+func TestValidate_PasswordAlgorithm(t *testing.T) {
+	for _, alg := range []string{"bcrypt", "argon2id"} {
+		cfg := validConfig()
+		cfg.Auth.PasswordAlgorithm = alg
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("%s: unexpected error: %v", alg, err)
+		}
+	}
+
+	cfg := validConfig()
+	cfg.Auth.PasswordAlgorithm = "md5"
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for unknown PasswordAlgorithm")
+	}
+}
+
+func TestValidate_LoginLockout(t *testing.T) {
+	cfg := validConfig()
+	cfg.Auth.LoginMaxFailures = -1
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for negative LoginMaxFailures")
+	}
+
+	cfg = validConfig()
+	cfg.Auth.LoginMaxFailures = 5
+	cfg.Auth.LoginFailureWindow = 0
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for zero LoginFailureWindow")
+	}
+
+	cfg = validConfig()
+	cfg.Auth.LoginMaxFailures = 0
+	cfg.Auth.LoginFailureWindow = 0
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("disabled lockout should not need a window: %v", err)
+	}
+}
+
 func TestValidate_AppleOAuthOnly(t *testing.T) {
 	cfg := validConfig()
 	cfg.Auth.GoogleClientID = ""
@@ -377,6 +464,54 @@ func TestValidate_Dictionary_HardDeleteRetentionDaysNegative(t *testing.T) {
 	}
 }
 
+func TestValidate_Dictionary_CustomEntryCaps(t *testing.T) {
+	for name, mutate := range map[string]func(*DictionaryConfig){
+		"senses":       func(d *DictionaryConfig) { d.MaxCustomSenses = 0 },
+		"translations": func(d *DictionaryConfig) { d.MaxCustomTranslations = -1 },
+		"examples":     func(d *DictionaryConfig) { d.MaxCustomExamples = 0 },
+	} {
+		cfg := validConfig()
+		mutate(&cfg.Dictionary)
+
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected error for non-positive cap", name)
+		}
+	}
+}
+
+func TestValidate_Dictionary_CustomEntryCapsAboveContentLimits(t *testing.T) {
+	for name, mutate := range map[string]func(*DictionaryConfig){
+		"senses":       func(d *DictionaryConfig) { d.MaxCustomSenses = domain.MaxSensesPerEntry + 1 },
+		"translations": func(d *DictionaryConfig) { d.MaxCustomTranslations = domain.MaxTranslationsPerSense + 1 },
+		"examples":     func(d *DictionaryConfig) { d.MaxCustomExamples = domain.MaxExamplesPerSense + 1 },
+	} {
+		cfg := validConfig()
+		mutate(&cfg.Dictionary)
+
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected error for cap above content limit", name)
+		}
+	}
+}
+
+func TestValidate_Dictionary_ReviewLogRetentionDaysZero(t *testing.T) {
+	cfg := validConfig()
+	cfg.Dictionary.ReviewLogRetentionDays = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for ReviewLogRetentionDays = 0")
+	}
+}
+
+func TestValidate_Dictionary_SessionRetentionDaysNegative(t *testing.T) {
+	cfg := validConfig()
+	cfg.Dictionary.SessionRetentionDays = -1
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for negative SessionRetentionDays")
+	}
+}
+
 func TestValidate_Dictionary_ValidBoundaryValues(t *testing.T) {
 	cfg := validConfig()
 	cfg.Dictionary.ImportChunkSize = 1
@@ -438,6 +573,22 @@ func TestParseLearningSteps_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestParseLearningSteps_NotPositive(t *testing.T) {
+	for _, raw := range []string{"0s", "1m,-10m"} {
+		if _, err := ParseLearningSteps(raw); err == nil {
+			t.Errorf("ParseLearningSteps(%q): expected error for non-positive step", raw)
+		}
+	}
+}
+
+func TestParseLearningSteps_NotIncreasing(t *testing.T) {
+	for _, raw := range []string{"10m,1m", "10m,10m"} {
+		if _, err := ParseLearningSteps(raw); err == nil {
+			t.Errorf("ParseLearningSteps(%q): expected error for non-increasing steps", raw)
+		}
+	}
+}
+
 func TestParseLearningSteps_SingleStep(t *testing.T) {
 	steps, err := ParseLearningSteps("5m")
 	if err != nil {
@@ -451,6 +602,52 @@ func TestParseLearningSteps_SingleStep(t *testing.T) {
 	}
 }
 
+func TestValidate_SRS_SessionInactivityTimeoutZero(t *testing.T) {
+	cfg := validConfig()
+	cfg.SRS.SessionInactivityTimeout = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for SessionInactivityTimeout = 0")
+	}
+}
+
+func TestValidate_SRS_NewCardInterleaveRatioZero(t *testing.T) {
+	cfg := validConfig()
+	cfg.SRS.NewCardInterleaveRatio = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for NewCardInterleaveRatio = 0")
+	}
+}
+
+func TestValidate_SRS_FrequentWordPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*SRSConfig)
+		wantErr bool
+	}{
+		{"defaults", func(*SRSConfig) {}, false},
+		{"disabled", func(s *SRSConfig) { s.FrequentWordMaxRank = 0 }, false},
+		{"negative rank", func(s *SRSConfig) { s.FrequentWordMaxRank = -1 }, true},
+		{"negative stability", func(s *SRSConfig) { s.FrequentWordStability = -1 }, true},
+		{"stability above max interval", func(s *SRSConfig) { s.FrequentWordStability = 400 }, true},
+		{"difficulty in range", func(s *SRSConfig) { s.FrequentWordDifficulty = 3 }, false},
+		{"difficulty out of range", func(s *SRSConfig) { s.FrequentWordDifficulty = 11 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(&cfg.SRS)
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidate_SRS_UndoWindowMinutesZero(t *testing.T) {
 	cfg := validConfig()
 	cfg.SRS.UndoWindowMinutes = 0
@@ -460,6 +657,29 @@ func TestValidate_SRS_UndoWindowMinutesZero(t *testing.T) {
 	}
 }
 
+func TestValidate_SRS_DifficultyRangeInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max float64
+	}{
+		{"min below 1", 0.5, 10},
+		{"max above 10", 1, 11},
+		{"min equals max", 5, 5},
+		{"min above max", 8, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.SRS.DifficultyMin = tt.min
+			cfg.SRS.DifficultyMax = tt.max
+
+			if err := cfg.Validate(); err == nil {
+				t.Fatalf("expected error for difficulty range [%v, %v]", tt.min, tt.max)
+			}
+		})
+	}
+}
+
 func TestValidate_SRS_UndoWindowMinutesNegative(t *testing.T) {
 	cfg := validConfig()
 	cfg.SRS.UndoWindowMinutes = -5
@@ -534,11 +754,30 @@ func TestValidate_SRS_AllFieldsValid(t *testing.T) {
 }
 
 // validConfig returns a Config that passes all validation checks.
+func TestValidate_Enrichment_MaxAttemptsZero(t *testing.T) {
+	cfg := validConfig()
+	cfg.Enrichment.MaxAttempts = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for zero enrichment max_attempts")
+	}
+}
+
+func TestValidate_Enrichment_RetryBaseDelayZero(t *testing.T) {
+	cfg := validConfig()
+	cfg.Enrichment.RetryBaseDelay = 0
+
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("expected error for zero enrichment retry_base_delay")
+	}
+}
+
 func validConfig() Config {
 	return Config{
 		Auth: AuthConfig{
 			JWTSecret:          "this-is-a-very-long-jwt-secret-for-testing-32+",
 			PasswordHashCost:   12,
+			PasswordAlgorithm:  "bcrypt",
 			GoogleClientID:     "gid",
 			GoogleClientSecret: "gsecret",
 		},
@@ -547,6 +786,11 @@ func validConfig() Config {
 			ImportChunkSize:         50,
 			ExportMaxEntries:        10000,
 			HardDeleteRetentionDays: 30,
+			ReviewLogRetentionDays:  730,
+			SessionRetentionDays:    90,
+			MaxCustomSenses:         20,
+			MaxCustomTranslations:   20,
+			MaxCustomExamples:       50,
 		},
 		SRS: SRSConfig{
 			DefaultRetention:   0.9,
@@ -557,6 +801,43 @@ func validConfig() Config {
 			NewCardsPerDay:     20,
 			ReviewsPerDay:      200,
 			UndoWindowMinutes:  10,
+			DifficultyMin:      1,
+			DifficultyMax:      10,
+
+			SessionInactivityTimeout: 2 * time.Hour,
+			NewCardInterleaveRatio:   5,
+			FrequentWordMaxRank:      2000,
+			FrequentWordStability:    4.5,
+		},
+		Enrichment: EnrichmentConfig{
+			MaxAttempts:    5,
+			RetryBaseDelay: time.Minute,
 		},
 	}
 }
+
+func TestValidate_Database_Pool(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(d *DatabaseConfig)
+		wantErr bool
+	}{
+		{"max equals min", func(d *DatabaseConfig) { d.MaxConns, d.MinConns = 5, 5 }, false},
+		{"all zero", func(d *DatabaseConfig) { *d = DatabaseConfig{} }, false},
+		{"max below min", func(d *DatabaseConfig) { d.MaxConns, d.MinConns = 2, 5 }, true},
+		{"negative min", func(d *DatabaseConfig) { d.MinConns = -1 }, true},
+		{"negative connect timeout", func(d *DatabaseConfig) { d.ConnectTimeout = -time.Second }, true},
+		{"negative lifetime", func(d *DatabaseConfig) { d.MaxConnLifetime = -time.Minute }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			cfg.Database = DatabaseConfig{MaxConns: 25, MinConns: 5, ConnectTimeout: 10 * time.Second}
+			tt.mutate(&cfg.Database)
+
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
 // Validate performs business-rule validation on the loaded configuration.
@@ -13,10 +15,34 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("auth.jwt_secret must be at least 32 characters (got %d)", len(c.Auth.JWTSecret))
 	}
 
+	previousKeys, err := ParseJWTKeys(c.Auth.JWTPreviousKeysRaw)
+	if err != nil {
+		return fmt.Errorf("auth.jwt_previous_keys: %w", err)
+	}
+	if _, ok := previousKeys[c.Auth.JWTKeyID]; ok {
+		return fmt.Errorf("auth.jwt_previous_keys: key id %q is the current auth.jwt_key_id", c.Auth.JWTKeyID)
+	}
+	c.Auth.JWTPreviousKeys = previousKeys
+
 	if c.Auth.PasswordHashCost < 4 || c.Auth.PasswordHashCost > 31 {
 		return fmt.Errorf("auth.password_hash_cost must be between 4 and 31 (got %d)", c.Auth.PasswordHashCost)
 	}
 
+	if c.Auth.PasswordAlgorithm != "bcrypt" && c.Auth.PasswordAlgorithm != "argon2id" {
+		return fmt.Errorf("auth.password_algorithm must be bcrypt or argon2id (got %q)", c.Auth.PasswordAlgorithm)
+	}
+
+	if c.Auth.LoginMaxFailures < 0 {
+		return fmt.Errorf("auth.login_max_failures must be non-negative (got %d)", c.Auth.LoginMaxFailures)
+	}
+	if c.Auth.LoginMaxFailures > 0 && c.Auth.LoginFailureWindow <= 0 {
+		return fmt.Errorf("auth.login_failure_window must be positive (got %s)", c.Auth.LoginFailureWindow)
+	}
+
+	if err := c.Database.validate(); err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+
 	if err := c.Dictionary.validate(); err != nil {
 		return fmt.Errorf("dictionary: %w", err)
 	}
@@ -25,6 +51,23 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("srs: %w", err)
 	}
 
+	if err := c.Enrichment.validate(); err != nil {
+		return fmt.Errorf("enrichment: %w", err)
+	}
+
+	return nil
+}
+
+func (d *DatabaseConfig) validate() error {
+	if d.MinConns < 0 {
+		return fmt.Errorf("min_conns must be non-negative (got %d)", d.MinConns)
+	}
+	if d.MaxConns < d.MinConns {
+		return fmt.Errorf("max_conns must be >= min_conns (got %d < %d)", d.MaxConns, d.MinConns)
+	}
+	if d.MaxConnLifetime < 0 || d.MaxConnIdleTime < 0 || d.ConnectTimeout < 0 {
+		return fmt.Errorf("max_conn_lifetime, max_conn_idle_time and connect_timeout must be non-negative")
+	}
 	return nil
 }
 
@@ -41,6 +84,34 @@ func (d *DictionaryConfig) validate() error {
 	if d.HardDeleteRetentionDays <= 0 {
 		return fmt.Errorf("hard_delete_retention_days must be positive (got %d)", d.HardDeleteRetentionDays)
 	}
+	if d.ReviewLogRetentionDays <= 0 {
+		return fmt.Errorf("review_log_retention_days must be positive (got %d)", d.ReviewLogRetentionDays)
+	}
+	if d.SessionRetentionDays <= 0 {
+		return fmt.Errorf("session_retention_days must be positive (got %d)", d.SessionRetentionDays)
+	}
+	if d.MaxCustomSenses <= 0 || d.MaxCustomTranslations <= 0 || d.MaxCustomExamples <= 0 {
+		return fmt.Errorf("max_custom_senses, max_custom_translations and max_custom_examples must be positive (got %d, %d, %d)",
+			d.MaxCustomSenses, d.MaxCustomTranslations, d.MaxCustomExamples)
+	}
+	// Custom entries must stay editable through the content service, which
+	// enforces the domain limits on every later change.
+	if d.MaxCustomSenses > domain.MaxSensesPerEntry || d.MaxCustomTranslations > domain.MaxTranslationsPerSense ||
+		d.MaxCustomExamples > domain.MaxExamplesPerSense {
+		return fmt.Errorf("max_custom_senses, max_custom_translations and max_custom_examples must not exceed %d, %d, %d (got %d, %d, %d)",
+			domain.MaxSensesPerEntry, domain.MaxTranslationsPerSense, domain.MaxExamplesPerSense,
+			d.MaxCustomSenses, d.MaxCustomTranslations, d.MaxCustomExamples)
+	}
+	return nil
+}
+
+func (e *EnrichmentConfig) validate() error {
+	if e.MaxAttempts < 1 {
+		return fmt.Errorf("max_attempts must be >= 1 (got %d)", e.MaxAttempts)
+	}
+	if e.RetryBaseDelay <= 0 {
+		return fmt.Errorf("retry_base_delay must be positive (got %s)", e.RetryBaseDelay)
+	}
 	return nil
 }
 
@@ -57,6 +128,26 @@ func (s *SRSConfig) validate() error {
 	if s.UndoWindowMinutes < 1 {
 		return fmt.Errorf("undo_window_minutes must be >= 1")
 	}
+	if s.DifficultyMin < 1 || s.DifficultyMax > 10 || s.DifficultyMin >= s.DifficultyMax {
+		return fmt.Errorf("difficulty_min and difficulty_max must satisfy 1 <= min < max <= 10 (got %v, %v)", s.DifficultyMin, s.DifficultyMax)
+	}
+
+	if s.SessionInactivityTimeout <= 0 {
+		return fmt.Errorf("session_inactivity_timeout must be positive (got %s)", s.SessionInactivityTimeout)
+	}
+	if s.NewCardInterleaveRatio < 1 {
+		return fmt.Errorf("new_card_interleave_ratio must be >= 1 (got %d)", s.NewCardInterleaveRatio)
+	}
+
+	if s.FrequentWordMaxRank < 0 {
+		return fmt.Errorf("frequent_word_max_rank must not be negative (got %d)", s.FrequentWordMaxRank)
+	}
+	if s.FrequentWordStability < 0 || s.FrequentWordStability > float64(s.MaxIntervalDays) {
+		return fmt.Errorf("frequent_word_stability must be between 0 and max_interval_days (got %v)", s.FrequentWordStability)
+	}
+	if s.FrequentWordDifficulty != 0 && (s.FrequentWordDifficulty < s.DifficultyMin || s.FrequentWordDifficulty > s.DifficultyMax) {
+		return fmt.Errorf("frequent_word_difficulty must be 0 or within [difficulty_min, difficulty_max] (got %v)", s.FrequentWordDifficulty)
+	}
 
 	steps, err := ParseLearningSteps(s.LearningStepsRaw)
 	if err != nil {
@@ -75,8 +166,42 @@ func (s *SRSConfig) validate() error {
 	return nil
 }
 
+// ParseJWTKeys parses a comma-separated list of "kid:secret" pairs
+// (e.g. "2024-01:first-secret,2024-06:second-secret") into a key ID → secret
+// map. Key IDs must be unique and secrets at least 32 characters. An empty
+// string returns a nil map.
+func ParseJWTKeys(raw string) (map[string]string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	keys := make(map[string]string)
+	for _, p := range strings.Split(raw, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(p, ":")
+		kid = strings.TrimSpace(kid)
+		if !ok || kid == "" {
+			return nil, fmt.Errorf("invalid key %q: expected kid:secret", p)
+		}
+		if len(secret) < 32 {
+			return nil, fmt.Errorf("key %q: secret must be at least 32 characters (got %d)", kid, len(secret))
+		}
+		if _, dup := keys[kid]; dup {
+			return nil, fmt.Errorf("duplicate key id %q", kid)
+		}
+		keys[kid] = secret
+	}
+
+	return keys, nil
+}
+
 // ParseLearningSteps parses a comma-separated string of durations (e.g. "1m,10m")
-// into a slice of time.Duration. An empty string returns a nil slice.
+// into a slice of time.Duration. Steps must be positive and strictly
+// increasing. An empty string returns a nil slice.
 func ParseLearningSteps(raw string) ([]time.Duration, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -95,6 +220,12 @@ func ParseLearningSteps(raw string) ([]time.Duration, error) {
 		if err != nil {
 			return nil, fmt.Errorf("invalid duration %q: %w", p, err)
 		}
+		if d <= 0 {
+			return nil, fmt.Errorf("step %q must be positive", p)
+		}
+		if len(steps) > 0 && d <= steps[len(steps)-1] {
+			return nil, fmt.Errorf("step %q must be longer than the previous step", p)
+		}
 		steps = append(steps, d)
 	}
 
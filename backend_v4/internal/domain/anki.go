@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// AnkiNote is one note read from an Anki .apkg package: the first two fields
+// as plain text, plus the review schedule of its first card when that card is
+// in review.
+type AnkiNote struct {
+	Front    string
+	Back     string
+	Schedule *AnkiSchedule
+}
+
+// AnkiSchedule is the scheduling state of a review card in Anki.
+type AnkiSchedule struct {
+	Due          time.Time
+	IntervalDays int
+	Ease         float64 // Anki ease factor, e.g. 2.5
+	Reps         int
+	Lapses       int
+}
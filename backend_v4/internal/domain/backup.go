@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackupVersion is the current version of the Backup format. Bump it whenever
+// the JSON shape changes and teach the importer to migrate older versions.
+const BackupVersion = 1
+
+// Backup is a portable, versioned snapshot of a user's dictionary. IDs are the
+// ones from the exporting account and only link records within the backup;
+// they are remapped on import.
+type Backup struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Entries    []BackupEntry `json:"entries"`
+	Topics     []BackupTopic `json:"topics"`
+}
+
+// BackupEntry is a dictionary entry with its content and card.
+type BackupEntry struct {
+	ID        uuid.UUID     `json:"id"`
+	Text      string        `json:"text"`
+	Notes     *string       `json:"notes,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+	Senses    []BackupSense `json:"senses"`
+	Card      *BackupCard   `json:"card,omitempty"`
+}
+
+// BackupSense is a sense with its translations and examples, in position order.
+type BackupSense struct {
+	Definition   *string         `json:"definition,omitempty"`
+	PartOfSpeech *PartOfSpeech   `json:"part_of_speech,omitempty"`
+	CEFRLevel    *string         `json:"cefr_level,omitempty"`
+	SourceSlug   string          `json:"source_slug"`
+	Translations []string        `json:"translations"`
+	Examples     []BackupExample `json:"examples"`
+}
+
+// BackupExample is a usage example.
+type BackupExample struct {
+	Sentence    string  `json:"sentence"`
+	Translation *string `json:"translation,omitempty"`
+}
+
+// BackupCard is the SRS state of an entry's card.
+type BackupCard struct {
+	State         CardState  `json:"state"`
+	Step          int        `json:"step"`
+	Stability     float64    `json:"stability"`
+	Difficulty    float64    `json:"difficulty"`
+	Due           time.Time  `json:"due"`
+	LastReview    *time.Time `json:"last_review,omitempty"`
+	Reps          int        `json:"reps"`
+	Lapses        int        `json:"lapses"`
+	ScheduledDays int        `json:"scheduled_days"`
+	ElapsedDays   int        `json:"elapsed_days"`
+}
+
+// BackupTopic is a topic and the backup IDs of the entries linked to it.
+type BackupTopic struct {
+	Name        string      `json:"name"`
+	Description *string     `json:"description,omitempty"`
+	EntryIDs    []uuid.UUID `json:"entry_ids"`
+}
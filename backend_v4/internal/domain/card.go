@@ -21,8 +21,14 @@ type Card struct {
 	Lapses        int
 	ScheduledDays int
 	ElapsedDays   int
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// Suspended cards are kept out of study queues and due/new counts
+	// until the user unsuspends them; their SRS state is left as is.
+	Suspended bool
+	// Version is bumped by every SRS update; updates computed from an older
+	// version are rejected so concurrent reviews cannot overwrite each other.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // IsDue returns true if the card needs review at the given time.
@@ -44,6 +50,16 @@ type ReviewLog struct {
 	PrevState  *CardSnapshot
 	DurationMs *int
 	ReviewedAt time.Time
+	// IdempotencyKey is the client key of the submission that created the
+	// log, if any; it is unique per card.
+	IdempotencyKey *string
+}
+
+// ReviewLogWithWord is a review log together with the text of its card's
+// entry, as exported for external analysis.
+type ReviewLogWithWord struct {
+	ReviewLog
+	Word string
 }
 
 // CardSnapshot captures the FSRS state of a card before a review (for undo).
@@ -69,6 +85,9 @@ type StudySession struct {
 	FinishedAt *time.Time
 	Result     *SessionResult
 	CreatedAt  time.Time
+
+	// QueueCardIDs is the remaining study queue snapshot, in review order.
+	QueueCardIDs []uuid.UUID
 }
 
 // GradeCounts holds per-grade counters for a study session.
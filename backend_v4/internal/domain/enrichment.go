@@ -24,6 +24,24 @@ func (s EnrichmentStatus) IsValid() bool {
 	return false
 }
 
+// EnrichmentClaimOrder selects which pending items ClaimBatch takes first.
+type EnrichmentClaimOrder string
+
+const (
+	// EnrichmentClaimOrderPriority claims by priority DESC, then oldest first.
+	EnrichmentClaimOrderPriority EnrichmentClaimOrder = "priority"
+	// EnrichmentClaimOrderFIFO claims strictly oldest first, ignoring priority.
+	EnrichmentClaimOrderFIFO EnrichmentClaimOrder = "fifo"
+)
+
+func (o EnrichmentClaimOrder) IsValid() bool {
+	switch o {
+	case EnrichmentClaimOrderPriority, EnrichmentClaimOrderFIFO:
+		return true
+	}
+	return false
+}
+
 // EnrichmentQueueItem represents a word queued for LLM enrichment.
 type EnrichmentQueueItem struct {
 	ID           uuid.UUID
@@ -34,6 +52,9 @@ type EnrichmentQueueItem struct {
 	RequestedAt  time.Time
 	ProcessedAt  *time.Time
 	CreatedAt    time.Time
+	Attempts     int
+	NextRetryAt  *time.Time
+	ClaimedAt    *time.Time
 }
 
 // EnrichmentQueueStats holds aggregate counts by status.
@@ -6,6 +6,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// Content limits shared by every path that writes senses, translations and
+// examples of a user's entry.
+const (
+	MaxSensesPerEntry       = 20
+	MaxTranslationsPerSense = 20
+	MaxExamplesPerSense     = 50
+)
+
 // Entry is a user's dictionary entry, optionally linked to a reference catalog entry.
 type Entry struct {
 	ID             uuid.UUID
@@ -14,6 +22,7 @@ type Entry struct {
 	Text           string
 	TextNormalized string
 	Notes          *string
+	Favorite       bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time
@@ -31,6 +40,14 @@ func (e *Entry) IsDeleted() bool {
 	return e.DeletedAt != nil
 }
 
+// EntrySearchHit is an entry matched by full-text search over the user's
+// dictionary, with its relevance rank and a highlighted excerpt.
+type EntrySearchHit struct {
+	Entry   Entry
+	Rank    float64
+	Snippet string
+}
+
 // Sense is a user's sense, optionally inheriting data from a reference sense via COALESCE.
 type Sense struct {
 	ID           uuid.UUID
@@ -40,6 +40,44 @@ func (g ReviewGrade) IsValid() bool {
 	return false
 }
 
+// NewCardOrder controls the order in which new cards are introduced.
+type NewCardOrder string
+
+const (
+	NewCardOrderAdded     NewCardOrder = "added"     // oldest entry first
+	NewCardOrderRandom    NewCardOrder = "random"    // shuffled
+	NewCardOrderFrequency NewCardOrder = "frequency" // most frequent ref entry first
+)
+
+func (o NewCardOrder) String() string { return string(o) }
+
+func (o NewCardOrder) IsValid() bool {
+	switch o {
+	case NewCardOrderAdded, NewCardOrderRandom, NewCardOrderFrequency:
+		return true
+	}
+	return false
+}
+
+// DueCardOrder controls the order in which due cards are reviewed.
+type DueCardOrder string
+
+const (
+	DueCardOrderDueDate DueCardOrder = "due_date" // most overdue first
+	DueCardOrderRandom  DueCardOrder = "random"   // shuffled
+	DueCardOrderAdded   DueCardOrder = "added"    // oldest card first
+)
+
+func (o DueCardOrder) String() string { return string(o) }
+
+func (o DueCardOrder) IsValid() bool {
+	switch o {
+	case DueCardOrderDueDate, DueCardOrderRandom, DueCardOrderAdded:
+		return true
+	}
+	return false
+}
+
 // PartOfSpeech represents the grammatical category of a word.
 type PartOfSpeech string
 
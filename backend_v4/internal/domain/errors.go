@@ -13,6 +13,8 @@ var (
 	ErrUnauthorized  = errors.New("unauthorized")
 	ErrForbidden     = errors.New("forbidden")
 	ErrConflict      = errors.New("conflict")
+
+	ErrTooManyAttempts = errors.New("too many attempts")
 )
 
 // FieldError describes a validation error for a specific field.
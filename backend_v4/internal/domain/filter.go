@@ -1,6 +1,10 @@
 package domain
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // EntryFilter contains filtering/pagination parameters for entry searches.
 type EntryFilter struct {
@@ -9,6 +13,7 @@ type EntryFilter struct {
 	PartOfSpeech *PartOfSpeech
 	TopicID      *uuid.UUID
 	Status       *CardState
+	Favorite     *bool
 	SortBy       string
 	SortOrder    string
 	Limit        int
@@ -16,6 +21,25 @@ type EntryFilter struct {
 	Offset       *int
 }
 
+// AuditFilter contains filtering/pagination parameters for audit log queries.
+// A nil UserID means all users; only admins may query that way.
+type AuditFilter struct {
+	UserID     *uuid.UUID
+	EntityType *EntityType
+	Action     *AuditAction
+	From       *time.Time // inclusive
+	To         *time.Time // exclusive
+	Limit      int
+	Offset     int
+}
+
+// ReviewLogCursor is a keyset position in review history ordered by
+// (reviewed_at, id): descending for a card's history, ascending for exports.
+type ReviewLogCursor struct {
+	ReviewedAt time.Time
+	ID         uuid.UUID
+}
+
 // ReorderItem represents an item to reorder with its new position.
 type ReorderItem struct {
 	ID       uuid.UUID
@@ -30,6 +30,7 @@ type RefSense struct {
 	CEFRLevel    *string
 	Notes        *string
 	SourceSlug   string
+	Sources      []string // other sources whose near-identical senses were merged in
 	Position     int
 	CreatedAt    time.Time
 
@@ -114,6 +115,19 @@ type EntryMetadataUpdate struct {
 	IsCoreLexicon  *bool
 }
 
+// RefSensePosition is a new position for a ref_sense.
+type RefSensePosition struct {
+	SenseID  uuid.UUID
+	Position int
+}
+
+// RefEntryPoolFilter narrows the reference catalog to a pool of candidate entries.
+type RefEntryPoolFilter struct {
+	CEFRLevels       []string   // empty = any level
+	MaxFrequencyRank int        // 0 = no rank limit
+	ExcludeUserID    *uuid.UUID // skip entries already in this user's dictionary
+}
+
 // Int32PtrToIntPtr converts *int32 (sqlc) to *int (domain).
 func Int32PtrToIntPtr(v *int32) *int {
 	if v == nil {
@@ -131,3 +145,39 @@ func IntPtrToInt32Ptr(v *int) *int32 {
 	i := int32(*v)
 	return &i
 }
+
+// CatalogGapCounts counts reference entries lacking key data within one
+// frequency band. Unranked entries form their own band with Ranked false;
+// MaxRank 0 means the band has no upper bound.
+type CatalogGapCounts struct {
+	Ranked           bool
+	MinRank          int
+	MaxRank          int
+	Entries          int
+	NoTranslations   int
+	NoExamples       int
+	NoPronunciations int
+	StubDefinitions  int // entries whose every definition is a stub, or that have no senses
+}
+
+// CatalogGapEntry is a reference entry missing some key data.
+type CatalogGapEntry struct {
+	RefEntryID       uuid.UUID
+	Text             string
+	FrequencyRank    *int
+	NoTranslations   bool
+	NoExamples       bool
+	NoPronunciations bool
+	StubDefinitions  bool
+}
+
+// Gaps returns how many kinds of data the entry lacks.
+func (e CatalogGapEntry) Gaps() int {
+	n := 0
+	for _, missing := range []bool{e.NoTranslations, e.NoExamples, e.NoPronunciations, e.StubDefinitions} {
+		if missing {
+			n++
+		}
+	}
+	return n
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // SRSConfig holds FSRS-5 spaced-repetition algorithm parameters (pure domain type).
@@ -14,6 +16,32 @@ type SRSConfig struct {
 	NewCardsPerDay    int
 	ReviewsPerDay     int // Not enforced in study queue. Due cards are always shown regardless of this limit.
 	UndoWindowMinutes int
+	DifficultyMin     float64
+	DifficultyMax     float64
+	CardPresets       CardPresets
+	// NewCardInterleaveRatio is the number of due cards before each new card
+	// when the user interleaves new cards.
+	NewCardInterleaveRatio int
+}
+
+// CardPresets picks the starting memory state of a new card from the
+// frequency rank of its reference entry. Frequent words are assumed easier
+// and may start with a higher stability. Zero values mean "no preset": the
+// first review then uses the plain FSRS initial state.
+type CardPresets struct {
+	FrequentMaxRank    int     // ranks 1..FrequentMaxRank count as frequent; 0 disables presets
+	FrequentStability  float64 // starting stability for frequent words
+	FrequentDifficulty float64 // starting difficulty for frequent words
+}
+
+// For returns the starting stability and difficulty for a word with the
+// given frequency rank. Words without a rank, or outside the frequent band,
+// get zeros.
+func (p CardPresets) For(frequencyRank *int) (stability, difficulty float64) {
+	if p.FrequentMaxRank <= 0 || frequencyRank == nil || *frequencyRank < 1 || *frequencyRank > p.FrequentMaxRank {
+		return 0, 0
+	}
+	return p.FrequentStability, p.FrequentDifficulty
 }
 
 // SRSUpdateParams holds the fields to update on a card after FSRS calculation.
@@ -28,6 +56,8 @@ type SRSUpdateParams struct {
 	Lapses        int
 	ScheduledDays int
 	ElapsedDays   int
+	// Version is the card version the update was computed from.
+	Version int
 }
 
 // CardStatusCounts holds the count of cards per state.
@@ -46,9 +76,14 @@ type Dashboard struct {
 	ReviewedToday int
 	NewToday      int
 	Streak        int
+	LongestStreak int
 	StatusCounts  CardStatusCounts
 	OverdueCount  int
 	ActiveSession *StudySession
+	// GoalProgress is ReviewedToday capped at the user's daily goal; both
+	// goal fields stay zero when the user has no goal.
+	GoalProgress int
+	GoalReached  bool
 }
 
 // DayReviewCount holds the review count for a specific date.
@@ -57,6 +92,12 @@ type DayReviewCount struct {
 	Count int
 }
 
+// DayGradeCounts holds the review count per grade for a specific date.
+type DayGradeCounts struct {
+	Date   time.Time
+	Grades GradeCounts
+}
+
 // ReviewLogAggregation holds aggregated review stats computed in SQL.
 type ReviewLogAggregation struct {
 	TotalReviews  int
@@ -78,3 +119,47 @@ type CardStats struct {
 	ScheduledDays     int
 	GradeDistribution *GradeCounts
 }
+
+// UserReviewAggregation holds account-wide review counts computed in SQL.
+// The Recent* fields cover reviews since a cutoff of cards that were already
+// in REVIEW state, which is what retention is measured on.
+type UserReviewAggregation struct {
+	TotalReviews         int
+	AgainCount           int
+	HardCount            int
+	GoodCount            int
+	EasyCount            int
+	RecentMatureReviews  int
+	RecentMatureRecalled int // not graded AGAIN
+}
+
+// DifficultWord is an entry the user struggles with, ranked by the share of
+// its card's reviews graded AGAIN.
+type DifficultWord struct {
+	EntryID      uuid.UUID
+	CardID       uuid.UUID
+	Text         string
+	TotalReviews int
+	AgainCount   int
+	LapseRate    float64 // AgainCount / TotalReviews
+}
+
+// StabilityBucket is one bar of a card stability histogram: cards with
+// Min <= stability < Max (Max nil = unbounded).
+type StabilityBucket struct {
+	Min          float64
+	Max          *float64
+	Count        int
+	StabilitySum float64
+}
+
+// UserStats holds learning statistics across all of a user's cards.
+type UserStats struct {
+	TotalReviews       int
+	AccuracyRate       float64 // % of reviews graded GOOD or EASY
+	AverageStability   float64 // days, over cards that left NEW
+	MatureCards        int
+	RetentionRate      float64 // % of last-30-day reviews of REVIEW cards not graded AGAIN
+	GradeDistribution  GradeCounts
+	StabilityHistogram []StabilityBucket
+}
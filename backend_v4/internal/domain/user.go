@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,6 +17,29 @@ type User struct {
 	Role      UserRole
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// ProfileCustomized is set once the user edits their name or avatar;
+	// OAuth login then no longer overwrites them with provider data.
+	ProfileCustomized bool
+}
+
+// deletedUserEmailSuffix marks the email of an anonymized (deleted) account.
+// The .invalid TLD is reserved, so no real address can collide with it.
+const deletedUserEmailSuffix = "@deleted.invalid"
+
+// AnonymizedUserEmail returns the placeholder email of a deleted account.
+func AnonymizedUserEmail(id uuid.UUID) string {
+	return "deleted-" + id.String() + deletedUserEmailSuffix
+}
+
+// AnonymizedUsername returns the placeholder username of a deleted account.
+func AnonymizedUsername(id uuid.UUID) string {
+	return "deleted-" + id.String()
+}
+
+// IsDeleted reports whether the account has been deleted and anonymized.
+func (u *User) IsDeleted() bool {
+	return strings.HasSuffix(u.Email, deletedUserEmailSuffix)
 }
 
 // UserSettings holds per-user SRS and display preferences.
@@ -26,18 +50,37 @@ type UserSettings struct {
 	MaxIntervalDays  int
 	DesiredRetention float64
 	Timezone         string
-	UpdatedAt        time.Time
+	NewCardOrder     NewCardOrder
+	// HardIntervalFactor scales the interval FSRS computes for a Hard grade
+	// on a review card; 1.0 leaves it unchanged.
+	HardIntervalFactor float64
+	// DailyGoal is the number of reviews per day the user aims for; 0 means
+	// no goal.
+	DailyGoal int
+	// DueCardOrder is the order of due cards in the study queue.
+	DueCardOrder DueCardOrder
+	// InterleaveNew spreads new cards among due cards in the study queue
+	// instead of appending them after all due cards.
+	InterleaveNew bool
+	// LearningSteps and RelearningSteps override the server's steps for new
+	// and lapsed cards; nil uses the server default.
+	LearningSteps   []time.Duration
+	RelearningSteps []time.Duration
+	UpdatedAt       time.Time
 }
 
 // DefaultUserSettings returns UserSettings with sensible defaults.
 func DefaultUserSettings(userID uuid.UUID) UserSettings {
 	return UserSettings{
-		UserID:           userID,
-		NewCardsPerDay:   20,
-		ReviewsPerDay:    200,
-		MaxIntervalDays:  365,
-		DesiredRetention: 0.9,
-		Timezone:         "UTC",
+		UserID:             userID,
+		NewCardsPerDay:     20,
+		ReviewsPerDay:      200,
+		MaxIntervalDays:    365,
+		DesiredRetention:   0.9,
+		Timezone:           "UTC",
+		NewCardOrder:       NewCardOrderAdded,
+		HardIntervalFactor: 1.0,
+		DueCardOrder:       DueCardOrderDueDate,
 	}
 }
 
@@ -0,0 +1,82 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package auth
+
+import (
+	"context"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"sync"
+)
+
+// Ensure, that auditRepoMock does implement auditRepo.
+// If this is not the case, regenerate this file with moq.
+var _ auditRepo = &auditRepoMock{}
+
+// auditRepoMock is a mock implementation of auditRepo.
+//
+//	func TestSomethingThatUsesauditRepo(t *testing.T) {
+//
+//		// make and configure a mocked auditRepo
+//		mockedauditRepo := &auditRepoMock{
+//			CreateFunc: func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
+//				panic("mock out the Create method")
+//			},
+//		}
+//
+//		// use mockedauditRepo in code that requires auditRepo
+//		// and then make assertions.
+//
+//	}
+type auditRepoMock struct {
+	// CreateFunc mocks the Create method.
+	CreateFunc func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Create holds details about calls to the Create method.
+		Create []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Record is the record argument value.
+			Record domain.AuditRecord
+		}
+	}
+	lockCreate sync.RWMutex
+}
+
+// Create calls CreateFunc.
+func (mock *auditRepoMock) Create(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
+	if mock.CreateFunc == nil {
+		panic("auditRepoMock.CreateFunc: method is nil but auditRepo.Create was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		Record domain.AuditRecord
+	}{
+		Ctx:    ctx,
+		Record: record,
+	}
+	mock.lockCreate.Lock()
+	mock.calls.Create = append(mock.calls.Create, callInfo)
+	mock.lockCreate.Unlock()
+	return mock.CreateFunc(ctx, record)
+}
+
+// CreateCalls gets all the calls that were made to Create.
+// Check the length with:
+//
+//	len(mockedauditRepo.CreateCalls())
+func (mock *auditRepoMock) CreateCalls() []struct {
+	Ctx    context.Context
+	Record domain.AuditRecord
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Record domain.AuditRecord
+	}
+	mock.lockCreate.RLock()
+	calls = mock.calls.Create
+	mock.lockCreate.RUnlock()
+	return calls
+}
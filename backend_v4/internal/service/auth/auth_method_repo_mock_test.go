@@ -20,15 +20,27 @@ var _ authMethodRepo = &authMethodRepoMock{}
 //
 //		// make and configure a mocked authMethodRepo
 //		mockedauthMethodRepo := &authMethodRepoMock{
+//			CountByUserForUpdateFunc: func(ctx context.Context, userID uuid.UUID) (int, error) {
+//				panic("mock out the CountByUserForUpdate method")
+//			},
 //			CreateFunc: func(ctx context.Context, am *domain.AuthMethod) (*domain.AuthMethod, error) {
 //				panic("mock out the Create method")
 //			},
+//			DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+//				panic("mock out the Delete method")
+//			},
 //			GetByOAuthFunc: func(ctx context.Context, method domain.AuthMethodType, providerID string) (*domain.AuthMethod, error) {
 //				panic("mock out the GetByOAuth method")
 //			},
 //			GetByUserAndMethodFunc: func(ctx context.Context, userID uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
 //				panic("mock out the GetByUserAndMethod method")
 //			},
+//			ListByUserFunc: func(ctx context.Context, userID uuid.UUID) ([]domain.AuthMethod, error) {
+//				panic("mock out the ListByUser method")
+//			},
+//			UpdatePasswordHashFunc: func(ctx context.Context, id uuid.UUID, hash string) error {
+//				panic("mock out the UpdatePasswordHash method")
+//			},
 //		}
 //
 //		// use mockedauthMethodRepo in code that requires authMethodRepo
@@ -36,17 +48,36 @@ var _ authMethodRepo = &authMethodRepoMock{}
 //
 //	}
 type authMethodRepoMock struct {
+	// CountByUserForUpdateFunc mocks the CountByUserForUpdate method.
+	CountByUserForUpdateFunc func(ctx context.Context, userID uuid.UUID) (int, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, am *domain.AuthMethod) (*domain.AuthMethod, error)
 
+	// DeleteFunc mocks the Delete method.
+	DeleteFunc func(ctx context.Context, id uuid.UUID) error
+
 	// GetByOAuthFunc mocks the GetByOAuth method.
 	GetByOAuthFunc func(ctx context.Context, method domain.AuthMethodType, providerID string) (*domain.AuthMethod, error)
 
 	// GetByUserAndMethodFunc mocks the GetByUserAndMethod method.
 	GetByUserAndMethodFunc func(ctx context.Context, userID uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error)
 
+	// ListByUserFunc mocks the ListByUser method.
+	ListByUserFunc func(ctx context.Context, userID uuid.UUID) ([]domain.AuthMethod, error)
+
+	// UpdatePasswordHashFunc mocks the UpdatePasswordHash method.
+	UpdatePasswordHashFunc func(ctx context.Context, id uuid.UUID, hash string) error
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// CountByUserForUpdate holds details about calls to the CountByUserForUpdate method.
+		CountByUserForUpdate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -54,6 +85,13 @@ type authMethodRepoMock struct {
 			// Am is the am argument value.
 			Am *domain.AuthMethod
 		}
+		// Delete holds details about calls to the Delete method.
+		Delete []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// GetByOAuth holds details about calls to the GetByOAuth method.
 		GetByOAuth []struct {
 			// Ctx is the ctx argument value.
@@ -72,10 +110,66 @@ type authMethodRepoMock struct {
 			// Method is the method argument value.
 			Method domain.AuthMethodType
 		}
+		// ListByUser holds details about calls to the ListByUser method.
+		ListByUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
+		// UpdatePasswordHash holds details about calls to the UpdatePasswordHash method.
+		UpdatePasswordHash []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockCountByUserForUpdate sync.RWMutex
+	lockCreate               sync.RWMutex
+	lockDelete               sync.RWMutex
+	lockGetByOAuth           sync.RWMutex
+	lockGetByUserAndMethod   sync.RWMutex
+	lockListByUser           sync.RWMutex
+	lockUpdatePasswordHash   sync.RWMutex
+}
+
+// CountByUserForUpdate calls CountByUserForUpdateFunc.
+func (mock *authMethodRepoMock) CountByUserForUpdate(ctx context.Context, userID uuid.UUID) (int, error) {
+	if mock.CountByUserForUpdateFunc == nil {
+		panic("authMethodRepoMock.CountByUserForUpdateFunc: method is nil but authMethodRepo.CountByUserForUpdate was just called")
 	}
-	lockCreate             sync.RWMutex
-	lockGetByOAuth         sync.RWMutex
-	lockGetByUserAndMethod sync.RWMutex
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockCountByUserForUpdate.Lock()
+	mock.calls.CountByUserForUpdate = append(mock.calls.CountByUserForUpdate, callInfo)
+	mock.lockCountByUserForUpdate.Unlock()
+	return mock.CountByUserForUpdateFunc(ctx, userID)
+}
+
+// CountByUserForUpdateCalls gets all the calls that were made to CountByUserForUpdate.
+// Check the length with:
+//
+//	len(mockedauthMethodRepo.CountByUserForUpdateCalls())
+func (mock *authMethodRepoMock) CountByUserForUpdateCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockCountByUserForUpdate.RLock()
+	calls = mock.calls.CountByUserForUpdate
+	mock.lockCountByUserForUpdate.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -114,6 +208,42 @@ func (mock *authMethodRepoMock) CreateCalls() []struct {
 	return calls
 }
 
+// Delete calls DeleteFunc.
+func (mock *authMethodRepoMock) Delete(ctx context.Context, id uuid.UUID) error {
+	if mock.DeleteFunc == nil {
+		panic("authMethodRepoMock.DeleteFunc: method is nil but authMethodRepo.Delete was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockDelete.Lock()
+	mock.calls.Delete = append(mock.calls.Delete, callInfo)
+	mock.lockDelete.Unlock()
+	return mock.DeleteFunc(ctx, id)
+}
+
+// DeleteCalls gets all the calls that were made to Delete.
+// Check the length with:
+//
+//	len(mockedauthMethodRepo.DeleteCalls())
+func (mock *authMethodRepoMock) DeleteCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockDelete.RLock()
+	calls = mock.calls.Delete
+	mock.lockDelete.RUnlock()
+	return calls
+}
+
 // GetByOAuth calls GetByOAuthFunc.
 func (mock *authMethodRepoMock) GetByOAuth(ctx context.Context, method domain.AuthMethodType, providerID string) (*domain.AuthMethod, error) {
 	if mock.GetByOAuthFunc == nil {
@@ -193,3 +323,79 @@ func (mock *authMethodRepoMock) GetByUserAndMethodCalls() []struct {
 	mock.lockGetByUserAndMethod.RUnlock()
 	return calls
 }
+
+// ListByUser calls ListByUserFunc.
+func (mock *authMethodRepoMock) ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.AuthMethod, error) {
+	if mock.ListByUserFunc == nil {
+		panic("authMethodRepoMock.ListByUserFunc: method is nil but authMethodRepo.ListByUser was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockListByUser.Lock()
+	mock.calls.ListByUser = append(mock.calls.ListByUser, callInfo)
+	mock.lockListByUser.Unlock()
+	return mock.ListByUserFunc(ctx, userID)
+}
+
+// ListByUserCalls gets all the calls that were made to ListByUser.
+// Check the length with:
+//
+//	len(mockedauthMethodRepo.ListByUserCalls())
+func (mock *authMethodRepoMock) ListByUserCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockListByUser.RLock()
+	calls = mock.calls.ListByUser
+	mock.lockListByUser.RUnlock()
+	return calls
+}
+
+// UpdatePasswordHash calls UpdatePasswordHashFunc.
+func (mock *authMethodRepoMock) UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	if mock.UpdatePasswordHashFunc == nil {
+		panic("authMethodRepoMock.UpdatePasswordHashFunc: method is nil but authMethodRepo.UpdatePasswordHash was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		ID   uuid.UUID
+		Hash string
+	}{
+		Ctx:  ctx,
+		ID:   id,
+		Hash: hash,
+	}
+	mock.lockUpdatePasswordHash.Lock()
+	mock.calls.UpdatePasswordHash = append(mock.calls.UpdatePasswordHash, callInfo)
+	mock.lockUpdatePasswordHash.Unlock()
+	return mock.UpdatePasswordHashFunc(ctx, id, hash)
+}
+
+// UpdatePasswordHashCalls gets all the calls that were made to UpdatePasswordHash.
+// Check the length with:
+//
+//	len(mockedauthMethodRepo.UpdatePasswordHashCalls())
+func (mock *authMethodRepoMock) UpdatePasswordHashCalls() []struct {
+	Ctx  context.Context
+	ID   uuid.UUID
+	Hash string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		ID   uuid.UUID
+		Hash string
+	}
+	mock.lockUpdatePasswordHash.RLock()
+	calls = mock.calls.UpdatePasswordHash
+	mock.lockUpdatePasswordHash.RUnlock()
+	return calls
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ListAuthMethods returns the sign-in methods linked to the authenticated
+// user's account, oldest first.
+func (s *Service) ListAuthMethods(ctx context.Context) ([]AuthMethodInfo, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	methods, err := s.authMethods.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("auth.ListAuthMethods: %w", err)
+	}
+
+	result := make([]AuthMethodInfo, len(methods))
+	for i, am := range methods {
+		result[i] = AuthMethodInfo{Method: am.Method, CreatedAt: am.CreatedAt}
+	}
+	return result, nil
+}
+
+// UnlinkAuthMethod removes a sign-in method from the authenticated user's
+// account. The last remaining method cannot be removed, so the account always
+// stays accessible. Returns ErrNotFound if the method isn't linked.
+func (s *Service) UnlinkAuthMethod(ctx context.Context, method domain.AuthMethodType) error {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if !method.IsValid() {
+		return domain.NewValidationError("method", "must be one of password, google, apple")
+	}
+
+	err := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		am, err := s.authMethods.GetByUserAndMethod(txCtx, userID, method)
+		if err != nil {
+			return fmt.Errorf("get auth method: %w", err)
+		}
+
+		// Lock the user's methods so concurrent unlinks serialize: the
+		// second one sees the first's delete and counts what is left.
+		count, err := s.authMethods.CountByUserForUpdate(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("count auth methods: %w", err)
+		}
+		if count <= 1 {
+			return domain.NewValidationError("method", "cannot remove the only sign-in method")
+		}
+
+		if err := s.authMethods.Delete(txCtx, am.ID); err != nil {
+			return fmt.Errorf("delete auth method: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("auth.UnlinkAuthMethod: %w", err)
+	}
+
+	s.log.InfoContext(ctx, "auth method unlinked",
+		slog.String("user_id", userID.String()),
+		slog.String("method", method.String()))
+
+	return nil
+}
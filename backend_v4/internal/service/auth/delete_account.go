@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// DeleteAccount deletes the authenticated user's account. Accounts with a
+// password must re-enter it; OAuth-only accounts must send the confirmation
+// phrase. In one transaction all owned data (entries, cards, review logs,
+// sessions, topics, inbox, audit history, auth methods, refresh tokens and
+// settings) is deleted, the users row is anonymized and a final audit record
+// is written. Deleting an already deleted account is a no-op.
+func (s *Service) DeleteAccount(ctx context.Context, input DeleteAccountInput) error {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return fmt.Errorf("auth.DeleteAccount get user: %w", err)
+	}
+	if user.IsDeleted() {
+		return nil
+	}
+
+	am, err := s.authMethods.GetByUserAndMethod(ctx, userID, domain.AuthMethodPassword)
+	switch {
+	case err == nil && am.PasswordHash != nil:
+		if s.hasher.Compare(*am.PasswordHash, input.Password) != nil {
+			return domain.ErrUnauthorized
+		}
+	case err == nil || errors.Is(err, domain.ErrNotFound):
+		if input.Confirmation != DeleteAccountConfirmation {
+			return domain.NewValidationError("confirmation", "must be "+DeleteAccountConfirmation)
+		}
+	default:
+		return fmt.Errorf("auth.DeleteAccount get auth method: %w", err)
+	}
+
+	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		if err := s.users.DeleteOwnedData(txCtx, userID); err != nil {
+			return fmt.Errorf("delete owned data: %w", err)
+		}
+		if _, err := s.users.Anonymize(txCtx, userID); err != nil {
+			return fmt.Errorf("anonymize user: %w", err)
+		}
+		if s.audit == nil {
+			return nil
+		}
+		if _, err := s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeUser,
+			EntityID:   &userID,
+			Action:     domain.AuditActionDelete,
+			Changes:    map[string]any{"account": "deleted"},
+		}); err != nil {
+			return fmt.Errorf("audit create: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("auth.DeleteAccount: %w", err)
+	}
+
+	s.log.InfoContext(ctx, "user account deleted", slog.String("user_id", userID.String()))
+	return nil
+}
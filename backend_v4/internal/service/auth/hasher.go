@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Argon2id parameters (OWASP recommendation: 19 MiB, 2 iterations, 1 lane).
+const (
+	argon2idMemory  = 19 * 1024
+	argon2idTime    = 2
+	argon2idThreads = 1
+	argon2idKeyLen  = 32
+	argon2idSaltLen = 16
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var errPasswordMismatch = errors.New("password mismatch")
+
+// newPasswordHasher returns the hasher for the configured algorithm.
+// Unknown algorithms fall back to bcrypt; config validation rejects them.
+func newPasswordHasher(algorithm string, bcryptCost int) passwordHasher {
+	if algorithm == "argon2id" {
+		return argon2idHasher{}
+	}
+	return bcryptHasher{cost: bcryptCost}
+}
+
+// comparePassword verifies password against a bcrypt or argon2id hash. Both
+// formats carry their own parameters, so any hasher can verify hashes made by
+// the other while migrating between algorithms.
+func comparePassword(hash, password string) error {
+	if strings.HasPrefix(hash, argon2idPrefix) {
+		return compareArgon2id(hash, password)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
+
+// bcryptHasher hashes passwords with bcrypt at a fixed cost.
+type bcryptHasher struct {
+	cost int
+}
+
+// Hash returns the bcrypt hash of password.
+func (h bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// Compare returns nil if password matches hash.
+func (h bcryptHasher) Compare(hash, password string) error {
+	return comparePassword(hash, password)
+}
+
+// NeedsRehash reports whether hash is not a bcrypt hash of the configured cost.
+func (h bcryptHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+// argon2idHasher hashes passwords with argon2id, encoded in the PHC string
+// format: $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>.
+type argon2idHasher struct{}
+
+// Hash returns the argon2id hash of password with a random salt.
+func (h argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2idTime, argon2idMemory, argon2idThreads, argon2idKeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, argon2idMemory, argon2idTime, argon2idThreads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Compare returns nil if password matches hash.
+func (h argon2idHasher) Compare(hash, password string) error {
+	return comparePassword(hash, password)
+}
+
+// NeedsRehash reports whether hash is not an argon2id hash with the current
+// parameters.
+func (h argon2idHasher) NeedsRehash(hash string) bool {
+	p, _, _, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return p != argon2idParams{memory: argon2idMemory, time: argon2idTime, threads: argon2idThreads}
+}
+
+type argon2idParams struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func compareArgon2id(hash, password string) error {
+	p, salt, key, err := parseArgon2id(hash)
+	if err != nil {
+		return err
+	}
+	got := argon2.IDKey([]byte(password), salt, p.time, p.memory, p.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(got, key) != 1 {
+		return errPasswordMismatch
+	}
+	return nil
+}
+
+func parseArgon2id(hash string) (argon2idParams, []byte, []byte, error) {
+	var p argon2idParams
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return p, nil, nil, errors.New("invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, errors.New("unsupported argon2id version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, errors.New("invalid argon2id key")
+	}
+	return p, salt, key, nil
+}
@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestArgon2idHasher_HashAndCompare(t *testing.T) {
+	t.Parallel()
+
+	h := argon2idHasher{}
+	hash, err := h.Hash("s3cret-password")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=19456,t=2,p=1$") {
+		t.Errorf("unexpected hash format: %s", hash)
+	}
+
+	if err := h.Compare(hash, "s3cret-password"); err != nil {
+		t.Errorf("Compare correct password: %v", err)
+	}
+	if err := h.Compare(hash, "wrong-password"); err == nil {
+		t.Error("Compare wrong password: expected error")
+	}
+	if h.NeedsRehash(hash) {
+		t.Error("fresh argon2id hash should not need rehash")
+	}
+}
+
+func TestPasswordHashers_CrossScheme(t *testing.T) {
+	t.Parallel()
+
+	bcryptHash, err := bcryptHasher{cost: 4}.Hash("password123")
+	if err != nil {
+		t.Fatalf("bcrypt Hash: %v", err)
+	}
+	argonHash, err := argon2idHasher{}.Hash("password123")
+	if err != nil {
+		t.Fatalf("argon2id Hash: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		hasher      passwordHasher
+		hash        string
+		needsRehash bool
+	}{
+		{"argon2id verifies bcrypt", argon2idHasher{}, bcryptHash, true},
+		{"bcrypt verifies argon2id", bcryptHasher{cost: 4}, argonHash, true},
+		{"bcrypt cost changed", bcryptHasher{cost: 5}, bcryptHash, true},
+		{"bcrypt same cost", bcryptHasher{cost: 4}, bcryptHash, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.hasher.Compare(tt.hash, "password123"); err != nil {
+				t.Errorf("Compare: %v", err)
+			}
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.needsRehash {
+				t.Errorf("NeedsRehash: got=%v, want=%v", got, tt.needsRehash)
+			}
+		})
+	}
+}
+
+func TestArgon2idHasher_CompareMalformed(t *testing.T) {
+	t.Parallel()
+
+	for _, hash := range []string{
+		"$argon2id$",
+		"$argon2id$v=18$m=19456,t=2,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=x$c2FsdA$a2V5",
+		"$argon2id$v=19$m=19456,t=2,p=1$c2FsdA$",
+	} {
+		if err := (argon2idHasher{}).Compare(hash, "password"); err == nil {
+			t.Errorf("Compare(%q): expected error", hash)
+		}
+	}
+}
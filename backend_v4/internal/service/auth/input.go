@@ -118,3 +118,32 @@ func (i LoginPasswordInput) Validate() error {
 	}
 	return nil
 }
+
+// DeleteAccountConfirmation is the phrase accounts without a password must
+// type to confirm deletion.
+const DeleteAccountConfirmation = "DELETE"
+
+// DeleteAccountInput holds parameters for account deletion. Accounts with a
+// password re-enter it; OAuth-only accounts send DeleteAccountConfirmation.
+type DeleteAccountInput struct {
+	Password     string
+	Confirmation string
+}
+
+// Validate validates the delete-account input.
+func (i DeleteAccountInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.Password == "" && i.Confirmation == "" {
+		errs = append(errs, domain.FieldError{Field: "password", Message: "password or confirmation required"})
+	}
+
+	if i.Confirmation != "" && i.Confirmation != DeleteAccountConfirmation {
+		errs = append(errs, domain.FieldError{Field: "confirmation", Message: "must be " + DeleteAccountConfirmation})
+	}
+
+	if len(errs) > 0 {
+		return &domain.ValidationError{Errors: errs}
+	}
+	return nil
+}
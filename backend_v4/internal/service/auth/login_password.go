@@ -7,13 +7,15 @@ import (
 	"log/slog"
 	"strings"
 
-	"golang.org/x/crypto/bcrypt"
-
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
 )
 
 // LoginWithPassword authenticates a user with email + password.
-// Returns ErrUnauthorized if the email is not found or the password is wrong.
+// Returns ErrUnauthorized if the email is not found or the password is wrong
+// (both paths run a password comparison so timing doesn't reveal which),
+// and ErrTooManyAttempts while the email or client IP is locked out after
+// repeated failures.
 func (s *Service) LoginWithPassword(ctx context.Context, input LoginPasswordInput) (*AuthResult, error) {
 	// Normalize input before validation.
 	input.Email = strings.ToLower(strings.TrimSpace(input.Email))
@@ -23,41 +25,118 @@ func (s *Service) LoginWithPassword(ctx context.Context, input LoginPasswordInpu
 		return nil, err
 	}
 
-	// Step 2: Find user by email
+	// Step 2: Reject locked-out callers. The dummy hash comparison keeps the
+	// response time in line with a wrong-password attempt.
+	emailKey, keys := loginAttemptKeys(ctx, input.Email)
+	if s.attempts != nil && s.attempts.Locked(keys...) {
+		s.compareDummyPassword(input.Password)
+		s.log.WarnContext(ctx, "password login locked out",
+			slog.String("ip", ctxutil.ClientIPFromCtx(ctx)))
+		return nil, domain.ErrTooManyAttempts
+	}
+
+	// Step 3: Verify credentials
+	user, err := s.checkPassword(ctx, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthorized) && s.attempts != nil {
+			s.attempts.RecordFailure(keys...)
+		}
+		return nil, err
+	}
+	// Only the email counter is cleared: logging into one's own account must
+	// not wipe the failures recorded against the client IP.
+	if s.attempts != nil {
+		s.attempts.Reset(emailKey)
+	}
+
+	// Step 4: Issue tokens
+	result, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("auth.LoginWithPassword issue tokens: %w", err)
+	}
+
+	s.log.InfoContext(ctx, "user logged in via password",
+		slog.String("user_id", user.ID.String()))
+
+	return result, nil
+}
+
+// checkPassword returns the user owning the email if the password matches
+// their password auth method, and ErrUnauthorized otherwise.
+func (s *Service) checkPassword(ctx context.Context, input LoginPasswordInput) (*domain.User, error) {
 	user, err := s.users.GetByEmail(ctx, input.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
+			s.compareDummyPassword(input.Password)
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, fmt.Errorf("auth.LoginWithPassword get user: %w", err)
 	}
 
-	// Step 3: Find password auth method for this user
 	am, err := s.authMethods.GetByUserAndMethod(ctx, user.ID, domain.AuthMethodPassword)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			// User exists but has no password method (OAuth-only)
+			s.compareDummyPassword(input.Password)
 			return nil, domain.ErrUnauthorized
 		}
 		return nil, fmt.Errorf("auth.LoginWithPassword get auth method: %w", err)
 	}
 
-	// Step 4: Verify password
 	if am.PasswordHash == nil {
+		s.compareDummyPassword(input.Password)
 		return nil, domain.ErrUnauthorized
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(*am.PasswordHash), []byte(input.Password)); err != nil {
+	if err := s.hasher.Compare(*am.PasswordHash, input.Password); err != nil {
 		return nil, domain.ErrUnauthorized
 	}
 
-	// Step 5: Issue tokens
-	result, err := s.issueTokens(ctx, user)
+	s.rehashPassword(ctx, am, input.Password)
+
+	return user, nil
+}
+
+// rehashPassword upgrades a verified password hash made with a legacy
+// algorithm or parameters to the configured hasher. Failures are logged and
+// do not fail the login; the upgrade is retried on the next one.
+func (s *Service) rehashPassword(ctx context.Context, am *domain.AuthMethod, password string) {
+	if !s.hasher.NeedsRehash(*am.PasswordHash) {
+		return
+	}
+
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.authMethods.UpdatePasswordHash(ctx, am.ID, hash)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("auth.LoginWithPassword issue tokens: %w", err)
+		s.log.WarnContext(ctx, "password rehash failed",
+			slog.String("user_id", am.UserID.String()),
+			slog.String("error", err.Error()))
+		return
 	}
 
-	s.log.InfoContext(ctx, "user logged in via password",
-		slog.String("user_id", user.ID.String()))
+	s.log.InfoContext(ctx, "password hash upgraded",
+		slog.String("user_id", am.UserID.String()))
+}
 
-	return result, nil
+// compareDummyPassword compares password against a throwaway hash so that
+// logins rejected before a real comparison (unknown email, no password
+// method, lockout) take as long as a wrong password and don't reveal whether
+// the account exists.
+func (s *Service) compareDummyPassword(password string) {
+	s.dummyHashOnce.Do(func() {
+		s.dummyHash, _ = s.hasher.Hash("dummy-password")
+	})
+	_ = s.hasher.Compare(s.dummyHash, password)
+}
+
+// loginAttemptKeys returns the failure-counter key for the email and the
+// full key set, which also includes the client IP when known.
+func loginAttemptKeys(ctx context.Context, email string) (string, []string) {
+	emailKey := "email:" + email
+	keys := []string{emailKey}
+	if ip := ctxutil.ClientIPFromCtx(ctx); ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return emailKey, keys
 }
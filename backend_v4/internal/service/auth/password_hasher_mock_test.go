@@ -0,0 +1,168 @@
+// Code generated by moq; DO NOT EDIT.
+// github.com/matryer/moq
+
+package auth
+
+import (
+	"sync"
+)
+
+// Ensure, that passwordHasherMock does implement passwordHasher.
+// If this is not the case, regenerate this file with moq.
+var _ passwordHasher = &passwordHasherMock{}
+
+// passwordHasherMock is a mock implementation of passwordHasher.
+//
+//	func TestSomethingThatUsespasswordHasher(t *testing.T) {
+//
+//		// make and configure a mocked passwordHasher
+//		mockedpasswordHasher := &passwordHasherMock{
+//			CompareFunc: func(hash string, password string) error {
+//				panic("mock out the Compare method")
+//			},
+//			HashFunc: func(password string) (string, error) {
+//				panic("mock out the Hash method")
+//			},
+//			NeedsRehashFunc: func(hash string) bool {
+//				panic("mock out the NeedsRehash method")
+//			},
+//		}
+//
+//		// use mockedpasswordHasher in code that requires passwordHasher
+//		// and then make assertions.
+//
+//	}
+type passwordHasherMock struct {
+	// CompareFunc mocks the Compare method.
+	CompareFunc func(hash string, password string) error
+
+	// HashFunc mocks the Hash method.
+	HashFunc func(password string) (string, error)
+
+	// NeedsRehashFunc mocks the NeedsRehash method.
+	NeedsRehashFunc func(hash string) bool
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// Compare holds details about calls to the Compare method.
+		Compare []struct {
+			// Hash is the hash argument value.
+			Hash string
+			// Password is the password argument value.
+			Password string
+		}
+		// Hash holds details about calls to the Hash method.
+		Hash []struct {
+			// Password is the password argument value.
+			Password string
+		}
+		// NeedsRehash holds details about calls to the NeedsRehash method.
+		NeedsRehash []struct {
+			// Hash is the hash argument value.
+			Hash string
+		}
+	}
+	lockCompare     sync.RWMutex
+	lockHash        sync.RWMutex
+	lockNeedsRehash sync.RWMutex
+}
+
+// Compare calls CompareFunc.
+func (mock *passwordHasherMock) Compare(hash string, password string) error {
+	if mock.CompareFunc == nil {
+		panic("passwordHasherMock.CompareFunc: method is nil but passwordHasher.Compare was just called")
+	}
+	callInfo := struct {
+		Hash     string
+		Password string
+	}{
+		Hash:     hash,
+		Password: password,
+	}
+	mock.lockCompare.Lock()
+	mock.calls.Compare = append(mock.calls.Compare, callInfo)
+	mock.lockCompare.Unlock()
+	return mock.CompareFunc(hash, password)
+}
+
+// CompareCalls gets all the calls that were made to Compare.
+// Check the length with:
+//
+//	len(mockedpasswordHasher.CompareCalls())
+func (mock *passwordHasherMock) CompareCalls() []struct {
+	Hash     string
+	Password string
+} {
+	var calls []struct {
+		Hash     string
+		Password string
+	}
+	mock.lockCompare.RLock()
+	calls = mock.calls.Compare
+	mock.lockCompare.RUnlock()
+	return calls
+}
+
+// Hash calls HashFunc.
+func (mock *passwordHasherMock) Hash(password string) (string, error) {
+	if mock.HashFunc == nil {
+		panic("passwordHasherMock.HashFunc: method is nil but passwordHasher.Hash was just called")
+	}
+	callInfo := struct {
+		Password string
+	}{
+		Password: password,
+	}
+	mock.lockHash.Lock()
+	mock.calls.Hash = append(mock.calls.Hash, callInfo)
+	mock.lockHash.Unlock()
+	return mock.HashFunc(password)
+}
+
+// HashCalls gets all the calls that were made to Hash.
+// Check the length with:
+//
+//	len(mockedpasswordHasher.HashCalls())
+func (mock *passwordHasherMock) HashCalls() []struct {
+	Password string
+} {
+	var calls []struct {
+		Password string
+	}
+	mock.lockHash.RLock()
+	calls = mock.calls.Hash
+	mock.lockHash.RUnlock()
+	return calls
+}
+
+// NeedsRehash calls NeedsRehashFunc.
+func (mock *passwordHasherMock) NeedsRehash(hash string) bool {
+	if mock.NeedsRehashFunc == nil {
+		panic("passwordHasherMock.NeedsRehashFunc: method is nil but passwordHasher.NeedsRehash was just called")
+	}
+	callInfo := struct {
+		Hash string
+	}{
+		Hash: hash,
+	}
+	mock.lockNeedsRehash.Lock()
+	mock.calls.NeedsRehash = append(mock.calls.NeedsRehash, callInfo)
+	mock.lockNeedsRehash.Unlock()
+	return mock.NeedsRehashFunc(hash)
+}
+
+// NeedsRehashCalls gets all the calls that were made to NeedsRehash.
+// Check the length with:
+//
+//	len(mockedpasswordHasher.NeedsRehashCalls())
+func (mock *passwordHasherMock) NeedsRehashCalls() []struct {
+	Hash string
+} {
+	var calls []struct {
+		Hash string
+	}
+	mock.lockNeedsRehash.RLock()
+	calls = mock.calls.NeedsRehash
+	mock.lockNeedsRehash.RUnlock()
+	return calls
+}
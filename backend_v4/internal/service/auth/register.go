@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
@@ -27,11 +26,10 @@ func (s *Service) Register(ctx context.Context, input RegisterInput) (*AuthResul
 	}
 
 	// Step 2: Hash password
-	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), s.cfg.PasswordHashCost)
+	hashStr, err := s.hasher.Hash(input.Password)
 	if err != nil {
 		return nil, fmt.Errorf("auth.Register hash password: %w", err)
 	}
-	hashStr := string(hash)
 
 	// Step 3: Create user + auth method + settings in a transaction.
 	// Email and username uniqueness are enforced by DB constraints.
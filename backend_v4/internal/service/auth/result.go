@@ -1,6 +1,10 @@
 package auth
 
-import "github.com/heartmarshall/myenglish-backend/internal/domain"
+import (
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
 
 // AuthResult is returned by Login and Refresh operations.
 type AuthResult struct {
@@ -8,3 +12,10 @@ type AuthResult struct {
 	RefreshToken string // raw token, NOT hash
 	User         *domain.User
 }
+
+// AuthMethodInfo describes a sign-in method linked to the account, without
+// its credentials. Method is "password" or the OAuth provider.
+type AuthMethodInfo struct {
+	Method    domain.AuthMethodType
+	CreatedAt time.Time
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,6 +19,8 @@ type userRepo interface {
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
 	Update(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error)
+	DeleteOwnedData(ctx context.Context, userID uuid.UUID) error
+	Anonymize(ctx context.Context, id uuid.UUID) (*domain.User, error)
 }
 
 // settingsRepo defines the settings repository interface needed by auth service.
@@ -39,6 +42,29 @@ type authMethodRepo interface {
 	GetByOAuth(ctx context.Context, method domain.AuthMethodType, providerID string) (*domain.AuthMethod, error)
 	GetByUserAndMethod(ctx context.Context, userID uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error)
 	Create(ctx context.Context, am *domain.AuthMethod) (*domain.AuthMethod, error)
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]domain.AuthMethod, error)
+	CountByUserForUpdate(ctx context.Context, userID uuid.UUID) (int, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// auditRepo defines the audit repository interface needed by auth service.
+type auditRepo interface {
+	Create(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error)
+}
+
+// loginAttempts defines the failed-login counter needed by auth service.
+type loginAttempts interface {
+	Locked(keys ...string) bool
+	RecordFailure(keys ...string)
+	Reset(key string)
+}
+
+// passwordHasher defines the password hashing needed by auth service.
+type passwordHasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+	NeedsRehash(hash string) bool
 }
 
 // txManager defines the transaction manager interface needed by auth service.
@@ -68,7 +94,13 @@ type Service struct {
 	tx          txManager
 	oauth       oauthVerifier
 	jwt         jwtManager
+	audit       auditRepo
+	attempts    loginAttempts
+	hasher      passwordHasher
 	cfg         config.AuthConfig
+
+	dummyHashOnce sync.Once
+	dummyHash     string
 }
 
 // NewService creates a new auth service instance.
@@ -92,10 +124,21 @@ func NewService(
 		tx:          tx,
 		oauth:       oauth,
 		jwt:         jwt,
+		hasher:      newPasswordHasher(cfg.PasswordAlgorithm, cfg.PasswordHashCost),
 		cfg:         cfg,
 	}
 }
 
+// SetAuditRepo enables the audit record written when an account is deleted.
+func (s *Service) SetAuditRepo(audit auditRepo) {
+	s.audit = audit
+}
+
+// SetLoginAttempts enables lockout of password logins after repeated failures.
+func (s *Service) SetLoginAttempts(attempts loginAttempts) {
+	s.attempts = attempts
+}
+
 // issueTokens generates access and refresh tokens for the given user, stores
 // the refresh token hash in DB, and returns an AuthResult.
 func (s *Service) issueTokens(ctx context.Context, user *domain.User) (*AuthResult, error) {
@@ -145,7 +188,11 @@ func ptrStringNotEqual(a, b *string) bool {
 }
 
 // profileChanged checks if the OAuth identity profile differs from the stored user profile.
+// Profiles the user has edited themselves are never synced from the provider.
 func profileChanged(user *domain.User, identity *auth.OAuthIdentity) bool {
+	if user.ProfileCustomized {
+		return false
+	}
 	if identity.Name != nil && *identity.Name != user.Name {
 		return true
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +24,8 @@ import (
 //go:generate moq -out tx_manager_mock_test.go -pkg auth . txManager
 //go:generate moq -out oauth_verifier_mock_test.go -pkg auth . oauthVerifier
 //go:generate moq -out jwt_manager_mock_test.go -pkg auth . jwtManager
+//go:generate moq -out audit_repo_mock_test.go -pkg auth . auditRepo
+//go:generate moq -out password_hasher_mock_test.go -pkg auth . passwordHasher
 
 // defaultCfg returns a config suitable for most tests.
 func defaultCfg() config.AuthConfig {
@@ -382,6 +385,76 @@ func TestService_Login_ProfileChanged(t *testing.T) {
 	}
 }
 
+func TestService_Login_CustomizedProfileNotOverwritten(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	// The user has set their own name and avatar via UpdateProfile.
+	existingUser := &domain.User{
+		ID:                userID,
+		Email:             "test@example.com",
+		Username:          "test",
+		Name:              "My Chosen Name",
+		AvatarURL:         ptrString("https://example.com/my_avatar.jpg"),
+		ProfileCustomized: true,
+	}
+
+	oauthMock := &oauthVerifierMock{
+		VerifyCodeFunc: func(ctx context.Context, p, c string) (*auth.OAuthIdentity, error) {
+			return &auth.OAuthIdentity{
+				ProviderID: "google_123",
+				Email:      "test@example.com",
+				Name:       ptrString("Provider Name"),
+				AvatarURL:  ptrString("https://example.com/provider_avatar.jpg"),
+			}, nil
+		},
+	}
+	authMethodsMock := &authMethodRepoMock{
+		GetByOAuthFunc: func(ctx context.Context, method domain.AuthMethodType, providerID string) (*domain.AuthMethod, error) {
+			return &domain.AuthMethod{ID: uuid.New(), UserID: userID, Method: method, ProviderID: &providerID}, nil
+		},
+	}
+	usersMock := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return existingUser, nil
+		},
+	}
+	jwtMock := &jwtManagerMock{
+		GenerateAccessTokenFunc: func(uid uuid.UUID, role string) (string, error) {
+			return "access_token_123", nil
+		},
+		GenerateRefreshTokenFunc: func() (string, string, error) {
+			return "raw_refresh_123", "hash_refresh_123", nil
+		},
+	}
+	tokensMock := &tokenRepoMock{
+		CreateFunc: func(ctx context.Context, token *domain.RefreshToken) error {
+			return nil
+		},
+	}
+
+	svc := NewService(
+		slog.Default(), usersMock, &settingsRepoMock{}, tokensMock, authMethodsMock,
+		&txManagerMock{}, oauthMock, jwtMock, defaultCfg(),
+	)
+
+	result, err := svc.Login(ctx, LoginInput{Provider: "google", Code: "auth_code_123"})
+	if err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+	if len(usersMock.UpdateCalls()) != 0 {
+		t.Errorf("Update called %d times, want 0", len(usersMock.UpdateCalls()))
+	}
+	if result.User.Name != "My Chosen Name" {
+		t.Errorf("User.Name: got=%s, want=%s", result.User.Name, "My Chosen Name")
+	}
+	if result.User.AvatarURL == nil || *result.User.AvatarURL != "https://example.com/my_avatar.jpg" {
+		t.Errorf("User.AvatarURL: got=%v, want=%s", result.User.AvatarURL, "https://example.com/my_avatar.jpg")
+	}
+}
+
 func TestService_Login_ProfileNotChanged(t *testing.T) {
 	t.Parallel()
 
@@ -1354,6 +1427,73 @@ func TestService_LoginWithPassword_NoPasswordMethod(t *testing.T) {
 	}
 }
 
+func TestService_LoginWithPassword_DummyCompareForUnknownAccounts(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tests := []struct {
+		name      string
+		getUser   func(ctx context.Context, email string) (*domain.User, error)
+		getMethod func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error)
+	}{
+		{
+			name: "user not found",
+			getUser: func(ctx context.Context, email string) (*domain.User, error) {
+				return nil, domain.ErrNotFound
+			},
+		},
+		{
+			name: "no password method",
+			getUser: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{ID: userID, Email: email}, nil
+			},
+			getMethod: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+				return nil, domain.ErrNotFound
+			},
+		},
+		{
+			name: "password method without hash",
+			getUser: func(ctx context.Context, email string) (*domain.User, error) {
+				return &domain.User{ID: userID, Email: email}, nil
+			},
+			getMethod: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+				return &domain.AuthMethod{ID: uuid.New(), UserID: uid, Method: method}, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bc := bcryptHasher{cost: 4}
+			hasherMock := &passwordHasherMock{HashFunc: bc.Hash, CompareFunc: bc.Compare}
+
+			svc := NewService(
+				slog.Default(), &userRepoMock{GetByEmailFunc: tt.getUser}, &settingsRepoMock{}, &tokenRepoMock{},
+				&authMethodRepoMock{GetByUserAndMethodFunc: tt.getMethod}, &txManagerMock{}, &oauthVerifierMock{}, &jwtManagerMock{}, defaultCfg(),
+			)
+			svc.hasher = hasherMock
+
+			_, err := svc.LoginWithPassword(context.Background(), LoginPasswordInput{
+				Email:    "someone@example.com",
+				Password: "password123",
+			})
+
+			if !errors.Is(err, domain.ErrUnauthorized) {
+				t.Fatalf("LoginWithPassword error: got=%v, want=ErrUnauthorized", err)
+			}
+			calls := hasherMock.CompareCalls()
+			if len(calls) != 1 {
+				t.Fatalf("Compare calls: got=%d, want=1", len(calls))
+			}
+			if calls[0].Password != "password123" {
+				t.Errorf("Compare password: got=%q, want=%q", calls[0].Password, "password123")
+			}
+		})
+	}
+}
+
 func TestService_LoginWithPassword_WrongPassword(t *testing.T) {
 	t.Parallel()
 
@@ -1459,6 +1599,189 @@ func TestService_LoginWithPassword_ValidationErrors(t *testing.T) {
 
 // ─── Refresh Tests ──────────────────────────────────────────────────────────
 
+func passwordLoginFixture(t *testing.T, password string, maxFailures int) (*Service, *userRepoMock) {
+	t.Helper()
+
+	userID := uuid.New()
+	passHash := hashPassword(t, password)
+
+	usersMock := &userRepoMock{
+		GetByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+			return &domain.User{ID: userID, Email: email, Username: "testuser"}, nil
+		},
+	}
+	authMethodsMock := &authMethodRepoMock{
+		GetByUserAndMethodFunc: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+			return &domain.AuthMethod{ID: uuid.New(), UserID: uid, Method: method, PasswordHash: &passHash}, nil
+		},
+	}
+	jwtMock := &jwtManagerMock{
+		GenerateAccessTokenFunc: func(uid uuid.UUID, role string) (string, error) {
+			return "access_token", nil
+		},
+		GenerateRefreshTokenFunc: func() (string, string, error) {
+			return "raw_refresh", "hash_refresh", nil
+		},
+	}
+	tokensMock := &tokenRepoMock{
+		CreateFunc: func(ctx context.Context, token *domain.RefreshToken) error {
+			return nil
+		},
+	}
+
+	svc := NewService(
+		slog.Default(), usersMock, &settingsRepoMock{}, tokensMock, authMethodsMock,
+		&txManagerMock{}, &oauthVerifierMock{}, jwtMock, defaultCfg(),
+	)
+	svc.SetLoginAttempts(auth.NewLoginAttempts(maxFailures, time.Hour))
+	return svc, usersMock
+}
+
+func TestService_LoginWithPassword_LockoutAfterFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithClientIP(context.Background(), "192.0.2.1")
+	svc, usersMock := passwordLoginFixture(t, "correct_password", 3)
+	wrong := LoginPasswordInput{Email: "test@example.com", Password: "wrong_password"}
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.LoginWithPassword(ctx, wrong); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("attempt %d: got err=%v, want ErrUnauthorized", i+1, err)
+		}
+	}
+
+	// Locked out even with the correct password; credentials are not checked.
+	_, err := svc.LoginWithPassword(ctx, LoginPasswordInput{Email: "test@example.com", Password: "correct_password"})
+	if !errors.Is(err, domain.ErrTooManyAttempts) {
+		t.Fatalf("got err=%v, want ErrTooManyAttempts", err)
+	}
+	if n := len(usersMock.GetByEmailCalls()); n != 3 {
+		t.Errorf("GetByEmail calls: got=%d, want=3", n)
+	}
+
+	// The client IP is locked out for other emails too.
+	_, err = svc.LoginWithPassword(ctx, LoginPasswordInput{Email: "other@example.com", Password: "correct_password"})
+	if !errors.Is(err, domain.ErrTooManyAttempts) {
+		t.Errorf("other email from same IP: got err=%v, want ErrTooManyAttempts", err)
+	}
+
+	// A different IP can still log into the other account.
+	otherCtx := ctxutil.WithClientIP(context.Background(), "198.51.100.2")
+	if _, err := svc.LoginWithPassword(otherCtx, LoginPasswordInput{Email: "other@example.com", Password: "correct_password"}); err != nil {
+		t.Errorf("other email from other IP: unexpected error: %v", err)
+	}
+}
+
+func TestService_LoginWithPassword_SuccessResetsFailures(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	svc, _ := passwordLoginFixture(t, "correct_password", 3)
+	wrong := LoginPasswordInput{Email: "test@example.com", Password: "wrong_password"}
+	right := LoginPasswordInput{Email: "test@example.com", Password: "correct_password"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.LoginWithPassword(ctx, wrong); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("attempt %d: got err=%v, want ErrUnauthorized", i+1, err)
+		}
+	}
+	if _, err := svc.LoginWithPassword(ctx, right); err != nil {
+		t.Fatalf("correct password: unexpected error: %v", err)
+	}
+
+	// The counter starts over: two more failures do not lock the account.
+	for i := 0; i < 2; i++ {
+		if _, err := svc.LoginWithPassword(ctx, wrong); !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("attempt %d after reset: got err=%v, want ErrUnauthorized", i+1, err)
+		}
+	}
+	if _, err := svc.LoginWithPassword(ctx, right); err != nil {
+		t.Errorf("correct password after reset: unexpected error: %v", err)
+	}
+}
+
+func TestService_LoginWithPassword_UpgradesLegacyHash(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	userID := uuid.New()
+	amID := uuid.New()
+	password := "correct_password"
+	legacyHash := hashPassword(t, password)
+
+	usersMock := &userRepoMock{
+		GetByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
+			return &domain.User{ID: userID, Email: email, Username: "testuser"}, nil
+		},
+	}
+	authMethodsMock := &authMethodRepoMock{
+		GetByUserAndMethodFunc: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+			return &domain.AuthMethod{ID: amID, UserID: uid, Method: method, PasswordHash: &legacyHash}, nil
+		},
+		UpdatePasswordHashFunc: func(ctx context.Context, id uuid.UUID, hash string) error {
+			return nil
+		},
+	}
+	jwtMock := &jwtManagerMock{
+		GenerateAccessTokenFunc: func(uid uuid.UUID, role string) (string, error) {
+			return "access_token", nil
+		},
+		GenerateRefreshTokenFunc: func() (string, string, error) {
+			return "raw_refresh", "hash_refresh", nil
+		},
+	}
+	tokensMock := &tokenRepoMock{
+		CreateFunc: func(ctx context.Context, token *domain.RefreshToken) error {
+			return nil
+		},
+	}
+
+	cfg := defaultCfg()
+	cfg.PasswordAlgorithm = "argon2id"
+
+	svc := NewService(
+		slog.Default(), usersMock, &settingsRepoMock{}, tokensMock, authMethodsMock,
+		&txManagerMock{}, &oauthVerifierMock{}, jwtMock, cfg,
+	)
+
+	if _, err := svc.LoginWithPassword(ctx, LoginPasswordInput{Email: "test@example.com", Password: password}); err != nil {
+		t.Fatalf("LoginWithPassword: unexpected error: %v", err)
+	}
+
+	calls := authMethodsMock.UpdatePasswordHashCalls()
+	if len(calls) != 1 {
+		t.Fatalf("UpdatePasswordHash calls: got=%d, want=1", len(calls))
+	}
+	if calls[0].ID != amID {
+		t.Errorf("UpdatePasswordHash id: got=%s, want=%s", calls[0].ID, amID)
+	}
+	if !strings.HasPrefix(calls[0].Hash, "$argon2id$") {
+		t.Errorf("new hash is not argon2id: %s", calls[0].Hash)
+	}
+	if err := comparePassword(calls[0].Hash, password); err != nil {
+		t.Errorf("new hash does not verify the password: %v", err)
+	}
+
+	// Once upgraded, the hash is left alone.
+	if svc.hasher.NeedsRehash(calls[0].Hash) {
+		t.Error("upgraded hash still needs rehash")
+	}
+}
+
+func TestService_LoginWithPassword_RehashFailureDoesNotFailLogin(t *testing.T) {
+	t.Parallel()
+
+	svc, _ := passwordLoginFixture(t, "correct_password", 5)
+	svc.hasher = argon2idHasher{}
+	svc.authMethods.(*authMethodRepoMock).UpdatePasswordHashFunc = func(ctx context.Context, id uuid.UUID, hash string) error {
+		return errors.New("db down")
+	}
+
+	if _, err := svc.LoginWithPassword(context.Background(), LoginPasswordInput{Email: "test@example.com", Password: "correct_password"}); err != nil {
+		t.Fatalf("LoginWithPassword: unexpected error: %v", err)
+	}
+}
+
 func TestService_Refresh_Success(t *testing.T) {
 	t.Parallel()
 
@@ -2035,6 +2358,346 @@ func TestService_Logout_RevokeError(t *testing.T) {
 	}
 }
 
+// ─── DeleteAccount Tests ────────────────────────────────────────────────────
+
+// deleteAccountFixture returns mocks for a live account; hash is the stored
+// password hash, or nil for an OAuth-only account.
+func deleteAccountFixture(t *testing.T, userID uuid.UUID, hash *string) (*Service, *userRepoMock, *auditRepoMock, *txManagerMock) {
+	t.Helper()
+
+	usersMock := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: id, Email: "user@example.com", Username: "user"}, nil
+		},
+		DeleteOwnedDataFunc: func(ctx context.Context, id uuid.UUID) error {
+			return nil
+		},
+		AnonymizeFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: id, Email: domain.AnonymizedUserEmail(id)}, nil
+		},
+	}
+	authMethodsMock := &authMethodRepoMock{
+		GetByUserAndMethodFunc: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+			if hash == nil {
+				return nil, domain.ErrNotFound
+			}
+			return &domain.AuthMethod{UserID: uid, Method: domain.AuthMethodPassword, PasswordHash: hash}, nil
+		},
+	}
+	auditMock := &auditRepoMock{
+		CreateFunc: func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
+			return record, nil
+		},
+	}
+	txMock := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+
+	svc := NewService(
+		slog.Default(), usersMock, &settingsRepoMock{}, &tokenRepoMock{}, authMethodsMock,
+		txMock, &oauthVerifierMock{}, &jwtManagerMock{}, defaultCfg(),
+	)
+	svc.SetAuditRepo(auditMock)
+	return svc, usersMock, auditMock, txMock
+}
+
+func TestService_DeleteAccount_PasswordAccount(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	hash := hashPassword(t, "correct-password")
+	svc, usersMock, auditMock, txMock := deleteAccountFixture(t, userID, &hash)
+
+	if err := svc.DeleteAccount(ctx, DeleteAccountInput{Password: "correct-password"}); err != nil {
+		t.Fatalf("DeleteAccount returned error: %v", err)
+	}
+
+	if len(txMock.RunInTxCalls()) != 1 {
+		t.Errorf("RunInTx called %d times, want 1", len(txMock.RunInTxCalls()))
+	}
+	if calls := usersMock.DeleteOwnedDataCalls(); len(calls) != 1 || calls[0].UserID != userID {
+		t.Errorf("DeleteOwnedData calls: %+v, want one for %s", calls, userID)
+	}
+	if calls := usersMock.AnonymizeCalls(); len(calls) != 1 || calls[0].ID != userID {
+		t.Errorf("Anonymize calls: %+v, want one for %s", calls, userID)
+	}
+
+	auditCalls := auditMock.CreateCalls()
+	if len(auditCalls) != 1 {
+		t.Fatalf("audit Create called %d times, want 1", len(auditCalls))
+	}
+	record := auditCalls[0].Record
+	if record.UserID != userID || record.EntityType != domain.EntityTypeUser || record.Action != domain.AuditActionDelete {
+		t.Errorf("audit record: got %+v", record)
+	}
+	if record.EntityID == nil || *record.EntityID != userID {
+		t.Errorf("audit record EntityID: got %v, want %s", record.EntityID, userID)
+	}
+}
+
+func TestService_DeleteAccount_WrongPassword(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	hash := hashPassword(t, "correct-password")
+	svc, usersMock, auditMock, _ := deleteAccountFixture(t, userID, &hash)
+
+	err := svc.DeleteAccount(ctx, DeleteAccountInput{Password: "wrong-password"})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("DeleteAccount error: got=%v, want=ErrUnauthorized", err)
+	}
+
+	// A confirmation phrase does not replace the password.
+	err = svc.DeleteAccount(ctx, DeleteAccountInput{Confirmation: DeleteAccountConfirmation})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("DeleteAccount with confirmation only: got=%v, want=ErrUnauthorized", err)
+	}
+
+	if len(usersMock.DeleteOwnedDataCalls()) != 0 || len(usersMock.AnonymizeCalls()) != 0 || len(auditMock.CreateCalls()) != 0 {
+		t.Error("account data modified despite wrong password")
+	}
+}
+
+func TestService_DeleteAccount_OAuthAccount(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	t.Run("confirmed", func(t *testing.T) {
+		svc, usersMock, _, _ := deleteAccountFixture(t, userID, nil)
+
+		if err := svc.DeleteAccount(ctx, DeleteAccountInput{Confirmation: DeleteAccountConfirmation}); err != nil {
+			t.Fatalf("DeleteAccount returned error: %v", err)
+		}
+		if len(usersMock.DeleteOwnedDataCalls()) != 1 {
+			t.Errorf("DeleteOwnedData called %d times, want 1", len(usersMock.DeleteOwnedDataCalls()))
+		}
+	})
+
+	t.Run("missing confirmation", func(t *testing.T) {
+		svc, usersMock, _, _ := deleteAccountFixture(t, userID, nil)
+
+		err := svc.DeleteAccount(ctx, DeleteAccountInput{Password: "some-password"})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("DeleteAccount error: got=%v, want=ErrValidation", err)
+		}
+		if len(usersMock.DeleteOwnedDataCalls()) != 0 {
+			t.Error("DeleteOwnedData called without confirmation")
+		}
+	})
+}
+
+func TestService_DeleteAccount_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	tests := []struct {
+		name    string
+		getByID func(ctx context.Context, id uuid.UUID) (*domain.User, error)
+	}{
+		{"already anonymized", func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: id, Email: domain.AnonymizedUserEmail(id)}, nil
+		}},
+		{"user row gone", func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return nil, domain.ErrNotFound
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, usersMock, _, txMock := deleteAccountFixture(t, userID, nil)
+			usersMock.GetByIDFunc = tt.getByID
+
+			if err := svc.DeleteAccount(ctx, DeleteAccountInput{Confirmation: DeleteAccountConfirmation}); err != nil {
+				t.Fatalf("DeleteAccount returned error: %v", err)
+			}
+			if len(txMock.RunInTxCalls()) != 0 {
+				t.Error("expected no transaction for an already deleted account")
+			}
+		})
+	}
+}
+
+func TestService_DeleteAccount_RollsBackOnFailure(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	svc, usersMock, auditMock, _ := deleteAccountFixture(t, userID, nil)
+
+	dbErr := errors.New("database error")
+	usersMock.AnonymizeFunc = func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+		return nil, dbErr
+	}
+
+	err := svc.DeleteAccount(ctx, DeleteAccountInput{Confirmation: DeleteAccountConfirmation})
+	if !errors.Is(err, dbErr) {
+		t.Fatalf("DeleteAccount error: got=%v, want wrapped %v", err, dbErr)
+	}
+	if len(auditMock.CreateCalls()) != 0 {
+		t.Error("audit record written for a failed deletion")
+	}
+}
+
+func TestService_DeleteAccount_Validation(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	t.Run("no user in context", func(t *testing.T) {
+		svc, _, _, _ := deleteAccountFixture(t, userID, nil)
+		err := svc.DeleteAccount(context.Background(), DeleteAccountInput{Confirmation: DeleteAccountConfirmation})
+		if !errors.Is(err, domain.ErrUnauthorized) {
+			t.Fatalf("DeleteAccount error: got=%v, want=ErrUnauthorized", err)
+		}
+	})
+
+	invalid := map[string]DeleteAccountInput{
+		"empty":              {},
+		"wrong confirmation": {Confirmation: "yes"},
+	}
+	for name, input := range invalid {
+		t.Run(name, func(t *testing.T) {
+			svc, usersMock, _, _ := deleteAccountFixture(t, userID, nil)
+			err := svc.DeleteAccount(ctxutil.WithUserID(context.Background(), userID), input)
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("DeleteAccount error: got=%v, want=ErrValidation", err)
+			}
+			if len(usersMock.GetByIDCalls()) != 0 {
+				t.Error("GetByID called for invalid input")
+			}
+		})
+	}
+}
+
+// ─── Auth Method Management Tests ───────────────────────────────────────────
+
+func authMethodsFixture(userID uuid.UUID, linked ...domain.AuthMethodType) (*Service, *authMethodRepoMock) {
+	methods := make([]domain.AuthMethod, len(linked))
+	for i, m := range linked {
+		hash := "secret-hash"
+		methods[i] = domain.AuthMethod{ID: uuid.New(), UserID: userID, Method: m, PasswordHash: &hash, CreatedAt: time.Now().Add(time.Duration(i) * time.Hour)}
+	}
+
+	authMethodsMock := &authMethodRepoMock{
+		ListByUserFunc: func(ctx context.Context, uid uuid.UUID) ([]domain.AuthMethod, error) {
+			return methods, nil
+		},
+		GetByUserAndMethodFunc: func(ctx context.Context, uid uuid.UUID, method domain.AuthMethodType) (*domain.AuthMethod, error) {
+			for i := range methods {
+				if methods[i].Method == method {
+					return &methods[i], nil
+				}
+			}
+			return nil, domain.ErrNotFound
+		},
+		CountByUserForUpdateFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return len(methods), nil
+		},
+		DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
+			return nil
+		},
+	}
+	txMock := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+
+	svc := NewService(
+		slog.Default(), &userRepoMock{}, &settingsRepoMock{}, &tokenRepoMock{}, authMethodsMock,
+		txMock, &oauthVerifierMock{}, &jwtManagerMock{}, defaultCfg(),
+	)
+	return svc, authMethodsMock
+}
+
+func TestService_ListAuthMethods(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	svc, _ := authMethodsFixture(userID, domain.AuthMethodPassword, domain.AuthMethodGoogle)
+
+	got, err := svc.ListAuthMethods(ctx)
+	if err != nil {
+		t.Fatalf("ListAuthMethods: unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Method != domain.AuthMethodPassword || got[1].Method != domain.AuthMethodGoogle {
+		t.Fatalf("ListAuthMethods: got=%+v, want password and google", got)
+	}
+	if got[0].CreatedAt.IsZero() {
+		t.Error("CreatedAt should be set")
+	}
+
+	if _, err := svc.ListAuthMethods(context.Background()); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("without user: got err=%v, want ErrUnauthorized", err)
+	}
+}
+
+func TestService_UnlinkAuthMethod_MultipleLinked(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	svc, authMethodsMock := authMethodsFixture(userID, domain.AuthMethodPassword, domain.AuthMethodGoogle)
+
+	if err := svc.UnlinkAuthMethod(ctx, domain.AuthMethodGoogle); err != nil {
+		t.Fatalf("UnlinkAuthMethod: unexpected error: %v", err)
+	}
+
+	calls := authMethodsMock.DeleteCalls()
+	if len(calls) != 1 {
+		t.Fatalf("Delete calls: got=%d, want=1", len(calls))
+	}
+	googleAM, _ := authMethodsMock.GetByUserAndMethod(ctx, userID, domain.AuthMethodGoogle)
+	if calls[0].ID != googleAM.ID {
+		t.Errorf("Delete id: got=%s, want=%s", calls[0].ID, googleAM.ID)
+	}
+}
+
+func TestService_UnlinkAuthMethod_LastMethodRefused(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	svc, authMethodsMock := authMethodsFixture(userID, domain.AuthMethodGoogle)
+
+	err := svc.UnlinkAuthMethod(ctx, domain.AuthMethodGoogle)
+
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("UnlinkAuthMethod: got err=%v, want ValidationError", err)
+	}
+	if len(authMethodsMock.DeleteCalls()) != 0 {
+		t.Error("Delete should not be called for the last method")
+	}
+}
+
+func TestService_UnlinkAuthMethod_NotLinked(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	svc, authMethodsMock := authMethodsFixture(userID, domain.AuthMethodPassword, domain.AuthMethodGoogle)
+
+	if err := svc.UnlinkAuthMethod(ctx, domain.AuthMethodApple); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("UnlinkAuthMethod: got err=%v, want ErrNotFound", err)
+	}
+	if err := svc.UnlinkAuthMethod(ctx, "facebook"); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("unknown method: got err=%v, want ErrValidation", err)
+	}
+	if len(authMethodsMock.DeleteCalls()) != 0 {
+		t.Error("Delete should not be called")
+	}
+}
+
 // ─── ValidateToken Tests ────────────────────────────────────────────────────
 
 func TestService_ValidateToken_ValidToken(t *testing.T) {
@@ -20,9 +20,15 @@ var _ userRepo = &userRepoMock{}
 //
 //		// make and configure a mocked userRepo
 //		mockeduserRepo := &userRepoMock{
+//			AnonymizeFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+//				panic("mock out the Anonymize method")
+//			},
 //			CreateFunc: func(ctx context.Context, user *domain.User) (*domain.User, error) {
 //				panic("mock out the Create method")
 //			},
+//			DeleteOwnedDataFunc: func(ctx context.Context, userID uuid.UUID) error {
+//				panic("mock out the DeleteOwnedData method")
+//			},
 //			GetByEmailFunc: func(ctx context.Context, email string) (*domain.User, error) {
 //				panic("mock out the GetByEmail method")
 //			},
@@ -39,9 +45,15 @@ var _ userRepo = &userRepoMock{}
 //
 //	}
 type userRepoMock struct {
+	// AnonymizeFunc mocks the Anonymize method.
+	AnonymizeFunc func(ctx context.Context, id uuid.UUID) (*domain.User, error)
+
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, user *domain.User) (*domain.User, error)
 
+	// DeleteOwnedDataFunc mocks the DeleteOwnedData method.
+	DeleteOwnedDataFunc func(ctx context.Context, userID uuid.UUID) error
+
 	// GetByEmailFunc mocks the GetByEmail method.
 	GetByEmailFunc func(ctx context.Context, email string) (*domain.User, error)
 
@@ -53,6 +65,13 @@ type userRepoMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// Anonymize holds details about calls to the Anonymize method.
+		Anonymize []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+		}
 		// Create holds details about calls to the Create method.
 		Create []struct {
 			// Ctx is the ctx argument value.
@@ -60,6 +79,13 @@ type userRepoMock struct {
 			// User is the user argument value.
 			User *domain.User
 		}
+		// DeleteOwnedData holds details about calls to the DeleteOwnedData method.
+		DeleteOwnedData []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+		}
 		// GetByEmail holds details about calls to the GetByEmail method.
 		GetByEmail []struct {
 			// Ctx is the ctx argument value.
@@ -86,10 +112,48 @@ type userRepoMock struct {
 			AvatarURL *string
 		}
 	}
-	lockCreate     sync.RWMutex
-	lockGetByEmail sync.RWMutex
-	lockGetByID    sync.RWMutex
-	lockUpdate     sync.RWMutex
+	lockAnonymize       sync.RWMutex
+	lockCreate          sync.RWMutex
+	lockDeleteOwnedData sync.RWMutex
+	lockGetByEmail      sync.RWMutex
+	lockGetByID         sync.RWMutex
+	lockUpdate          sync.RWMutex
+}
+
+// Anonymize calls AnonymizeFunc.
+func (mock *userRepoMock) Anonymize(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if mock.AnonymizeFunc == nil {
+		panic("userRepoMock.AnonymizeFunc: method is nil but userRepo.Anonymize was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}{
+		Ctx: ctx,
+		ID:  id,
+	}
+	mock.lockAnonymize.Lock()
+	mock.calls.Anonymize = append(mock.calls.Anonymize, callInfo)
+	mock.lockAnonymize.Unlock()
+	return mock.AnonymizeFunc(ctx, id)
+}
+
+// AnonymizeCalls gets all the calls that were made to Anonymize.
+// Check the length with:
+//
+//	len(mockeduserRepo.AnonymizeCalls())
+func (mock *userRepoMock) AnonymizeCalls() []struct {
+	Ctx context.Context
+	ID  uuid.UUID
+} {
+	var calls []struct {
+		Ctx context.Context
+		ID  uuid.UUID
+	}
+	mock.lockAnonymize.RLock()
+	calls = mock.calls.Anonymize
+	mock.lockAnonymize.RUnlock()
+	return calls
 }
 
 // Create calls CreateFunc.
@@ -128,6 +192,42 @@ func (mock *userRepoMock) CreateCalls() []struct {
 	return calls
 }
 
+// DeleteOwnedData calls DeleteOwnedDataFunc.
+func (mock *userRepoMock) DeleteOwnedData(ctx context.Context, userID uuid.UUID) error {
+	if mock.DeleteOwnedDataFunc == nil {
+		panic("userRepoMock.DeleteOwnedDataFunc: method is nil but userRepo.DeleteOwnedData was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+	}
+	mock.lockDeleteOwnedData.Lock()
+	mock.calls.DeleteOwnedData = append(mock.calls.DeleteOwnedData, callInfo)
+	mock.lockDeleteOwnedData.Unlock()
+	return mock.DeleteOwnedDataFunc(ctx, userID)
+}
+
+// DeleteOwnedDataCalls gets all the calls that were made to DeleteOwnedData.
+// Check the length with:
+//
+//	len(mockeduserRepo.DeleteOwnedDataCalls())
+func (mock *userRepoMock) DeleteOwnedDataCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+	}
+	mock.lockDeleteOwnedData.RLock()
+	calls = mock.calls.DeleteOwnedData
+	mock.lockDeleteOwnedData.RUnlock()
+	return calls
+}
+
 // GetByEmail calls GetByEmailFunc.
 func (mock *userRepoMock) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	if mock.GetByEmailFunc == nil {
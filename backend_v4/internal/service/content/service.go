@@ -13,9 +13,9 @@ import (
 // ---------------------------------------------------------------------------
 
 const (
-	MaxSensesPerEntry       = 20
-	MaxTranslationsPerSense = 20
-	MaxExamplesPerSense     = 50
+	MaxSensesPerEntry       = domain.MaxSensesPerEntry
+	MaxTranslationsPerSense = domain.MaxTranslationsPerSense
+	MaxExamplesPerSense     = domain.MaxExamplesPerSense
 	MaxUserImagesPerEntry   = 20
 )
 
@@ -0,0 +1,425 @@
+package dictionary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/internal/service/topic"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ---------------------------------------------------------------------------
+// 22. ExportBackup
+// ---------------------------------------------------------------------------
+
+// ExportBackup builds a versioned snapshot of the user's entries with their
+// senses, translations, examples, notes, card SRS state and topics. A backup
+// is never partial: dictionaries larger than ExportMaxEntries are rejected.
+func (s *Service) ExportBackup(ctx context.Context) (domain.Backup, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return domain.Backup{}, domain.ErrUnauthorized
+	}
+
+	backup := domain.Backup{
+		Version:    domain.BackupVersion,
+		ExportedAt: time.Now().UTC(),
+		Entries:    []domain.BackupEntry{},
+		Topics:     []domain.BackupTopic{},
+	}
+
+	entries, total, err := s.entries.Find(ctx, userID, domain.EntryFilter{
+		SortBy:    "created_at",
+		SortOrder: "ASC",
+		Limit:     s.cfg.ExportMaxEntries,
+	})
+	if err != nil {
+		return domain.Backup{}, fmt.Errorf("find entries for backup: %w", err)
+	}
+	if total > len(entries) {
+		return domain.Backup{}, domain.NewValidationError("entries",
+			fmt.Sprintf("too many entries to back up (max %d)", s.cfg.ExportMaxEntries))
+	}
+	if len(entries) == 0 {
+		return backup, nil
+	}
+
+	entryIDs := make([]uuid.UUID, len(entries))
+	exported := make(map[uuid.UUID]bool, len(entries))
+	for i, e := range entries {
+		entryIDs[i] = e.ID
+		exported[e.ID] = true
+	}
+
+	senses, err := s.senses.GetByEntryIDs(ctx, entryIDs)
+	if err != nil {
+		return domain.Backup{}, fmt.Errorf("get senses: %w", err)
+	}
+
+	sensesByEntry := make(map[uuid.UUID][]domain.Sense)
+	var senseIDs []uuid.UUID
+	for _, sense := range senses {
+		sensesByEntry[sense.EntryID] = append(sensesByEntry[sense.EntryID], sense)
+		senseIDs = append(senseIDs, sense.ID)
+	}
+
+	var translations []domain.Translation
+	var examples []domain.Example
+	if len(senseIDs) > 0 {
+		translations, err = s.translations.GetBySenseIDs(ctx, senseIDs)
+		if err != nil {
+			return domain.Backup{}, fmt.Errorf("get translations: %w", err)
+		}
+		examples, err = s.examples.GetBySenseIDs(ctx, senseIDs)
+		if err != nil {
+			return domain.Backup{}, fmt.Errorf("get examples: %w", err)
+		}
+	}
+
+	translationsBySense := make(map[uuid.UUID][]domain.Translation)
+	for _, tr := range translations {
+		translationsBySense[tr.SenseID] = append(translationsBySense[tr.SenseID], tr)
+	}
+	examplesBySense := make(map[uuid.UUID][]domain.Example)
+	for _, ex := range examples {
+		examplesBySense[ex.SenseID] = append(examplesBySense[ex.SenseID], ex)
+	}
+
+	cards, err := s.cards.GetByEntryIDs(ctx, userID, entryIDs)
+	if err != nil {
+		return domain.Backup{}, fmt.Errorf("get cards: %w", err)
+	}
+	cardByEntry := make(map[uuid.UUID]domain.Card, len(cards))
+	for _, c := range cards {
+		cardByEntry[c.EntryID] = c
+	}
+
+	for _, entry := range entries {
+		be := domain.BackupEntry{
+			ID:        entry.ID,
+			Text:      entry.Text,
+			Notes:     entry.Notes,
+			CreatedAt: entry.CreatedAt,
+			Senses:    []domain.BackupSense{},
+		}
+
+		for _, sense := range sensesByEntry[entry.ID] {
+			bs := domain.BackupSense{
+				Definition:   sense.Definition,
+				PartOfSpeech: sense.PartOfSpeech,
+				CEFRLevel:    sense.CEFRLevel,
+				SourceSlug:   sense.SourceSlug,
+				Translations: []string{},
+				Examples:     []domain.BackupExample{},
+			}
+			for _, tr := range translationsBySense[sense.ID] {
+				if tr.Text != nil {
+					bs.Translations = append(bs.Translations, *tr.Text)
+				}
+			}
+			for _, ex := range examplesBySense[sense.ID] {
+				if ex.Sentence != nil {
+					bs.Examples = append(bs.Examples, domain.BackupExample{
+						Sentence:    *ex.Sentence,
+						Translation: ex.Translation,
+					})
+				}
+			}
+			be.Senses = append(be.Senses, bs)
+		}
+
+		if card, found := cardByEntry[entry.ID]; found {
+			be.Card = &domain.BackupCard{
+				State:         card.State,
+				Step:          card.Step,
+				Stability:     card.Stability,
+				Difficulty:    card.Difficulty,
+				Due:           card.Due,
+				LastReview:    card.LastReview,
+				Reps:          card.Reps,
+				Lapses:        card.Lapses,
+				ScheduledDays: card.ScheduledDays,
+				ElapsedDays:   card.ElapsedDays,
+			}
+		}
+
+		backup.Entries = append(backup.Entries, be)
+	}
+
+	if s.topics != nil {
+		topics, err := s.topics.List(ctx, userID)
+		if err != nil {
+			return domain.Backup{}, fmt.Errorf("list topics: %w", err)
+		}
+		for _, t := range topics {
+			ids, err := s.topics.GetEntryIDsByTopicID(ctx, t.ID)
+			if err != nil {
+				return domain.Backup{}, fmt.Errorf("get topic entries: %w", err)
+			}
+			bt := domain.BackupTopic{
+				Name:        t.Name,
+				Description: t.Description,
+				EntryIDs:    []uuid.UUID{},
+			}
+			for _, id := range ids {
+				if exported[id] {
+					bt.EntryIDs = append(bt.EntryIDs, id)
+				}
+			}
+			backup.Topics = append(backup.Topics, bt)
+		}
+	}
+
+	return backup, nil
+}
+
+// WriteBackup exports the user's backup and streams it to w as JSON.
+func (s *Service) WriteBackup(ctx context.Context, w io.Writer) error {
+	backup, err := s.ExportBackup(ctx)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(backup); err != nil {
+		return fmt.Errorf("encode backup: %w", err)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// 23. ImportBackup
+// ---------------------------------------------------------------------------
+
+// ImportBackup restores a JSON backup written by WriteBackup into the current
+// user's dictionary. Backup IDs are remapped to fresh ones. Entries whose text
+// already exists are skipped and reported; existing topics are reused by name.
+// Restored content is editable: catalog-derived senses come back as imported
+// custom content because their catalog links are not part of the backup.
+// Runs in a single transaction; result line numbers are 1-based entry positions.
+func (s *Service) ImportBackup(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	var backup domain.Backup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return nil, domain.NewValidationError("file", "invalid backup JSON")
+	}
+	if err := migrateBackup(&backup); err != nil {
+		return nil, err
+	}
+
+	count, err := s.entries.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count entries: %w", err)
+	}
+	if count+len(backup.Entries) > s.cfg.MaxEntriesPerUser {
+		return nil, domain.NewValidationError("file", "restoring this backup would exceed entry limit")
+	}
+
+	var result *ImportResult
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		result = &ImportResult{}
+		idMap := make(map[uuid.UUID]uuid.UUID, len(backup.Entries))
+		seen := make(map[string]bool, len(backup.Entries))
+
+		for i, be := range backup.Entries {
+			lineNumber := i + 1
+
+			normalized := domain.NormalizeText(be.Text)
+			reason := ""
+			switch {
+			case normalized == "":
+				reason = "empty text after normalization"
+			case len(be.Text) > 500:
+				reason = "text too long (max 500)"
+			case seen[normalized]:
+				reason = "duplicate within import"
+			}
+			if reason == "" {
+				_, getErr := s.entries.GetByText(txCtx, userID, normalized)
+				if getErr == nil {
+					reason = "entry already exists"
+				} else if !errors.Is(getErr, domain.ErrNotFound) {
+					return fmt.Errorf("check duplicate: %w", getErr)
+				}
+			}
+			if reason != "" {
+				result.Errors = append(result.Errors, ImportError{LineNumber: lineNumber, Text: be.Text, Reason: reason})
+				result.Skipped++
+				continue
+			}
+			seen[normalized] = true
+
+			newID, err := s.restoreBackupEntry(txCtx, userID, normalized, be)
+			if err != nil {
+				return err
+			}
+			idMap[be.ID] = newID
+			result.Imported++
+		}
+
+		return s.restoreBackupTopics(txCtx, userID, backup.Topics, idMap)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return result, nil
+}
+
+// migrateBackup upgrades an older backup to domain.BackupVersion in place.
+// Version 1 is the first format, so for now it only rejects unknown versions.
+func migrateBackup(b *domain.Backup) error {
+	switch {
+	case b.Version < 1:
+		return domain.NewValidationError("version", "missing or invalid backup version")
+	case b.Version > domain.BackupVersion:
+		return domain.NewValidationError("version",
+			fmt.Sprintf("unsupported backup version %d (max %d)", b.Version, domain.BackupVersion))
+	}
+	return nil
+}
+
+// restoreBackupEntry creates one entry with its content and card and returns
+// the new entry ID. Must run inside a transaction.
+func (s *Service) restoreBackupEntry(ctx context.Context, userID uuid.UUID, normalized string, be domain.BackupEntry) (uuid.UUID, error) {
+	now := time.Now().UTC()
+	createdAt := be.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+
+	created, err := s.entries.Create(ctx, &domain.Entry{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Text:           be.Text,
+		TextNormalized: normalized,
+		Notes:          be.Notes,
+		CreatedAt:      createdAt,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("create entry: %w", err)
+	}
+
+	for _, bs := range be.Senses {
+		slug := bs.SourceSlug
+		if !isUserSourced(slug) {
+			slug = importSourceSlug
+		}
+
+		sense, err := s.senses.CreateCustom(ctx, created.ID, bs.Definition, bs.PartOfSpeech, bs.CEFRLevel, slug)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("create sense: %w", err)
+		}
+		for _, tr := range bs.Translations {
+			if _, err := s.translations.CreateCustom(ctx, sense.ID, tr, slug); err != nil {
+				return uuid.Nil, fmt.Errorf("create translation: %w", err)
+			}
+		}
+		for _, ex := range bs.Examples {
+			if _, err := s.examples.CreateCustom(ctx, sense.ID, ex.Sentence, ex.Translation, slug); err != nil {
+				return uuid.Nil, fmt.Errorf("create example: %w", err)
+			}
+		}
+	}
+
+	if be.Card != nil {
+		card, err := s.cards.Create(ctx, userID, created.ID)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("create card: %w", err)
+		}
+		bc := be.Card
+		if bc.State != domain.CardStateNew && bc.State.IsValid() {
+			_, err = s.cards.UpdateSRS(ctx, userID, card.ID, domain.SRSUpdateParams{
+				State:         bc.State,
+				Step:          bc.Step,
+				Stability:     bc.Stability,
+				Difficulty:    bc.Difficulty,
+				Due:           bc.Due,
+				LastReview:    bc.LastReview,
+				Reps:          bc.Reps,
+				Lapses:        bc.Lapses,
+				ScheduledDays: bc.ScheduledDays,
+				ElapsedDays:   bc.ElapsedDays,
+				Version:       card.Version,
+			})
+			if err != nil {
+				return uuid.Nil, fmt.Errorf("update card srs: %w", err)
+			}
+		}
+	}
+
+	return created.ID, nil
+}
+
+// restoreBackupTopics creates missing topics (reusing existing ones by name)
+// and links them to the restored entries. Links to skipped entries are dropped.
+// New topics go through the same validation and per-user limit as CreateTopic.
+func (s *Service) restoreBackupTopics(ctx context.Context, userID uuid.UUID, topics []domain.BackupTopic, idMap map[uuid.UUID]uuid.UUID) error {
+	if s.topics == nil || len(topics) == 0 {
+		return nil
+	}
+
+	existing, err := s.topics.List(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list topics: %w", err)
+	}
+	topicByName := make(map[string]uuid.UUID, len(existing))
+	for _, t := range existing {
+		topicByName[t.Name] = t.ID
+	}
+
+	for _, bt := range topics {
+		name := strings.TrimSpace(bt.Name)
+		topicID, found := topicByName[name]
+		if !found {
+			if err := (topic.CreateTopicInput{Name: name, Description: bt.Description}).Validate(); err != nil {
+				return err
+			}
+			if len(topicByName) >= topic.MaxTopicsPerUser {
+				return domain.NewValidationError("topics",
+					fmt.Sprintf("restoring this backup would exceed topic limit (max %d)", topic.MaxTopicsPerUser))
+			}
+			created, err := s.topics.Create(ctx, userID, &domain.Topic{Name: name, Description: trimmedOrNil(bt.Description)})
+			if err != nil {
+				return fmt.Errorf("create topic: %w", err)
+			}
+			topicID = created.ID
+			topicByName[name] = topicID
+		}
+
+		for _, oldID := range bt.EntryIDs {
+			newID, restored := idMap[oldID]
+			if !restored {
+				continue
+			}
+			if err := s.topics.LinkEntry(ctx, newID, topicID); err != nil {
+				return fmt.Errorf("link topic: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// trimmedOrNil trims s and maps an empty result to nil, like CreateTopic does
+// for topic descriptions.
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
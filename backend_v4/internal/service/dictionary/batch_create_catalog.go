@@ -0,0 +1,101 @@
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ---------------------------------------------------------------------------
+// 24. BatchCreateFromCatalog
+// ---------------------------------------------------------------------------
+
+// BatchCreateFromCatalog creates entries with all catalog senses for a list of
+// words. Each word gets its own transaction, so one failure does not undo the
+// others. Words already in the dictionary or missing from the catalog are
+// reported, not treated as errors; once MaxEntriesPerUser is reached the
+// remaining words are reported as errors.
+func (s *Service) BatchCreateFromCatalog(ctx context.Context, input BatchCreateFromCatalogInput) (*BatchCreateFromCatalogResult, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	count, err := s.entries.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count entries: %w", err)
+	}
+
+	result := &BatchCreateFromCatalogResult{}
+	seen := make(map[string]bool, len(input.Texts))
+
+	for _, text := range input.Texts {
+		normalized := domain.NormalizeText(text)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Check the dictionary before the catalog, which may call an external API.
+		_, getErr := s.entries.GetByText(ctx, userID, normalized)
+		if getErr == nil {
+			result.SkippedExisting = append(result.SkippedExisting, normalized)
+			continue
+		}
+		if !errors.Is(getErr, domain.ErrNotFound) {
+			result.Errors = append(result.Errors, WordError{Text: normalized, Reason: "check duplicate: " + getErr.Error()})
+			continue
+		}
+
+		if count >= s.cfg.MaxEntriesPerUser {
+			result.Errors = append(result.Errors, WordError{Text: normalized, Reason: "entry limit reached"})
+			continue
+		}
+
+		refEntry, fetchErr := s.refCatalog.GetOrFetchEntry(ctx, normalized)
+		if fetchErr != nil {
+			if errors.Is(fetchErr, domain.ErrNotFound) {
+				result.NotFoundInCatalog = append(result.NotFoundInCatalog, normalized)
+			} else {
+				result.Errors = append(result.Errors, WordError{Text: normalized, Reason: "fetch from catalog: " + fetchErr.Error()})
+			}
+			continue
+		}
+
+		created, createErr := s.createFromRef(ctx, userID, refEntry, selectAll(refEntry), nil, input.CreateCards)
+		if createErr != nil {
+			if errors.Is(createErr, domain.ErrAlreadyExists) {
+				result.SkippedExisting = append(result.SkippedExisting, normalized)
+			} else {
+				result.Errors = append(result.Errors, WordError{Text: normalized, Reason: createErr.Error()})
+			}
+			continue
+		}
+
+		count++
+		result.Created = append(result.Created, created)
+		s.enqueueEnrichment(refEntry.ID)
+	}
+
+	s.log.InfoContext(ctx, "batch create from catalog",
+		slog.String("user_id", userID.String()),
+		slog.Int("created", len(result.Created)),
+		slog.Int("skipped_existing", len(result.SkippedExisting)),
+		slog.Int("not_found", len(result.NotFoundInCatalog)),
+		slog.Int("errors", len(result.Errors)),
+	)
+
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package dictionary
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// maxSimilarResults caps how many similar entries CheckSimilar returns.
+const maxSimilarResults = 10
+
+// ---------------------------------------------------------------------------
+// 19. CheckSimilar
+// ---------------------------------------------------------------------------
+
+// CheckSimilar returns the user's entries that resemble text: the same
+// normalized text, another form of the same lemma (when lemma data is set),
+// or a small edit distance away. It is advisory; nothing is blocked.
+func (s *Service) CheckSimilar(ctx context.Context, text string) ([]SimilarEntry, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	normalized := domain.NormalizeText(text)
+	if normalized == "" {
+		return []SimilarEntry{}, nil
+	}
+
+	lemma := s.lemmaOf(normalized)
+	forms := append([]string{normalized, lemma}, s.lemmaForms[lemma]...)
+
+	maxDist := maxEditDistance(utf8.RuneCountInString(normalized))
+
+	candidates, err := s.entries.FindSimilarCandidates(ctx, userID, forms, normalized, maxDist)
+	if err != nil {
+		return nil, fmt.Errorf("find similar candidates: %w", err)
+	}
+
+	similar := []SimilarEntry{}
+	for _, c := range candidates {
+		switch {
+		case c.TextNormalized == normalized:
+			similar = append(similar, SimilarEntry{Entry: c, Reason: SimilarityExact})
+		case s.lemmaOf(c.TextNormalized) == lemma:
+			similar = append(similar, SimilarEntry{
+				Entry:    c,
+				Reason:   SimilarityLemma,
+				Distance: levenshtein(normalized, c.TextNormalized),
+			})
+		default:
+			if d := levenshtein(normalized, c.TextNormalized); d <= maxDist {
+				similar = append(similar, SimilarEntry{Entry: c, Reason: SimilarityEditDistance, Distance: d})
+			}
+		}
+	}
+
+	rank := map[SimilarityReason]int{SimilarityExact: 0, SimilarityLemma: 1, SimilarityEditDistance: 2}
+	slices.SortFunc(similar, func(a, b SimilarEntry) int {
+		return cmp.Or(
+			cmp.Compare(rank[a.Reason], rank[b.Reason]),
+			cmp.Compare(a.Distance, b.Distance),
+			cmp.Compare(a.Entry.TextNormalized, b.Entry.TextNormalized),
+		)
+	})
+	if len(similar) > maxSimilarResults {
+		similar = similar[:maxSimilarResults]
+	}
+
+	return similar, nil
+}
+
+// lemmaOf returns the lemma of a normalized word, or the word itself when no
+// lemma data is known for it.
+func (s *Service) lemmaOf(normalized string) string {
+	if lemma, ok := s.lemmas[normalized]; ok {
+		return lemma
+	}
+	return normalized
+}
+
+// maxEditDistance is the largest edit distance still considered similar for
+// a word of n characters. Short words tolerate one edit, longer ones two.
+func maxEditDistance(n int) int {
+	switch {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levenshtein returns the edit distance between a and b, counted in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
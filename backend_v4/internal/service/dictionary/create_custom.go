@@ -22,7 +22,8 @@ func (s *Service) CreateEntryCustom(ctx context.Context, input CreateCustomInput
 		return nil, domain.ErrUnauthorized
 	}
 
-	if err := input.Validate(); err != nil {
+	input.normalize()
+	if err := input.validate(s.customEntryLimits()); err != nil {
 		return nil, err
 	}
 
@@ -49,8 +50,6 @@ func (s *Service) CreateEntryCustom(ctx context.Context, input CreateCustomInput
 		return nil, fmt.Errorf("check duplicate: %w", err)
 	}
 
-	const sourceSlug = "user"
-
 	var created *domain.Entry
 	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
 		now := time.Now().UTC()
@@ -72,19 +71,19 @@ func (s *Service) CreateEntryCustom(ctx context.Context, input CreateCustomInput
 
 		// Create senses and their children.
 		for _, si := range input.Senses {
-			sense, senseErr := s.senses.CreateCustom(txCtx, created.ID, si.Definition, si.PartOfSpeech, nil, sourceSlug)
+			sense, senseErr := s.senses.CreateCustom(txCtx, created.ID, si.Definition, si.PartOfSpeech, nil, userSourceSlug)
 			if senseErr != nil {
 				return fmt.Errorf("create custom sense: %w", senseErr)
 			}
 
 			for _, tr := range si.Translations {
-				if _, trErr := s.translations.CreateCustom(txCtx, sense.ID, tr, sourceSlug); trErr != nil {
+				if _, trErr := s.translations.CreateCustom(txCtx, sense.ID, tr, userSourceSlug); trErr != nil {
 					return fmt.Errorf("create custom translation: %w", trErr)
 				}
 			}
 
 			for _, ex := range si.Examples {
-				if _, exErr := s.examples.CreateCustom(txCtx, sense.ID, ex.Sentence, ex.Translation, sourceSlug); exErr != nil {
+				if _, exErr := s.examples.CreateCustom(txCtx, sense.ID, ex.Sentence, ex.Translation, userSourceSlug); exErr != nil {
 					return fmt.Errorf("create custom example: %w", exErr)
 				}
 			}
@@ -103,7 +102,7 @@ func (s *Service) CreateEntryCustom(ctx context.Context, input CreateCustomInput
 			EntityType: domain.EntityTypeEntry,
 			EntityID:   &created.ID,
 			Action:     domain.AuditActionCreate,
-			Changes:    map[string]any{"text": created.Text, "source": sourceSlug},
+			Changes:    map[string]any{"text": created.Text, "source": userSourceSlug},
 		})
 		if auditErr != nil {
 			return fmt.Errorf("audit create: %w", auditErr)
@@ -121,3 +120,19 @@ func (s *Service) CreateEntryCustom(ctx context.Context, input CreateCustomInput
 
 	return created, nil
 }
+
+// customEntryLimits returns the configured custom entry caps, falling back to
+// the defaults for unset values.
+func (s *Service) customEntryLimits() customEntryLimits {
+	limits := defaultCustomEntryLimits
+	if s.cfg.MaxCustomSenses > 0 {
+		limits.senses = s.cfg.MaxCustomSenses
+	}
+	if s.cfg.MaxCustomTranslations > 0 {
+		limits.translations = s.cfg.MaxCustomTranslations
+	}
+	if s.cfg.MaxCustomExamples > 0 {
+		limits.examples = s.cfg.MaxCustomExamples
+	}
+	return limits
+}
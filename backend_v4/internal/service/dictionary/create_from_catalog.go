@@ -73,7 +73,78 @@ func (s *Service) CreateEntryFromCatalog(ctx context.Context, input CreateFromCa
 		}
 	}
 
-	// Create entry in transaction.
+	// Determine which pronunciations and images to link.
+	selectedPronunciations, err := selectLinked(refEntry.Pronunciations, input.PronunciationIDs,
+		func(rp domain.RefPronunciation) uuid.UUID { return rp.ID }, "pronunciation_ids", "pronunciation")
+	if err != nil {
+		return nil, err
+	}
+	selectedImages, err := selectLinked(refEntry.Images, input.ImageIDs,
+		func(ri domain.RefImage) uuid.UUID { return ri.ID }, "image_ids", "image")
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.createFromRef(ctx, userID, refEntry, refSelection{
+		senses:         selectedSenses,
+		pronunciations: selectedPronunciations,
+		images:         selectedImages,
+	}, input.Notes, input.CreateCard)
+	if err != nil {
+		return nil, err
+	}
+
+	s.enqueueEnrichment(refEntry.ID)
+
+	return created, nil
+}
+
+// selectLinked returns the items of a ref entry picked by ids, in ids order.
+// Empty ids selects all items. An id that is not among the items is a
+// validation error on field; repeated ids are ignored, since an item can only
+// be linked to an entry once.
+func selectLinked[T any](items []T, ids []uuid.UUID, id func(T) uuid.UUID, field, noun string) ([]T, error) {
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	byID := make(map[uuid.UUID]T, len(items))
+	for _, item := range items {
+		byID[id(item)] = item
+	}
+
+	selected := make([]T, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, itemID := range ids {
+		item, found := byID[itemID]
+		if !found {
+			return nil, domain.NewValidationError(field, noun+" not found: "+itemID.String())
+		}
+		if seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+		selected = append(selected, item)
+	}
+	return selected, nil
+}
+
+// refSelection is the part of a reference entry copied into a new entry.
+type refSelection struct {
+	senses         []domain.RefSense
+	pronunciations []domain.RefPronunciation
+	images         []domain.RefImage
+}
+
+// selectAll selects every sense, pronunciation and image of refEntry.
+func selectAll(refEntry *domain.RefEntry) refSelection {
+	return refSelection{senses: refEntry.Senses, pronunciations: refEntry.Pronunciations, images: refEntry.Images}
+}
+
+// createFromRef creates an entry for refEntry with the selected senses,
+// pronunciations and images, an optional card, and an audit record, all in
+// one transaction.
+func (s *Service) createFromRef(ctx context.Context, userID uuid.UUID, refEntry *domain.RefEntry, sel refSelection, notes *string, createCard bool) (*domain.Entry, error) {
 	var created *domain.Entry
 	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
 		now := time.Now().UTC()
@@ -83,7 +154,7 @@ func (s *Service) CreateEntryFromCatalog(ctx context.Context, input CreateFromCa
 			RefEntryID:     &refEntry.ID,
 			Text:           refEntry.Text,
 			TextNormalized: refEntry.TextNormalized,
-			Notes:          input.Notes,
+			Notes:          notes,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 		}
@@ -95,7 +166,7 @@ func (s *Service) CreateEntryFromCatalog(ctx context.Context, input CreateFromCa
 		}
 
 		// Create senses and their children.
-		for _, rs := range selectedSenses {
+		for _, rs := range sel.senses {
 			sense, senseErr := s.senses.CreateFromRef(txCtx, created.ID, rs.ID, rs.SourceSlug)
 			if senseErr != nil {
 				return fmt.Errorf("create sense from ref: %w", senseErr)
@@ -117,22 +188,23 @@ func (s *Service) CreateEntryFromCatalog(ctx context.Context, input CreateFromCa
 		}
 
 		// Link pronunciations.
-		for _, rp := range refEntry.Pronunciations {
+		for _, rp := range sel.pronunciations {
 			if linkErr := s.pronunciations.Link(txCtx, created.ID, rp.ID); linkErr != nil {
 				return fmt.Errorf("link pronunciation: %w", linkErr)
 			}
 		}
 
 		// Link images.
-		for _, ri := range refEntry.Images {
+		for _, ri := range sel.images {
 			if linkErr := s.images.LinkCatalog(txCtx, created.ID, ri.ID); linkErr != nil {
 				return fmt.Errorf("link image: %w", linkErr)
 			}
 		}
 
-		// Create card if requested.
-		if input.CreateCard {
-			if _, cardErr := s.cards.Create(txCtx, userID, created.ID); cardErr != nil {
+		// Create card if requested, seeded from the frequency presets.
+		if createCard {
+			stability, difficulty := s.cardPresets.For(refEntry.FrequencyRank)
+			if _, cardErr := s.cards.CreateWithParams(txCtx, userID, created.ID, stability, difficulty); cardErr != nil {
 				return fmt.Errorf("create card: %w", cardErr)
 			}
 		}
@@ -160,14 +232,18 @@ func (s *Service) CreateEntryFromCatalog(ctx context.Context, input CreateFromCa
 		return nil, txErr
 	}
 
-	// Best-effort: enqueue for enrichment (don't fail the request).
-	if s.enrichment != nil && input.RefEntryID != uuid.Nil {
-		go func() {
-			if err := s.enrichment.Enqueue(context.Background(), input.RefEntryID); err != nil {
-				s.log.Warn("enrichment enqueue failed", "error", err.Error())
-			}
-		}()
-	}
-
 	return created, nil
 }
+
+// enqueueEnrichment queues refEntryID for enrichment in the background.
+// Best-effort: failures are only logged.
+func (s *Service) enqueueEnrichment(refEntryID uuid.UUID) {
+	if s.enrichment == nil || refEntryID == uuid.Nil {
+		return
+	}
+	go func() {
+		if err := s.enrichment.Enqueue(context.Background(), refEntryID); err != nil {
+			s.log.Warn("enrichment enqueue failed", "error", err.Error())
+		}
+	}()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -131,3 +132,61 @@ func (s *Service) BatchDeleteEntries(ctx context.Context, entryIDs []uuid.UUID)
 
 	return result, nil
 }
+
+// ---------------------------------------------------------------------------
+// 20. RestoreAllDeleted
+// ---------------------------------------------------------------------------
+
+// RestoreAllDeleted restores every soft-deleted entry still inside the
+// retention window in one transaction. Entries past the window are awaiting
+// purge and are only counted. An entry whose text has since been re-added
+// stays deleted.
+func (s *Service) RestoreAllDeleted(ctx context.Context) (*RestoreAllResult, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	threshold := time.Now().AddDate(0, 0, -s.cfg.HardDeleteRetentionDays)
+
+	result := &RestoreAllResult{}
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		pending, err := s.entries.CountDeletedBefore(txCtx, userID, threshold)
+		if err != nil {
+			return fmt.Errorf("count pending purge: %w", err)
+		}
+		result.PendingPurge = pending
+
+		restored, err := s.entries.RestoreBatch(txCtx, userID, threshold)
+		if err != nil {
+			return fmt.Errorf("restore batch: %w", err)
+		}
+		result.Restored = len(restored)
+
+		if len(restored) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(restored))
+		for i, id := range restored {
+			ids[i] = id.String()
+		}
+		_, err = s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeEntry,
+			Action:     domain.AuditActionUpdate,
+			Changes:    map[string]any{"batch_restore": ids, "count": len(ids)},
+		})
+		if err != nil {
+			return fmt.Errorf("audit restore all: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return result, nil
+}
@@ -52,6 +52,7 @@ func (s *Service) FindEntries(ctx context.Context, input FindInput) (*FindResult
 		PartOfSpeech: input.PartOfSpeech,
 		TopicID:      input.TopicID,
 		Status:       input.Status,
+		Favorite:     input.Favorite,
 		SortBy:       sortBy,
 		SortOrder:    sortOrder,
 		Limit:        limit,
@@ -124,8 +125,9 @@ func (s *Service) GetEntry(ctx context.Context, entryID uuid.UUID) (*domain.Entr
 // 7. FindDeletedEntries
 // ---------------------------------------------------------------------------
 
-// FindDeletedEntries returns soft-deleted entries for the user.
-func (s *Service) FindDeletedEntries(ctx context.Context, limit, offset int) ([]domain.Entry, int, error) {
+// FindDeletedEntries returns soft-deleted entries for the user, each with the
+// time it will be purged (DeletedAt + HardDeleteRetentionDays).
+func (s *Service) FindDeletedEntries(ctx context.Context, limit, offset int) ([]DeletedEntry, int, error) {
 	userID, ok := ctxutil.UserIDFromCtx(ctx)
 	if !ok {
 		return nil, 0, domain.ErrUnauthorized
@@ -133,5 +135,18 @@ func (s *Service) FindDeletedEntries(ctx context.Context, limit, offset int) ([]
 
 	limit = clampLimit(limit, 1, 200, 20)
 
-	return s.entries.FindDeleted(ctx, userID, limit, offset)
+	entries, total, err := s.entries.FindDeleted(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deleted := make([]DeletedEntry, len(entries))
+	for i, e := range entries {
+		deleted[i] = DeletedEntry{Entry: e}
+		if e.DeletedAt != nil {
+			deleted[i].PurgeAt = e.DeletedAt.AddDate(0, 0, s.cfg.HardDeleteRetentionDays)
+		}
+	}
+
+	return deleted, total, nil
 }
@@ -0,0 +1,276 @@
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+const (
+	maxAnkiNotes            = 5000
+	maxAnkiTranslations     = 10
+	maxAnkiTranslationWords = 4
+)
+
+// ---------------------------------------------------------------------------
+// 21. ImportAnki
+// ---------------------------------------------------------------------------
+
+// ImportAnki imports the notes of an Anki .apkg deck as custom entries. Each
+// note becomes an entry (front) with one sense whose definition is the back;
+// short non-Latin fragments of the back are also added as translations. Every
+// imported entry gets a card, and review cards keep their Anki due date and
+// interval. Per-chunk transactions, like ImportEntries; result line numbers
+// are 1-based note positions.
+func (s *Service) ImportAnki(ctx context.Context, r io.Reader) (*ImportResult, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if s.anki == nil {
+		return nil, fmt.Errorf("anki import is not configured")
+	}
+
+	notes, err := s.anki.ReadNotes(ctx, r)
+	if err != nil {
+		return nil, domain.NewValidationError("file", "not a readable Anki package: "+err.Error())
+	}
+	if len(notes) == 0 {
+		return nil, domain.NewValidationError("file", "package contains no notes")
+	}
+	if len(notes) > maxAnkiNotes {
+		return nil, domain.NewValidationError("file", fmt.Sprintf("too many notes (max %d)", maxAnkiNotes))
+	}
+
+	count, err := s.entries.CountByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("count entries: %w", err)
+	}
+	if count+len(notes) > s.cfg.MaxEntriesPerUser {
+		return nil, domain.NewValidationError("file", "importing these notes would exceed entry limit")
+	}
+
+	result := &ImportResult{}
+	seen := make(map[string]bool)
+
+	chunkSize := s.cfg.ImportChunkSize
+	if chunkSize <= 0 {
+		chunkSize = 50
+	}
+
+	for chunkStart := 0; chunkStart < len(notes); chunkStart += chunkSize {
+		chunkEnd := min(chunkStart+chunkSize, len(notes))
+		chunk := notes[chunkStart:chunkEnd]
+
+		var chunkImported int
+		var chunkSkipped int
+		var chunkErrors []ImportError
+		var chunkSeenTexts []string
+
+		txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+			// RunInTx reruns the closure after a serialization failure or
+			// deadlock; start each attempt from a clean slate.
+			for _, text := range chunkSeenTexts {
+				delete(seen, text)
+			}
+			chunkImported, chunkSkipped, chunkErrors, chunkSeenTexts = 0, 0, nil, nil
+
+			for i, note := range chunk {
+				lineNumber := chunkStart + i + 1
+
+				if reason := validateAnkiNote(note); reason != "" {
+					chunkErrors = append(chunkErrors, ImportError{
+						LineNumber: lineNumber,
+						Text:       note.Front,
+						Reason:     reason,
+					})
+					chunkSkipped++
+					continue
+				}
+
+				normalized := domain.NormalizeText(note.Front)
+				if seen[normalized] {
+					chunkErrors = append(chunkErrors, ImportError{
+						LineNumber: lineNumber,
+						Text:       note.Front,
+						Reason:     "duplicate within import",
+					})
+					chunkSkipped++
+					continue
+				}
+
+				_, getErr := s.entries.GetByText(txCtx, userID, normalized)
+				if getErr == nil {
+					chunkErrors = append(chunkErrors, ImportError{
+						LineNumber: lineNumber,
+						Text:       note.Front,
+						Reason:     "entry already exists",
+					})
+					chunkSkipped++
+					seen[normalized] = true
+					chunkSeenTexts = append(chunkSeenTexts, normalized)
+					continue
+				}
+				if !errors.Is(getErr, domain.ErrNotFound) {
+					return fmt.Errorf("check duplicate: %w", getErr)
+				}
+
+				seen[normalized] = true
+				chunkSeenTexts = append(chunkSeenTexts, normalized)
+
+				if err := s.importAnkiNote(txCtx, userID, normalized, note); err != nil {
+					return err
+				}
+				chunkImported++
+			}
+			return nil
+		})
+
+		if txErr != nil {
+			for _, text := range chunkSeenTexts {
+				delete(seen, text)
+			}
+			for i, note := range chunk {
+				result.Errors = append(result.Errors, ImportError{
+					LineNumber: chunkStart + i + 1,
+					Text:       note.Front,
+					Reason:     "chunk transaction failed: " + txErr.Error(),
+				})
+			}
+			result.Skipped += len(chunk)
+		} else {
+			result.Imported += chunkImported
+			result.Skipped += chunkSkipped
+			result.Errors = append(result.Errors, chunkErrors...)
+		}
+	}
+
+	return result, nil
+}
+
+// importAnkiNote creates the entry, sense, translations and card for a single
+// note. Must run inside a transaction.
+func (s *Service) importAnkiNote(ctx context.Context, userID uuid.UUID, normalized string, note domain.AnkiNote) error {
+	now := time.Now().UTC()
+	created, err := s.entries.Create(ctx, &domain.Entry{
+		ID:             uuid.New(),
+		UserID:         userID,
+		Text:           note.Front,
+		TextNormalized: normalized,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	})
+	if err != nil {
+		return fmt.Errorf("create entry: %w", err)
+	}
+
+	definition := note.Back
+	sense, err := s.senses.CreateCustom(ctx, created.ID, &definition, nil, nil, importSourceSlug)
+	if err != nil {
+		return fmt.Errorf("create sense: %w", err)
+	}
+
+	for _, tr := range ankiTranslations(note.Back) {
+		if _, err := s.translations.CreateCustom(ctx, sense.ID, tr, importSourceSlug); err != nil {
+			return fmt.Errorf("create translation: %w", err)
+		}
+	}
+
+	card, err := s.cards.Create(ctx, userID, created.ID)
+	if err != nil {
+		return fmt.Errorf("create card: %w", err)
+	}
+
+	if note.Schedule != nil {
+		params := ankiSRSParams(*note.Schedule)
+		params.Version = card.Version
+		if _, err := s.cards.UpdateSRS(ctx, userID, card.ID, params); err != nil {
+			return fmt.Errorf("update card srs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateAnkiNote returns the reason a note cannot be imported, or "".
+func validateAnkiNote(note domain.AnkiNote) string {
+	switch {
+	case domain.NormalizeText(note.Front) == "":
+		return "malformed note: empty front"
+	case strings.TrimSpace(note.Back) == "":
+		return "malformed note: empty back"
+	case len(note.Front) > 500:
+		return "malformed note: front too long (max 500)"
+	case len(note.Back) > 2000:
+		return "malformed note: back too long (max 2000)"
+	}
+	return ""
+}
+
+// ankiTranslations picks translation candidates out of a note's back: the
+// comma-, semicolon-, slash- or line-separated fragments that are short and
+// written in a non-Latin script. Longer or Latin fragments are most likely
+// part of an English definition and are kept only there.
+func ankiTranslations(back string) []string {
+	parts := strings.FieldsFunc(back, func(r rune) bool {
+		return r == ',' || r == ';' || r == '/' || r == '\n'
+	})
+
+	var out []string
+	seen := make(map[string]bool)
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" || len(p) > 500 || seen[p] {
+			continue
+		}
+		if len(strings.Fields(p)) > maxAnkiTranslationWords || !hasNonLatinLetter(p) {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+		if len(out) == maxAnkiTranslations {
+			break
+		}
+	}
+	return out
+}
+
+func hasNonLatinLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ankiSRSParams maps an Anki review schedule onto FSRS card state. The
+// interval is taken as stability, and the ease factor (2.5 = default) is
+// mapped linearly onto difficulty so that 2.5 → 5 and 1.3 → 10.
+func ankiSRSParams(sch domain.AnkiSchedule) domain.SRSUpdateParams {
+	difficulty := 5 - (sch.Ease-2.5)/0.24
+	difficulty = math.Max(1, math.Min(10, difficulty))
+
+	lastReview := sch.Due.AddDate(0, 0, -sch.IntervalDays)
+
+	return domain.SRSUpdateParams{
+		State:         domain.CardStateReview,
+		Stability:     float64(sch.IntervalDays),
+		Difficulty:    difficulty,
+		Due:           sch.Due,
+		LastReview:    &lastReview,
+		Reps:          sch.Reps,
+		Lapses:        sch.Lapses,
+		ScheduledDays: sch.IntervalDays,
+	}
+}
@@ -35,8 +35,6 @@ func (s *Service) ImportEntries(ctx context.Context, input ImportInput) (*Import
 		return nil, domain.NewValidationError("items", "importing these items would exceed entry limit")
 	}
 
-	const sourceSlug = "import"
-
 	result := &ImportResult{}
 	seen := make(map[string]bool)
 
@@ -61,6 +59,13 @@ func (s *Service) ImportEntries(ctx context.Context, input ImportInput) (*Import
 		var chunkSeenTexts []string
 
 		txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+			// RunInTx reruns the closure after a serialization failure or
+			// deadlock; start each attempt from a clean slate.
+			for _, text := range chunkSeenTexts {
+				delete(seen, text)
+			}
+			chunkImported, chunkSkipped, chunkErrors, chunkSeenTexts = 0, 0, nil, nil
+
 			for i, item := range chunk {
 				lineNumber := chunkStart + i + 1 // 1-based
 
@@ -124,13 +129,13 @@ func (s *Service) ImportEntries(ctx context.Context, input ImportInput) (*Import
 
 				// If translations provided, create a single sense with them.
 				if len(item.Translations) > 0 {
-					sense, senseErr := s.senses.CreateCustom(txCtx, created.ID, nil, nil, nil, sourceSlug)
+					sense, senseErr := s.senses.CreateCustom(txCtx, created.ID, nil, nil, nil, importSourceSlug)
 					if senseErr != nil {
 						return fmt.Errorf("create sense: %w", senseErr)
 					}
 
 					for _, tr := range item.Translations {
-						if _, trErr := s.translations.CreateCustom(txCtx, sense.ID, tr, sourceSlug); trErr != nil {
+						if _, trErr := s.translations.CreateCustom(txCtx, sense.ID, tr, importSourceSlug); trErr != nil {
 							return fmt.Errorf("create translation: %w", trErr)
 						}
 					}
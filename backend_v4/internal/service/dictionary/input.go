@@ -2,6 +2,7 @@ package dictionary
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -11,6 +12,10 @@ import (
 type CreateFromCatalogInput struct {
 	RefEntryID uuid.UUID
 	SenseIDs   []uuid.UUID
+	// PronunciationIDs selects the ref pronunciations to link; empty links all.
+	PronunciationIDs []uuid.UUID
+	// ImageIDs selects the ref images to link; empty links all.
+	ImageIDs   []uuid.UUID
 	CreateCard bool
 	Notes      *string
 }
@@ -25,6 +30,12 @@ func (i *CreateFromCatalogInput) Validate() error {
 	if len(i.SenseIDs) > 20 {
 		errs = append(errs, domain.FieldError{Field: "sense_ids", Message: "too many (max 20)"})
 	}
+	if len(i.PronunciationIDs) > 20 {
+		errs = append(errs, domain.FieldError{Field: "pronunciation_ids", Message: "too many (max 20)"})
+	}
+	if len(i.ImageIDs) > 20 {
+		errs = append(errs, domain.FieldError{Field: "image_ids", Message: "too many (max 20)"})
+	}
 	if i.Notes != nil && len(*i.Notes) > 5000 {
 		errs = append(errs, domain.FieldError{Field: "notes", Message: "too long (max 5000)"})
 	}
@@ -35,6 +46,33 @@ func (i *CreateFromCatalogInput) Validate() error {
 	return nil
 }
 
+// maxBatchCreateFromCatalog caps the number of words BatchCreateFromCatalog
+// accepts in one call.
+const maxBatchCreateFromCatalog = 100
+
+// BatchCreateFromCatalogInput holds the words for BatchCreateFromCatalog.
+type BatchCreateFromCatalogInput struct {
+	Texts       []string
+	CreateCards bool
+}
+
+// Validate checks all fields and collects all errors.
+func (i *BatchCreateFromCatalogInput) Validate() error {
+	var errs []domain.FieldError
+
+	if len(i.Texts) == 0 {
+		errs = append(errs, domain.FieldError{Field: "texts", Message: "required"})
+	}
+	if len(i.Texts) > maxBatchCreateFromCatalog {
+		errs = append(errs, domain.FieldError{Field: "texts", Message: "too many (max " + strconv.Itoa(maxBatchCreateFromCatalog) + ")"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
 // CreateCustomInput holds the parameters for creating a custom entry.
 type CreateCustomInput struct {
 	Text       string
@@ -58,8 +96,82 @@ type ExampleInput struct {
 	Translation *string
 }
 
-// Validate checks all fields and collects all errors.
+// normalize trims translations and example sentences, drops the ones left
+// empty and collapses translations that differ only in case (keeping the first).
+// New slices are built so the caller's input is never mutated.
+func (i *CreateCustomInput) normalize() {
+	if len(i.Senses) == 0 {
+		return
+	}
+
+	senses := make([]SenseInput, len(i.Senses))
+	for si, sense := range i.Senses {
+		seen := make(map[string]struct{}, len(sense.Translations))
+		var translations []string
+		for _, tr := range sense.Translations {
+			tr = strings.TrimSpace(tr)
+			if tr == "" {
+				continue
+			}
+			key := strings.ToLower(tr)
+			if _, dup := seen[key]; dup {
+				continue
+			}
+			seen[key] = struct{}{}
+			translations = append(translations, tr)
+		}
+
+		var examples []ExampleInput
+		for _, ex := range sense.Examples {
+			ex.Sentence = strings.TrimSpace(ex.Sentence)
+			if ex.Sentence == "" {
+				continue
+			}
+			if ex.Translation != nil {
+				tr := strings.TrimSpace(*ex.Translation)
+				if tr == "" {
+					ex.Translation = nil
+				} else {
+					ex.Translation = &tr
+				}
+			}
+			examples = append(examples, ex)
+		}
+
+		sense.Translations = translations
+		sense.Examples = examples
+		senses[si] = sense
+	}
+	i.Senses = senses
+}
+
+// customEntryLimits caps the number of senses of a custom entry and the
+// translations and examples of each sense.
+type customEntryLimits struct {
+	senses       int
+	translations int
+	examples     int
+}
+
+// defaultCustomEntryLimits are the content limits every entry is held to; the
+// configured caps may only lower them.
+var defaultCustomEntryLimits = customEntryLimits{
+	senses:       domain.MaxSensesPerEntry,
+	translations: domain.MaxTranslationsPerSense,
+	examples:     domain.MaxExamplesPerSense,
+}
+
+// tooMany formats the "too many" message for a cap.
+func tooMany(limit int) string {
+	return "too many (max " + strconv.Itoa(limit) + ")"
+}
+
+// Validate checks all fields against the default limits and collects all errors.
 func (i *CreateCustomInput) Validate() error {
+	return i.validate(defaultCustomEntryLimits)
+}
+
+func (i *CreateCustomInput) validate(limits customEntryLimits) error {
 	var errs []domain.FieldError
 
 	if i.Text == "" {
@@ -68,8 +180,8 @@ func (i *CreateCustomInput) Validate() error {
 		errs = append(errs, domain.FieldError{Field: "text", Message: "too long (max 500)"})
 	}
 
-	if len(i.Senses) > 20 {
-		errs = append(errs, domain.FieldError{Field: "senses", Message: "too many (max 20)"})
+	if len(i.Senses) > limits.senses {
+		errs = append(errs, domain.FieldError{Field: "senses", Message: tooMany(limits.senses)})
 	}
 
 	for si, sense := range i.Senses {
@@ -85,10 +197,10 @@ func (i *CreateCustomInput) Validate() error {
 				Message: "invalid value",
 			})
 		}
-		if len(sense.Translations) > 20 {
+		if len(sense.Translations) > limits.translations {
 			errs = append(errs, domain.FieldError{
 				Field:   fieldIndex("senses", si, "translations"),
-				Message: "too many (max 20)",
+				Message: tooMany(limits.translations),
 			})
 		}
 		for ti, tr := range sense.Translations {
@@ -104,10 +216,10 @@ func (i *CreateCustomInput) Validate() error {
 				})
 			}
 		}
-		if len(sense.Examples) > 20 {
+		if len(sense.Examples) > limits.examples {
 			errs = append(errs, domain.FieldError{
 				Field:   fieldIndex("senses", si, "examples"),
-				Message: "too many (max 20)",
+				Message: tooMany(limits.examples),
 			})
 		}
 		for ei, ex := range sense.Examples {
@@ -148,6 +260,7 @@ type FindInput struct {
 	PartOfSpeech *domain.PartOfSpeech
 	TopicID      *uuid.UUID
 	Status       *domain.CardState
+	Favorite     *bool
 	SortBy       string
 	SortOrder    string
 	Limit        int
@@ -163,8 +276,12 @@ func (i *FindInput) Validate() error {
 		switch i.SortBy {
 		case "text", "created_at", "updated_at":
 			// valid
+		case "due_date", "review_count":
+			if i.Cursor != nil {
+				errs = append(errs, domain.FieldError{Field: "sort_by", Message: "not supported with cursor pagination"})
+			}
 		default:
-			errs = append(errs, domain.FieldError{Field: "sort_by", Message: "invalid value (allowed: text, created_at, updated_at)"})
+			errs = append(errs, domain.FieldError{Field: "sort_by", Message: "invalid value (allowed: text, created_at, updated_at, due_date, review_count)"})
 		}
 	}
 
@@ -185,6 +302,11 @@ func (i *FindInput) Validate() error {
 		errs = append(errs, domain.FieldError{Field: "status", Message: "invalid value"})
 	}
 
+	// A card state implies the entry has a card.
+	if i.Status != nil && i.HasCard != nil && !*i.HasCard {
+		errs = append(errs, domain.FieldError{Field: "has_card", Message: "must not be false when status is set"})
+	}
+
 	if len(errs) > 0 {
 		return domain.NewValidationErrors(errs)
 	}
@@ -214,6 +336,135 @@ func (i *UpdateNotesInput) Validate() error {
 	return nil
 }
 
+// ReorderSensesInput holds the parameters for reordering an entry's senses.
+// OrderedSenseIDs must list every sense of the entry exactly once; a sense's
+// new position is its index in the slice.
+type ReorderSensesInput struct {
+	EntryID         uuid.UUID
+	OrderedSenseIDs []uuid.UUID
+}
+
+// Validate checks all fields and collects all errors.
+func (i *ReorderSensesInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.EntryID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "entry_id", Message: "required"})
+	}
+	if len(i.OrderedSenseIDs) == 0 {
+		errs = append(errs, domain.FieldError{Field: "ordered_sense_ids", Message: "required (at least 1)"})
+	} else if len(i.OrderedSenseIDs) > 50 {
+		errs = append(errs, domain.FieldError{Field: "ordered_sense_ids", Message: "too many (max 50)"})
+	}
+
+	seen := make(map[uuid.UUID]bool, len(i.OrderedSenseIDs))
+	for idx, id := range i.OrderedSenseIDs {
+		if seen[id] {
+			errs = append(errs, domain.FieldError{
+				Field:   "ordered_sense_ids[" + strconv.Itoa(idx) + "]",
+				Message: "duplicate",
+			})
+		}
+		seen[id] = true
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// UpdateSenseInput holds the parameters for editing a user-sourced sense.
+// Nil fields are left unchanged.
+type UpdateSenseInput struct {
+	SenseID      uuid.UUID
+	Definition   *string
+	PartOfSpeech *domain.PartOfSpeech
+	CEFRLevel    *string
+}
+
+// Validate checks all fields and collects all errors.
+func (i *UpdateSenseInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.SenseID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "sense_id", Message: "required"})
+	}
+	if i.Definition != nil && len(*i.Definition) > 2000 {
+		errs = append(errs, domain.FieldError{Field: "definition", Message: "too long (max 2000)"})
+	}
+	if i.PartOfSpeech != nil && !i.PartOfSpeech.IsValid() {
+		errs = append(errs, domain.FieldError{Field: "part_of_speech", Message: "invalid value"})
+	}
+	if i.CEFRLevel != nil && !validCEFRLevels[*i.CEFRLevel] {
+		errs = append(errs, domain.FieldError{Field: "cefr_level", Message: "must be one of: A1, A2, B1, B2, C1, C2"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// UpdateTranslationInput holds the parameters for editing a user-sourced translation.
+type UpdateTranslationInput struct {
+	TranslationID uuid.UUID
+	Text          string
+}
+
+// Validate checks all fields and collects all errors.
+func (i *UpdateTranslationInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.TranslationID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "translation_id", Message: "required"})
+	}
+	if i.Text == "" {
+		errs = append(errs, domain.FieldError{Field: "text", Message: "required"})
+	} else if len(i.Text) > 500 {
+		errs = append(errs, domain.FieldError{Field: "text", Message: "too long (max 500)"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// UpdateExampleInput holds the parameters for editing a user-sourced example.
+type UpdateExampleInput struct {
+	ExampleID   uuid.UUID
+	Sentence    string
+	Translation *string
+}
+
+// Validate checks all fields and collects all errors.
+func (i *UpdateExampleInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.ExampleID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "example_id", Message: "required"})
+	}
+	if i.Sentence == "" {
+		errs = append(errs, domain.FieldError{Field: "sentence", Message: "required"})
+	} else if len(i.Sentence) > 2000 {
+		errs = append(errs, domain.FieldError{Field: "sentence", Message: "too long (max 2000)"})
+	}
+	if i.Translation != nil && len(*i.Translation) > 2000 {
+		errs = append(errs, domain.FieldError{Field: "translation", Message: "too long (max 2000)"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// validCEFRLevels lists the accepted CEFR level values.
+var validCEFRLevels = map[string]bool{
+	"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true,
+}
+
 // ImportInput holds the parameters for importing entries.
 type ImportInput struct {
 	Items []ImportItem
@@ -0,0 +1,84 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ---------------------------------------------------------------------------
+// 15. ReorderSenses
+// ---------------------------------------------------------------------------
+
+// ReorderSenses sets the order of an entry's senses. The input must list
+// every sense of the entry exactly once.
+func (s *Service) ReorderSenses(ctx context.Context, input ReorderSensesInput) error {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	return s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		if _, err := s.entries.GetByID(txCtx, userID, input.EntryID); err != nil {
+			return err
+		}
+
+		senses, err := s.senses.GetByEntryIDs(txCtx, []uuid.UUID{input.EntryID})
+		if err != nil {
+			return fmt.Errorf("get senses: %w", err)
+		}
+
+		if !sameSenseSet(senses, input.OrderedSenseIDs) {
+			return domain.NewValidationError("ordered_sense_ids", "must list every sense of the entry exactly once")
+		}
+
+		if err := s.senses.UpdatePositions(txCtx, input.EntryID, input.OrderedSenseIDs); err != nil {
+			return fmt.Errorf("update sense positions: %w", err)
+		}
+
+		oldOrder := make([]uuid.UUID, len(senses))
+		for i, sense := range senses {
+			oldOrder[i] = sense.ID
+		}
+
+		_, err = s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeEntry,
+			EntityID:   &input.EntryID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"sense_order": map[string]any{"old": oldOrder, "new": input.OrderedSenseIDs},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("audit reorder: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// sameSenseSet reports whether ids (already known to be unique) are exactly
+// the IDs of senses.
+func sameSenseSet(senses []domain.Sense, ids []uuid.UUID) bool {
+	if len(senses) != len(ids) {
+		return false
+	}
+	existing := make(map[uuid.UUID]bool, len(senses))
+	for _, sense := range senses {
+		existing[sense.ID] = true
+	}
+	for _, id := range ids {
+		if !existing[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -21,12 +21,42 @@ type PageInfo struct {
 	EndCursor   *string
 }
 
+// DeletedEntry is a soft-deleted entry with the time it becomes eligible for
+// permanent removal by the cleanup job.
+type DeletedEntry struct {
+	Entry   domain.Entry
+	PurgeAt time.Time
+}
+
+// SimilarityReason explains why CheckSimilar flagged an entry.
+type SimilarityReason string
+
+const (
+	SimilarityExact        SimilarityReason = "EXACT"
+	SimilarityLemma        SimilarityReason = "LEMMA"
+	SimilarityEditDistance SimilarityReason = "EDIT_DISTANCE"
+)
+
+// SimilarEntry is an existing entry that resembles a word about to be added.
+type SimilarEntry struct {
+	Entry    domain.Entry
+	Reason   SimilarityReason
+	Distance int
+}
+
 // BatchResult contains the result of a batch delete operation.
 type BatchResult struct {
 	Deleted int
 	Errors  []BatchError
 }
 
+// RestoreAllResult contains the result of restoring all deleted entries.
+// PendingPurge counts entries past the retention window that were left alone.
+type RestoreAllResult struct {
+	Restored     int
+	PendingPurge int
+}
+
 // BatchError describes a single failure in a batch operation.
 type BatchError struct {
 	EntryID uuid.UUID
@@ -47,6 +77,21 @@ type ImportError struct {
 	Reason     string
 }
 
+// BatchCreateFromCatalogResult contains the result of BatchCreateFromCatalog.
+// Word lists hold normalized text.
+type BatchCreateFromCatalogResult struct {
+	Created           []*domain.Entry
+	SkippedExisting   []string
+	NotFoundInCatalog []string
+	Errors            []WordError
+}
+
+// WordError describes a word that could not be processed.
+type WordError struct {
+	Text   string
+	Reason string
+}
+
 // ExportResult contains the exported dictionary data.
 type ExportResult struct {
 	Items      []ExportItem
@@ -2,6 +2,8 @@ package dictionary
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
@@ -38,3 +40,57 @@ func (s *Service) PreviewRefEntry(ctx context.Context, text string) (*domain.Ref
 
 	return s.refCatalog.GetOrFetchEntry(ctx, text)
 }
+
+// maxBatchPreview caps how many distinct words BatchPreviewRefEntries accepts.
+const maxBatchPreview = 50
+
+// BatchPreviewRefEntries fetches or retrieves reference entries for several
+// words at once, e.g. for a pasted word list. Inputs are normalized and
+// deduplicated, and both returned maps are keyed by the normalized text. A
+// word that fails to resolve is reported in the errors map and does not stop
+// the others; the call itself only fails on invalid input, missing auth, or
+// a canceled context.
+func (s *Service) BatchPreviewRefEntries(ctx context.Context, texts []string) (map[string]*domain.RefEntry, map[string]error, error) {
+	if _, ok := ctxutil.UserIDFromCtx(ctx); !ok {
+		return nil, nil, domain.ErrUnauthorized
+	}
+
+	var words []string
+	seen := make(map[string]bool, len(texts))
+	for _, t := range texts {
+		norm := domain.NormalizeText(t)
+		if norm == "" || seen[norm] {
+			continue
+		}
+		seen[norm] = true
+		words = append(words, norm)
+	}
+	if len(words) == 0 {
+		return nil, nil, domain.NewValidationError("texts", "required")
+	}
+	if len(words) > maxBatchPreview {
+		return nil, nil, domain.NewValidationError("texts", fmt.Sprintf("too many (max %d)", maxBatchPreview))
+	}
+
+	entries := make(map[string]*domain.RefEntry, len(words))
+	failed := make(map[string]error)
+	for _, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		entry, err := s.refCatalog.GetOrFetchEntry(ctx, word)
+		if err != nil {
+			failed[word] = err
+			continue
+		}
+		entries[word] = entry
+	}
+
+	s.log.InfoContext(ctx, "batch preview",
+		slog.Int("requested", len(words)),
+		slog.Int("found", len(entries)),
+		slog.Int("failed", len(failed)),
+	)
+
+	return entries, failed, nil
+}
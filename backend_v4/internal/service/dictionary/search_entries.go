@@ -0,0 +1,38 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ---------------------------------------------------------------------------
+// 14. SearchMyEntries
+// ---------------------------------------------------------------------------
+
+// SearchMyEntries runs a full-text search over the user's own entries,
+// matching entry text, definitions, translations and notes. Hits are ranked
+// by relevance and carry a highlighted snippet.
+func (s *Service) SearchMyEntries(ctx context.Context, query string, limit int) ([]domain.EntrySearchHit, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.EntrySearchHit{}, nil
+	}
+
+	limit = clampLimit(limit, 1, 50, 20)
+
+	hits, err := s.entries.Search(ctx, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search entries: %w", err)
+	}
+
+	return hits, nil
+}
@@ -2,6 +2,7 @@ package dictionary
 
 import (
 	"context"
+	"io"
 	"log/slog"
 	"time"
 
@@ -21,11 +22,16 @@ type entryRepo interface {
 	Find(ctx context.Context, userID uuid.UUID, filter domain.EntryFilter) ([]domain.Entry, int, error)
 	FindCursor(ctx context.Context, userID uuid.UUID, filter domain.EntryFilter) ([]domain.Entry, bool, error)
 	FindDeleted(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Entry, int, error)
+	Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.EntrySearchHit, error)
+	FindSimilarCandidates(ctx context.Context, userID uuid.UUID, forms []string, text string, maxDist int) ([]domain.Entry, error)
 	CountByUser(ctx context.Context, userID uuid.UUID) (int, error)
 	Create(ctx context.Context, entry *domain.Entry) (*domain.Entry, error)
 	UpdateNotes(ctx context.Context, userID, entryID uuid.UUID, notes *string) (*domain.Entry, error)
+	SetFavorite(ctx context.Context, userID, entryID uuid.UUID, favorite bool) (*domain.Entry, error)
 	SoftDelete(ctx context.Context, userID, entryID uuid.UUID) error
 	Restore(ctx context.Context, userID, entryID uuid.UUID) (*domain.Entry, error)
+	RestoreBatch(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error)
+	CountDeletedBefore(ctx context.Context, userID uuid.UUID, threshold time.Time) (int, error)
 	HardDeleteOld(ctx context.Context, threshold time.Time) (int64, error)
 }
 
@@ -33,18 +39,25 @@ type senseRepo interface {
 	GetByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]domain.Sense, error)
 	CreateFromRef(ctx context.Context, entryID, refSenseID uuid.UUID, sourceSlug string) (*domain.Sense, error)
 	CreateCustom(ctx context.Context, entryID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string, sourceSlug string) (*domain.Sense, error)
+	UpdatePositions(ctx context.Context, entryID uuid.UUID, orderedIDs []uuid.UUID) error
+	GetByIDForUser(ctx context.Context, userID, senseID uuid.UUID) (*domain.Sense, error)
+	Update(ctx context.Context, senseID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string) (*domain.Sense, error)
 }
 
 type translationRepo interface {
 	GetBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Translation, error)
 	CreateFromRef(ctx context.Context, senseID, refTranslationID uuid.UUID, sourceSlug string) (*domain.Translation, error)
 	CreateCustom(ctx context.Context, senseID uuid.UUID, text string, sourceSlug string) (*domain.Translation, error)
+	GetByIDForUser(ctx context.Context, userID, translationID uuid.UUID) (*domain.Translation, error)
+	Update(ctx context.Context, translationID uuid.UUID, text string) (*domain.Translation, error)
 }
 
 type exampleRepo interface {
 	GetBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Example, error)
 	CreateFromRef(ctx context.Context, senseID, refExampleID uuid.UUID, sourceSlug string) (*domain.Example, error)
 	CreateCustom(ctx context.Context, senseID uuid.UUID, sentence string, translation *string, sourceSlug string) (*domain.Example, error)
+	GetByIDForUser(ctx context.Context, userID, exampleID uuid.UUID) (*domain.Example, error)
+	Update(ctx context.Context, exampleID uuid.UUID, sentence string, translation *string) (*domain.Example, error)
 }
 
 type pronunciationRepo interface {
@@ -58,6 +71,15 @@ type imageRepo interface {
 type cardRepo interface {
 	GetByEntryIDs(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) ([]domain.Card, error)
 	Create(ctx context.Context, userID, entryID uuid.UUID) (*domain.Card, error)
+	CreateWithParams(ctx context.Context, userID, entryID uuid.UUID, stability, difficulty float64) (*domain.Card, error)
+	UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error)
+}
+
+type topicRepo interface {
+	List(ctx context.Context, userID uuid.UUID) ([]*domain.Topic, error)
+	GetEntryIDsByTopicID(ctx context.Context, topicID uuid.UUID) ([]uuid.UUID, error)
+	Create(ctx context.Context, userID uuid.UUID, topic *domain.Topic) (*domain.Topic, error)
+	LinkEntry(ctx context.Context, entryID, topicID uuid.UUID) error
 }
 
 type auditRepo interface {
@@ -72,6 +94,10 @@ type enrichmentEnqueuer interface {
 	Enqueue(ctx context.Context, refEntryID uuid.UUID) error
 }
 
+type ankiReader interface {
+	ReadNotes(ctx context.Context, r io.Reader) ([]domain.AnkiNote, error)
+}
+
 type refCatalogService interface {
 	GetOrFetchEntry(ctx context.Context, text string) (*domain.RefEntry, error)
 	GetRefEntry(ctx context.Context, refEntryID uuid.UUID) (*domain.RefEntry, error)
@@ -96,7 +122,15 @@ type Service struct {
 	tx             txManager
 	refCatalog     refCatalogService
 	enrichment     enrichmentEnqueuer
+	anki           ankiReader
+	topics         topicRepo
+	cardPresets    domain.CardPresets
 	cfg            config.DictionaryConfig
+
+	// lemmas maps a normalized word form to its lemma; lemmaForms is the
+	// inverse. Both are optional and used only by CheckSimilar.
+	lemmas     map[string]string
+	lemmaForms map[string][]string
 }
 
 // NewService creates a new Dictionary service.
@@ -135,6 +169,38 @@ func (s *Service) SetEnrichment(e enrichmentEnqueuer) {
 	s.enrichment = e
 }
 
+// SetAnkiReader injects the optional .apkg reader used by ImportAnki.
+func (s *Service) SetAnkiReader(r ankiReader) {
+	s.anki = r
+}
+
+// SetTopics injects the optional topic repository used by ExportBackup and
+// ImportBackup. Without it backups carry no topics.
+func (s *Service) SetTopics(t topicRepo) {
+	s.topics = t
+}
+
+// SetCardPresets configures the starting memory state of cards created for
+// catalog words. Without it those cards start like any other NEW card.
+func (s *Service) SetCardPresets(p domain.CardPresets) {
+	s.cardPresets = p
+}
+
+// SetLemmas injects an optional word-form → lemma map used by CheckSimilar to
+// flag inflections of existing entries. Keys and values are normalized.
+func (s *Service) SetLemmas(lemmas map[string]string) {
+	s.lemmas = make(map[string]string, len(lemmas))
+	s.lemmaForms = make(map[string][]string)
+	for form, lemma := range lemmas {
+		form, lemma = domain.NormalizeText(form), domain.NormalizeText(lemma)
+		if form == "" || lemma == "" {
+			continue
+		}
+		s.lemmas[form] = lemma
+		s.lemmaForms[lemma] = append(s.lemmaForms[lemma], form)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
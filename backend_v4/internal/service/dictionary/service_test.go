@@ -1,15 +1,21 @@
 package dictionary
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/config"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/internal/service/topic"
 	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,11 +32,16 @@ type mockEntryRepo struct {
 	FindFunc        func(ctx context.Context, userID uuid.UUID, filter domain.EntryFilter) ([]domain.Entry, int, error)
 	FindCursorFunc  func(ctx context.Context, userID uuid.UUID, filter domain.EntryFilter) ([]domain.Entry, bool, error)
 	FindDeletedFunc func(ctx context.Context, userID uuid.UUID, limit, offset int) ([]domain.Entry, int, error)
+	SearchFunc      func(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.EntrySearchHit, error)
+	FindSimilarCandidatesFunc func(ctx context.Context, userID uuid.UUID, forms []string, text string, maxDist int) ([]domain.Entry, error)
 	CountByUserFunc func(ctx context.Context, userID uuid.UUID) (int, error)
 	CreateFunc      func(ctx context.Context, entry *domain.Entry) (*domain.Entry, error)
 	UpdateNotesFunc func(ctx context.Context, userID, entryID uuid.UUID, notes *string) (*domain.Entry, error)
+	SetFavoriteFunc func(ctx context.Context, userID, entryID uuid.UUID, favorite bool) (*domain.Entry, error)
 	SoftDeleteFunc  func(ctx context.Context, userID, entryID uuid.UUID) error
 	RestoreFunc     func(ctx context.Context, userID, entryID uuid.UUID) (*domain.Entry, error)
+	RestoreBatchFunc       func(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error)
+	CountDeletedBeforeFunc func(ctx context.Context, userID uuid.UUID, threshold time.Time) (int, error)
 	HardDeleteOldFunc func(ctx context.Context, threshold time.Time) (int64, error)
 }
 
@@ -76,6 +87,34 @@ func (m *mockEntryRepo) FindDeleted(ctx context.Context, userID uuid.UUID, limit
 	return nil, 0, nil
 }
 
+func (m *mockEntryRepo) Search(ctx context.Context, userID uuid.UUID, query string, limit int) ([]domain.EntrySearchHit, error) {
+	if m.SearchFunc != nil {
+		return m.SearchFunc(ctx, userID, query, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockEntryRepo) FindSimilarCandidates(ctx context.Context, userID uuid.UUID, forms []string, text string, maxDist int) ([]domain.Entry, error) {
+	if m.FindSimilarCandidatesFunc != nil {
+		return m.FindSimilarCandidatesFunc(ctx, userID, forms, text, maxDist)
+	}
+	return nil, nil
+}
+
+func (m *mockEntryRepo) RestoreBatch(ctx context.Context, userID uuid.UUID, since time.Time) ([]uuid.UUID, error) {
+	if m.RestoreBatchFunc != nil {
+		return m.RestoreBatchFunc(ctx, userID, since)
+	}
+	return nil, nil
+}
+
+func (m *mockEntryRepo) CountDeletedBefore(ctx context.Context, userID uuid.UUID, threshold time.Time) (int, error) {
+	if m.CountDeletedBeforeFunc != nil {
+		return m.CountDeletedBeforeFunc(ctx, userID, threshold)
+	}
+	return 0, nil
+}
+
 func (m *mockEntryRepo) CountByUser(ctx context.Context, userID uuid.UUID) (int, error) {
 	if m.CountByUserFunc != nil {
 		return m.CountByUserFunc(ctx, userID)
@@ -98,6 +137,13 @@ func (m *mockEntryRepo) UpdateNotes(ctx context.Context, userID, entryID uuid.UU
 	return nil, nil
 }
 
+func (m *mockEntryRepo) SetFavorite(ctx context.Context, userID, entryID uuid.UUID, favorite bool) (*domain.Entry, error) {
+	if m.SetFavoriteFunc != nil {
+		return m.SetFavoriteFunc(ctx, userID, entryID, favorite)
+	}
+	return nil, nil
+}
+
 func (m *mockEntryRepo) SoftDelete(ctx context.Context, userID, entryID uuid.UUID) error {
 	if m.SoftDeleteFunc != nil {
 		return m.SoftDeleteFunc(ctx, userID, entryID)
@@ -117,9 +163,33 @@ func (m *mockEntryRepo) HardDeleteOld(ctx context.Context, threshold time.Time)
 }
 
 type mockSenseRepo struct {
-	GetByEntryIDsFunc func(ctx context.Context, entryIDs []uuid.UUID) ([]domain.Sense, error)
-	CreateFromRefFunc func(ctx context.Context, entryID, refSenseID uuid.UUID, sourceSlug string) (*domain.Sense, error)
-	CreateCustomFunc  func(ctx context.Context, entryID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string, sourceSlug string) (*domain.Sense, error)
+	GetByEntryIDsFunc   func(ctx context.Context, entryIDs []uuid.UUID) ([]domain.Sense, error)
+	CreateFromRefFunc   func(ctx context.Context, entryID, refSenseID uuid.UUID, sourceSlug string) (*domain.Sense, error)
+	CreateCustomFunc    func(ctx context.Context, entryID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string, sourceSlug string) (*domain.Sense, error)
+	UpdatePositionsFunc func(ctx context.Context, entryID uuid.UUID, orderedIDs []uuid.UUID) error
+	GetByIDForUserFunc  func(ctx context.Context, userID, senseID uuid.UUID) (*domain.Sense, error)
+	UpdateFunc          func(ctx context.Context, senseID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string) (*domain.Sense, error)
+}
+
+func (m *mockSenseRepo) GetByIDForUser(ctx context.Context, userID, senseID uuid.UUID) (*domain.Sense, error) {
+	if m.GetByIDForUserFunc != nil {
+		return m.GetByIDForUserFunc(ctx, userID, senseID)
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockSenseRepo) Update(ctx context.Context, senseID uuid.UUID, definition *string, pos *domain.PartOfSpeech, cefr *string) (*domain.Sense, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, senseID, definition, pos, cefr)
+	}
+	return &domain.Sense{ID: senseID, Definition: definition, PartOfSpeech: pos, CEFRLevel: cefr}, nil
+}
+
+func (m *mockSenseRepo) UpdatePositions(ctx context.Context, entryID uuid.UUID, orderedIDs []uuid.UUID) error {
+	if m.UpdatePositionsFunc != nil {
+		return m.UpdatePositionsFunc(ctx, entryID, orderedIDs)
+	}
+	return nil
 }
 
 func (m *mockSenseRepo) GetByEntryIDs(ctx context.Context, entryIDs []uuid.UUID) ([]domain.Sense, error) {
@@ -144,9 +214,25 @@ func (m *mockSenseRepo) CreateCustom(ctx context.Context, entryID uuid.UUID, def
 }
 
 type mockTranslationRepo struct {
-	GetBySenseIDsFunc func(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Translation, error)
-	CreateFromRefFunc func(ctx context.Context, senseID, refTranslationID uuid.UUID, sourceSlug string) (*domain.Translation, error)
-	CreateCustomFunc  func(ctx context.Context, senseID uuid.UUID, text string, sourceSlug string) (*domain.Translation, error)
+	GetBySenseIDsFunc  func(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Translation, error)
+	CreateFromRefFunc  func(ctx context.Context, senseID, refTranslationID uuid.UUID, sourceSlug string) (*domain.Translation, error)
+	CreateCustomFunc   func(ctx context.Context, senseID uuid.UUID, text string, sourceSlug string) (*domain.Translation, error)
+	GetByIDForUserFunc func(ctx context.Context, userID, translationID uuid.UUID) (*domain.Translation, error)
+	UpdateFunc         func(ctx context.Context, translationID uuid.UUID, text string) (*domain.Translation, error)
+}
+
+func (m *mockTranslationRepo) GetByIDForUser(ctx context.Context, userID, translationID uuid.UUID) (*domain.Translation, error) {
+	if m.GetByIDForUserFunc != nil {
+		return m.GetByIDForUserFunc(ctx, userID, translationID)
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockTranslationRepo) Update(ctx context.Context, translationID uuid.UUID, text string) (*domain.Translation, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, translationID, text)
+	}
+	return &domain.Translation{ID: translationID, Text: &text}, nil
 }
 
 func (m *mockTranslationRepo) GetBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Translation, error) {
@@ -171,9 +257,25 @@ func (m *mockTranslationRepo) CreateCustom(ctx context.Context, senseID uuid.UUI
 }
 
 type mockExampleRepo struct {
-	GetBySenseIDsFunc func(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Example, error)
-	CreateFromRefFunc func(ctx context.Context, senseID, refExampleID uuid.UUID, sourceSlug string) (*domain.Example, error)
-	CreateCustomFunc  func(ctx context.Context, senseID uuid.UUID, sentence string, translation *string, sourceSlug string) (*domain.Example, error)
+	GetBySenseIDsFunc  func(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Example, error)
+	CreateFromRefFunc  func(ctx context.Context, senseID, refExampleID uuid.UUID, sourceSlug string) (*domain.Example, error)
+	CreateCustomFunc   func(ctx context.Context, senseID uuid.UUID, sentence string, translation *string, sourceSlug string) (*domain.Example, error)
+	GetByIDForUserFunc func(ctx context.Context, userID, exampleID uuid.UUID) (*domain.Example, error)
+	UpdateFunc         func(ctx context.Context, exampleID uuid.UUID, sentence string, translation *string) (*domain.Example, error)
+}
+
+func (m *mockExampleRepo) GetByIDForUser(ctx context.Context, userID, exampleID uuid.UUID) (*domain.Example, error) {
+	if m.GetByIDForUserFunc != nil {
+		return m.GetByIDForUserFunc(ctx, userID, exampleID)
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (m *mockExampleRepo) Update(ctx context.Context, exampleID uuid.UUID, sentence string, translation *string) (*domain.Example, error) {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, exampleID, sentence, translation)
+	}
+	return &domain.Example{ID: exampleID, Sentence: &sentence, Translation: translation}, nil
 }
 
 func (m *mockExampleRepo) GetBySenseIDs(ctx context.Context, senseIDs []uuid.UUID) ([]domain.Example, error) {
@@ -220,8 +322,10 @@ func (m *mockImageRepo) LinkCatalog(ctx context.Context, entryID, refImageID uui
 }
 
 type mockCardRepo struct {
-	GetByEntryIDsFunc func(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) ([]domain.Card, error)
-	CreateFunc        func(ctx context.Context, userID, entryID uuid.UUID) (*domain.Card, error)
+	GetByEntryIDsFunc    func(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) ([]domain.Card, error)
+	CreateFunc           func(ctx context.Context, userID, entryID uuid.UUID) (*domain.Card, error)
+	CreateWithParamsFunc func(ctx context.Context, userID, entryID uuid.UUID, stability, difficulty float64) (*domain.Card, error)
+	UpdateSRSFunc        func(ctx context.Context, userID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error)
 }
 
 func (m *mockCardRepo) GetByEntryIDs(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) ([]domain.Card, error) {
@@ -238,6 +342,68 @@ func (m *mockCardRepo) Create(ctx context.Context, userID, entryID uuid.UUID) (*
 	return &domain.Card{ID: uuid.New(), UserID: userID, EntryID: entryID, State: domain.CardStateNew}, nil
 }
 
+func (m *mockCardRepo) CreateWithParams(ctx context.Context, userID, entryID uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+	if m.CreateWithParamsFunc != nil {
+		return m.CreateWithParamsFunc(ctx, userID, entryID, stability, difficulty)
+	}
+	return &domain.Card{ID: uuid.New(), UserID: userID, EntryID: entryID, State: domain.CardStateNew, Stability: stability, Difficulty: difficulty}, nil
+}
+
+func (m *mockCardRepo) UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+	if m.UpdateSRSFunc != nil {
+		return m.UpdateSRSFunc(ctx, userID, cardID, params)
+	}
+	return &domain.Card{ID: cardID, UserID: userID}, nil
+}
+
+type mockAnkiReader struct {
+	ReadNotesFunc func(ctx context.Context, r io.Reader) ([]domain.AnkiNote, error)
+}
+
+func (m *mockAnkiReader) ReadNotes(ctx context.Context, r io.Reader) ([]domain.AnkiNote, error) {
+	if m.ReadNotesFunc != nil {
+		return m.ReadNotesFunc(ctx, r)
+	}
+	return nil, nil
+}
+
+type mockTopicRepo struct {
+	ListFunc                 func(ctx context.Context, userID uuid.UUID) ([]*domain.Topic, error)
+	GetEntryIDsByTopicIDFunc func(ctx context.Context, topicID uuid.UUID) ([]uuid.UUID, error)
+	CreateFunc               func(ctx context.Context, userID uuid.UUID, topic *domain.Topic) (*domain.Topic, error)
+	LinkEntryFunc            func(ctx context.Context, entryID, topicID uuid.UUID) error
+}
+
+func (m *mockTopicRepo) List(ctx context.Context, userID uuid.UUID) ([]*domain.Topic, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, userID)
+	}
+	return []*domain.Topic{}, nil
+}
+
+func (m *mockTopicRepo) GetEntryIDsByTopicID(ctx context.Context, topicID uuid.UUID) ([]uuid.UUID, error) {
+	if m.GetEntryIDsByTopicIDFunc != nil {
+		return m.GetEntryIDsByTopicIDFunc(ctx, topicID)
+	}
+	return []uuid.UUID{}, nil
+}
+
+func (m *mockTopicRepo) Create(ctx context.Context, userID uuid.UUID, topic *domain.Topic) (*domain.Topic, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, topic)
+	}
+	topic.ID = uuid.New()
+	topic.UserID = userID
+	return topic, nil
+}
+
+func (m *mockTopicRepo) LinkEntry(ctx context.Context, entryID, topicID uuid.UUID) error {
+	if m.LinkEntryFunc != nil {
+		return m.LinkEntryFunc(ctx, entryID, topicID)
+	}
+	return nil
+}
+
 type mockAuditRepo struct {
 	CreateFunc func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error)
 }
@@ -470,6 +636,76 @@ func TestService_PreviewRefEntry_APIError(t *testing.T) {
 	require.ErrorIs(t, err, apiErr)
 }
 
+func TestService_BatchPreviewRefEntries_Dedupe(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	var fetched []string
+	deps.refCatalog.GetOrFetchEntryFunc = func(_ context.Context, text string) (*domain.RefEntry, error) {
+		fetched = append(fetched, text)
+		return makeRefEntry(text), nil
+	}
+
+	entries, failed, err := svc.BatchPreviewRefEntries(ctx, []string{"Hello", " hello ", "world", "", "HELLO"})
+	require.NoError(t, err)
+	assert.Empty(t, failed)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "hello", entries["hello"].Text)
+	assert.Equal(t, "world", entries["world"].Text)
+	assert.Equal(t, []string{"hello", "world"}, fetched, "duplicates should be fetched once")
+}
+
+func TestService_BatchPreviewRefEntries_PerWordErrors(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	apiErr := errors.New("API timeout")
+	deps.refCatalog.GetOrFetchEntryFunc = func(_ context.Context, text string) (*domain.RefEntry, error) {
+		switch text {
+		case "qwxz":
+			return nil, domain.ErrNotFound
+		case "slow":
+			return nil, apiErr
+		}
+		return makeRefEntry(text), nil
+	}
+
+	entries, failed, err := svc.BatchPreviewRefEntries(ctx, []string{"qwxz", "hello", "slow", "world"})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Contains(t, entries, "hello")
+	assert.Contains(t, entries, "world")
+	require.Len(t, failed, 2)
+	assert.ErrorIs(t, failed["qwxz"], domain.ErrNotFound)
+	assert.ErrorIs(t, failed["slow"], apiErr)
+}
+
+func TestService_BatchPreviewRefEntries_Validation(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	_, _, err := svc.BatchPreviewRefEntries(ctx, []string{" ", ""})
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	tooMany := make([]string, maxBatchPreview+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("word%d", i)
+	}
+	_, _, err = svc.BatchPreviewRefEntries(ctx, tooMany)
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_BatchPreviewRefEntries_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+
+	_, _, err := svc.BatchPreviewRefEntries(context.Background(), []string{"hello"})
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
 // ===========================================================================
 // 3. CreateEntryFromCatalog Tests
 // ===========================================================================
@@ -569,7 +805,7 @@ func TestService_CreateFromCatalog_WithCard(t *testing.T) {
 	}
 
 	cardCreated := false
-	deps.cards.CreateFunc = func(_ context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
+	deps.cards.CreateWithParamsFunc = func(_ context.Context, uid, eid uuid.UUID, _, _ float64) (*domain.Card, error) {
 		assert.Equal(t, userID, uid)
 		cardCreated = true
 		return &domain.Card{ID: uuid.New()}, nil
@@ -595,7 +831,7 @@ func TestService_CreateFromCatalog_NoCard(t *testing.T) {
 	}
 
 	cardCreated := false
-	deps.cards.CreateFunc = func(_ context.Context, _, _ uuid.UUID) (*domain.Card, error) {
+	deps.cards.CreateWithParamsFunc = func(_ context.Context, _, _ uuid.UUID, _, _ float64) (*domain.Card, error) {
 		cardCreated = true
 		return &domain.Card{ID: uuid.New()}, nil
 	}
@@ -609,6 +845,52 @@ func TestService_CreateFromCatalog_NoCard(t *testing.T) {
 	assert.False(t, cardCreated)
 }
 
+func TestService_CreateFromCatalog_CardPresets(t *testing.T) {
+	t.Parallel()
+	rank := func(v int) *int { return &v }
+
+	tests := []struct {
+		name          string
+		rank          *int
+		wantStability float64
+		wantDiff      float64
+	}{
+		{"top frequency word gets the preset", rank(1), 4.5, 3},
+		{"rare word keeps defaults", rank(50000), 0, 0},
+		{"no frequency data keeps defaults", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, deps := newTestService(defaultCfg())
+			svc.SetCardPresets(domain.CardPresets{FrequentMaxRank: 2000, FrequentStability: 4.5, FrequentDifficulty: 3})
+			ctx, _ := authCtx()
+
+			refEntry := makeRefEntry("the")
+			refEntry.FrequencyRank = tt.rank
+			deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+				return refEntry, nil
+			}
+
+			var gotStability, gotDiff float64
+			deps.cards.CreateWithParamsFunc = func(_ context.Context, _, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+				gotStability, gotDiff = stability, difficulty
+				return &domain.Card{ID: uuid.New(), EntryID: eid}, nil
+			}
+
+			_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{
+				RefEntryID: refEntry.ID,
+				CreateCard: true,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStability, gotStability)
+			assert.Equal(t, tt.wantDiff, gotDiff)
+		})
+	}
+}
+
 func TestService_CreateFromCatalog_WithNotes(t *testing.T) {
 	t.Parallel()
 	svc, deps := newTestService(defaultCfg())
@@ -741,6 +1023,134 @@ func TestService_CreateFromCatalog_InvalidSenseID(t *testing.T) {
 	assert.Equal(t, "sense_ids", ve.Errors[0].Field)
 }
 
+func TestService_CreateFromCatalog_SelectedPronunciations(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	us, uk, au := uuid.New(), uuid.New(), uuid.New()
+	refEntry := makeRefEntry("hello", makeRefSense("def1"))
+	refEntry.Pronunciations = []domain.RefPronunciation{{ID: us}, {ID: uk}, {ID: au}}
+	deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+		return refEntry, nil
+	}
+
+	var linked []uuid.UUID
+	deps.pronunciations.LinkFunc = func(_ context.Context, _, refPronID uuid.UUID) error {
+		linked = append(linked, refPronID)
+		return nil
+	}
+
+	_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{
+		RefEntryID:       refEntry.ID,
+		PronunciationIDs: []uuid.UUID{uk, au, uk},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{uk, au}, linked)
+}
+
+func TestService_CreateFromCatalog_AllPronunciationsByDefault(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	refEntry := makeRefEntry("hello", makeRefSense("def1"))
+	refEntry.Pronunciations = []domain.RefPronunciation{{ID: uuid.New()}, {ID: uuid.New()}}
+	deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+		return refEntry, nil
+	}
+
+	var linked []uuid.UUID
+	deps.pronunciations.LinkFunc = func(_ context.Context, _, refPronID uuid.UUID) error {
+		linked = append(linked, refPronID)
+		return nil
+	}
+
+	_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{RefEntryID: refEntry.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{refEntry.Pronunciations[0].ID, refEntry.Pronunciations[1].ID}, linked)
+}
+
+func TestService_CreateFromCatalog_InvalidPronunciationID(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	refEntry := makeRefEntry("hello", makeRefSense("def1"))
+	refEntry.Pronunciations = []domain.RefPronunciation{{ID: uuid.New()}}
+	deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+		return refEntry, nil
+	}
+	deps.entries.CreateFunc = func(context.Context, *domain.Entry) (*domain.Entry, error) {
+		t.Error("entry must not be created for a foreign pronunciation")
+		return nil, nil
+	}
+
+	// A pronunciation of another ref entry.
+	_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{
+		RefEntryID:       refEntry.ID,
+		PronunciationIDs: []uuid.UUID{uuid.New()},
+	})
+
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "pronunciation_ids", ve.Errors[0].Field)
+}
+
+func TestService_CreateFromCatalog_SelectedImages(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	img1, img2, img3 := uuid.New(), uuid.New(), uuid.New()
+	refEntry := makeRefEntry("hello", makeRefSense("def1"))
+	refEntry.Images = []domain.RefImage{{ID: img1}, {ID: img2}, {ID: img3}}
+	deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+		return refEntry, nil
+	}
+
+	var linked []uuid.UUID
+	deps.images.LinkCatalogFunc = func(_ context.Context, _, refImageID uuid.UUID) error {
+		linked = append(linked, refImageID)
+		return nil
+	}
+
+	_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{
+		RefEntryID: refEntry.ID,
+		ImageIDs:   []uuid.UUID{img3, img1, img3},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{img3, img1}, linked)
+}
+
+func TestService_CreateFromCatalog_InvalidImageID(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	refEntry := makeRefEntry("hello", makeRefSense("def1"))
+	refEntry.Images = []domain.RefImage{{ID: uuid.New()}}
+	deps.refCatalog.GetRefEntryFunc = func(_ context.Context, _ uuid.UUID) (*domain.RefEntry, error) {
+		return refEntry, nil
+	}
+	deps.entries.CreateFunc = func(context.Context, *domain.Entry) (*domain.Entry, error) {
+		t.Error("entry must not be created for a foreign image")
+		return nil, nil
+	}
+
+	_, err := svc.CreateEntryFromCatalog(ctx, CreateFromCatalogInput{
+		RefEntryID: refEntry.ID,
+		ImageIDs:   []uuid.UUID{refEntry.Images[0].ID, uuid.New()},
+	})
+
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "image_ids", ve.Errors[0].Field)
+}
+
 func TestService_CreateFromCatalog_InvalidInput(t *testing.T) {
 	t.Parallel()
 	svc, _ := newTestService(defaultCfg())
@@ -952,70 +1362,195 @@ func TestService_CreateCustom_TooLongText(t *testing.T) {
 	assert.Equal(t, "text", ve.Errors[0].Field)
 }
 
-func TestService_CreateCustom_SourceSlugUser(t *testing.T) {
+func TestService_CreateCustom_NormalizesTranslations(t *testing.T) {
 	t.Parallel()
 	svc, deps := newTestService(defaultCfg())
 	ctx, _ := authCtx()
 
-	var senseSlug, trSlug, exSlug string
-	deps.senses.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ *string, _ *domain.PartOfSpeech, _ *string, slug string) (*domain.Sense, error) {
-		senseSlug = slug
-		return &domain.Sense{ID: uuid.New()}, nil
-	}
-	deps.translations.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ string, slug string) (*domain.Translation, error) {
-		trSlug = slug
-		return &domain.Translation{ID: uuid.New()}, nil
-	}
-	deps.examples.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ string, _ *string, slug string) (*domain.Example, error) {
-		exSlug = slug
-		return &domain.Example{ID: uuid.New()}, nil
+	var created []string
+	deps.translations.CreateCustomFunc = func(_ context.Context, senseID uuid.UUID, text string, _ string) (*domain.Translation, error) {
+		created = append(created, text)
+		return &domain.Translation{ID: uuid.New(), SenseID: senseID, Text: &text}, nil
 	}
 
-	_, err := svc.CreateEntryCustom(ctx, CreateCustomInput{
+	input := CreateCustomInput{
 		Text: "hello",
 		Senses: []SenseInput{
-			{
-				Translations: []string{"привет"},
-				Examples:     []ExampleInput{{Sentence: "Hello!"}},
-			},
+			{Translations: []string{"  Привет ", "   ", "", "привет", "ПРИВЕТ", "здравствуй"}},
 		},
-	})
+	}
+	_, err := svc.CreateEntryCustom(ctx, input)
 
 	require.NoError(t, err)
-	assert.Equal(t, "user", senseSlug)
-	assert.Equal(t, "user", trSlug)
-	assert.Equal(t, "user", exSlug)
+	assert.Equal(t, []string{"Привет", "здравствуй"}, created)
+	assert.Len(t, input.Senses[0].Translations, 6, "caller input must not be mutated")
 }
 
-// ===========================================================================
-// 5. FindEntries Tests
-// ===========================================================================
-
-func TestService_FindEntries_NoFiltersOffset(t *testing.T) {
+func TestService_CreateCustom_NormalizesExamples(t *testing.T) {
 	t.Parallel()
 	svc, deps := newTestService(defaultCfg())
 	ctx, _ := authCtx()
 
-	entries := []domain.Entry{{ID: uuid.New(), Text: "hello"}, {ID: uuid.New(), Text: "world"}}
-	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
-		assert.Equal(t, "created_at", f.SortBy)
-		assert.Equal(t, "DESC", f.SortOrder)
-		return entries, 2, nil
+	type example struct {
+		sentence    string
+		translation *string
+	}
+	var created []example
+	deps.examples.CreateCustomFunc = func(_ context.Context, senseID uuid.UUID, sentence string, translation *string, _ string) (*domain.Example, error) {
+		created = append(created, example{sentence, translation})
+		return &domain.Example{ID: uuid.New(), SenseID: senseID}, nil
 	}
 
-	result, err := svc.FindEntries(ctx, FindInput{Limit: 20})
+	_, err := svc.CreateEntryCustom(ctx, CreateCustomInput{
+		Text: "hello",
+		Senses: []SenseInput{
+			{Examples: []ExampleInput{
+				{Sentence: "  Hello there.  ", Translation: ptrString(" Привет. ")},
+				{Sentence: " \t "},
+				{Sentence: "Hi!", Translation: ptrString("   ")},
+			}},
+		},
+	})
+
 	require.NoError(t, err)
-	assert.Len(t, result.Entries, 2)
-	assert.Equal(t, 2, result.TotalCount)
-	assert.False(t, result.HasNextPage)
+	require.Len(t, created, 2)
+	assert.Equal(t, "Hello there.", created[0].sentence)
+	require.NotNil(t, created[0].translation)
+	assert.Equal(t, "Привет.", *created[0].translation)
+	assert.Equal(t, "Hi!", created[1].sentence)
+	assert.Nil(t, created[1].translation)
 }
 
-func TestService_FindEntries_WithSearch(t *testing.T) {
+func TestService_CreateCustom_ConfiguredCaps(t *testing.T) {
 	t.Parallel()
-	svc, deps := newTestService(defaultCfg())
-	ctx, _ := authCtx()
 
-	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
+	cfg := defaultCfg()
+	cfg.MaxCustomSenses = 2
+	cfg.MaxCustomTranslations = 2
+	cfg.MaxCustomExamples = 2
+
+	tests := []struct {
+		name   string
+		senses []SenseInput
+		field  string
+	}{
+		{
+			name:   "senses",
+			senses: make([]SenseInput, 3),
+			field:  "senses",
+		},
+		{
+			name:   "translations",
+			senses: []SenseInput{{Translations: []string{"a", "b", "c"}}},
+			field:  "senses[0].translations",
+		},
+		{
+			name: "examples",
+			senses: []SenseInput{{Examples: []ExampleInput{
+				{Sentence: "a"}, {Sentence: "b"}, {Sentence: "c"},
+			}}},
+			field: "senses[0].examples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, deps := newTestService(cfg)
+			ctx, _ := authCtx()
+			deps.entries.CountByUserFunc = func(context.Context, uuid.UUID) (int, error) {
+				t.Error("must fail validation before touching the repo")
+				return 0, nil
+			}
+
+			_, err := svc.CreateEntryCustom(ctx, CreateCustomInput{Text: "word", Senses: tt.senses})
+
+			var ve *domain.ValidationError
+			require.ErrorAs(t, err, &ve)
+			require.Len(t, ve.Errors, 1)
+			assert.Equal(t, tt.field, ve.Errors[0].Field)
+			assert.Equal(t, "too many (max 2)", ve.Errors[0].Message)
+		})
+	}
+}
+
+func TestService_CreateCustom_CapsDefaultWhenUnset(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	_, err := svc.CreateEntryCustom(ctx, CreateCustomInput{Text: "word", Senses: make([]SenseInput, domain.MaxSensesPerEntry+1)})
+
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "senses", ve.Errors[0].Field)
+	assert.Equal(t, "too many (max 20)", ve.Errors[0].Message)
+}
+
+func TestService_CreateCustom_SourceSlugUser(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	var senseSlug, trSlug, exSlug string
+	deps.senses.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ *string, _ *domain.PartOfSpeech, _ *string, slug string) (*domain.Sense, error) {
+		senseSlug = slug
+		return &domain.Sense{ID: uuid.New()}, nil
+	}
+	deps.translations.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ string, slug string) (*domain.Translation, error) {
+		trSlug = slug
+		return &domain.Translation{ID: uuid.New()}, nil
+	}
+	deps.examples.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, _ string, _ *string, slug string) (*domain.Example, error) {
+		exSlug = slug
+		return &domain.Example{ID: uuid.New()}, nil
+	}
+
+	_, err := svc.CreateEntryCustom(ctx, CreateCustomInput{
+		Text: "hello",
+		Senses: []SenseInput{
+			{
+				Translations: []string{"привет"},
+				Examples:     []ExampleInput{{Sentence: "Hello!"}},
+			},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "user", senseSlug)
+	assert.Equal(t, "user", trSlug)
+	assert.Equal(t, "user", exSlug)
+}
+
+// ===========================================================================
+// 5. FindEntries Tests
+// ===========================================================================
+
+func TestService_FindEntries_NoFiltersOffset(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	entries := []domain.Entry{{ID: uuid.New(), Text: "hello"}, {ID: uuid.New(), Text: "world"}}
+	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
+		assert.Equal(t, "created_at", f.SortBy)
+		assert.Equal(t, "DESC", f.SortOrder)
+		return entries, 2, nil
+	}
+
+	result, err := svc.FindEntries(ctx, FindInput{Limit: 20})
+	require.NoError(t, err)
+	assert.Len(t, result.Entries, 2)
+	assert.Equal(t, 2, result.TotalCount)
+	assert.False(t, result.HasNextPage)
+}
+
+func TestService_FindEntries_WithSearch(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
 		require.NotNil(t, f.Search)
 		assert.Equal(t, "hello", *f.Search)
 		return nil, 0, nil
@@ -1025,6 +1560,24 @@ func TestService_FindEntries_WithSearch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_FindEntries_FavoriteFilter(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
+		require.NotNil(t, f.Favorite)
+		assert.True(t, *f.Favorite)
+		return []domain.Entry{{ID: uuid.New(), Favorite: true}}, 1, nil
+	}
+
+	favorite := true
+	result, err := svc.FindEntries(ctx, FindInput{Favorite: &favorite, Limit: 20})
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.True(t, result.Entries[0].Favorite)
+}
+
 func TestService_FindEntries_SearchSpaces(t *testing.T) {
 	t.Parallel()
 	svc, deps := newTestService(defaultCfg())
@@ -1164,6 +1717,35 @@ func TestService_FindEntries_CustomSort(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_FindEntries_ComputedSort(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	for _, sortBy := range []string{"due_date", "review_count"} {
+		deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, f domain.EntryFilter) ([]domain.Entry, int, error) {
+			assert.Equal(t, sortBy, f.SortBy)
+			return nil, 0, nil
+		}
+
+		_, err := svc.FindEntries(ctx, FindInput{SortBy: sortBy, Limit: 20})
+		require.NoError(t, err)
+	}
+}
+
+func TestService_FindEntries_ComputedSortRejectsCursor(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	cursor := "abc"
+	_, err := svc.FindEntries(ctx, FindInput{SortBy: "due_date", Cursor: &cursor, Limit: 20})
+	require.Error(t, err)
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "sort_by", ve.Errors[0].Field)
+}
+
 func TestService_FindEntries_InvalidSortBy(t *testing.T) {
 	t.Parallel()
 	svc, _ := newTestService(defaultCfg())
@@ -1379,6 +1961,24 @@ func TestService_FindDeletedEntries_WithEntries(t *testing.T) {
 	assert.Equal(t, 2, total)
 }
 
+func TestService_FindDeletedEntries_PurgeAt(t *testing.T) {
+	t.Parallel()
+	cfg := defaultCfg()
+	cfg.HardDeleteRetentionDays = 14
+	svc, deps := newTestService(cfg)
+	ctx, _ := authCtx()
+
+	deletedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	deps.entries.FindDeletedFunc = func(_ context.Context, _ uuid.UUID, _, _ int) ([]domain.Entry, int, error) {
+		return []domain.Entry{{ID: uuid.New(), DeletedAt: &deletedAt}}, 1, nil
+	}
+
+	result, _, err := svc.FindDeletedEntries(ctx, 20, 0)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), result[0].PurgeAt)
+}
+
 func TestService_FindDeletedEntries_Empty(t *testing.T) {
 	t.Parallel()
 	svc, deps := newTestService(defaultCfg())
@@ -1874,16 +2474,18 @@ func TestCreateFromCatalogInput_Validate_CollectsAllErrors(t *testing.T) {
 	}
 
 	input := CreateFromCatalogInput{
-		RefEntryID: uuid.Nil,
-		SenseIDs:   ids,
-		Notes:      &notesStr,
+		RefEntryID:       uuid.Nil,
+		SenseIDs:         ids,
+		PronunciationIDs: ids,
+		ImageIDs:         ids,
+		Notes:            &notesStr,
 	}
 
 	err := input.Validate()
 	require.Error(t, err)
 	var ve *domain.ValidationError
 	require.ErrorAs(t, err, &ve)
-	assert.Len(t, ve.Errors, 3, "should collect all 3 errors")
+	assert.Len(t, ve.Errors, 5, "should collect all 5 errors")
 }
 
 func TestCreateCustomInput_Validate_CollectsAllErrors(t *testing.T) {
@@ -2200,6 +2802,24 @@ func TestFindInput_Validate_InvalidStatus(t *testing.T) {
 	assert.Equal(t, "status", ve.Errors[0].Field)
 }
 
+func TestFindInput_Validate_StatusWithoutCard(t *testing.T) {
+	t.Parallel()
+
+	status := domain.CardStateReview
+	noCard := false
+	input := FindInput{Status: &status, HasCard: &noCard}
+
+	err := input.Validate()
+	require.Error(t, err)
+	var ve *domain.ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "has_card", ve.Errors[0].Field)
+
+	hasCard := true
+	input.HasCard = &hasCard
+	assert.NoError(t, input.Validate())
+}
+
 // ===========================================================================
 // Missing UpdateNotesInput.Validate Edge Cases
 // ===========================================================================
@@ -2354,3 +2974,1186 @@ func TestService_ImportEntries_ChunkRollbackResetsSeenMap(t *testing.T) {
 	assert.Equal(t, 1, result.Imported, "second chunk should succeed after first chunk rollback")
 	assert.Equal(t, 1, result.Skipped, "first chunk items should be skipped")
 }
+
+// ===========================================================================
+// 14. SearchMyEntries Tests
+// ===========================================================================
+
+func TestService_SearchMyEntries_BlankQuery(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.SearchFunc = func(_ context.Context, _ uuid.UUID, _ string, _ int) ([]domain.EntrySearchHit, error) {
+		t.Fatal("Search should not be called for a blank query")
+		return nil, nil
+	}
+
+	hits, err := svc.SearchMyEntries(ctx, "   ", 10)
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+}
+
+func TestService_SearchMyEntries_PassesQueryAndClampsLimit(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, userID := authCtx()
+
+	expected := []domain.EntrySearchHit{{
+		Entry:   domain.Entry{ID: uuid.New(), Text: "abandon"},
+		Rank:    0.4,
+		Snippet: "To <b>leave</b> permanently",
+	}}
+	deps.entries.SearchFunc = func(_ context.Context, uid uuid.UUID, q string, l int) ([]domain.EntrySearchHit, error) {
+		assert.Equal(t, userID, uid)
+		assert.Equal(t, "leave", q)
+		assert.Equal(t, 50, l)
+		return expected, nil
+	}
+
+	hits, err := svc.SearchMyEntries(ctx, " leave ", 999)
+	require.NoError(t, err)
+	assert.Equal(t, expected, hits)
+}
+
+func TestService_SearchMyEntries_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+
+	_, err := svc.SearchMyEntries(context.Background(), "test", 10)
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+// ===========================================================================
+// 15. ReorderSenses Tests
+// ===========================================================================
+
+func reorderFixture(deps *testDeps, userID uuid.UUID, senseIDs ...uuid.UUID) uuid.UUID {
+	entryID := uuid.New()
+	deps.entries.GetByIDFunc = func(_ context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
+		if uid != userID || eid != entryID {
+			return nil, domain.ErrNotFound
+		}
+		return &domain.Entry{ID: entryID, UserID: userID}, nil
+	}
+	deps.senses.GetByEntryIDsFunc = func(_ context.Context, ids []uuid.UUID) ([]domain.Sense, error) {
+		senses := make([]domain.Sense, len(senseIDs))
+		for i, id := range senseIDs {
+			senses[i] = domain.Sense{ID: id, EntryID: entryID, Position: i}
+		}
+		return senses, nil
+	}
+	return entryID
+}
+
+func TestService_ReorderSenses_Success(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, userID := authCtx()
+
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	entryID := reorderFixture(deps, userID, a, b, c)
+
+	var gotIDs []uuid.UUID
+	deps.senses.UpdatePositionsFunc = func(_ context.Context, eid uuid.UUID, ids []uuid.UUID) error {
+		assert.Equal(t, entryID, eid)
+		gotIDs = ids
+		return nil
+	}
+	var audited *domain.AuditRecord
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		audited = &rec
+		return rec, nil
+	}
+
+	err := svc.ReorderSenses(ctx, ReorderSensesInput{EntryID: entryID, OrderedSenseIDs: []uuid.UUID{c, a, b}})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{c, a, b}, gotIDs)
+	require.NotNil(t, audited)
+	assert.Equal(t, domain.EntityTypeEntry, audited.EntityType)
+	assert.Equal(t, domain.AuditActionUpdate, audited.Action)
+	assert.Contains(t, audited.Changes, "sense_order")
+}
+
+func TestService_ReorderSenses_MismatchedSet(t *testing.T) {
+	t.Parallel()
+
+	a, b := uuid.New(), uuid.New()
+	tests := []struct {
+		name string
+		ids  []uuid.UUID
+	}{
+		{"missing sense", []uuid.UUID{a}},
+		{"foreign sense", []uuid.UUID{a, uuid.New()}},
+		{"extra sense", []uuid.UUID{a, b, uuid.New()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, deps := newTestService(defaultCfg())
+			ctx, userID := authCtx()
+			entryID := reorderFixture(deps, userID, a, b)
+
+			deps.senses.UpdatePositionsFunc = func(_ context.Context, _ uuid.UUID, _ []uuid.UUID) error {
+				t.Fatal("UpdatePositions should not be called")
+				return nil
+			}
+
+			err := svc.ReorderSenses(ctx, ReorderSensesInput{EntryID: entryID, OrderedSenseIDs: tt.ids})
+			var verr *domain.ValidationError
+			require.ErrorAs(t, err, &verr)
+		})
+	}
+}
+
+func TestService_ReorderSenses_DuplicateIDs(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	id := uuid.New()
+	err := svc.ReorderSenses(ctx, ReorderSensesInput{EntryID: uuid.New(), OrderedSenseIDs: []uuid.UUID{id, id}})
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ReorderSenses_EntryNotFound(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	err := svc.ReorderSenses(ctx, ReorderSensesInput{EntryID: uuid.New(), OrderedSenseIDs: []uuid.UUID{uuid.New()}})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+// ===========================================================================
+// 16-18. UpdateSense / UpdateTranslation / UpdateExample Tests
+// ===========================================================================
+
+func TestService_UpdateSense_UserSourced(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, userID := authCtx()
+
+	senseID := uuid.New()
+	deps.senses.GetByIDForUserFunc = func(_ context.Context, uid, sid uuid.UUID) (*domain.Sense, error) {
+		assert.Equal(t, userID, uid)
+		return &domain.Sense{ID: sid, Definition: ptrString("old"), SourceSlug: "user"}, nil
+	}
+	var audited *domain.AuditRecord
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		audited = &rec
+		return rec, nil
+	}
+
+	sense, err := svc.UpdateSense(ctx, UpdateSenseInput{SenseID: senseID, Definition: ptrString("new")})
+	require.NoError(t, err)
+	assert.Equal(t, "new", *sense.Definition)
+	require.NotNil(t, audited)
+	assert.Equal(t, domain.EntityTypeSense, audited.EntityType)
+	assert.Equal(t, map[string]any{"old": ptrString("old"), "new": ptrString("new")}, audited.Changes["definition"])
+}
+
+func TestService_UpdateSense_PartialKeepsOtherFields(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	pos := domain.PartOfSpeechVerb
+	deps.senses.GetByIDForUserFunc = func(_ context.Context, _, sid uuid.UUID) (*domain.Sense, error) {
+		return &domain.Sense{ID: sid, Definition: ptrString("old"), PartOfSpeech: &pos, CEFRLevel: ptrString("B2"), SourceSlug: "user"}, nil
+	}
+	deps.senses.UpdateFunc = func(_ context.Context, sid uuid.UUID, definition *string, p *domain.PartOfSpeech, cefr *string) (*domain.Sense, error) {
+		return &domain.Sense{ID: sid, Definition: definition, PartOfSpeech: p, CEFRLevel: cefr, SourceSlug: "user"}, nil
+	}
+
+	sense, err := svc.UpdateSense(ctx, UpdateSenseInput{SenseID: uuid.New(), Definition: ptrString("new")})
+	require.NoError(t, err)
+	assert.Equal(t, "new", *sense.Definition)
+	require.NotNil(t, sense.PartOfSpeech)
+	assert.Equal(t, domain.PartOfSpeechVerb, *sense.PartOfSpeech)
+	require.NotNil(t, sense.CEFRLevel)
+	assert.Equal(t, "B2", *sense.CEFRLevel)
+}
+
+func TestService_UpdateSense_CatalogSourcedRejected(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.senses.GetByIDForUserFunc = func(_ context.Context, _, sid uuid.UUID) (*domain.Sense, error) {
+		return &domain.Sense{ID: sid, SourceSlug: "freedict"}, nil
+	}
+	deps.senses.UpdateFunc = func(_ context.Context, _ uuid.UUID, _ *string, _ *domain.PartOfSpeech, _ *string) (*domain.Sense, error) {
+		t.Fatal("Update should not be called for a catalog sense")
+		return nil, nil
+	}
+
+	_, err := svc.UpdateSense(ctx, UpdateSenseInput{SenseID: uuid.New(), Definition: ptrString("new")})
+	var verr *domain.ValidationError
+	require.ErrorAs(t, err, &verr)
+}
+
+func TestService_UpdateSense_NotOwned(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	_, err := svc.UpdateSense(ctx, UpdateSenseInput{SenseID: uuid.New(), Definition: ptrString("new")})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestService_UpdateTranslation_UserSourcedAndCatalog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		slug    string
+		wantErr bool
+	}{
+		{"user", false},
+		{"import", false},
+		{"freedict", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			t.Parallel()
+			svc, deps := newTestService(defaultCfg())
+			ctx, _ := authCtx()
+
+			senseID := uuid.New()
+			deps.translations.GetByIDForUserFunc = func(_ context.Context, _, tid uuid.UUID) (*domain.Translation, error) {
+				return &domain.Translation{ID: tid, SenseID: senseID, Text: ptrString("old"), SourceSlug: tt.slug}, nil
+			}
+			var audited *domain.AuditRecord
+			deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+				audited = &rec
+				return rec, nil
+			}
+
+			tr, err := svc.UpdateTranslation(ctx, UpdateTranslationInput{TranslationID: uuid.New(), Text: "new"})
+			if tt.wantErr {
+				require.ErrorIs(t, err, domain.ErrValidation)
+				assert.Nil(t, audited)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "new", *tr.Text)
+			require.NotNil(t, audited)
+			assert.Equal(t, &senseID, audited.EntityID)
+		})
+	}
+}
+
+func TestService_UpdateExample_CatalogSourcedRejected(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.examples.GetByIDForUserFunc = func(_ context.Context, _, eid uuid.UUID) (*domain.Example, error) {
+		return &domain.Example{ID: eid, SourceSlug: "tatoeba"}, nil
+	}
+
+	_, err := svc.UpdateExample(ctx, UpdateExampleInput{ExampleID: uuid.New(), Sentence: "New sentence."})
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_UpdateExample_UserSourced(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.examples.GetByIDForUserFunc = func(_ context.Context, _, eid uuid.UUID) (*domain.Example, error) {
+		return &domain.Example{ID: eid, Sentence: ptrString("Old sentence."), SourceSlug: "user"}, nil
+	}
+	var audited *domain.AuditRecord
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		audited = &rec
+		return rec, nil
+	}
+
+	ex, err := svc.UpdateExample(ctx, UpdateExampleInput{ExampleID: uuid.New(), Sentence: "New sentence."})
+	require.NoError(t, err)
+	assert.Equal(t, "New sentence.", *ex.Sentence)
+	require.NotNil(t, audited)
+	assert.Equal(t, domain.EntityTypeExample, audited.EntityType)
+}
+
+// ===========================================================================
+// 19. CheckSimilar Tests
+// ===========================================================================
+
+func TestService_CheckSimilar_LemmaMatch(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	svc.SetLemmas(map[string]string{"running": "run", "ran": "run", "runs": "run"})
+	ctx, userID := authCtx()
+
+	run := domain.Entry{ID: uuid.New(), Text: "run", TextNormalized: "run"}
+	deps.entries.FindSimilarCandidatesFunc = func(_ context.Context, uid uuid.UUID, forms []string, text string, maxDist int) ([]domain.Entry, error) {
+		assert.Equal(t, userID, uid)
+		assert.Contains(t, forms, "run")
+		assert.Equal(t, "running", text)
+		assert.Equal(t, 2, maxDist)
+		return []domain.Entry{run}, nil
+	}
+
+	similar, err := svc.CheckSimilar(ctx, "Running")
+	require.NoError(t, err)
+	require.Len(t, similar, 1)
+	assert.Equal(t, run.ID, similar[0].Entry.ID)
+	assert.Equal(t, SimilarityLemma, similar[0].Reason)
+}
+
+func TestService_CheckSimilar_WithoutLemmaDataIgnoresInflection(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.FindSimilarCandidatesFunc = func(_ context.Context, _ uuid.UUID, _ []string, _ string, _ int) ([]domain.Entry, error) {
+		return []domain.Entry{{ID: uuid.New(), TextNormalized: "run"}}, nil
+	}
+
+	similar, err := svc.CheckSimilar(ctx, "running")
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+}
+
+func TestService_CheckSimilar_TypoAndExactOrdering(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.FindSimilarCandidatesFunc = func(_ context.Context, _ uuid.UUID, _ []string, _ string, _ int) ([]domain.Entry, error) {
+		return []domain.Entry{
+			{TextNormalized: "recieve"},
+			{TextNormalized: "relieve"},
+			{TextNormalized: "receive"},
+			{TextNormalized: "deceiver"},
+			{TextNormalized: "perceive"},
+		}, nil
+	}
+
+	similar, err := svc.CheckSimilar(ctx, "receive")
+	require.NoError(t, err)
+	require.Len(t, similar, 4)
+	assert.Equal(t, SimilarityExact, similar[0].Reason)
+	assert.Equal(t, "receive", similar[0].Entry.TextNormalized)
+	assert.Equal(t, "deceiver", similar[1].Entry.TextNormalized)
+	assert.Equal(t, 2, similar[1].Distance)
+	for _, s := range similar[1:] {
+		assert.Equal(t, SimilarityEditDistance, s.Reason)
+	}
+}
+
+func TestService_CheckSimilar_BlankText(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.FindSimilarCandidatesFunc = func(_ context.Context, _ uuid.UUID, _ []string, _ string, _ int) ([]domain.Entry, error) {
+		t.Fatal("FindSimilarCandidates should not be called for blank text")
+		return nil, nil
+	}
+
+	similar, err := svc.CheckSimilar(ctx, "  ")
+	require.NoError(t, err)
+	assert.Empty(t, similar)
+}
+
+func TestService_CheckSimilar_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+
+	_, err := svc.CheckSimilar(context.Background(), "run")
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"run", "", 3},
+		{"run", "run", 0},
+		{"kitten", "sitting", 3},
+		{"receive", "recieve", 2},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, levenshtein(tt.a, tt.b), "%q vs %q", tt.a, tt.b)
+	}
+}
+
+// ===========================================================================
+// 20. RestoreAllDeleted Tests
+// ===========================================================================
+
+func TestService_RestoreAllDeleted_UsesRetentionWindow(t *testing.T) {
+	t.Parallel()
+	cfg := defaultCfg()
+	cfg.HardDeleteRetentionDays = 7
+	svc, deps := newTestService(cfg)
+	ctx, userID := authCtx()
+
+	restored := []uuid.UUID{uuid.New(), uuid.New()}
+	var since, countBefore time.Time
+	deps.entries.CountDeletedBeforeFunc = func(_ context.Context, uid uuid.UUID, threshold time.Time) (int, error) {
+		assert.Equal(t, userID, uid)
+		countBefore = threshold
+		return 3, nil
+	}
+	deps.entries.RestoreBatchFunc = func(_ context.Context, uid uuid.UUID, s time.Time) ([]uuid.UUID, error) {
+		assert.Equal(t, userID, uid)
+		since = s
+		return restored, nil
+	}
+	var audited *domain.AuditRecord
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		audited = &rec
+		return rec, nil
+	}
+
+	before := time.Now()
+	result, err := svc.RestoreAllDeleted(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Restored)
+	assert.Equal(t, 3, result.PendingPurge)
+	assert.Equal(t, since, countBefore, "restore and pending count must share one boundary")
+	assert.WithinDuration(t, before.AddDate(0, 0, -7), since, time.Second)
+	require.NotNil(t, audited)
+	assert.Equal(t, 2, audited.Changes["count"])
+}
+
+func TestService_RestoreAllDeleted_NothingToRestoreSkipsAudit(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.CountDeletedBeforeFunc = func(_ context.Context, _ uuid.UUID, _ time.Time) (int, error) {
+		return 4, nil
+	}
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		t.Fatal("audit should not be written when nothing was restored")
+		return rec, nil
+	}
+
+	result, err := svc.RestoreAllDeleted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Restored)
+	assert.Equal(t, 4, result.PendingPurge)
+}
+
+func TestService_RestoreAllDeleted_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+
+	_, err := svc.RestoreAllDeleted(context.Background())
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+// ===========================================================================
+// 21. ImportAnki Tests
+// ===========================================================================
+
+func newAnkiTestService(notes []domain.AnkiNote) (*Service, *testDeps) {
+	svc, deps := newTestService(defaultCfg())
+	svc.SetAnkiReader(&mockAnkiReader{
+		ReadNotesFunc: func(_ context.Context, _ io.Reader) ([]domain.AnkiNote, error) {
+			return notes, nil
+		},
+	})
+	deps.entries.CreateFunc = func(_ context.Context, entry *domain.Entry) (*domain.Entry, error) {
+		return entry, nil
+	}
+	return svc, deps
+}
+
+func TestService_ImportAnki_Happy(t *testing.T) {
+	t.Parallel()
+	due := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	svc, deps := newAnkiTestService([]domain.AnkiNote{
+		{
+			Front:    "run",
+			Back:     "бежать, мчаться; управлять",
+			Schedule: &domain.AnkiSchedule{Due: due, IntervalDays: 12, Ease: 2.5, Reps: 5, Lapses: 1},
+		},
+		{Front: "serendipity", Back: "a happy accident"},
+	})
+	ctx, userID := authCtx()
+
+	var definitions []string
+	deps.senses.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, def *string, _ *domain.PartOfSpeech, _ *string, slug string) (*domain.Sense, error) {
+		require.NotNil(t, def)
+		assert.Equal(t, "import", slug)
+		definitions = append(definitions, *def)
+		return &domain.Sense{ID: uuid.New()}, nil
+	}
+	var translations []string
+	deps.translations.CreateCustomFunc = func(_ context.Context, _ uuid.UUID, text string, _ string) (*domain.Translation, error) {
+		translations = append(translations, text)
+		return &domain.Translation{ID: uuid.New()}, nil
+	}
+	var cardsCreated int
+	deps.cards.CreateFunc = func(_ context.Context, uid, entryID uuid.UUID) (*domain.Card, error) {
+		cardsCreated++
+		return &domain.Card{ID: uuid.New(), UserID: uid, EntryID: entryID}, nil
+	}
+	var srs *domain.SRSUpdateParams
+	deps.cards.UpdateSRSFunc = func(_ context.Context, uid, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+		assert.Equal(t, userID, uid)
+		srs = &params
+		return &domain.Card{ID: cardID}, nil
+	}
+
+	result, err := svc.ImportAnki(ctx, strings.NewReader("apkg"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Equal(t, []string{"бежать, мчаться; управлять", "a happy accident"}, definitions)
+	assert.Equal(t, []string{"бежать", "мчаться", "управлять"}, translations)
+	assert.Equal(t, 2, cardsCreated)
+
+	require.NotNil(t, srs, "review schedule should be carried over")
+	assert.Equal(t, domain.CardStateReview, srs.State)
+	assert.Equal(t, due, srs.Due)
+	assert.Equal(t, 12, srs.ScheduledDays)
+	assert.InDelta(t, 12, srs.Stability, 1e-9)
+	assert.InDelta(t, 5, srs.Difficulty, 1e-9)
+	assert.Equal(t, 5, srs.Reps)
+	assert.Equal(t, 1, srs.Lapses)
+	require.NotNil(t, srs.LastReview)
+	assert.Equal(t, due.AddDate(0, 0, -12), *srs.LastReview)
+}
+
+func TestService_ImportAnki_SkipsMalformedAndDuplicates(t *testing.T) {
+	t.Parallel()
+	svc, deps := newAnkiTestService([]domain.AnkiNote{
+		{Front: "orphan"},
+		{Front: "", Back: "no front"},
+		{Front: "hello", Back: "привет"},
+		{Front: "Hello", Back: "здравствуй"},
+		{Front: "world", Back: "мир"},
+	})
+	ctx, _ := authCtx()
+
+	deps.entries.GetByTextFunc = func(_ context.Context, _ uuid.UUID, text string) (*domain.Entry, error) {
+		if text == "world" {
+			return &domain.Entry{ID: uuid.New()}, nil
+		}
+		return nil, domain.ErrNotFound
+	}
+
+	result, err := svc.ImportAnki(ctx, strings.NewReader("apkg"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 4, result.Skipped)
+	require.Len(t, result.Errors, 4)
+	assert.Equal(t, ImportError{LineNumber: 1, Text: "orphan", Reason: "malformed note: empty back"}, result.Errors[0])
+	assert.Equal(t, "malformed note: empty front", result.Errors[1].Reason)
+	assert.Equal(t, "duplicate within import", result.Errors[2].Reason)
+	assert.Equal(t, 4, result.Errors[2].LineNumber)
+	assert.Equal(t, "entry already exists", result.Errors[3].Reason)
+}
+
+func TestService_ImportAnki_Chunks(t *testing.T) {
+	t.Parallel()
+	notes := make([]domain.AnkiNote, 5)
+	for i := range notes {
+		notes[i] = domain.AnkiNote{Front: fmt.Sprintf("word%d", i), Back: "definition"}
+	}
+	svc, deps := newAnkiTestService(notes)
+	svc.cfg.ImportChunkSize = 2
+	ctx, _ := authCtx()
+
+	var txCalls int
+	deps.tx.RunInTxFunc = func(ctx context.Context, fn func(context.Context) error) error {
+		txCalls++
+		if txCalls == 2 {
+			return errors.New("boom")
+		}
+		return fn(ctx)
+	}
+
+	result, err := svc.ImportAnki(ctx, strings.NewReader("apkg"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, txCalls)
+	assert.Equal(t, 3, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 3, result.Errors[0].LineNumber)
+	assert.Contains(t, result.Errors[0].Reason, "chunk transaction failed")
+}
+
+func TestService_ImportAnki_UnreadablePackage(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	svc.SetAnkiReader(&mockAnkiReader{
+		ReadNotesFunc: func(_ context.Context, _ io.Reader) ([]domain.AnkiNote, error) {
+			return nil, errors.New("not a zip")
+		},
+	})
+	ctx, _ := authCtx()
+
+	_, err := svc.ImportAnki(ctx, strings.NewReader("junk"))
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ImportAnki_EmptyPackage(t *testing.T) {
+	t.Parallel()
+	svc, _ := newAnkiTestService(nil)
+	ctx, _ := authCtx()
+
+	_, err := svc.ImportAnki(ctx, strings.NewReader("apkg"))
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ImportAnki_ExceedsEntryLimit(t *testing.T) {
+	t.Parallel()
+	svc, deps := newAnkiTestService([]domain.AnkiNote{{Front: "a", Back: "b"}})
+	ctx, _ := authCtx()
+
+	deps.entries.CountByUserFunc = func(_ context.Context, _ uuid.UUID) (int, error) {
+		return svc.cfg.MaxEntriesPerUser, nil
+	}
+
+	_, err := svc.ImportAnki(ctx, strings.NewReader("apkg"))
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ImportAnki_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newAnkiTestService(nil)
+
+	_, err := svc.ImportAnki(context.Background(), strings.NewReader("apkg"))
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestAnkiTranslations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		back string
+		want []string
+	}{
+		{"бежать, мчаться; управлять", []string{"бежать", "мчаться", "управлять"}},
+		{"to leave the ground\nвзлетать", []string{"взлетать"}},
+		{"a happy accident", nil},
+		{"очень длинная фраза из многих слов", nil},
+		{"дом / дом", []string{"дом"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ankiTranslations(tt.back), tt.back)
+	}
+}
+
+func TestAnkiSRSParams_DifficultyFromEase(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 10, ankiSRSParams(domain.AnkiSchedule{Ease: 1.3}).Difficulty, 1e-9)
+	assert.InDelta(t, 1, ankiSRSParams(domain.AnkiSchedule{Ease: 4.0}).Difficulty, 1e-9)
+}
+
+// ===========================================================================
+// 22-23. ExportBackup / ImportBackup Tests
+// ===========================================================================
+
+// memDictionary is an in-memory stand-in for the repositories touched by
+// backups, so a round trip can export what an import wrote.
+type memDictionary struct {
+	entries      []domain.Entry
+	senses       []domain.Sense
+	translations []domain.Translation
+	examples     []domain.Example
+	cards        []domain.Card
+	topics       []*domain.Topic
+	links        map[uuid.UUID][]uuid.UUID // topic ID -> entry IDs
+}
+
+func newMemBackupService() (*Service, *memDictionary) {
+	svc, deps := newTestService(defaultCfg())
+	mem := &memDictionary{links: make(map[uuid.UUID][]uuid.UUID)}
+
+	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, _ domain.EntryFilter) ([]domain.Entry, int, error) {
+		return mem.entries, len(mem.entries), nil
+	}
+	deps.entries.GetByTextFunc = func(_ context.Context, _ uuid.UUID, text string) (*domain.Entry, error) {
+		for i := range mem.entries {
+			if mem.entries[i].TextNormalized == text {
+				return &mem.entries[i], nil
+			}
+		}
+		return nil, domain.ErrNotFound
+	}
+	deps.entries.CreateFunc = func(_ context.Context, entry *domain.Entry) (*domain.Entry, error) {
+		mem.entries = append(mem.entries, *entry)
+		return entry, nil
+	}
+	deps.senses.GetByEntryIDsFunc = func(_ context.Context, _ []uuid.UUID) ([]domain.Sense, error) {
+		return mem.senses, nil
+	}
+	deps.senses.CreateCustomFunc = func(_ context.Context, entryID uuid.UUID, def *string, pos *domain.PartOfSpeech, cefr *string, slug string) (*domain.Sense, error) {
+		sense := domain.Sense{ID: uuid.New(), EntryID: entryID, Definition: def, PartOfSpeech: pos, CEFRLevel: cefr, SourceSlug: slug}
+		mem.senses = append(mem.senses, sense)
+		return &sense, nil
+	}
+	deps.translations.GetBySenseIDsFunc = func(_ context.Context, _ []uuid.UUID) ([]domain.Translation, error) {
+		return mem.translations, nil
+	}
+	deps.translations.CreateCustomFunc = func(_ context.Context, senseID uuid.UUID, text string, slug string) (*domain.Translation, error) {
+		tr := domain.Translation{ID: uuid.New(), SenseID: senseID, Text: &text, SourceSlug: slug}
+		mem.translations = append(mem.translations, tr)
+		return &tr, nil
+	}
+	deps.examples.GetBySenseIDsFunc = func(_ context.Context, _ []uuid.UUID) ([]domain.Example, error) {
+		return mem.examples, nil
+	}
+	deps.examples.CreateCustomFunc = func(_ context.Context, senseID uuid.UUID, sentence string, translation *string, slug string) (*domain.Example, error) {
+		ex := domain.Example{ID: uuid.New(), SenseID: senseID, Sentence: &sentence, Translation: translation, SourceSlug: slug}
+		mem.examples = append(mem.examples, ex)
+		return &ex, nil
+	}
+	deps.cards.GetByEntryIDsFunc = func(_ context.Context, _ uuid.UUID, _ []uuid.UUID) ([]domain.Card, error) {
+		return mem.cards, nil
+	}
+	deps.cards.CreateFunc = func(_ context.Context, userID, entryID uuid.UUID) (*domain.Card, error) {
+		card := domain.Card{ID: uuid.New(), UserID: userID, EntryID: entryID, State: domain.CardStateNew}
+		mem.cards = append(mem.cards, card)
+		return &card, nil
+	}
+	deps.cards.UpdateSRSFunc = func(_ context.Context, _ uuid.UUID, cardID uuid.UUID, p domain.SRSUpdateParams) (*domain.Card, error) {
+		for i := range mem.cards {
+			if mem.cards[i].ID == cardID {
+				c := &mem.cards[i]
+				c.State, c.Step, c.Stability, c.Difficulty = p.State, p.Step, p.Stability, p.Difficulty
+				c.Due, c.LastReview, c.Reps, c.Lapses = p.Due, p.LastReview, p.Reps, p.Lapses
+				c.ScheduledDays, c.ElapsedDays = p.ScheduledDays, p.ElapsedDays
+				return c, nil
+			}
+		}
+		return nil, domain.ErrNotFound
+	}
+
+	svc.SetTopics(&mockTopicRepo{
+		ListFunc: func(_ context.Context, _ uuid.UUID) ([]*domain.Topic, error) {
+			return mem.topics, nil
+		},
+		GetEntryIDsByTopicIDFunc: func(_ context.Context, topicID uuid.UUID) ([]uuid.UUID, error) {
+			return mem.links[topicID], nil
+		},
+		CreateFunc: func(_ context.Context, userID uuid.UUID, topic *domain.Topic) (*domain.Topic, error) {
+			t := &domain.Topic{ID: uuid.New(), UserID: userID, Name: topic.Name, Description: topic.Description}
+			mem.topics = append(mem.topics, t)
+			return t, nil
+		},
+		LinkEntryFunc: func(_ context.Context, entryID, topicID uuid.UUID) error {
+			mem.links[topicID] = append(mem.links[topicID], entryID)
+			return nil
+		},
+	})
+
+	return svc, mem
+}
+
+// normalizeBackup drops the fields that legitimately change across a round
+// trip: export time and the backup-local IDs (topic links become entry texts).
+func normalizeBackup(b domain.Backup) (domain.Backup, map[string][]string) {
+	textByID := make(map[uuid.UUID]string, len(b.Entries))
+	for i := range b.Entries {
+		textByID[b.Entries[i].ID] = b.Entries[i].Text
+		b.Entries[i].ID = uuid.Nil
+	}
+	links := make(map[string][]string)
+	for i := range b.Topics {
+		for _, id := range b.Topics[i].EntryIDs {
+			links[b.Topics[i].Name] = append(links[b.Topics[i].Name], textByID[id])
+		}
+		b.Topics[i].EntryIDs = nil
+	}
+	b.ExportedAt = time.Time{}
+	return b, links
+}
+
+func TestService_Backup_RoundTrip(t *testing.T) {
+	t.Parallel()
+	src, mem := newMemBackupService()
+	ctx, _ := authCtx()
+
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	due := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	lastReview := due.AddDate(0, 0, -10)
+	noun := domain.PartOfSpeechNoun
+
+	entryA, entryB := uuid.New(), uuid.New()
+	senseA := uuid.New()
+	mem.entries = []domain.Entry{
+		{ID: entryA, Text: "house", TextNormalized: "house", Notes: ptrString("my note"), CreatedAt: created},
+		{ID: entryB, Text: "cat", TextNormalized: "cat", CreatedAt: created.Add(time.Hour)},
+	}
+	mem.senses = []domain.Sense{
+		{ID: senseA, EntryID: entryA, Definition: ptrString("a building"), PartOfSpeech: &noun, CEFRLevel: ptrString("A1"), SourceSlug: "user"},
+	}
+	mem.translations = []domain.Translation{{ID: uuid.New(), SenseID: senseA, Text: ptrString("дом"), SourceSlug: "user"}}
+	mem.examples = []domain.Example{{ID: uuid.New(), SenseID: senseA, Sentence: ptrString("A big house."), Translation: ptrString("Большой дом."), SourceSlug: "user"}}
+	mem.cards = []domain.Card{
+		{ID: uuid.New(), EntryID: entryA, State: domain.CardStateReview, Stability: 10.5, Difficulty: 4.2, Due: due, LastReview: &lastReview, Reps: 3, Lapses: 1, ScheduledDays: 10, ElapsedDays: 9},
+		{ID: uuid.New(), EntryID: entryB, State: domain.CardStateNew},
+	}
+	topicID := uuid.New()
+	mem.topics = []*domain.Topic{{ID: topicID, Name: "home", Description: ptrString("around the house")}}
+	mem.links[topicID] = []uuid.UUID{entryA}
+
+	var buf strings.Builder
+	require.NoError(t, src.WriteBackup(ctx, &buf))
+
+	dst, _ := newMemBackupService()
+	dstCtx, _ := authCtx()
+	result, err := dst.ImportBackup(dstCtx, strings.NewReader(buf.String()))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 0, result.Skipped)
+
+	want, err := src.ExportBackup(ctx)
+	require.NoError(t, err)
+	got, err := dst.ExportBackup(dstCtx)
+	require.NoError(t, err)
+
+	wantNorm, wantLinks := normalizeBackup(want)
+	gotNorm, gotLinks := normalizeBackup(got)
+	assert.Equal(t, domain.BackupVersion, got.Version)
+	assert.Equal(t, wantNorm, gotNorm)
+	assert.Equal(t, map[string][]string{"home": {"house"}}, gotLinks)
+	assert.Equal(t, wantLinks, gotLinks)
+}
+
+func TestService_ImportBackup_SkipsConflictsAndReusesTopics(t *testing.T) {
+	t.Parallel()
+	svc, mem := newMemBackupService()
+	ctx, _ := authCtx()
+
+	existingTopic := &domain.Topic{ID: uuid.New(), Name: "animals"}
+	mem.topics = []*domain.Topic{existingTopic}
+	mem.entries = []domain.Entry{{ID: uuid.New(), Text: "cat", TextNormalized: "cat"}}
+
+	catID, dogID := uuid.New(), uuid.New()
+	backup := domain.Backup{
+		Version: domain.BackupVersion,
+		Entries: []domain.BackupEntry{
+			{ID: catID, Text: "cat"},
+			{ID: dogID, Text: "dog", Senses: []domain.BackupSense{{Definition: ptrString("an animal"), SourceSlug: "freedict"}}},
+			{ID: uuid.New(), Text: "Dog"},
+		},
+		Topics: []domain.BackupTopic{{Name: "animals", EntryIDs: []uuid.UUID{catID, dogID}}},
+	}
+	raw, err := json.Marshal(backup)
+	require.NoError(t, err)
+
+	result, err := svc.ImportBackup(ctx, bytes.NewReader(raw))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 2, result.Skipped)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "entry already exists", result.Errors[0].Reason)
+	assert.Equal(t, "duplicate within import", result.Errors[1].Reason)
+
+	require.Len(t, mem.topics, 1, "existing topic should be reused by name")
+	dog := mem.entries[len(mem.entries)-1]
+	assert.NotEqual(t, dogID, dog.ID, "IDs are remapped")
+	assert.Equal(t, []uuid.UUID{dog.ID}, mem.links[existingTopic.ID])
+
+	require.Len(t, mem.senses, 1)
+	assert.Equal(t, "import", mem.senses[0].SourceSlug, "catalog content is restored as editable import content")
+}
+
+func TestService_ImportBackup_Version(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing version", `{"entries":[]}`},
+		{"future version", `{"version":99,"entries":[]}`},
+		{"not json", `nope`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, _ := newMemBackupService()
+			ctx, _ := authCtx()
+
+			_, err := svc.ImportBackup(ctx, strings.NewReader(tt.body))
+			require.ErrorIs(t, err, domain.ErrValidation)
+		})
+	}
+}
+
+func TestService_ImportBackup_ExceedsEntryLimit(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.CountByUserFunc = func(_ context.Context, _ uuid.UUID) (int, error) {
+		return svc.cfg.MaxEntriesPerUser, nil
+	}
+
+	_, err := svc.ImportBackup(ctx, strings.NewReader(`{"version":1,"entries":[{"text":"x"}]}`))
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ImportBackup_TopicChecks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		topics []domain.BackupTopic
+		seed   int
+	}{
+		{"empty name", []domain.BackupTopic{{Name: "   "}}, 0},
+		{"name too long", []domain.BackupTopic{{Name: strings.Repeat("a", 101)}}, 0},
+		{"topic limit", []domain.BackupTopic{{Name: "one more"}}, topic.MaxTopicsPerUser},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			svc, mem := newMemBackupService()
+			ctx, _ := authCtx()
+			for i := range tt.seed {
+				mem.topics = append(mem.topics, &domain.Topic{ID: uuid.New(), Name: fmt.Sprintf("topic %d", i)})
+			}
+
+			raw, err := json.Marshal(domain.Backup{Version: domain.BackupVersion, Topics: tt.topics})
+			require.NoError(t, err)
+
+			_, err = svc.ImportBackup(ctx, bytes.NewReader(raw))
+			require.ErrorIs(t, err, domain.ErrValidation)
+			assert.Len(t, mem.topics, tt.seed, "no topic should be created")
+		})
+	}
+}
+
+func TestService_ExportBackup_TooManyEntries(t *testing.T) {
+	t.Parallel()
+	cfg := defaultCfg()
+	cfg.ExportMaxEntries = 1
+	svc, deps := newTestService(cfg)
+	ctx, _ := authCtx()
+
+	deps.entries.FindFunc = func(_ context.Context, _ uuid.UUID, _ domain.EntryFilter) ([]domain.Entry, int, error) {
+		return []domain.Entry{{ID: uuid.New(), Text: "cat"}}, 2, nil
+	}
+
+	_, err := svc.ExportBackup(ctx)
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
+
+func TestService_ExportBackup_NoAuth(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+
+	_, err := svc.ExportBackup(context.Background())
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+	_, err = svc.ImportBackup(context.Background(), strings.NewReader("{}"))
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+// ===========================================================================
+// 24. BatchCreateFromCatalog Tests
+// ===========================================================================
+
+func TestService_BatchCreateFromCatalog_DuplicatesAndMisses(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.GetByTextFunc = func(_ context.Context, _ uuid.UUID, text string) (*domain.Entry, error) {
+		if text == "cat" {
+			return &domain.Entry{ID: uuid.New(), Text: "cat"}, nil
+		}
+		return nil, domain.ErrNotFound
+	}
+	var fetched []string
+	deps.refCatalog.GetOrFetchEntryFunc = func(_ context.Context, text string) (*domain.RefEntry, error) {
+		fetched = append(fetched, text)
+		if text == "qwxz" {
+			return nil, domain.ErrNotFound
+		}
+		return makeRefEntry(text, makeRefSense("def")), nil
+	}
+	cardsCreated := 0
+	deps.cards.CreateWithParamsFunc = func(_ context.Context, userID, entryID uuid.UUID, _, _ float64) (*domain.Card, error) {
+		cardsCreated++
+		return &domain.Card{ID: uuid.New(), UserID: userID, EntryID: entryID}, nil
+	}
+
+	result, err := svc.BatchCreateFromCatalog(ctx, BatchCreateFromCatalogInput{
+		Texts:       []string{"Dog", "cat", "dog", "qwxz", "  ", "bird"},
+		CreateCards: true,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 2)
+	assert.Equal(t, "dog", result.Created[0].TextNormalized)
+	assert.Equal(t, "bird", result.Created[1].TextNormalized)
+	assert.Equal(t, []string{"cat"}, result.SkippedExisting)
+	assert.Equal(t, []string{"qwxz"}, result.NotFoundInCatalog)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 2, cardsCreated)
+	assert.Equal(t, []string{"dog", "qwxz", "bird"}, fetched, "existing and duplicate words should not hit the catalog")
+}
+
+func TestService_BatchCreateFromCatalog_LimitReachedMidBatch(t *testing.T) {
+	t.Parallel()
+	cfg := defaultCfg()
+	cfg.MaxEntriesPerUser = 5
+	svc, deps := newTestService(cfg)
+	ctx, _ := authCtx()
+
+	deps.entries.CountByUserFunc = func(_ context.Context, _ uuid.UUID) (int, error) {
+		return 3, nil
+	}
+	deps.refCatalog.GetOrFetchEntryFunc = func(_ context.Context, text string) (*domain.RefEntry, error) {
+		return makeRefEntry(text), nil
+	}
+
+	result, err := svc.BatchCreateFromCatalog(ctx, BatchCreateFromCatalogInput{
+		Texts: []string{"one", "two", "three", "four"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 2)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, WordError{Text: "three", Reason: "entry limit reached"}, result.Errors[0])
+	assert.Equal(t, "four", result.Errors[1].Text)
+}
+
+func TestService_BatchCreateFromCatalog_WordFailureIsolated(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.refCatalog.GetOrFetchEntryFunc = func(_ context.Context, text string) (*domain.RefEntry, error) {
+		if text == "flaky" {
+			return nil, errors.New("API timeout")
+		}
+		return makeRefEntry(text), nil
+	}
+	deps.entries.CreateFunc = func(_ context.Context, entry *domain.Entry) (*domain.Entry, error) {
+		if entry.TextNormalized == "raced" {
+			return nil, domain.ErrAlreadyExists
+		}
+		return entry, nil
+	}
+
+	result, err := svc.BatchCreateFromCatalog(ctx, BatchCreateFromCatalogInput{
+		Texts: []string{"flaky", "raced", "fine"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, "fine", result.Created[0].TextNormalized)
+	assert.Equal(t, []string{"raced"}, result.SkippedExisting)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "flaky", result.Errors[0].Text)
+	assert.Contains(t, result.Errors[0].Reason, "API timeout")
+}
+
+func TestService_BatchCreateFromCatalog_Validation(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	_, err := svc.BatchCreateFromCatalog(ctx, BatchCreateFromCatalogInput{})
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = svc.BatchCreateFromCatalog(ctx, BatchCreateFromCatalogInput{Texts: make([]string, maxBatchCreateFromCatalog+1)})
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = svc.BatchCreateFromCatalog(context.Background(), BatchCreateFromCatalogInput{Texts: []string{"hello"}})
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+// ===========================================================================
+// 25. SetFavorite Tests
+// ===========================================================================
+
+func TestService_SetFavorite_Star(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, userID := authCtx()
+
+	entryID := uuid.New()
+	deps.entries.GetByIDFunc = func(_ context.Context, _, _ uuid.UUID) (*domain.Entry, error) {
+		return &domain.Entry{ID: entryID, UserID: userID}, nil
+	}
+	deps.entries.SetFavoriteFunc = func(_ context.Context, uid, eid uuid.UUID, favorite bool) (*domain.Entry, error) {
+		assert.Equal(t, userID, uid)
+		assert.Equal(t, entryID, eid)
+		return &domain.Entry{ID: entryID, UserID: userID, Favorite: favorite}, nil
+	}
+
+	var auditChanges map[string]any
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		assert.Equal(t, domain.AuditActionUpdate, rec.Action)
+		assert.Equal(t, &entryID, rec.EntityID)
+		auditChanges = rec.Changes
+		return rec, nil
+	}
+
+	result, err := svc.SetFavorite(ctx, entryID, true)
+	require.NoError(t, err)
+	assert.True(t, result.Favorite)
+	assert.Equal(t, map[string]any{"old": false, "new": true}, auditChanges["favorite"])
+}
+
+func TestService_SetFavorite_Unchanged(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	entryID := uuid.New()
+	deps.entries.GetByIDFunc = func(_ context.Context, _, _ uuid.UUID) (*domain.Entry, error) {
+		return &domain.Entry{ID: entryID, Favorite: true}, nil
+	}
+	deps.entries.SetFavoriteFunc = func(context.Context, uuid.UUID, uuid.UUID, bool) (*domain.Entry, error) {
+		t.Error("SetFavorite must not be called when the flag is unchanged")
+		return nil, nil
+	}
+	deps.audit.CreateFunc = func(_ context.Context, rec domain.AuditRecord) (domain.AuditRecord, error) {
+		t.Error("unchanged flag must not be audited")
+		return rec, nil
+	}
+
+	result, err := svc.SetFavorite(ctx, entryID, true)
+	require.NoError(t, err)
+	assert.True(t, result.Favorite)
+}
+
+func TestService_SetFavorite_NotOwned(t *testing.T) {
+	t.Parallel()
+	svc, deps := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	deps.entries.GetByIDFunc = func(_ context.Context, _, _ uuid.UUID) (*domain.Entry, error) {
+		return nil, domain.ErrNotFound
+	}
+
+	_, err := svc.SetFavorite(ctx, uuid.New(), true)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestService_SetFavorite_Validation(t *testing.T) {
+	t.Parallel()
+	svc, _ := newTestService(defaultCfg())
+	ctx, _ := authCtx()
+
+	_, err := svc.SetFavorite(ctx, uuid.Nil, true)
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	_, err = svc.SetFavorite(context.Background(), uuid.New(), true)
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
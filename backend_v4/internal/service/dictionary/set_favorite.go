@@ -0,0 +1,66 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ---------------------------------------------------------------------------
+// 25. SetFavorite
+// ---------------------------------------------------------------------------
+
+// SetFavorite stars or unstars one of the user's entries. Setting the flag to
+// its current value is a no-op and is not audited.
+func (s *Service) SetFavorite(ctx context.Context, entryID uuid.UUID, favorite bool) (*domain.Entry, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if entryID == uuid.Nil {
+		return nil, domain.NewValidationError("entry_id", "required")
+	}
+
+	// Ownership check: GetByID is scoped to the user.
+	entry, err := s.entries.GetByID(ctx, userID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Favorite == favorite {
+		return entry, nil
+	}
+
+	var updated *domain.Entry
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		var updateErr error
+		updated, updateErr = s.entries.SetFavorite(txCtx, userID, entryID, favorite)
+		if updateErr != nil {
+			return fmt.Errorf("set favorite: %w", updateErr)
+		}
+
+		_, auditErr := s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeEntry,
+			EntityID:   &entryID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"favorite": map[string]any{"old": entry.Favorite, "new": favorite},
+			},
+		})
+		if auditErr != nil {
+			return fmt.Errorf("audit update: %w", auditErr)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,200 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// Source slugs of user-authored content. Rows with any other slug were
+// copied from the reference catalog and are read-only.
+const (
+	userSourceSlug   = "user"
+	importSourceSlug = "import"
+)
+
+// isUserSourced reports whether content with the given source slug was
+// written by the user and may be edited.
+func isUserSourced(sourceSlug string) bool {
+	return sourceSlug == userSourceSlug || sourceSlug == importSourceSlug
+}
+
+// ---------------------------------------------------------------------------
+// 16. UpdateSense
+// ---------------------------------------------------------------------------
+
+// UpdateSense edits a user-sourced sense. Ownership is checked through the
+// sense's entry; catalog-derived senses are rejected.
+func (s *Service) UpdateSense(ctx context.Context, input UpdateSenseInput) (*domain.Sense, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *domain.Sense
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		old, err := s.senses.GetByIDForUser(txCtx, userID, input.SenseID)
+		if err != nil {
+			return err
+		}
+		if !isUserSourced(old.SourceSlug) {
+			return domain.NewValidationError("sense_id", "catalog senses cannot be edited")
+		}
+
+		// Nil input fields keep their stored values; the update writes all columns.
+		definition, pos, cefr := old.Definition, old.PartOfSpeech, old.CEFRLevel
+		if input.Definition != nil {
+			definition = input.Definition
+		}
+		if input.PartOfSpeech != nil {
+			pos = input.PartOfSpeech
+		}
+		if input.CEFRLevel != nil {
+			cefr = input.CEFRLevel
+		}
+
+		updated, err = s.senses.Update(txCtx, input.SenseID, definition, pos, cefr)
+		if err != nil {
+			return fmt.Errorf("update sense: %w", err)
+		}
+
+		_, err = s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeSense,
+			EntityID:   &input.SenseID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"definition":     map[string]any{"old": old.Definition, "new": updated.Definition},
+				"part_of_speech": map[string]any{"old": old.PartOfSpeech, "new": updated.PartOfSpeech},
+				"cefr_level":     map[string]any{"old": old.CEFRLevel, "new": updated.CEFRLevel},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("audit update sense: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return updated, nil
+}
+
+// ---------------------------------------------------------------------------
+// 17. UpdateTranslation
+// ---------------------------------------------------------------------------
+
+// UpdateTranslation edits a user-sourced translation. Ownership is checked
+// through translation→sense→entry; catalog-derived translations are rejected.
+func (s *Service) UpdateTranslation(ctx context.Context, input UpdateTranslationInput) (*domain.Translation, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *domain.Translation
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		old, err := s.translations.GetByIDForUser(txCtx, userID, input.TranslationID)
+		if err != nil {
+			return err
+		}
+		if !isUserSourced(old.SourceSlug) {
+			return domain.NewValidationError("translation_id", "catalog translations cannot be edited")
+		}
+
+		updated, err = s.translations.Update(txCtx, input.TranslationID, input.Text)
+		if err != nil {
+			return fmt.Errorf("update translation: %w", err)
+		}
+
+		_, err = s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeSense,
+			EntityID:   &old.SenseID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"translation_id": input.TranslationID,
+				"text":           map[string]any{"old": old.Text, "new": updated.Text},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("audit update translation: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return updated, nil
+}
+
+// ---------------------------------------------------------------------------
+// 18. UpdateExample
+// ---------------------------------------------------------------------------
+
+// UpdateExample edits a user-sourced example. Ownership is checked through
+// example→sense→entry; catalog-derived examples are rejected.
+func (s *Service) UpdateExample(ctx context.Context, input UpdateExampleInput) (*domain.Example, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var updated *domain.Example
+	txErr := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		old, err := s.examples.GetByIDForUser(txCtx, userID, input.ExampleID)
+		if err != nil {
+			return err
+		}
+		if !isUserSourced(old.SourceSlug) {
+			return domain.NewValidationError("example_id", "catalog examples cannot be edited")
+		}
+
+		updated, err = s.examples.Update(txCtx, input.ExampleID, input.Sentence, input.Translation)
+		if err != nil {
+			return fmt.Errorf("update example: %w", err)
+		}
+
+		_, err = s.audit.Create(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeExample,
+			EntityID:   &input.ExampleID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"sentence":    map[string]any{"old": old.Sentence, "new": updated.Sentence},
+				"translation": map[string]any{"old": old.Translation, "new": updated.Translation},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("audit update example: %w", err)
+		}
+
+		return nil
+	})
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return updated, nil
+}
@@ -3,34 +3,45 @@ package enrichment
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
 type queueRepo interface {
 	Enqueue(ctx context.Context, refEntryID uuid.UUID, priority int) error
-	ClaimBatch(ctx context.Context, limit int) ([]domain.EnrichmentQueueItem, error)
+	ClaimBatch(ctx context.Context, limit int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error)
 	MarkDone(ctx context.Context, refEntryID uuid.UUID) error
-	MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error
+	MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string, maxAttempts int, baseDelay time.Duration) error
 	GetStats(ctx context.Context) (domain.EnrichmentQueueStats, error)
 	List(ctx context.Context, status string, limit, offset int) ([]domain.EnrichmentQueueItem, error)
 	RetryAllFailed(ctx context.Context) (int, error)
 	ResetProcessing(ctx context.Context) (int, error)
+	Release(ctx context.Context, refEntryIDs []uuid.UUID) (int, error)
+	ReclaimStale(ctx context.Context, olderThan time.Duration) (int, error)
+	EnqueueMissing(ctx context.Context, limit int) (int, error)
 }
 
+// maxEnqueueMissing caps how many entries one EnqueueMissing call may queue.
+const maxEnqueueMissing = 10000
+
 // Service wraps the enrichment queue repository with business logic.
 type Service struct {
 	log   *slog.Logger
 	queue queueRepo
+	cfg   config.EnrichmentConfig
 }
 
 // NewService creates a new enrichment service.
-func NewService(log *slog.Logger, queue queueRepo) *Service {
+func NewService(log *slog.Logger, queue queueRepo, cfg config.EnrichmentConfig) *Service {
 	return &Service{
 		log:   log.With("service", "enrichment"),
 		queue: queue,
+		cfg:   cfg,
 	}
 }
 
@@ -39,16 +50,33 @@ func (s *Service) Enqueue(ctx context.Context, refEntryID uuid.UUID) error {
 	return s.queue.Enqueue(ctx, refEntryID, 0)
 }
 
-// ClaimBatch claims up to limit pending items for processing.
-func (s *Service) ClaimBatch(ctx context.Context, limit int) ([]domain.EnrichmentQueueItem, error) {
+// EnqueueWithPriority adds a ref entry to the queue with an explicit priority.
+// Higher values are claimed first in priority order; re-queuing never lowers
+// an existing priority.
+func (s *Service) EnqueueWithPriority(ctx context.Context, refEntryID uuid.UUID, priority int) error {
+	if priority < 0 {
+		return domain.NewValidationError("priority", "must not be negative")
+	}
+	return s.queue.Enqueue(ctx, refEntryID, priority)
+}
+
+// ClaimBatch claims up to limit pending items for processing. An empty order
+// defaults to priority order.
+func (s *Service) ClaimBatch(ctx context.Context, limit int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error) {
 	if limit <= 0 {
 		limit = 50
 	}
-	items, err := s.queue.ClaimBatch(ctx, limit)
+	if order == "" {
+		order = domain.EnrichmentClaimOrderPriority
+	}
+	if !order.IsValid() {
+		return nil, domain.NewValidationError("order", "must be priority or fifo")
+	}
+	items, err := s.queue.ClaimBatch(ctx, limit, order)
 	if err != nil {
 		return nil, err
 	}
-	s.log.InfoContext(ctx, "claimed batch", slog.Int("count", len(items)))
+	s.log.InfoContext(ctx, "claimed batch", slog.Int("count", len(items)), slog.String("order", string(order)))
 	return items, nil
 }
 
@@ -57,9 +85,12 @@ func (s *Service) MarkDone(ctx context.Context, refEntryID uuid.UUID) error {
 	return s.queue.MarkDone(ctx, refEntryID)
 }
 
-// MarkFailed marks an item as failed with error message.
+// MarkFailed records a failed attempt. The item is retried with exponential
+// backoff starting at RetryBaseDelay until MaxAttempts is reached, after
+// which it stays failed until RetryAllFailed.
 func (s *Service) MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error {
-	return s.queue.MarkFailed(ctx, refEntryID, errMsg)
+	maxAttempts := max(s.cfg.MaxAttempts, 1)
+	return s.queue.MarkFailed(ctx, refEntryID, errMsg, maxAttempts, s.cfg.RetryBaseDelay)
 }
 
 // GetStats returns aggregate counts by status.
@@ -94,3 +125,47 @@ func (s *Service) ResetProcessing(ctx context.Context) (int, error) {
 	s.log.InfoContext(ctx, "reset processing items", slog.Int("count", n))
 	return n, nil
 }
+
+// ReclaimStale moves processing items claimed more than olderThan ago back
+// to pending. Claimed items normally stay in processing until llm-import
+// handles them, so olderThan should comfortably exceed that turnaround.
+func (s *Service) ReclaimStale(ctx context.Context, olderThan time.Duration) (int, error) {
+	if olderThan <= 0 {
+		return 0, domain.NewValidationError("older_than", "must be positive")
+	}
+	n, err := s.queue.ReclaimStale(ctx, olderThan)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "reclaimed stale items", slog.Int("count", n), slog.Duration("older_than", olderThan))
+	return n, nil
+}
+
+// Release returns claimed items that were not processed back to pending, so
+// a run that stops early does not leave them stuck in processing.
+func (s *Service) Release(ctx context.Context, refEntryIDs []uuid.UUID) (int, error) {
+	if len(refEntryIDs) == 0 {
+		return 0, nil
+	}
+	n, err := s.queue.Release(ctx, refEntryIDs)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "released items", slog.Int("count", n))
+	return n, nil
+}
+
+// EnqueueMissing queues up to limit ref entries that have not been enriched
+// by the LLM and lack examples or translations, so queue runs target gaps in
+// the catalog. Entries already in the queue are left alone.
+func (s *Service) EnqueueMissing(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 || limit > maxEnqueueMissing {
+		return 0, domain.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", maxEnqueueMissing))
+	}
+	n, err := s.queue.EnqueueMissing(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	s.log.InfoContext(ctx, "enqueued under-enriched entries", slog.Int("count", n), slog.Int("limit", limit))
+	return n, nil
+}
@@ -2,36 +2,42 @@ package enrichment
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"log/slog"
 
 	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/config"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
 type mockQueueRepo struct {
 	enqueueFn         func(ctx context.Context, refEntryID uuid.UUID, priority int) error
-	claimBatchFn      func(ctx context.Context, limit int) ([]domain.EnrichmentQueueItem, error)
+	claimBatchFn      func(ctx context.Context, limit int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error)
 	markDoneFn        func(ctx context.Context, refEntryID uuid.UUID) error
-	markFailedFn      func(ctx context.Context, refEntryID uuid.UUID, errMsg string) error
+	markFailedFn      func(ctx context.Context, refEntryID uuid.UUID, errMsg string, maxAttempts int, baseDelay time.Duration) error
 	getStatsFn        func(ctx context.Context) (domain.EnrichmentQueueStats, error)
 	listFn            func(ctx context.Context, status string, limit, offset int) ([]domain.EnrichmentQueueItem, error)
 	retryAllFailedFn  func(ctx context.Context) (int, error)
 	resetProcessingFn func(ctx context.Context) (int, error)
+	releaseFn         func(ctx context.Context, refEntryIDs []uuid.UUID) (int, error)
+	reclaimStaleFn    func(ctx context.Context, olderThan time.Duration) (int, error)
+	enqueueMissingFn  func(ctx context.Context, limit int) (int, error)
 }
 
 func (m *mockQueueRepo) Enqueue(ctx context.Context, refEntryID uuid.UUID, priority int) error {
 	return m.enqueueFn(ctx, refEntryID, priority)
 }
-func (m *mockQueueRepo) ClaimBatch(ctx context.Context, limit int) ([]domain.EnrichmentQueueItem, error) {
-	return m.claimBatchFn(ctx, limit)
+func (m *mockQueueRepo) ClaimBatch(ctx context.Context, limit int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error) {
+	return m.claimBatchFn(ctx, limit, order)
 }
 func (m *mockQueueRepo) MarkDone(ctx context.Context, refEntryID uuid.UUID) error {
 	return m.markDoneFn(ctx, refEntryID)
 }
-func (m *mockQueueRepo) MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string) error {
-	return m.markFailedFn(ctx, refEntryID, errMsg)
+func (m *mockQueueRepo) MarkFailed(ctx context.Context, refEntryID uuid.UUID, errMsg string, maxAttempts int, baseDelay time.Duration) error {
+	return m.markFailedFn(ctx, refEntryID, errMsg, maxAttempts, baseDelay)
 }
 func (m *mockQueueRepo) GetStats(ctx context.Context) (domain.EnrichmentQueueStats, error) {
 	return m.getStatsFn(ctx)
@@ -45,6 +51,17 @@ func (m *mockQueueRepo) RetryAllFailed(ctx context.Context) (int, error) {
 func (m *mockQueueRepo) ResetProcessing(ctx context.Context) (int, error) {
 	return m.resetProcessingFn(ctx)
 }
+func (m *mockQueueRepo) Release(ctx context.Context, refEntryIDs []uuid.UUID) (int, error) {
+	return m.releaseFn(ctx, refEntryIDs)
+}
+func (m *mockQueueRepo) ReclaimStale(ctx context.Context, olderThan time.Duration) (int, error) {
+	return m.reclaimStaleFn(ctx, olderThan)
+}
+func (m *mockQueueRepo) EnqueueMissing(ctx context.Context, limit int) (int, error) {
+	return m.enqueueMissingFn(ctx, limit)
+}
+
+var testConfig = config.EnrichmentConfig{MaxAttempts: 3, RetryBaseDelay: time.Minute}
 
 func TestService_Enqueue(t *testing.T) {
 	t.Parallel()
@@ -61,7 +78,7 @@ func TestService_Enqueue(t *testing.T) {
 		},
 	}
 
-	svc := NewService(slog.Default(), repo)
+	svc := NewService(slog.Default(), repo, testConfig)
 	err := svc.Enqueue(context.Background(), refID)
 	if err != nil {
 		t.Fatalf("Enqueue: %v", err)
@@ -79,19 +96,110 @@ func TestService_ClaimBatch_DefaultLimit(t *testing.T) {
 
 	var calledLimit int
 	repo := &mockQueueRepo{
-		claimBatchFn: func(_ context.Context, limit int) ([]domain.EnrichmentQueueItem, error) {
+		claimBatchFn: func(_ context.Context, limit int, _ domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error) {
 			calledLimit = limit
 			return nil, nil
 		},
 	}
 
-	svc := NewService(slog.Default(), repo)
-	_, _ = svc.ClaimBatch(context.Background(), 0)
+	svc := NewService(slog.Default(), repo, testConfig)
+	_, _ = svc.ClaimBatch(context.Background(), 0, "")
 	if calledLimit != 50 {
 		t.Errorf("ClaimBatch default limit = %d, want 50", calledLimit)
 	}
 }
 
+func TestService_ClaimBatch_Order(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		order domain.EnrichmentClaimOrder
+		want  domain.EnrichmentClaimOrder
+	}{
+		{"default is priority", "", domain.EnrichmentClaimOrderPriority},
+		{"priority", domain.EnrichmentClaimOrderPriority, domain.EnrichmentClaimOrderPriority},
+		{"fifo", domain.EnrichmentClaimOrderFIFO, domain.EnrichmentClaimOrderFIFO},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calledOrder domain.EnrichmentClaimOrder
+			repo := &mockQueueRepo{
+				claimBatchFn: func(_ context.Context, _ int, order domain.EnrichmentClaimOrder) ([]domain.EnrichmentQueueItem, error) {
+					calledOrder = order
+					return nil, nil
+				},
+			}
+
+			svc := NewService(slog.Default(), repo, testConfig)
+			if _, err := svc.ClaimBatch(context.Background(), 10, tt.order); err != nil {
+				t.Fatalf("ClaimBatch: %v", err)
+			}
+			if calledOrder != tt.want {
+				t.Errorf("ClaimBatch order = %q, want %q", calledOrder, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ClaimBatch_InvalidOrder(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(slog.Default(), &mockQueueRepo{}, testConfig)
+	_, err := svc.ClaimBatch(context.Background(), 10, "random")
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("ClaimBatch error = %v, want ErrValidation", err)
+	}
+}
+
+func TestService_EnqueueWithPriority(t *testing.T) {
+	t.Parallel()
+
+	var calledPriority int
+	repo := &mockQueueRepo{
+		enqueueFn: func(_ context.Context, _ uuid.UUID, p int) error {
+			calledPriority = p
+			return nil
+		},
+	}
+
+	svc := NewService(slog.Default(), repo, testConfig)
+	if err := svc.EnqueueWithPriority(context.Background(), uuid.New(), 7); err != nil {
+		t.Fatalf("EnqueueWithPriority: %v", err)
+	}
+	if calledPriority != 7 {
+		t.Errorf("Enqueue priority = %d, want 7", calledPriority)
+	}
+
+	if err := svc.EnqueueWithPriority(context.Background(), uuid.New(), -1); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("negative priority error = %v, want ErrValidation", err)
+	}
+}
+
+func TestService_MarkFailed_PassesRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	var gotMax int
+	var gotDelay time.Duration
+	repo := &mockQueueRepo{
+		markFailedFn: func(_ context.Context, _ uuid.UUID, _ string, maxAttempts int, baseDelay time.Duration) error {
+			gotMax, gotDelay = maxAttempts, baseDelay
+			return nil
+		},
+	}
+
+	svc := NewService(slog.Default(), repo, testConfig)
+	if err := svc.MarkFailed(context.Background(), uuid.New(), "llm timeout"); err != nil {
+		t.Fatalf("MarkFailed: %v", err)
+	}
+	if gotMax != 3 || gotDelay != time.Minute {
+		t.Errorf("MarkFailed policy = (%d, %s), want (3, 1m0s)", gotMax, gotDelay)
+	}
+}
+
 func TestService_GetStats(t *testing.T) {
 	t.Parallel()
 
@@ -102,7 +210,7 @@ func TestService_GetStats(t *testing.T) {
 		},
 	}
 
-	svc := NewService(slog.Default(), repo)
+	svc := NewService(slog.Default(), repo, testConfig)
 	stats, err := svc.GetStats(context.Background())
 	if err != nil {
 		t.Fatalf("GetStats: %v", err)
@@ -111,3 +219,98 @@ func TestService_GetStats(t *testing.T) {
 		t.Errorf("stats = %+v, want %+v", stats, expected)
 	}
 }
+
+func TestService_Release(t *testing.T) {
+	t.Parallel()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	var got []uuid.UUID
+	repo := &mockQueueRepo{
+		releaseFn: func(_ context.Context, refEntryIDs []uuid.UUID) (int, error) {
+			got = refEntryIDs
+			return len(refEntryIDs), nil
+		},
+	}
+
+	svc := NewService(slog.Default(), repo, testConfig)
+	n, err := svc.Release(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if n != 2 || len(got) != 2 || got[0] != ids[0] || got[1] != ids[1] {
+		t.Errorf("Release = %d with ids %v, want 2 with %v", n, got, ids)
+	}
+}
+
+func TestService_Release_EmptySkipsRepo(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(slog.Default(), &mockQueueRepo{}, testConfig)
+	n, err := svc.Release(context.Background(), nil)
+	if err != nil || n != 0 {
+		t.Errorf("Release(nil) = %d, %v, want 0, nil", n, err)
+	}
+}
+
+func TestService_ReclaimStale(t *testing.T) {
+	t.Parallel()
+
+	var got time.Duration
+	repo := &mockQueueRepo{
+		reclaimStaleFn: func(_ context.Context, olderThan time.Duration) (int, error) {
+			got = olderThan
+			return 4, nil
+		},
+	}
+
+	svc := NewService(slog.Default(), repo, testConfig)
+	n, err := svc.ReclaimStale(context.Background(), 2*time.Hour)
+	if err != nil {
+		t.Fatalf("ReclaimStale: %v", err)
+	}
+	if n != 4 || got != 2*time.Hour {
+		t.Errorf("ReclaimStale = %d with olderThan %s, want 4 with 2h0m0s", n, got)
+	}
+}
+
+func TestService_ReclaimStale_NonPositive(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(slog.Default(), &mockQueueRepo{}, testConfig)
+	_, err := svc.ReclaimStale(context.Background(), 0)
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("err = %v, want ErrValidation", err)
+	}
+}
+
+func TestService_EnqueueMissing(t *testing.T) {
+	t.Parallel()
+
+	var got int
+	repo := &mockQueueRepo{
+		enqueueMissingFn: func(_ context.Context, limit int) (int, error) {
+			got = limit
+			return 7, nil
+		},
+	}
+
+	svc := NewService(slog.Default(), repo, testConfig)
+	n, err := svc.EnqueueMissing(context.Background(), 500)
+	if err != nil {
+		t.Fatalf("EnqueueMissing: %v", err)
+	}
+	if n != 7 || got != 500 {
+		t.Errorf("EnqueueMissing = %d with limit %d, want 7 with 500", n, got)
+	}
+}
+
+func TestService_EnqueueMissing_LimitOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(slog.Default(), &mockQueueRepo{}, testConfig)
+	for _, limit := range []int{0, -1, maxEnqueueMissing + 1} {
+		if _, err := svc.EnqueueMissing(context.Background(), limit); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("limit %d: err = %v, want ErrValidation", limit, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package refcatalog
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -18,6 +19,11 @@ type refEntryRepo interface {
 	GetAllDataSources(ctx context.Context) ([]domain.RefDataSource, error)
 	GetDataSourceBySlug(ctx context.Context, slug string) (*domain.RefDataSource, error)
 	GetCoverageByEntryID(ctx context.Context, entryID uuid.UUID) ([]domain.RefEntrySourceCoverage, error)
+	PickFromPool(ctx context.Context, filter domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error)
+}
+
+type settingsRepo interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error)
 }
 
 type txManager interface {
@@ -39,6 +45,8 @@ type Service struct {
 	tx            txManager
 	dictProvider  dictionaryProvider
 	transProvider translationProvider
+	settings      settingsRepo
+	now           func() time.Time
 }
 
 // NewService creates a new RefCatalog service.
@@ -55,9 +63,16 @@ func NewService(
 		tx:            tx,
 		dictProvider:  dictProvider,
 		transProvider: transProvider,
+		now:           time.Now,
 	}
 }
 
+// SetSettings injects the optional user settings repo used to resolve the
+// user's local day (e.g. for GetWordOfTheDay). Without it, days follow UTC.
+func (s *Service) SetSettings(settings settingsRepo) {
+	s.settings = settings
+}
+
 // clampLimit ensures the limit is within [1, 50], defaulting 0 to 20.
 func clampLimit(limit int) int {
 	if limit <= 0 {
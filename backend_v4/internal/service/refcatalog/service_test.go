@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/internal/provider"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,6 +29,7 @@ type mockRefEntryRepo struct {
 	GetAllDataSourcesFunc   func(ctx context.Context) ([]domain.RefDataSource, error)
 	GetDataSourceBySlugFunc func(ctx context.Context, slug string) (*domain.RefDataSource, error)
 	GetCoverageByEntryIDFunc func(ctx context.Context, entryID uuid.UUID) ([]domain.RefEntrySourceCoverage, error)
+	PickFromPoolFunc         func(ctx context.Context, filter domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error)
 }
 
 func (m *mockRefEntryRepo) Search(ctx context.Context, query string, limit int) ([]domain.RefEntry, error) {
@@ -73,6 +76,24 @@ func (m *mockRefEntryRepo) GetCoverageByEntryID(ctx context.Context, entryID uui
 	return nil, nil
 }
 
+func (m *mockRefEntryRepo) PickFromPool(ctx context.Context, filter domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error) {
+	if m.PickFromPoolFunc != nil {
+		return m.PickFromPoolFunc(ctx, filter, seed)
+	}
+	return uuid.Nil, domain.ErrNotFound
+}
+
+type mockSettingsRepo struct {
+	GetByUserIDFunc func(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error)
+}
+
+func (m *mockSettingsRepo) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+	if m.GetByUserIDFunc != nil {
+		return m.GetByUserIDFunc(ctx, userID)
+	}
+	return nil, domain.ErrNotFound
+}
+
 type mockTxManager struct {
 	RunInTxFunc func(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -1164,3 +1185,138 @@ func TestService_GetRefEntryByID_NotFound(t *testing.T) {
 
 	require.ErrorIs(t, err, domain.ErrNotFound)
 }
+
+// ---------------------------------------------------------------------------
+// GetWordOfTheDay
+// ---------------------------------------------------------------------------
+
+// pickFrom mimics PickFromPool over an in-memory pool.
+func pickFrom(pool []uuid.UUID, seed int64) (uuid.UUID, error) {
+	if len(pool) == 0 {
+		return uuid.Nil, domain.ErrNotFound
+	}
+	return pool[seed%int64(len(pool))], nil
+}
+
+func newWordOfTheDayService(pool []uuid.UUID, now *time.Time) *Service {
+	repo := &mockRefEntryRepo{
+		PickFromPoolFunc: func(_ context.Context, _ domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error) {
+			return pickFrom(pool, seed)
+		},
+		GetFullTreeByIDFunc: func(_ context.Context, id uuid.UUID) (*domain.RefEntry, error) {
+			return &domain.RefEntry{ID: id}, nil
+		},
+	}
+	svc := newTestService(repo, nil, nil, nil)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+func makePool(n int) []uuid.UUID {
+	pool := make([]uuid.UUID, n)
+	for i := range pool {
+		pool[i] = uuid.New()
+	}
+	return pool
+}
+
+func TestService_GetWordOfTheDay_StableWithinDay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	svc := newWordOfTheDayService(makePool(50), &now)
+
+	first, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{})
+	require.NoError(t, err)
+
+	now = time.Date(2026, 3, 10, 23, 59, 0, 0, time.UTC)
+	second, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestService_GetWordOfTheDay_ChangesNextDay(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc := newWordOfTheDayService(makePool(50), &now)
+
+	today, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{})
+	require.NoError(t, err)
+
+	now = now.AddDate(0, 0, 1)
+	tomorrow, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, today.ID, tomorrow.ID)
+}
+
+func TestService_GetWordOfTheDay_UsesUserTimezone(t *testing.T) {
+	t.Parallel()
+
+	// 23:30 UTC on March 10 is already March 11 in Tokyo.
+	now := time.Date(2026, 3, 10, 23, 30, 0, 0, time.UTC)
+	pool := makePool(50)
+	svc := newWordOfTheDayService(pool, &now)
+	userID := uuid.New()
+	svc.SetSettings(&mockSettingsRepo{
+		GetByUserIDFunc: func(_ context.Context, _ uuid.UUID) (*domain.UserSettings, error) {
+			return &domain.UserSettings{UserID: userID, Timezone: "Asia/Tokyo"}, nil
+		},
+	})
+
+	got, err := svc.GetWordOfTheDay(ctxutil.WithUserID(context.Background(), userID), WordOfTheDayInput{})
+	require.NoError(t, err)
+
+	want, _ := pickFrom(pool, daySeed("2026-03-11"))
+	assert.Equal(t, want, got.ID)
+}
+
+func TestService_GetWordOfTheDay_ExcludeOwnedPassesUser(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	var gotFilter domain.RefEntryPoolFilter
+	repo := &mockRefEntryRepo{
+		PickFromPoolFunc: func(_ context.Context, filter domain.RefEntryPoolFilter, seed int64) (uuid.UUID, error) {
+			gotFilter = filter
+			return pickFrom(makePool(3), seed)
+		},
+		GetFullTreeByIDFunc: func(_ context.Context, id uuid.UUID) (*domain.RefEntry, error) {
+			return &domain.RefEntry{ID: id}, nil
+		},
+	}
+	svc := newTestService(repo, nil, nil, nil)
+
+	input := WordOfTheDayInput{CEFRLevels: []string{"B1", "B2"}, MaxFrequencyRank: 3000, ExcludeOwned: true}
+	_, err := svc.GetWordOfTheDay(ctxutil.WithUserID(context.Background(), userID), input)
+	require.NoError(t, err)
+
+	require.NotNil(t, gotFilter.ExcludeUserID)
+	assert.Equal(t, userID, *gotFilter.ExcludeUserID)
+	assert.Equal(t, []string{"B1", "B2"}, gotFilter.CEFRLevels)
+	assert.Equal(t, 3000, gotFilter.MaxFrequencyRank)
+}
+
+func TestService_GetWordOfTheDay_EmptyPool(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc := newWordOfTheDayService(nil, &now)
+
+	_, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{})
+
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestService_GetWordOfTheDay_InvalidCEFR(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc := newWordOfTheDayService(makePool(3), &now)
+
+	_, err := svc.GetWordOfTheDay(context.Background(), WordOfTheDayInput{CEFRLevels: []string{"Z9"}})
+
+	require.ErrorIs(t, err, domain.ErrValidation)
+}
@@ -0,0 +1,108 @@
+package refcatalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+	"github.com/heartmarshall/myenglish-backend/pkg/tzutil"
+)
+
+// WordOfTheDayInput configures the candidate pool for GetWordOfTheDay.
+type WordOfTheDayInput struct {
+	CEFRLevels       []string // empty = any level
+	MaxFrequencyRank int      // 0 = no rank limit
+	ExcludeOwned     bool     // skip words already in the caller's dictionary
+}
+
+// Validate checks the input for invalid CEFR levels and negative ranks.
+func (i WordOfTheDayInput) Validate() error {
+	var errs []domain.FieldError
+
+	for _, level := range i.CEFRLevels {
+		if !validCEFRLevels[level] {
+			errs = append(errs, domain.FieldError{Field: "cefr_levels", Message: "invalid CEFR level: " + level})
+			break
+		}
+	}
+	if i.MaxFrequencyRank < 0 {
+		errs = append(errs, domain.FieldError{Field: "max_frequency_rank", Message: "must be non-negative"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+var validCEFRLevels = map[string]bool{
+	"A1": true, "A2": true,
+	"B1": true, "B2": true,
+	"C1": true, "C2": true,
+}
+
+// GetWordOfTheDay deterministically picks a catalog entry for the caller's
+// current local day. The same pool yields the same word for the whole day and
+// a (usually) different one the next day. The day boundary follows the user's
+// timezone setting when available, UTC otherwise.
+func (s *Service) GetWordOfTheDay(ctx context.Context, input WordOfTheDayInput) (*domain.RefEntry, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	userID, hasUser := ctxutil.UserIDFromCtx(ctx)
+
+	loc, err := s.userLocation(ctx, userID, hasUser)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := domain.RefEntryPoolFilter{
+		CEFRLevels:       input.CEFRLevels,
+		MaxFrequencyRank: input.MaxFrequencyRank,
+	}
+	if input.ExcludeOwned && hasUser {
+		filter.ExcludeUserID = &userID
+	}
+
+	day := s.now().In(loc).Format(time.DateOnly)
+	id, err := s.refEntries.PickFromPool(ctx, filter, daySeed(day))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("word of the day: empty pool: %w", domain.ErrNotFound)
+		}
+		return nil, fmt.Errorf("pick word of the day: %w", err)
+	}
+
+	return s.refEntries.GetFullTreeByID(ctx, id)
+}
+
+// userLocation resolves the user's timezone, falling back to UTC for anonymous
+// callers, missing settings, or an unknown zone name.
+func (s *Service) userLocation(ctx context.Context, userID uuid.UUID, hasUser bool) (*time.Location, error) {
+	if !hasUser || s.settings == nil {
+		return time.UTC, nil
+	}
+
+	settings, err := s.settings.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return time.UTC, nil
+		}
+		return nil, fmt.Errorf("get user settings: %w", err)
+	}
+
+	return tzutil.Location(settings.Timezone, s.log), nil
+}
+
+// daySeed maps a calendar day to a stable non-negative seed for PickFromPool.
+func daySeed(day string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(day))
+	return int64(h.Sum64() >> 1)
+}
@@ -2,6 +2,7 @@ package study
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -35,12 +36,19 @@ func (s *Service) CreateCard(ctx context.Context, input CreateCardInput) (*domai
 		return nil, domain.NewValidationError("entry_id", "entry must have at least one sense to create a card")
 	}
 
+	// Starting memory state from the configured presets (zeros = FSRS default).
+	rank, err := s.entries.GetFrequencyRank(ctx, userID, input.EntryID)
+	if err != nil {
+		return nil, fmt.Errorf("get frequency rank: %w", err)
+	}
+	stability, difficulty := s.srsConfig.CardPresets.For(rank)
+
 	var card *domain.Card
 
 	// Transaction: create card + audit
 	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
 		var createErr error
-		card, createErr = s.cards.Create(txCtx, userID, input.EntryID)
+		card, createErr = s.cards.CreateWithParams(txCtx, userID, input.EntryID, stability, difficulty)
 		if createErr != nil {
 			return fmt.Errorf("create card: %w", createErr)
 		}
@@ -66,6 +74,8 @@ func (s *Service) CreateCard(ctx context.Context, input CreateCardInput) (*domai
 		return nil, err
 	}
 
+	s.metrics.cardsCreatedN(1)
+
 	s.log.InfoContext(ctx, "card created",
 		slog.String("user_id", userID.String()),
 		slog.String("card_id", card.ID.String()),
@@ -171,38 +181,59 @@ func (s *Service) BatchCreateCards(ctx context.Context, input BatchCreateCardsIn
 		return result, nil
 	}
 
-	// Create all cards in a single transaction
+	stability, difficulty, err := s.batchCardPresets(ctx, userID, toCreate)
+	if err != nil {
+		return result, err
+	}
+
+	// Insert all eligible cards with a single statement and record one
+	// summary audit entry for the whole batch.
+	var created int
 	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
-		for _, entryID := range toCreate {
-			createdCard, createErr := s.cards.Create(txCtx, userID, entryID)
-			if createErr != nil {
-				result.Errors = append(result.Errors, BatchCreateError{
-					EntryID: entryID,
-					Reason:  createErr.Error(),
-				})
-				continue
-			}
-			result.Created++
+		cards, createErr := s.cards.BatchCreate(txCtx, userID, toCreate, stability, difficulty)
+		if createErr != nil {
+			return fmt.Errorf("insert cards: %w", createErr)
+		}
 
-			auditErr := s.audit.Log(txCtx, domain.AuditRecord{
-				UserID:     userID,
-				EntityType: domain.EntityTypeCard,
-				EntityID:   &createdCard.ID,
-				Action:     domain.AuditActionCreate,
-				Changes: map[string]any{
-					"entry_id": map[string]any{"new": entryID},
-				},
-			})
-			if auditErr != nil {
-				return fmt.Errorf("audit log: %w", auditErr)
-			}
+		created = len(cards)
+		if created == 0 {
+			return nil
 		}
-		return nil
+
+		createdEntryIDs := make([]uuid.UUID, 0, len(cards))
+		for _, c := range cards {
+			createdEntryIDs = append(createdEntryIDs, c.EntryID)
+		}
+		return s.audit.Log(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeCard,
+			Action:     domain.AuditActionCreate,
+			Changes: map[string]any{
+				"entry_ids": map[string]any{"new": createdEntryIDs},
+				"count":     map[string]any{"new": created},
+			},
+		})
 	})
-	if err != nil {
+	switch {
+	case err == nil:
+		// Entries that got a card concurrently (after the existence check)
+		// are skipped by the insert and accounted for as existing.
+		result.Created = created
+		result.SkippedExisting += len(toCreate) - created
+	case ctx.Err() != nil:
 		return result, fmt.Errorf("batch create cards: %w", err)
+	default:
+		// The bulk insert rolled back as a whole. Retry entry by entry so
+		// one bad entry does not cost the rest of the batch.
+		s.log.WarnContext(ctx, "bulk card insert failed, retrying per entry",
+			slog.String("user_id", userID.String()),
+			slog.String("error", err.Error()),
+		)
+		s.createCardsPerEntry(ctx, userID, toCreate, stability, difficulty, &result)
 	}
 
+	s.metrics.cardsCreatedN(result.Created)
+
 	s.log.InfoContext(ctx, "batch card creation completed",
 		slog.String("user_id", userID.String()),
 		slog.Int("created", result.Created),
@@ -213,3 +244,58 @@ func (s *Service) BatchCreateCards(ctx context.Context, input BatchCreateCardsIn
 
 	return result, nil
 }
+
+// batchCardPresets returns the starting stability and difficulty for the
+// card of each entry, index-aligned with entryIDs, using the same presets as
+// CreateCard. Frequency ranks are only looked up when presets are enabled.
+func (s *Service) batchCardPresets(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) (stability, difficulty []float64, err error) {
+	stability = make([]float64, len(entryIDs))
+	difficulty = make([]float64, len(entryIDs))
+	if s.srsConfig.CardPresets.FrequentMaxRank <= 0 {
+		return stability, difficulty, nil
+	}
+
+	ranks, err := s.entries.GetFrequencyRanks(ctx, userID, entryIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get frequency ranks: %w", err)
+	}
+	for i, id := range entryIDs {
+		var rank *int
+		if r, ok := ranks[id]; ok {
+			rank = &r
+		}
+		stability[i], difficulty[i] = s.srsConfig.CardPresets.For(rank)
+	}
+	return stability, difficulty, nil
+}
+
+// createCardsPerEntry creates a card for each entry in its own transaction,
+// recording the outcome of every entry in result. stability and difficulty
+// are index-aligned with entryIDs.
+func (s *Service) createCardsPerEntry(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability, difficulty []float64, result *BatchCreateResult) {
+	for i, entryID := range entryIDs {
+		err := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+			card, createErr := s.cards.CreateWithParams(txCtx, userID, entryID, stability[i], difficulty[i])
+			if createErr != nil {
+				return createErr
+			}
+			return s.audit.Log(txCtx, domain.AuditRecord{
+				UserID:     userID,
+				EntityType: domain.EntityTypeCard,
+				EntityID:   &card.ID,
+				Action:     domain.AuditActionCreate,
+				Changes: map[string]any{
+					"entry_id": map[string]any{"new": entryID},
+				},
+			})
+		})
+		switch {
+		case err == nil:
+			result.Created++
+		case errors.Is(err, domain.ErrAlreadyExists):
+			result.SkippedExisting++
+		default:
+			result.Errors = append(result.Errors, BatchCreateError{EntryID: entryID, Reason: err.Error()})
+		}
+	}
+}
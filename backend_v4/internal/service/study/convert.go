@@ -2,6 +2,8 @@ package study
 
 import (
 	"context"
+	"encoding/base64"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -64,6 +66,62 @@ func snapshotFromCard(card *domain.Card) *domain.CardSnapshot {
 	}
 }
 
+// srsDiff returns an audit Changes map of the scheduling fields that differ
+// between before and after, each as {"old": ..., "new": ...}.
+func srsDiff(before, after *domain.CardSnapshot) map[string]any {
+	changes := make(map[string]any)
+	add := func(field string, old, new any) {
+		changes[field] = map[string]any{"old": old, "new": new}
+	}
+
+	if before.State != after.State {
+		add("state", before.State, after.State)
+	}
+	if before.Stability != after.Stability {
+		add("stability", before.Stability, after.Stability)
+	}
+	if before.Difficulty != after.Difficulty {
+		add("difficulty", before.Difficulty, after.Difficulty)
+	}
+	if before.ScheduledDays != after.ScheduledDays {
+		add("scheduled_days", before.ScheduledDays, after.ScheduledDays)
+	}
+	if !before.Due.Equal(after.Due) {
+		add("due", before.Due, after.Due)
+	}
+	return changes
+}
+
+// encodeHistoryCursor produces an opaque cursor pointing just past log.
+func encodeHistoryCursor(log *domain.ReviewLog) string {
+	raw := log.ReviewedAt.UTC().Format(time.RFC3339Nano) + "|" + log.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeHistoryCursor parses a cursor produced by encodeHistoryCursor.
+func decodeHistoryCursor(cursor string) (*domain.ReviewLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.NewValidationError("cursor", "invalid cursor encoding")
+	}
+
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, domain.NewValidationError("cursor", "invalid cursor format")
+	}
+
+	reviewedAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, domain.NewValidationError("cursor", "invalid cursor timestamp")
+	}
+	logID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.NewValidationError("cursor", "invalid cursor ID")
+	}
+
+	return &domain.ReviewLogCursor{ReviewedAt: reviewedAt, ID: logID}, nil
+}
+
 // computeElapsedDays calculates whole days elapsed since the last review.
 func computeElapsedDays(lastReview *time.Time, now time.Time) int {
 	if lastReview == nil {
@@ -174,14 +232,28 @@ func filterBatchEntries(
 	return toCreate, skippedExisting, skippedNoSenses, errors
 }
 
-// buildFSRSParams merges global SRS config with per-user settings into FSRS parameters.
+// buildFSRSParams merges global SRS config with per-user settings into FSRS
+// parameters. The user's learning and relearning steps, if set, replace the
+// global ones.
 func (s *Service) buildFSRSParams(settings *domain.UserSettings) fsrs.Parameters {
+	learningSteps := s.srsConfig.LearningSteps
+	if len(settings.LearningSteps) > 0 {
+		learningSteps = settings.LearningSteps
+	}
+	relearningSteps := s.srsConfig.RelearningSteps
+	if len(settings.RelearningSteps) > 0 {
+		relearningSteps = settings.RelearningSteps
+	}
+
 	return fsrs.Parameters{
-		W:                s.fsrsWeights,
-		DesiredRetention: settings.DesiredRetention,
-		MaxIntervalDays:  min(s.srsConfig.MaxIntervalDays, settings.MaxIntervalDays),
-		EnableFuzz:       s.srsConfig.EnableFuzz,
-		LearningSteps:    s.srsConfig.LearningSteps,
-		RelearningSteps:  s.srsConfig.RelearningSteps,
+		W:                  s.fsrsWeights,
+		DesiredRetention:   settings.DesiredRetention,
+		MaxIntervalDays:    min(s.srsConfig.MaxIntervalDays, settings.MaxIntervalDays),
+		EnableFuzz:         s.srsConfig.EnableFuzz,
+		LearningSteps:      learningSteps,
+		RelearningSteps:    relearningSteps,
+		DifficultyMin:      s.srsConfig.DifficultyMin,
+		DifficultyMax:      s.srsConfig.DifficultyMax,
+		HardIntervalFactor: settings.HardIntervalFactor,
 	}
 }
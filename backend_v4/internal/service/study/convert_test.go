@@ -2,6 +2,7 @@ package study
 
 import (
 	"context"
+	"slices"
 	"testing"
 	"time"
 
@@ -217,12 +218,15 @@ func TestBuildFSRSParams(t *testing.T) {
 			EnableFuzz:      true,
 			LearningSteps:   []time.Duration{1 * time.Minute, 10 * time.Minute},
 			RelearningSteps: []time.Duration{10 * time.Minute},
+			DifficultyMin:   2,
+			DifficultyMax:   9,
 		},
 	}
 
 	settings := &domain.UserSettings{
-		DesiredRetention: 0.9,
-		MaxIntervalDays:  180, // user's limit is lower than global
+		DesiredRetention:   0.9,
+		MaxIntervalDays:    180, // user's limit is lower than global
+		HardIntervalFactor: 1.3,
 	}
 
 	params := svc.buildFSRSParams(settings)
@@ -239,6 +243,30 @@ func TestBuildFSRSParams(t *testing.T) {
 	if len(params.LearningSteps) != 2 {
 		t.Errorf("LearningSteps: got %d, want 2", len(params.LearningSteps))
 	}
+	if params.DifficultyMin != 2 || params.DifficultyMax != 9 {
+		t.Errorf("Difficulty range: got [%v, %v], want [2, 9]", params.DifficultyMin, params.DifficultyMax)
+	}
+	if params.HardIntervalFactor != 1.3 {
+		t.Errorf("HardIntervalFactor: got %v, want 1.3", params.HardIntervalFactor)
+	}
+	if !slices.Equal(params.RelearningSteps, []time.Duration{10 * time.Minute}) {
+		t.Errorf("RelearningSteps: got %v, want global [10m]", params.RelearningSteps)
+	}
+
+	settings.RelearningSteps = []time.Duration{5 * time.Minute, 30 * time.Minute}
+	params = svc.buildFSRSParams(settings)
+	if !slices.Equal(params.RelearningSteps, settings.RelearningSteps) {
+		t.Errorf("RelearningSteps: got %v, want user override %v", params.RelearningSteps, settings.RelearningSteps)
+	}
+	if len(params.LearningSteps) != 2 {
+		t.Errorf("LearningSteps: got %d, want global 2", len(params.LearningSteps))
+	}
+
+	settings.LearningSteps = []time.Duration{2 * time.Minute}
+	params = svc.buildFSRSParams(settings)
+	if !slices.Equal(params.LearningSteps, settings.LearningSteps) {
+		t.Errorf("LearningSteps: got %v, want user override %v", params.LearningSteps, settings.LearningSteps)
+	}
 }
 
 func TestAggregateSessionResult(t *testing.T) {
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/tzutil"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -26,8 +27,8 @@ func (s *Service) GetDashboard(ctx context.Context) (domain.Dashboard, error) {
 		return domain.Dashboard{}, fmt.Errorf("load settings: %w", err)
 	}
 
-	tz := ParseTimezone(settings.Timezone)
-	dayStart := DayStart(now, tz)
+	tz := tzutil.Location(settings.Timezone, s.log)
+	dayStart := tzutil.DayStart(now, tz)
 
 	var (
 		dueCount      int
@@ -37,6 +38,7 @@ func (s *Service) GetDashboard(ctx context.Context) (domain.Dashboard, error) {
 		overdueCount  int
 		statusCounts  domain.CardStatusCounts
 		streakDays    []domain.DayReviewCount
+		activeDays    []time.Time
 		activeSession *domain.StudySession
 	)
 
@@ -77,6 +79,11 @@ func (s *Service) GetDashboard(ctx context.Context) (domain.Dashboard, error) {
 		streakDays, gErr = s.reviews.GetStreakDays(gctx, userID, dayStart, 365, settings.Timezone)
 		return gErr
 	})
+	g.Go(func() error {
+		var gErr error
+		activeDays, gErr = s.reviews.GetActiveDays(gctx, userID, dayStart.AddDate(0, 0, -longestStreakWindowDays), settings.Timezone)
+		return gErr
+	})
 	g.Go(func() error {
 		var gErr error
 		activeSession, gErr = s.sessions.GetActive(gctx, userID)
@@ -94,6 +101,9 @@ func (s *Service) GetDashboard(ctx context.Context) (domain.Dashboard, error) {
 	nowInTz := now.In(tz)
 	today := time.Date(nowInTz.Year(), nowInTz.Month(), nowInTz.Day(), 0, 0, 0, 0, tz)
 	streak := calculateStreak(streakDays, today)
+	// The active-day history is bounded by longestStreakWindowDays, so never
+	// report less than the current streak.
+	longestStreak := max(calculateLongestStreak(activeDays), streak)
 
 	dashboard := domain.Dashboard{
 		DueCount:      dueCount,
@@ -101,16 +111,22 @@ func (s *Service) GetDashboard(ctx context.Context) (domain.Dashboard, error) {
 		ReviewedToday: reviewedToday,
 		NewToday:      newToday,
 		Streak:        streak,
+		LongestStreak: longestStreak,
 		StatusCounts:  statusCounts,
 		OverdueCount:  overdueCount,
 		ActiveSession: activeSession,
 	}
+	if settings.DailyGoal > 0 {
+		dashboard.GoalProgress = min(reviewedToday, settings.DailyGoal)
+		dashboard.GoalReached = reviewedToday >= settings.DailyGoal
+	}
 
 	s.log.InfoContext(ctx, "dashboard loaded",
 		slog.String("user_id", userID.String()),
 		slog.Int("due_count", dueCount),
 		slog.Int("new_count", newCount),
 		slog.Int("streak", streak),
+		slog.Int("longest_streak", longestStreak),
 	)
 
 	return dashboard, nil
@@ -154,6 +170,52 @@ func (s *Service) GetCardHistory(ctx context.Context, input GetCardHistoryInput)
 	return logs, total, nil
 }
 
+// GetCardHistoryCursor returns the review history of a card using keyset
+// pagination on (reviewed_at, id), newest first. Unlike GetCardHistory, pages
+// stay stable when new reviews are inserted between requests.
+func (s *Service) GetCardHistoryCursor(ctx context.Context, input GetHistoryCursorInput) (*CardHistoryPage, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var after *domain.ReviewLogCursor
+	if input.Cursor != nil {
+		after, err = decodeHistoryCursor(*input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check ownership
+	_, err = s.cards.GetByID(ctx, userID, input.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("get card: %w", err)
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = 50
+	}
+
+	logs, hasMore, err := s.reviews.GetByCardIDCursor(ctx, input.CardID, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get review logs: %w", err)
+	}
+
+	page := &CardHistoryPage{Logs: logs, HasMore: hasMore}
+	if hasMore && len(logs) > 0 {
+		next := encodeHistoryCursor(logs[len(logs)-1])
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
+
 // GetCardStats returns aggregated statistics for a card.
 func (s *Service) GetCardStats(ctx context.Context, input GetCardHistoryInput) (domain.CardStats, error) {
 	userID, err := s.userID(ctx)
@@ -239,3 +301,86 @@ func calculateStreak(days []domain.DayReviewCount, today time.Time) int {
 	}
 	return streak
 }
+
+// longestStreakWindowDays bounds the review history scanned for the longest
+// streak to roughly the last ten years.
+const longestStreakWindowDays = 3650
+
+// calculateLongestStreak returns the longest run of consecutive days in days.
+// days must be sorted DESC by date and hold each date at most once.
+func calculateLongestStreak(days []time.Time) int {
+	longest, run := 0, 0
+	for i, d := range days {
+		next := d.AddDate(0, 0, 1)
+		if i > 0 && next.Year() == days[i-1].Year() && next.Month() == days[i-1].Month() && next.Day() == days[i-1].Day() {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// Stability histogram bounds in days. Cards at or above matureStabilityDays
+// count as mature.
+var stabilityBounds = []float64{1, 7, matureStabilityDays, 90, 365}
+
+const (
+	matureStabilityDays = 21
+	retentionWindowDays = 30
+)
+
+// GetUserStats returns learning statistics across all of the user's cards,
+// using one grouped query for reviews and one for card stability.
+func (s *Service) GetUserStats(ctx context.Context) (domain.UserStats, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return domain.UserStats{}, err
+	}
+
+	since := s.clock.Now().AddDate(0, 0, -retentionWindowDays)
+
+	agg, err := s.reviews.GetUserAggregation(ctx, userID, since)
+	if err != nil {
+		return domain.UserStats{}, fmt.Errorf("get review aggregation: %w", err)
+	}
+
+	buckets, err := s.cards.GetStabilityHistogram(ctx, userID, stabilityBounds)
+	if err != nil {
+		return domain.UserStats{}, fmt.Errorf("get stability histogram: %w", err)
+	}
+
+	stats := domain.UserStats{
+		TotalReviews: agg.TotalReviews,
+		GradeDistribution: domain.GradeCounts{
+			Again: agg.AgainCount,
+			Hard:  agg.HardCount,
+			Good:  agg.GoodCount,
+			Easy:  agg.EasyCount,
+		},
+		StabilityHistogram: buckets,
+	}
+
+	if agg.TotalReviews > 0 {
+		stats.AccuracyRate = float64(agg.GoodCount+agg.EasyCount) / float64(agg.TotalReviews) * 100
+	}
+	if agg.RecentMatureReviews > 0 {
+		stats.RetentionRate = float64(agg.RecentMatureRecalled) / float64(agg.RecentMatureReviews) * 100
+	}
+
+	var cards int
+	var stabilitySum float64
+	for _, b := range buckets {
+		cards += b.Count
+		stabilitySum += b.StabilitySum
+		if b.Min >= matureStabilityDays {
+			stats.MatureCards += b.Count
+		}
+	}
+	if cards > 0 {
+		stats.AverageStability = stabilitySum / float64(cards)
+	}
+
+	return stats, nil
+}
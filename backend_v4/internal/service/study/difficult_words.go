@@ -0,0 +1,41 @@
+package study
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+const (
+	// difficultWordsMinReviews keeps cards with too little history, e.g. a
+	// single AGAIN, from topping the difficult words report.
+	difficultWordsMinReviews = 3
+	defaultDifficultWords    = 20
+)
+
+// GetDifficultWords returns the user's hardest words: entries whose cards
+// have the highest share of AGAIN grades over their whole review history.
+// Cards with fewer than difficultWordsMinReviews reviews are not ranked.
+func (s *Service) GetDifficultWords(ctx context.Context, input GetDifficultWordsInput) ([]domain.DifficultWord, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit == 0 {
+		limit = defaultDifficultWords
+	}
+
+	words, err := s.reviews.GetDifficultWords(ctx, userID, difficultWordsMinReviews, limit)
+	if err != nil {
+		return nil, fmt.Errorf("get difficult words: %w", err)
+	}
+
+	return words, nil
+}
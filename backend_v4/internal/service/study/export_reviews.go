@@ -0,0 +1,80 @@
+package study
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// reviewExportPageSize is how many review logs ExportReviewLogsCSV loads per
+// query, so large histories are streamed instead of held in memory.
+const reviewExportPageSize = 500
+
+// reviewExportHeader lists the CSV columns written by ExportReviewLogsCSV.
+var reviewExportHeader = []string{"card_id", "word", "grade", "prev_state", "duration_ms", "reviewed_at"}
+
+// ExportReviewLogsCSV streams the user's review logs within the date range to
+// w as CSV, oldest first, one page at a time. prev_state is the card state
+// before the review; prev_state and duration_ms are empty when unknown.
+func (s *Service) ExportReviewLogsCSV(ctx context.Context, input ExportReviewLogsInput, w io.Writer) error {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reviewExportHeader); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	var after *domain.ReviewLogCursor
+	for {
+		logs, err := s.reviews.GetByPeriodPage(ctx, userID, input.From, input.To, after, reviewExportPageSize)
+		if err != nil {
+			return fmt.Errorf("get review logs: %w", err)
+		}
+
+		for _, rl := range logs {
+			if err := cw.Write(reviewExportRecord(rl)); err != nil {
+				return fmt.Errorf("write csv row: %w", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("flush csv: %w", err)
+		}
+
+		if len(logs) < reviewExportPageSize {
+			return nil
+		}
+		last := logs[len(logs)-1]
+		after = &domain.ReviewLogCursor{ReviewedAt: last.ReviewedAt, ID: last.ID}
+	}
+}
+
+func reviewExportRecord(rl domain.ReviewLogWithWord) []string {
+	var prevState, duration string
+	if rl.PrevState != nil {
+		prevState = string(rl.PrevState.State)
+	}
+	if rl.DurationMs != nil {
+		duration = strconv.Itoa(*rl.DurationMs)
+	}
+	return []string{
+		rl.CardID.String(),
+		rl.Word,
+		string(rl.Grade),
+		prevState,
+		duration,
+		rl.ReviewedAt.UTC().Format(time.RFC3339),
+	}
+}
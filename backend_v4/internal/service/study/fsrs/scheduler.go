@@ -2,6 +2,7 @@ package fsrs
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -29,6 +30,14 @@ type Parameters struct {
 	EnableFuzz       bool
 	LearningSteps    []time.Duration
 	RelearningSteps  []time.Duration
+	// DifficultyMin and DifficultyMax bound card difficulty after every
+	// review. Zero values fall back to the FSRS range [1, 10].
+	DifficultyMin float64
+	DifficultyMax float64
+	// HardIntervalFactor scales the interval of a Hard review on a REVIEW
+	// card. The result stays within [1, Good interval - 1]; learning steps
+	// are not affected. Zero means 1 (no change).
+	HardIntervalFactor float64
 }
 
 // DefaultParameters returns sensible defaults.
@@ -40,24 +49,59 @@ func DefaultParameters() Parameters {
 		EnableFuzz:       true,
 		LearningSteps:    []time.Duration{time.Minute, 10 * time.Minute},
 		RelearningSteps:  []time.Duration{10 * time.Minute},
+		DifficultyMin:    1,
+		DifficultyMax:    10,
 	}
 }
 
 // ReviewCard is the main entry point: given current card state, rating, and time,
-// return the updated card.
+// return the updated card. Stability and difficulty are clamped on the way in
+// (for non-NEW cards) and on the way out, so corrupt stored values cannot
+// produce zero or negative intervals.
 func ReviewCard(params Parameters, card Card, rating Rating, now time.Time) (Card, error) {
+	if card.State != domain.CardStateNew {
+		card = clampMemoryState(params, card)
+	}
+
+	var next Card
 	switch card.State {
 	case domain.CardStateNew:
-		return reviewNew(params, card, rating, now), nil
+		next = reviewNew(params, card, rating, now)
 	case domain.CardStateLearning:
-		return reviewLearning(params, card, rating, now, false), nil
+		next = reviewLearning(params, card, rating, now, false)
 	case domain.CardStateRelearning:
-		return reviewLearning(params, card, rating, now, true), nil
+		next = reviewLearning(params, card, rating, now, true)
 	case domain.CardStateReview:
-		return reviewReview(params, card, rating, now), nil
+		next = reviewReview(params, card, rating, now)
 	default:
 		return Card{}, fmt.Errorf("unknown card state: %q", card.State)
 	}
+
+	return clampMemoryState(params, next), nil
+}
+
+// clampMemoryState bounds difficulty to [DifficultyMin, DifficultyMax] and
+// stability to at least MinStability. NaN values are replaced by the middle
+// of the difficulty range and by MinStability respectively.
+func clampMemoryState(params Parameters, card Card) Card {
+	dMin, dMax := params.DifficultyMin, params.DifficultyMax
+	if dMin <= 0 {
+		dMin = 1
+	}
+	if dMax <= 0 {
+		dMax = 10
+	}
+
+	if math.IsNaN(card.Difficulty) {
+		card.Difficulty = (dMin + dMax) / 2
+	}
+	card.Difficulty = math.Max(dMin, math.Min(dMax, card.Difficulty))
+
+	if math.IsNaN(card.Stability) || card.Stability < MinStability {
+		card.Stability = MinStability
+	}
+
+	return card
 }
 
 // reviewNew handles a NEW card's first review.
@@ -68,13 +112,20 @@ func reviewNew(params Parameters, card Card, rating Rating, now time.Time) Card
 	s := InitialStability(params.W, rating)
 	d := InitialDifficulty(params.W, rating)
 
+	// A NEW card may carry a preset starting state (domain.CardPresets).
+	// The preset stability is a floor for a successful first answer, and the
+	// preset difficulty replaces the Good baseline, shifted by the grade.
+	if card.Stability > 0 && rating >= Good {
+		s = max(s, card.Stability)
+	}
+	if card.Difficulty > 0 {
+		d = clampDifficulty(card.Difficulty + d - InitialDifficulty(params.W, Good))
+	}
+
 	card.Stability = s
 	card.Difficulty = d
 
-	steps := params.LearningSteps
-	if len(steps) == 0 {
-		steps = []time.Duration{time.Minute}
-	}
+	steps := learningSteps(params)
 
 	switch rating {
 	case Again:
@@ -132,12 +183,9 @@ func reviewLearning(params Parameters, card Card, rating Rating, now time.Time,
 	card.Reps++
 	card.LastReview = &now
 
-	steps := params.LearningSteps
+	steps := learningSteps(params)
 	if isRelearning {
-		steps = params.RelearningSteps
-	}
-	if len(steps) == 0 {
-		steps = []time.Duration{time.Minute}
+		steps = relearningSteps(params)
 	}
 
 	// Snapshot pre-update stability for interval ordering (Easy vs Good comparison).
@@ -224,10 +272,7 @@ func reviewReview(params Parameters, card Card, rating Rating, now time.Time) Ca
 		newS := StabilityAfterForgettingCapped(params.W, card.Stability, preD, r)
 		card.Stability = newS
 
-		steps := params.RelearningSteps
-		if len(steps) == 0 {
-			steps = []time.Duration{10 * time.Minute}
-		}
+		steps := relearningSteps(params)
 
 		card.ElapsedDays = 0
 		card.ScheduledDays = 0
@@ -266,6 +311,13 @@ func reviewReview(params Parameters, card Card, rating Rating, now time.Time) Ca
 	goodIvl = clampInterval(goodIvl, params.MaxIntervalDays)
 	easyIvl = clampInterval(easyIvl, params.MaxIntervalDays)
 
+	// Per-user Hard adjustment, kept strictly below Good so the Good and
+	// Easy intervals are not pushed out by the ordering rules.
+	if f := params.HardIntervalFactor; f > 0 && f != 1 {
+		hardIvl = int(math.Round(float64(hardIvl) * f))
+		hardIvl = max(1, min(hardIvl, goodIvl-1))
+	}
+
 	// Apply fuzz if enabled
 	if params.EnableFuzz {
 		maxIvl := float64(params.MaxIntervalDays)
@@ -317,6 +369,24 @@ func reviewReview(params Parameters, card Card, rating Rating, now time.Time) Ca
 	return card
 }
 
+// learningSteps returns the steps for NEW and LEARNING cards, defaulting to a
+// single one-minute step.
+func learningSteps(params Parameters) []time.Duration {
+	if len(params.LearningSteps) == 0 {
+		return []time.Duration{time.Minute}
+	}
+	return params.LearningSteps
+}
+
+// relearningSteps returns the steps for lapsed (RELEARNING) cards, defaulting
+// to a single ten-minute step. Learning steps are never used for lapses.
+func relearningSteps(params Parameters) []time.Duration {
+	if len(params.RelearningSteps) == 0 {
+		return []time.Duration{10 * time.Minute}
+	}
+	return params.RelearningSteps
+}
+
 // graduateToReview transitions a card from Learning/New to Review.
 func graduateToReview(params Parameters, card Card, stability, difficulty float64, now time.Time) Card {
 	card.State = domain.CardStateReview
@@ -327,6 +397,14 @@ func graduateToReview(params Parameters, card Card, stability, difficulty float6
 	interval := NextInterval(stability, params.DesiredRetention)
 	interval = clampInterval(interval, params.MaxIntervalDays)
 
+	// Fuzz the first review interval too, so cards learned together in one
+	// sitting do not stay due on the same day forever.
+	if params.EnableFuzz {
+		seed := FuzzSeed(now, card.Reps, difficulty, stability)
+		interval = int(applyFuzz(float64(interval), 0, float64(params.MaxIntervalDays), seed))
+		interval = clampInterval(interval, params.MaxIntervalDays)
+	}
+
 	card.ScheduledDays = interval
 	card.ElapsedDays = 0
 	card.Due = now.Add(time.Duration(interval) * 24 * time.Hour)
@@ -1,6 +1,7 @@
 package fsrs
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -79,6 +80,40 @@ func TestReviewNew_Easy_Graduate(t *testing.T) {
 	}
 }
 
+func TestReviewNew_PresetState(t *testing.T) {
+	params := newTestParams()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	plain := Card{State: domain.CardStateNew}
+	preset := Card{State: domain.CardStateNew, Stability: 20, Difficulty: 5}
+
+	good := mustReview(t, params, preset, Good, now)
+	if good.Stability != 20 {
+		t.Errorf("Good stability = %f, want preset 20", good.Stability)
+	}
+	if good.Difficulty != 5 {
+		t.Errorf("Good difficulty = %f, want preset 5", good.Difficulty)
+	}
+
+	// Easy shifts difficulty below the preset by the same amount as without one.
+	easy := mustReview(t, params, preset, Easy, now)
+	plainEasy := mustReview(t, params, plain, Easy, now)
+	plainGood := mustReview(t, params, plain, Good, now)
+	wantEasyD := 5 + plainEasy.Difficulty - plainGood.Difficulty
+	if math.Abs(easy.Difficulty-wantEasyD) > 1e-9 {
+		t.Errorf("Easy difficulty = %f, want %f", easy.Difficulty, wantEasyD)
+	}
+	if easy.ScheduledDays <= plainEasy.ScheduledDays {
+		t.Errorf("Easy interval = %d, want longer than without preset (%d)", easy.ScheduledDays, plainEasy.ScheduledDays)
+	}
+
+	// A failed first answer ignores the preset stability.
+	again := mustReview(t, params, preset, Again, now)
+	plainAgain := mustReview(t, params, plain, Again, now)
+	if again.Stability != plainAgain.Stability {
+		t.Errorf("Again stability = %f, want %f", again.Stability, plainAgain.Stability)
+	}
+}
+
 func TestReviewLearning_Good_Graduate(t *testing.T) {
 	params := newTestParams()
 	card := Card{
@@ -160,6 +195,51 @@ func TestReviewReview_IntervalOrdering(t *testing.T) {
 	}
 }
 
+func TestReviewReview_HardIntervalFactor(t *testing.T) {
+	card := Card{
+		State:       domain.CardStateReview,
+		Stability:   20.0,
+		Difficulty:  5.0,
+		ElapsedDays: 20,
+		Reps:        5,
+	}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	base := newTestParams()
+	longer := newTestParams()
+	longer.HardIntervalFactor = 1.5
+	shorter := newTestParams()
+	shorter.HardIntervalFactor = 0.5
+
+	baseHard := mustReview(t, base, card, Hard, now)
+	longHard := mustReview(t, longer, card, Hard, now)
+	shortHard := mustReview(t, shorter, card, Hard, now)
+	if longHard.ScheduledDays <= baseHard.ScheduledDays {
+		t.Errorf("factor 1.5: Hard = %d, want > default %d", longHard.ScheduledDays, baseHard.ScheduledDays)
+	}
+	if shortHard.ScheduledDays >= baseHard.ScheduledDays {
+		t.Errorf("factor 0.5: Hard = %d, want < default %d", shortHard.ScheduledDays, baseHard.ScheduledDays)
+	}
+	if longHard.Stability != baseHard.Stability {
+		t.Errorf("factor changed Hard stability: %v vs %v", longHard.Stability, baseHard.Stability)
+	}
+
+	for _, rating := range []Rating{Good, Easy} {
+		want := mustReview(t, base, card, rating, now)
+		got := mustReview(t, longer, card, rating, now)
+		if got.ScheduledDays != want.ScheduledDays {
+			t.Errorf("rating %d: ScheduledDays = %d with factor, want %d", rating, got.ScheduledDays, want.ScheduledDays)
+		}
+	}
+
+	// Even a large factor keeps Hard below Good.
+	longer.HardIntervalFactor = 2.0
+	good := mustReview(t, longer, card, Good, now)
+	if hard := mustReview(t, longer, card, Hard, now); hard.ScheduledDays >= good.ScheduledDays {
+		t.Errorf("factor 2.0: Hard = %d, want < Good %d", hard.ScheduledDays, good.ScheduledDays)
+	}
+}
+
 func TestReviewReview_Again_Lapse(t *testing.T) {
 	params := newTestParams()
 	card := Card{
@@ -188,6 +268,47 @@ func TestReviewReview_Again_Lapse(t *testing.T) {
 	}
 }
 
+func TestReviewReview_LapseFollowsRelearningSteps(t *testing.T) {
+	params := newTestParams()
+	params.LearningSteps = []time.Duration{time.Minute, 10 * time.Minute, time.Hour}
+	params.RelearningSteps = []time.Duration{5 * time.Minute, 20 * time.Minute}
+	card := Card{
+		State:       domain.CardStateReview,
+		Stability:   20.0,
+		Difficulty:  5.0,
+		ElapsedDays: 20,
+		Reps:        10,
+	}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Lapse: first relearning step, not the first learning step.
+	card = mustReview(t, params, card, Again, now)
+	if card.State != domain.CardStateRelearning {
+		t.Fatalf("state = %s, want RELEARNING", card.State)
+	}
+	if got := card.Due.Sub(now); got != 5*time.Minute {
+		t.Errorf("after lapse: due in %v, want 5m", got)
+	}
+
+	// Good: second relearning step (a learning card would wait 10m).
+	now = card.Due
+	card = mustReview(t, params, card, Good, now)
+	if card.State != domain.CardStateRelearning || card.Step != 1 {
+		t.Fatalf("after first Good: state = %s step = %d, want RELEARNING step 1", card.State, card.Step)
+	}
+	if got := card.Due.Sub(now); got != 20*time.Minute {
+		t.Errorf("after first Good: due in %v, want 20m", got)
+	}
+
+	// Good: relearning has two steps, so the card graduates (a learning
+	// card would still have the 1h step left).
+	now = card.Due
+	card = mustReview(t, params, card, Good, now)
+	if card.State != domain.CardStateReview {
+		t.Errorf("after second Good: state = %s, want REVIEW", card.State)
+	}
+}
+
 func TestReviewReview_LapseCappedByNextSMin(t *testing.T) {
 	params := newTestParams()
 	card := Card{
@@ -370,3 +491,105 @@ func TestReviewCard_UnknownState(t *testing.T) {
 		t.Error("expected error for unknown card state, got nil")
 	}
 }
+
+func TestReviewCard_DifficultyClampedToConfiguredRange(t *testing.T) {
+	params := newTestParams()
+	params.DifficultyMin = 3
+	params.DifficultyMax = 7
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Repeated Again drives difficulty up; repeated Easy drives it down.
+	for _, tc := range []struct {
+		rating Rating
+		want   float64
+	}{
+		{Again, 7},
+		{Easy, 3},
+	} {
+		card := Card{State: domain.CardStateNew}
+		for i := 0; i < 30; i++ {
+			card = mustReview(t, params, card, tc.rating, now)
+			if card.Difficulty < params.DifficultyMin || card.Difficulty > params.DifficultyMax {
+				t.Fatalf("rating %d, review %d: difficulty = %v, want within [3, 7]", tc.rating, i, card.Difficulty)
+			}
+			now = card.Due
+			card.ElapsedDays = card.ScheduledDays
+		}
+		if card.Difficulty != tc.want {
+			t.Errorf("rating %d: final difficulty = %v, want %v", tc.rating, card.Difficulty, tc.want)
+		}
+	}
+}
+
+func TestReviewCard_ClampsCorruptInput(t *testing.T) {
+	params := newTestParams()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	inputs := []Card{
+		{State: domain.CardStateReview, Stability: 0, Difficulty: 5, ElapsedDays: 3},
+		{State: domain.CardStateReview, Stability: -40, Difficulty: 50, ElapsedDays: 3},
+		{State: domain.CardStateReview, Stability: math.NaN(), Difficulty: math.NaN(), ElapsedDays: 3},
+		{State: domain.CardStateRelearning, Stability: -1, Difficulty: -3},
+	}
+	for _, in := range inputs {
+		for _, rating := range []Rating{Again, Hard, Good, Easy} {
+			got := mustReview(t, params, in, rating, now)
+
+			if math.IsNaN(got.Stability) || got.Stability < MinStability {
+				t.Errorf("%+v rated %d: stability = %v, want >= %v", in, rating, got.Stability, MinStability)
+			}
+			if math.IsNaN(got.Difficulty) || got.Difficulty < 1 || got.Difficulty > 10 {
+				t.Errorf("%+v rated %d: difficulty = %v, want within [1, 10]", in, rating, got.Difficulty)
+			}
+			if !got.Due.After(now) {
+				t.Errorf("%+v rated %d: due %v not after now", in, rating, got.Due)
+			}
+			if got.State == domain.CardStateReview && got.ScheduledDays < 1 {
+				t.Errorf("%+v rated %d: scheduled days = %d, want >= 1", in, rating, got.ScheduledDays)
+			}
+		}
+	}
+}
+
+func TestReviewCard_FuzzSpreadsGraduatingCards(t *testing.T) {
+	params := newTestParams()
+	params.EnableFuzz = true
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	graduate := func(i int, p Parameters) Card {
+		// 50 new cards rated Easy one minute apart in a single sitting.
+		return mustReview(t, p, Card{State: domain.CardStateNew}, Easy, start.Add(time.Duration(i)*time.Minute))
+	}
+
+	distinct := map[int]bool{}
+	for i := 0; i < 50; i++ {
+		got := graduate(i, params)
+		if again := graduate(i, params); again.ScheduledDays != got.ScheduledDays {
+			t.Fatalf("card %d: fuzz not deterministic: %d then %d", i, got.ScheduledDays, again.ScheduledDays)
+		}
+		if got.ScheduledDays < 1 || got.ScheduledDays > params.MaxIntervalDays {
+			t.Fatalf("card %d: scheduled days = %d, outside [1, %d]", i, got.ScheduledDays, params.MaxIntervalDays)
+		}
+		distinct[got.ScheduledDays] = true
+	}
+	if len(distinct) < 2 {
+		t.Errorf("fuzz produced a single interval for 50 cards: %v", distinct)
+	}
+}
+
+func TestReviewCard_FuzzRespectsMaxInterval(t *testing.T) {
+	params := newTestParams()
+	params.EnableFuzz = true
+	params.MaxIntervalDays = 10
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 100; i++ {
+		card := Card{State: domain.CardStateReview, Stability: 50, Difficulty: 5, ElapsedDays: 10, Reps: i}
+		for _, rating := range []Rating{Hard, Good, Easy} {
+			got := mustReview(t, params, card, rating, now.Add(time.Duration(i)*time.Hour))
+			if got.ScheduledDays < 1 || got.ScheduledDays > 10 {
+				t.Fatalf("reps %d rated %d: scheduled days = %d, outside [1, 10]", i, rating, got.ScheduledDays)
+			}
+		}
+	}
+}
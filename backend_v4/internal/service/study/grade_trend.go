@@ -0,0 +1,60 @@
+package study
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/tzutil"
+)
+
+const (
+	defaultGradeTrendDays = 30
+	maxGradeTrendDays     = 365
+)
+
+// GetGradeTrend returns the user's review counts per grade for each of the
+// last days calendar days in their timezone, oldest first and ending today.
+// Days without reviews are included with zero counts. days defaults to 30
+// and is capped at 365.
+func (s *Service) GetGradeTrend(ctx context.Context, days int) ([]domain.DayGradeCounts, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if days <= 0 {
+		days = defaultGradeTrendDays
+	}
+	days = min(days, maxGradeTrendDays)
+
+	settings, err := s.settings.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get settings: %w", err)
+	}
+
+	tz := tzutil.Location(settings.Timezone, s.log)
+	// AddDate in the user's location keeps day starts right across DST.
+	first := tzutil.DayStart(s.clock.Now(), tz).In(tz).AddDate(0, 0, -(days - 1))
+
+	counts, err := s.reviews.GetGradeCountsByDay(ctx, userID, first, settings.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("get grade counts: %w", err)
+	}
+
+	byDate := make(map[string]domain.GradeCounts, len(counts))
+	for _, c := range counts {
+		byDate[c.Date.Format(time.DateOnly)] = c.Grades
+	}
+
+	// Dates are calendar dates like the ones the repo returns: midnight UTC.
+	trend := make([]domain.DayGradeCounts, days)
+	for i := range trend {
+		day := first.AddDate(0, 0, i)
+		date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+		trend[i] = domain.DayGradeCounts{Date: date, Grades: byDate[date.Format(time.DateOnly)]}
+	}
+
+	return trend, nil
+}
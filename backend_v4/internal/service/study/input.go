@@ -1,6 +1,8 @@
 package study
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
@@ -8,8 +10,14 @@ import (
 // GetQueueInput holds the parameters for fetching the study queue.
 type GetQueueInput struct {
 	Limit int
+	// ExcludeCardIDs are left out of the queue, e.g. cards skipped earlier in
+	// the session, so a refetch does not bring them back.
+	ExcludeCardIDs []uuid.UUID
 }
 
+// maxExcludeCardIDs caps GetQueueInput.ExcludeCardIDs.
+const maxExcludeCardIDs = 500
+
 // Validate checks all fields and collects all errors.
 func (i *GetQueueInput) Validate() error {
 	var errs []domain.FieldError
@@ -18,6 +26,10 @@ func (i *GetQueueInput) Validate() error {
 		errs = append(errs, domain.FieldError{Field: "limit", Message: "must be between 0 and 200"})
 	}
 
+	if len(i.ExcludeCardIDs) > maxExcludeCardIDs {
+		errs = append(errs, domain.FieldError{Field: "exclude_card_ids", Message: "too many (max 500)"})
+	}
+
 	if len(errs) > 0 {
 		return domain.NewValidationErrors(errs)
 	}
@@ -26,9 +38,35 @@ func (i *GetQueueInput) Validate() error {
 
 // ReviewCardInput holds the parameters for reviewing a card.
 type ReviewCardInput struct {
-	CardID     uuid.UUID
-	Grade      domain.ReviewGrade
+	CardID uuid.UUID
+	Grade  domain.ReviewGrade
+	// DurationMs is how long the learner looked at the card. Negative values
+	// and values over a day are rejected as client bugs; values over 10
+	// minutes are clamped to 10 minutes, as the card was most likely left open.
 	DurationMs *int
+	// IdempotencyKey optionally identifies the submission. Resubmitting a
+	// review with a key already used for the card does not review it again.
+	IdempotencyKey string
+}
+
+// maxIdempotencyKeyLen caps ReviewCardInput.IdempotencyKey.
+const maxIdempotencyKeyLen = 128
+
+const (
+	// maxReviewDurationMs is the longest review duration that is stored.
+	maxReviewDurationMs = 10 * 60 * 1000
+	// maxAcceptedDurationMs is the longest review duration accepted at all.
+	maxAcceptedDurationMs = 24 * 60 * 60 * 1000
+)
+
+// reviewDurationMs returns the duration to store for the review: DurationMs
+// clamped to maxReviewDurationMs. Call after Validate.
+func (i *ReviewCardInput) reviewDurationMs() *int {
+	if i.DurationMs == nil || *i.DurationMs <= maxReviewDurationMs {
+		return i.DurationMs
+	}
+	d := maxReviewDurationMs
+	return &d
 }
 
 // Validate checks all fields and collects all errors.
@@ -45,8 +83,11 @@ func (i *ReviewCardInput) Validate() error {
 	if i.DurationMs != nil && *i.DurationMs < 0 {
 		errs = append(errs, domain.FieldError{Field: "duration_ms", Message: "must be non-negative"})
 	}
-	if i.DurationMs != nil && *i.DurationMs > 600_000 {
-		errs = append(errs, domain.FieldError{Field: "duration_ms", Message: "max 10 minutes"})
+	if i.DurationMs != nil && *i.DurationMs > maxAcceptedDurationMs {
+		errs = append(errs, domain.FieldError{Field: "duration_ms", Message: "max 24 hours"})
+	}
+	if len(i.IdempotencyKey) > maxIdempotencyKeyLen {
+		errs = append(errs, domain.FieldError{Field: "idempotency_key", Message: "too long (max 128)"})
 	}
 	if len(errs) > 0 {
 		return domain.NewValidationErrors(errs)
@@ -73,6 +114,25 @@ func (i *UndoReviewInput) Validate() error {
 	return nil
 }
 
+// SkipCardInput holds the parameters for skipping a card.
+type SkipCardInput struct {
+	CardID uuid.UUID
+}
+
+// Validate checks all fields and collects all errors.
+func (i *SkipCardInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.CardID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "card_id", Message: "required"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
 // CreateCardInput holds the parameters for creating a card.
 type CreateCardInput struct {
 	EntryID uuid.UUID
@@ -111,6 +171,105 @@ func (i *DeleteCardInput) Validate() error {
 	return nil
 }
 
+// GetDifficultWordsInput holds the parameters for the difficult words report.
+type GetDifficultWordsInput struct {
+	Limit int // 0 means the default of 20
+}
+
+// Validate checks all fields and collects all errors.
+func (i *GetDifficultWordsInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.Limit < 0 || i.Limit > 100 {
+		errs = append(errs, domain.FieldError{Field: "limit", Message: "must be between 0 and 100"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// maxReviewExportSpan caps the date range of a review log export.
+const maxReviewExportSpan = 2 * 365 * 24 * time.Hour
+
+// ExportReviewLogsInput holds the date range of a review log export. Both
+// bounds are inclusive.
+type ExportReviewLogsInput struct {
+	From time.Time
+	To   time.Time
+}
+
+// Validate checks all fields and collects all errors.
+func (i *ExportReviewLogsInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.From.IsZero() {
+		errs = append(errs, domain.FieldError{Field: "from", Message: "required"})
+	}
+	if i.To.IsZero() {
+		errs = append(errs, domain.FieldError{Field: "to", Message: "required"})
+	}
+	if !i.From.IsZero() && !i.To.IsZero() {
+		if i.To.Before(i.From) {
+			errs = append(errs, domain.FieldError{Field: "to", Message: "must not be before from"})
+		} else if i.To.Sub(i.From) > maxReviewExportSpan {
+			errs = append(errs, domain.FieldError{Field: "to", Message: "range must not exceed 2 years"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// SuspendCardInput holds the parameters for suspending or unsuspending a card.
+type SuspendCardInput struct {
+	CardID uuid.UUID
+}
+
+// Validate checks all fields and collects all errors.
+func (i *SuspendCardInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.CardID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "card_id", Message: "required"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
+// GetHistoryCursorInput holds the parameters for cursor-paged card review history.
+type GetHistoryCursorInput struct {
+	CardID uuid.UUID
+	Cursor *string // nil = first page
+	Limit  int
+}
+
+// Validate checks all fields and collects all errors.
+func (i *GetHistoryCursorInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.CardID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "card_id", Message: "required"})
+	}
+	if i.Limit < 0 || i.Limit > 200 {
+		errs = append(errs, domain.FieldError{Field: "limit", Message: "must be between 0 and 200"})
+	}
+	if i.Cursor != nil && *i.Cursor == "" {
+		errs = append(errs, domain.FieldError{Field: "cursor", Message: "must not be empty"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
+
 // GetCardHistoryInput holds the parameters for fetching card review history.
 type GetCardHistoryInput struct {
 	CardID uuid.UUID
@@ -177,3 +336,26 @@ func (i *FinishSessionInput) Validate() error {
 	}
 	return nil
 }
+
+// RescheduleInput holds the parameters for manually setting a card's due date.
+type RescheduleInput struct {
+	CardID uuid.UUID
+	Due    time.Time
+}
+
+// Validate checks all fields and collects all errors.
+func (i *RescheduleInput) Validate() error {
+	var errs []domain.FieldError
+
+	if i.CardID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "card_id", Message: "required"})
+	}
+	if i.Due.IsZero() {
+		errs = append(errs, domain.FieldError{Field: "due", Message: "required"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+	return nil
+}
@@ -2,12 +2,42 @@ package study
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 )
 
+func TestReviewCardInput_reviewDurationMs(t *testing.T) {
+	if got := (&ReviewCardInput{}).reviewDurationMs(); got != nil {
+		t.Errorf("nil duration: got %d, want nil", *got)
+	}
+
+	tests := []struct {
+		name string
+		in   int
+		want int
+	}{
+		{name: "zero", in: 0, want: 0},
+		{name: "typical", in: 4200, want: 4200},
+		{name: "at cap", in: 600_000, want: 600_000},
+		{name: "over cap", in: 600_001, want: 600_000},
+		{name: "one day", in: 86_400_000, want: 600_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := ReviewCardInput{DurationMs: ptr(tt.in)}
+			got := input.reviewDurationMs()
+			if got == nil || *got != tt.want {
+				t.Errorf("reviewDurationMs(%d) = %v, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetQueueInput_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -82,8 +112,28 @@ func TestReviewCardInput_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name:    "invalid duration exceeds 10 min",
+			name:    "valid duration over 10 min is clamped later",
 			input:   ReviewCardInput{CardID: validID, Grade: domain.ReviewGradeGood, DurationMs: ptr(600_001)},
+			wantErr: false,
+		},
+		{
+			name:    "valid duration one day",
+			input:   ReviewCardInput{CardID: validID, Grade: domain.ReviewGradeGood, DurationMs: ptr(86_400_000)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid duration exceeds one day",
+			input:   ReviewCardInput{CardID: validID, Grade: domain.ReviewGradeGood, DurationMs: ptr(86_400_001)},
+			wantErr: true,
+		},
+		{
+			name:    "valid with idempotency key",
+			input:   ReviewCardInput{CardID: validID, Grade: domain.ReviewGradeGood, IdempotencyKey: strings.Repeat("k", 128)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid idempotency key too long",
+			input:   ReviewCardInput{CardID: validID, Grade: domain.ReviewGradeGood, IdempotencyKey: strings.Repeat("k", 129)},
 			wantErr: true,
 		},
 	}
@@ -243,3 +293,34 @@ func TestFinishSessionInput_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestExportReviewLogsInput_Validate(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   ExportReviewLogsInput
+		wantErr bool
+	}{
+		{name: "valid", input: ExportReviewLogsInput{From: from, To: from.AddDate(0, 1, 0)}, wantErr: false},
+		{name: "valid single instant", input: ExportReviewLogsInput{From: from, To: from}, wantErr: false},
+		{name: "valid 2 years", input: ExportReviewLogsInput{From: from, To: from.Add(maxReviewExportSpan)}, wantErr: false},
+		{name: "invalid missing from", input: ExportReviewLogsInput{To: from}, wantErr: true},
+		{name: "invalid missing to", input: ExportReviewLogsInput{From: from}, wantErr: true},
+		{name: "invalid to before from", input: ExportReviewLogsInput{From: from, To: from.Add(-time.Second)}, wantErr: true},
+		{name: "invalid span too long", input: ExportReviewLogsInput{From: from, To: from.Add(maxReviewExportSpan + time.Second)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, domain.ErrValidation) {
+				t.Errorf("expected ErrValidation, got %v", err)
+			}
+		})
+	}
+}
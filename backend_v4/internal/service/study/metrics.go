@@ -0,0 +1,72 @@
+package study
+
+import (
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/metrics"
+)
+
+// reviewDurationBuckets are upper bounds, in seconds, for review durations.
+var reviewDurationBuckets = []float64{1, 2, 5, 10, 20, 30, 60, 120, 300}
+
+// Metrics holds the study service counters. A nil *Metrics is valid and
+// records nothing, so the service works without metrics configured.
+type Metrics struct {
+	reviews           *metrics.CounterVec
+	reviewDuration    *metrics.Histogram
+	sessionsStarted   *metrics.Counter
+	sessionsFinished  *metrics.Counter
+	sessionsAbandoned *metrics.Counter
+	cardsCreated      *metrics.Counter
+}
+
+// NewMetrics registers the study metrics in reg.
+func NewMetrics(reg *metrics.Registry) *Metrics {
+	return &Metrics{
+		reviews: reg.NewCounterVec("myenglish_study_reviews_total",
+			"Card reviews by grade.", "grade"),
+		reviewDuration: reg.NewHistogram("myenglish_study_review_duration_seconds",
+			"Client-reported time spent on a review.", reviewDurationBuckets),
+		sessionsStarted: reg.NewCounter("myenglish_study_sessions_started_total",
+			"Study sessions started."),
+		sessionsFinished: reg.NewCounter("myenglish_study_sessions_finished_total",
+			"Study sessions finished."),
+		sessionsAbandoned: reg.NewCounter("myenglish_study_sessions_abandoned_total",
+			"Study sessions abandoned."),
+		cardsCreated: reg.NewCounter("myenglish_study_cards_created_total",
+			"Cards created, individually or in batches."),
+	}
+}
+
+func (m *Metrics) reviewed(grade domain.ReviewGrade, durationMs *int) {
+	if m == nil {
+		return
+	}
+	m.reviews.With(string(grade)).Inc()
+	if durationMs != nil {
+		m.reviewDuration.Observe(float64(*durationMs) / 1000)
+	}
+}
+
+func (m *Metrics) sessionStarted() {
+	if m != nil {
+		m.sessionsStarted.Inc()
+	}
+}
+
+func (m *Metrics) sessionFinished() {
+	if m != nil {
+		m.sessionsFinished.Inc()
+	}
+}
+
+func (m *Metrics) sessionAbandoned() {
+	if m != nil {
+		m.sessionsAbandoned.Inc()
+	}
+}
+
+func (m *Metrics) cardsCreatedN(n int) {
+	if m != nil && n > 0 {
+		m.cardsCreated.Add(uint64(n))
+	}
+}
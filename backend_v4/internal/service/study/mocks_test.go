@@ -21,6 +21,9 @@ var _ cardRepo = &cardRepoMock{}
 //
 //		// make and configure a mocked cardRepo
 //		mockedcardRepo := &cardRepoMock{
+//			BatchCreateFunc: func(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability []float64, difficulty []float64) ([]*domain.Card, error) {
+//				panic("mock out the BatchCreate method")
+//			},
 //			CountByStatusFunc: func(ctx context.Context, userID uuid.UUID) (domain.CardStatusCounts, error) {
 //				panic("mock out the CountByStatus method")
 //			},
@@ -33,8 +36,8 @@ var _ cardRepo = &cardRepoMock{}
 //			CountOverdueFunc: func(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error) {
 //				panic("mock out the CountOverdue method")
 //			},
-//			CreateFunc: func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*domain.Card, error) {
-//				panic("mock out the Create method")
+//			CreateWithParamsFunc: func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID, stability float64, difficulty float64) (*domain.Card, error) {
+//				panic("mock out the CreateWithParams method")
 //			},
 //			DeleteFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
 //				panic("mock out the Delete method")
@@ -51,12 +54,27 @@ var _ cardRepo = &cardRepoMock{}
 //			GetByIDForUpdateFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) (*domain.Card, error) {
 //				panic("mock out the GetByIDForUpdate method")
 //			},
-//			GetDueCardsFunc: func(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*domain.Card, error) {
+//			GetDueCardsFunc: func(ctx context.Context, userID uuid.UUID, now time.Time, limit int, order domain.DueCardOrder) ([]*domain.Card, error) {
 //				panic("mock out the GetDueCards method")
 //			},
-//			GetNewCardsFunc: func(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Card, error) {
+//			GetLearningDueFunc: func(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*domain.Card, error) {
+//				panic("mock out the GetLearningDue method")
+//			},
+//			GetNewCardsFunc: func(ctx context.Context, userID uuid.UUID, limit int, order domain.NewCardOrder) ([]*domain.Card, error) {
 //				panic("mock out the GetNewCards method")
 //			},
+//			GetStabilityHistogramFunc: func(ctx context.Context, userID uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error) {
+//				panic("mock out the GetStabilityHistogram method")
+//			},
+//			IncrementSkipCountFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) (int, error) {
+//				panic("mock out the IncrementSkipCount method")
+//			},
+//			SetSuspendedFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, suspended bool) (*domain.Card, error) {
+//				panic("mock out the SetSuspended method")
+//			},
+//			StudyableByIDsFunc: func(ctx context.Context, userID uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+//				panic("mock out the StudyableByIDs method")
+//			},
 //			UpdateSRSFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
 //				panic("mock out the UpdateSRS method")
 //			},
@@ -67,6 +85,9 @@ var _ cardRepo = &cardRepoMock{}
 //
 //	}
 type cardRepoMock struct {
+	// BatchCreateFunc mocks the BatchCreate method.
+	BatchCreateFunc func(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability []float64, difficulty []float64) ([]*domain.Card, error)
+
 	// CountByStatusFunc mocks the CountByStatus method.
 	CountByStatusFunc func(ctx context.Context, userID uuid.UUID) (domain.CardStatusCounts, error)
 
@@ -79,8 +100,8 @@ type cardRepoMock struct {
 	// CountOverdueFunc mocks the CountOverdue method.
 	CountOverdueFunc func(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error)
 
-	// CreateFunc mocks the Create method.
-	CreateFunc func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*domain.Card, error)
+	// CreateWithParamsFunc mocks the CreateWithParams method.
+	CreateWithParamsFunc func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID, stability float64, difficulty float64) (*domain.Card, error)
 
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error
@@ -98,16 +119,44 @@ type cardRepoMock struct {
 	GetByIDForUpdateFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) (*domain.Card, error)
 
 	// GetDueCardsFunc mocks the GetDueCards method.
-	GetDueCardsFunc func(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*domain.Card, error)
+	GetDueCardsFunc func(ctx context.Context, userID uuid.UUID, now time.Time, limit int, order domain.DueCardOrder) ([]*domain.Card, error)
+
+	// GetLearningDueFunc mocks the GetLearningDue method.
+	GetLearningDueFunc func(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*domain.Card, error)
 
 	// GetNewCardsFunc mocks the GetNewCards method.
-	GetNewCardsFunc func(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Card, error)
+	GetNewCardsFunc func(ctx context.Context, userID uuid.UUID, limit int, order domain.NewCardOrder) ([]*domain.Card, error)
+
+	// GetStabilityHistogramFunc mocks the GetStabilityHistogram method.
+	GetStabilityHistogramFunc func(ctx context.Context, userID uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error)
+
+	// IncrementSkipCountFunc mocks the IncrementSkipCount method.
+	IncrementSkipCountFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) (int, error)
+
+	// SetSuspendedFunc mocks the SetSuspended method.
+	SetSuspendedFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, suspended bool) (*domain.Card, error)
+
+	// StudyableByIDsFunc mocks the StudyableByIDs method.
+	StudyableByIDsFunc func(ctx context.Context, userID uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 
 	// UpdateSRSFunc mocks the UpdateSRS method.
 	UpdateSRSFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// BatchCreate holds details about calls to the BatchCreate method.
+		BatchCreate []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// EntryIDs is the entryIDs argument value.
+			EntryIDs []uuid.UUID
+			// Stability is the stability argument value.
+			Stability []float64
+			// Difficulty is the difficulty argument value.
+			Difficulty []float64
+		}
 		// CountByStatus holds details about calls to the CountByStatus method.
 		CountByStatus []struct {
 			// Ctx is the ctx argument value.
@@ -140,14 +189,18 @@ type cardRepoMock struct {
 			// DayStart is the dayStart argument value.
 			DayStart time.Time
 		}
-		// Create holds details about calls to the Create method.
-		Create []struct {
+		// CreateWithParams holds details about calls to the CreateWithParams method.
+		CreateWithParams []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// UserID is the userID argument value.
 			UserID uuid.UUID
 			// EntryID is the entryID argument value.
 			EntryID uuid.UUID
+			// Stability is the stability argument value.
+			Stability float64
+			// Difficulty is the difficulty argument value.
+			Difficulty float64
 		}
 		// Delete holds details about calls to the Delete method.
 		Delete []struct {
@@ -204,6 +257,19 @@ type cardRepoMock struct {
 			Now time.Time
 			// Limit is the limit argument value.
 			Limit int
+			// Order is the order argument value.
+			Order domain.DueCardOrder
+		}
+		// GetLearningDue holds details about calls to the GetLearningDue method.
+		GetLearningDue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Before is the before argument value.
+			Before time.Time
+			// Limit is the limit argument value.
+			Limit int
 		}
 		// GetNewCards holds details about calls to the GetNewCards method.
 		GetNewCards []struct {
@@ -213,6 +279,46 @@ type cardRepoMock struct {
 			UserID uuid.UUID
 			// Limit is the limit argument value.
 			Limit int
+			// Order is the order argument value.
+			Order domain.NewCardOrder
+		}
+		// GetStabilityHistogram holds details about calls to the GetStabilityHistogram method.
+		GetStabilityHistogram []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Bounds is the bounds argument value.
+			Bounds []float64
+		}
+		// IncrementSkipCount holds details about calls to the IncrementSkipCount method.
+		IncrementSkipCount []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
+		// SetSuspended holds details about calls to the SetSuspended method.
+		SetSuspended []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+			// Suspended is the suspended argument value.
+			Suspended bool
+		}
+		// StudyableByIDs holds details about calls to the StudyableByIDs method.
+		StudyableByIDs []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardIDs is the cardIDs argument value.
+			CardIDs []uuid.UUID
 		}
 		// UpdateSRS holds details about calls to the UpdateSRS method.
 		UpdateSRS []struct {
@@ -226,19 +332,73 @@ type cardRepoMock struct {
 			Params domain.SRSUpdateParams
 		}
 	}
-	lockCountByStatus    sync.RWMutex
-	lockCountDue         sync.RWMutex
-	lockCountNew         sync.RWMutex
-	lockCountOverdue     sync.RWMutex
-	lockCreate           sync.RWMutex
-	lockDelete           sync.RWMutex
-	lockExistsByEntryIDs sync.RWMutex
-	lockGetByEntryID     sync.RWMutex
-	lockGetByID          sync.RWMutex
-	lockGetByIDForUpdate sync.RWMutex
-	lockGetDueCards      sync.RWMutex
-	lockGetNewCards      sync.RWMutex
-	lockUpdateSRS        sync.RWMutex
+	lockBatchCreate           sync.RWMutex
+	lockCountByStatus         sync.RWMutex
+	lockCountDue              sync.RWMutex
+	lockCountNew              sync.RWMutex
+	lockCountOverdue          sync.RWMutex
+	lockCreateWithParams      sync.RWMutex
+	lockDelete                sync.RWMutex
+	lockExistsByEntryIDs      sync.RWMutex
+	lockGetByEntryID          sync.RWMutex
+	lockGetByID               sync.RWMutex
+	lockGetByIDForUpdate      sync.RWMutex
+	lockGetDueCards           sync.RWMutex
+	lockGetLearningDue        sync.RWMutex
+	lockGetNewCards           sync.RWMutex
+	lockGetStabilityHistogram sync.RWMutex
+	lockIncrementSkipCount    sync.RWMutex
+	lockSetSuspended          sync.RWMutex
+	lockStudyableByIDs        sync.RWMutex
+	lockUpdateSRS             sync.RWMutex
+}
+
+// BatchCreate calls BatchCreateFunc.
+func (mock *cardRepoMock) BatchCreate(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability []float64, difficulty []float64) ([]*domain.Card, error) {
+	if mock.BatchCreateFunc == nil {
+		panic("cardRepoMock.BatchCreateFunc: method is nil but cardRepo.BatchCreate was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		EntryIDs   []uuid.UUID
+		Stability  []float64
+		Difficulty []float64
+	}{
+		Ctx:        ctx,
+		UserID:     userID,
+		EntryIDs:   entryIDs,
+		Stability:  stability,
+		Difficulty: difficulty,
+	}
+	mock.lockBatchCreate.Lock()
+	mock.calls.BatchCreate = append(mock.calls.BatchCreate, callInfo)
+	mock.lockBatchCreate.Unlock()
+	return mock.BatchCreateFunc(ctx, userID, entryIDs, stability, difficulty)
+}
+
+// BatchCreateCalls gets all the calls that were made to BatchCreate.
+// Check the length with:
+//
+//	len(mockedcardRepo.BatchCreateCalls())
+func (mock *cardRepoMock) BatchCreateCalls() []struct {
+	Ctx        context.Context
+	UserID     uuid.UUID
+	EntryIDs   []uuid.UUID
+	Stability  []float64
+	Difficulty []float64
+} {
+	var calls []struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		EntryIDs   []uuid.UUID
+		Stability  []float64
+		Difficulty []float64
+	}
+	mock.lockBatchCreate.RLock()
+	calls = mock.calls.BatchCreate
+	mock.lockBatchCreate.RUnlock()
+	return calls
 }
 
 // CountByStatus calls CountByStatusFunc.
@@ -393,43 +553,51 @@ func (mock *cardRepoMock) CountOverdueCalls() []struct {
 	return calls
 }
 
-// Create calls CreateFunc.
-func (mock *cardRepoMock) Create(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*domain.Card, error) {
-	if mock.CreateFunc == nil {
-		panic("cardRepoMock.CreateFunc: method is nil but cardRepo.Create was just called")
+// CreateWithParams calls CreateWithParamsFunc.
+func (mock *cardRepoMock) CreateWithParams(ctx context.Context, userID uuid.UUID, entryID uuid.UUID, stability float64, difficulty float64) (*domain.Card, error) {
+	if mock.CreateWithParamsFunc == nil {
+		panic("cardRepoMock.CreateWithParamsFunc: method is nil but cardRepo.CreateWithParams was just called")
 	}
 	callInfo := struct {
-		Ctx     context.Context
-		UserID  uuid.UUID
-		EntryID uuid.UUID
+		Ctx        context.Context
+		UserID     uuid.UUID
+		EntryID    uuid.UUID
+		Stability  float64
+		Difficulty float64
 	}{
-		Ctx:     ctx,
-		UserID:  userID,
-		EntryID: entryID,
+		Ctx:        ctx,
+		UserID:     userID,
+		EntryID:    entryID,
+		Stability:  stability,
+		Difficulty: difficulty,
 	}
-	mock.lockCreate.Lock()
-	mock.calls.Create = append(mock.calls.Create, callInfo)
-	mock.lockCreate.Unlock()
-	return mock.CreateFunc(ctx, userID, entryID)
+	mock.lockCreateWithParams.Lock()
+	mock.calls.CreateWithParams = append(mock.calls.CreateWithParams, callInfo)
+	mock.lockCreateWithParams.Unlock()
+	return mock.CreateWithParamsFunc(ctx, userID, entryID, stability, difficulty)
 }
 
-// CreateCalls gets all the calls that were made to Create.
+// CreateWithParamsCalls gets all the calls that were made to CreateWithParams.
 // Check the length with:
 //
-//	len(mockedcardRepo.CreateCalls())
-func (mock *cardRepoMock) CreateCalls() []struct {
-	Ctx     context.Context
-	UserID  uuid.UUID
-	EntryID uuid.UUID
+//	len(mockedcardRepo.CreateWithParamsCalls())
+func (mock *cardRepoMock) CreateWithParamsCalls() []struct {
+	Ctx        context.Context
+	UserID     uuid.UUID
+	EntryID    uuid.UUID
+	Stability  float64
+	Difficulty float64
 } {
 	var calls []struct {
-		Ctx     context.Context
-		UserID  uuid.UUID
-		EntryID uuid.UUID
-	}
-	mock.lockCreate.RLock()
-	calls = mock.calls.Create
-	mock.lockCreate.RUnlock()
+		Ctx        context.Context
+		UserID     uuid.UUID
+		EntryID    uuid.UUID
+		Stability  float64
+		Difficulty float64
+	}
+	mock.lockCreateWithParams.RLock()
+	calls = mock.calls.CreateWithParams
+	mock.lockCreateWithParams.RUnlock()
 	return calls
 }
 
@@ -634,7 +802,7 @@ func (mock *cardRepoMock) GetByIDForUpdateCalls() []struct {
 }
 
 // GetDueCards calls GetDueCardsFunc.
-func (mock *cardRepoMock) GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*domain.Card, error) {
+func (mock *cardRepoMock) GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int, order domain.DueCardOrder) ([]*domain.Card, error) {
 	if mock.GetDueCardsFunc == nil {
 		panic("cardRepoMock.GetDueCardsFunc: method is nil but cardRepo.GetDueCards was just called")
 	}
@@ -643,16 +811,18 @@ func (mock *cardRepoMock) GetDueCards(ctx context.Context, userID uuid.UUID, now
 		UserID uuid.UUID
 		Now    time.Time
 		Limit  int
+		Order  domain.DueCardOrder
 	}{
 		Ctx:    ctx,
 		UserID: userID,
 		Now:    now,
 		Limit:  limit,
+		Order:  order,
 	}
 	mock.lockGetDueCards.Lock()
 	mock.calls.GetDueCards = append(mock.calls.GetDueCards, callInfo)
 	mock.lockGetDueCards.Unlock()
-	return mock.GetDueCardsFunc(ctx, userID, now, limit)
+	return mock.GetDueCardsFunc(ctx, userID, now, limit, order)
 }
 
 // GetDueCardsCalls gets all the calls that were made to GetDueCards.
@@ -664,12 +834,14 @@ func (mock *cardRepoMock) GetDueCardsCalls() []struct {
 	UserID uuid.UUID
 	Now    time.Time
 	Limit  int
+	Order  domain.DueCardOrder
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID uuid.UUID
 		Now    time.Time
 		Limit  int
+		Order  domain.DueCardOrder
 	}
 	mock.lockGetDueCards.RLock()
 	calls = mock.calls.GetDueCards
@@ -677,8 +849,52 @@ func (mock *cardRepoMock) GetDueCardsCalls() []struct {
 	return calls
 }
 
+// GetLearningDue calls GetLearningDueFunc.
+func (mock *cardRepoMock) GetLearningDue(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*domain.Card, error) {
+	if mock.GetLearningDueFunc == nil {
+		panic("cardRepoMock.GetLearningDueFunc: method is nil but cardRepo.GetLearningDue was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Before time.Time
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Before: before,
+		Limit:  limit,
+	}
+	mock.lockGetLearningDue.Lock()
+	mock.calls.GetLearningDue = append(mock.calls.GetLearningDue, callInfo)
+	mock.lockGetLearningDue.Unlock()
+	return mock.GetLearningDueFunc(ctx, userID, before, limit)
+}
+
+// GetLearningDueCalls gets all the calls that were made to GetLearningDue.
+// Check the length with:
+//
+//	len(mockedcardRepo.GetLearningDueCalls())
+func (mock *cardRepoMock) GetLearningDueCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Before time.Time
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Before time.Time
+		Limit  int
+	}
+	mock.lockGetLearningDue.RLock()
+	calls = mock.calls.GetLearningDue
+	mock.lockGetLearningDue.RUnlock()
+	return calls
+}
+
 // GetNewCards calls GetNewCardsFunc.
-func (mock *cardRepoMock) GetNewCards(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Card, error) {
+func (mock *cardRepoMock) GetNewCards(ctx context.Context, userID uuid.UUID, limit int, order domain.NewCardOrder) ([]*domain.Card, error) {
 	if mock.GetNewCardsFunc == nil {
 		panic("cardRepoMock.GetNewCardsFunc: method is nil but cardRepo.GetNewCards was just called")
 	}
@@ -686,15 +902,17 @@ func (mock *cardRepoMock) GetNewCards(ctx context.Context, userID uuid.UUID, lim
 		Ctx    context.Context
 		UserID uuid.UUID
 		Limit  int
+		Order  domain.NewCardOrder
 	}{
 		Ctx:    ctx,
 		UserID: userID,
 		Limit:  limit,
+		Order:  order,
 	}
 	mock.lockGetNewCards.Lock()
 	mock.calls.GetNewCards = append(mock.calls.GetNewCards, callInfo)
 	mock.lockGetNewCards.Unlock()
-	return mock.GetNewCardsFunc(ctx, userID, limit)
+	return mock.GetNewCardsFunc(ctx, userID, limit, order)
 }
 
 // GetNewCardsCalls gets all the calls that were made to GetNewCards.
@@ -705,11 +923,13 @@ func (mock *cardRepoMock) GetNewCardsCalls() []struct {
 	Ctx    context.Context
 	UserID uuid.UUID
 	Limit  int
+	Order  domain.NewCardOrder
 } {
 	var calls []struct {
 		Ctx    context.Context
 		UserID uuid.UUID
 		Limit  int
+		Order  domain.NewCardOrder
 	}
 	mock.lockGetNewCards.RLock()
 	calls = mock.calls.GetNewCards
@@ -717,6 +937,170 @@ func (mock *cardRepoMock) GetNewCardsCalls() []struct {
 	return calls
 }
 
+// GetStabilityHistogram calls GetStabilityHistogramFunc.
+func (mock *cardRepoMock) GetStabilityHistogram(ctx context.Context, userID uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error) {
+	if mock.GetStabilityHistogramFunc == nil {
+		panic("cardRepoMock.GetStabilityHistogramFunc: method is nil but cardRepo.GetStabilityHistogram was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Bounds []float64
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Bounds: bounds,
+	}
+	mock.lockGetStabilityHistogram.Lock()
+	mock.calls.GetStabilityHistogram = append(mock.calls.GetStabilityHistogram, callInfo)
+	mock.lockGetStabilityHistogram.Unlock()
+	return mock.GetStabilityHistogramFunc(ctx, userID, bounds)
+}
+
+// GetStabilityHistogramCalls gets all the calls that were made to GetStabilityHistogram.
+// Check the length with:
+//
+//	len(mockedcardRepo.GetStabilityHistogramCalls())
+func (mock *cardRepoMock) GetStabilityHistogramCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Bounds []float64
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Bounds []float64
+	}
+	mock.lockGetStabilityHistogram.RLock()
+	calls = mock.calls.GetStabilityHistogram
+	mock.lockGetStabilityHistogram.RUnlock()
+	return calls
+}
+
+// IncrementSkipCount calls IncrementSkipCountFunc.
+func (mock *cardRepoMock) IncrementSkipCount(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) (int, error) {
+	if mock.IncrementSkipCountFunc == nil {
+		panic("cardRepoMock.IncrementSkipCountFunc: method is nil but cardRepo.IncrementSkipCount was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		CardID: cardID,
+	}
+	mock.lockIncrementSkipCount.Lock()
+	mock.calls.IncrementSkipCount = append(mock.calls.IncrementSkipCount, callInfo)
+	mock.lockIncrementSkipCount.Unlock()
+	return mock.IncrementSkipCountFunc(ctx, userID, cardID)
+}
+
+// IncrementSkipCountCalls gets all the calls that were made to IncrementSkipCount.
+// Check the length with:
+//
+//	len(mockedcardRepo.IncrementSkipCountCalls())
+func (mock *cardRepoMock) IncrementSkipCountCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	CardID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}
+	mock.lockIncrementSkipCount.RLock()
+	calls = mock.calls.IncrementSkipCount
+	mock.lockIncrementSkipCount.RUnlock()
+	return calls
+}
+
+// SetSuspended calls SetSuspendedFunc.
+func (mock *cardRepoMock) SetSuspended(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, suspended bool) (*domain.Card, error) {
+	if mock.SetSuspendedFunc == nil {
+		panic("cardRepoMock.SetSuspendedFunc: method is nil but cardRepo.SetSuspended was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		CardID    uuid.UUID
+		Suspended bool
+	}{
+		Ctx:       ctx,
+		UserID:    userID,
+		CardID:    cardID,
+		Suspended: suspended,
+	}
+	mock.lockSetSuspended.Lock()
+	mock.calls.SetSuspended = append(mock.calls.SetSuspended, callInfo)
+	mock.lockSetSuspended.Unlock()
+	return mock.SetSuspendedFunc(ctx, userID, cardID, suspended)
+}
+
+// SetSuspendedCalls gets all the calls that were made to SetSuspended.
+// Check the length with:
+//
+//	len(mockedcardRepo.SetSuspendedCalls())
+func (mock *cardRepoMock) SetSuspendedCalls() []struct {
+	Ctx       context.Context
+	UserID    uuid.UUID
+	CardID    uuid.UUID
+	Suspended bool
+} {
+	var calls []struct {
+		Ctx       context.Context
+		UserID    uuid.UUID
+		CardID    uuid.UUID
+		Suspended bool
+	}
+	mock.lockSetSuspended.RLock()
+	calls = mock.calls.SetSuspended
+	mock.lockSetSuspended.RUnlock()
+	return calls
+}
+
+// StudyableByIDs calls StudyableByIDsFunc.
+func (mock *cardRepoMock) StudyableByIDs(ctx context.Context, userID uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if mock.StudyableByIDsFunc == nil {
+		panic("cardRepoMock.StudyableByIDsFunc: method is nil but cardRepo.StudyableByIDs was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		CardIDs []uuid.UUID
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		CardIDs: cardIDs,
+	}
+	mock.lockStudyableByIDs.Lock()
+	mock.calls.StudyableByIDs = append(mock.calls.StudyableByIDs, callInfo)
+	mock.lockStudyableByIDs.Unlock()
+	return mock.StudyableByIDsFunc(ctx, userID, cardIDs)
+}
+
+// StudyableByIDsCalls gets all the calls that were made to StudyableByIDs.
+// Check the length with:
+//
+//	len(mockedcardRepo.StudyableByIDsCalls())
+func (mock *cardRepoMock) StudyableByIDsCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	CardIDs []uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		CardIDs []uuid.UUID
+	}
+	mock.lockStudyableByIDs.RLock()
+	calls = mock.calls.StudyableByIDs
+	mock.lockStudyableByIDs.RUnlock()
+	return calls
+}
+
 // UpdateSRS calls UpdateSRSFunc.
 func (mock *cardRepoMock) UpdateSRS(ctx context.Context, userID uuid.UUID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
 	if mock.UpdateSRSFunc == nil {
@@ -783,12 +1167,30 @@ var _ reviewLogRepo = &reviewLogRepoMock{}
 //			DeleteFunc: func(ctx context.Context, id uuid.UUID) error {
 //				panic("mock out the Delete method")
 //			},
+//			GetActiveDaysFunc: func(ctx context.Context, userID uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+//				panic("mock out the GetActiveDays method")
+//			},
 //			GetByCardIDFunc: func(ctx context.Context, cardID uuid.UUID, limit int, offset int) ([]*domain.ReviewLog, int, error) {
 //				panic("mock out the GetByCardID method")
 //			},
+//			GetByCardIDCursorFunc: func(ctx context.Context, cardID uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error) {
+//				panic("mock out the GetByCardIDCursor method")
+//			},
+//			GetByIdempotencyKeyFunc: func(ctx context.Context, cardID uuid.UUID, key string) (*domain.ReviewLog, error) {
+//				panic("mock out the GetByIdempotencyKey method")
+//			},
 //			GetByPeriodFunc: func(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time) ([]*domain.ReviewLog, error) {
 //				panic("mock out the GetByPeriod method")
 //			},
+//			GetByPeriodPageFunc: func(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error) {
+//				panic("mock out the GetByPeriodPage method")
+//			},
+//			GetDifficultWordsFunc: func(ctx context.Context, userID uuid.UUID, minReviews int, limit int) ([]domain.DifficultWord, error) {
+//				panic("mock out the GetDifficultWords method")
+//			},
+//			GetGradeCountsByDayFunc: func(ctx context.Context, userID uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error) {
+//				panic("mock out the GetGradeCountsByDay method")
+//			},
 //			GetLastByCardIDFunc: func(ctx context.Context, cardID uuid.UUID) (*domain.ReviewLog, error) {
 //				panic("mock out the GetLastByCardID method")
 //			},
@@ -798,6 +1200,9 @@ var _ reviewLogRepo = &reviewLogRepoMock{}
 //			GetStreakDaysFunc: func(ctx context.Context, userID uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
 //				panic("mock out the GetStreakDays method")
 //			},
+//			GetUserAggregationFunc: func(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UserReviewAggregation, error) {
+//				panic("mock out the GetUserAggregation method")
+//			},
 //		}
 //
 //		// use mockedreviewLogRepo in code that requires reviewLogRepo
@@ -817,12 +1222,30 @@ type reviewLogRepoMock struct {
 	// DeleteFunc mocks the Delete method.
 	DeleteFunc func(ctx context.Context, id uuid.UUID) error
 
+	// GetActiveDaysFunc mocks the GetActiveDays method.
+	GetActiveDaysFunc func(ctx context.Context, userID uuid.UUID, since time.Time, timezone string) ([]time.Time, error)
+
 	// GetByCardIDFunc mocks the GetByCardID method.
 	GetByCardIDFunc func(ctx context.Context, cardID uuid.UUID, limit int, offset int) ([]*domain.ReviewLog, int, error)
 
+	// GetByCardIDCursorFunc mocks the GetByCardIDCursor method.
+	GetByCardIDCursorFunc func(ctx context.Context, cardID uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error)
+
+	// GetByIdempotencyKeyFunc mocks the GetByIdempotencyKey method.
+	GetByIdempotencyKeyFunc func(ctx context.Context, cardID uuid.UUID, key string) (*domain.ReviewLog, error)
+
 	// GetByPeriodFunc mocks the GetByPeriod method.
 	GetByPeriodFunc func(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time) ([]*domain.ReviewLog, error)
 
+	// GetByPeriodPageFunc mocks the GetByPeriodPage method.
+	GetByPeriodPageFunc func(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error)
+
+	// GetDifficultWordsFunc mocks the GetDifficultWords method.
+	GetDifficultWordsFunc func(ctx context.Context, userID uuid.UUID, minReviews int, limit int) ([]domain.DifficultWord, error)
+
+	// GetGradeCountsByDayFunc mocks the GetGradeCountsByDay method.
+	GetGradeCountsByDayFunc func(ctx context.Context, userID uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error)
+
 	// GetLastByCardIDFunc mocks the GetLastByCardID method.
 	GetLastByCardIDFunc func(ctx context.Context, cardID uuid.UUID) (*domain.ReviewLog, error)
 
@@ -832,6 +1255,9 @@ type reviewLogRepoMock struct {
 	// GetStreakDaysFunc mocks the GetStreakDays method.
 	GetStreakDaysFunc func(ctx context.Context, userID uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error)
 
+	// GetUserAggregationFunc mocks the GetUserAggregation method.
+	GetUserAggregationFunc func(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UserReviewAggregation, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CountNewToday holds details about calls to the CountNewToday method.
@@ -866,6 +1292,17 @@ type reviewLogRepoMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// GetActiveDays holds details about calls to the GetActiveDays method.
+		GetActiveDays []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+			// Timezone is the timezone argument value.
+			Timezone string
+		}
 		// GetByCardID holds details about calls to the GetByCardID method.
 		GetByCardID []struct {
 			// Ctx is the ctx argument value.
@@ -877,6 +1314,26 @@ type reviewLogRepoMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// GetByCardIDCursor holds details about calls to the GetByCardIDCursor method.
+		GetByCardIDCursor []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+			// After is the after argument value.
+			After *domain.ReviewLogCursor
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetByIdempotencyKey holds details about calls to the GetByIdempotencyKey method.
+		GetByIdempotencyKey []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+			// Key is the key argument value.
+			Key string
+		}
 		// GetByPeriod holds details about calls to the GetByPeriod method.
 		GetByPeriod []struct {
 			// Ctx is the ctx argument value.
@@ -888,21 +1345,58 @@ type reviewLogRepoMock struct {
 			// To is the to argument value.
 			To time.Time
 		}
-		// GetLastByCardID holds details about calls to the GetLastByCardID method.
-		GetLastByCardID []struct {
+		// GetByPeriodPage holds details about calls to the GetByPeriodPage method.
+		GetByPeriodPage []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// CardID is the cardID argument value.
-			CardID uuid.UUID
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+			// After is the after argument value.
+			After *domain.ReviewLogCursor
+			// Limit is the limit argument value.
+			Limit int
 		}
-		// GetStatsByCardID holds details about calls to the GetStatsByCardID method.
-		GetStatsByCardID []struct {
+		// GetDifficultWords holds details about calls to the GetDifficultWords method.
+		GetDifficultWords []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
-			// CardID is the cardID argument value.
-			CardID uuid.UUID
-		}
-		// GetStreakDays holds details about calls to the GetStreakDays method.
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// MinReviews is the minReviews argument value.
+			MinReviews int
+			// Limit is the limit argument value.
+			Limit int
+		}
+		// GetGradeCountsByDay holds details about calls to the GetGradeCountsByDay method.
+		GetGradeCountsByDay []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// From is the from argument value.
+			From time.Time
+			// Timezone is the timezone argument value.
+			Timezone string
+		}
+		// GetLastByCardID holds details about calls to the GetLastByCardID method.
+		GetLastByCardID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
+		// GetStatsByCardID holds details about calls to the GetStatsByCardID method.
+		GetStatsByCardID []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
+		// GetStreakDays holds details about calls to the GetStreakDays method.
 		GetStreakDays []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
@@ -915,16 +1409,32 @@ type reviewLogRepoMock struct {
 			// Timezone is the timezone argument value.
 			Timezone string
 		}
+		// GetUserAggregation holds details about calls to the GetUserAggregation method.
+		GetUserAggregation []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Since is the since argument value.
+			Since time.Time
+		}
 	}
-	lockCountNewToday    sync.RWMutex
-	lockCountToday       sync.RWMutex
-	lockCreate           sync.RWMutex
-	lockDelete           sync.RWMutex
-	lockGetByCardID      sync.RWMutex
-	lockGetByPeriod      sync.RWMutex
-	lockGetLastByCardID  sync.RWMutex
-	lockGetStatsByCardID sync.RWMutex
-	lockGetStreakDays    sync.RWMutex
+	lockCountNewToday       sync.RWMutex
+	lockCountToday          sync.RWMutex
+	lockCreate              sync.RWMutex
+	lockDelete              sync.RWMutex
+	lockGetActiveDays       sync.RWMutex
+	lockGetByCardID         sync.RWMutex
+	lockGetByCardIDCursor   sync.RWMutex
+	lockGetByIdempotencyKey sync.RWMutex
+	lockGetByPeriod         sync.RWMutex
+	lockGetByPeriodPage     sync.RWMutex
+	lockGetDifficultWords   sync.RWMutex
+	lockGetGradeCountsByDay sync.RWMutex
+	lockGetLastByCardID     sync.RWMutex
+	lockGetStatsByCardID    sync.RWMutex
+	lockGetStreakDays       sync.RWMutex
+	lockGetUserAggregation  sync.RWMutex
 }
 
 // CountNewToday calls CountNewTodayFunc.
@@ -1079,6 +1589,50 @@ func (mock *reviewLogRepoMock) DeleteCalls() []struct {
 	return calls
 }
 
+// GetActiveDays calls GetActiveDaysFunc.
+func (mock *reviewLogRepoMock) GetActiveDays(ctx context.Context, userID uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+	if mock.GetActiveDaysFunc == nil {
+		panic("reviewLogRepoMock.GetActiveDaysFunc: method is nil but reviewLogRepo.GetActiveDays was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Since    time.Time
+		Timezone string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		Since:    since,
+		Timezone: timezone,
+	}
+	mock.lockGetActiveDays.Lock()
+	mock.calls.GetActiveDays = append(mock.calls.GetActiveDays, callInfo)
+	mock.lockGetActiveDays.Unlock()
+	return mock.GetActiveDaysFunc(ctx, userID, since, timezone)
+}
+
+// GetActiveDaysCalls gets all the calls that were made to GetActiveDays.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetActiveDaysCalls())
+func (mock *reviewLogRepoMock) GetActiveDaysCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	Since    time.Time
+	Timezone string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		Since    time.Time
+		Timezone string
+	}
+	mock.lockGetActiveDays.RLock()
+	calls = mock.calls.GetActiveDays
+	mock.lockGetActiveDays.RUnlock()
+	return calls
+}
+
 // GetByCardID calls GetByCardIDFunc.
 func (mock *reviewLogRepoMock) GetByCardID(ctx context.Context, cardID uuid.UUID, limit int, offset int) ([]*domain.ReviewLog, int, error) {
 	if mock.GetByCardIDFunc == nil {
@@ -1123,6 +1677,90 @@ func (mock *reviewLogRepoMock) GetByCardIDCalls() []struct {
 	return calls
 }
 
+// GetByCardIDCursor calls GetByCardIDCursorFunc.
+func (mock *reviewLogRepoMock) GetByCardIDCursor(ctx context.Context, cardID uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error) {
+	if mock.GetByCardIDCursorFunc == nil {
+		panic("reviewLogRepoMock.GetByCardIDCursorFunc: method is nil but reviewLogRepo.GetByCardIDCursor was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		CardID uuid.UUID
+		After  *domain.ReviewLogCursor
+		Limit  int
+	}{
+		Ctx:    ctx,
+		CardID: cardID,
+		After:  after,
+		Limit:  limit,
+	}
+	mock.lockGetByCardIDCursor.Lock()
+	mock.calls.GetByCardIDCursor = append(mock.calls.GetByCardIDCursor, callInfo)
+	mock.lockGetByCardIDCursor.Unlock()
+	return mock.GetByCardIDCursorFunc(ctx, cardID, after, limit)
+}
+
+// GetByCardIDCursorCalls gets all the calls that were made to GetByCardIDCursor.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetByCardIDCursorCalls())
+func (mock *reviewLogRepoMock) GetByCardIDCursorCalls() []struct {
+	Ctx    context.Context
+	CardID uuid.UUID
+	After  *domain.ReviewLogCursor
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		CardID uuid.UUID
+		After  *domain.ReviewLogCursor
+		Limit  int
+	}
+	mock.lockGetByCardIDCursor.RLock()
+	calls = mock.calls.GetByCardIDCursor
+	mock.lockGetByCardIDCursor.RUnlock()
+	return calls
+}
+
+// GetByIdempotencyKey calls GetByIdempotencyKeyFunc.
+func (mock *reviewLogRepoMock) GetByIdempotencyKey(ctx context.Context, cardID uuid.UUID, key string) (*domain.ReviewLog, error) {
+	if mock.GetByIdempotencyKeyFunc == nil {
+		panic("reviewLogRepoMock.GetByIdempotencyKeyFunc: method is nil but reviewLogRepo.GetByIdempotencyKey was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		CardID uuid.UUID
+		Key    string
+	}{
+		Ctx:    ctx,
+		CardID: cardID,
+		Key:    key,
+	}
+	mock.lockGetByIdempotencyKey.Lock()
+	mock.calls.GetByIdempotencyKey = append(mock.calls.GetByIdempotencyKey, callInfo)
+	mock.lockGetByIdempotencyKey.Unlock()
+	return mock.GetByIdempotencyKeyFunc(ctx, cardID, key)
+}
+
+// GetByIdempotencyKeyCalls gets all the calls that were made to GetByIdempotencyKey.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetByIdempotencyKeyCalls())
+func (mock *reviewLogRepoMock) GetByIdempotencyKeyCalls() []struct {
+	Ctx    context.Context
+	CardID uuid.UUID
+	Key    string
+} {
+	var calls []struct {
+		Ctx    context.Context
+		CardID uuid.UUID
+		Key    string
+	}
+	mock.lockGetByIdempotencyKey.RLock()
+	calls = mock.calls.GetByIdempotencyKey
+	mock.lockGetByIdempotencyKey.RUnlock()
+	return calls
+}
+
 // GetByPeriod calls GetByPeriodFunc.
 func (mock *reviewLogRepoMock) GetByPeriod(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time) ([]*domain.ReviewLog, error) {
 	if mock.GetByPeriodFunc == nil {
@@ -1167,6 +1805,146 @@ func (mock *reviewLogRepoMock) GetByPeriodCalls() []struct {
 	return calls
 }
 
+// GetByPeriodPage calls GetByPeriodPageFunc.
+func (mock *reviewLogRepoMock) GetByPeriodPage(ctx context.Context, userID uuid.UUID, from time.Time, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error) {
+	if mock.GetByPeriodPageFunc == nil {
+		panic("reviewLogRepoMock.GetByPeriodPageFunc: method is nil but reviewLogRepo.GetByPeriodPage was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		From   time.Time
+		To     time.Time
+		After  *domain.ReviewLogCursor
+		Limit  int
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		From:   from,
+		To:     to,
+		After:  after,
+		Limit:  limit,
+	}
+	mock.lockGetByPeriodPage.Lock()
+	mock.calls.GetByPeriodPage = append(mock.calls.GetByPeriodPage, callInfo)
+	mock.lockGetByPeriodPage.Unlock()
+	return mock.GetByPeriodPageFunc(ctx, userID, from, to, after, limit)
+}
+
+// GetByPeriodPageCalls gets all the calls that were made to GetByPeriodPage.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetByPeriodPageCalls())
+func (mock *reviewLogRepoMock) GetByPeriodPageCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	From   time.Time
+	To     time.Time
+	After  *domain.ReviewLogCursor
+	Limit  int
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		From   time.Time
+		To     time.Time
+		After  *domain.ReviewLogCursor
+		Limit  int
+	}
+	mock.lockGetByPeriodPage.RLock()
+	calls = mock.calls.GetByPeriodPage
+	mock.lockGetByPeriodPage.RUnlock()
+	return calls
+}
+
+// GetDifficultWords calls GetDifficultWordsFunc.
+func (mock *reviewLogRepoMock) GetDifficultWords(ctx context.Context, userID uuid.UUID, minReviews int, limit int) ([]domain.DifficultWord, error) {
+	if mock.GetDifficultWordsFunc == nil {
+		panic("reviewLogRepoMock.GetDifficultWordsFunc: method is nil but reviewLogRepo.GetDifficultWords was just called")
+	}
+	callInfo := struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		MinReviews int
+		Limit      int
+	}{
+		Ctx:        ctx,
+		UserID:     userID,
+		MinReviews: minReviews,
+		Limit:      limit,
+	}
+	mock.lockGetDifficultWords.Lock()
+	mock.calls.GetDifficultWords = append(mock.calls.GetDifficultWords, callInfo)
+	mock.lockGetDifficultWords.Unlock()
+	return mock.GetDifficultWordsFunc(ctx, userID, minReviews, limit)
+}
+
+// GetDifficultWordsCalls gets all the calls that were made to GetDifficultWords.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetDifficultWordsCalls())
+func (mock *reviewLogRepoMock) GetDifficultWordsCalls() []struct {
+	Ctx        context.Context
+	UserID     uuid.UUID
+	MinReviews int
+	Limit      int
+} {
+	var calls []struct {
+		Ctx        context.Context
+		UserID     uuid.UUID
+		MinReviews int
+		Limit      int
+	}
+	mock.lockGetDifficultWords.RLock()
+	calls = mock.calls.GetDifficultWords
+	mock.lockGetDifficultWords.RUnlock()
+	return calls
+}
+
+// GetGradeCountsByDay calls GetGradeCountsByDayFunc.
+func (mock *reviewLogRepoMock) GetGradeCountsByDay(ctx context.Context, userID uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error) {
+	if mock.GetGradeCountsByDayFunc == nil {
+		panic("reviewLogRepoMock.GetGradeCountsByDayFunc: method is nil but reviewLogRepo.GetGradeCountsByDay was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		From     time.Time
+		Timezone string
+	}{
+		Ctx:      ctx,
+		UserID:   userID,
+		From:     from,
+		Timezone: timezone,
+	}
+	mock.lockGetGradeCountsByDay.Lock()
+	mock.calls.GetGradeCountsByDay = append(mock.calls.GetGradeCountsByDay, callInfo)
+	mock.lockGetGradeCountsByDay.Unlock()
+	return mock.GetGradeCountsByDayFunc(ctx, userID, from, timezone)
+}
+
+// GetGradeCountsByDayCalls gets all the calls that were made to GetGradeCountsByDay.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetGradeCountsByDayCalls())
+func (mock *reviewLogRepoMock) GetGradeCountsByDayCalls() []struct {
+	Ctx      context.Context
+	UserID   uuid.UUID
+	From     time.Time
+	Timezone string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		UserID   uuid.UUID
+		From     time.Time
+		Timezone string
+	}
+	mock.lockGetGradeCountsByDay.RLock()
+	calls = mock.calls.GetGradeCountsByDay
+	mock.lockGetGradeCountsByDay.RUnlock()
+	return calls
+}
+
 // GetLastByCardID calls GetLastByCardIDFunc.
 func (mock *reviewLogRepoMock) GetLastByCardID(ctx context.Context, cardID uuid.UUID) (*domain.ReviewLog, error) {
 	if mock.GetLastByCardIDFunc == nil {
@@ -1287,6 +2065,46 @@ func (mock *reviewLogRepoMock) GetStreakDaysCalls() []struct {
 	return calls
 }
 
+// GetUserAggregation calls GetUserAggregationFunc.
+func (mock *reviewLogRepoMock) GetUserAggregation(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UserReviewAggregation, error) {
+	if mock.GetUserAggregationFunc == nil {
+		panic("reviewLogRepoMock.GetUserAggregationFunc: method is nil but reviewLogRepo.GetUserAggregation was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Since:  since,
+	}
+	mock.lockGetUserAggregation.Lock()
+	mock.calls.GetUserAggregation = append(mock.calls.GetUserAggregation, callInfo)
+	mock.lockGetUserAggregation.Unlock()
+	return mock.GetUserAggregationFunc(ctx, userID, since)
+}
+
+// GetUserAggregationCalls gets all the calls that were made to GetUserAggregation.
+// Check the length with:
+//
+//	len(mockedreviewLogRepo.GetUserAggregationCalls())
+func (mock *reviewLogRepoMock) GetUserAggregationCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Since  time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Since  time.Time
+	}
+	mock.lockGetUserAggregation.RLock()
+	calls = mock.calls.GetUserAggregation
+	mock.lockGetUserAggregation.RUnlock()
+	return calls
+}
+
 // Ensure, that sessionRepoMock does implement sessionRepo.
 // If this is not the case, regenerate this file with moq.
 var _ sessionRepo = &sessionRepoMock{}
@@ -1315,6 +2133,15 @@ var _ sessionRepo = &sessionRepoMock{}
 //			GetByUserIDFunc: func(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*domain.StudySession, int, error) {
 //				panic("mock out the GetByUserID method")
 //			},
+//			MoveToQueueEndFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+//				panic("mock out the MoveToQueueEnd method")
+//			},
+//			RemoveFromQueueFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+//				panic("mock out the RemoveFromQueue method")
+//			},
+//			ReturnToQueueFunc: func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+//				panic("mock out the ReturnToQueue method")
+//			},
 //		}
 //
 //		// use mockedsessionRepo in code that requires sessionRepo
@@ -1340,6 +2167,15 @@ type sessionRepoMock struct {
 	// GetByUserIDFunc mocks the GetByUserID method.
 	GetByUserIDFunc func(ctx context.Context, userID uuid.UUID, limit int, offset int) ([]*domain.StudySession, int, error)
 
+	// MoveToQueueEndFunc mocks the MoveToQueueEnd method.
+	MoveToQueueEndFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error
+
+	// RemoveFromQueueFunc mocks the RemoveFromQueue method.
+	RemoveFromQueueFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error
+
+	// ReturnToQueueFunc mocks the ReturnToQueue method.
+	ReturnToQueueFunc func(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Abandon holds details about calls to the Abandon method.
@@ -1396,13 +2232,43 @@ type sessionRepoMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
+		// MoveToQueueEnd holds details about calls to the MoveToQueueEnd method.
+		MoveToQueueEnd []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
+		// RemoveFromQueue holds details about calls to the RemoveFromQueue method.
+		RemoveFromQueue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
+		// ReturnToQueue holds details about calls to the ReturnToQueue method.
+		ReturnToQueue []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// CardID is the cardID argument value.
+			CardID uuid.UUID
+		}
 	}
-	lockAbandon     sync.RWMutex
-	lockCreate      sync.RWMutex
-	lockFinish      sync.RWMutex
-	lockGetActive   sync.RWMutex
-	lockGetByID     sync.RWMutex
-	lockGetByUserID sync.RWMutex
+	lockAbandon         sync.RWMutex
+	lockCreate          sync.RWMutex
+	lockFinish          sync.RWMutex
+	lockGetActive       sync.RWMutex
+	lockGetByID         sync.RWMutex
+	lockGetByUserID     sync.RWMutex
+	lockMoveToQueueEnd  sync.RWMutex
+	lockRemoveFromQueue sync.RWMutex
+	lockReturnToQueue   sync.RWMutex
 }
 
 // Abandon calls AbandonFunc.
@@ -1645,6 +2511,126 @@ func (mock *sessionRepoMock) GetByUserIDCalls() []struct {
 	return calls
 }
 
+// MoveToQueueEnd calls MoveToQueueEndFunc.
+func (mock *sessionRepoMock) MoveToQueueEnd(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+	if mock.MoveToQueueEndFunc == nil {
+		panic("sessionRepoMock.MoveToQueueEndFunc: method is nil but sessionRepo.MoveToQueueEnd was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		CardID: cardID,
+	}
+	mock.lockMoveToQueueEnd.Lock()
+	mock.calls.MoveToQueueEnd = append(mock.calls.MoveToQueueEnd, callInfo)
+	mock.lockMoveToQueueEnd.Unlock()
+	return mock.MoveToQueueEndFunc(ctx, userID, cardID)
+}
+
+// MoveToQueueEndCalls gets all the calls that were made to MoveToQueueEnd.
+// Check the length with:
+//
+//	len(mockedsessionRepo.MoveToQueueEndCalls())
+func (mock *sessionRepoMock) MoveToQueueEndCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	CardID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}
+	mock.lockMoveToQueueEnd.RLock()
+	calls = mock.calls.MoveToQueueEnd
+	mock.lockMoveToQueueEnd.RUnlock()
+	return calls
+}
+
+// RemoveFromQueue calls RemoveFromQueueFunc.
+func (mock *sessionRepoMock) RemoveFromQueue(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+	if mock.RemoveFromQueueFunc == nil {
+		panic("sessionRepoMock.RemoveFromQueueFunc: method is nil but sessionRepo.RemoveFromQueue was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		CardID: cardID,
+	}
+	mock.lockRemoveFromQueue.Lock()
+	mock.calls.RemoveFromQueue = append(mock.calls.RemoveFromQueue, callInfo)
+	mock.lockRemoveFromQueue.Unlock()
+	return mock.RemoveFromQueueFunc(ctx, userID, cardID)
+}
+
+// RemoveFromQueueCalls gets all the calls that were made to RemoveFromQueue.
+// Check the length with:
+//
+//	len(mockedsessionRepo.RemoveFromQueueCalls())
+func (mock *sessionRepoMock) RemoveFromQueueCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	CardID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}
+	mock.lockRemoveFromQueue.RLock()
+	calls = mock.calls.RemoveFromQueue
+	mock.lockRemoveFromQueue.RUnlock()
+	return calls
+}
+
+// ReturnToQueue calls ReturnToQueueFunc.
+func (mock *sessionRepoMock) ReturnToQueue(ctx context.Context, userID uuid.UUID, cardID uuid.UUID) error {
+	if mock.ReturnToQueueFunc == nil {
+		panic("sessionRepoMock.ReturnToQueueFunc: method is nil but sessionRepo.ReturnToQueue was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		CardID: cardID,
+	}
+	mock.lockReturnToQueue.Lock()
+	mock.calls.ReturnToQueue = append(mock.calls.ReturnToQueue, callInfo)
+	mock.lockReturnToQueue.Unlock()
+	return mock.ReturnToQueueFunc(ctx, userID, cardID)
+}
+
+// ReturnToQueueCalls gets all the calls that were made to ReturnToQueue.
+// Check the length with:
+//
+//	len(mockedsessionRepo.ReturnToQueueCalls())
+func (mock *sessionRepoMock) ReturnToQueueCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	CardID uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		CardID uuid.UUID
+	}
+	mock.lockReturnToQueue.RLock()
+	calls = mock.calls.ReturnToQueue
+	mock.lockReturnToQueue.RUnlock()
+	return calls
+}
+
 // Ensure, that entryRepoMock does implement entryRepo.
 // If this is not the case, regenerate this file with moq.
 var _ entryRepo = &entryRepoMock{}
@@ -1664,6 +2650,12 @@ var _ entryRepo = &entryRepoMock{}
 //			GetByIDsFunc: func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]domain.Entry, error) {
 //				panic("mock out the GetByIDs method")
 //			},
+//			GetFrequencyRankFunc: func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*int, error) {
+//				panic("mock out the GetFrequencyRank method")
+//			},
+//			GetFrequencyRanksFunc: func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+//				panic("mock out the GetFrequencyRanks method")
+//			},
 //		}
 //
 //		// use mockedentryRepo in code that requires entryRepo
@@ -1680,6 +2672,12 @@ type entryRepoMock struct {
 	// GetByIDsFunc mocks the GetByIDs method.
 	GetByIDsFunc func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]domain.Entry, error)
 
+	// GetFrequencyRankFunc mocks the GetFrequencyRank method.
+	GetFrequencyRankFunc func(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*int, error)
+
+	// GetFrequencyRanksFunc mocks the GetFrequencyRanks method.
+	GetFrequencyRanksFunc func(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// ExistByIDs holds details about calls to the ExistByIDs method.
@@ -1709,10 +2707,30 @@ type entryRepoMock struct {
 			// Ids is the ids argument value.
 			Ids []uuid.UUID
 		}
+		// GetFrequencyRank holds details about calls to the GetFrequencyRank method.
+		GetFrequencyRank []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// EntryID is the entryID argument value.
+			EntryID uuid.UUID
+		}
+		// GetFrequencyRanks holds details about calls to the GetFrequencyRanks method.
+		GetFrequencyRanks []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// UserID is the userID argument value.
+			UserID uuid.UUID
+			// Ids is the ids argument value.
+			Ids []uuid.UUID
+		}
 	}
-	lockExistByIDs sync.RWMutex
-	lockGetByID    sync.RWMutex
-	lockGetByIDs   sync.RWMutex
+	lockExistByIDs        sync.RWMutex
+	lockGetByID           sync.RWMutex
+	lockGetByIDs          sync.RWMutex
+	lockGetFrequencyRank  sync.RWMutex
+	lockGetFrequencyRanks sync.RWMutex
 }
 
 // ExistByIDs calls ExistByIDsFunc.
@@ -1835,6 +2853,86 @@ func (mock *entryRepoMock) GetByIDsCalls() []struct {
 	return calls
 }
 
+// GetFrequencyRank calls GetFrequencyRankFunc.
+func (mock *entryRepoMock) GetFrequencyRank(ctx context.Context, userID uuid.UUID, entryID uuid.UUID) (*int, error) {
+	if mock.GetFrequencyRankFunc == nil {
+		panic("entryRepoMock.GetFrequencyRankFunc: method is nil but entryRepo.GetFrequencyRank was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		EntryID uuid.UUID
+	}{
+		Ctx:     ctx,
+		UserID:  userID,
+		EntryID: entryID,
+	}
+	mock.lockGetFrequencyRank.Lock()
+	mock.calls.GetFrequencyRank = append(mock.calls.GetFrequencyRank, callInfo)
+	mock.lockGetFrequencyRank.Unlock()
+	return mock.GetFrequencyRankFunc(ctx, userID, entryID)
+}
+
+// GetFrequencyRankCalls gets all the calls that were made to GetFrequencyRank.
+// Check the length with:
+//
+//	len(mockedentryRepo.GetFrequencyRankCalls())
+func (mock *entryRepoMock) GetFrequencyRankCalls() []struct {
+	Ctx     context.Context
+	UserID  uuid.UUID
+	EntryID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		UserID  uuid.UUID
+		EntryID uuid.UUID
+	}
+	mock.lockGetFrequencyRank.RLock()
+	calls = mock.calls.GetFrequencyRank
+	mock.lockGetFrequencyRank.RUnlock()
+	return calls
+}
+
+// GetFrequencyRanks calls GetFrequencyRanksFunc.
+func (mock *entryRepoMock) GetFrequencyRanks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+	if mock.GetFrequencyRanksFunc == nil {
+		panic("entryRepoMock.GetFrequencyRanksFunc: method is nil but entryRepo.GetFrequencyRanks was just called")
+	}
+	callInfo := struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Ids    []uuid.UUID
+	}{
+		Ctx:    ctx,
+		UserID: userID,
+		Ids:    ids,
+	}
+	mock.lockGetFrequencyRanks.Lock()
+	mock.calls.GetFrequencyRanks = append(mock.calls.GetFrequencyRanks, callInfo)
+	mock.lockGetFrequencyRanks.Unlock()
+	return mock.GetFrequencyRanksFunc(ctx, userID, ids)
+}
+
+// GetFrequencyRanksCalls gets all the calls that were made to GetFrequencyRanks.
+// Check the length with:
+//
+//	len(mockedentryRepo.GetFrequencyRanksCalls())
+func (mock *entryRepoMock) GetFrequencyRanksCalls() []struct {
+	Ctx    context.Context
+	UserID uuid.UUID
+	Ids    []uuid.UUID
+} {
+	var calls []struct {
+		Ctx    context.Context
+		UserID uuid.UUID
+		Ids    []uuid.UUID
+	}
+	mock.lockGetFrequencyRanks.RLock()
+	calls = mock.calls.GetFrequencyRanks
+	mock.lockGetFrequencyRanks.RUnlock()
+	return calls
+}
+
 // Ensure, that senseRepoMock does implement senseRepo.
 // If this is not the case, regenerate this file with moq.
 var _ senseRepo = &senseRepoMock{}
@@ -0,0 +1,105 @@
+package study
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// RescheduleCard moves a REVIEW card to a user-chosen due date. The date must
+// be in the future and no further than the effective MaxIntervalDays. Memory
+// state (stability, difficulty) is left untouched; only the schedule changes.
+func (s *Service) RescheduleCard(ctx context.Context, input RescheduleInput) (*domain.Card, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if !input.Due.After(now) {
+		return nil, domain.NewValidationError("due", "must be in the future")
+	}
+
+	settings, err := s.settings.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get settings: %w", err)
+	}
+
+	maxDays := s.buildFSRSParams(settings).MaxIntervalDays
+	daysUntilDue := int(math.Ceil(input.Due.Sub(now).Hours() / 24))
+	if daysUntilDue > maxDays {
+		return nil, domain.NewValidationError("due", fmt.Sprintf("must be within %d days", maxDays))
+	}
+
+	var updatedCard *domain.Card
+
+	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		card, cardErr := s.cards.GetByIDForUpdate(txCtx, userID, input.CardID)
+		if cardErr != nil {
+			return fmt.Errorf("get card: %w", cardErr)
+		}
+
+		if card.State != domain.CardStateReview {
+			return domain.NewValidationError("card_id", "only cards in review can be rescheduled")
+		}
+
+		snapshot := snapshotFromCard(card)
+
+		// ScheduledDays is the interval from the last review to the new due
+		// date, as if FSRS had scheduled it; ElapsedDays is measured to now.
+		elapsed := computeElapsedDays(card.LastReview, now)
+		params := domain.SRSUpdateParams{
+			State:         card.State,
+			Step:          card.Step,
+			Stability:     card.Stability,
+			Difficulty:    card.Difficulty,
+			Due:           input.Due,
+			LastReview:    card.LastReview,
+			Reps:          card.Reps,
+			Lapses:        card.Lapses,
+			ScheduledDays: elapsed + daysUntilDue,
+			ElapsedDays:   elapsed,
+			Version:       card.Version,
+		}
+
+		var updateErr error
+		updatedCard, updateErr = s.cards.UpdateSRS(txCtx, userID, card.ID, params)
+		if updateErr != nil {
+			return fmt.Errorf("update card: %w", updateErr)
+		}
+
+		changes := srsDiff(snapshot, snapshotFromCard(updatedCard))
+		changes["due"] = map[string]any{"old": card.Due, "new": updatedCard.Due}
+		auditErr := s.audit.Log(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeCard,
+			EntityID:   &card.ID,
+			Action:     domain.AuditActionUpdate,
+			Changes:    changes,
+		})
+		if auditErr != nil {
+			return fmt.Errorf("audit log: %w", auditErr)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "card rescheduled",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", input.CardID.String()),
+		slog.Time("due", input.Due),
+	)
+
+	return updatedCard, nil
+}
@@ -1,6 +1,9 @@
 package study
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
 
 // BatchCreateResult holds the outcome of a batch card creation.
 type BatchCreateResult struct {
@@ -15,3 +18,18 @@ type BatchCreateError struct {
 	EntryID uuid.UUID
 	Reason  string
 }
+
+// SkipCardResult is returned by SkipCard. Skipping does not change the
+// card's schedule: the client should move the card to the end of its local
+// queue and pass it in GetQueueInput.ExcludeCardIDs when refetching.
+type SkipCardResult struct {
+	CardID    uuid.UUID
+	SkipCount int
+}
+
+// CardHistoryPage is one cursor-paged slice of a card's review history.
+type CardHistoryPage struct {
+	Logs       []*domain.ReviewLog
+	NextCursor *string // nil when there are no more pages
+	HasMore    bool
+}
@@ -2,6 +2,7 @@ package study
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -11,6 +12,8 @@ import (
 )
 
 // ReviewCard records a review and updates the card's SRS state using FSRS-5.
+// With an idempotency key that was already used for the card, the review is
+// not applied again and the card is returned as it is.
 func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*domain.Card, error) {
 	userID, err := s.userID(ctx)
 	if err != nil {
@@ -20,6 +23,7 @@ func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*domai
 	if err := input.Validate(); err != nil {
 		return nil, err
 	}
+	durationMs := input.reviewDurationMs()
 
 	now := s.clock.Now()
 
@@ -32,7 +36,10 @@ func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*domai
 	params := s.buildFSRSParams(settings)
 	rating := mapGradeToRating(input.Grade)
 
-	var updatedCard *domain.Card
+	var (
+		updatedCard *domain.Card
+		replayed    bool
+	)
 
 	// Transaction: lock card, compute FSRS, update card + create log + audit
 	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
@@ -42,54 +49,74 @@ func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*domai
 			return fmt.Errorf("get card: %w", cardErr)
 		}
 
+		// The row lock serializes retried submissions, so a retry sees the
+		// log of the first one once it has committed.
+		replayed = false
+		if input.IdempotencyKey != "" {
+			_, keyErr := s.reviews.GetByIdempotencyKey(txCtx, card.ID, input.IdempotencyKey)
+			if keyErr == nil {
+				updatedCard, replayed = card, true
+				return nil
+			}
+			if !errors.Is(keyErr, domain.ErrNotFound) {
+				return fmt.Errorf("get review by idempotency key: %w", keyErr)
+			}
+		}
+
 		snapshot := snapshotFromCard(card)
 
+		// The stored ElapsedDays is stale by the time of the next review;
+		// always derive it from LastReview (0 for a first review).
+		elapsed := computeElapsedDays(card.LastReview, now)
 		fsrsCard := cardToFSRS(card)
-		fsrsCard.ElapsedDays = computeElapsedDays(card.LastReview, now)
+		fsrsCard.ElapsedDays = elapsed
 
 		// Calculate new SRS state
 		result, fsrsErr := fsrs.ReviewCard(params, fsrsCard, rating, now)
 		if fsrsErr != nil {
 			return fmt.Errorf("fsrs review: %w", fsrsErr)
 		}
+		// Persist the gap this review was scheduled from, not the scheduler's reset value.
+		result.ElapsedDays = elapsed
+
+		update := fsrsResultToUpdateParams(result)
+		update.Version = card.Version
 
 		var updateErr error
-		updatedCard, updateErr = s.cards.UpdateSRS(txCtx, userID, card.ID, fsrsResultToUpdateParams(result))
+		updatedCard, updateErr = s.cards.UpdateSRS(txCtx, userID, card.ID, update)
 		if updateErr != nil {
 			return fmt.Errorf("update card: %w", updateErr)
 		}
 
+		var idempotencyKey *string
+		if input.IdempotencyKey != "" {
+			idempotencyKey = &input.IdempotencyKey
+		}
+
 		// Create review log
 		_, logErr := s.reviews.Create(txCtx, &domain.ReviewLog{
-			ID:         uuid.New(),
-			CardID:     card.ID,
-			UserID:     userID,
-			Grade:      input.Grade,
-			PrevState:  snapshot,
-			DurationMs: input.DurationMs,
-			ReviewedAt: now,
+			ID:             uuid.New(),
+			CardID:         card.ID,
+			UserID:         userID,
+			Grade:          input.Grade,
+			PrevState:      snapshot,
+			DurationMs:     durationMs,
+			ReviewedAt:     now,
+			IdempotencyKey: idempotencyKey,
 		})
 		if logErr != nil {
 			return fmt.Errorf("create review log: %w", logErr)
 		}
 
 		// Audit
+		changes := srsDiff(snapshot, snapshotFromCard(updatedCard))
+		changes["grade"] = map[string]any{"new": input.Grade}
 		auditErr := s.audit.Log(txCtx, domain.AuditRecord{
 			UserID:     userID,
 			EntityType: domain.EntityTypeCard,
 			EntityID:   &card.ID,
 			Action:     domain.AuditActionUpdate,
-			Changes: map[string]any{
-				"grade": map[string]any{"new": input.Grade},
-				"state": map[string]any{
-					"old": card.State,
-					"new": updatedCard.State,
-				},
-				"stability": map[string]any{
-					"old": card.Stability,
-					"new": updatedCard.Stability,
-				},
-			},
+			Changes:    changes,
 		})
 		if auditErr != nil {
 			return fmt.Errorf("audit log: %w", auditErr)
@@ -106,6 +133,26 @@ func (s *Service) ReviewCard(ctx context.Context, input ReviewCardInput) (*domai
 		return nil, fmt.Errorf("card update failed: no result returned")
 	}
 
+	if replayed {
+		s.log.InfoContext(ctx, "review replayed",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", input.CardID.String()),
+		)
+		return updatedCard, nil
+	}
+
+	// Best-effort: the queue snapshot only serves session resumption, so a
+	// failure here must not fail an already committed review.
+	if err := s.sessions.RemoveFromQueue(ctx, userID, updatedCard.ID); err != nil {
+		s.log.WarnContext(ctx, "remove card from session queue",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", updatedCard.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	s.metrics.reviewed(input.Grade, durationMs)
+
 	s.log.InfoContext(ctx, "card reviewed",
 		slog.String("user_id", userID.String()),
 		slog.String("card_id", input.CardID.String()),
@@ -19,28 +19,41 @@ type cardRepo interface {
 	GetByID(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
 	GetByIDForUpdate(ctx context.Context, userID, cardID uuid.UUID) (*domain.Card, error)
 	GetByEntryID(ctx context.Context, userID, entryID uuid.UUID) (*domain.Card, error)
-	Create(ctx context.Context, userID, entryID uuid.UUID) (*domain.Card, error)
+	CreateWithParams(ctx context.Context, userID, entryID uuid.UUID, stability, difficulty float64) (*domain.Card, error)
+	BatchCreate(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error)
 	UpdateSRS(ctx context.Context, userID, cardID uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error)
 	Delete(ctx context.Context, userID, cardID uuid.UUID) error
-	GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int) ([]*domain.Card, error)
-	GetNewCards(ctx context.Context, userID uuid.UUID, limit int) ([]*domain.Card, error)
+	GetDueCards(ctx context.Context, userID uuid.UUID, now time.Time, limit int, order domain.DueCardOrder) ([]*domain.Card, error)
+	GetNewCards(ctx context.Context, userID uuid.UUID, limit int, order domain.NewCardOrder) ([]*domain.Card, error)
+	GetLearningDue(ctx context.Context, userID uuid.UUID, before time.Time, limit int) ([]*domain.Card, error)
 	CountByStatus(ctx context.Context, userID uuid.UUID) (domain.CardStatusCounts, error)
 	CountDue(ctx context.Context, userID uuid.UUID, now time.Time) (int, error)
 	CountNew(ctx context.Context, userID uuid.UUID) (int, error)
 	CountOverdue(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error)
 	ExistsByEntryIDs(ctx context.Context, userID uuid.UUID, entryIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	StudyableByIDs(ctx context.Context, userID uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	GetStabilityHistogram(ctx context.Context, userID uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error)
+	IncrementSkipCount(ctx context.Context, userID, cardID uuid.UUID) (int, error)
+	SetSuspended(ctx context.Context, userID, cardID uuid.UUID, suspended bool) (*domain.Card, error)
 }
 
 type reviewLogRepo interface {
 	Create(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error)
 	GetByCardID(ctx context.Context, cardID uuid.UUID, limit, offset int) ([]*domain.ReviewLog, int, error)
+	GetByCardIDCursor(ctx context.Context, cardID uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error)
 	GetLastByCardID(ctx context.Context, cardID uuid.UUID) (*domain.ReviewLog, error)
+	GetByIdempotencyKey(ctx context.Context, cardID uuid.UUID, key string) (*domain.ReviewLog, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	CountToday(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error)
 	CountNewToday(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error)
 	GetStreakDays(ctx context.Context, userID uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error)
+	GetActiveDays(ctx context.Context, userID uuid.UUID, since time.Time, timezone string) ([]time.Time, error)
+	GetGradeCountsByDay(ctx context.Context, userID uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error)
 	GetByPeriod(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*domain.ReviewLog, error)
+	GetByPeriodPage(ctx context.Context, userID uuid.UUID, from, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error)
 	GetStatsByCardID(ctx context.Context, cardID uuid.UUID) (domain.ReviewLogAggregation, error)
+	GetUserAggregation(ctx context.Context, userID uuid.UUID, since time.Time) (domain.UserReviewAggregation, error)
+	GetDifficultWords(ctx context.Context, userID uuid.UUID, minReviews, limit int) ([]domain.DifficultWord, error)
 }
 
 type sessionRepo interface {
@@ -50,12 +63,17 @@ type sessionRepo interface {
 	Finish(ctx context.Context, userID, sessionID uuid.UUID, result domain.SessionResult) (*domain.StudySession, error)
 	Abandon(ctx context.Context, userID, sessionID uuid.UUID) error
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.StudySession, int, error)
+	RemoveFromQueue(ctx context.Context, userID, cardID uuid.UUID) error
+	ReturnToQueue(ctx context.Context, userID, cardID uuid.UUID) error
+	MoveToQueueEnd(ctx context.Context, userID, cardID uuid.UUID) error
 }
 
 type entryRepo interface {
 	GetByID(ctx context.Context, userID, entryID uuid.UUID) (*domain.Entry, error)
 	GetByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) ([]domain.Entry, error)
 	ExistByIDs(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+	GetFrequencyRank(ctx context.Context, userID, entryID uuid.UUID) (*int, error)
+	GetFrequencyRanks(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error)
 }
 
 type senseRepo interface {
@@ -103,6 +121,7 @@ type Service struct {
 	log         *slog.Logger
 	srsConfig   domain.SRSConfig
 	fsrsWeights [19]float64
+	metrics     *Metrics
 }
 
 // NewService creates a new Study service.
@@ -139,3 +158,8 @@ func NewService(
 		fsrsWeights: fsrsWeights,
 	}, nil
 }
+
+// SetMetrics injects the optional metrics recorder.
+func (s *Service) SetMetrics(m *Metrics) {
+	s.metrics = m
+}
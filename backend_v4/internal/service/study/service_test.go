@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/internal/service/study/fsrs"
 	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+	"github.com/heartmarshall/myenglish-backend/pkg/metrics"
 )
 
 // ---------------------------------------------------------------------------
@@ -65,7 +69,7 @@ func TestService_GetStudyQueue_Success_MixOfDueAndNew(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			if uid != userID {
 				t.Errorf("unexpected userID: got %v, want %v", uid, userID)
 			}
@@ -74,7 +78,7 @@ func TestService_GetStudyQueue_Success_MixOfDueAndNew(t *testing.T) {
 			}
 			return []*domain.Card{dueCard1, dueCard2}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			if uid != userID {
 				t.Errorf("unexpected userID: got %v, want %v", uid, userID)
 			}
@@ -128,6 +132,285 @@ func TestService_GetStudyQueue_Success_MixOfDueAndNew(t *testing.T) {
 	}
 }
 
+func TestService_GetStudyQueue_PassesNewCardOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range []domain.NewCardOrder{
+		domain.NewCardOrderAdded, domain.NewCardOrderRandom, domain.NewCardOrderFrequency,
+	} {
+		t.Run(string(order), func(t *testing.T) {
+			t.Parallel()
+
+			userID := uuid.New()
+			mockCards := &cardRepoMock{
+				GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+					return nil, nil
+				},
+				GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+					return nil, nil
+				},
+			}
+			svc := &Service{
+				cards: mockCards,
+				reviews: &reviewLogRepoMock{
+					CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+						return 0, nil
+					},
+				},
+				settings: &settingsRepoMock{
+					GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+						s := domain.DefaultUserSettings(uid)
+						s.NewCardOrder = order
+						return &s, nil
+					},
+				},
+				log:   slog.Default(),
+				clock: RealClock{},
+			}
+
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			if _, err := svc.GetStudyQueue(ctx, GetQueueInput{Limit: 10}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			calls := mockCards.GetNewCardsCalls()
+			if len(calls) != 1 {
+				t.Fatalf("GetNewCards calls: got %d, want 1", len(calls))
+			}
+			if calls[0].Order != order {
+				t.Errorf("order: got %q, want %q", calls[0].Order, order)
+			}
+		})
+	}
+}
+
+func TestService_GetStudyQueue_PassesDueCardOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, order := range []domain.DueCardOrder{
+		domain.DueCardOrderDueDate, domain.DueCardOrderRandom, domain.DueCardOrderAdded,
+	} {
+		t.Run(string(order), func(t *testing.T) {
+			t.Parallel()
+
+			userID := uuid.New()
+			mockCards := &cardRepoMock{
+				GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+					return nil, nil
+				},
+				GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+					return nil, nil
+				},
+			}
+			svc := &Service{
+				cards: mockCards,
+				reviews: &reviewLogRepoMock{
+					CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+						return 0, nil
+					},
+				},
+				settings: &settingsRepoMock{
+					GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+						s := domain.DefaultUserSettings(uid)
+						s.DueCardOrder = order
+						return &s, nil
+					},
+				},
+				log:   slog.Default(),
+				clock: RealClock{},
+			}
+
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			if _, err := svc.GetStudyQueue(ctx, GetQueueInput{Limit: 10}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			calls := mockCards.GetDueCardsCalls()
+			if len(calls) != 1 {
+				t.Fatalf("GetDueCards calls: got %d, want 1", len(calls))
+			}
+			if calls[0].Order != order {
+				t.Errorf("order: got %q, want %q", calls[0].Order, order)
+			}
+		})
+	}
+}
+
+func TestService_GetStudyQueue_InterleaveNew(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	due := make([]*domain.Card, 10)
+	for i := range due {
+		due[i] = &domain.Card{ID: uuid.New(), State: domain.CardStateReview}
+	}
+	newCards := make([]*domain.Card, 3)
+	for i := range newCards {
+		newCards[i] = &domain.Card{ID: uuid.New(), State: domain.CardStateNew}
+	}
+
+	svc := &Service{
+		cards: &cardRepoMock{
+			GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+				return due, nil
+			},
+			GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+				return newCards, nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+				return 0, nil
+			},
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				s := domain.DefaultUserSettings(uid)
+				s.InterleaveNew = true
+				return &s, nil
+			},
+		},
+		log:       slog.Default(),
+		clock:     RealClock{},
+		srsConfig: domain.SRSConfig{NewCardInterleaveRatio: 5},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	queue, err := svc.GetStudyQueue(ctx, GetQueueInput{Limit: 50})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Five due, one new, five due, one new, then the leftover new card.
+	want := slices.Concat(due[:5], newCards[:1], due[5:], newCards[1:])
+	if len(queue) != len(want) {
+		t.Fatalf("queue length: got %d, want %d", len(queue), len(want))
+	}
+	for i := range want {
+		if queue[i].ID != want[i].ID {
+			t.Errorf("queue[%d]: got %s card, want %s card", i, queue[i].State, want[i].State)
+		}
+	}
+}
+
+func TestInterleaveNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		due    int
+		new    int
+		ratio  int
+		layout string // D = due card, N = new card
+	}{
+		{"one new per five due", 10, 2, 5, "DDDDDNDDDDDN"},
+		{"leftover new cards go last", 5, 3, 5, "DDDDDNNN"},
+		{"fewer due than ratio", 3, 2, 5, "DDDNN"},
+		{"ratio one alternates", 3, 3, 1, "DNDNDN"},
+		{"no new cards", 4, 0, 2, "DDDD"},
+		{"no due cards", 0, 2, 5, "NN"},
+		{"zero ratio treated as one", 2, 2, 0, "DNDN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mk := func(n int, state domain.CardState) []*domain.Card {
+				cards := make([]*domain.Card, n)
+				for i := range cards {
+					cards[i] = &domain.Card{ID: uuid.New(), State: state}
+				}
+				return cards
+			}
+
+			queue := interleaveNew(mk(tt.due, domain.CardStateReview), mk(tt.new, domain.CardStateNew), tt.ratio)
+
+			var layout strings.Builder
+			for _, c := range queue {
+				if c.State == domain.CardStateNew {
+					layout.WriteByte('N')
+				} else {
+					layout.WriteByte('D')
+				}
+			}
+			if layout.String() != tt.layout {
+				t.Errorf("layout: got %s, want %s", layout.String(), tt.layout)
+			}
+		})
+	}
+}
+
+func TestService_GetStudyQueue_ExcludeCardIDs(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	due := []*domain.Card{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	fresh := []*domain.Card{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+	head := func(cards []*domain.Card, limit int) []*domain.Card {
+		return slices.Clone(cards[:min(limit, len(cards))])
+	}
+
+	mockCards := &cardRepoMock{
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+			return head(due, limit), nil
+		},
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+			return head(fresh, limit), nil
+		},
+	}
+	svc := &Service{
+		cards: mockCards,
+		reviews: &reviewLogRepoMock{
+			CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+				return 0, nil
+			},
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				s := domain.DefaultUserSettings(uid)
+				return &s, nil
+			},
+		},
+		log:   slog.Default(),
+		clock: RealClock{},
+	}
+
+	// Skip one due and one new card; the queue still fills to the limit.
+	skipped := []uuid.UUID{due[0].ID, fresh[0].ID}
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	queue, err := svc.GetStudyQueue(ctx, GetQueueInput{Limit: 4, ExcludeCardIDs: skipped})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []uuid.UUID{due[1].ID, due[2].ID, fresh[1].ID, fresh[2].ID}
+	got := make([]uuid.UUID, len(queue))
+	for i, c := range queue {
+		got[i] = c.ID
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("queue = %v, want %v", got, want)
+	}
+	for _, c := range queue {
+		if slices.Contains(skipped, c.ID) {
+			t.Errorf("excluded card %s reappeared", c.ID)
+		}
+	}
+	if calls := mockCards.GetDueCardsCalls(); calls[0].Limit != 6 {
+		t.Errorf("GetDueCards limit = %d, want 6 (limit + excluded)", calls[0].Limit)
+	}
+}
+
+func TestGetQueueInput_TooManyExcludeCardIDs(t *testing.T) {
+	t.Parallel()
+
+	input := GetQueueInput{ExcludeCardIDs: make([]uuid.UUID, maxExcludeCardIDs+1)}
+	if err := input.Validate(); !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("got %v, want ErrValidation", err)
+	}
+}
+
 func TestService_GetStudyQueue_NoUserID(t *testing.T) {
 	t.Parallel()
 
@@ -255,7 +538,7 @@ func TestService_GetStudyQueue_DueCardsError(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return nil, errors.New("due cards error")
 		},
 	}
@@ -309,10 +592,10 @@ func TestService_GetStudyQueue_DailyLimitReached(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{dueCard}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			t.Error("GetNewCards should not be called when limit reached")
 			return nil, nil
 		},
@@ -380,10 +663,10 @@ func TestService_GetStudyQueue_OnlyDueCards(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return dueCards, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			t.Error("GetNewCards should not be called when queue is full")
 			return nil, nil
 		},
@@ -507,6 +790,7 @@ func TestService_ReviewCard_Success_NewToLearning(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions: noopQueueSessions(),
 		cards:    mockCards,
 		reviews:  mockReviews,
 		settings: mockSettings,
@@ -628,6 +912,7 @@ func TestService_ReviewCard_Success_LearningToReview(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions: noopQueueSessions(),
 		cards:    mockCards,
 		reviews:  mockReviews,
 		settings: mockSettings,
@@ -739,6 +1024,7 @@ func TestService_ReviewCard_Success_ReviewIntervalIncrease(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions:    noopQueueSessions(),
 		cards:       mockCards,
 		reviews:     mockReviews,
 		settings:    mockSettings,
@@ -837,6 +1123,7 @@ func TestService_ReviewCard_ElapsedDaysComputedFromLastReview(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions:    noopQueueSessions(),
 		cards:       mockCards,
 		reviews:     mockReviews,
 		settings:    mockSettings,
@@ -878,6 +1165,151 @@ func TestService_ReviewCard_ElapsedDaysComputedFromLastReview(t *testing.T) {
 		t.Errorf("stability matches buggy value (%f), elapsed_days was likely not recomputed",
 			capturedParams.Stability)
 	}
+	if capturedParams.ElapsedDays != 7 {
+		t.Errorf("persisted ElapsedDays: got %d, want 7", capturedParams.ElapsedDays)
+	}
+}
+
+func TestService_ReviewCard_FirstReviewElapsedDaysZero(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	card := &domain.Card{
+		ID:          uuid.New(),
+		UserID:      userID,
+		State:       domain.CardStateNew,
+		ElapsedDays: 42, // garbage must be ignored
+	}
+
+	var capturedParams domain.SRSUpdateParams
+	svc := &Service{
+		sessions: noopQueueSessions(),
+		cards: &cardRepoMock{
+			GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+				return card, nil
+			},
+			UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+				capturedParams = params
+				return &domain.Card{ID: cid, State: params.State}, nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) { return log, nil },
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: uid, MaxIntervalDays: 365, DesiredRetention: 0.9}, nil
+			},
+		},
+		audit: &auditLoggerMock{
+			LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+		},
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:         slog.Default(),
+		clock:       RealClock{},
+		fsrsWeights: fsrs.DefaultWeights,
+		srsConfig: domain.SRSConfig{
+			LearningSteps: []time.Duration{1 * time.Minute, 10 * time.Minute},
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	if _, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: card.ID, Grade: domain.ReviewGradeGood}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capturedParams.ElapsedDays != 0 {
+		t.Errorf("persisted ElapsedDays: got %d, want 0 for a first review", capturedParams.ElapsedDays)
+	}
+}
+
+func TestService_ReviewCard_IncrementsGradeCounter(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	failUpdate := false
+
+	reg := metrics.NewRegistry()
+	m := NewMetrics(reg)
+	svc := &Service{
+		sessions: noopQueueSessions(),
+		cards: &cardRepoMock{
+			GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+				return &domain.Card{ID: cid, UserID: uid, State: domain.CardStateNew}, nil
+			},
+			UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+				if failUpdate {
+					return nil, errors.New("db down")
+				}
+				return &domain.Card{ID: cid, State: params.State}, nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) { return log, nil },
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: uid, MaxIntervalDays: 365, DesiredRetention: 0.9}, nil
+			},
+		},
+		audit: &auditLoggerMock{
+			LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+		},
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:         slog.Default(),
+		clock:       RealClock{},
+		fsrsWeights: fsrs.DefaultWeights,
+		srsConfig: domain.SRSConfig{
+			LearningSteps: []time.Duration{1 * time.Minute, 10 * time.Minute},
+		},
+	}
+	svc.SetMetrics(m)
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	review := func(grade domain.ReviewGrade, durationMs *int) error {
+		_, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: cardID, Grade: grade, DurationMs: durationMs})
+		return err
+	}
+
+	if err := review(domain.ReviewGradeGood, ptr(4000)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := review(domain.ReviewGradeGood, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := review(domain.ReviewGradeAgain, ptr(1500)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	failUpdate = true
+	if err := review(domain.ReviewGradeEasy, ptr(1000)); err == nil {
+		t.Fatal("expected error from failed update")
+	}
+
+	if got := m.reviews.With("GOOD").Value(); got != 2 {
+		t.Errorf("GOOD reviews: got %d, want 2", got)
+	}
+	if got := m.reviews.With("AGAIN").Value(); got != 1 {
+		t.Errorf("AGAIN reviews: got %d, want 1", got)
+	}
+	if got := m.reviews.With("EASY").Value(); got != 0 {
+		t.Errorf("EASY reviews: got %d, want 0 (failed review must not count)", got)
+	}
+	if got := m.reviewDuration.Count(); got != 2 {
+		t.Errorf("duration observations: got %d, want 2 (only reviews with DurationMs)", got)
+	}
+
+	var out strings.Builder
+	if err := reg.WriteText(&out); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	if !strings.Contains(out.String(), `myenglish_study_reviews_total{grade="GOOD"} 2`) {
+		t.Errorf("exposition missing GOOD counter:\n%s", out.String())
+	}
 }
 
 func TestService_ReviewCard_NoUserID(t *testing.T) {
@@ -1100,112 +1532,348 @@ func TestService_ReviewCard_UpdateSRSError_TxRollback(t *testing.T) {
 	}
 }
 
-func TestService_ReviewCard_CreateReviewLogError_TxRollback(t *testing.T) {
+func TestService_ReviewCard_ConcurrentUpdate_Conflict(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
 	cardID := uuid.New()
-
-	card := &domain.Card{
-		ID:           cardID,
-		State:        domain.CardStateNew,
-		Step:         0,
-		ScheduledDays: 0,
-		Stability:    2.5,
-	}
-
-	updatedCard := &domain.Card{
-		ID:           cardID,
-		State:        domain.CardStateLearning,
-		Step:         0,
-		ScheduledDays: 0,
-		Stability:    2.5,
-	}
-
-	settings := &domain.UserSettings{
-		UserID:          userID,
-		MaxIntervalDays: 365,
-	}
+	card := &domain.Card{ID: cardID, State: domain.CardStateNew, Version: 7}
 
 	mockCards := &cardRepoMock{
 		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
 			return card, nil
 		},
 		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
-			return updatedCard, nil
-		},
-	}
-
-	mockSettings := &settingsRepoMock{
-		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
-			return settings, nil
+			return nil, domain.ErrConflict
 		},
 	}
+	mockReviews := &reviewLogRepoMock{}
 
-	mockReviews := &reviewLogRepoMock{
-		CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) {
-			return nil, errors.New("create log error")
-		},
-	}
-
-	mockAudit := &auditLoggerMock{
-		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
-			t.Error("Audit should not be called after CreateLog error")
-			return nil
+	svc := &Service{
+		cards:   mockCards,
+		reviews: mockReviews,
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: userID, MaxIntervalDays: 365}, nil
+			},
 		},
-	}
-
-	mockTx := &txManagerMock{
-		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
-			return fn(ctx)
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
 		},
-	}
-
-	svc := &Service{
-		cards:    mockCards,
-		reviews:  mockReviews,
-		settings: mockSettings,
-		audit:    mockAudit,
-		tx:       mockTx,
-		log:      slog.Default(),
-		clock:    RealClock{},
+		log:   slog.Default(),
+		clock: RealClock{},
 		srsConfig: domain.SRSConfig{
-			LearningSteps:    []time.Duration{1 * time.Minute, 10 * time.Minute},
-			DefaultRetention: 0.9,
-			MaxIntervalDays:  365,
+			LearningSteps:     []time.Duration{1 * time.Minute, 10 * time.Minute},
+			DefaultRetention:  0.9,
+			MaxIntervalDays:   365,
 			UndoWindowMinutes: 15,
 		},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
-	input := ReviewCardInput{
-		CardID:     cardID,
-		Grade:      domain.ReviewGradeGood,
-		DurationMs: ptr(5000),
+	_, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: cardID, Grade: domain.ReviewGradeGood})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
 	}
 
-	_, err := svc.ReviewCard(ctx, input)
-	if err == nil {
-		t.Fatal("expected error, got nil")
+	calls := mockCards.UpdateSRSCalls()
+	if len(calls) != 1 || calls[0].Params.Version != card.Version {
+		t.Errorf("UpdateSRS should be conditioned on the version read with the card (%d), got %+v", card.Version, calls)
 	}
-
-	// Verify Audit was not called
-	if len(mockAudit.LogCalls()) != 0 {
-		t.Error("Audit should not be called after CreateLog error")
+	if len(mockReviews.CreateCalls()) != 0 {
+		t.Error("no review log should be written for a conflicting review")
 	}
 }
 
-func TestService_ReviewCard_AuditError_TxRollback(t *testing.T) {
+func TestService_ReviewCard_IdempotencyKey_AppliedOnce(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
 	cardID := uuid.New()
+	card := &domain.Card{ID: cardID, UserID: userID, State: domain.CardStateNew}
 
-	card := &domain.Card{
-		ID:           cardID,
-		State:        domain.CardStateNew,
-		Step:         0,
-		ScheduledDays: 0,
+	// Stateful fakes: the card and its review logs persist between calls.
+	logs := map[string]*domain.ReviewLog{}
+	mockCards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			c := *card
+			return &c, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+			card = &domain.Card{
+				ID: cid, UserID: uid, State: params.State, Step: params.Step,
+				Stability: params.Stability, Difficulty: params.Difficulty,
+				Due: params.Due, LastReview: params.LastReview, Reps: params.Reps,
+				Version: params.Version + 1,
+			}
+			c := *card
+			return &c, nil
+		},
+	}
+	mockReviews := &reviewLogRepoMock{
+		GetByIdempotencyKeyFunc: func(ctx context.Context, cid uuid.UUID, key string) (*domain.ReviewLog, error) {
+			if rl, ok := logs[key]; ok && rl.CardID == cid {
+				return rl, nil
+			}
+			return nil, domain.ErrNotFound
+		},
+		CreateFunc: func(ctx context.Context, rl *domain.ReviewLog) (*domain.ReviewLog, error) {
+			if rl.IdempotencyKey == nil || *rl.IdempotencyKey != "tap-1" {
+				t.Errorf("IdempotencyKey: got %v, want tap-1", rl.IdempotencyKey)
+			} else {
+				logs[*rl.IdempotencyKey] = rl
+			}
+			return rl, nil
+		},
+	}
+
+	svc := &Service{
+		sessions: noopQueueSessions(),
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: userID, MaxIntervalDays: 365}, nil
+			},
+		},
+		audit: &auditLoggerMock{
+			LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+		},
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		},
+		log:   slog.Default(),
+		clock: RealClock{},
+		srsConfig: domain.SRSConfig{
+			LearningSteps:     []time.Duration{1 * time.Minute, 10 * time.Minute},
+			DefaultRetention:  0.9,
+			MaxIntervalDays:   365,
+			UndoWindowMinutes: 15,
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := ReviewCardInput{CardID: cardID, Grade: domain.ReviewGradeGood, IdempotencyKey: "tap-1"}
+
+	first, err := svc.ReviewCard(ctx, input)
+	if err != nil {
+		t.Fatalf("first ReviewCard: %v", err)
+	}
+	second, err := svc.ReviewCard(ctx, input)
+	if err != nil {
+		t.Fatalf("retried ReviewCard: %v", err)
+	}
+
+	if len(mockReviews.CreateCalls()) != 1 || len(logs) != 1 {
+		t.Errorf("review logs: %d created, want 1", len(mockReviews.CreateCalls()))
+	}
+	if len(mockCards.UpdateSRSCalls()) != 1 {
+		t.Errorf("UpdateSRS calls: got %d, want 1", len(mockCards.UpdateSRSCalls()))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("retry result differs:\nfirst:  %+v\nsecond: %+v", first, second)
+	}
+	if first.State != domain.CardStateLearning || first.Version != 1 {
+		t.Errorf("card after review: state %s, version %d; want LEARNING at version 1", first.State, first.Version)
+	}
+}
+
+func TestService_ReviewCard_DurationBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		durationMs int
+		wantStored int
+		wantErr    bool
+	}{
+		{name: "negative", durationMs: -1, wantErr: true},
+		{name: "zero", durationMs: 0, wantStored: 0},
+		{name: "over cap clamped", durationMs: 900_000, wantStored: 600_000},
+		{name: "over a day", durationMs: 90_000_000, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			cardID := uuid.New()
+
+			mockReviews := &reviewLogRepoMock{
+				CreateFunc: func(ctx context.Context, rl *domain.ReviewLog) (*domain.ReviewLog, error) {
+					return rl, nil
+				},
+			}
+			svc := &Service{
+				sessions: noopQueueSessions(),
+				cards: &cardRepoMock{
+					GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+						return &domain.Card{ID: cid, State: domain.CardStateNew}, nil
+					},
+					UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+						return &domain.Card{ID: cid, State: params.State}, nil
+					},
+				},
+				reviews: mockReviews,
+				settings: &settingsRepoMock{
+					GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+						return &domain.UserSettings{UserID: userID, MaxIntervalDays: 365}, nil
+					},
+				},
+				audit: &auditLoggerMock{
+					LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+				},
+				tx: &txManagerMock{
+					RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					},
+				},
+				log:   slog.Default(),
+				clock: RealClock{},
+				srsConfig: domain.SRSConfig{
+					LearningSteps:     []time.Duration{1 * time.Minute, 10 * time.Minute},
+					DefaultRetention:  0.9,
+					MaxIntervalDays:   365,
+					UndoWindowMinutes: 15,
+				},
+			}
+
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			_, err := svc.ReviewCard(ctx, ReviewCardInput{
+				CardID:     cardID,
+				Grade:      domain.ReviewGradeGood,
+				DurationMs: ptr(tt.durationMs),
+			})
+
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrValidation) {
+					t.Errorf("error: got %v, want ErrValidation", err)
+				}
+				if len(mockReviews.CreateCalls()) != 0 {
+					t.Error("no review log should be written for an invalid duration")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			calls := mockReviews.CreateCalls()
+			if len(calls) != 1 || calls[0].Log.DurationMs == nil || *calls[0].Log.DurationMs != tt.wantStored {
+				t.Fatalf("stored duration: got %+v, want %d", calls, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestService_ReviewCard_CreateReviewLogError_TxRollback(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+
+	card := &domain.Card{
+		ID:           cardID,
+		State:        domain.CardStateNew,
+		Step:         0,
+		ScheduledDays: 0,
+		Stability:    2.5,
+	}
+
+	updatedCard := &domain.Card{
+		ID:           cardID,
+		State:        domain.CardStateLearning,
+		Step:         0,
+		ScheduledDays: 0,
+		Stability:    2.5,
+	}
+
+	settings := &domain.UserSettings{
+		UserID:          userID,
+		MaxIntervalDays: 365,
+	}
+
+	mockCards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return card, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+			return updatedCard, nil
+		},
+	}
+
+	mockSettings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			return settings, nil
+		},
+	}
+
+	mockReviews := &reviewLogRepoMock{
+		CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) {
+			return nil, errors.New("create log error")
+		},
+	}
+
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
+			t.Error("Audit should not be called after CreateLog error")
+			return nil
+		},
+	}
+
+	mockTx := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+
+	svc := &Service{
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: mockSettings,
+		audit:    mockAudit,
+		tx:       mockTx,
+		log:      slog.Default(),
+		clock:    RealClock{},
+		srsConfig: domain.SRSConfig{
+			LearningSteps:    []time.Duration{1 * time.Minute, 10 * time.Minute},
+			DefaultRetention: 0.9,
+			MaxIntervalDays:  365,
+			UndoWindowMinutes: 15,
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := ReviewCardInput{
+		CardID:     cardID,
+		Grade:      domain.ReviewGradeGood,
+		DurationMs: ptr(5000),
+	}
+
+	_, err := svc.ReviewCard(ctx, input)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// Verify Audit was not called
+	if len(mockAudit.LogCalls()) != 0 {
+		t.Error("Audit should not be called after CreateLog error")
+	}
+}
+
+func TestService_ReviewCard_AuditError_TxRollback(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+
+	card := &domain.Card{
+		ID:           cardID,
+		State:        domain.CardStateNew,
+		Step:         0,
+		ScheduledDays: 0,
 		Stability:    2.5,
 	}
 
@@ -1349,6 +2017,7 @@ func TestService_ReviewCard_Again_NewToLearning(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions: noopQueueSessions(),
 		cards:    mockCards,
 		reviews:  mockReviews,
 		settings: mockSettings,
@@ -1447,6 +2116,7 @@ func TestService_ReviewCard_Hard_ReviewStaysReview(t *testing.T) {
 	}
 
 	svc := &Service{
+		sessions: noopQueueSessions(),
 		cards:    mockCards,
 		reviews:  mockReviews,
 		settings: mockSettings,
@@ -1577,13 +2247,20 @@ func TestService_UndoReview_Success(t *testing.T) {
 		},
 	}
 
+	mockSessions := &sessionRepoMock{
+		ReturnToQueueFunc: func(ctx context.Context, uid, cid uuid.UUID) error {
+			return nil
+		},
+	}
+
 	svc := &Service{
-		cards:   mockCards,
-		reviews: mockReviews,
-		audit:   mockAudit,
-		tx:      mockTx,
-		log:     slog.Default(),
-		clock:   RealClock{},
+		cards:    mockCards,
+		reviews:  mockReviews,
+		sessions: mockSessions,
+		audit:    mockAudit,
+		tx:       mockTx,
+		log:      slog.Default(),
+		clock:    RealClock{},
 		srsConfig: domain.SRSConfig{
 			LearningSteps:    []time.Duration{1 * time.Minute, 10 * time.Minute},
 			DefaultRetention: 0.9,
@@ -1623,6 +2300,53 @@ func TestService_UndoReview_Success(t *testing.T) {
 	if len(mockAudit.LogCalls()) != 1 {
 		t.Errorf("Audit Log calls: got %d, want 1", len(mockAudit.LogCalls()))
 	}
+	if calls := mockSessions.ReturnToQueueCalls(); len(calls) != 1 || calls[0].UserID != userID || calls[0].CardID != cardID {
+		t.Errorf("ReturnToQueue calls: got %+v, want one for the undone card", calls)
+	}
+}
+
+func TestService_UndoReview_QueueErrorIgnored(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	now := time.Now()
+
+	svc := &Service{
+		cards: &cardRepoMock{
+			GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+				return &domain.Card{ID: cardID, State: domain.CardStateLearning}, nil
+			},
+			UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+				return &domain.Card{ID: cardID, State: params.State}, nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			GetLastByCardIDFunc: func(ctx context.Context, cid uuid.UUID) (*domain.ReviewLog, error) {
+				return &domain.ReviewLog{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeGood, PrevState: &domain.CardSnapshot{State: domain.CardStateNew}, ReviewedAt: now.Add(-time.Minute)}, nil
+			},
+			DeleteFunc: func(ctx context.Context, id uuid.UUID) error { return nil },
+		},
+		sessions: &sessionRepoMock{
+			ReturnToQueueFunc: func(ctx context.Context, uid, cid uuid.UUID) error {
+				return errors.New("db down")
+			},
+		},
+		audit: &auditLoggerMock{
+			LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+		},
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:       slog.Default(),
+		clock:     &clockMock{NowFunc: func() time.Time { return now }},
+		srsConfig: domain.SRSConfig{UndoWindowMinutes: 15},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	if _, err := svc.UndoReview(ctx, UndoReviewInput{CardID: cardID}); err != nil {
+		t.Fatalf("queue failure must not fail a committed undo: %v", err)
+	}
 }
 
 func TestService_UndoReview_NoUserID(t *testing.T) {
@@ -2174,6 +2898,41 @@ func TestService_UndoReview_AuditError_TxRollback(t *testing.T) {
 // Session Operations Tests (8 tests)
 // ---------------------------------------------------------------------------
 
+// emptyQueueMocks returns repo mocks describing an empty study queue, as read
+// by StartSession when it snapshots the queue of a new session.
+func emptyQueueMocks() (*cardRepoMock, *reviewLogRepoMock, *settingsRepoMock) {
+	cards := &cardRepoMock{
+		GetDueCardsFunc: func(ctx context.Context, userID uuid.UUID, now time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+			return []*domain.Card{}, nil
+		},
+		GetNewCardsFunc: func(ctx context.Context, userID uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+			return []*domain.Card{}, nil
+		},
+	}
+	reviews := &reviewLogRepoMock{
+		CountNewTodayFunc: func(ctx context.Context, userID uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+	}
+	settings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, userID uuid.UUID) (*domain.UserSettings, error) {
+			s := domain.DefaultUserSettings(userID)
+			return &s, nil
+		},
+	}
+	return cards, reviews, settings
+}
+
+// noopQueueSessions returns a session repo mock that accepts queue updates
+// made by ReviewCard.
+func noopQueueSessions() *sessionRepoMock {
+	return &sessionRepoMock{
+		RemoveFromQueueFunc: func(ctx context.Context, userID, cardID uuid.UUID) error {
+			return nil
+		},
+	}
+}
+
 func TestService_StartSession_Success_CreatesNew(t *testing.T) {
 	t.Parallel()
 
@@ -2197,8 +2956,13 @@ func TestService_StartSession_Success_CreatesNew(t *testing.T) {
 		},
 	}
 
+	mockCards, mockReviews, mockSettings := emptyQueueMocks()
+
 	svc := &Service{
 		sessions: mockSessions,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: mockSettings,
 		log:      slog.Default(),
 		clock:    RealClock{},
 	}
@@ -2582,39 +3346,148 @@ func TestService_FinishSession_NotFound(t *testing.T) {
 	}
 }
 
-func TestService_AbandonSession_Success(t *testing.T) {
+func TestService_GetSessionProgress_MatchesFinish(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
 	sessionID := uuid.New()
-	now := time.Now()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := now.Add(-20 * time.Minute)
+	session := &domain.StudySession{
+		ID: sessionID, UserID: userID, Status: domain.SessionStatusActive, StartedAt: startedAt,
+	}
 
-	activeSession := &domain.StudySession{
-		ID:        sessionID,
-		UserID:    userID,
-		Status:    domain.SessionStatusActive,
-		StartedAt: now.Add(-15 * time.Minute),
+	var logs []*domain.ReviewLog
+	review := func(grade domain.ReviewGrade, prev domain.CardState, ago time.Duration) {
+		logs = append(logs, &domain.ReviewLog{
+			ID: uuid.New(), CardID: uuid.New(), UserID: userID, Grade: grade,
+			PrevState: &domain.CardSnapshot{State: prev}, ReviewedAt: now.Add(-ago),
+		})
 	}
 
+	var finished domain.SessionResult
 	mockSessions := &sessionRepoMock{
 		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
-			if uid != userID {
-				t.Errorf("userID: got %v, want %v", uid, userID)
-			}
-			return activeSession, nil
+			return session, nil
 		},
-		AbandonFunc: func(ctx context.Context, uid, sid uuid.UUID) error {
-			if uid != userID || sid != sessionID {
-				t.Errorf("unexpected IDs: got (%v, %v), want (%v, %v)", uid, sid, userID, sessionID)
+		FinishFunc: func(ctx context.Context, uid, sid uuid.UUID, result domain.SessionResult) (*domain.StudySession, error) {
+			finished = result
+			return &domain.StudySession{ID: sid, Status: domain.SessionStatusFinished, Result: &result}, nil
+		},
+	}
+	mockReviews := &reviewLogRepoMock{
+		GetByPeriodFunc: func(ctx context.Context, uid uuid.UUID, from, to time.Time) ([]*domain.ReviewLog, error) {
+			if !from.Equal(startedAt) || !to.Equal(now) {
+				t.Errorf("period: got %v..%v, want %v..%v", from, to, startedAt, now)
 			}
-			return nil
+			return logs, nil
 		},
 	}
 
 	svc := &Service{
 		sessions: mockSessions,
-		log:      slog.Default(),
-		clock:    RealClock{},
+		reviews:  mockReviews,
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			},
+		},
+		log:   slog.Default(),
+		clock: &clockMock{NowFunc: func() time.Time { return now }},
+	}
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	empty, err := svc.GetSessionProgress(ctx)
+	if err != nil {
+		t.Fatalf("GetSessionProgress: %v", err)
+	}
+	if empty == nil || empty.TotalReviewed != 0 || empty.DurationMs != 20*60*1000 {
+		t.Errorf("progress before any review = %+v, want 0 reviewed over 20 minutes", empty)
+	}
+
+	review(domain.ReviewGradeGood, domain.CardStateNew, 15*time.Minute)
+	review(domain.ReviewGradeAgain, domain.CardStateReview, 10*time.Minute)
+	review(domain.ReviewGradeEasy, domain.CardStateReview, 5*time.Minute)
+
+	progress, err := svc.GetSessionProgress(ctx)
+	if err != nil {
+		t.Fatalf("GetSessionProgress: %v", err)
+	}
+	if progress.TotalReviewed != 3 || progress.NewReviewed != 1 || progress.DueReviewed != 2 {
+		t.Errorf("progress counts = %+v, want 3 reviewed (1 new, 2 due)", progress)
+	}
+	if progress.GradeCounts != (domain.GradeCounts{Again: 1, Good: 1, Easy: 1}) {
+		t.Errorf("grade counts = %+v", progress.GradeCounts)
+	}
+	if len(mockSessions.FinishCalls()) != 0 {
+		t.Error("GetSessionProgress must not finish the session")
+	}
+
+	if _, err := svc.FinishActiveSession(ctx); err != nil {
+		t.Fatalf("FinishActiveSession: %v", err)
+	}
+	if *progress != finished {
+		t.Errorf("progress %+v differs from finished result %+v", *progress, finished)
+	}
+}
+
+func TestService_GetSessionProgress_NoActiveSession(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		sessions: &sessionRepoMock{
+			GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+				return nil, domain.ErrNotFound
+			},
+		},
+		reviews: &reviewLogRepoMock{},
+		log:     slog.Default(),
+		clock:   RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	progress, err := svc.GetSessionProgress(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if progress != nil {
+		t.Errorf("progress without an active session = %+v, want nil", progress)
+	}
+}
+
+func TestService_AbandonSession_Success(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	now := time.Now()
+
+	activeSession := &domain.StudySession{
+		ID:        sessionID,
+		UserID:    userID,
+		Status:    domain.SessionStatusActive,
+		StartedAt: now.Add(-15 * time.Minute),
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			if uid != userID {
+				t.Errorf("userID: got %v, want %v", uid, userID)
+			}
+			return activeSession, nil
+		},
+		AbandonFunc: func(ctx context.Context, uid, sid uuid.UUID) error {
+			if uid != userID || sid != sessionID {
+				t.Errorf("unexpected IDs: got (%v, %v), want (%v, %v)", uid, sid, userID, sessionID)
+			}
+			return nil
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		log:      slog.Default(),
+		clock:    RealClock{},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
@@ -2671,7 +3544,7 @@ func TestService_AbandonSession_NoActive_IdempotentNoop(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// CreateCard Tests (6 tests)
+// CreateCard Tests (7 tests)
 // ---------------------------------------------------------------------------
 
 func TestService_CreateCard_Success(t *testing.T) {
@@ -2705,6 +3578,9 @@ func TestService_CreateCard_Success(t *testing.T) {
 			}
 			return entry, nil
 		},
+		GetFrequencyRankFunc: func(ctx context.Context, uid, eid uuid.UUID) (*int, error) {
+			return nil, nil
+		},
 	}
 
 	mockSenses := &senseRepoMock{
@@ -2717,7 +3593,7 @@ func TestService_CreateCard_Success(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
+		CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
 			if uid != userID {
 				t.Errorf("userID: got %v, want %v", uid, userID)
 			}
@@ -2781,8 +3657,8 @@ func TestService_CreateCard_Success(t *testing.T) {
 	if len(mockSenses.CountByEntryIDCalls()) != 1 {
 		t.Errorf("CountByEntryID calls: got %d, want 1", len(mockSenses.CountByEntryIDCalls()))
 	}
-	if len(mockCards.CreateCalls()) != 1 {
-		t.Errorf("Create calls: got %d, want 1", len(mockCards.CreateCalls()))
+	if len(mockCards.CreateWithParamsCalls()) != 1 {
+		t.Errorf("Create calls: got %d, want 1", len(mockCards.CreateWithParamsCalls()))
 	}
 	if len(mockAudit.LogCalls()) != 1 {
 		t.Errorf("Audit Log calls: got %d, want 1", len(mockAudit.LogCalls()))
@@ -2881,6 +3757,9 @@ func TestService_CreateCard_CardAlreadyExists(t *testing.T) {
 		GetByIDFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
 			return entry, nil
 		},
+		GetFrequencyRankFunc: func(ctx context.Context, uid, eid uuid.UUID) (*int, error) {
+			return nil, nil
+		},
 	}
 
 	mockSenses := &senseRepoMock{
@@ -2890,7 +3769,7 @@ func TestService_CreateCard_CardAlreadyExists(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
+		CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
 			return nil, domain.ErrAlreadyExists
 		},
 	}
@@ -2948,6 +3827,9 @@ func TestService_CreateCard_TransactionRollback_AuditError(t *testing.T) {
 		GetByIDFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
 			return entry, nil
 		},
+		GetFrequencyRankFunc: func(ctx context.Context, uid, eid uuid.UUID) (*int, error) {
+			return nil, nil
+		},
 	}
 
 	mockSenses := &senseRepoMock{
@@ -2957,7 +3839,7 @@ func TestService_CreateCard_TransactionRollback_AuditError(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
+		CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
 			return createdCard, nil
 		},
 	}
@@ -3013,6 +3895,78 @@ func TestService_CreateCard_NoUserID(t *testing.T) {
 	}
 }
 
+func TestService_CreateCard_FrequencyPresets(t *testing.T) {
+	t.Parallel()
+
+	presets := domain.CardPresets{FrequentMaxRank: 2000, FrequentStability: 4.5, FrequentDifficulty: 3}
+	rank := func(v int) *int { return &v }
+
+	tests := []struct {
+		name          string
+		rank          *int
+		wantStability float64
+		wantDiff      float64
+	}{
+		{"top frequency word gets the preset", rank(15), 4.5, 3},
+		{"rank at the boundary gets the preset", rank(2000), 4.5, 3},
+		{"rare word keeps defaults", rank(2001), 0, 0},
+		{"no frequency data keeps defaults", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userID := uuid.New()
+			entryID := uuid.New()
+
+			mockCards := &cardRepoMock{
+				CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+					return &domain.Card{ID: uuid.New(), EntryID: eid, State: domain.CardStateNew, Stability: stability, Difficulty: difficulty}, nil
+				},
+			}
+
+			svc := &Service{
+				entries: &entryRepoMock{
+					GetByIDFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
+						return &domain.Entry{ID: eid, UserID: uid}, nil
+					},
+					GetFrequencyRankFunc: func(ctx context.Context, uid, eid uuid.UUID) (*int, error) {
+						return tt.rank, nil
+					},
+				},
+				senses: &senseRepoMock{
+					CountByEntryIDFunc: func(ctx context.Context, eid uuid.UUID) (int, error) { return 1, nil },
+				},
+				cards: mockCards,
+				audit: &auditLoggerMock{
+					LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+				},
+				tx: &txManagerMock{
+					RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				},
+				log:       slog.Default(),
+				clock:     RealClock{},
+				srsConfig: domain.SRSConfig{DefaultRetention: 0.9, CardPresets: presets},
+			}
+
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			if _, err := svc.CreateCard(ctx, CreateCardInput{EntryID: entryID}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			calls := mockCards.CreateWithParamsCalls()
+			if len(calls) != 1 {
+				t.Fatalf("CreateWithParams calls: got %d, want 1", len(calls))
+			}
+			if calls[0].Stability != tt.wantStability || calls[0].Difficulty != tt.wantDiff {
+				t.Errorf("starting state: got (%v, %v), want (%v, %v)",
+					calls[0].Stability, calls[0].Difficulty, tt.wantStability, tt.wantDiff)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // DeleteCard Tests (4 tests)
 // ---------------------------------------------------------------------------
@@ -3192,7 +4146,7 @@ func TestService_DeleteCard_NoUserID(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// BatchCreateCards Tests (6 tests)
+// BatchCreateCards Tests (8 tests)
 // ---------------------------------------------------------------------------
 
 func TestService_BatchCreateCards_Success_AllCreated(t *testing.T) {
@@ -3218,8 +4172,8 @@ func TestService_BatchCreateCards_Success_AllCreated(t *testing.T) {
 				entryID2: false,
 			}, nil
 		},
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
-			return &domain.Card{UserID: uid, EntryID: eid, State: domain.CardStateNew, Stability: 0}, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
@@ -3305,8 +4259,8 @@ func TestService_BatchCreateCards_SomeEntriesNotExist(t *testing.T) {
 				entryID3: false,
 			}, nil
 		},
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
-			return &domain.Card{UserID: uid, EntryID: eid, State: domain.CardStateNew, Stability: 0}, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
@@ -3386,8 +4340,8 @@ func TestService_BatchCreateCards_SomeEntriesNoSenses(t *testing.T) {
 				entryID2: false,
 			}, nil
 		},
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
-			return &domain.Card{UserID: uid, EntryID: eid, State: domain.CardStateNew, Stability: 0}, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
@@ -3441,6 +4395,84 @@ func TestService_BatchCreateCards_SomeEntriesNoSenses(t *testing.T) {
 	}
 }
 
+func TestService_BatchCreateCards_SingleSenseCountQuery(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	entryIDs := make([]uuid.UUID, 6)
+	for i := range entryIDs {
+		entryIDs[i] = uuid.New()
+	}
+	// Entries 1 and 4 have no senses (4 is absent from the map).
+	counts := map[uuid.UUID]int{
+		entryIDs[0]: 2, entryIDs[1]: 0, entryIDs[2]: 1,
+		entryIDs[3]: 5, entryIDs[5]: 1,
+	}
+
+	mockEntries := &entryRepoMock{
+		ExistByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			m := make(map[uuid.UUID]bool, len(ids))
+			for _, id := range ids {
+				m[id] = true
+			}
+			return m, nil
+		},
+	}
+	mockCards := &cardRepoMock{
+		ExistsByEntryIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{}, nil
+		},
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
+		},
+	}
+	mockSenses := &senseRepoMock{
+		CountByEntryIDsFunc: func(ctx context.Context, eids []uuid.UUID) (map[uuid.UUID]int, error) {
+			return counts, nil
+		},
+		CountByEntryIDFunc: func(ctx context.Context, entryID uuid.UUID) (int, error) {
+			t.Error("BatchCreateCards must not count senses per entry")
+			return counts[entryID], nil
+		},
+	}
+
+	svc := &Service{
+		entries: mockEntries,
+		cards:   mockCards,
+		senses:  mockSenses,
+		audit:   &auditLoggerMock{LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil }},
+		tx: &txManagerMock{RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}},
+		log:   slog.Default(),
+		clock: RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	result, err := svc.BatchCreateCards(ctx, BatchCreateCardsInput{EntryIDs: entryIDs})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := len(mockSenses.CountByEntryIDsCalls()); got != 1 {
+		t.Errorf("CountByEntryIDs calls: got %d, want 1", got)
+	}
+
+	// Same outcome as counting each entry individually.
+	wantSkipped := 0
+	for _, id := range entryIDs {
+		if counts[id] == 0 {
+			wantSkipped++
+		}
+	}
+	if result.SkippedNoSenses != wantSkipped {
+		t.Errorf("SkippedNoSenses: got %d, want %d", result.SkippedNoSenses, wantSkipped)
+	}
+	if result.Created != len(entryIDs)-wantSkipped {
+		t.Errorf("Created: got %d, want %d", result.Created, len(entryIDs)-wantSkipped)
+	}
+}
+
 func TestService_BatchCreateCards_SomeEntriesAlreadyHaveCards(t *testing.T) {
 	t.Parallel()
 
@@ -3464,8 +4496,8 @@ func TestService_BatchCreateCards_SomeEntriesAlreadyHaveCards(t *testing.T) {
 				entryID2: false, // No card yet
 			}, nil
 		},
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
-			return &domain.Card{UserID: uid, EntryID: eid, State: domain.CardStateNew, Stability: 0}, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
@@ -3548,8 +4580,8 @@ func TestService_BatchCreateCards_MixedScenario(t *testing.T) {
 				entryID4: false, // No card
 			}, nil
 		},
-		CreateFunc: func(ctx context.Context, uid, eid uuid.UUID) (*domain.Card, error) {
-			return &domain.Card{UserID: uid, EntryID: eid, State: domain.CardStateNew, Stability: 0}, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
@@ -3640,225 +4672,395 @@ func TestService_BatchCreateCards_ValidationError(t *testing.T) {
 	}
 }
 
-// ---------------------------------------------------------------------------
-// GetDashboard Tests (7 tests)
-// ---------------------------------------------------------------------------
-
-func TestService_GetDashboard_Success_AllCountersCorrect(t *testing.T) {
+func TestService_BatchCreateCards_SingleInsertAndSummaryAudit(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	sessionID := uuid.New()
-
-	settings := &domain.UserSettings{
-		UserID:   userID,
-		Timezone: "UTC",
-	}
-
-	statusCounts := domain.CardStatusCounts{
-		New:      10,
-		Learning: 5,
-		Review:   20,
-		Relearning: 15,
-		Total:    50,
-	}
-
-	// Use current date for streak data (use UTC to match service)
-	now := time.Now().UTC()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-	streakDays := []domain.DayReviewCount{
-		{Date: today, Count: 5},
-		{Date: today.AddDate(0, 0, -1), Count: 3},
-		{Date: today.AddDate(0, 0, -2), Count: 7},
-	}
-
-	activeSession := &domain.StudySession{
-		ID:     sessionID,
-		UserID: userID,
-		Status: domain.SessionStatusActive,
-	}
+	entryID1 := uuid.New()
+	entryID2 := uuid.New()
+	entryID3 := uuid.New()
+	entryID4 := uuid.New()
+	entryID5 := uuid.New()
 
-	mockSettings := &settingsRepoMock{
-		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
-			return settings, nil
+	mockEntries := &entryRepoMock{
+		ExistByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{
+				entryID1: true,
+				entryID2: false, // Not exist
+				entryID3: true,
+				entryID4: true,
+				entryID5: true,
+			}, nil
 		},
 	}
 
 	mockCards := &cardRepoMock{
-		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
-			return 8, nil
-		},
-		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
-			return 10, nil
-		},
-		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
-			return statusCounts, nil
+		ExistsByEntryIDsFunc: func(ctx context.Context, uid uuid.UUID, entryIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{entryID1: true}, nil
 		},
-		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 3, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return newCardsForEntries(uid, eids), nil
 		},
 	}
 
-	mockReviews := &reviewLogRepoMock{
-		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 5, nil
-		},
-		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 2, nil
+	mockSenses := &senseRepoMock{
+		CountByEntryIDsFunc: func(ctx context.Context, eids []uuid.UUID) (map[uuid.UUID]int, error) {
+			return map[uuid.UUID]int{
+				entryID3: 1,
+				entryID5: 2,
+				// entryID4 absent = 0 senses
+			}, nil
 		},
-		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-			return streakDays, nil
+	}
+
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
+			return nil
 		},
 	}
 
-	mockSessions := &sessionRepoMock{
-		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
-			return activeSession, nil
+	mockTx := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
 		},
 	}
 
 	svc := &Service{
-		settings: mockSettings,
-		cards:    mockCards,
-		reviews:  mockReviews,
-		sessions: mockSessions,
-		log:      slog.Default(),
-		clock:    RealClock{},
+		entries: mockEntries,
+		cards:   mockCards,
+		senses:  mockSenses,
+		audit:   mockAudit,
+		tx:      mockTx,
+		log:     slog.Default(),
+		clock:   RealClock{},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := BatchCreateCardsInput{EntryIDs: []uuid.UUID{entryID1, entryID2, entryID3, entryID4, entryID5}}
 
-	dashboard, err := svc.GetDashboard(ctx)
+	result, err := svc.BatchCreateCards(ctx, input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if dashboard.DueCount != 8 {
-		t.Errorf("DueCount: got %d, want 8", dashboard.DueCount)
+	if result.Created != 2 {
+		t.Errorf("Created: got %d, want 2", result.Created)
 	}
-	if dashboard.NewCount != 10 {
-		t.Errorf("NewCount: got %d, want 10", dashboard.NewCount)
+	if result.SkippedExisting != 1 {
+		t.Errorf("SkippedExisting: got %d, want 1", result.SkippedExisting)
 	}
-	if dashboard.ReviewedToday != 5 {
-		t.Errorf("ReviewedToday: got %d, want 5", dashboard.ReviewedToday)
+	if result.SkippedNoSenses != 1 {
+		t.Errorf("SkippedNoSenses: got %d, want 1", result.SkippedNoSenses)
 	}
-	if dashboard.NewToday != 2 {
-		t.Errorf("NewToday: got %d, want 2", dashboard.NewToday)
+	if len(result.Errors) != 1 {
+		t.Errorf("Errors: got %d, want 1", len(result.Errors))
 	}
-	if dashboard.Streak != 3 {
-		t.Errorf("Streak: got %d, want 3", dashboard.Streak)
+
+	batchCalls := mockCards.BatchCreateCalls()
+	if len(batchCalls) != 1 {
+		t.Fatalf("BatchCreate calls: got %d, want 1", len(batchCalls))
 	}
-	if dashboard.StatusCounts.Total != 50 {
-		t.Errorf("StatusCounts.Total: got %d, want 50", dashboard.StatusCounts.Total)
+	if len(batchCalls[0].EntryIDs) != 2 {
+		t.Errorf("BatchCreate entry IDs: got %d, want 2", len(batchCalls[0].EntryIDs))
 	}
-	if dashboard.ActiveSession == nil {
-		t.Error("ActiveSession should not be nil")
-	} else if dashboard.ActiveSession.ID != sessionID {
-		t.Errorf("ActiveSession.ID: got %v, want %v", dashboard.ActiveSession.ID, sessionID)
+	if len(mockCards.CreateWithParamsCalls()) != 0 {
+		t.Errorf("CreateWithParams calls: got %d, want 0", len(mockCards.CreateWithParamsCalls()))
+	}
+
+	auditCalls := mockAudit.LogCalls()
+	if len(auditCalls) != 1 {
+		t.Fatalf("audit calls: got %d, want 1", len(auditCalls))
+	}
+	if auditCalls[0].Record.EntityID != nil {
+		t.Errorf("summary audit EntityID: got %v, want nil", auditCalls[0].Record.EntityID)
 	}
 }
 
-func TestService_GetDashboard_NoCards_AllZeros(t *testing.T) {
+func TestService_BatchCreateCards_ConcurrentlyCreatedCountedAsExisting(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
+	entryID1 := uuid.New()
+	entryID2 := uuid.New()
 
-	settings := &domain.UserSettings{
-		UserID:   userID,
-		Timezone: "UTC",
+	mockEntries := &entryRepoMock{
+		ExistByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{entryID1: true, entryID2: true}, nil
+		},
 	}
 
-	mockSettings := &settingsRepoMock{
-		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
-			return settings, nil
+	mockCards := &cardRepoMock{
+		ExistsByEntryIDsFunc: func(ctx context.Context, uid uuid.UUID, entryIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{}, nil
+		},
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			// entryID2 got a card between the check and the insert.
+			return newCardsForEntries(uid, []uuid.UUID{entryID1}), nil
 		},
 	}
 
-	mockCards := &cardRepoMock{
-		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
-			return 0, nil
+	mockSenses := &senseRepoMock{
+		CountByEntryIDsFunc: func(ctx context.Context, eids []uuid.UUID) (map[uuid.UUID]int, error) {
+			return map[uuid.UUID]int{entryID1: 1, entryID2: 1}, nil
 		},
-		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
-			return 0, nil
+	}
+
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
+			return nil
 		},
-		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
-			return domain.CardStatusCounts{}, nil
+	}
+
+	mockTx := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
 		},
-		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+	}
+
+	svc := &Service{
+		entries: mockEntries,
+		cards:   mockCards,
+		senses:  mockSenses,
+		audit:   mockAudit,
+		tx:      mockTx,
+		log:     slog.Default(),
+		clock:   RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := BatchCreateCardsInput{EntryIDs: []uuid.UUID{entryID1, entryID2}}
+
+	result, err := svc.BatchCreateCards(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Created != 1 {
+		t.Errorf("Created: got %d, want 1", result.Created)
+	}
+	if result.SkippedExisting != 1 {
+		t.Errorf("SkippedExisting: got %d, want 1", result.SkippedExisting)
+	}
+}
+
+func TestService_BatchCreateCards_OneEntryFailsOthersCommit(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	entryID1 := uuid.New()
+	entryID2 := uuid.New()
+	entryID3 := uuid.New()
+	entryID4 := uuid.New()
+	dbErr := errors.New("foreign key violation")
+
+	mockEntries := &entryRepoMock{
+		ExistByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{entryID1: true, entryID2: true, entryID3: true, entryID4: true}, nil
 		},
 	}
 
-	mockReviews := &reviewLogRepoMock{
-		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+	mockCards := &cardRepoMock{
+		ExistsByEntryIDsFunc: func(ctx context.Context, uid uuid.UUID, entryIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{}, nil
 		},
-		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+		BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+			return nil, dbErr
 		},
-		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-			return []domain.DayReviewCount{}, nil
+		CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+			switch eid {
+			case entryID2:
+				return nil, dbErr
+			case entryID4:
+				return nil, domain.ErrAlreadyExists
+			}
+			return &domain.Card{ID: uuid.New(), UserID: uid, EntryID: eid, State: domain.CardStateNew}, nil
 		},
 	}
 
-	mockSessions := &sessionRepoMock{
-		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
-			return nil, domain.ErrNotFound
+	mockSenses := &senseRepoMock{
+		CountByEntryIDsFunc: func(ctx context.Context, eids []uuid.UUID) (map[uuid.UUID]int, error) {
+			return map[uuid.UUID]int{entryID1: 1, entryID2: 1, entryID3: 1, entryID4: 1}, nil
+		},
+	}
+
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
+			return nil
+		},
+	}
+
+	// Each transaction commits only when fn succeeds.
+	var committed int
+	mockTx := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			committed++
+			return nil
 		},
 	}
 
 	svc := &Service{
-		settings: mockSettings,
-		cards:    mockCards,
-		reviews:  mockReviews,
-		sessions: mockSessions,
-		log:      slog.Default(),
-		clock:    RealClock{},
+		entries: mockEntries,
+		cards:   mockCards,
+		senses:  mockSenses,
+		audit:   mockAudit,
+		tx:      mockTx,
+		log:     slog.Default(),
+		clock:   RealClock{},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := BatchCreateCardsInput{EntryIDs: []uuid.UUID{entryID1, entryID2, entryID3, entryID4}}
 
-	dashboard, err := svc.GetDashboard(ctx)
+	result, err := svc.BatchCreateCards(ctx, input)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if dashboard.DueCount != 0 {
-		t.Errorf("DueCount: got %d, want 0", dashboard.DueCount)
+	if result.Created != 2 {
+		t.Errorf("Created: got %d, want 2", result.Created)
 	}
-	if dashboard.NewCount != 0 {
-		t.Errorf("NewCount: got %d, want 0", dashboard.NewCount)
+	if result.SkippedExisting != 1 {
+		t.Errorf("SkippedExisting: got %d, want 1", result.SkippedExisting)
 	}
-	if dashboard.Streak != 0 {
-		t.Errorf("Streak: got %d, want 0", dashboard.Streak)
+	if len(result.Errors) != 1 || result.Errors[0].EntryID != entryID2 {
+		t.Fatalf("Errors: got %+v, want one error for entry 2", result.Errors)
 	}
-	if dashboard.ActiveSession != nil {
-		t.Error("ActiveSession should be nil")
+	if !strings.Contains(result.Errors[0].Reason, "foreign key violation") {
+		t.Errorf("Reason: got %q", result.Errors[0].Reason)
+	}
+	if committed != 2 {
+		t.Errorf("committed transactions: got %d, want 2", committed)
+	}
+	if got := len(mockAudit.LogCalls()); got != 2 {
+		t.Errorf("audit calls: got %d, want 2 (one per created card)", got)
 	}
 }
 
-func TestService_GetDashboard_StreakCalculation_FiveDays(t *testing.T) {
+func TestService_BatchCreateCards_FrequencyPresets(t *testing.T) {
+	t.Parallel()
+
+	presets := domain.CardPresets{FrequentMaxRank: 2000, FrequentStability: 4.5, FrequentDifficulty: 3}
+	frequent, rare, custom := uuid.New(), uuid.New(), uuid.New()
+	entryIDs := []uuid.UUID{frequent, rare, custom}
+	want := map[uuid.UUID][2]float64{frequent: {4.5, 3}, rare: {0, 0}, custom: {0, 0}}
+
+	for _, bulkFails := range []bool{false, true} {
+		t.Run(map[bool]string{false: "bulk insert", true: "per-entry fallback"}[bulkFails], func(t *testing.T) {
+			t.Parallel()
+
+			userID := uuid.New()
+			got := make(map[uuid.UUID][2]float64)
+			mockCards := &cardRepoMock{
+				ExistsByEntryIDsFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID) (map[uuid.UUID]bool, error) {
+					return map[uuid.UUID]bool{}, nil
+				},
+				BatchCreateFunc: func(ctx context.Context, uid uuid.UUID, eids []uuid.UUID, stability, difficulty []float64) ([]*domain.Card, error) {
+					if bulkFails {
+						return nil, errors.New("deadlock detected")
+					}
+					for i, eid := range eids {
+						got[eid] = [2]float64{stability[i], difficulty[i]}
+					}
+					return newCardsForEntries(uid, eids), nil
+				},
+				CreateWithParamsFunc: func(ctx context.Context, uid, eid uuid.UUID, stability, difficulty float64) (*domain.Card, error) {
+					got[eid] = [2]float64{stability, difficulty}
+					return &domain.Card{ID: uuid.New(), UserID: uid, EntryID: eid, State: domain.CardStateNew}, nil
+				},
+			}
+
+			svc := &Service{
+				entries: &entryRepoMock{
+					ExistByIDsFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+						return map[uuid.UUID]bool{frequent: true, rare: true, custom: true}, nil
+					},
+					GetFrequencyRanksFunc: func(ctx context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+						return map[uuid.UUID]int{frequent: 15, rare: 9000}, nil
+					},
+				},
+				cards: mockCards,
+				senses: &senseRepoMock{
+					CountByEntryIDsFunc: func(ctx context.Context, eids []uuid.UUID) (map[uuid.UUID]int, error) {
+						return map[uuid.UUID]int{frequent: 1, rare: 1, custom: 1}, nil
+					},
+				},
+				audit: &auditLoggerMock{
+					LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+				},
+				tx: &txManagerMock{
+					RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+				},
+				log:       slog.Default(),
+				clock:     RealClock{},
+				srsConfig: domain.SRSConfig{DefaultRetention: 0.9, CardPresets: presets},
+			}
+
+			ctx := ctxutil.WithUserID(context.Background(), userID)
+			result, err := svc.BatchCreateCards(ctx, BatchCreateCardsInput{EntryIDs: entryIDs})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Created != 3 {
+				t.Fatalf("Created: got %d, want 3", result.Created)
+			}
+			for _, eid := range entryIDs {
+				if got[eid] != want[eid] {
+					t.Errorf("entry %v: starting state %v, want %v", eid, got[eid], want[eid])
+				}
+			}
+		})
+	}
+}
+
+// newCardsForEntries builds NEW cards the way cardRepo.BatchCreate returns them.
+func newCardsForEntries(userID uuid.UUID, entryIDs []uuid.UUID) []*domain.Card {
+	cards := make([]*domain.Card, 0, len(entryIDs))
+	for _, eid := range entryIDs {
+		cards = append(cards, &domain.Card{ID: uuid.New(), UserID: userID, EntryID: eid, State: domain.CardStateNew})
+	}
+	return cards
+}
+
+// ---------------------------------------------------------------------------
+// GetDashboard Tests (7 tests)
+// ---------------------------------------------------------------------------
+
+func TestService_GetDashboard_Success_AllCountersCorrect(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	// Use current date for streak data (use UTC to match service)
-	now := time.Now().UTC()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sessionID := uuid.New()
 
 	settings := &domain.UserSettings{
 		UserID:   userID,
 		Timezone: "UTC",
 	}
 
-	// 5 consecutive days including today
+	statusCounts := domain.CardStatusCounts{
+		New:      10,
+		Learning: 5,
+		Review:   20,
+		Relearning: 15,
+		Total:    50,
+	}
+
+	// Use current date for streak data (use UTC to match service)
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
 	streakDays := []domain.DayReviewCount{
 		{Date: today, Count: 5},
 		{Date: today.AddDate(0, 0, -1), Count: 3},
 		{Date: today.AddDate(0, 0, -2), Count: 7},
-		{Date: today.AddDate(0, 0, -3), Count: 2},
-		{Date: today.AddDate(0, 0, -4), Count: 4},
-		{Date: today.AddDate(0, 0, -6), Count: 1}, // Gap here
+	}
+
+	activeSession := &domain.StudySession{
+		ID:     sessionID,
+		UserID: userID,
+		Status: domain.SessionStatusActive,
 	}
 
 	mockSettings := &settingsRepoMock{
@@ -3869,34 +5071,37 @@ func TestService_GetDashboard_StreakCalculation_FiveDays(t *testing.T) {
 
 	mockCards := &cardRepoMock{
 		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
-			return 0, nil
+			return 8, nil
 		},
 		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
-			return 0, nil
+			return 10, nil
 		},
 		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
-			return domain.CardStatusCounts{}, nil
+			return statusCounts, nil
 		},
 		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+			return 3, nil
 		},
 	}
 
 	mockReviews := &reviewLogRepoMock{
 		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+			return 5, nil
 		},
 		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 0, nil
+			return 2, nil
 		},
 		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
 			return streakDays, nil
 		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
+		},
 	}
 
 	mockSessions := &sessionRepoMock{
 		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
-			return nil, domain.ErrNotFound
+			return activeSession, nil
 		},
 	}
 
@@ -3916,32 +5121,48 @@ func TestService_GetDashboard_StreakCalculation_FiveDays(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if dashboard.Streak != 5 {
-		t.Errorf("Streak: got %d, want 5", dashboard.Streak)
+	if dashboard.DueCount != 8 {
+		t.Errorf("DueCount: got %d, want 8", dashboard.DueCount)
+	}
+	if dashboard.NewCount != 10 {
+		t.Errorf("NewCount: got %d, want 10", dashboard.NewCount)
+	}
+	if dashboard.ReviewedToday != 5 {
+		t.Errorf("ReviewedToday: got %d, want 5", dashboard.ReviewedToday)
+	}
+	if dashboard.NewToday != 2 {
+		t.Errorf("NewToday: got %d, want 2", dashboard.NewToday)
+	}
+	if dashboard.Streak != 3 {
+		t.Errorf("Streak: got %d, want 3", dashboard.Streak)
+	}
+	activeCalls := mockReviews.GetActiveDaysCalls()
+	if len(activeCalls) != 1 {
+		t.Fatalf("GetActiveDays calls: got %d, want 1", len(activeCalls))
+	}
+	if days := time.Since(activeCalls[0].Since).Hours() / 24; days < longestStreakWindowDays || days > longestStreakWindowDays+2 {
+		t.Errorf("GetActiveDays since: %.1f days ago, want about %d", days, longestStreakWindowDays)
+	}
+	if dashboard.StatusCounts.Total != 50 {
+		t.Errorf("StatusCounts.Total: got %d, want 50", dashboard.StatusCounts.Total)
+	}
+	if dashboard.ActiveSession == nil {
+		t.Error("ActiveSession should not be nil")
+	} else if dashboard.ActiveSession.ID != sessionID {
+		t.Errorf("ActiveSession.ID: got %v, want %v", dashboard.ActiveSession.ID, sessionID)
 	}
 }
 
-func TestService_GetDashboard_StreakBroken_Gap(t *testing.T) {
+func TestService_GetDashboard_NoCards_AllZeros(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	// Use current date for streak data (use UTC to match service)
-	now := time.Now().UTC()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	settings := &domain.UserSettings{
 		UserID:   userID,
 		Timezone: "UTC",
 	}
 
-	// Gap between day -1 and day -3
-	streakDays := []domain.DayReviewCount{
-		{Date: today, Count: 5},
-		{Date: today.AddDate(0, 0, -1), Count: 3},
-		// Missing day -2
-		{Date: today.AddDate(0, 0, -3), Count: 7},
-	}
-
 	mockSettings := &settingsRepoMock{
 		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
 			return settings, nil
@@ -3971,7 +5192,10 @@ func TestService_GetDashboard_StreakBroken_Gap(t *testing.T) {
 			return 0, nil
 		},
 		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-			return streakDays, nil
+			return []domain.DayReviewCount{}, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
 		},
 	}
 
@@ -3997,13 +5221,21 @@ func TestService_GetDashboard_StreakBroken_Gap(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Streak should be 2 (today + yesterday)
-	if dashboard.Streak != 2 {
-		t.Errorf("Streak: got %d, want 2", dashboard.Streak)
+	if dashboard.DueCount != 0 {
+		t.Errorf("DueCount: got %d, want 0", dashboard.DueCount)
 	}
-}
+	if dashboard.NewCount != 0 {
+		t.Errorf("NewCount: got %d, want 0", dashboard.NewCount)
+	}
+	if dashboard.Streak != 0 {
+		t.Errorf("Streak: got %d, want 0", dashboard.Streak)
+	}
+	if dashboard.ActiveSession != nil {
+		t.Error("ActiveSession should be nil")
+	}
+}
 
-func TestService_GetDashboard_StreakStartsFromYesterday(t *testing.T) {
+func TestService_GetDashboard_StreakCalculation_FiveDays(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
@@ -4016,12 +5248,14 @@ func TestService_GetDashboard_StreakStartsFromYesterday(t *testing.T) {
 		Timezone: "UTC",
 	}
 
-	// No review today, but yesterday and before
+	// 5 consecutive days including today
 	streakDays := []domain.DayReviewCount{
-		// Today missing
+		{Date: today, Count: 5},
 		{Date: today.AddDate(0, 0, -1), Count: 3},
 		{Date: today.AddDate(0, 0, -2), Count: 7},
 		{Date: today.AddDate(0, 0, -3), Count: 2},
+		{Date: today.AddDate(0, 0, -4), Count: 4},
+		{Date: today.AddDate(0, 0, -6), Count: 1}, // Gap here
 	}
 
 	mockSettings := &settingsRepoMock{
@@ -4055,6 +5289,9 @@ func TestService_GetDashboard_StreakStartsFromYesterday(t *testing.T) {
 		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
 			return streakDays, nil
 		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
+		},
 	}
 
 	mockSessions := &sessionRepoMock{
@@ -4079,22 +5316,32 @@ func TestService_GetDashboard_StreakStartsFromYesterday(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Streak should be 3 (yesterday + 2 days before)
-	if dashboard.Streak != 3 {
-		t.Errorf("Streak: got %d, want 3", dashboard.Streak)
+	if dashboard.Streak != 5 {
+		t.Errorf("Streak: got %d, want 5", dashboard.Streak)
 	}
 }
 
-func TestService_GetDashboard_OverdueCount(t *testing.T) {
+func TestService_GetDashboard_StreakBroken_Gap(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
+	// Use current date for streak data (use UTC to match service)
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	settings := &domain.UserSettings{
 		UserID:   userID,
 		Timezone: "UTC",
 	}
 
+	// Gap between day -1 and day -3
+	streakDays := []domain.DayReviewCount{
+		{Date: today, Count: 5},
+		{Date: today.AddDate(0, 0, -1), Count: 3},
+		// Missing day -2
+		{Date: today.AddDate(0, 0, -3), Count: 7},
+	}
+
 	mockSettings := &settingsRepoMock{
 		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
 			return settings, nil
@@ -4103,7 +5350,7 @@ func TestService_GetDashboard_OverdueCount(t *testing.T) {
 
 	mockCards := &cardRepoMock{
 		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
-			return 25, nil // 25 due cards
+			return 0, nil
 		},
 		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
 			return 0, nil
@@ -4112,7 +5359,7 @@ func TestService_GetDashboard_OverdueCount(t *testing.T) {
 			return domain.CardStatusCounts{}, nil
 		},
 		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
-			return 12, nil // 12 overdue cards
+			return 0, nil
 		},
 	}
 
@@ -4124,7 +5371,10 @@ func TestService_GetDashboard_OverdueCount(t *testing.T) {
 			return 0, nil
 		},
 		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-			return []domain.DayReviewCount{}, nil
+			return streakDays, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
 		},
 	}
 
@@ -4150,26 +5400,31 @@ func TestService_GetDashboard_OverdueCount(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if dashboard.OverdueCount != 12 {
-		t.Errorf("OverdueCount: got %d, want 12", dashboard.OverdueCount)
+	// Streak should be 2 (today + yesterday)
+	if dashboard.Streak != 2 {
+		t.Errorf("Streak: got %d, want 2", dashboard.Streak)
 	}
 }
 
-func TestService_GetDashboard_ActiveSessionPresent(t *testing.T) {
+func TestService_GetDashboard_StreakStartsFromYesterday(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	sessionID := uuid.New()
+	// Use current date for streak data (use UTC to match service)
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
 	settings := &domain.UserSettings{
 		UserID:   userID,
 		Timezone: "UTC",
 	}
 
-	activeSession := &domain.StudySession{
-		ID:     sessionID,
-		UserID: userID,
-		Status: domain.SessionStatusActive,
+	// No review today, but yesterday and before
+	streakDays := []domain.DayReviewCount{
+		// Today missing
+		{Date: today.AddDate(0, 0, -1), Count: 3},
+		{Date: today.AddDate(0, 0, -2), Count: 7},
+		{Date: today.AddDate(0, 0, -3), Count: 2},
 	}
 
 	mockSettings := &settingsRepoMock{
@@ -4201,13 +5456,16 @@ func TestService_GetDashboard_ActiveSessionPresent(t *testing.T) {
 			return 0, nil
 		},
 		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
-			return []domain.DayReviewCount{}, nil
+			return streakDays, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
 		},
 	}
 
 	mockSessions := &sessionRepoMock{
 		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
-			return activeSession, nil
+			return nil, domain.ErrNotFound
 		},
 	}
 
@@ -4227,140 +5485,494 @@ func TestService_GetDashboard_ActiveSessionPresent(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if dashboard.ActiveSession == nil {
-		t.Error("ActiveSession should not be nil")
-	} else if dashboard.ActiveSession.ID != sessionID {
-		t.Errorf("ActiveSession.ID: got %v, want %v", dashboard.ActiveSession.ID, sessionID)
+	// Streak should be 3 (yesterday + 2 days before)
+	if dashboard.Streak != 3 {
+		t.Errorf("Streak: got %d, want 3", dashboard.Streak)
 	}
 }
 
-// ---------------------------------------------------------------------------
-// GetCardHistory Tests (3 tests)
-// ---------------------------------------------------------------------------
-
-func TestService_GetCardHistory_Success_WithPagination(t *testing.T) {
+func TestService_GetDashboard_LongestStreakInPast(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	cardID := uuid.New()
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 
-	card := &domain.Card{
-		ID:     cardID,
-		UserID: userID,
-		State:  domain.CardStateReview,
+	// Current streak is 2 days; a 5-day run a year and a half ago is longer
+	// and lies outside the GetStreakDays window.
+	old := today.AddDate(0, 0, -500)
+	activeDays := []time.Time{
+		today,
+		today.AddDate(0, 0, -1),
+		today.AddDate(0, 0, -10),
+		old,
+		old.AddDate(0, 0, -1),
+		old.AddDate(0, 0, -2),
+		old.AddDate(0, 0, -3),
+		old.AddDate(0, 0, -4),
+		old.AddDate(0, 0, -7),
 	}
 
-	logs := []*domain.ReviewLog{
-		{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeGood},
-		{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeEasy},
+	mockSettings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			return &domain.UserSettings{UserID: userID, Timezone: "UTC"}, nil
+		},
 	}
 
 	mockCards := &cardRepoMock{
-		GetByIDFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
-			if uid != userID || cid != cardID {
-				t.Errorf("unexpected IDs: got (%v, %v), want (%v, %v)", uid, cid, userID, cardID)
-			}
-			return card, nil
+		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
+			return 0, nil
+		},
+		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return 0, nil
+		},
+		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
+			return domain.CardStatusCounts{}, nil
+		},
+		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
 		},
 	}
 
 	mockReviews := &reviewLogRepoMock{
-		GetByCardIDFunc: func(ctx context.Context, cid uuid.UUID, limit, offset int) ([]*domain.ReviewLog, int, error) {
-			if cid != cardID {
-				t.Errorf("cardID: got %v, want %v", cid, cardID)
-			}
-			if limit != 10 {
-				t.Errorf("limit: got %d, want 10", limit)
-			}
-			if offset != 5 {
-				t.Errorf("offset: got %d, want 5", offset)
-			}
-			return logs, 25, nil // 2 logs, 25 total
+		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
+			return []domain.DayReviewCount{
+				{Date: today, Count: 3},
+				{Date: today.AddDate(0, 0, -1), Count: 1},
+				{Date: today.AddDate(0, 0, -10), Count: 4},
+			}, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return activeDays, nil
+		},
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return nil, domain.ErrNotFound
 		},
 	}
 
 	svc := &Service{
-		cards:   mockCards,
-		reviews: mockReviews,
-		log:     slog.Default(),
-		clock:   RealClock{},
+		settings: mockSettings,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		sessions: mockSessions,
+		log:      slog.Default(),
+		clock:    RealClock{},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
-	input := GetCardHistoryInput{
-		CardID: cardID,
-		Limit:  10,
-		Offset: 5,
-	}
 
-	result, total, err := svc.GetCardHistory(ctx, input)
+	dashboard, err := svc.GetDashboard(ctx)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if len(result) != 2 {
-		t.Errorf("result length: got %d, want 2", len(result))
+	if dashboard.Streak != 2 {
+		t.Errorf("Streak: got %d, want 2", dashboard.Streak)
 	}
-	if total != 25 {
-		t.Errorf("total: got %d, want 25", total)
+	if dashboard.LongestStreak != 5 {
+		t.Errorf("LongestStreak: got %d, want 5", dashboard.LongestStreak)
 	}
+}
 
-	// Verify calls
-	if len(mockCards.GetByIDCalls()) != 1 {
-		t.Errorf("GetByID calls: got %d, want 1", len(mockCards.GetByIDCalls()))
-	}
-	if len(mockReviews.GetByCardIDCalls()) != 1 {
-		t.Errorf("GetByCardID calls: got %d, want 1", len(mockReviews.GetByCardIDCalls()))
+func TestCalculateLongestStreak(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	back := func(n int) time.Time { return day.AddDate(0, 0, -n) }
+
+	tests := []struct {
+		name string
+		days []time.Time
+		want int
+	}{
+		{name: "no days", days: nil, want: 0},
+		{name: "single day", days: []time.Time{back(0)}, want: 1},
+		{name: "all consecutive", days: []time.Time{back(0), back(1), back(2)}, want: 3},
+		{name: "longest run at the end", days: []time.Time{back(0), back(2), back(3), back(4), back(9)}, want: 3},
+		{name: "longest run first", days: []time.Time{back(0), back(1), back(2), back(3), back(5), back(6)}, want: 4},
+		{name: "no two consecutive", days: []time.Time{back(0), back(2), back(4)}, want: 1},
+		{name: "run across month boundary", days: []time.Time{back(29), back(30), back(31), back(32)}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := calculateLongestStreak(tt.days); got != tt.want {
+				t.Errorf("calculateLongestStreak() = %d, want %d", got, tt.want)
+			}
+		})
 	}
 }
 
-func TestService_GetCardHistory_CardNotFound_OwnershipCheck(t *testing.T) {
+func TestService_GetDashboard_OverdueCount(t *testing.T) {
 	t.Parallel()
 
 	userID := uuid.New()
-	cardID := uuid.New()
+
+	settings := &domain.UserSettings{
+		UserID:   userID,
+		Timezone: "UTC",
+	}
+
+	mockSettings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			return settings, nil
+		},
+	}
 
 	mockCards := &cardRepoMock{
-		GetByIDFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
+			return 25, nil // 25 due cards
+		},
+		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return 0, nil
+		},
+		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
+			return domain.CardStatusCounts{}, nil
+		},
+		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 12, nil // 12 overdue cards
+		},
+	}
+
+	mockReviews := &reviewLogRepoMock{
+		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
+			return []domain.DayReviewCount{}, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
+		},
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
 			return nil, domain.ErrNotFound
 		},
 	}
 
 	svc := &Service{
-		cards: mockCards,
-		log:   slog.Default(),
-		clock: RealClock{},
+		settings: mockSettings,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		sessions: mockSessions,
+		log:      slog.Default(),
+		clock:    RealClock{},
 	}
 
 	ctx := ctxutil.WithUserID(context.Background(), userID)
-	input := GetCardHistoryInput{
-		CardID: cardID,
-		Limit:  50,
-		Offset: 0,
+
+	dashboard, err := svc.GetDashboard(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	_, _, err := svc.GetCardHistory(ctx, input)
-	if !errors.Is(err, domain.ErrNotFound) {
-		t.Errorf("error: got %v, want ErrNotFound", err)
+	if dashboard.OverdueCount != 12 {
+		t.Errorf("OverdueCount: got %d, want 12", dashboard.OverdueCount)
 	}
 }
 
-func TestService_GetCardHistory_NoUserID(t *testing.T) {
+func TestService_GetDashboard_DailyGoal(t *testing.T) {
 	t.Parallel()
 
-	svc := &Service{
-		log:   slog.Default(),
-		clock: RealClock{},
-	}
+	tests := []struct {
+		name          string
+		goal          int
+		reviewedToday int
+		wantProgress  int
+		wantReached   bool
+	}{
+		{name: "no goal", goal: 0, reviewedToday: 30, wantProgress: 0, wantReached: false},
+		{name: "one short of goal", goal: 20, reviewedToday: 19, wantProgress: 19, wantReached: false},
+		{name: "goal met exactly", goal: 20, reviewedToday: 20, wantProgress: 20, wantReached: true},
+		{name: "goal exceeded", goal: 20, reviewedToday: 35, wantProgress: 20, wantReached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			userID := uuid.New()
+			svc := &Service{
+				settings: &settingsRepoMock{
+					GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+						return &domain.UserSettings{UserID: userID, Timezone: "UTC", DailyGoal: tt.goal}, nil
+					},
+				},
+				cards: &cardRepoMock{
+					CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
+						return 0, nil
+					},
+					CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+						return 0, nil
+					},
+					CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
+						return domain.CardStatusCounts{}, nil
+					},
+					CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+						return 0, nil
+					},
+				},
+				reviews: &reviewLogRepoMock{
+					CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+						return tt.reviewedToday, nil
+					},
+					CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+						return 0, nil
+					},
+					GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
+						return nil, nil
+					},
+					GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+						return nil, nil
+					},
+				},
+				sessions: &sessionRepoMock{
+					GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+						return nil, domain.ErrNotFound
+					},
+				},
+				log:   slog.Default(),
+				clock: RealClock{},
+			}
 
-	ctx := context.Background() // No user ID
-	input := GetCardHistoryInput{
-		CardID: uuid.New(),
-		Limit:  50,
-		Offset: 0,
+			dashboard, err := svc.GetDashboard(ctxutil.WithUserID(context.Background(), userID))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dashboard.GoalProgress != tt.wantProgress {
+				t.Errorf("GoalProgress: got %d, want %d", dashboard.GoalProgress, tt.wantProgress)
+			}
+			if dashboard.GoalReached != tt.wantReached {
+				t.Errorf("GoalReached: got %v, want %v", dashboard.GoalReached, tt.wantReached)
+			}
+		})
 	}
+}
 
-	_, _, err := svc.GetCardHistory(ctx, input)
+func TestService_GetDashboard_ActiveSessionPresent(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	settings := &domain.UserSettings{
+		UserID:   userID,
+		Timezone: "UTC",
+	}
+
+	activeSession := &domain.StudySession{
+		ID:     sessionID,
+		UserID: userID,
+		Status: domain.SessionStatusActive,
+	}
+
+	mockSettings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			return settings, nil
+		},
+	}
+
+	mockCards := &cardRepoMock{
+		CountDueFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time) (int, error) {
+			return 0, nil
+		},
+		CountNewFunc: func(ctx context.Context, uid uuid.UUID) (int, error) {
+			return 0, nil
+		},
+		CountByStatusFunc: func(ctx context.Context, uid uuid.UUID) (domain.CardStatusCounts, error) {
+			return domain.CardStatusCounts{}, nil
+		},
+		CountOverdueFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+	}
+
+	mockReviews := &reviewLogRepoMock{
+		CountTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		CountNewTodayFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time) (int, error) {
+			return 0, nil
+		},
+		GetStreakDaysFunc: func(ctx context.Context, uid uuid.UUID, dayStart time.Time, lastNDays int, timezone string) ([]domain.DayReviewCount, error) {
+			return []domain.DayReviewCount{}, nil
+		},
+		GetActiveDaysFunc: func(ctx context.Context, uid uuid.UUID, since time.Time, timezone string) ([]time.Time, error) {
+			return nil, nil
+		},
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return activeSession, nil
+		},
+	}
+
+	svc := &Service{
+		settings: mockSettings,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		sessions: mockSessions,
+		log:      slog.Default(),
+		clock:    RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	dashboard, err := svc.GetDashboard(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dashboard.ActiveSession == nil {
+		t.Error("ActiveSession should not be nil")
+	} else if dashboard.ActiveSession.ID != sessionID {
+		t.Errorf("ActiveSession.ID: got %v, want %v", dashboard.ActiveSession.ID, sessionID)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetCardHistory Tests (3 tests)
+// ---------------------------------------------------------------------------
+
+func TestService_GetCardHistory_Success_WithPagination(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+
+	card := &domain.Card{
+		ID:     cardID,
+		UserID: userID,
+		State:  domain.CardStateReview,
+	}
+
+	logs := []*domain.ReviewLog{
+		{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeGood},
+		{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeEasy},
+	}
+
+	mockCards := &cardRepoMock{
+		GetByIDFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			if uid != userID || cid != cardID {
+				t.Errorf("unexpected IDs: got (%v, %v), want (%v, %v)", uid, cid, userID, cardID)
+			}
+			return card, nil
+		},
+	}
+
+	mockReviews := &reviewLogRepoMock{
+		GetByCardIDFunc: func(ctx context.Context, cid uuid.UUID, limit, offset int) ([]*domain.ReviewLog, int, error) {
+			if cid != cardID {
+				t.Errorf("cardID: got %v, want %v", cid, cardID)
+			}
+			if limit != 10 {
+				t.Errorf("limit: got %d, want 10", limit)
+			}
+			if offset != 5 {
+				t.Errorf("offset: got %d, want 5", offset)
+			}
+			return logs, 25, nil // 2 logs, 25 total
+		},
+	}
+
+	svc := &Service{
+		cards:   mockCards,
+		reviews: mockReviews,
+		log:     slog.Default(),
+		clock:   RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := GetCardHistoryInput{
+		CardID: cardID,
+		Limit:  10,
+		Offset: 5,
+	}
+
+	result, total, err := svc.GetCardHistory(ctx, input)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Errorf("result length: got %d, want 2", len(result))
+	}
+	if total != 25 {
+		t.Errorf("total: got %d, want 25", total)
+	}
+
+	// Verify calls
+	if len(mockCards.GetByIDCalls()) != 1 {
+		t.Errorf("GetByID calls: got %d, want 1", len(mockCards.GetByIDCalls()))
+	}
+	if len(mockReviews.GetByCardIDCalls()) != 1 {
+		t.Errorf("GetByCardID calls: got %d, want 1", len(mockReviews.GetByCardIDCalls()))
+	}
+}
+
+func TestService_GetCardHistory_CardNotFound_OwnershipCheck(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+
+	mockCards := &cardRepoMock{
+		GetByIDFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+
+	svc := &Service{
+		cards: mockCards,
+		log:   slog.Default(),
+		clock: RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	input := GetCardHistoryInput{
+		CardID: cardID,
+		Limit:  50,
+		Offset: 0,
+	}
+
+	_, _, err := svc.GetCardHistory(ctx, input)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("error: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestService_GetCardHistory_NoUserID(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		log:   slog.Default(),
+		clock: RealClock{},
+	}
+
+	ctx := context.Background() // No user ID
+	input := GetCardHistoryInput{
+		CardID: uuid.New(),
+		Limit:  50,
+		Offset: 0,
+	}
+
+	_, _, err := svc.GetCardHistory(ctx, input)
 	if !errors.Is(err, domain.ErrUnauthorized) {
 		t.Errorf("error: got %v, want ErrUnauthorized", err)
 	}
@@ -4661,8 +6273,13 @@ func TestService_StartSession_RaceCondition_ErrAlreadyExists(t *testing.T) {
 		},
 	}
 
+	mockCards, mockReviews, mockSettings := emptyQueueMocks()
+
 	svc := &Service{
 		sessions: mockSessions,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: mockSettings,
 		log:      slog.Default(),
 		clock:    RealClock{},
 	}
@@ -4735,13 +6352,13 @@ func TestService_GetStudyQueue_DefaultLimit_WhenZero(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			if limit != 50 {
 				t.Errorf("expected default limit 50, got %d", limit)
 			}
 			return []*domain.Card{}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{}, nil
 		},
 	}
@@ -4799,10 +6416,10 @@ func TestService_GetStudyQueueEntries_BatchLoadsEntries(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{card1, card2}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{}, nil
 		},
 	}
@@ -4888,10 +6505,10 @@ func TestService_GetStudyQueueEntries_EmptyQueue(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{}, nil
 		},
 	}
@@ -4973,10 +6590,10 @@ func TestService_GetStudyQueueEntries_GetByIDsError(t *testing.T) {
 	}
 
 	mockCards := &cardRepoMock{
-		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int) ([]*domain.Card, error) {
+		GetDueCardsFunc: func(ctx context.Context, uid uuid.UUID, nowTime time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{card}, nil
 		},
-		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int) ([]*domain.Card, error) {
+		GetNewCardsFunc: func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
 			return []*domain.Card{}, nil
 		},
 	}
@@ -5018,3 +6635,1355 @@ func TestService_GetStudyQueueEntries_GetByIDsError(t *testing.T) {
 func ptr[T any](v T) *T {
 	return &v
 }
+
+// ---------------------------------------------------------------------------
+// Session resumption Tests
+// ---------------------------------------------------------------------------
+
+func TestService_StartSession_SnapshotsQueueInOrder(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	dueID, learningID, newID := uuid.New(), uuid.New(), uuid.New()
+
+	mockCards, mockReviews, mockSettings := emptyQueueMocks()
+	mockCards.GetDueCardsFunc = func(ctx context.Context, uid uuid.UUID, now time.Time, limit int, _ domain.DueCardOrder) ([]*domain.Card, error) {
+		return []*domain.Card{{ID: dueID}, {ID: learningID}}, nil
+	}
+	mockCards.GetNewCardsFunc = func(ctx context.Context, uid uuid.UUID, limit int, _ domain.NewCardOrder) ([]*domain.Card, error) {
+		return []*domain.Card{{ID: newID}}, nil
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return nil, domain.ErrNotFound
+		},
+		CreateFunc: func(ctx context.Context, session *domain.StudySession) (*domain.StudySession, error) {
+			return session, nil
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: mockSettings,
+		log:      slog.Default(),
+		clock:    RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	session, err := svc.StartSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []uuid.UUID{dueID, learningID, newID}
+	if len(session.QueueCardIDs) != len(want) {
+		t.Fatalf("QueueCardIDs: got %d, want %d", len(session.QueueCardIDs), len(want))
+	}
+	for i, id := range want {
+		if session.QueueCardIDs[i] != id {
+			t.Errorf("QueueCardIDs[%d]: got %v, want %v", i, session.QueueCardIDs[i], id)
+		}
+	}
+}
+
+func TestService_ResumeSession_ReturnsRemainingInOrder(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardA, cardB, cardC := uuid.New(), uuid.New(), uuid.New()
+
+	// cardA was already reviewed and removed from the snapshot.
+	session := &domain.StudySession{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Status:       domain.SessionStatusActive,
+		QueueCardIDs: []uuid.UUID{cardB, cardC},
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return session, nil
+		},
+	}
+	mockCards := &cardRepoMock{
+		StudyableByIDsFunc: func(ctx context.Context, uid uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{cardA: true, cardB: true, cardC: true}, nil
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		cards:    mockCards,
+		log:      slog.Default(),
+		clock:    RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	got, remaining, err := svc.ResumeSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.ID != session.ID {
+		t.Errorf("session ID: got %v, want %v", got.ID, session.ID)
+	}
+	if len(remaining) != 2 || remaining[0] != cardB || remaining[1] != cardC {
+		t.Errorf("remaining: got %v, want [%v %v]", remaining, cardB, cardC)
+	}
+}
+
+func TestService_ResumeSession_DropsNoLongerStudyableCard(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardA, cardB, cardC := uuid.New(), uuid.New(), uuid.New()
+
+	session := &domain.StudySession{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Status:       domain.SessionStatusActive,
+		QueueCardIDs: []uuid.UUID{cardA, cardB, cardC},
+	}
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return session, nil
+		},
+	}
+	mockCards := &cardRepoMock{
+		StudyableByIDsFunc: func(ctx context.Context, uid uuid.UUID, cardIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+			// cardB's entry was deleted since the session started.
+			return map[uuid.UUID]bool{cardA: true, cardC: true}, nil
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		cards:    mockCards,
+		log:      slog.Default(),
+		clock:    RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	_, remaining, err := svc.ResumeSession(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(remaining) != 2 || remaining[0] != cardA || remaining[1] != cardC {
+		t.Errorf("remaining: got %v, want [%v %v]", remaining, cardA, cardC)
+	}
+}
+
+func TestService_ResumeSession_NoActiveSession(t *testing.T) {
+	t.Parallel()
+
+	mockSessions := &sessionRepoMock{
+		GetActiveFunc: func(ctx context.Context, uid uuid.UUID) (*domain.StudySession, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		log:      slog.Default(),
+		clock:    RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	_, _, err := svc.ResumeSession(ctx)
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("error: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestService_ReviewCard_QueueRemovalFailureDoesNotFailReview(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+
+	card := &domain.Card{ID: cardID, State: domain.CardStateNew}
+
+	mockCards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return card, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+			return &domain.Card{ID: cardID, State: params.State}, nil
+		},
+	}
+	mockSettings := &settingsRepoMock{
+		GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			s := domain.DefaultUserSettings(uid)
+			return &s, nil
+		},
+	}
+	mockReviews := &reviewLogRepoMock{
+		CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) {
+			return log, nil
+		},
+	}
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error {
+			return nil
+		},
+	}
+	mockTx := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+	mockSessions := &sessionRepoMock{
+		RemoveFromQueueFunc: func(ctx context.Context, uid, cid uuid.UUID) error {
+			return errors.New("db down")
+		},
+	}
+
+	svc := &Service{
+		sessions: mockSessions,
+		cards:    mockCards,
+		reviews:  mockReviews,
+		settings: mockSettings,
+		audit:    mockAudit,
+		tx:       mockTx,
+		log:      slog.Default(),
+		clock:    RealClock{},
+		srsConfig: domain.SRSConfig{
+			LearningSteps:    []time.Duration{1 * time.Minute, 10 * time.Minute},
+			DefaultRetention: 0.9,
+			MaxIntervalDays:  365,
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	_, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: cardID, Grade: domain.ReviewGradeGood})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := mockSessions.RemoveFromQueueCalls()
+	if len(calls) != 1 || calls[0].CardID != cardID {
+		t.Errorf("RemoveFromQueue calls: got %v, want one call for %v", calls, cardID)
+	}
+}
+
+func TestService_ReviewCard_AuditRecordsSRSDiff(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	card := &domain.Card{ID: cardID, State: domain.CardStateNew, Difficulty: 5}
+	updatedCard := &domain.Card{
+		ID:         cardID,
+		State:      domain.CardStateLearning,
+		Stability:  2.3,
+		Difficulty: 5,
+		Due:        now.Add(10 * time.Minute),
+	}
+
+	mockCards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return card, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+			return updatedCard, nil
+		},
+	}
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+	}
+
+	svc := &Service{
+		sessions: noopQueueSessions(),
+		cards:    mockCards,
+		reviews: &reviewLogRepoMock{
+			CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) { return log, nil },
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: userID, MaxIntervalDays: 365}, nil
+			},
+		},
+		audit: mockAudit,
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:   slog.Default(),
+		clock: &clockMock{NowFunc: func() time.Time { return now }},
+		srsConfig: domain.SRSConfig{
+			LearningSteps:     []time.Duration{1 * time.Minute, 10 * time.Minute},
+			DefaultRetention:  0.9,
+			MaxIntervalDays:   365,
+			UndoWindowMinutes: 15,
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	if _, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: cardID, Grade: domain.ReviewGradeGood}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(mockAudit.LogCalls()) != 1 {
+		t.Fatalf("Audit Log calls: got %d, want 1", len(mockAudit.LogCalls()))
+	}
+	changes := mockAudit.LogCalls()[0].Record.Changes
+
+	wantDiff := map[string]map[string]any{
+		"state":     {"old": domain.CardStateNew, "new": domain.CardStateLearning},
+		"stability": {"old": 0.0, "new": 2.3},
+		"due":       {"old": time.Time{}, "new": now.Add(10 * time.Minute)},
+	}
+	for field, want := range wantDiff {
+		got, ok := changes[field].(map[string]any)
+		if !ok {
+			t.Errorf("Changes[%q] missing", field)
+			continue
+		}
+		if got["old"] != want["old"] || got["new"] != want["new"] {
+			t.Errorf("Changes[%q] = %v, want %v", field, got, want)
+		}
+	}
+	if _, ok := changes["difficulty"]; ok {
+		t.Error("unchanged difficulty should be omitted from Changes")
+	}
+	if _, ok := changes["scheduled_days"]; ok {
+		t.Error("unchanged scheduled_days should be omitted from Changes")
+	}
+	if grade, ok := changes["grade"].(map[string]any); !ok || grade["new"] != domain.ReviewGradeGood {
+		t.Errorf("Changes[grade] = %v, want new=GOOD", changes["grade"])
+	}
+}
+
+func TestService_UndoReview_AuditRecordsSRSDiff(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	now := time.Now()
+
+	card := &domain.Card{ID: cardID, State: domain.CardStateReview, Stability: 4.1, ScheduledDays: 4, Due: now.Add(96 * time.Hour)}
+	prevState := &domain.CardSnapshot{State: domain.CardStateLearning, Stability: 2.3, ScheduledDays: 0, Due: now}
+
+	mockAudit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+	}
+
+	svc := &Service{
+		cards: &cardRepoMock{
+			GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+				return card, nil
+			},
+			UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+				return &domain.Card{ID: cardID, State: params.State}, nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			GetLastByCardIDFunc: func(ctx context.Context, cid uuid.UUID) (*domain.ReviewLog, error) {
+				return &domain.ReviewLog{ID: uuid.New(), CardID: cardID, Grade: domain.ReviewGradeEasy, PrevState: prevState, ReviewedAt: now.Add(-time.Minute)}, nil
+			},
+			DeleteFunc: func(ctx context.Context, id uuid.UUID) error { return nil },
+		},
+		sessions: &sessionRepoMock{
+			ReturnToQueueFunc: func(ctx context.Context, uid, cid uuid.UUID) error { return nil },
+		},
+		audit: mockAudit,
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:       slog.Default(),
+		clock:     &clockMock{NowFunc: func() time.Time { return now }},
+		srsConfig: domain.SRSConfig{UndoWindowMinutes: 15},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	if _, err := svc.UndoReview(ctx, UndoReviewInput{CardID: cardID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	changes := mockAudit.LogCalls()[0].Record.Changes
+	for field, want := range map[string][2]any{
+		"state":          {domain.CardStateReview, domain.CardStateLearning},
+		"stability":      {4.1, 2.3},
+		"scheduled_days": {4, 0},
+	} {
+		got, ok := changes[field].(map[string]any)
+		if !ok || got["old"] != want[0] || got["new"] != want[1] {
+			t.Errorf("Changes[%q] = %v, want old=%v new=%v", field, changes[field], want[0], want[1])
+		}
+	}
+	if _, ok := changes["difficulty"]; ok {
+		t.Error("unchanged difficulty should be omitted from Changes")
+	}
+	if undo, ok := changes["undo"].(map[string]any); !ok || undo["old"] != domain.ReviewGradeEasy {
+		t.Errorf("Changes[undo] = %v, want old=EASY", changes["undo"])
+	}
+}
+
+func TestService_GetCardHistoryCursor_StableUnderInserts(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	cardID := uuid.New()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// In-memory review log store with (reviewed_at, id) DESC keyset semantics.
+	// Two logs share a timestamp so the id tie-break is exercised.
+	var store []*domain.ReviewLog
+	for i := range 7 {
+		at := base.Add(time.Duration(i/2) * time.Hour)
+		store = append(store, &domain.ReviewLog{ID: uuid.New(), CardID: cardID, ReviewedAt: at})
+	}
+	less := func(a, b *domain.ReviewLog) bool { // a sorts after b in DESC order
+		if !a.ReviewedAt.Equal(b.ReviewedAt) {
+			return a.ReviewedAt.Before(b.ReviewedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	}
+
+	reviews := &reviewLogRepoMock{
+		GetByCardIDCursorFunc: func(ctx context.Context, cid uuid.UUID, after *domain.ReviewLogCursor, limit int) ([]*domain.ReviewLog, bool, error) {
+			sorted := slices.Clone(store)
+			slices.SortFunc(sorted, func(a, b *domain.ReviewLog) int {
+				if less(a, b) {
+					return 1
+				}
+				return -1
+			})
+			var out []*domain.ReviewLog
+			for _, rl := range sorted {
+				if after != nil && !less(rl, &domain.ReviewLog{ReviewedAt: after.ReviewedAt, ID: after.ID}) {
+					continue
+				}
+				out = append(out, rl)
+			}
+			if len(out) > limit {
+				return out[:limit], true, nil
+			}
+			return out, false, nil
+		},
+	}
+	cards := &cardRepoMock{
+		GetByIDFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return &domain.Card{ID: cardID}, nil
+		},
+	}
+
+	svc := &Service{cards: cards, reviews: reviews, log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	seen := make(map[uuid.UUID]int)
+	var cursor *string
+	for page := 0; ; page++ {
+		result, err := svc.GetCardHistoryCursor(ctx, GetHistoryCursorInput{CardID: cardID, Cursor: cursor, Limit: 3})
+		if err != nil {
+			t.Fatalf("page %d: unexpected error: %v", page, err)
+		}
+		for _, rl := range result.Logs {
+			seen[rl.ID]++
+		}
+
+		if page == 0 {
+			// A new review lands between page requests; it is newer than
+			// everything already paged and must not shift later pages.
+			store = append(store, &domain.ReviewLog{ID: uuid.New(), CardID: cardID, ReviewedAt: base.Add(24 * time.Hour)})
+		}
+
+		if !result.HasMore {
+			if result.NextCursor != nil {
+				t.Error("NextCursor should be nil on the last page")
+			}
+			break
+		}
+		cursor = result.NextCursor
+	}
+
+	for _, rl := range store[:7] {
+		if seen[rl.ID] != 1 {
+			t.Errorf("log %s seen %d times, want exactly once", rl.ID, seen[rl.ID])
+		}
+	}
+	if len(seen) != 7 {
+		t.Errorf("paged %d distinct logs, want the 7 that existed when paging began", len(seen))
+	}
+}
+
+func TestService_GetCardHistoryCursor_InvalidCursor(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	_, err := svc.GetCardHistoryCursor(ctx, GetHistoryCursorInput{CardID: uuid.New(), Cursor: ptr("not-a-cursor!")})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestHistoryCursor_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	log := &domain.ReviewLog{ID: uuid.New(), ReviewedAt: time.Date(2026, 2, 3, 4, 5, 6, 789000, time.UTC)}
+	got, err := decodeHistoryCursor(encodeHistoryCursor(log))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ID != log.ID || !got.ReviewedAt.Equal(log.ReviewedAt) {
+		t.Errorf("round trip = %+v, want (%v, %v)", got, log.ReviewedAt, log.ID)
+	}
+}
+
+func TestService_GetUserStats_AggregatesReviewsAndStability(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	var gotSince time.Time
+	mockReviews := &reviewLogRepoMock{
+		GetUserAggregationFunc: func(ctx context.Context, uid uuid.UUID, since time.Time) (domain.UserReviewAggregation, error) {
+			gotSince = since
+			return domain.UserReviewAggregation{
+				TotalReviews:         10,
+				AgainCount:           2,
+				HardCount:            1,
+				GoodCount:            5,
+				EasyCount:            2,
+				RecentMatureReviews:  4,
+				RecentMatureRecalled: 3,
+			}, nil
+		},
+	}
+
+	var gotBounds []float64
+	mockCards := &cardRepoMock{
+		GetStabilityHistogramFunc: func(ctx context.Context, uid uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error) {
+			gotBounds = bounds
+			one, seven, mature, ninety := 1.0, 7.0, float64(matureStabilityDays), 90.0
+			return []domain.StabilityBucket{
+				{Min: 0, Max: &one, Count: 1, StabilitySum: 0.5},
+				{Min: 1, Max: &seven, Count: 2, StabilitySum: 7},
+				{Min: 7, Max: &mature, Count: 0},
+				{Min: float64(matureStabilityDays), Max: &ninety, Count: 2, StabilitySum: 62.5},
+				{Min: 90, Count: 1, StabilitySum: 130},
+			}, nil
+		},
+	}
+
+	svc := &Service{
+		cards:   mockCards,
+		reviews: mockReviews,
+		log:     slog.Default(),
+		clock:   &clockMock{NowFunc: func() time.Time { return now }},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	stats, err := svc.GetUserStats(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := now.AddDate(0, 0, -retentionWindowDays); !gotSince.Equal(want) {
+		t.Errorf("since: got %v, want %v", gotSince, want)
+	}
+	if !slices.Equal(gotBounds, stabilityBounds) {
+		t.Errorf("bounds: got %v, want %v", gotBounds, stabilityBounds)
+	}
+	if stats.TotalReviews != 10 {
+		t.Errorf("TotalReviews: got %d, want 10", stats.TotalReviews)
+	}
+	// AccuracyRate = (5 GOOD + 2 EASY) / 10 * 100 = 70%
+	if stats.AccuracyRate != 70.0 {
+		t.Errorf("AccuracyRate: got %.2f, want 70.00", stats.AccuracyRate)
+	}
+	// RetentionRate = 3 recalled / 4 mature reviews * 100 = 75%
+	if stats.RetentionRate != 75.0 {
+		t.Errorf("RetentionRate: got %.2f, want 75.00", stats.RetentionRate)
+	}
+	// MatureCards = cards in buckets starting at or above 21 days.
+	if stats.MatureCards != 3 {
+		t.Errorf("MatureCards: got %d, want 3", stats.MatureCards)
+	}
+	// AverageStability = (0.5 + 7 + 62.5 + 130) / 6 cards.
+	if want := 200.0 / 6; stats.AverageStability != want {
+		t.Errorf("AverageStability: got %.4f, want %.4f", stats.AverageStability, want)
+	}
+	if stats.GradeDistribution.Again != 2 || stats.GradeDistribution.Easy != 2 {
+		t.Errorf("GradeDistribution: got %+v", stats.GradeDistribution)
+	}
+}
+
+func TestService_GetUserStats_NoReviews_AllZeros(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		cards: &cardRepoMock{
+			GetStabilityHistogramFunc: func(ctx context.Context, uid uuid.UUID, bounds []float64) ([]domain.StabilityBucket, error) {
+				return make([]domain.StabilityBucket, len(bounds)+1), nil
+			},
+		},
+		reviews: &reviewLogRepoMock{
+			GetUserAggregationFunc: func(ctx context.Context, uid uuid.UUID, since time.Time) (domain.UserReviewAggregation, error) {
+				return domain.UserReviewAggregation{}, nil
+			},
+		},
+		log:   slog.Default(),
+		clock: RealClock{},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	stats, err := svc.GetUserStats(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if stats.TotalReviews != 0 || stats.AccuracyRate != 0 || stats.RetentionRate != 0 ||
+		stats.AverageStability != 0 || stats.MatureCards != 0 {
+		t.Errorf("stats: got %+v, want all zeros", stats)
+	}
+}
+
+func TestService_GetUserStats_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{log: slog.Default(), clock: RealClock{}}
+
+	_, err := svc.GetUserStats(context.Background())
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// RescheduleCard Tests
+// ---------------------------------------------------------------------------
+
+// newRescheduleService builds a Service whose card has the given state and
+// whose user caps intervals at userMaxDays.
+func newRescheduleService(now time.Time, state domain.CardState, userMaxDays int) (*Service, *cardRepoMock, *auditLoggerMock) {
+	lastReview := now.Add(-3 * 24 * time.Hour)
+	card := &domain.Card{
+		ID:         uuid.New(),
+		State:      state,
+		Stability:  12,
+		Difficulty: 5,
+		Due:        now.Add(24 * time.Hour),
+		LastReview: &lastReview,
+	}
+
+	cards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return card, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, params domain.SRSUpdateParams) (*domain.Card, error) {
+			updated := *card
+			updated.Due = params.Due
+			updated.ScheduledDays = params.ScheduledDays
+			updated.ElapsedDays = params.ElapsedDays
+			return &updated, nil
+		},
+	}
+	audit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+	}
+
+	svc := &Service{
+		cards: cards,
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: uid, DesiredRetention: 0.9, MaxIntervalDays: userMaxDays}, nil
+			},
+		},
+		audit: audit,
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:       slog.Default(),
+		clock:     &clockMock{NowFunc: func() time.Time { return now }},
+		srsConfig: domain.SRSConfig{MaxIntervalDays: 365},
+	}
+	return svc, cards, audit
+}
+
+func TestService_RescheduleCard_Success(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc, cards, audit := newRescheduleService(now, domain.CardStateReview, 365)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	due := now.Add(10 * 24 * time.Hour)
+
+	card, err := svc.RescheduleCard(ctx, RescheduleInput{CardID: uuid.New(), Due: due})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !card.Due.Equal(due) {
+		t.Errorf("Due: got %v, want %v", card.Due, due)
+	}
+	params := cards.UpdateSRSCalls()[0].Params
+	if params.ElapsedDays != 3 {
+		t.Errorf("ElapsedDays: got %d, want 3", params.ElapsedDays)
+	}
+	if params.ScheduledDays != 13 {
+		t.Errorf("ScheduledDays: got %d, want 13 (3 elapsed + 10 ahead)", params.ScheduledDays)
+	}
+	if params.Stability != 12 || params.Difficulty != 5 || params.State != domain.CardStateReview {
+		t.Errorf("memory state changed: %+v", params)
+	}
+
+	logs := audit.LogCalls()
+	if len(logs) != 1 {
+		t.Fatalf("audit calls: got %d, want 1", len(logs))
+	}
+	dueChange, ok := logs[0].Record.Changes["due"].(map[string]any)
+	if !ok || !dueChange["new"].(time.Time).Equal(due) {
+		t.Errorf("audit due change: got %v", logs[0].Record.Changes["due"])
+	}
+}
+
+func TestService_RescheduleCard_RejectsPastOrNow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	svc, cards, _ := newRescheduleService(now, domain.CardStateReview, 365)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	for _, due := range []time.Time{now, now.Add(-time.Hour)} {
+		_, err := svc.RescheduleCard(ctx, RescheduleInput{CardID: uuid.New(), Due: due})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("due %v: got %v, want ErrValidation", due, err)
+		}
+	}
+	if len(cards.UpdateSRSCalls()) != 0 {
+		t.Error("UpdateSRS must not be called for an invalid date")
+	}
+}
+
+func TestService_RescheduleCard_MaxIntervalCap(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	// The user's cap (30) is below the global one (365) and wins.
+	svc, _, _ := newRescheduleService(now, domain.CardStateReview, 30)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	if _, err := svc.RescheduleCard(ctx, RescheduleInput{CardID: uuid.New(), Due: now.Add(30 * 24 * time.Hour)}); err != nil {
+		t.Fatalf("due at the cap: unexpected error: %v", err)
+	}
+
+	_, err := svc.RescheduleCard(ctx, RescheduleInput{CardID: uuid.New(), Due: now.Add(30*24*time.Hour + time.Minute)})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("due past the cap: got %v, want ErrValidation", err)
+	}
+}
+
+func TestService_RescheduleCard_RejectsNonReviewStates(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	for _, state := range []domain.CardState{domain.CardStateLearning, domain.CardStateRelearning, domain.CardStateNew} {
+		svc, cards, _ := newRescheduleService(now, state, 365)
+
+		_, err := svc.RescheduleCard(ctx, RescheduleInput{CardID: uuid.New(), Due: now.Add(48 * time.Hour)})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("state %s: got %v, want ErrValidation", state, err)
+		}
+		if len(cards.UpdateSRSCalls()) != 0 {
+			t.Errorf("state %s: UpdateSRS must not be called", state)
+		}
+	}
+}
+
+func TestService_RescheduleCard_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{log: slog.Default(), clock: RealClock{}}
+
+	_, err := svc.RescheduleCard(context.Background(), RescheduleInput{CardID: uuid.New(), Due: time.Now().Add(time.Hour)})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetSessionReQueue Tests
+// ---------------------------------------------------------------------------
+
+func TestService_GetSessionReQueue_AgainCardSurfaces(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	lastReview := now.AddDate(0, 0, -5)
+
+	stored := &domain.Card{
+		ID:         uuid.New(),
+		UserID:     userID,
+		State:      domain.CardStateReview,
+		Stability:  8,
+		Difficulty: 5,
+		Due:        now,
+		LastReview: &lastReview,
+		Reps:       4,
+	}
+
+	cards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			c := *stored
+			return &c, nil
+		},
+		UpdateSRSFunc: func(ctx context.Context, uid, cid uuid.UUID, p domain.SRSUpdateParams) (*domain.Card, error) {
+			stored.State, stored.Step, stored.Due = p.State, p.Step, p.Due
+			stored.Stability, stored.Difficulty = p.Stability, p.Difficulty
+			c := *stored
+			return &c, nil
+		},
+		GetLearningDueFunc: func(ctx context.Context, uid uuid.UUID, before time.Time, limit int) ([]*domain.Card, error) {
+			if !before.Equal(now.Add(SessionReQueueWindow)) {
+				t.Errorf("before: got %v, want now + window", before)
+			}
+			var out []*domain.Card
+			if (stored.State == domain.CardStateLearning || stored.State == domain.CardStateRelearning) && !stored.Due.After(before) {
+				out = append(out, stored)
+			}
+			return out, nil
+		},
+	}
+
+	svc := &Service{
+		sessions: noopQueueSessions(),
+		cards:    cards,
+		reviews: &reviewLogRepoMock{
+			CreateFunc: func(ctx context.Context, log *domain.ReviewLog) (*domain.ReviewLog, error) { return log, nil },
+		},
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: uid, MaxIntervalDays: 365, DesiredRetention: 0.9}, nil
+			},
+		},
+		audit: &auditLoggerMock{
+			LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+		},
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log:         slog.Default(),
+		clock:       &clockMock{NowFunc: func() time.Time { return now }},
+		fsrsWeights: fsrs.DefaultWeights,
+		srsConfig: domain.SRSConfig{
+			LearningSteps:   []time.Duration{1 * time.Minute, 10 * time.Minute},
+			RelearningSteps: []time.Duration{10 * time.Minute},
+		},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	// Before the lapse the review card is not in the re-queue.
+	queue, err := svc.GetSessionReQueue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue) != 0 {
+		t.Fatalf("re-queue before review: got %d cards, want 0", len(queue))
+	}
+
+	reviewed, err := svc.ReviewCard(ctx, ReviewCardInput{CardID: stored.ID, Grade: domain.ReviewGradeAgain})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ShouldReQueue(reviewed, now) {
+		t.Errorf("ShouldReQueue: got false for AGAIN-graded card (state %s, due %v)", reviewed.State, reviewed.Due)
+	}
+
+	queue, err = svc.GetSessionReQueue(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queue) != 1 || queue[0].ID != stored.ID {
+		t.Fatalf("re-queue after AGAIN: got %v, want the lapsed card", queue)
+	}
+}
+
+func TestShouldReQueue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		state domain.CardState
+		due   time.Time
+		want  bool
+	}{
+		{"relearning soon", domain.CardStateRelearning, now.Add(10 * time.Minute), true},
+		{"learning at window edge", domain.CardStateLearning, now.Add(SessionReQueueWindow), true},
+		{"learning beyond window", domain.CardStateLearning, now.Add(SessionReQueueWindow + time.Second), false},
+		{"review due tomorrow", domain.CardStateReview, now.Add(24 * time.Hour), false},
+		{"review due now", domain.CardStateReview, now, false},
+	}
+	for _, tt := range tests {
+		if got := ShouldReQueue(&domain.Card{State: tt.state, Due: tt.due}, now); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestService_GetSessionReQueue_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{log: slog.Default(), clock: RealClock{}}
+
+	_, err := svc.GetSessionReQueue(context.Background())
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SkipCard Tests
+// ---------------------------------------------------------------------------
+
+func TestService_SkipCard_Success(t *testing.T) {
+	t.Parallel()
+
+	userID, cardID := uuid.New(), uuid.New()
+	mockCards := &cardRepoMock{
+		IncrementSkipCountFunc: func(ctx context.Context, uid, cid uuid.UUID) (int, error) {
+			return 3, nil
+		},
+	}
+	mockSessions := &sessionRepoMock{
+		MoveToQueueEndFunc: func(ctx context.Context, uid, cid uuid.UUID) error {
+			return errors.New("no active session table")
+		},
+	}
+	svc := &Service{cards: mockCards, sessions: mockSessions, log: slog.Default()}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	result, err := svc.SkipCard(ctx, SkipCardInput{CardID: cardID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.CardID != cardID || result.SkipCount != 3 {
+		t.Errorf("result = %+v, want card %s with skip count 3", result, cardID)
+	}
+
+	calls := mockSessions.MoveToQueueEndCalls()
+	if len(calls) != 1 || calls[0].UserID != userID || calls[0].CardID != cardID {
+		t.Errorf("MoveToQueueEnd calls = %+v", calls)
+	}
+}
+
+func TestService_SkipCard_NotFound(t *testing.T) {
+	t.Parallel()
+
+	mockCards := &cardRepoMock{
+		IncrementSkipCountFunc: func(ctx context.Context, uid, cid uuid.UUID) (int, error) {
+			return 0, domain.ErrNotFound
+		},
+	}
+	mockSessions := &sessionRepoMock{}
+	svc := &Service{cards: mockCards, sessions: mockSessions, log: slog.Default()}
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	if _, err := svc.SkipCard(ctx, SkipCardInput{CardID: uuid.New()}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+	if len(mockSessions.MoveToQueueEndCalls()) != 0 {
+		t.Error("session queue should not change for an unknown card")
+	}
+}
+
+func TestService_SkipCard_InvalidInput(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	if _, err := svc.SkipCard(ctx, SkipCardInput{}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("got %v, want ErrValidation", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// SuspendCard / UnsuspendCard
+// ---------------------------------------------------------------------------
+
+func newSuspendService(suspended bool) (*Service, *cardRepoMock, *auditLoggerMock) {
+	card := &domain.Card{ID: uuid.New(), State: domain.CardStateReview, Suspended: suspended}
+
+	cards := &cardRepoMock{
+		GetByIDForUpdateFunc: func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+			return card, nil
+		},
+		SetSuspendedFunc: func(ctx context.Context, uid, cid uuid.UUID, suspended bool) (*domain.Card, error) {
+			updated := *card
+			updated.Suspended = suspended
+			return &updated, nil
+		},
+	}
+	audit := &auditLoggerMock{
+		LogFunc: func(ctx context.Context, record domain.AuditRecord) error { return nil },
+	}
+
+	svc := &Service{
+		cards: cards,
+		audit: audit,
+		tx: &txManagerMock{
+			RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) },
+		},
+		log: slog.Default(),
+	}
+	return svc, cards, audit
+}
+
+func TestService_SuspendCard_Success(t *testing.T) {
+	t.Parallel()
+
+	svc, cards, audit := newSuspendService(false)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	card, err := svc.SuspendCard(ctx, SuspendCardInput{CardID: uuid.New()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !card.Suspended {
+		t.Error("Suspended: got false, want true")
+	}
+	if calls := cards.SetSuspendedCalls(); len(calls) != 1 || !calls[0].Suspended {
+		t.Errorf("SetSuspended calls: got %+v, want one call with true", calls)
+	}
+
+	logs := audit.LogCalls()
+	if len(logs) != 1 {
+		t.Fatalf("audit calls: got %d, want 1", len(logs))
+	}
+	rec := logs[0].Record
+	if rec.EntityType != domain.EntityTypeCard || rec.Action != domain.AuditActionUpdate {
+		t.Errorf("audit record: got %s/%s, want CARD/UPDATE", rec.EntityType, rec.Action)
+	}
+	change, ok := rec.Changes["suspended"].(map[string]any)
+	if !ok || change["old"] != false || change["new"] != true {
+		t.Errorf("audit suspended change: got %v", rec.Changes["suspended"])
+	}
+}
+
+func TestService_UnsuspendCard_Success(t *testing.T) {
+	t.Parallel()
+
+	svc, cards, audit := newSuspendService(true)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	card, err := svc.UnsuspendCard(ctx, SuspendCardInput{CardID: uuid.New()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if card.Suspended {
+		t.Error("Suspended: got true, want false")
+	}
+	if calls := cards.SetSuspendedCalls(); len(calls) != 1 || calls[0].Suspended {
+		t.Errorf("SetSuspended calls: got %+v, want one call with false", calls)
+	}
+	logs := audit.LogCalls()
+	if len(logs) != 1 {
+		t.Fatalf("audit calls: got %d, want 1", len(logs))
+	}
+	change, ok := logs[0].Record.Changes["suspended"].(map[string]any)
+	if !ok || change["old"] != true || change["new"] != false {
+		t.Errorf("audit suspended change: got %v", logs[0].Record.Changes["suspended"])
+	}
+}
+
+func TestService_SuspendCard_AlreadySuspendedIsNoop(t *testing.T) {
+	t.Parallel()
+
+	svc, cards, audit := newSuspendService(true)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	card, err := svc.SuspendCard(ctx, SuspendCardInput{CardID: uuid.New()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !card.Suspended {
+		t.Error("Suspended: got false, want true")
+	}
+	if len(cards.SetSuspendedCalls()) != 0 {
+		t.Error("SetSuspended must not be called for an already suspended card")
+	}
+	if len(audit.LogCalls()) != 0 {
+		t.Error("no audit record expected for a no-op")
+	}
+}
+
+func TestService_SuspendCard_NotFound(t *testing.T) {
+	t.Parallel()
+
+	svc, cards, _ := newSuspendService(false)
+	cards.GetByIDForUpdateFunc = func(ctx context.Context, uid, cid uuid.UUID) (*domain.Card, error) {
+		return nil, domain.ErrNotFound
+	}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	if _, err := svc.SuspendCard(ctx, SuspendCardInput{CardID: uuid.New()}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("got %v, want ErrNotFound", err)
+	}
+}
+
+func TestService_SuspendCard_RequiresCardID(t *testing.T) {
+	t.Parallel()
+
+	svc, _, _ := newSuspendService(false)
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	if _, err := svc.SuspendCard(ctx, SuspendCardInput{}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("got %v, want ErrValidation", err)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// GetDifficultWords
+// ---------------------------------------------------------------------------
+
+func TestService_GetGradeTrend_FillsGapsWithZeros(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+	// 01:30 on March 5 in Tokyo, still March 4 in UTC.
+	now := time.Date(2026, time.March, 4, 16, 30, 0, 0, time.UTC)
+	date := func(day int) time.Time { return time.Date(2026, time.March, day, 0, 0, 0, 0, time.UTC) }
+
+	mockReviews := &reviewLogRepoMock{
+		GetGradeCountsByDayFunc: func(ctx context.Context, uid uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error) {
+			if want := time.Date(2026, time.March, 1, 0, 0, 0, 0, tokyo); !from.Equal(want) || timezone != "Asia/Tokyo" {
+				t.Errorf("from %v in %q, want %v in Asia/Tokyo", from, timezone, want)
+			}
+			return []domain.DayGradeCounts{
+				{Date: date(2), Grades: domain.GradeCounts{Again: 2, Good: 3}},
+				{Date: date(5), Grades: domain.GradeCounts{Hard: 1, Easy: 4}},
+			}, nil
+		},
+	}
+	svc := &Service{
+		reviews: mockReviews,
+		settings: &settingsRepoMock{
+			GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+				return &domain.UserSettings{UserID: uid, Timezone: "Asia/Tokyo"}, nil
+			},
+		},
+		log:   slog.Default(),
+		clock: &clockMock{NowFunc: func() time.Time { return now }},
+	}
+
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	got, err := svc.GetGradeTrend(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetGradeTrend: %v", err)
+	}
+
+	want := []domain.DayGradeCounts{
+		{Date: date(1)},
+		{Date: date(2), Grades: domain.GradeCounts{Again: 2, Good: 3}},
+		{Date: date(3)},
+		{Date: date(4)},
+		{Date: date(5), Grades: domain.GradeCounts{Hard: 1, Easy: 4}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d days, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !got[i].Date.Equal(want[i].Date) || got[i].Grades != want[i].Grades {
+			t.Errorf("day %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestService_GetGradeTrend_DaysDefaultAndCap(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct{ days, want int }{{0, 30}, {-3, 30}, {7, 7}, {10_000, 365}} {
+		svc := &Service{
+			reviews: &reviewLogRepoMock{
+				GetGradeCountsByDayFunc: func(ctx context.Context, uid uuid.UUID, from time.Time, timezone string) ([]domain.DayGradeCounts, error) {
+					return nil, nil
+				},
+			},
+			settings: &settingsRepoMock{
+				GetByUserIDFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+					return &domain.UserSettings{UserID: uid, Timezone: "UTC"}, nil
+				},
+			},
+			log:   slog.Default(),
+			clock: RealClock{},
+		}
+
+		ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+		got, err := svc.GetGradeTrend(ctx, tt.days)
+		if err != nil {
+			t.Fatalf("GetGradeTrend(%d): %v", tt.days, err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("GetGradeTrend(%d): got %d days, want %d", tt.days, len(got), tt.want)
+		}
+	}
+}
+
+func TestService_GetDifficultWords_PassesMinReviewsAndLimit(t *testing.T) {
+	t.Parallel()
+
+	want := []domain.DifficultWord{
+		{EntryID: uuid.New(), Text: "ubiquitous", TotalReviews: 4, AgainCount: 3, LapseRate: 0.75},
+		{EntryID: uuid.New(), Text: "ephemeral", TotalReviews: 5, AgainCount: 2, LapseRate: 0.4},
+	}
+	reviews := &reviewLogRepoMock{
+		GetDifficultWordsFunc: func(ctx context.Context, userID uuid.UUID, minReviews, limit int) ([]domain.DifficultWord, error) {
+			return want, nil
+		},
+	}
+	svc := &Service{reviews: reviews, log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	got, err := svc.GetDifficultWords(ctx, GetDifficultWordsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].Text != "ubiquitous" {
+		t.Errorf("words: got %+v, want %+v", got, want)
+	}
+
+	call := reviews.GetDifficultWordsCalls()[0]
+	if call.MinReviews != difficultWordsMinReviews {
+		t.Errorf("minReviews: got %d, want %d", call.MinReviews, difficultWordsMinReviews)
+	}
+	if call.Limit != defaultDifficultWords {
+		t.Errorf("limit: got %d, want default %d", call.Limit, defaultDifficultWords)
+	}
+
+	if _, err := svc.GetDifficultWords(ctx, GetDifficultWordsInput{Limit: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if call := reviews.GetDifficultWordsCalls()[1]; call.Limit != 5 {
+		t.Errorf("limit: got %d, want 5", call.Limit)
+	}
+}
+
+func TestService_GetDifficultWords_InvalidLimit(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{reviews: &reviewLogRepoMock{}, log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	for _, limit := range []int{-1, 101} {
+		if _, err := svc.GetDifficultWords(ctx, GetDifficultWordsInput{Limit: limit}); !errors.Is(err, domain.ErrValidation) {
+			t.Errorf("limit %d: got %v, want ErrValidation", limit, err)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// ExportReviewLogsCSV
+// ---------------------------------------------------------------------------
+
+func TestService_ExportReviewLogsCSV_StreamsAllPages(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cardID := uuid.New()
+	duration := 1500
+
+	// One full page followed by a short one.
+	total := reviewExportPageSize + 3
+	all := make([]domain.ReviewLogWithWord, total)
+	for i := range all {
+		all[i] = domain.ReviewLogWithWord{
+			ReviewLog: domain.ReviewLog{
+				ID:         uuid.New(),
+				CardID:     cardID,
+				Grade:      domain.ReviewGradeGood,
+				ReviewedAt: from.Add(time.Duration(i) * time.Minute),
+			},
+			Word: "serendipity",
+		}
+	}
+	all[0].Grade = domain.ReviewGradeAgain
+	all[0].PrevState = &domain.CardSnapshot{State: domain.CardStateNew}
+	all[0].DurationMs = &duration
+
+	reviews := &reviewLogRepoMock{
+		GetByPeriodPageFunc: func(ctx context.Context, userID uuid.UUID, from, to time.Time, after *domain.ReviewLogCursor, limit int) ([]domain.ReviewLogWithWord, error) {
+			start := 0
+			if after != nil {
+				start = slices.IndexFunc(all, func(rl domain.ReviewLogWithWord) bool { return rl.ID == after.ID }) + 1
+			}
+			return all[start:min(start+limit, len(all))], nil
+		},
+	}
+	svc := &Service{reviews: reviews, log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+
+	var buf strings.Builder
+	err := svc.ExportReviewLogsCSV(ctx, ExportReviewLogsInput{From: from, To: from.AddDate(0, 1, 0)}, &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "card_id,word,grade,prev_state,duration_ms,reviewed_at" {
+		t.Errorf("header: got %q", lines[0])
+	}
+	if len(lines) != total+1 {
+		t.Fatalf("lines: got %d, want %d (header + %d rows)", len(lines), total+1, total)
+	}
+	wantFirst := cardID.String() + ",serendipity,AGAIN,NEW,1500,2026-01-01T00:00:00Z"
+	if lines[1] != wantFirst {
+		t.Errorf("first row: got %q, want %q", lines[1], wantFirst)
+	}
+	wantLast := cardID.String() + ",serendipity,GOOD,,," + all[total-1].ReviewedAt.Format(time.RFC3339)
+	if lines[total] != wantLast {
+		t.Errorf("last row: got %q, want %q", lines[total], wantLast)
+	}
+
+	calls := reviews.GetByPeriodPageCalls()
+	if len(calls) != 2 {
+		t.Fatalf("page queries: got %d, want 2", len(calls))
+	}
+	if calls[0].After != nil {
+		t.Errorf("first page cursor: got %+v, want nil", calls[0].After)
+	}
+	if c := calls[1].After; c == nil || c.ID != all[reviewExportPageSize-1].ID {
+		t.Errorf("second page cursor: got %+v, want last row of the first page", c)
+	}
+}
+
+func TestService_ExportReviewLogsCSV_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	reviews := &reviewLogRepoMock{}
+	svc := &Service{reviews: reviews, log: slog.Default()}
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var buf strings.Builder
+	err := svc.ExportReviewLogsCSV(ctx, ExportReviewLogsInput{From: from, To: from.AddDate(3, 0, 0)}, &buf)
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("got %v, want ErrValidation", err)
+	}
+	if buf.Len() != 0 || len(reviews.GetByPeriodPageCalls()) != 0 {
+		t.Error("nothing must be written or queried for an invalid range")
+	}
+}
@@ -27,6 +27,33 @@ func (s *Service) GetActiveSession(ctx context.Context) (*domain.StudySession, e
 	return session, nil
 }
 
+// GetSessionProgress returns the results of the user's active session so far,
+// aggregated the same way FinishSession does, without finishing it.
+// Returns nil if there is no active session.
+func (s *Service) GetSessionProgress(ctx context.Context) (*domain.SessionResult, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.sessions.GetActive(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	now := s.clock.Now()
+	logs, err := s.reviews.GetByPeriod(ctx, userID, session.StartedAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("get review logs: %w", err)
+	}
+
+	result := aggregateSessionResult(logs, session.StartedAt, now)
+	return &result, nil
+}
+
 // StartSession starts a new study session or returns existing ACTIVE session (idempotent).
 func (s *Service) StartSession(ctx context.Context) (*domain.StudySession, error) {
 	userID, err := s.userID(ctx)
@@ -48,12 +75,22 @@ func (s *Service) StartSession(ctx context.Context) (*domain.StudySession, error
 		return nil, fmt.Errorf("check active session: %w", err)
 	}
 
-	// No active session - create new one
+	// No active session - snapshot the current queue so the session can be resumed
+	queue, err := s.buildQueue(ctx, userID, defaultQueueLimit, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build queue snapshot: %w", err)
+	}
+	queueIDs := make([]uuid.UUID, len(queue))
+	for i, c := range queue {
+		queueIDs[i] = c.ID
+	}
+
 	session := &domain.StudySession{
-		ID:        uuid.New(),
-		UserID:    userID,
-		Status:    domain.SessionStatusActive,
-		StartedAt: s.clock.Now(),
+		ID:           uuid.New(),
+		UserID:       userID,
+		Status:       domain.SessionStatusActive,
+		StartedAt:    s.clock.Now(),
+		QueueCardIDs: queueIDs,
 	}
 
 	created, err := s.sessions.Create(ctx, session)
@@ -74,6 +111,8 @@ func (s *Service) StartSession(ctx context.Context) (*domain.StudySession, error
 		return nil, fmt.Errorf("create session: %w", err)
 	}
 
+	s.metrics.sessionStarted()
+
 	s.log.InfoContext(ctx, "session started",
 		slog.String("user_id", userID.String()),
 		slog.String("session_id", created.ID.String()),
@@ -82,6 +121,36 @@ func (s *Service) StartSession(ctx context.Context) (*domain.StudySession, error
 	return created, nil
 }
 
+// ResumeSession returns the user's ACTIVE session together with the card IDs
+// still left in its queue snapshot, in the original order. Cards that can no
+// longer be studied (deleted, or their entry was removed) are dropped.
+// Returns domain.ErrNotFound if there is no active session.
+func (s *Service) ResumeSession(ctx context.Context) (*domain.StudySession, []uuid.UUID, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := s.sessions.GetActive(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get active session: %w", err)
+	}
+
+	studyable, err := s.cards.StudyableByIDs(ctx, userID, session.QueueCardIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("check queued cards: %w", err)
+	}
+
+	remaining := make([]uuid.UUID, 0, len(session.QueueCardIDs))
+	for _, id := range session.QueueCardIDs {
+		if studyable[id] {
+			remaining = append(remaining, id)
+		}
+	}
+
+	return session, remaining, nil
+}
+
 // FinishActiveSession finishes the user's current ACTIVE session.
 func (s *Service) FinishActiveSession(ctx context.Context) (*domain.StudySession, error) {
 	userID, err := s.userID(ctx)
@@ -142,6 +211,8 @@ func (s *Service) finishSession(ctx context.Context, userID uuid.UUID, session *
 		return nil, fmt.Errorf("finish session: %w", err)
 	}
 
+	s.metrics.sessionFinished()
+
 	s.log.InfoContext(ctx, "session finished",
 		slog.String("user_id", userID.String()),
 		slog.String("session_id", session.ID.String()),
@@ -172,6 +243,8 @@ func (s *Service) AbandonSession(ctx context.Context) error {
 		return fmt.Errorf("abandon session: %w", err)
 	}
 
+	s.metrics.sessionAbandoned()
+
 	s.log.InfoContext(ctx, "session abandoned",
 		slog.String("user_id", userID.String()),
 		slog.String("session_id", session.ID.String()),
@@ -0,0 +1,45 @@
+package study
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// SessionReQueueWindow is how far ahead a short-step card may be due and
+// still be shown again in the current session. It covers the default 10m
+// relearning step.
+const SessionReQueueWindow = 15 * time.Minute
+
+// reQueueLimit caps how many cards GetSessionReQueue returns.
+const reQueueLimit = 50
+
+// ShouldReQueue reports whether a card returned by ReviewCard should be shown
+// again in the current session: it is in (re)learning and its next step falls
+// inside SessionReQueueWindow. Cards graded AGAIN always qualify with the
+// default steps.
+func ShouldReQueue(card *domain.Card, now time.Time) bool {
+	if card.State != domain.CardStateLearning && card.State != domain.CardStateRelearning {
+		return false
+	}
+	return !card.Due.After(now.Add(SessionReQueueWindow))
+}
+
+// GetSessionReQueue returns LEARNING and RELEARNING cards due within
+// SessionReQueueWindow, soonest first. The client appends them to the
+// session it already fetched so failed cards come back without a refetch.
+func (s *Service) GetSessionReQueue(ctx context.Context) ([]*domain.Card, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cards.GetLearningDue(ctx, userID, s.clock.Now().Add(SessionReQueueWindow), reQueueLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get learning due: %w", err)
+	}
+
+	return cards, nil
+}
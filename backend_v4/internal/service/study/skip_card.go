@@ -0,0 +1,45 @@
+package study
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// SkipCard postpones a card within the current session without grading it.
+// The card's SRS state is untouched; only its skip counter is incremented
+// for analytics, and the card is moved to the end of the active session's
+// queue snapshot. See SkipCardResult for what the client should do.
+func (s *Service) SkipCard(ctx context.Context, input SkipCardInput) (*SkipCardResult, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	skipCount, err := s.cards.IncrementSkipCount(ctx, userID, input.CardID)
+	if err != nil {
+		return nil, fmt.Errorf("increment skip count: %w", err)
+	}
+
+	// Best-effort, like RemoveFromQueue after a review: the snapshot only
+	// serves session resumption.
+	if err := s.sessions.MoveToQueueEnd(ctx, userID, input.CardID); err != nil {
+		s.log.WarnContext(ctx, "move card to end of session queue",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", input.CardID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	s.log.InfoContext(ctx, "card skipped",
+		slog.String("user_id", userID.String()),
+		slog.String("card_id", input.CardID.String()),
+		slog.Int("skip_count", skipCount),
+	)
+
+	return &SkipCardResult{CardID: input.CardID, SkipCount: skipCount}, nil
+}
@@ -5,9 +5,14 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/tzutil"
 )
 
+// defaultQueueLimit is the queue size used when the caller does not specify one.
+const defaultQueueLimit = 50
+
 // GetStudyQueue returns cards ready for review (due cards + new cards respecting daily limit).
 func (s *Service) GetStudyQueue(ctx context.Context, input GetQueueInput) ([]*domain.Card, error) {
 	userID, err := s.userID(ctx)
@@ -21,9 +26,17 @@ func (s *Service) GetStudyQueue(ctx context.Context, input GetQueueInput) ([]*do
 
 	limit := input.Limit
 	if limit == 0 {
-		limit = 50
+		limit = defaultQueueLimit
 	}
 
+	return s.buildQueue(ctx, userID, limit, input.ExcludeCardIDs)
+}
+
+// buildQueue assembles the study queue: due cards first, then new cards up to
+// the user's remaining daily new-card allowance. With InterleaveNew set, the
+// new cards are spread among the due cards instead. Cards in exclude are left
+// out; each fetch asks for len(exclude) extra rows so the queue still fills.
+func (s *Service) buildQueue(ctx context.Context, userID uuid.UUID, limit int, exclude []uuid.UUID) ([]*domain.Card, error) {
 	now := s.clock.Now()
 
 	// Load user settings for limits and timezone
@@ -32,8 +45,8 @@ func (s *Service) GetStudyQueue(ctx context.Context, input GetQueueInput) ([]*do
 		return nil, fmt.Errorf("load settings: %w", err)
 	}
 
-	tz := ParseTimezone(settings.Timezone)
-	dayStart := DayStart(now, tz)
+	tz := tzutil.Location(settings.Timezone, s.log)
+	dayStart := tzutil.DayStart(now, tz)
 
 	// Count new cards reviewed today
 	newToday, err := s.reviews.CountNewToday(ctx, userID, dayStart)
@@ -46,20 +59,31 @@ func (s *Service) GetStudyQueue(ctx context.Context, input GetQueueInput) ([]*do
 	// Due cards are always returned regardless of ReviewsPerDay setting.
 	// Design decision: hiding due cards degrades long-term retention (Anki behaviour).
 	// ReviewsPerDay is an informational goal shown in dashboard UI, not a hard limit.
-	dueCards, err := s.cards.GetDueCards(ctx, userID, now, limit)
+	excluded := make(map[uuid.UUID]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	dueCards, err := s.cards.GetDueCards(ctx, userID, now, limit+len(excluded), settings.DueCardOrder)
 	if err != nil {
 		return nil, fmt.Errorf("get due cards: %w", err)
 	}
+	dueCards = withoutCards(dueCards, excluded, limit)
 
 	// Fill remaining slots with new cards
 	queue := dueCards
 	if len(dueCards) < limit && newRemaining > 0 {
 		newLimit := min(limit-len(dueCards), newRemaining)
-		newCards, err := s.cards.GetNewCards(ctx, userID, newLimit)
+		newCards, err := s.cards.GetNewCards(ctx, userID, newLimit+len(excluded), settings.NewCardOrder)
 		if err != nil {
 			return nil, fmt.Errorf("get new cards: %w", err)
 		}
-		queue = append(queue, newCards...)
+		newCards = withoutCards(newCards, excluded, newLimit)
+		if settings.InterleaveNew {
+			queue = interleaveNew(dueCards, newCards, s.srsConfig.NewCardInterleaveRatio)
+		} else {
+			queue = append(queue, newCards...)
+		}
 	}
 
 	s.log.InfoContext(ctx, "study queue generated",
@@ -71,3 +95,38 @@ func (s *Service) GetStudyQueue(ctx context.Context, input GetQueueInput) ([]*do
 
 	return queue, nil
 }
+
+// interleaveNew places one new card after every ratio due cards. New cards
+// left over once the due cards run out go at the end. A ratio below 1 is
+// treated as 1.
+func interleaveNew(due, newCards []*domain.Card, ratio int) []*domain.Card {
+	ratio = max(ratio, 1)
+
+	queue := make([]*domain.Card, 0, len(due)+len(newCards))
+	n := 0
+	for i, c := range due {
+		queue = append(queue, c)
+		if (i+1)%ratio == 0 && n < len(newCards) {
+			queue = append(queue, newCards[n])
+			n++
+		}
+	}
+	return append(queue, newCards[n:]...)
+}
+
+// withoutCards drops cards in excluded and truncates the result to limit.
+func withoutCards(cards []*domain.Card, excluded map[uuid.UUID]bool, limit int) []*domain.Card {
+	if len(excluded) > 0 {
+		kept := cards[:0]
+		for _, c := range cards {
+			if !excluded[c.ID] {
+				kept = append(kept, c)
+			}
+		}
+		cards = kept
+	}
+	if len(cards) > limit {
+		cards = cards[:limit]
+	}
+	return cards
+}
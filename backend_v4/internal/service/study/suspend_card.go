@@ -0,0 +1,89 @@
+package study
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+)
+
+// SuspendCard excludes a card from study queues and due/new counts until it is
+// unsuspended. Scheduling state is kept, so unsuspending resumes where the card
+// left off. Suspending an already suspended card is a no-op.
+func (s *Service) SuspendCard(ctx context.Context, input SuspendCardInput) (*domain.Card, error) {
+	return s.setSuspended(ctx, input, true)
+}
+
+// UnsuspendCard returns a suspended card to study queues. Unsuspending a card
+// that is not suspended is a no-op.
+func (s *Service) UnsuspendCard(ctx context.Context, input SuspendCardInput) (*domain.Card, error) {
+	return s.setSuspended(ctx, input, false)
+}
+
+func (s *Service) setSuspended(ctx context.Context, input SuspendCardInput, suspended bool) (*domain.Card, error) {
+	userID, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		updatedCard *domain.Card
+		changed     bool
+	)
+
+	err = s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		card, cardErr := s.cards.GetByIDForUpdate(txCtx, userID, input.CardID)
+		if cardErr != nil {
+			return fmt.Errorf("get card: %w", cardErr)
+		}
+
+		if card.Suspended == suspended {
+			updatedCard = card
+			return nil
+		}
+
+		var updateErr error
+		updatedCard, updateErr = s.cards.SetSuspended(txCtx, userID, card.ID, suspended)
+		if updateErr != nil {
+			return fmt.Errorf("set suspended: %w", updateErr)
+		}
+		changed = true
+
+		auditErr := s.audit.Log(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeCard,
+			EntityID:   &card.ID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"suspended": map[string]any{"old": card.Suspended, "new": suspended},
+			},
+		})
+		if auditErr != nil {
+			return fmt.Errorf("audit log: %w", auditErr)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if changed {
+		msg := "card unsuspended"
+		if suspended {
+			msg = "card suspended"
+		}
+		s.log.InfoContext(ctx, msg,
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", input.CardID.String()),
+		)
+	}
+
+	return updatedCard, nil
+}
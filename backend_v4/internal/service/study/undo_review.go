@@ -57,8 +57,11 @@ func (s *Service) UndoReview(ctx context.Context, input UndoReviewInput) (*domai
 		undoneGrade = lastLog.Grade
 		restoredState = lastLog.PrevState.State
 
+		restore := snapshotToUpdateParams(lastLog.PrevState)
+		restore.Version = card.Version
+
 		var restoreErr error
-		restoredCard, restoreErr = s.cards.UpdateSRS(txCtx, userID, card.ID, snapshotToUpdateParams(lastLog.PrevState))
+		restoredCard, restoreErr = s.cards.UpdateSRS(txCtx, userID, card.ID, restore)
 		if restoreErr != nil {
 			return fmt.Errorf("restore card: %w", restoreErr)
 		}
@@ -69,18 +72,14 @@ func (s *Service) UndoReview(ctx context.Context, input UndoReviewInput) (*domai
 		}
 
 		// Audit
+		changes := srsDiff(snapshotFromCard(card), lastLog.PrevState)
+		changes["undo"] = map[string]any{"old": lastLog.Grade}
 		auditErr := s.audit.Log(txCtx, domain.AuditRecord{
 			UserID:     userID,
 			EntityType: domain.EntityTypeCard,
 			EntityID:   &card.ID,
 			Action:     domain.AuditActionUpdate,
-			Changes: map[string]any{
-				"undo": map[string]any{"old": lastLog.Grade},
-				"state": map[string]any{
-					"old": card.State,
-					"new": lastLog.PrevState.State,
-				},
-			},
+			Changes:    changes,
 		})
 		if auditErr != nil {
 			return fmt.Errorf("audit log: %w", auditErr)
@@ -97,6 +96,16 @@ func (s *Service) UndoReview(ctx context.Context, input UndoReviewInput) (*domai
 		return nil, fmt.Errorf("card restore failed: no result returned")
 	}
 
+	// Best-effort, like the RemoveFromQueue call in ReviewCard: the card is
+	// due again, so a resumed session should offer it next.
+	if err := s.sessions.ReturnToQueue(ctx, userID, restoredCard.ID); err != nil {
+		s.log.WarnContext(ctx, "return card to session queue",
+			slog.String("user_id", userID.String()),
+			slog.String("card_id", restoredCard.ID.String()),
+			slog.String("error", err.Error()),
+		)
+	}
+
 	s.log.InfoContext(ctx, "review undone",
 		slog.String("user_id", userID.String()),
 		slog.String("card_id", input.CardID.String()),
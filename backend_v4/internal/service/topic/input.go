@@ -106,6 +106,28 @@ func (i LinkEntryInput) Validate() error {
 	return nil
 }
 
+// AssignEntryInput holds the parameters for moving an entry to a single
+// topic. A nil TopicID removes the entry from all topics.
+type AssignEntryInput struct {
+	EntryID uuid.UUID
+	TopicID *uuid.UUID
+}
+
+// Validate checks all fields and collects all errors.
+func (i AssignEntryInput) Validate() error {
+	var errs []domain.FieldError
+	if i.EntryID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "entry_id", Message: "required"})
+	}
+	if i.TopicID != nil && *i.TopicID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "topic_id", Message: "must not be empty"})
+	}
+	if len(errs) > 0 {
+		return &domain.ValidationError{Errors: errs}
+	}
+	return nil
+}
+
 // UnlinkEntryInput holds the parameters for unlinking an entry from a topic.
 type UnlinkEntryInput struct {
 	TopicID uuid.UUID
@@ -150,3 +172,28 @@ func (i BatchLinkEntriesInput) Validate() error {
 	}
 	return nil
 }
+
+// BatchAssignInput holds the parameters for moving several entries into a
+// single topic. A nil TopicID removes the entries from all topics.
+type BatchAssignInput struct {
+	EntryIDs []uuid.UUID
+	TopicID  *uuid.UUID
+}
+
+// Validate checks all fields and collects all errors.
+func (i BatchAssignInput) Validate() error {
+	var errs []domain.FieldError
+	if len(i.EntryIDs) == 0 {
+		errs = append(errs, domain.FieldError{Field: "entry_ids", Message: "at least one entry required"})
+	}
+	if len(i.EntryIDs) > 100 {
+		errs = append(errs, domain.FieldError{Field: "entry_ids", Message: "max 100 entries per batch"})
+	}
+	if i.TopicID != nil && *i.TopicID == uuid.Nil {
+		errs = append(errs, domain.FieldError{Field: "topic_id", Message: "must not be empty"})
+	}
+	if len(errs) > 0 {
+		return &domain.ValidationError{Errors: errs}
+	}
+	return nil
+}
@@ -199,3 +199,163 @@ func (s *Service) BatchLinkEntries(ctx context.Context, input BatchLinkEntriesIn
 
 	return result, nil
 }
+
+// AssignEntryToTopic moves an entry into exactly one topic, replacing its
+// existing links. A nil TopicID removes the entry from all topics.
+func (s *Service) AssignEntryToTopic(ctx context.Context, input AssignEntryInput) error {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	err := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		// Check entry ownership (also filters soft-deleted)
+		if _, err := s.entries.GetByID(txCtx, userID, input.EntryID); err != nil {
+			return fmt.Errorf("get entry: %w", err)
+		}
+
+		if input.TopicID != nil {
+			if _, err := s.topics.GetByID(txCtx, userID, *input.TopicID); err != nil {
+				return fmt.Errorf("get topic: %w", err)
+			}
+		}
+
+		oldTopics, err := s.topics.GetTopicsByEntryID(txCtx, input.EntryID)
+		if err != nil {
+			return fmt.Errorf("get entry topics: %w", err)
+		}
+		oldTopicIDs := make([]uuid.UUID, len(oldTopics))
+		for i, t := range oldTopics {
+			oldTopicIDs[i] = t.ID
+		}
+
+		if err := s.topics.UnlinkAllFromEntry(txCtx, input.EntryID); err != nil {
+			return fmt.Errorf("unlink entry topics: %w", err)
+		}
+
+		if input.TopicID != nil {
+			if err := s.topics.LinkEntry(txCtx, input.EntryID, *input.TopicID); err != nil {
+				return fmt.Errorf("link entry: %w", err)
+			}
+		}
+
+		if auditErr := s.audit.Log(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeEntry,
+			EntityID:   &input.EntryID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"topics": map[string]any{"old": oldTopicIDs, "new": input.TopicID},
+			},
+		}); auditErr != nil {
+			return fmt.Errorf("audit log: %w", auditErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.log.InfoContext(ctx, "entry assigned to topic",
+		slog.String("user_id", userID.String()),
+		slog.String("entry_id", input.EntryID.String()),
+		slog.Any("topic_id", input.TopicID),
+	)
+
+	return nil
+}
+
+// BatchAssignTopic moves several entries into exactly one topic, replacing
+// their existing links. A nil TopicID removes them from all topics. Entries
+// that do not exist or belong to another user are reported in NotFound.
+func (s *Service) BatchAssignTopic(ctx context.Context, input BatchAssignInput) (*BatchAssignResult, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Deduplicate entry IDs before any DB work.
+	seen := make(map[uuid.UUID]struct{}, len(input.EntryIDs))
+	var uniqueIDs []uuid.UUID
+	for _, id := range input.EntryIDs {
+		if _, exists := seen[id]; !exists {
+			seen[id] = struct{}{}
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+
+	result := &BatchAssignResult{NotFound: []uuid.UUID{}}
+	err := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		// Reset in case RunInTx reruns the closure after a serialization failure.
+		result.NotFound = result.NotFound[:0]
+
+		if input.TopicID != nil {
+			if _, err := s.topics.GetByID(txCtx, userID, *input.TopicID); err != nil {
+				return fmt.Errorf("get topic: %w", err)
+			}
+		}
+
+		existing, err := s.entries.ExistByIDs(txCtx, userID, uniqueIDs)
+		if err != nil {
+			return fmt.Errorf("check entries: %w", err)
+		}
+
+		var validEntryIDs []uuid.UUID
+		for _, id := range uniqueIDs {
+			if existing[id] {
+				validEntryIDs = append(validEntryIDs, id)
+			} else {
+				result.NotFound = append(result.NotFound, id)
+			}
+		}
+
+		if len(validEntryIDs) == 0 {
+			return nil
+		}
+
+		if err := s.topics.SetTopicBatch(txCtx, validEntryIDs, input.TopicID); err != nil {
+			return fmt.Errorf("set topic batch: %w", err)
+		}
+		result.Assigned = len(validEntryIDs)
+
+		if auditErr := s.audit.Log(txCtx, domain.AuditRecord{
+			UserID:     userID,
+			EntityType: domain.EntityTypeTopic,
+			EntityID:   input.TopicID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"batch_assigned_entries": map[string]any{
+					"entry_ids": validEntryIDs,
+					"topic_id":  input.TopicID,
+					"assigned":  result.Assigned,
+				},
+			},
+		}); auditErr != nil {
+			return fmt.Errorf("audit log: %w", auditErr)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.InfoContext(ctx, "entries batch assigned to topic",
+		slog.String("user_id", userID.String()),
+		slog.Any("topic_id", input.TopicID),
+		slog.Int("requested", len(input.EntryIDs)),
+		slog.Int("assigned", result.Assigned),
+		slog.Int("not_found", len(result.NotFound)),
+	)
+
+	return result, nil
+}
@@ -919,3 +919,274 @@ func TestBatchLinkEntries_DuplicateEntryIDs(t *testing.T) {
 		t.Errorf("expected 2 skipped (3 requested - 1 linked), got %d", result.Skipped)
 	}
 }
+
+// --- AssignEntryToTopic tests ---
+
+func TestAssignEntryToTopic_ReplacesExistingLinks(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	entryID := uuid.New()
+	oldTopicID := uuid.New()
+	newTopicID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	var calls []string
+	topicsMock := &topicRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, tid uuid.UUID) (*domain.Topic, error) {
+			return &domain.Topic{ID: tid, UserID: uid}, nil
+		},
+		GetTopicsByEntryIDFunc: func(_ context.Context, eid uuid.UUID) ([]*domain.Topic, error) {
+			return []*domain.Topic{{ID: oldTopicID}}, nil
+		},
+		UnlinkAllFromEntryFunc: func(_ context.Context, eid uuid.UUID) error {
+			calls = append(calls, "unlink_all")
+			return nil
+		},
+		LinkEntryFunc: func(_ context.Context, eid, tid uuid.UUID) error {
+			calls = append(calls, "link:"+tid.String())
+			return nil
+		},
+	}
+	entriesMock := &entryRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
+			return &domain.Entry{ID: eid, UserID: uid}, nil
+		},
+	}
+
+	var audited domain.AuditRecord
+	svc := NewService(
+		slog.Default(),
+		topicsMock,
+		entriesMock,
+		&auditLoggerMock{LogFunc: func(_ context.Context, r domain.AuditRecord) error { audited = r; return nil }},
+		&txManagerMock{RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }},
+	)
+
+	err := svc.AssignEntryToTopic(ctx, AssignEntryInput{EntryID: entryID, TopicID: &newTopicID})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []string{"unlink_all", "link:" + newTopicID.String()}
+	if len(calls) != 2 || calls[0] != want[0] || calls[1] != want[1] {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if audited.EntityType != domain.EntityTypeEntry || *audited.EntityID != entryID {
+		t.Errorf("audit record = %+v, want entry %s", audited, entryID)
+	}
+	change, ok := audited.Changes["topics"].(map[string]any)
+	if !ok {
+		t.Fatalf("audit changes missing topics: %+v", audited.Changes)
+	}
+	if old, _ := change["old"].([]uuid.UUID); len(old) != 1 || old[0] != oldTopicID {
+		t.Errorf("audit old topics = %v, want [%s]", change["old"], oldTopicID)
+	}
+}
+
+func TestAssignEntryToTopic_NilTopicDetaches(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	topicsMock := &topicRepoMock{
+		GetTopicsByEntryIDFunc: func(_ context.Context, eid uuid.UUID) ([]*domain.Topic, error) {
+			return nil, nil
+		},
+		UnlinkAllFromEntryFunc: func(_ context.Context, eid uuid.UUID) error {
+			return nil
+		},
+	}
+	entriesMock := &entryRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
+			return &domain.Entry{ID: eid, UserID: uid}, nil
+		},
+	}
+
+	svc := newLinkTestService(t, topicsMock, entriesMock)
+	if err := svc.AssignEntryToTopic(ctx, AssignEntryInput{EntryID: uuid.New()}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(topicsMock.UnlinkAllFromEntryCalls()) != 1 {
+		t.Errorf("expected 1 UnlinkAllFromEntry call, got %d", len(topicsMock.UnlinkAllFromEntryCalls()))
+	}
+	if len(topicsMock.LinkEntryCalls()) != 0 {
+		t.Errorf("expected no LinkEntry calls, got %d", len(topicsMock.LinkEntryCalls()))
+	}
+	if len(topicsMock.GetByIDCalls()) != 0 {
+		t.Errorf("expected no topic lookup for nil topic, got %d", len(topicsMock.GetByIDCalls()))
+	}
+}
+
+func TestAssignEntryToTopic_WrongUserTopic(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	topicID := uuid.New()
+
+	topicsMock := &topicRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, tid uuid.UUID) (*domain.Topic, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+	entriesMock := &entryRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, eid uuid.UUID) (*domain.Entry, error) {
+			return &domain.Entry{ID: eid, UserID: uid}, nil
+		},
+	}
+
+	svc := newLinkTestService(t, topicsMock, entriesMock)
+	err := svc.AssignEntryToTopic(ctx, AssignEntryInput{EntryID: uuid.New(), TopicID: &topicID})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if len(topicsMock.UnlinkAllFromEntryCalls()) != 0 {
+		t.Error("links must not change when the topic is not owned")
+	}
+}
+
+func TestAssignEntryToTopic_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := newLinkTestService(t, &topicRepoMock{}, &entryRepoMock{})
+	err := svc.AssignEntryToTopic(context.Background(), AssignEntryInput{EntryID: uuid.New()})
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+// --- BatchAssignTopic tests ---
+
+func TestBatchAssignTopic_PartialNotFound(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	topicID := uuid.New()
+	found1, found2, missing := uuid.New(), uuid.New(), uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	topicsMock := &topicRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, tid uuid.UUID) (*domain.Topic, error) {
+			return &domain.Topic{ID: tid, UserID: uid}, nil
+		},
+		SetTopicBatchFunc: func(_ context.Context, ids []uuid.UUID, tid *uuid.UUID) error {
+			return nil
+		},
+	}
+	entriesMock := &entryRepoMock{
+		ExistByIDsFunc: func(_ context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{found1: true, found2: true}, nil
+		},
+	}
+
+	var auditCalls int
+	svc := NewService(
+		slog.Default(),
+		topicsMock,
+		entriesMock,
+		&auditLoggerMock{LogFunc: func(_ context.Context, r domain.AuditRecord) error { auditCalls++; return nil }},
+		&txManagerMock{RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) }},
+	)
+
+	result, err := svc.BatchAssignTopic(ctx, BatchAssignInput{
+		EntryIDs: []uuid.UUID{found1, missing, found2, found1},
+		TopicID:  &topicID,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if result.Assigned != 2 {
+		t.Errorf("Assigned = %d, want 2", result.Assigned)
+	}
+	if len(result.NotFound) != 1 || result.NotFound[0] != missing {
+		t.Errorf("NotFound = %v, want [%s]", result.NotFound, missing)
+	}
+
+	calls := topicsMock.SetTopicBatchCalls()
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 SetTopicBatch call, got %d", len(calls))
+	}
+	if len(calls[0].EntryIDs) != 2 || *calls[0].TopicID != topicID {
+		t.Errorf("SetTopicBatch called with %v, %v", calls[0].EntryIDs, calls[0].TopicID)
+	}
+	if auditCalls != 1 {
+		t.Errorf("expected 1 audit record, got %d", auditCalls)
+	}
+}
+
+func TestBatchAssignTopic_TopicNotOwned(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	topicID := uuid.New()
+
+	topicsMock := &topicRepoMock{
+		GetByIDFunc: func(_ context.Context, uid, tid uuid.UUID) (*domain.Topic, error) {
+			return nil, domain.ErrNotFound
+		},
+	}
+	entriesMock := &entryRepoMock{}
+
+	svc := newLinkTestService(t, topicsMock, entriesMock)
+	_, err := svc.BatchAssignTopic(ctx, BatchAssignInput{EntryIDs: []uuid.UUID{uuid.New()}, TopicID: &topicID})
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if len(topicsMock.SetTopicBatchCalls()) != 0 {
+		t.Error("SetTopicBatch must not be called when the topic is not owned")
+	}
+	if len(entriesMock.ExistByIDsCalls()) != 0 {
+		t.Error("entries must not be checked when the topic is not owned")
+	}
+}
+
+func TestBatchAssignTopic_NilTopicRemoves(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	entryID := uuid.New()
+
+	topicsMock := &topicRepoMock{
+		SetTopicBatchFunc: func(_ context.Context, ids []uuid.UUID, tid *uuid.UUID) error {
+			return nil
+		},
+	}
+	entriesMock := &entryRepoMock{
+		ExistByIDsFunc: func(_ context.Context, uid uuid.UUID, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+			return map[uuid.UUID]bool{entryID: true}, nil
+		},
+	}
+
+	svc := newLinkTestService(t, topicsMock, entriesMock)
+	result, err := svc.BatchAssignTopic(ctx, BatchAssignInput{EntryIDs: []uuid.UUID{entryID}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Assigned != 1 {
+		t.Errorf("Assigned = %d, want 1", result.Assigned)
+	}
+	if calls := topicsMock.SetTopicBatchCalls(); len(calls) != 1 || calls[0].TopicID != nil {
+		t.Errorf("expected SetTopicBatch with nil topic, got %+v", calls)
+	}
+	if len(topicsMock.GetByIDCalls()) != 0 {
+		t.Error("no topic lookup expected for nil topic")
+	}
+}
+
+func TestBatchAssignTopic_TooMany(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	ids := make([]uuid.UUID, 101)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	svc := newLinkTestService(t, &topicRepoMock{}, &entryRepoMock{})
+	_, err := svc.BatchAssignTopic(ctx, BatchAssignInput{EntryIDs: ids})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+}
@@ -50,6 +50,12 @@ var _ topicRepo = &topicRepoMock{}
 //			ListFunc: func(ctx context.Context, userID uuid.UUID) ([]*domain.Topic, error) {
 //				panic("mock out the List method")
 //			},
+//			SetTopicBatchFunc: func(ctx context.Context, entryIDs []uuid.UUID, topicID *uuid.UUID) error {
+//				panic("mock out the SetTopicBatch method")
+//			},
+//			UnlinkAllFromEntryFunc: func(ctx context.Context, entryID uuid.UUID) error {
+//				panic("mock out the UnlinkAllFromEntry method")
+//			},
 //			UnlinkEntryFunc: func(ctx context.Context, entryID uuid.UUID, topicID uuid.UUID) error {
 //				panic("mock out the UnlinkEntry method")
 //			},
@@ -93,6 +99,12 @@ type topicRepoMock struct {
 	// ListFunc mocks the List method.
 	ListFunc func(ctx context.Context, userID uuid.UUID) ([]*domain.Topic, error)
 
+	// SetTopicBatchFunc mocks the SetTopicBatch method.
+	SetTopicBatchFunc func(ctx context.Context, entryIDs []uuid.UUID, topicID *uuid.UUID) error
+
+	// UnlinkAllFromEntryFunc mocks the UnlinkAllFromEntry method.
+	UnlinkAllFromEntryFunc func(ctx context.Context, entryID uuid.UUID) error
+
 	// UnlinkEntryFunc mocks the UnlinkEntry method.
 	UnlinkEntryFunc func(ctx context.Context, entryID uuid.UUID, topicID uuid.UUID) error
 
@@ -181,6 +193,22 @@ type topicRepoMock struct {
 			// UserID is the userID argument value.
 			UserID uuid.UUID
 		}
+		// SetTopicBatch holds details about calls to the SetTopicBatch method.
+		SetTopicBatch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EntryIDs is the entryIDs argument value.
+			EntryIDs []uuid.UUID
+			// TopicID is the topicID argument value.
+			TopicID *uuid.UUID
+		}
+		// UnlinkAllFromEntry holds details about calls to the UnlinkAllFromEntry method.
+		UnlinkAllFromEntry []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// EntryID is the entryID argument value.
+			EntryID uuid.UUID
+		}
 		// UnlinkEntry holds details about calls to the UnlinkEntry method.
 		UnlinkEntry []struct {
 			// Ctx is the ctx argument value.
@@ -212,6 +240,8 @@ type topicRepoMock struct {
 	lockGetTopicsByEntryID    sync.RWMutex
 	lockLinkEntry             sync.RWMutex
 	lockList                  sync.RWMutex
+	lockSetTopicBatch         sync.RWMutex
+	lockUnlinkAllFromEntry    sync.RWMutex
 	lockUnlinkEntry           sync.RWMutex
 	lockUpdate                sync.RWMutex
 }
@@ -596,6 +626,82 @@ func (mock *topicRepoMock) ListCalls() []struct {
 	return calls
 }
 
+// SetTopicBatch calls SetTopicBatchFunc.
+func (mock *topicRepoMock) SetTopicBatch(ctx context.Context, entryIDs []uuid.UUID, topicID *uuid.UUID) error {
+	if mock.SetTopicBatchFunc == nil {
+		panic("topicRepoMock.SetTopicBatchFunc: method is nil but topicRepo.SetTopicBatch was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		EntryIDs []uuid.UUID
+		TopicID  *uuid.UUID
+	}{
+		Ctx:      ctx,
+		EntryIDs: entryIDs,
+		TopicID:  topicID,
+	}
+	mock.lockSetTopicBatch.Lock()
+	mock.calls.SetTopicBatch = append(mock.calls.SetTopicBatch, callInfo)
+	mock.lockSetTopicBatch.Unlock()
+	return mock.SetTopicBatchFunc(ctx, entryIDs, topicID)
+}
+
+// SetTopicBatchCalls gets all the calls that were made to SetTopicBatch.
+// Check the length with:
+//
+//	len(mockedtopicRepo.SetTopicBatchCalls())
+func (mock *topicRepoMock) SetTopicBatchCalls() []struct {
+	Ctx      context.Context
+	EntryIDs []uuid.UUID
+	TopicID  *uuid.UUID
+} {
+	var calls []struct {
+		Ctx      context.Context
+		EntryIDs []uuid.UUID
+		TopicID  *uuid.UUID
+	}
+	mock.lockSetTopicBatch.RLock()
+	calls = mock.calls.SetTopicBatch
+	mock.lockSetTopicBatch.RUnlock()
+	return calls
+}
+
+// UnlinkAllFromEntry calls UnlinkAllFromEntryFunc.
+func (mock *topicRepoMock) UnlinkAllFromEntry(ctx context.Context, entryID uuid.UUID) error {
+	if mock.UnlinkAllFromEntryFunc == nil {
+		panic("topicRepoMock.UnlinkAllFromEntryFunc: method is nil but topicRepo.UnlinkAllFromEntry was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		EntryID uuid.UUID
+	}{
+		Ctx:     ctx,
+		EntryID: entryID,
+	}
+	mock.lockUnlinkAllFromEntry.Lock()
+	mock.calls.UnlinkAllFromEntry = append(mock.calls.UnlinkAllFromEntry, callInfo)
+	mock.lockUnlinkAllFromEntry.Unlock()
+	return mock.UnlinkAllFromEntryFunc(ctx, entryID)
+}
+
+// UnlinkAllFromEntryCalls gets all the calls that were made to UnlinkAllFromEntry.
+// Check the length with:
+//
+//	len(mockedtopicRepo.UnlinkAllFromEntryCalls())
+func (mock *topicRepoMock) UnlinkAllFromEntryCalls() []struct {
+	Ctx     context.Context
+	EntryID uuid.UUID
+} {
+	var calls []struct {
+		Ctx     context.Context
+		EntryID uuid.UUID
+	}
+	mock.lockUnlinkAllFromEntry.RLock()
+	calls = mock.calls.UnlinkAllFromEntry
+	mock.lockUnlinkAllFromEntry.RUnlock()
+	return calls
+}
+
 // UnlinkEntry calls UnlinkEntryFunc.
 func (mock *topicRepoMock) UnlinkEntry(ctx context.Context, entryID uuid.UUID, topicID uuid.UUID) error {
 	if mock.UnlinkEntryFunc == nil {
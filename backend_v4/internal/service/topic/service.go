@@ -20,6 +20,8 @@ type topicRepo interface {
 	// M2M: entry <-> topic
 	LinkEntry(ctx context.Context, entryID, topicID uuid.UUID) error
 	UnlinkEntry(ctx context.Context, entryID, topicID uuid.UUID) error
+	UnlinkAllFromEntry(ctx context.Context, entryID uuid.UUID) error
+	SetTopicBatch(ctx context.Context, entryIDs []uuid.UUID, topicID *uuid.UUID) error
 	BatchLinkEntries(ctx context.Context, entryIDs []uuid.UUID, topicID uuid.UUID) (int, error)
 
 	// M2M read
@@ -77,6 +79,12 @@ type BatchLinkResult struct {
 	Skipped int
 }
 
+// BatchAssignResult holds the outcome of a batch assign operation.
+type BatchAssignResult struct {
+	Assigned int
+	NotFound []uuid.UUID
+}
+
 // trimOrNil trims whitespace. Returns nil if result is empty.
 func trimOrNil(s *string) *string {
 	if s == nil {
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// QueryAuditLog returns audit records matching the filter plus the total
+// match count. Regular users always see only their own records; admins may
+// query a specific user or, with a nil UserID, all users.
+func (s *Service) QueryAuditLog(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, 0, domain.ErrUnauthorized
+	}
+
+	if !ctxutil.IsAdminCtx(ctx) {
+		filter.UserID = &userID
+	}
+
+	if err := validateAuditFilter(&filter); err != nil {
+		return nil, 0, err
+	}
+
+	records, total, err := s.audit.Query(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("user.QueryAuditLog: %w", err)
+	}
+
+	return records, total, nil
+}
+
+// validateAuditFilter checks filter values and applies the default limit.
+func validateAuditFilter(f *domain.AuditFilter) error {
+	var errs []domain.FieldError
+
+	if f.EntityType != nil && !f.EntityType.IsValid() {
+		errs = append(errs, domain.FieldError{Field: "entity_type", Message: "invalid entity type"})
+	}
+	if f.Action != nil && !f.Action.IsValid() {
+		errs = append(errs, domain.FieldError{Field: "action", Message: "invalid action"})
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		errs = append(errs, domain.FieldError{Field: "to", Message: "must be after from"})
+	}
+	if f.Limit < 0 || f.Limit > maxAuditLimit {
+		errs = append(errs, domain.FieldError{Field: "limit", Message: fmt.Sprintf("must be between 0 and %d", maxAuditLimit)})
+	}
+	if f.Offset < 0 {
+		errs = append(errs, domain.FieldError{Field: "offset", Message: "must not be negative"})
+	}
+
+	if len(errs) > 0 {
+		return domain.NewValidationErrors(errs)
+	}
+
+	if f.Limit == 0 {
+		f.Limit = defaultAuditLimit
+	}
+	return nil
+}
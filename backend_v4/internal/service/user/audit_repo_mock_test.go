@@ -22,6 +22,9 @@ var _ auditRepo = &auditRepoMock{}
 //			CreateFunc: func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
 //				panic("mock out the Create method")
 //			},
+//			QueryFunc: func(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+//				panic("mock out the Query method")
+//			},
 //		}
 //
 //		// use mockedauditRepo in code that requires auditRepo
@@ -32,6 +35,9 @@ type auditRepoMock struct {
 	// CreateFunc mocks the Create method.
 	CreateFunc func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error)
 
+	// QueryFunc mocks the Query method.
+	QueryFunc func(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// Create holds details about calls to the Create method.
@@ -41,8 +47,16 @@ type auditRepoMock struct {
 			// Record is the record argument value.
 			Record domain.AuditRecord
 		}
+		// Query holds details about calls to the Query method.
+		Query []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// F is the f argument value.
+			F domain.AuditFilter
+		}
 	}
 	lockCreate sync.RWMutex
+	lockQuery  sync.RWMutex
 }
 
 // Create calls CreateFunc.
@@ -80,3 +94,39 @@ func (mock *auditRepoMock) CreateCalls() []struct {
 	mock.lockCreate.RUnlock()
 	return calls
 }
+
+// Query calls QueryFunc.
+func (mock *auditRepoMock) Query(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+	if mock.QueryFunc == nil {
+		panic("auditRepoMock.QueryFunc: method is nil but auditRepo.Query was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		F   domain.AuditFilter
+	}{
+		Ctx: ctx,
+		F:   f,
+	}
+	mock.lockQuery.Lock()
+	mock.calls.Query = append(mock.calls.Query, callInfo)
+	mock.lockQuery.Unlock()
+	return mock.QueryFunc(ctx, f)
+}
+
+// QueryCalls gets all the calls that were made to Query.
+// Check the length with:
+//
+//	len(mockedauditRepo.QueryCalls())
+func (mock *auditRepoMock) QueryCalls() []struct {
+	Ctx context.Context
+	F   domain.AuditFilter
+} {
+	var calls []struct {
+		Ctx context.Context
+		F   domain.AuditFilter
+	}
+	mock.lockQuery.RLock()
+	calls = mock.calls.Query
+	mock.lockQuery.RUnlock()
+	return calls
+}
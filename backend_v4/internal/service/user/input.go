@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
+	"github.com/heartmarshall/myenglish-backend/pkg/tzutil"
 )
 
 // UpdateProfileInput holds parameters for profile update operation.
@@ -32,6 +33,17 @@ func (i UpdateProfileInput) Validate() error {
 	return nil
 }
 
+// validateUsername checks the username length rule shared with registration.
+func validateUsername(username string) error {
+	if username == "" {
+		return domain.NewValidationError("username", "required")
+	}
+	if len(username) < 2 || len(username) > 50 {
+		return domain.NewValidationError("username", "must be between 2 and 50 characters")
+	}
+	return nil
+}
+
 // UpdateSettingsInput holds parameters for settings update operation.
 // All fields are optional (nil = don't change).
 type UpdateSettingsInput struct {
@@ -40,8 +52,24 @@ type UpdateSettingsInput struct {
 	MaxIntervalDays  *int
 	Timezone         *string
 	DesiredRetention *float64
+	NewCardOrder     *domain.NewCardOrder
+	// HardIntervalFactor scales the review interval after a Hard grade.
+	HardIntervalFactor *float64
+	// DailyGoal is the target number of reviews per day; 0 disables the goal.
+	DailyGoal *int
+	// DueCardOrder is the order of due cards in the study queue.
+	DueCardOrder *domain.DueCardOrder
+	// InterleaveNew spreads new cards among due cards in the study queue.
+	InterleaveNew *bool
+	// LearningSteps and RelearningSteps are the steps a new or lapsed card
+	// goes through before review; an empty slice restores the server default.
+	LearningSteps   *[]time.Duration
+	RelearningSteps *[]time.Duration
 }
 
+// maxSteps caps UpdateSettingsInput.LearningSteps and RelearningSteps.
+const maxSteps = 10
+
 // Validate validates the update settings input.
 func (i UpdateSettingsInput) Validate() error {
 	var errs []domain.FieldError
@@ -83,13 +111,66 @@ func (i UpdateSettingsInput) Validate() error {
 			errs = append(errs, domain.FieldError{Field: "timezone", Message: "cannot be empty"})
 		} else if len(*i.Timezone) > 64 {
 			errs = append(errs, domain.FieldError{Field: "timezone", Message: "too long"})
-		} else if _, err := time.LoadLocation(*i.Timezone); err != nil {
+		} else if !tzutil.IsValid(*i.Timezone) {
 			errs = append(errs, domain.FieldError{Field: "timezone", Message: "invalid IANA timezone"})
 		}
 	}
 
+	if i.NewCardOrder != nil && !i.NewCardOrder.IsValid() {
+		errs = append(errs, domain.FieldError{Field: "new_card_order", Message: "must be one of added, random, frequency"})
+	}
+
+	if i.HardIntervalFactor != nil {
+		if *i.HardIntervalFactor < 0.5 {
+			errs = append(errs, domain.FieldError{Field: "hard_interval_factor", Message: "must be at least 0.5"})
+		} else if *i.HardIntervalFactor > 2.0 {
+			errs = append(errs, domain.FieldError{Field: "hard_interval_factor", Message: "must be at most 2.0"})
+		}
+	}
+
+	if i.DailyGoal != nil {
+		if *i.DailyGoal < 0 {
+			errs = append(errs, domain.FieldError{Field: "daily_goal", Message: "must not be negative"})
+		} else if *i.DailyGoal > 9999 {
+			errs = append(errs, domain.FieldError{Field: "daily_goal", Message: "must be at most 9999"})
+		}
+	}
+
+	if i.DueCardOrder != nil && !i.DueCardOrder.IsValid() {
+		errs = append(errs, domain.FieldError{Field: "due_card_order", Message: "must be one of due_date, random, added"})
+	}
+
+	if i.LearningSteps != nil {
+		if msg := validateSteps(*i.LearningSteps); msg != "" {
+			errs = append(errs, domain.FieldError{Field: "learning_steps", Message: msg})
+		}
+	}
+
+	if i.RelearningSteps != nil {
+		if msg := validateSteps(*i.RelearningSteps); msg != "" {
+			errs = append(errs, domain.FieldError{Field: "relearning_steps", Message: msg})
+		}
+	}
+
 	if len(errs) > 0 {
 		return &domain.ValidationError{Errors: errs}
 	}
 	return nil
 }
+
+// validateSteps checks that SRS steps are whole seconds, positive and
+// strictly increasing. It returns an error message, or "" if steps are valid.
+func validateSteps(steps []time.Duration) string {
+	if len(steps) > maxSteps {
+		return "too many steps (max 10)"
+	}
+	for j, d := range steps {
+		if d < time.Second || d%time.Second != 0 {
+			return "each step must be a positive whole number of seconds"
+		}
+		if j > 0 && d <= steps[j-1] {
+			return "steps must be strictly increasing"
+		}
+	}
+	return ""
+}
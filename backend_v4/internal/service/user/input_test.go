@@ -3,6 +3,7 @@ package user
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -173,6 +174,11 @@ func TestUpdateSettingsInput_Validate(t *testing.T) {
 			input:   UpdateSettingsInput{Timezone: ptr("Not/A/Timezone")},
 			wantErr: true,
 		},
+		{
+			name:    "invalid: timezone Local depends on the server",
+			input:   UpdateSettingsInput{Timezone: ptr("Local")},
+			wantErr: true,
+		},
 		{
 			name:    "invalid: timezone at 65",
 			input:   UpdateSettingsInput{Timezone: ptr(strings.Repeat("z", 65))},
@@ -219,6 +225,117 @@ func TestUpdateSettingsInput_Validate(t *testing.T) {
 			input:   UpdateSettingsInput{DesiredRetention: ptr(-0.5)},
 			wantErr: true,
 		},
+		// NewCardOrder values
+		{
+			name:    "valid: new_card_order frequency",
+			input:   UpdateSettingsInput{NewCardOrder: ptr(domain.NewCardOrderFrequency)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: new_card_order unknown",
+			input:   UpdateSettingsInput{NewCardOrder: ptr(domain.NewCardOrder("alphabetical"))},
+			wantErr: true,
+		},
+		// HardIntervalFactor boundaries
+		{
+			name:    "valid: hard_interval_factor min",
+			input:   UpdateSettingsInput{HardIntervalFactor: ptr(0.5)},
+			wantErr: false,
+		},
+		{
+			name:    "valid: hard_interval_factor max",
+			input:   UpdateSettingsInput{HardIntervalFactor: ptr(2.0)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: hard_interval_factor too low",
+			input:   UpdateSettingsInput{HardIntervalFactor: ptr(0.49)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: hard_interval_factor too high",
+			input:   UpdateSettingsInput{HardIntervalFactor: ptr(2.01)},
+			wantErr: true,
+		},
+		// DailyGoal boundaries
+		{
+			name:    "valid: daily_goal zero disables goal",
+			input:   UpdateSettingsInput{DailyGoal: ptr(0)},
+			wantErr: false,
+		},
+		{
+			name:    "valid: daily_goal max",
+			input:   UpdateSettingsInput{DailyGoal: ptr(9999)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: daily_goal negative",
+			input:   UpdateSettingsInput{DailyGoal: ptr(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: daily_goal too high",
+			input:   UpdateSettingsInput{DailyGoal: ptr(10000)},
+			wantErr: true,
+		},
+		// DueCardOrder
+		{
+			name:    "valid: due_card_order random",
+			input:   UpdateSettingsInput{DueCardOrder: ptr(domain.DueCardOrderRandom)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: due_card_order unknown",
+			input:   UpdateSettingsInput{DueCardOrder: ptr(domain.DueCardOrder("oldest"))},
+			wantErr: true,
+		},
+		// LearningSteps
+		{
+			name:    "valid: learning_steps increasing",
+			input:   UpdateSettingsInput{LearningSteps: ptr([]time.Duration{time.Minute, 10 * time.Minute})},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: learning_steps not increasing",
+			input:   UpdateSettingsInput{LearningSteps: ptr([]time.Duration{10 * time.Minute, time.Minute})},
+			wantErr: true,
+		},
+		// RelearningSteps
+		{
+			name:    "valid: relearning_steps increasing",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{5 * time.Minute, 20 * time.Minute})},
+			wantErr: false,
+		},
+		{
+			name:    "valid: relearning_steps empty restores default",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{})},
+			wantErr: false,
+		},
+		{
+			name:    "invalid: relearning_steps not positive",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{0})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: relearning_steps not increasing",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{20 * time.Minute, 5 * time.Minute})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: relearning_steps repeated",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{10 * time.Minute, 10 * time.Minute})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: relearning_steps fractional seconds",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{1500 * time.Millisecond})},
+			wantErr: true,
+		},
+		{
+			name:    "invalid: relearning_steps too many",
+			input:   UpdateSettingsInput{RelearningSteps: ptr([]time.Duration{1 * time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 5 * time.Minute, 6 * time.Minute, 7 * time.Minute, 8 * time.Minute, 9 * time.Minute, 10 * time.Minute, 11 * time.Minute})},
+			wantErr: true,
+		},
 		// All nil = no error
 		{
 			name:    "valid: all fields nil",
@@ -268,3 +385,16 @@ func TestUpdateSettingsInput_Validate_MultipleErrors(t *testing.T) {
 	require.ErrorAs(t, err, &valErr)
 	assert.Len(t, valErr.Errors, 5, "each invalid field should produce a separate error")
 }
+
+func TestUpdateSettingsInput_Validate_InvalidTimezone(t *testing.T) {
+	t.Parallel()
+
+	err := UpdateSettingsInput{Timezone: ptr("Europe/Atlantis")}.Validate()
+	require.ErrorIs(t, err, domain.ErrValidation)
+
+	var valErr *domain.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	require.Len(t, valErr.Errors, 1)
+	assert.Equal(t, "timezone", valErr.Errors[0].Field)
+	assert.Equal(t, "invalid IANA timezone", valErr.Errors[0].Message)
+}
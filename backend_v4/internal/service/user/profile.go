@@ -2,9 +2,13 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
 	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
 )
@@ -25,7 +29,9 @@ func (s *Service) GetProfile(ctx context.Context) (*domain.User, error) {
 	return user, nil
 }
 
-// UpdateProfile updates the authenticated user's profile (name and avatar).
+// UpdateProfile updates the authenticated user's profile (name and avatar)
+// and marks it as customized, so later OAuth logins keep these values instead
+// of syncing them from the provider.
 // Returns ErrUnauthorized if no userID is found in context.
 func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*domain.User, error) {
 	// Step 1: Validate input
@@ -40,7 +46,7 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 	}
 
 	// Step 3: Update profile
-	user, err := s.users.Update(ctx, userID, &input.Name, input.AvatarURL)
+	user, err := s.users.UpdateProfile(ctx, userID, &input.Name, input.AvatarURL)
 	if err != nil {
 		return nil, fmt.Errorf("user.UpdateProfile: %w", err)
 	}
@@ -51,3 +57,72 @@ func (s *Service) UpdateProfile(ctx context.Context, input UpdateProfileInput) (
 
 	return user, nil
 }
+
+// ChangeUsername changes the authenticated user's username and records the
+// change in the audit log. Returns ErrAlreadyExists if another user has the
+// username. Setting the current username again is a no-op.
+func (s *Service) ChangeUsername(ctx context.Context, newUsername string) (*domain.User, error) {
+	// Step 1: Validate input
+	newUsername = strings.TrimSpace(newUsername)
+	if err := validateUsername(newUsername); err != nil {
+		return nil, err
+	}
+
+	// Step 2: Extract userID from context
+	userID, ok := ctxutil.UserIDFromCtx(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	// Step 3: Check availability, update and audit in a transaction.
+	// The unique index on users.username catches concurrent claims.
+	var updated *domain.User
+	err := s.tx.RunInTx(ctx, func(txCtx context.Context) error {
+		current, err := s.users.GetByID(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+		if current.Username == newUsername {
+			updated = current
+			return nil
+		}
+
+		owner, err := s.users.GetByUsername(txCtx, newUsername)
+		switch {
+		case err == nil && owner.ID != userID:
+			return domain.ErrAlreadyExists
+		case err != nil && !errors.Is(err, domain.ErrNotFound):
+			return fmt.Errorf("get user by username: %w", err)
+		}
+
+		updated, err = s.users.UpdateUsername(txCtx, userID, newUsername)
+		if err != nil {
+			return fmt.Errorf("update username: %w", err)
+		}
+
+		if _, err := s.audit.Create(txCtx, domain.AuditRecord{
+			ID:         uuid.New(),
+			UserID:     userID,
+			EntityType: domain.EntityTypeUser,
+			EntityID:   &userID,
+			Action:     domain.AuditActionUpdate,
+			Changes: map[string]any{
+				"username": map[string]any{"old": current.Username, "new": newUsername},
+			},
+			CreatedAt: time.Now().UTC(),
+		}); err != nil {
+			return fmt.Errorf("create audit record: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("user.ChangeUsername: %w", err)
+	}
+
+	// Step 4: Log the change
+	s.log.InfoContext(ctx, "username changed",
+		slog.String("user_id", userID.String()))
+
+	return updated, nil
+}
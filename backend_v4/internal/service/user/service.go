@@ -11,7 +11,9 @@ import (
 // userRepo defines the user repository interface needed by user service.
 type userRepo interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	Update(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error)
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	UpdateProfile(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error)
+	UpdateUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error)
 	UpdateRole(ctx context.Context, id uuid.UUID, role string) (*domain.User, error)
 	ListUsers(ctx context.Context, limit, offset int) ([]domain.User, error)
 	CountUsers(ctx context.Context) (int, error)
@@ -26,6 +28,7 @@ type settingsRepo interface {
 // auditRepo defines the audit repository interface needed by user service.
 type auditRepo interface {
 	Create(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error)
+	Query(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error)
 }
 
 // txManager defines the transaction manager interface needed by user service.
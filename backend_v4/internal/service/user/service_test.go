@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -114,7 +115,7 @@ func TestService_UpdateProfile_Success(t *testing.T) {
 	}
 
 	users := &userRepoMock{
-		UpdateFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
 			assert.Equal(t, userID, id)
 			assert.Equal(t, ptr("New Name"), name)
 			assert.Equal(t, ptr("https://example.com/avatar.jpg"), avatarURL)
@@ -127,7 +128,7 @@ func TestService_UpdateProfile_Success(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.Equal(t, &expected, user)
-	assert.Len(t, users.UpdateCalls(), 1)
+	assert.Len(t, users.UpdateProfileCalls(), 1)
 }
 
 func TestService_UpdateProfile_ValidationError(t *testing.T) {
@@ -197,7 +198,7 @@ func TestService_UpdateProfile_RepoError(t *testing.T) {
 	repoErr := errors.New("db connection lost")
 
 	users := &userRepoMock{
-		UpdateFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
 			return nil, repoErr
 		},
 	}
@@ -227,7 +228,7 @@ func TestService_UpdateProfile_NilAvatarURL(t *testing.T) {
 	}
 
 	users := &userRepoMock{
-		UpdateFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+		UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
 			assert.Nil(t, avatarURL, "nil AvatarURL should be passed through to repo")
 			return &expected, nil
 		},
@@ -240,6 +241,146 @@ func TestService_UpdateProfile_NilAvatarURL(t *testing.T) {
 	assert.Equal(t, &expected, user)
 }
 
+// ---------------------------------------------------------------------------
+// ChangeUsername tests
+// ---------------------------------------------------------------------------
+
+func passthroughTx() *txManagerMock {
+	return &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+}
+
+func TestService_ChangeUsername_Success(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	users := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: userID, Username: "old_name"}, nil
+		},
+		GetByUsernameFunc: func(ctx context.Context, username string) (*domain.User, error) {
+			return nil, domain.ErrNotFound
+		},
+		UpdateUsernameFunc: func(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+			assert.Equal(t, userID, id)
+			assert.Equal(t, "new_name", username)
+			return &domain.User{ID: userID, Username: username}, nil
+		},
+	}
+	audit := &auditRepoMock{
+		CreateFunc: func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
+			assert.Equal(t, domain.EntityTypeUser, record.EntityType)
+			assert.Equal(t, domain.AuditActionUpdate, record.Action)
+			assert.Equal(t, map[string]any{"old": "old_name", "new": "new_name"}, record.Changes["username"])
+			return record, nil
+		},
+	}
+
+	svc := newTestService(users, nil, audit, passthroughTx())
+	user, err := svc.ChangeUsername(ctx, "  new_name ")
+
+	require.NoError(t, err)
+	assert.Equal(t, "new_name", user.Username)
+	assert.Len(t, users.UpdateUsernameCalls(), 1)
+	assert.Len(t, audit.CreateCalls(), 1)
+}
+
+func TestService_ChangeUsername_Taken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	users := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: userID, Username: "old_name"}, nil
+		},
+		GetByUsernameFunc: func(ctx context.Context, username string) (*domain.User, error) {
+			return &domain.User{ID: uuid.New(), Username: username}, nil
+		},
+	}
+	audit := &auditRepoMock{}
+
+	svc := newTestService(users, nil, audit, passthroughTx())
+	_, err := svc.ChangeUsername(ctx, "taken_name")
+
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+	assert.Empty(t, users.UpdateUsernameCalls())
+	assert.Empty(t, audit.CreateCalls())
+}
+
+func TestService_ChangeUsername_ConcurrentClaim(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	users := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: userID, Username: "old_name"}, nil
+		},
+		GetByUsernameFunc: func(ctx context.Context, username string) (*domain.User, error) {
+			return nil, domain.ErrNotFound
+		},
+		UpdateUsernameFunc: func(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+			return nil, domain.ErrAlreadyExists // unique index violation
+		},
+	}
+
+	svc := newTestService(users, nil, &auditRepoMock{}, passthroughTx())
+	_, err := svc.ChangeUsername(ctx, "raced_name")
+
+	assert.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
+func TestService_ChangeUsername_SameUsername(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	users := &userRepoMock{
+		GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+			return &domain.User{ID: userID, Username: "same_name"}, nil
+		},
+	}
+	audit := &auditRepoMock{}
+
+	svc := newTestService(users, nil, audit, passthroughTx())
+	user, err := svc.ChangeUsername(ctx, "same_name")
+
+	require.NoError(t, err)
+	assert.Equal(t, "same_name", user.Username)
+	assert.Empty(t, users.UpdateUsernameCalls())
+	assert.Empty(t, audit.CreateCalls())
+}
+
+func TestService_ChangeUsername_ValidationError(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	svc := newTestService(&userRepoMock{}, nil, nil, nil)
+
+	for _, username := range []string{"", "   ", "a", strings.Repeat("u", 51)} {
+		_, err := svc.ChangeUsername(ctx, username)
+		assert.ErrorIs(t, err, domain.ErrValidation, "username %q", username)
+	}
+}
+
+func TestService_ChangeUsername_NoUserIDInContext(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(&userRepoMock{}, nil, nil, nil)
+	_, err := svc.ChangeUsername(context.Background(), "new_name")
+
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
 // ---------------------------------------------------------------------------
 // GetSettings tests
 // ---------------------------------------------------------------------------
@@ -435,6 +576,109 @@ func TestService_UpdateSettings_PartialUpdate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_UpdateSettings_MultiFieldUpdate(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+	current := domain.DefaultUserSettings(userID)
+
+	input := UpdateSettingsInput{
+		NewCardsPerDay:   ptr(40),
+		MaxIntervalDays:  ptr(180),
+		DesiredRetention: ptr(0.85),
+		Timezone:         ptr("Europe/Berlin"),
+		LearningSteps:    ptr([]time.Duration{time.Minute, 15 * time.Minute, time.Hour}),
+		RelearningSteps:  ptr([]time.Duration{5 * time.Minute}),
+		DailyGoal:        ptr(120),
+	}
+
+	settingsRepo := &settingsRepoMock{
+		GetSettingsFunc: func(ctx context.Context, uid uuid.UUID) (*domain.UserSettings, error) {
+			return &current, nil
+		},
+		UpdateSettingsFunc: func(ctx context.Context, uid uuid.UUID, s domain.UserSettings) (*domain.UserSettings, error) {
+			return &s, nil
+		},
+	}
+	auditRepo := &auditRepoMock{
+		CreateFunc: func(ctx context.Context, record domain.AuditRecord) (domain.AuditRecord, error) {
+			return record, nil
+		},
+	}
+	txMgr := &txManagerMock{
+		RunInTxFunc: func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		},
+	}
+
+	svc := newTestService(nil, settingsRepo, auditRepo, txMgr)
+	result, err := svc.UpdateSettings(ctx, input)
+	require.NoError(t, err)
+
+	// One write with every requested field; the rest keep their values.
+	require.Len(t, settingsRepo.UpdateSettingsCalls(), 1)
+	want := current
+	want.NewCardsPerDay = 40
+	want.MaxIntervalDays = 180
+	want.DesiredRetention = 0.85
+	want.Timezone = "Europe/Berlin"
+	want.LearningSteps = []time.Duration{time.Minute, 15 * time.Minute, time.Hour}
+	want.RelearningSteps = []time.Duration{5 * time.Minute}
+	want.DailyGoal = 120
+	assert.Equal(t, want, settingsRepo.UpdateSettingsCalls()[0].S)
+	assert.Equal(t, &want, result)
+
+	// One audit record listing exactly the changed fields.
+	require.Len(t, auditRepo.CreateCalls(), 1)
+	changed := make([]string, 0, len(auditRepo.CreateCalls()[0].Record.Changes))
+	for field := range auditRepo.CreateCalls()[0].Record.Changes {
+		changed = append(changed, field)
+	}
+	assert.ElementsMatch(t, []string{
+		"new_cards_per_day", "max_interval_days", "desired_retention", "timezone",
+		"learning_steps", "relearning_steps", "daily_goal",
+	}, changed)
+}
+
+func TestService_UpdateSettings_ValidationFailureWritesNothing(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserID(context.Background(), userID)
+
+	// Valid fields next to invalid ones must not be applied either.
+	input := UpdateSettingsInput{
+		NewCardsPerDay:   ptr(40),
+		DesiredRetention: ptr(1.0),
+		Timezone:         ptr("Europe/Berlin"),
+		LearningSteps:    ptr([]time.Duration{10 * time.Minute, time.Minute}),
+	}
+
+	settingsRepo := &settingsRepoMock{}
+	auditRepo := &auditRepoMock{}
+	txMgr := &txManagerMock{}
+
+	svc := newTestService(nil, settingsRepo, auditRepo, txMgr)
+	result, err := svc.UpdateSettings(ctx, input)
+
+	require.ErrorIs(t, err, domain.ErrValidation)
+	assert.Nil(t, result)
+
+	var valErr *domain.ValidationError
+	require.ErrorAs(t, err, &valErr)
+	fields := make([]string, 0, len(valErr.Errors))
+	for _, fe := range valErr.Errors {
+		fields = append(fields, fe.Field)
+	}
+	assert.ElementsMatch(t, []string{"desired_retention", "learning_steps"}, fields)
+
+	assert.Empty(t, txMgr.RunInTxCalls())
+	assert.Empty(t, settingsRepo.GetSettingsCalls())
+	assert.Empty(t, settingsRepo.UpdateSettingsCalls())
+	assert.Empty(t, auditRepo.CreateCalls())
+}
+
 func TestService_UpdateSettings_ValidationError(t *testing.T) {
 	t.Parallel()
 
@@ -722,6 +966,27 @@ func TestApplySettingsChanges(t *testing.T) {
 			input:    UpdateSettingsInput{},
 			expected: current,
 		},
+		{
+			name: "set relearning_steps",
+			input: UpdateSettingsInput{
+				RelearningSteps: ptr([]time.Duration{5 * time.Minute}),
+			},
+			expected: domain.UserSettings{
+				UserID:          current.UserID,
+				NewCardsPerDay:  20,
+				ReviewsPerDay:   200,
+				MaxIntervalDays: 365,
+				Timezone:        "UTC",
+				RelearningSteps: []time.Duration{5 * time.Minute},
+			},
+		},
+		{
+			name: "empty relearning_steps restores default",
+			input: UpdateSettingsInput{
+				RelearningSteps: ptr([]time.Duration{}),
+			},
+			expected: current,
+		},
 	}
 
 	for _, tt := range tests {
@@ -782,6 +1047,54 @@ func TestBuildSettingsChanges(t *testing.T) {
 				"new_cards_per_day": map[string]any{"old": 20, "new": 50},
 			},
 		},
+		{
+			name: "only new_card_order changed",
+			old:  domain.UserSettings{NewCardOrder: domain.NewCardOrderAdded},
+			new:  domain.UserSettings{NewCardOrder: domain.NewCardOrderRandom},
+			expected: map[string]any{
+				"new_card_order": map[string]any{"old": domain.NewCardOrderAdded, "new": domain.NewCardOrderRandom},
+			},
+		},
+		{
+			name: "only hard_interval_factor changed",
+			old:  domain.UserSettings{HardIntervalFactor: 1.0},
+			new:  domain.UserSettings{HardIntervalFactor: 1.5},
+			expected: map[string]any{
+				"hard_interval_factor": map[string]any{"old": 1.0, "new": 1.5},
+			},
+		},
+		{
+			name: "only daily_goal changed",
+			old:  domain.UserSettings{DailyGoal: 0},
+			new:  domain.UserSettings{DailyGoal: 50},
+			expected: map[string]any{
+				"daily_goal": map[string]any{"old": 0, "new": 50},
+			},
+		},
+		{
+			name: "only due_card_order changed",
+			old:  domain.UserSettings{DueCardOrder: domain.DueCardOrderDueDate},
+			new:  domain.UserSettings{DueCardOrder: domain.DueCardOrderAdded},
+			expected: map[string]any{
+				"due_card_order": map[string]any{"old": domain.DueCardOrderDueDate, "new": domain.DueCardOrderAdded},
+			},
+		},
+		{
+			name: "only interleave_new changed",
+			old:  domain.UserSettings{InterleaveNew: false},
+			new:  domain.UserSettings{InterleaveNew: true},
+			expected: map[string]any{
+				"interleave_new": map[string]any{"old": false, "new": true},
+			},
+		},
+		{
+			name: "only relearning_steps changed",
+			old:  domain.UserSettings{},
+			new:  domain.UserSettings{RelearningSteps: []time.Duration{5 * time.Minute, 20 * time.Minute}},
+			expected: map[string]any{
+				"relearning_steps": map[string]any{"old": []string(nil), "new": []string{"5m0s", "20m0s"}},
+			},
+		},
 		{
 			name: "no changes",
 			old: domain.UserSettings{
@@ -1045,3 +1358,120 @@ func TestService_ListUsers_CountError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, 0, total)
 }
+
+// ---------------------------------------------------------------------------
+// QueryAuditLog tests
+// ---------------------------------------------------------------------------
+
+func TestService_QueryAuditLog_UserScopedToOwnRecords(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	ctx := ctxutil.WithUserRole(ctxutil.WithUserID(context.Background(), userID), "user")
+
+	audit := &auditRepoMock{
+		QueryFunc: func(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+			return []domain.AuditRecord{{UserID: userID}}, 1, nil
+		},
+	}
+
+	svc := newTestService(nil, nil, audit, nil)
+
+	// Asking for someone else's records is silently rescoped to the caller.
+	other := uuid.New()
+	records, total, err := svc.QueryAuditLog(ctx, domain.AuditFilter{UserID: &other})
+
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, 1, total)
+	require.Len(t, audit.QueryCalls(), 1)
+	f := audit.QueryCalls()[0].F
+	require.NotNil(t, f.UserID)
+	assert.Equal(t, userID, *f.UserID)
+	assert.Equal(t, 50, f.Limit, "limit=0 should default to 50")
+}
+
+func TestService_QueryAuditLog_AdminQueriesAcrossUsers(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserRole(ctxutil.WithUserID(context.Background(), uuid.New()), "admin")
+	action := domain.AuditActionDelete
+
+	audit := &auditRepoMock{
+		QueryFunc: func(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+			return nil, 7, nil
+		},
+	}
+
+	svc := newTestService(nil, nil, audit, nil)
+	_, total, err := svc.QueryAuditLog(ctx, domain.AuditFilter{Action: &action, Limit: 20, Offset: 40})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, total)
+	f := audit.QueryCalls()[0].F
+	assert.Nil(t, f.UserID, "admin filter without UserID should span all users")
+	assert.Equal(t, &action, f.Action)
+	assert.Equal(t, 20, f.Limit)
+	assert.Equal(t, 40, f.Offset)
+}
+
+func TestService_QueryAuditLog_AdminQueriesSpecificUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserRole(ctxutil.WithUserID(context.Background(), uuid.New()), "admin")
+	target := uuid.New()
+
+	audit := &auditRepoMock{
+		QueryFunc: func(ctx context.Context, f domain.AuditFilter) ([]domain.AuditRecord, int, error) {
+			return nil, 0, nil
+		},
+	}
+
+	svc := newTestService(nil, nil, audit, nil)
+	_, _, err := svc.QueryAuditLog(ctx, domain.AuditFilter{UserID: &target})
+
+	require.NoError(t, err)
+	assert.Equal(t, &target, audit.QueryCalls()[0].F.UserID)
+}
+
+func TestService_QueryAuditLog_Validation(t *testing.T) {
+	t.Parallel()
+
+	ctx := ctxutil.WithUserID(context.Background(), uuid.New())
+	now := time.Now()
+	badType := domain.EntityType("NOPE")
+
+	tests := []struct {
+		name   string
+		filter domain.AuditFilter
+		field  string
+	}{
+		{"invalid entity type", domain.AuditFilter{EntityType: &badType}, "entity_type"},
+		{"to before from", domain.AuditFilter{From: ptr(now), To: ptr(now.Add(-time.Hour))}, "to"},
+		{"limit too large", domain.AuditFilter{Limit: 201}, "limit"},
+		{"negative offset", domain.AuditFilter{Offset: -1}, "offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newTestService(nil, nil, &auditRepoMock{}, nil)
+			_, _, err := svc.QueryAuditLog(ctx, tt.filter)
+
+			require.ErrorIs(t, err, domain.ErrValidation)
+			var verr *domain.ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tt.field, verr.Errors[0].Field)
+		})
+	}
+}
+
+func TestService_QueryAuditLog_Unauthorized(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(nil, nil, nil, nil)
+	_, _, err := svc.QueryAuditLog(context.Background(), domain.AuditFilter{})
+
+	require.ErrorIs(t, err, domain.ErrUnauthorized)
+}
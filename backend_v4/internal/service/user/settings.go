@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -112,6 +113,27 @@ func applySettingsChanges(current domain.UserSettings, input UpdateSettingsInput
 	if input.Timezone != nil {
 		result.Timezone = *input.Timezone
 	}
+	if input.NewCardOrder != nil {
+		result.NewCardOrder = *input.NewCardOrder
+	}
+	if input.HardIntervalFactor != nil {
+		result.HardIntervalFactor = *input.HardIntervalFactor
+	}
+	if input.DailyGoal != nil {
+		result.DailyGoal = *input.DailyGoal
+	}
+	if input.DueCardOrder != nil {
+		result.DueCardOrder = *input.DueCardOrder
+	}
+	if input.InterleaveNew != nil {
+		result.InterleaveNew = *input.InterleaveNew
+	}
+	if input.LearningSteps != nil {
+		result.LearningSteps = stepsOrDefault(*input.LearningSteps)
+	}
+	if input.RelearningSteps != nil {
+		result.RelearningSteps = stepsOrDefault(*input.RelearningSteps)
+	}
 
 	return result
 }
@@ -150,6 +172,69 @@ func buildSettingsChanges(old, new domain.UserSettings) map[string]any {
 			"new": new.Timezone,
 		}
 	}
+	if old.NewCardOrder != new.NewCardOrder {
+		changes["new_card_order"] = map[string]any{
+			"old": old.NewCardOrder,
+			"new": new.NewCardOrder,
+		}
+	}
+	if old.HardIntervalFactor != new.HardIntervalFactor {
+		changes["hard_interval_factor"] = map[string]any{
+			"old": old.HardIntervalFactor,
+			"new": new.HardIntervalFactor,
+		}
+	}
+	if old.DailyGoal != new.DailyGoal {
+		changes["daily_goal"] = map[string]any{
+			"old": old.DailyGoal,
+			"new": new.DailyGoal,
+		}
+	}
+	if old.DueCardOrder != new.DueCardOrder {
+		changes["due_card_order"] = map[string]any{
+			"old": old.DueCardOrder,
+			"new": new.DueCardOrder,
+		}
+	}
+	if old.InterleaveNew != new.InterleaveNew {
+		changes["interleave_new"] = map[string]any{
+			"old": old.InterleaveNew,
+			"new": new.InterleaveNew,
+		}
+	}
+	if !slices.Equal(old.LearningSteps, new.LearningSteps) {
+		changes["learning_steps"] = map[string]any{
+			"old": stepStrings(old.LearningSteps),
+			"new": stepStrings(new.LearningSteps),
+		}
+	}
+	if !slices.Equal(old.RelearningSteps, new.RelearningSteps) {
+		changes["relearning_steps"] = map[string]any{
+			"old": stepStrings(old.RelearningSteps),
+			"new": stepStrings(new.RelearningSteps),
+		}
+	}
 
 	return changes
 }
+
+// stepsOrDefault copies steps from the input; an empty slice becomes nil,
+// which means the server default.
+func stepsOrDefault(steps []time.Duration) []time.Duration {
+	if len(steps) == 0 {
+		return nil
+	}
+	return slices.Clone(steps)
+}
+
+// stepStrings formats SRS steps for the audit log; nil stays nil.
+func stepStrings(steps []time.Duration) []string {
+	if steps == nil {
+		return nil
+	}
+	out := make([]string, len(steps))
+	for i, d := range steps {
+		out[i] = d.String()
+	}
+	return out
+}
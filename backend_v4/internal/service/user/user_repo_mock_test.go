@@ -26,15 +26,21 @@ var _ userRepo = &userRepoMock{}
 //			GetByIDFunc: func(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 //				panic("mock out the GetByID method")
 //			},
+//			GetByUsernameFunc: func(ctx context.Context, username string) (*domain.User, error) {
+//				panic("mock out the GetByUsername method")
+//			},
 //			ListUsersFunc: func(ctx context.Context, limit int, offset int) ([]domain.User, error) {
 //				panic("mock out the ListUsers method")
 //			},
-//			UpdateFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
-//				panic("mock out the Update method")
+//			UpdateProfileFunc: func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+//				panic("mock out the UpdateProfile method")
 //			},
 //			UpdateRoleFunc: func(ctx context.Context, id uuid.UUID, role string) (*domain.User, error) {
 //				panic("mock out the UpdateRole method")
 //			},
+//			UpdateUsernameFunc: func(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+//				panic("mock out the UpdateUsername method")
+//			},
 //		}
 //
 //		// use mockeduserRepo in code that requires userRepo
@@ -48,15 +54,21 @@ type userRepoMock struct {
 	// GetByIDFunc mocks the GetByID method.
 	GetByIDFunc func(ctx context.Context, id uuid.UUID) (*domain.User, error)
 
+	// GetByUsernameFunc mocks the GetByUsername method.
+	GetByUsernameFunc func(ctx context.Context, username string) (*domain.User, error)
+
 	// ListUsersFunc mocks the ListUsers method.
 	ListUsersFunc func(ctx context.Context, limit int, offset int) ([]domain.User, error)
 
-	// UpdateFunc mocks the Update method.
-	UpdateFunc func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error)
+	// UpdateProfileFunc mocks the UpdateProfile method.
+	UpdateProfileFunc func(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error)
 
 	// UpdateRoleFunc mocks the UpdateRole method.
 	UpdateRoleFunc func(ctx context.Context, id uuid.UUID, role string) (*domain.User, error)
 
+	// UpdateUsernameFunc mocks the UpdateUsername method.
+	UpdateUsernameFunc func(ctx context.Context, id uuid.UUID, username string) (*domain.User, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
 		// CountUsers holds details about calls to the CountUsers method.
@@ -71,6 +83,13 @@ type userRepoMock struct {
 			// ID is the id argument value.
 			ID uuid.UUID
 		}
+		// GetByUsername holds details about calls to the GetByUsername method.
+		GetByUsername []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Username is the username argument value.
+			Username string
+		}
 		// ListUsers holds details about calls to the ListUsers method.
 		ListUsers []struct {
 			// Ctx is the ctx argument value.
@@ -80,8 +99,8 @@ type userRepoMock struct {
 			// Offset is the offset argument value.
 			Offset int
 		}
-		// Update holds details about calls to the Update method.
-		Update []struct {
+		// UpdateProfile holds details about calls to the UpdateProfile method.
+		UpdateProfile []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// ID is the id argument value.
@@ -100,12 +119,23 @@ type userRepoMock struct {
 			// Role is the role argument value.
 			Role string
 		}
+		// UpdateUsername holds details about calls to the UpdateUsername method.
+		UpdateUsername []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ID is the id argument value.
+			ID uuid.UUID
+			// Username is the username argument value.
+			Username string
+		}
 	}
-	lockCountUsers sync.RWMutex
-	lockGetByID    sync.RWMutex
-	lockListUsers  sync.RWMutex
-	lockUpdate     sync.RWMutex
-	lockUpdateRole sync.RWMutex
+	lockCountUsers     sync.RWMutex
+	lockGetByID        sync.RWMutex
+	lockGetByUsername  sync.RWMutex
+	lockListUsers      sync.RWMutex
+	lockUpdateProfile  sync.RWMutex
+	lockUpdateRole     sync.RWMutex
+	lockUpdateUsername sync.RWMutex
 }
 
 // CountUsers calls CountUsersFunc.
@@ -176,6 +206,42 @@ func (mock *userRepoMock) GetByIDCalls() []struct {
 	return calls
 }
 
+// GetByUsername calls GetByUsernameFunc.
+func (mock *userRepoMock) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	if mock.GetByUsernameFunc == nil {
+		panic("userRepoMock.GetByUsernameFunc: method is nil but userRepo.GetByUsername was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		Username string
+	}{
+		Ctx:      ctx,
+		Username: username,
+	}
+	mock.lockGetByUsername.Lock()
+	mock.calls.GetByUsername = append(mock.calls.GetByUsername, callInfo)
+	mock.lockGetByUsername.Unlock()
+	return mock.GetByUsernameFunc(ctx, username)
+}
+
+// GetByUsernameCalls gets all the calls that were made to GetByUsername.
+// Check the length with:
+//
+//	len(mockeduserRepo.GetByUsernameCalls())
+func (mock *userRepoMock) GetByUsernameCalls() []struct {
+	Ctx      context.Context
+	Username string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		Username string
+	}
+	mock.lockGetByUsername.RLock()
+	calls = mock.calls.GetByUsername
+	mock.lockGetByUsername.RUnlock()
+	return calls
+}
+
 // ListUsers calls ListUsersFunc.
 func (mock *userRepoMock) ListUsers(ctx context.Context, limit int, offset int) ([]domain.User, error) {
 	if mock.ListUsersFunc == nil {
@@ -216,10 +282,10 @@ func (mock *userRepoMock) ListUsersCalls() []struct {
 	return calls
 }
 
-// Update calls UpdateFunc.
-func (mock *userRepoMock) Update(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
-	if mock.UpdateFunc == nil {
-		panic("userRepoMock.UpdateFunc: method is nil but userRepo.Update was just called")
+// UpdateProfile calls UpdateProfileFunc.
+func (mock *userRepoMock) UpdateProfile(ctx context.Context, id uuid.UUID, name *string, avatarURL *string) (*domain.User, error) {
+	if mock.UpdateProfileFunc == nil {
+		panic("userRepoMock.UpdateProfileFunc: method is nil but userRepo.UpdateProfile was just called")
 	}
 	callInfo := struct {
 		Ctx       context.Context
@@ -232,17 +298,17 @@ func (mock *userRepoMock) Update(ctx context.Context, id uuid.UUID, name *string
 		Name:      name,
 		AvatarURL: avatarURL,
 	}
-	mock.lockUpdate.Lock()
-	mock.calls.Update = append(mock.calls.Update, callInfo)
-	mock.lockUpdate.Unlock()
-	return mock.UpdateFunc(ctx, id, name, avatarURL)
+	mock.lockUpdateProfile.Lock()
+	mock.calls.UpdateProfile = append(mock.calls.UpdateProfile, callInfo)
+	mock.lockUpdateProfile.Unlock()
+	return mock.UpdateProfileFunc(ctx, id, name, avatarURL)
 }
 
-// UpdateCalls gets all the calls that were made to Update.
+// UpdateProfileCalls gets all the calls that were made to UpdateProfile.
 // Check the length with:
 //
-//	len(mockeduserRepo.UpdateCalls())
-func (mock *userRepoMock) UpdateCalls() []struct {
+//	len(mockeduserRepo.UpdateProfileCalls())
+func (mock *userRepoMock) UpdateProfileCalls() []struct {
 	Ctx       context.Context
 	ID        uuid.UUID
 	Name      *string
@@ -254,9 +320,9 @@ func (mock *userRepoMock) UpdateCalls() []struct {
 		Name      *string
 		AvatarURL *string
 	}
-	mock.lockUpdate.RLock()
-	calls = mock.calls.Update
-	mock.lockUpdate.RUnlock()
+	mock.lockUpdateProfile.RLock()
+	calls = mock.calls.UpdateProfile
+	mock.lockUpdateProfile.RUnlock()
 	return calls
 }
 
@@ -299,3 +365,43 @@ func (mock *userRepoMock) UpdateRoleCalls() []struct {
 	mock.lockUpdateRole.RUnlock()
 	return calls
 }
+
+// UpdateUsername calls UpdateUsernameFunc.
+func (mock *userRepoMock) UpdateUsername(ctx context.Context, id uuid.UUID, username string) (*domain.User, error) {
+	if mock.UpdateUsernameFunc == nil {
+		panic("userRepoMock.UpdateUsernameFunc: method is nil but userRepo.UpdateUsername was just called")
+	}
+	callInfo := struct {
+		Ctx      context.Context
+		ID       uuid.UUID
+		Username string
+	}{
+		Ctx:      ctx,
+		ID:       id,
+		Username: username,
+	}
+	mock.lockUpdateUsername.Lock()
+	mock.calls.UpdateUsername = append(mock.calls.UpdateUsername, callInfo)
+	mock.lockUpdateUsername.Unlock()
+	return mock.UpdateUsernameFunc(ctx, id, username)
+}
+
+// UpdateUsernameCalls gets all the calls that were made to UpdateUsername.
+// Check the length with:
+//
+//	len(mockeduserRepo.UpdateUsernameCalls())
+func (mock *userRepoMock) UpdateUsernameCalls() []struct {
+	Ctx      context.Context
+	ID       uuid.UUID
+	Username string
+} {
+	var calls []struct {
+		Ctx      context.Context
+		ID       uuid.UUID
+		Username string
+	}
+	mock.lockUpdateUsername.RLock()
+	calls = mock.calls.UpdateUsername
+	mock.lockUpdateUsername.RUnlock()
+	return calls
+}
@@ -41,6 +41,9 @@ func NewErrorPresenter(log *slog.Logger) graphql.ErrorPresenterFunc {
 		case errors.Is(err, domain.ErrConflict):
 			gqlErr.Extensions = map[string]interface{}{"code": "CONFLICT"}
 
+		case errors.Is(err, domain.ErrTooManyAttempts):
+			gqlErr.Extensions = map[string]interface{}{"code": "TOO_MANY_ATTEMPTS"}
+
 		default:
 			// Unexpected error - log it, return generic message to client
 			requestID := ctxutil.RequestIDFromCtx(ctx)
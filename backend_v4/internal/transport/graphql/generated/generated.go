@@ -230,9 +230,15 @@ type ComplexityRoot struct {
 
 	DeletedEntriesList struct {
 		Entries    func(childComplexity int) int
+		Items      func(childComplexity int) int
 		TotalCount func(childComplexity int) int
 	}
 
+	DeletedEntry struct {
+		Entry   func(childComplexity int) int
+		PurgeAt func(childComplexity int) int
+	}
+
 	DictionaryConnection struct {
 		Edges      func(childComplexity int) int
 		PageInfo   func(childComplexity int) int
@@ -1258,6 +1264,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.DeletedEntriesList.Entries(childComplexity), true
+	case "DeletedEntriesList.items":
+		if e.complexity.DeletedEntriesList.Items == nil {
+			break
+		}
+
+		return e.complexity.DeletedEntriesList.Items(childComplexity), true
 	case "DeletedEntriesList.totalCount":
 		if e.complexity.DeletedEntriesList.TotalCount == nil {
 			break
@@ -1265,6 +1277,19 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.DeletedEntriesList.TotalCount(childComplexity), true
 
+	case "DeletedEntry.entry":
+		if e.complexity.DeletedEntry.Entry == nil {
+			break
+		}
+
+		return e.complexity.DeletedEntry.Entry(childComplexity), true
+	case "DeletedEntry.purgeAt":
+		if e.complexity.DeletedEntry.PurgeAt == nil {
+			break
+		}
+
+		return e.complexity.DeletedEntry.PurgeAt(childComplexity), true
+
 	case "DictionaryConnection.edges":
 		if e.complexity.DictionaryConnection.Edges == nil {
 			break
@@ -3577,6 +3602,10 @@ input DictionaryFilterInput {
 input CreateEntryFromCatalogInput {
   refEntryId: UUID!
   senseIds: [UUID!]!
+  """Reference pronunciations to link; omitted or empty links all of them."""
+  pronunciationIds: [UUID!]
+  """Reference images to attach; omitted or empty attaches all of them."""
+  imageIds: [UUID!]
   notes: String
   createCard: Boolean
 }
@@ -3754,6 +3783,8 @@ enum EntrySortField {
   TEXT
   CREATED_AT
   UPDATED_AT
+  DUE_DATE
+  REVIEW_COUNT
 }
 
 enum SortDirection {
@@ -3918,9 +3949,17 @@ type DictionaryEdge {
 """Простой список с общим количеством (для offset-пагинации)."""
 type DeletedEntriesList {
   entries: [DictionaryEntry!]!
+  """Те же записи вместе с датой окончательного удаления."""
+  items: [DeletedEntry!]!
   totalCount: Int!
 }
 
+"""Удалённая запись и момент, когда её окончательно удалит очистка."""
+type DeletedEntry {
+  entry: DictionaryEntry!
+  purgeAt: DateTime!
+}
+
 type InboxItemList {
   items: [InboxItem!]!
   totalCount: Int!
@@ -4032,6 +4071,8 @@ input ReviewCardInput {
   cardId: UUID!
   grade: ReviewGrade!
   durationMs: Int
+  """Client key of this submission; resubmitting with the same key does not review the card again."""
+  idempotencyKey: String
 }
 
 input GetCardHistoryInput {
@@ -7317,6 +7358,41 @@ func (ec *executionContext) fieldContext_DeletedEntriesList_entries(_ context.Co
 	return fc, nil
 }
 
+func (ec *executionContext) _DeletedEntriesList_items(ctx context.Context, field graphql.CollectedField, obj *DeletedEntriesList) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_DeletedEntriesList_items,
+		func(ctx context.Context) (any, error) {
+			return obj.Items, nil
+		},
+		nil,
+		ec.marshalNDeletedEntry2ᚕᚖgithubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋtransportᚋgraphqlᚋgeneratedᚐDeletedEntryᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_DeletedEntriesList_items(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DeletedEntriesList",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "entry":
+				return ec.fieldContext_DeletedEntry_entry(ctx, field)
+			case "purgeAt":
+				return ec.fieldContext_DeletedEntry_purgeAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DeletedEntry", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _DeletedEntriesList_totalCount(ctx context.Context, field graphql.CollectedField, obj *DeletedEntriesList) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -7346,6 +7422,92 @@ func (ec *executionContext) fieldContext_DeletedEntriesList_totalCount(_ context
 	return fc, nil
 }
 
+func (ec *executionContext) _DeletedEntry_entry(ctx context.Context, field graphql.CollectedField, obj *DeletedEntry) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_DeletedEntry_entry,
+		func(ctx context.Context) (any, error) {
+			return obj.Entry, nil
+		},
+		nil,
+		ec.marshalNDictionaryEntry2ᚖgithubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋdomainᚐEntry,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_DeletedEntry_entry(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DeletedEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_DictionaryEntry_id(ctx, field)
+			case "text":
+				return ec.fieldContext_DictionaryEntry_text(ctx, field)
+			case "textNormalized":
+				return ec.fieldContext_DictionaryEntry_textNormalized(ctx, field)
+			case "notes":
+				return ec.fieldContext_DictionaryEntry_notes(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_DictionaryEntry_createdAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_DictionaryEntry_updatedAt(ctx, field)
+			case "deletedAt":
+				return ec.fieldContext_DictionaryEntry_deletedAt(ctx, field)
+			case "senses":
+				return ec.fieldContext_DictionaryEntry_senses(ctx, field)
+			case "pronunciations":
+				return ec.fieldContext_DictionaryEntry_pronunciations(ctx, field)
+			case "catalogImages":
+				return ec.fieldContext_DictionaryEntry_catalogImages(ctx, field)
+			case "userImages":
+				return ec.fieldContext_DictionaryEntry_userImages(ctx, field)
+			case "card":
+				return ec.fieldContext_DictionaryEntry_card(ctx, field)
+			case "topics":
+				return ec.fieldContext_DictionaryEntry_topics(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DictionaryEntry", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _DeletedEntry_purgeAt(ctx context.Context, field graphql.CollectedField, obj *DeletedEntry) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_DeletedEntry_purgeAt,
+		func(ctx context.Context) (any, error) {
+			return obj.PurgeAt, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_DeletedEntry_purgeAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "DeletedEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _DictionaryConnection_edges(ctx context.Context, field graphql.CollectedField, obj *DictionaryConnection) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -11927,6 +12089,8 @@ func (ec *executionContext) fieldContext_Query_deletedEntries(ctx context.Contex
 			switch field.Name {
 			case "entries":
 				return ec.fieldContext_DeletedEntriesList_entries(ctx, field)
+			case "items":
+				return ec.fieldContext_DeletedEntriesList_items(ctx, field)
 			case "totalCount":
 				return ec.fieldContext_DeletedEntriesList_totalCount(ctx, field)
 			}
@@ -18033,7 +18197,7 @@ func (ec *executionContext) unmarshalInputCreateEntryFromCatalogInput(ctx contex
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"refEntryId", "senseIds", "notes", "createCard"}
+	fieldsInOrder := [...]string{"refEntryId", "senseIds", "pronunciationIds", "imageIds", "notes", "createCard"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -18054,6 +18218,20 @@ func (ec *executionContext) unmarshalInputCreateEntryFromCatalogInput(ctx contex
 				return it, err
 			}
 			it.SenseIds = data
+		case "pronunciationIds":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pronunciationIds"))
+			data, err := ec.unmarshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.PronunciationIds = data
+		case "imageIds":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("imageIds"))
+			data, err := ec.unmarshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ImageIds = data
 		case "notes":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("notes"))
 			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
@@ -18614,7 +18792,7 @@ func (ec *executionContext) unmarshalInputReviewCardInput(ctx context.Context, o
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"cardId", "grade", "durationMs"}
+	fieldsInOrder := [...]string{"cardId", "grade", "durationMs", "idempotencyKey"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -18642,6 +18820,13 @@ func (ec *executionContext) unmarshalInputReviewCardInput(ctx context.Context, o
 				return it, err
 			}
 			it.DurationMs = data
+		case "idempotencyKey":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("idempotencyKey"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.IdempotencyKey = data
 		}
 	}
 
@@ -20477,6 +20662,11 @@ func (ec *executionContext) _DeletedEntriesList(ctx context.Context, sel ast.Sel
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "items":
+			out.Values[i] = ec._DeletedEntriesList_items(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "totalCount":
 			out.Values[i] = ec._DeletedEntriesList_totalCount(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
@@ -20505,6 +20695,50 @@ func (ec *executionContext) _DeletedEntriesList(ctx context.Context, sel ast.Sel
 	return out
 }
 
+var deletedEntryImplementors = []string{"DeletedEntry"}
+
+func (ec *executionContext) _DeletedEntry(ctx context.Context, sel ast.SelectionSet, obj *DeletedEntry) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, deletedEntryImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DeletedEntry")
+		case "entry":
+			out.Values[i] = ec._DeletedEntry_entry(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "purgeAt":
+			out.Values[i] = ec._DeletedEntry_purgeAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var dictionaryConnectionImplementors = []string{"DictionaryConnection"}
 
 func (ec *executionContext) _DictionaryConnection(ctx context.Context, sel ast.SelectionSet, obj *DictionaryConnection) graphql.Marshaler {
@@ -25380,6 +25614,60 @@ func (ec *executionContext) marshalNDeletedEntriesList2ᚖgithubᚗcomᚋheartma
 	return ec._DeletedEntriesList(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNDeletedEntry2ᚕᚖgithubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋtransportᚋgraphqlᚋgeneratedᚐDeletedEntryᚄ(ctx context.Context, sel ast.SelectionSet, v []*DeletedEntry) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDeletedEntry2ᚖgithubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋtransportᚋgraphqlᚋgeneratedᚐDeletedEntry(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNDeletedEntry2ᚖgithubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋtransportᚋgraphqlᚋgeneratedᚐDeletedEntry(ctx context.Context, sel ast.SelectionSet, v *DeletedEntry) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._DeletedEntry(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNDictionaryConnection2githubᚗcomᚋheartmarshallᚋmyenglishᚑbackendᚋinternalᚋtransportᚋgraphqlᚋgeneratedᚐDictionaryConnection(ctx context.Context, sel ast.SelectionSet, v DictionaryConnection) graphql.Marshaler {
 	return ec._DictionaryConnection(ctx, sel, &v)
 }
@@ -27857,6 +28145,24 @@ func (ec *executionContext) marshalOStudySession2ᚖgithubᚗcomᚋheartmarshall
 	return ec._StudySession(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalOUUID2ᚕgithubᚗcomᚋgoogleᚋuuidᚐUUIDᚄ(ctx context.Context, v any) ([]uuid.UUID, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]uuid.UUID, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNUUID2githubᚗcomᚋgoogleᚋuuidᚐUUID(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
 func (ec *executionContext) unmarshalOUUID2ᚖgithubᚗcomᚋgoogleᚋuuidᚐUUID(ctx context.Context, v any) (*uuid.UUID, error) {
 	if v == nil {
 		return nil, nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -126,6 +127,10 @@ type CreateEntryCustomInput struct {
 type CreateEntryFromCatalogInput struct {
 	RefEntryID uuid.UUID   `json:"refEntryId"`
 	SenseIds   []uuid.UUID `json:"senseIds"`
+	// Reference pronunciations to link; omitted or empty links all of them.
+	PronunciationIds []uuid.UUID `json:"pronunciationIds,omitempty"`
+	// Reference images to attach; omitted or empty attaches all of them.
+	ImageIds   []uuid.UUID `json:"imageIds,omitempty"`
 	Notes      *string     `json:"notes,omitempty"`
 	CreateCard *bool       `json:"createCard,omitempty"`
 }
@@ -198,10 +203,18 @@ type DeleteUserImagePayload struct {
 
 // Простой список с общим количеством (для offset-пагинации).
 type DeletedEntriesList struct {
-	Entries    []*domain.Entry `json:"entries"`
+	Entries []*domain.Entry `json:"entries"`
+	// Те же записи вместе с датой окончательного удаления.
+	Items      []*DeletedEntry `json:"items"`
 	TotalCount int             `json:"totalCount"`
 }
 
+// Удалённая запись и момент, когда её окончательно удалит очистка.
+type DeletedEntry struct {
+	Entry   *domain.Entry `json:"entry"`
+	PurgeAt time.Time     `json:"purgeAt"`
+}
+
 type DictionaryConnection struct {
 	Edges      []*DictionaryEdge `json:"edges"`
 	PageInfo   *PageInfo         `json:"pageInfo"`
@@ -323,6 +336,8 @@ type ReviewCardInput struct {
 	CardID     uuid.UUID          `json:"cardId"`
 	Grade      domain.ReviewGrade `json:"grade"`
 	DurationMs *int               `json:"durationMs,omitempty"`
+	// Client key of this submission; resubmitting with the same key does not review the card again.
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
 }
 
 type ReviewCardPayload struct {
@@ -419,20 +434,24 @@ type UpdateTranslationPayload struct {
 type EntrySortField string
 
 const (
-	EntrySortFieldText      EntrySortField = "TEXT"
-	EntrySortFieldCreatedAt EntrySortField = "CREATED_AT"
-	EntrySortFieldUpdatedAt EntrySortField = "UPDATED_AT"
+	EntrySortFieldText        EntrySortField = "TEXT"
+	EntrySortFieldCreatedAt   EntrySortField = "CREATED_AT"
+	EntrySortFieldUpdatedAt   EntrySortField = "UPDATED_AT"
+	EntrySortFieldDueDate     EntrySortField = "DUE_DATE"
+	EntrySortFieldReviewCount EntrySortField = "REVIEW_COUNT"
 )
 
 var AllEntrySortField = []EntrySortField{
 	EntrySortFieldText,
 	EntrySortFieldCreatedAt,
 	EntrySortFieldUpdatedAt,
+	EntrySortFieldDueDate,
+	EntrySortFieldReviewCount,
 }
 
 func (e EntrySortField) IsValid() bool {
 	switch e {
-	case EntrySortFieldText, EntrySortFieldCreatedAt, EntrySortFieldUpdatedAt:
+	case EntrySortFieldText, EntrySortFieldCreatedAt, EntrySortFieldUpdatedAt, EntrySortFieldDueDate, EntrySortFieldReviewCount:
 		return true
 	}
 	return false
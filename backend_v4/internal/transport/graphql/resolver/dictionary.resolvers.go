@@ -123,10 +123,12 @@ func (r *mutationResolver) CreateEntryFromCatalog(ctx context.Context, input gen
 	}
 
 	serviceInput := dictionary.CreateFromCatalogInput{
-		RefEntryID: input.RefEntryID,
-		SenseIDs:   input.SenseIds,
-		CreateCard: createCard,
-		Notes:      input.Notes,
+		RefEntryID:       input.RefEntryID,
+		SenseIDs:         input.SenseIds,
+		PronunciationIDs: input.PronunciationIds,
+		ImageIDs:         input.ImageIds,
+		CreateCard:       createCard,
+		Notes:            input.Notes,
 	}
 
 	entry, err := r.dictionary.CreateEntryFromCatalog(ctx, serviceInput)
@@ -393,6 +395,10 @@ func (r *queryResolver) Dictionary(ctx context.Context, input generated.Dictiona
 			serviceInput.SortBy = "created_at"
 		case generated.EntrySortFieldUpdatedAt:
 			serviceInput.SortBy = "updated_at"
+		case generated.EntrySortFieldDueDate:
+			serviceInput.SortBy = "due_date"
+		case generated.EntrySortFieldReviewCount:
+			serviceInput.SortBy = "review_count"
 		}
 	}
 
@@ -489,14 +495,17 @@ func (r *queryResolver) DeletedEntries(ctx context.Context, limit *int, offset *
 		return nil, err
 	}
 
-	// Convert []domain.Entry to []*domain.Entry
+	// Convert []dictionary.DeletedEntry to []*domain.Entry and []*generated.DeletedEntry
 	result := make([]*domain.Entry, len(entries))
+	items := make([]*generated.DeletedEntry, len(entries))
 	for i := range entries {
-		result[i] = &entries[i]
+		result[i] = &entries[i].Entry
+		items[i] = &generated.DeletedEntry{Entry: &entries[i].Entry, PurgeAt: entries[i].PurgeAt}
 	}
 
 	return &generated.DeletedEntriesList{
 		Entries:    result,
+		Items:      items,
 		TotalCount: totalCount,
 	}, nil
 }
@@ -36,7 +36,7 @@ var _ dictionaryService = &dictionaryServiceMock{}
 //			ExportEntriesFunc: func(ctx context.Context) (*dictionary.ExportResult, error) {
 //				panic("mock out the ExportEntries method")
 //			},
-//			FindDeletedEntriesFunc: func(ctx context.Context, limit int, offset int) ([]domain.Entry, int, error) {
+//			FindDeletedEntriesFunc: func(ctx context.Context, limit int, offset int) ([]dictionary.DeletedEntry, int, error) {
 //				panic("mock out the FindDeletedEntries method")
 //			},
 //			FindEntriesFunc: func(ctx context.Context, input dictionary.FindInput) (*dictionary.FindResult, error) {
@@ -83,7 +83,7 @@ type dictionaryServiceMock struct {
 	ExportEntriesFunc func(ctx context.Context) (*dictionary.ExportResult, error)
 
 	// FindDeletedEntriesFunc mocks the FindDeletedEntries method.
-	FindDeletedEntriesFunc func(ctx context.Context, limit int, offset int) ([]domain.Entry, int, error)
+	FindDeletedEntriesFunc func(ctx context.Context, limit int, offset int) ([]dictionary.DeletedEntry, int, error)
 
 	// FindEntriesFunc mocks the FindEntries method.
 	FindEntriesFunc func(ctx context.Context, input dictionary.FindInput) (*dictionary.FindResult, error)
@@ -394,7 +394,7 @@ func (mock *dictionaryServiceMock) ExportEntriesCalls() []struct {
 }
 
 // FindDeletedEntries calls FindDeletedEntriesFunc.
-func (mock *dictionaryServiceMock) FindDeletedEntries(ctx context.Context, limit int, offset int) ([]domain.Entry, int, error) {
+func (mock *dictionaryServiceMock) FindDeletedEntries(ctx context.Context, limit int, offset int) ([]dictionary.DeletedEntry, int, error) {
 	if mock.FindDeletedEntriesFunc == nil {
 		panic("dictionaryServiceMock.FindDeletedEntriesFunc: method is nil but dictionaryService.FindDeletedEntries was just called")
 	}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/heartmarshall/myenglish-backend/internal/domain"
@@ -228,12 +229,13 @@ func TestDeletedEntries_Success(t *testing.T) {
 
 	userID := uuid.New()
 	ctx := ctxutil.WithUserID(context.Background(), userID)
+	purgeAt := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
 
 	mock := &dictionaryServiceMock{
-		FindDeletedEntriesFunc: func(ctx context.Context, limit, offset int) ([]domain.Entry, int, error) {
-			return []domain.Entry{
-				{ID: uuid.New(), Text: "deleted1"},
-				{ID: uuid.New(), Text: "deleted2"},
+		FindDeletedEntriesFunc: func(ctx context.Context, limit, offset int) ([]dictionary.DeletedEntry, int, error) {
+			return []dictionary.DeletedEntry{
+				{Entry: domain.Entry{ID: uuid.New(), Text: "deleted1"}, PurgeAt: purgeAt},
+				{Entry: domain.Entry{ID: uuid.New(), Text: "deleted2"}, PurgeAt: purgeAt.AddDate(0, 0, 1)},
 			}, 10, nil
 		},
 	}
@@ -244,6 +246,10 @@ func TestDeletedEntries_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, result.Entries, 2)
 	assert.Equal(t, 10, result.TotalCount)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, "deleted1", result.Items[0].Entry.Text)
+	assert.Equal(t, purgeAt, result.Items[0].PurgeAt)
+	assert.Equal(t, purgeAt.AddDate(0, 0, 1), result.Items[1].PurgeAt)
 }
 
 // TestDeletedEntries_DefaultValues tests default limit and offset.
@@ -254,10 +260,10 @@ func TestDeletedEntries_DefaultValues(t *testing.T) {
 	ctx := ctxutil.WithUserID(context.Background(), userID)
 
 	mock := &dictionaryServiceMock{
-		FindDeletedEntriesFunc: func(ctx context.Context, limit, offset int) ([]domain.Entry, int, error) {
+		FindDeletedEntriesFunc: func(ctx context.Context, limit, offset int) ([]dictionary.DeletedEntry, int, error) {
 			assert.Equal(t, 50, limit)  // default limit
 			assert.Equal(t, 0, offset)   // default offset
-			return []domain.Entry{}, 0, nil
+			return []dictionary.DeletedEntry{}, 0, nil
 		},
 	}
 
@@ -309,11 +315,15 @@ func TestCreateEntryFromCatalog_Success(t *testing.T) {
 	userID := uuid.New()
 	entryID := uuid.New()
 	refEntryID := uuid.New()
+	pronID := uuid.New()
+	imageID := uuid.New()
 	ctx := ctxutil.WithUserID(context.Background(), userID)
 
 	mock := &dictionaryServiceMock{
 		CreateEntryFromCatalogFunc: func(ctx context.Context, input dictionary.CreateFromCatalogInput) (*domain.Entry, error) {
 			assert.Equal(t, refEntryID, input.RefEntryID)
+			assert.Equal(t, []uuid.UUID{pronID}, input.PronunciationIDs)
+			assert.Equal(t, []uuid.UUID{imageID}, input.ImageIDs)
 			assert.True(t, input.CreateCard)
 			return &domain.Entry{ID: entryID, Text: "test"}, nil
 		},
@@ -321,9 +331,11 @@ func TestCreateEntryFromCatalog_Success(t *testing.T) {
 
 	resolver := &mutationResolver{&Resolver{dictionary: mock}}
 	input := generated.CreateEntryFromCatalogInput{
-		RefEntryID: refEntryID,
-		SenseIds:   []uuid.UUID{uuid.New()},
-		CreateCard: ptr(true),
+		RefEntryID:       refEntryID,
+		SenseIds:         []uuid.UUID{uuid.New()},
+		PronunciationIds: []uuid.UUID{pronID},
+		ImageIds:         []uuid.UUID{imageID},
+		CreateCard:       ptr(true),
 	}
 
 	result, err := resolver.CreateEntryFromCatalog(ctx, input)
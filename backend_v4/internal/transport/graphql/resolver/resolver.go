@@ -24,7 +24,7 @@ type dictionaryService interface {
 	GetEntry(ctx context.Context, entryID uuid.UUID) (*domain.Entry, error)
 	UpdateNotes(ctx context.Context, input dictionary.UpdateNotesInput) (*domain.Entry, error)
 	DeleteEntry(ctx context.Context, entryID uuid.UUID) error
-	FindDeletedEntries(ctx context.Context, limit, offset int) ([]domain.Entry, int, error)
+	FindDeletedEntries(ctx context.Context, limit, offset int) ([]dictionary.DeletedEntry, int, error)
 	RestoreEntry(ctx context.Context, entryID uuid.UUID) (*domain.Entry, error)
 	BatchDeleteEntries(ctx context.Context, entryIDs []uuid.UUID) (*dictionary.BatchResult, error)
 	ImportEntries(ctx context.Context, input dictionary.ImportInput) (*dictionary.ImportResult, error)
@@ -40,6 +40,9 @@ func (r *mutationResolver) ReviewCard(ctx context.Context, input generated.Revie
 		Grade:      input.Grade,
 		DurationMs: input.DurationMs,
 	}
+	if input.IdempotencyKey != nil {
+		serviceInput.IdempotencyKey = *input.IdempotencyKey
+	}
 
 	card, err := r.study.ReviewCard(ctx, serviceInput)
 	if err != nil {
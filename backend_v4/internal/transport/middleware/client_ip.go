@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+// ClientIP returns middleware that stores the client IP address in the
+// context. The port is stripped from r.RemoteAddr; if the address cannot be
+// split it is stored as-is.
+func ClientIP() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				ip = r.RemoteAddr
+			}
+			next.ServeHTTP(w, r.WithContext(ctxutil.WithClientIP(r.Context(), ip)))
+		})
+	}
+}
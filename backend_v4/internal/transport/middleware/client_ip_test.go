@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/heartmarshall/myenglish-backend/pkg/ctxutil"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		remoteAddr string
+		want       string
+	}{
+		{"192.0.2.1:54321", "192.0.2.1"},
+		{"[2001:db8::1]:443", "2001:db8::1"},
+		{"192.0.2.1", "192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remoteAddr, func(t *testing.T) {
+			var got string
+			handler := ClientIP()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = ctxutil.ClientIPFromCtx(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/login/password", nil)
+			req.RemoteAddr = tt.remoteAddr
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
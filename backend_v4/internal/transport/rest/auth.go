@@ -193,6 +193,8 @@ func (h *AuthHandler) handleError(w http.ResponseWriter, r *http.Request, err er
 		writeError(w, http.StatusUnauthorized, "unauthorized")
 	case errors.Is(err, domain.ErrAlreadyExists):
 		writeError(w, http.StatusConflict, "already exists")
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		writeError(w, http.StatusTooManyRequests, "too many attempts")
 	default:
 		h.log.ErrorContext(r.Context(), "internal error", slog.String("error", err.Error()))
 		writeError(w, http.StatusInternalServerError, "internal server error")
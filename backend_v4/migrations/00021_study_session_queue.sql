@@ -0,0 +1,7 @@
+-- +goose Up
+
+-- Snapshot of the remaining study queue (card IDs in order) for session resumption
+ALTER TABLE study_sessions ADD COLUMN queue_card_ids UUID[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE study_sessions DROP COLUMN IF EXISTS queue_card_ids;
@@ -0,0 +1,12 @@
+-- +goose Up
+
+-- Retry bookkeeping: failed items are rescheduled with exponential backoff
+-- until attempts reaches the configured cap.
+ALTER TABLE enrichment_queue
+    ADD COLUMN attempts      INT NOT NULL DEFAULT 0,
+    ADD COLUMN next_retry_at TIMESTAMPTZ;
+
+-- +goose Down
+ALTER TABLE enrichment_queue
+    DROP COLUMN IF EXISTS next_retry_at,
+    DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,13 @@
+-- +goose Up
+
+-- Files already imported by cmd/llm-import, keyed by content hash so that
+-- re-running over the same directory skips them.
+CREATE TABLE llm_import_log (
+    content_hash TEXT PRIMARY KEY,
+    file_name    TEXT NOT NULL,
+    word         TEXT NOT NULL,
+    imported_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS llm_import_log;
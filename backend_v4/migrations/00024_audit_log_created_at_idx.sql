@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- Per-user audit queries use ix_audit_log_user (user_id, created_at DESC);
+-- this one serves admin queries across all users and retention cleanup.
+CREATE INDEX ix_audit_log_created_at ON audit_log(created_at DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_audit_log_created_at;
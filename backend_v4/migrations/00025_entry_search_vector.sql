@@ -0,0 +1,126 @@
+-- +goose Up
+
+-- Full-text search over a user's own entries. The document spans entries,
+-- senses and translations, so it cannot be a GENERATED column; triggers keep
+-- it current instead. Weights: headword A, definitions and translations B,
+-- notes C.
+ALTER TABLE entries ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_entry_definitions(p_entry_id UUID)
+RETURNS TEXT AS $$
+    SELECT string_agg(COALESCE(s.definition, rs.definition), ' ' ORDER BY s.position)
+    FROM senses s
+    LEFT JOIN ref_senses rs ON rs.id = s.ref_sense_id
+    WHERE s.entry_id = p_entry_id;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_entry_translations(p_entry_id UUID)
+RETURNS TEXT AS $$
+    SELECT string_agg(COALESCE(t.text, rt.text), ' ' ORDER BY s.position, t.position)
+    FROM senses s
+    JOIN translations t ON t.sense_id = s.id
+    LEFT JOIN ref_translations rt ON rt.id = t.ref_translation_id
+    WHERE s.entry_id = p_entry_id;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_entry_search_vector(p_entry_id UUID, p_text TEXT, p_notes TEXT)
+RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_text, '')), 'A')
+        || setweight(to_tsvector('english', COALESCE(fn_entry_definitions(p_entry_id), '')), 'B')
+        || setweight(to_tsvector('english', COALESCE(fn_entry_translations(p_entry_id), '')), 'B')
+        || setweight(to_tsvector('english', COALESCE(p_notes, '')), 'C');
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_entries_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := fn_entry_search_vector(NEW.id, NEW.text, NEW.notes);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_entries_search_vector
+    BEFORE INSERT OR UPDATE OF text, notes ON entries
+    FOR EACH ROW
+    EXECUTE FUNCTION fn_entries_search_vector();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_refresh_entry_search_vector(p_entry_id UUID)
+RETURNS VOID AS $$
+    UPDATE entries
+    SET search_vector = fn_entry_search_vector(id, text, notes)
+    WHERE id = p_entry_id;
+$$ LANGUAGE sql;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_senses_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM fn_refresh_entry_search_vector(OLD.entry_id);
+    END IF;
+    IF TG_OP <> 'DELETE' AND (TG_OP = 'INSERT' OR NEW.entry_id <> OLD.entry_id) THEN
+        PERFORM fn_refresh_entry_search_vector(NEW.entry_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_senses_search_vector
+    AFTER INSERT OR UPDATE OF entry_id, definition, ref_sense_id, position OR DELETE ON senses
+    FOR EACH ROW
+    EXECUTE FUNCTION fn_senses_search_vector();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_translations_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        PERFORM fn_refresh_entry_search_vector((SELECT entry_id FROM senses WHERE id = OLD.sense_id));
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        PERFORM fn_refresh_entry_search_vector((SELECT entry_id FROM senses WHERE id = NEW.sense_id));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_translations_search_vector
+    AFTER INSERT OR UPDATE OF sense_id, text, ref_translation_id, position OR DELETE ON translations
+    FOR EACH ROW
+    EXECUTE FUNCTION fn_translations_search_vector();
+
+UPDATE entries SET search_vector = fn_entry_search_vector(id, text, notes);
+
+CREATE INDEX ix_entries_search_vector ON entries USING GIN (search_vector);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_entries_search_vector;
+
+DROP TRIGGER IF EXISTS trg_translations_search_vector ON translations;
+DROP FUNCTION IF EXISTS fn_translations_search_vector();
+
+DROP TRIGGER IF EXISTS trg_senses_search_vector ON senses;
+DROP FUNCTION IF EXISTS fn_senses_search_vector();
+
+DROP FUNCTION IF EXISTS fn_refresh_entry_search_vector(UUID);
+
+DROP TRIGGER IF EXISTS trg_entries_search_vector ON entries;
+DROP FUNCTION IF EXISTS fn_entries_search_vector();
+
+DROP FUNCTION IF EXISTS fn_entry_search_vector(UUID, TEXT, TEXT);
+DROP FUNCTION IF EXISTS fn_entry_translations(UUID);
+DROP FUNCTION IF EXISTS fn_entry_definitions(UUID);
+
+ALTER TABLE entries DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+  ADD COLUMN new_card_order TEXT NOT NULL DEFAULT 'added'
+  CONSTRAINT chk_user_settings_new_card_order CHECK (new_card_order IN ('added', 'random', 'frequency'));
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS new_card_order;
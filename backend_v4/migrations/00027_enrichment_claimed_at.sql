@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- When an item was last claimed for processing, so items left in processing
+-- by a crashed run can be reclaimed once they are old enough.
+ALTER TABLE enrichment_queue
+    ADD COLUMN claimed_at TIMESTAMPTZ;
+
+CREATE INDEX ix_enrichment_queue_claimed_at ON enrichment_queue(claimed_at)
+    WHERE status = 'processing';
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_enrichment_queue_claimed_at;
+ALTER TABLE enrichment_queue
+    DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+  ADD COLUMN hard_interval_factor FLOAT NOT NULL DEFAULT 1.0
+  CONSTRAINT chk_user_settings_hard_interval_factor CHECK (hard_interval_factor BETWEEN 0.5 AND 2.0);
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS hard_interval_factor;
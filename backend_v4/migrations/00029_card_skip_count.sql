@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE cards ADD COLUMN skip_count INT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE cards DROP COLUMN IF EXISTS skip_count;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+  ADD COLUMN daily_goal INT NOT NULL DEFAULT 0
+  CONSTRAINT chk_user_settings_daily_goal CHECK (daily_goal BETWEEN 0 AND 9999);
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS daily_goal;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Other sources whose near-identical senses the seeder merged into this one.
+-- Numbered to fill the gap before 00032: databases already past it apply
+-- this file with goose up -allow-missing.
+ALTER TABLE ref_senses ADD COLUMN sources TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE ref_senses DROP COLUMN IF EXISTS sources;
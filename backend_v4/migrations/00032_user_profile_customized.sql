@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN profile_customized BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS profile_customized;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE user_settings
+  ADD COLUMN due_card_order TEXT NOT NULL DEFAULT 'due_date'
+  CONSTRAINT chk_user_settings_due_card_order CHECK (due_card_order IN ('due_date', 'random', 'added'));
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS due_card_order;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE user_settings
+  ADD COLUMN interleave_new BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS interleave_new;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Per-user relearning steps in seconds; NULL uses the server default.
+ALTER TABLE user_settings
+  ADD COLUMN relearning_steps INT[]
+  CONSTRAINT chk_user_settings_relearning_steps CHECK (
+    relearning_steps IS NULL
+    OR (cardinality(relearning_steps) BETWEEN 1 AND 10 AND 0 < ALL (relearning_steps))
+  );
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS relearning_steps;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Per-user learning steps in seconds; NULL uses the server default.
+ALTER TABLE user_settings
+  ADD COLUMN learning_steps INT[]
+  CONSTRAINT chk_user_settings_learning_steps CHECK (
+    learning_steps IS NULL
+    OR (cardinality(learning_steps) BETWEEN 1 AND 10 AND 0 < ALL (learning_steps))
+  );
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN IF EXISTS learning_steps;
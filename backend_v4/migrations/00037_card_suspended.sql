@@ -0,0 +1,6 @@
+-- +goose Up
+-- Manually suspended cards stay out of study queues and due/new counts.
+ALTER TABLE cards ADD COLUMN suspended BOOLEAN NOT NULL DEFAULT false;
+
+-- +goose Down
+ALTER TABLE cards DROP COLUMN IF EXISTS suspended;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Optimistic-locking counter: SRS updates only apply to the version they were
+-- computed from and bump it, so concurrent reviews cannot overwrite each other.
+ALTER TABLE cards ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE cards DROP COLUMN IF EXISTS version;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Client-supplied key of a review submission; a retried submission with the
+-- same key for the same card is answered from the first one.
+ALTER TABLE review_logs ADD COLUMN idempotency_key TEXT;
+
+CREATE UNIQUE INDEX ux_review_logs_card_idempotency_key
+    ON review_logs (card_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ux_review_logs_card_idempotency_key;
+ALTER TABLE review_logs DROP COLUMN IF EXISTS idempotency_key;
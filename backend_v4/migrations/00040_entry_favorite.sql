@@ -0,0 +1,8 @@
+-- +goose Up
+-- Users can star important entries and list only the starred ones.
+ALTER TABLE entries ADD COLUMN favorite BOOLEAN NOT NULL DEFAULT false;
+CREATE INDEX ix_entries_user_favorite ON entries(user_id) WHERE favorite AND deleted_at IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_entries_user_favorite;
+ALTER TABLE entries DROP COLUMN IF EXISTS favorite;
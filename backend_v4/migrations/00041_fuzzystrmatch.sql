@@ -0,0 +1,6 @@
+-- +goose Up
+-- CheckSimilar filters typo candidates with levenshtein_less_equal in SQL.
+CREATE EXTENSION IF NOT EXISTS fuzzystrmatch;
+
+-- +goose Down
+DROP EXTENSION IF EXISTS fuzzystrmatch;
@@ -0,0 +1,65 @@
+-- +goose Up
+
+-- Entry search vectors fall back to catalog definitions and translations for
+-- senses and translations without their own text (see 00025). Refresh the
+-- entries that inherit a ref_senses.definition or ref_translations.text when
+-- it changes. Deletes need no trigger here: the 00007 preserve triggers copy
+-- the text into the user rows, which fires the 00025 triggers.
+--
+-- Statement-level with transition tables so a bulk catalog update refreshes
+-- each affected entry once. Transition tables rule out an UPDATE OF column
+-- list, so the bodies compare old and new text themselves.
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_ref_senses_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM fn_refresh_entry_search_vector(affected.entry_id)
+    FROM (
+        SELECT DISTINCT s.entry_id
+        FROM new_rows n
+        JOIN old_rows o ON o.id = n.id
+        JOIN senses s ON s.ref_sense_id = n.id AND s.definition IS NULL
+        WHERE n.definition IS DISTINCT FROM o.definition
+    ) affected;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_ref_senses_search_vector
+    AFTER UPDATE ON ref_senses
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION fn_ref_senses_search_vector();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION fn_ref_translations_search_vector()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM fn_refresh_entry_search_vector(affected.entry_id)
+    FROM (
+        SELECT DISTINCT s.entry_id
+        FROM new_rows n
+        JOIN old_rows o ON o.id = n.id
+        JOIN translations t ON t.ref_translation_id = n.id AND t.text IS NULL
+        JOIN senses s ON s.id = t.sense_id
+        WHERE n.text IS DISTINCT FROM o.text
+    ) affected;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_ref_translations_search_vector
+    AFTER UPDATE ON ref_translations
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION fn_ref_translations_search_vector();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_ref_translations_search_vector ON ref_translations;
+DROP FUNCTION IF EXISTS fn_ref_translations_search_vector();
+
+DROP TRIGGER IF EXISTS trg_ref_senses_search_vector ON ref_senses;
+DROP FUNCTION IF EXISTS fn_ref_senses_search_vector();
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- ClaimBatch orders pending items by (priority DESC, created_at) and
+-- ClaimBatchFIFO by created_at alone; ix_enrichment_queue_status orders by
+-- requested_at and stays for List.
+CREATE INDEX ix_enrichment_queue_claim ON enrichment_queue(status, priority DESC, created_at);
+CREATE INDEX ix_enrichment_queue_claim_fifo ON enrichment_queue(status, created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS ix_enrichment_queue_claim_fifo;
+DROP INDEX IF EXISTS ix_enrichment_queue_claim;
@@ -12,6 +12,7 @@ const (
 	userIDKey    ctxKey = "user_id"
 	userRoleKey  ctxKey = "user_role"
 	requestIDKey ctxKey = "request_id"
+	clientIPKey  ctxKey = "client_ip"
 )
 
 // WithUserID stores the user ID in the context.
@@ -57,3 +58,15 @@ func RequestIDFromCtx(ctx context.Context) string {
 	id, _ := ctx.Value(requestIDKey).(string)
 	return id
 }
+
+// WithClientIP stores the client IP address in the context.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey, ip)
+}
+
+// ClientIPFromCtx extracts the client IP address from the context.
+// Returns an empty string if absent.
+func ClientIPFromCtx(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey).(string)
+	return ip
+}
@@ -92,3 +92,16 @@ func TestRequestIDFromCtx_WrongType(t *testing.T) {
 		t.Fatalf("expected empty string, got %s", got)
 	}
 }
+
+func TestWithClientIP_And_ClientIPFromCtx(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithClientIP(context.Background(), "203.0.113.7")
+
+	if got := ClientIPFromCtx(ctx); got != "203.0.113.7" {
+		t.Fatalf("expected 203.0.113.7, got %s", got)
+	}
+	if got := ClientIPFromCtx(context.Background()); got != "" {
+		t.Fatalf("expected empty string, got %s", got)
+	}
+}
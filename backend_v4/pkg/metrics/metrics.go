@@ -0,0 +1,241 @@
+// Package metrics is a small, dependency-free metrics registry that renders
+// counters and histograms in the Prometheus text exposition format.
+//
+// All metric types are safe for concurrent use. Registering two metrics with
+// the same name panics, mirroring prometheus.MustRegister.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// collector is a registered metric family that can render itself.
+type collector interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+// Registry holds metric families in registration order.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+	names      map[string]bool
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[c.name()] {
+		panic(fmt.Sprintf("metrics: duplicate metric %q", c.name()))
+	}
+	r.names[c.name()] = true
+	r.collectors = append(r.collectors, c)
+}
+
+// NewCounter registers and returns a counter without labels.
+func (r *Registry) NewCounter(name, help string) *Counter {
+	c := &Counter{family: family{n: name, help: help}}
+	r.register(c)
+	return c
+}
+
+// NewCounterVec registers and returns a counter partitioned by one label.
+func (r *Registry) NewCounterVec(name, help, label string) *CounterVec {
+	c := &CounterVec{
+		family: family{n: name, help: help},
+		label:  label,
+		values: make(map[string]*Counter),
+	}
+	r.register(c)
+	return c
+}
+
+// NewHistogram registers and returns a histogram with the given upper bucket
+// bounds. Bounds are sorted; the +Inf bucket is implicit.
+func (r *Registry) NewHistogram(name, help string, buckets []float64) *Histogram {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	h := &Histogram{
+		family: family{n: name, help: help},
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+	r.register(h)
+	return h
+}
+
+// WriteText renders all registered metrics in Prometheus text format.
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler returns an http.Handler serving the registry in text format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = r.WriteText(w)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Metric types
+// ---------------------------------------------------------------------------
+
+type family struct {
+	n    string
+	help string
+}
+
+func (f family) name() string { return f.n }
+
+func (f family) writeHeader(w *bufio.Writer, typ string) {
+	if f.help != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", f.n, escapeHelp(f.help))
+	}
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.n, typ)
+}
+
+// Counter is a monotonically increasing value.
+type Counter struct {
+	family
+	v atomic.Uint64
+}
+
+// Inc adds one.
+func (c *Counter) Inc() { c.v.Add(1) }
+
+// Add adds n.
+func (c *Counter) Add(n uint64) { c.v.Add(n) }
+
+// Value returns the current count.
+func (c *Counter) Value() uint64 { return c.v.Load() }
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.writeHeader(w, "counter")
+	fmt.Fprintf(w, "%s %d\n", c.n, c.Value())
+}
+
+// CounterVec is a set of counters keyed by the value of a single label.
+type CounterVec struct {
+	family
+	label string
+
+	mu     sync.Mutex
+	values map[string]*Counter
+}
+
+// With returns the counter for the given label value, creating it on first use.
+func (v *CounterVec) With(labelValue string) *Counter {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	c, ok := v.values[labelValue]
+	if !ok {
+		c = &Counter{}
+		v.values[labelValue] = c
+	}
+	return c
+}
+
+func (v *CounterVec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	v.mu.Unlock()
+	sort.Strings(keys)
+
+	v.writeHeader(w, "counter")
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{%s=\"%s\"} %d\n", v.n, v.label, escapeLabel(k), v.With(k).Value())
+	}
+}
+
+// Histogram counts observations into cumulative buckets.
+type Histogram struct {
+	family
+	bounds []float64
+
+	mu     sync.Mutex
+	counts []uint64 // per-bucket (non-cumulative); last is +Inf
+	sum    float64
+	count  uint64
+}
+
+// Observe records one value.
+func (h *Histogram) Observe(v float64) {
+	i := sort.SearchFloat64s(h.bounds, v) // first bound >= v
+
+	h.mu.Lock()
+	h.counts[i]++
+	h.sum += v
+	h.count++
+	h.mu.Unlock()
+}
+
+// Count returns the number of observations.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.mu.Lock()
+	counts := append([]uint64(nil), h.counts...)
+	sum, count := h.sum, h.count
+	h.mu.Unlock()
+
+	h.writeHeader(w, "histogram")
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += counts[i]
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", h.n, formatFloat(bound), cumulative)
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", h.n, count)
+	fmt.Fprintf(w, "%s_sum %s\n", h.n, formatFloat(sum))
+	fmt.Fprintf(w, "%s_count %d\n", h.n, count)
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func formatFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
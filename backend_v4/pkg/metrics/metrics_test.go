@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	reg := NewRegistry()
+
+	started := reg.NewCounter("sessions_started_total", "Sessions started.")
+	started.Inc()
+	started.Add(2)
+
+	reviews := reg.NewCounterVec("reviews_total", "Reviews by grade.", "grade")
+	reviews.With("GOOD").Inc()
+	reviews.With("AGAIN").Add(2)
+	reviews.With(`we"ird`).Inc()
+
+	dur := reg.NewHistogram("review_duration_seconds", "Review duration.", []float64{5, 1, 10})
+	dur.Observe(0.5)
+	dur.Observe(1)
+	dur.Observe(7)
+	dur.Observe(60)
+
+	var b strings.Builder
+	if err := reg.WriteText(&b); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+
+	want := `# HELP sessions_started_total Sessions started.
+# TYPE sessions_started_total counter
+sessions_started_total 3
+# HELP reviews_total Reviews by grade.
+# TYPE reviews_total counter
+reviews_total{grade="AGAIN"} 2
+reviews_total{grade="GOOD"} 1
+reviews_total{grade="we\"ird"} 1
+# HELP review_duration_seconds Review duration.
+# TYPE review_duration_seconds histogram
+review_duration_seconds_bucket{le="1"} 2
+review_duration_seconds_bucket{le="5"} 2
+review_duration_seconds_bucket{le="10"} 3
+review_duration_seconds_bucket{le="+Inf"} 4
+review_duration_seconds_sum 68.5
+review_duration_seconds_count 4
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteText mismatch:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRegistry_DuplicateNamePanics(t *testing.T) {
+	reg := NewRegistry()
+	reg.NewCounter("x_total", "")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate metric name")
+		}
+	}()
+	reg.NewCounterVec("x_total", "", "label")
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	reg := NewRegistry()
+	reg.NewCounter("up_total", "").Inc()
+
+	rec := httptest.NewRecorder()
+	reg.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "up_total 1\n") {
+		t.Errorf("body missing counter:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,50 @@
+// Package tzutil resolves user timezones and computes day boundaries in them,
+// so every service agrees on when a user's day starts.
+package tzutil
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Location resolves an IANA timezone name. An unknown name falls back to UTC
+// and, when log is non-nil, logs a warning: settings are validated on write,
+// so a bad value here points at stale or hand-edited data.
+func Location(name string, log *slog.Logger) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if log != nil {
+			log.Warn("invalid timezone, falling back to UTC",
+				slog.String("timezone", name),
+				slog.String("error", err.Error()),
+			)
+		}
+		return time.UTC
+	}
+	return loc
+}
+
+// IsValid reports whether name is an IANA timezone accepted for user
+// settings. "Local" is rejected because it depends on the server.
+func IsValid(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// DayStart returns the start of the current day in tz, converted to UTC.
+func DayStart(now time.Time, tz *time.Location) time.Time {
+	userNow := now.In(tz)
+	dayStart := time.Date(userNow.Year(), userNow.Month(), userNow.Day(), 0, 0, 0, 0, tz)
+	return dayStart.UTC()
+}
+
+// NextDayStart returns the start of the next day in tz, converted to UTC.
+func NextDayStart(now time.Time, tz *time.Location) time.Time {
+	dayStart := DayStart(now, tz)
+	// AddDate handles DST correctly, Add(24h) does not
+	nextDay := dayStart.In(tz).AddDate(0, 0, 1)
+	return time.Date(nextDay.Year(), nextDay.Month(), nextDay.Day(), 0, 0, 0, 0, tz).UTC()
+}
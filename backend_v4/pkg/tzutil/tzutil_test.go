@@ -0,0 +1,176 @@
+package tzutil
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDayStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		now      time.Time
+		tz       string
+		wantHour int
+		wantDay  int
+	}{
+		{
+			name:     "UTC midnight",
+			now:      time.Date(2024, 2, 15, 12, 30, 0, 0, time.UTC),
+			tz:       "UTC",
+			wantHour: 0,
+			wantDay:  15,
+		},
+		{
+			name:     "America/New_York",
+			now:      time.Date(2024, 2, 15, 12, 30, 0, 0, time.UTC),
+			tz:       "America/New_York",
+			wantHour: 5, // EST is UTC-5, so midnight EST = 5:00 UTC
+			wantDay:  15,
+		},
+		{
+			name:     "Asia/Tokyo",
+			now:      time.Date(2024, 2, 15, 12, 30, 0, 0, time.UTC),
+			tz:       "Asia/Tokyo",
+			wantHour: 15, // JST is UTC+9, so midnight JST = 15:00 prev day UTC
+			wantDay:  14, // Should be previous day
+		},
+		{
+			name:     "New York on spring-forward day",
+			now:      time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC), // 14:00 EDT
+			tz:       "America/New_York",
+			wantHour: 5, // midnight was still EST, before the 02:00 switch
+			wantDay:  10,
+		},
+		{
+			name:     "New York day after spring-forward",
+			now:      time.Date(2024, 3, 11, 18, 0, 0, 0, time.UTC),
+			tz:       "America/New_York",
+			wantHour: 4, // EDT is UTC-4
+			wantDay:  11,
+		},
+		{
+			name:     "Berlin on fall-back day",
+			now:      time.Date(2024, 10, 27, 12, 0, 0, 0, time.UTC),
+			tz:       "Europe/Berlin",
+			wantHour: 22, // midnight CEST (UTC+2) = 22:00 prev day UTC
+			wantDay:  26,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := Location(tt.tz, nil)
+			result := DayStart(tt.now, loc)
+
+			if result.Hour() != tt.wantHour {
+				t.Errorf("DayStart() hour = %d, want %d", result.Hour(), tt.wantHour)
+			}
+			if tt.wantDay > 0 && result.Day() != tt.wantDay {
+				t.Errorf("DayStart() day = %d, want %d", result.Day(), tt.wantDay)
+			}
+			if result.Minute() != 0 || result.Second() != 0 {
+				t.Errorf("DayStart() should be at 00:00:00, got %02d:%02d:%02d",
+					result.Hour(), result.Minute(), result.Second())
+			}
+		})
+	}
+}
+
+func TestNextDayStart(t *testing.T) {
+	now := time.Date(2024, 2, 15, 12, 30, 0, 0, time.UTC)
+	loc := time.UTC
+
+	next := NextDayStart(now, loc)
+	day := DayStart(now, loc)
+
+	diff := next.Sub(day)
+	if diff != 24*time.Hour {
+		t.Errorf("NextDayStart should be 24h after DayStart, got %v", diff)
+	}
+}
+
+func TestNextDayStart_DST(t *testing.T) {
+	loc := Location("America/New_York", nil)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Duration
+	}{
+		{"spring forward is 23h", time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC), 23 * time.Hour},
+		{"fall back is 25h", time.Date(2024, 11, 3, 18, 0, 0, 0, time.UTC), 25 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextDayStart(tt.now, loc).Sub(DayStart(tt.now, loc)); got != tt.want {
+				t.Errorf("day length = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		name  string
+		tz    string
+		valid bool
+	}{
+		{"valid UTC", "UTC", true},
+		{"valid New York", "America/New_York", true},
+		{"valid Tokyo", "Asia/Tokyo", true},
+		{"invalid", "Invalid/Timezone", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := Location(tt.tz, nil)
+			if tt.valid && loc == time.UTC && tt.tz != "UTC" {
+				t.Error("Expected non-UTC location for valid timezone")
+			}
+			if !tt.valid && loc != time.UTC {
+				t.Error("Expected UTC fallback for invalid timezone")
+			}
+		})
+	}
+}
+
+func TestLocation_LogsFallback(t *testing.T) {
+	var buf bytes.Buffer
+	log := slog.New(slog.NewTextHandler(&buf, nil))
+
+	Location("Europe/Moscow", log)
+	if buf.Len() != 0 {
+		t.Errorf("valid timezone should not log, got %q", buf.String())
+	}
+
+	if loc := Location("Mars/Olympus", log); loc != time.UTC {
+		t.Errorf("got %v, want UTC", loc)
+	}
+	if !strings.Contains(buf.String(), "Mars/Olympus") {
+		t.Errorf("fallback warning should name the timezone, got %q", buf.String())
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		tz   string
+		want bool
+	}{
+		{"UTC", true},
+		{"Europe/London", true},
+		{"", false},
+		{"Local", false},
+		{"Not/AZone", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValid(tt.tz); got != tt.want {
+			t.Errorf("IsValid(%q) = %v, want %v", tt.tz, got, tt.want)
+		}
+	}
+}